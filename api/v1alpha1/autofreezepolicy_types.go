@@ -0,0 +1,138 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// LabelAutoFreezePolicy is set on every DeploymentFreezer created by an AutoFreezePolicy; value: the policy name.
+const LabelAutoFreezePolicy = "apps.boolfixer.dev/auto-freeze-policy"
+
+// CrashLoopTrigger trips on Pods that keep crashing.
+type CrashLoopTrigger struct {
+	// Restarts a container in CrashLoopBackOff must have reached before it counts.
+	// +optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	MinRestarts int32 `json:"minRestarts,omitempty"`
+}
+
+// MetricTrigger trips on a Prometheus query, such as an error-budget burn rate.
+type MetricTrigger struct {
+	// PromQL query evaluated for each selected Deployment. "$namespace" and "$deployment" are
+	// replaced by the namespace and name of the Deployment. The highest sample of the result
+	// is compared with the threshold; an empty result never trips.
+	// +kubebuilder:validation:MinLength=1
+	Query string `json:"query"`
+
+	// The trigger trips while the query result is above this value.
+	Threshold resource.Quantity `json:"threshold"`
+}
+
+type AutoFreezePolicySpec struct {
+	// Deployments of the policy's namespace to watch.
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// Trip on Pods in CrashLoopBackOff.
+	// +optional
+	CrashLoop *CrashLoopTrigger `json:"crashLoop,omitempty"`
+
+	// Trip on a Prometheus query. Needs the controller's --prometheus-url.
+	// +optional
+	Metric *MetricTrigger `json:"metric,omitempty"`
+
+	// How long a trigger must keep tripping before the Deployment is frozen.
+	// +optional
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=0
+	ForSeconds int64 `json:"forSeconds,omitempty"`
+
+	// Duration of each freeze, capped by the controller's --max-duration and FreezerPolicies.
+	// +kubebuilder:validation:Minimum=1
+	DurationSeconds int64 `json:"durationSeconds"`
+
+	// Minimum time between two freezes of the same Deployment, counted from the start of the
+	// previous one, so a Deployment that is still broken after its freeze is not frozen again
+	// right away.
+	// +optional
+	// +kubebuilder:default=3600
+	// +kubebuilder:validation:Minimum=0
+	CooldownSeconds int64 `json:"cooldownSeconds,omitempty"`
+}
+
+// AutoFreezeTarget is the state of one selected Deployment.
+type AutoFreezeTarget struct {
+	// Name of the Deployment.
+	Deployment string `json:"deployment"`
+
+	// Since when a trigger trips without interruption; unset while the Deployment is healthy.
+	// +optional
+	UnhealthySince *metav1.Time `json:"unhealthySince,omitempty"`
+
+	// Why a trigger trips, or tripped for the last freeze.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Name of the last DeploymentFreezer created for the Deployment.
+	// +optional
+	Freezer string `json:"freezer,omitempty"`
+
+	// When the last DeploymentFreezer was created.
+	// +optional
+	FrozenAt *metav1.Time `json:"frozenAt,omitempty"`
+}
+
+type AutoFreezePolicyStatus struct {
+	// Selected Deployments that are unhealthy or were frozen by this policy.
+	// +optional
+	// +listType=map
+	// +listMapKey=deployment
+	Targets []AutoFreezeTarget `json:"targets,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=afp
+// +kubebuilder:printcolumn:name="Duration",type=integer,JSONPath=`.spec.durationSeconds`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// AutoFreezePolicy circuit-breaks unhealthy Deployments: once a selected Deployment has been
+// crash looping or burning its error budget for a while, it creates a DeploymentFreezer for it.
+// Deleting the policy unfreezes the Deployments it froze.
+type AutoFreezePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AutoFreezePolicySpec   `json:"spec,omitempty"`
+	Status AutoFreezePolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+type AutoFreezePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AutoFreezePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AutoFreezePolicy{}, &AutoFreezePolicyList{})
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// DefaultClusterFreezeReportName is the report the controller creates and keeps up to date.
+const DefaultClusterFreezeReportName = "cluster"
+
+type ClusterFreezeReportSpec struct {
+	// Unfreezes due within this many seconds are listed as upcoming.
+	// +optional
+	// +kubebuilder:default=3600
+	// +kubebuilder:validation:Minimum=1
+	UpcomingWindowSeconds int64 `json:"upcomingWindowSeconds,omitempty"`
+
+	// Aborted DeploymentFreezers are listed for this many seconds after they were aborted.
+	// +optional
+	// +kubebuilder:default=86400
+	// +kubebuilder:validation:Minimum=1
+	RecentWindowSeconds int64 `json:"recentWindowSeconds,omitempty"`
+}
+
+// FreezeSummary is a one-line view of a DeploymentFreezer.
+type FreezeSummary struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	// Name of the target Deployment.
+	Target string `json:"target"`
+
+	Phase Phase `json:"phase"`
+
+	// When the DeploymentFreezer entered its current phase.
+	// +optional
+	Since *metav1.Time `json:"since,omitempty"`
+
+	// When the Deployment is due to be unfrozen.
+	// +optional
+	FreezeUntil *metav1.Time `json:"freezeUntil,omitempty"`
+
+	// Pods and requests the freeze removed, once Frozen was reached.
+	// +optional
+	ResourcesFreed *ResourcesFreed `json:"resourcesFreed,omitempty"`
+}
+
+type ClusterFreezeReportStatus struct {
+	// When the content of the report last changed.
+	// +optional
+	LastUpdated *metav1.Time `json:"lastUpdated,omitempty"`
+
+	// Number of DeploymentFreezers per phase.
+	// +optional
+	Phases map[Phase]int32 `json:"phases,omitempty"`
+
+	// DeploymentFreezers currently holding a Deployment (Freezing, Frozen or Unfreezing).
+	// +optional
+	Active []FreezeSummary `json:"active,omitempty"`
+
+	// Frozen DeploymentFreezers due to unfreeze within spec.upcomingWindowSeconds, soonest first.
+	// +optional
+	UpcomingUnfreezes []FreezeSummary `json:"upcomingUnfreezes,omitempty"`
+
+	// DeploymentFreezers aborted within spec.recentWindowSeconds, most recent first.
+	// +optional
+	RecentAborts []FreezeSummary `json:"recentAborts,omitempty"`
+
+	// Set when a list was cut to its maximum length.
+	// +optional
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=cfr
+// +kubebuilder:printcolumn:name="Frozen",type=integer,JSONPath=`.status.phases.Frozen`
+// +kubebuilder:printcolumn:name="Updated",type=date,JSONPath=`.status.lastUpdated`
+
+// ClusterFreezeReport summarizes every DeploymentFreezer in the cluster: active freezes,
+// upcoming unfreezes and recent aborts. It is maintained by the controller.
+type ClusterFreezeReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterFreezeReportSpec   `json:"spec,omitempty"`
+	Status ClusterFreezeReportStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+type ClusterFreezeReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterFreezeReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterFreezeReport{}, &ClusterFreezeReportList{})
+}
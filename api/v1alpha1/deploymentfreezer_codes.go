@@ -0,0 +1,91 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// ConditionCode classifies what went wrong behind a condition. Reasons are free-form and may be
+// refined or gain detail between releases; codes are a closed set that automation can switch on.
+type ConditionCode string
+
+const (
+	// The target workload does not exist.
+	ConditionCodeTargetNotFound ConditionCode = "TargetNotFound"
+	// The target was deleted and recreated under the same name.
+	ConditionCodeTargetReplaced ConditionCode = "TargetReplaced"
+	// spec.targetRef.selector matches more than one workload.
+	ConditionCodeTargetAmbiguous ConditionCode = "TargetAmbiguous"
+	// The target exists but the controller does not watch or cannot freeze it.
+	ConditionCodeTargetUnsupported ConditionCode = "TargetUnsupported"
+	// Another owner holds the target, or took it over, possibly by preemption.
+	ConditionCodeOwnershipConflict ConditionCode = "OwnershipConflict"
+	// A FreezerPolicy or a protected namespace refuses the freeze.
+	ConditionCodePolicyDenied ConditionCode = "PolicyDenied"
+	// The spec is rejected, e.g. an unfreeze window that does not parse.
+	ConditionCodeInvalidSpec ConditionCode = "InvalidSpec"
+	// The API server refused to scale the target down.
+	ConditionCodeScaleDownBlocked ConditionCode = "ScaleDownBlocked"
+	// The target could not be scaled back up to its recorded replicas.
+	ConditionCodeRestoreFailed ConditionCode = "RestoreFailed"
+	// Someone changed the frozen target behind the controller's back.
+	ConditionCodeTargetDrifted ConditionCode = "TargetDrifted"
+	// The target's Pods are failing after the unfreeze.
+	ConditionCodeWorkloadUnhealthy ConditionCode = "WorkloadUnhealthy"
+	// Writes conflicted or failed; the controller retries.
+	ConditionCodeAPIError ConditionCode = "APIError"
+	// The controller lacks the RBAC for the operation.
+	ConditionCodeForbidden ConditionCode = "Forbidden"
+	// The kill switch stopped the controller from acting.
+	ConditionCodeKillSwitch ConditionCode = "KillSwitch"
+	// Traffic could not be routed to the maintenance page or back to the target.
+	ConditionCodeTrafficUnroutable ConditionCode = "TrafficUnroutable"
+	// A managed cluster did not apply its copy of the DeploymentFreezer.
+	ConditionCodePropagationFailed ConditionCode = "PropagationFailed"
+)
+
+var reasonCodes = map[ConditionReason]ConditionCode{
+	ConditionReasonNotFound:            ConditionCodeTargetNotFound,
+	ConditionReasonUIDMismatch:         ConditionCodeTargetReplaced,
+	ConditionReasonAmbiguousTarget:     ConditionCodeTargetAmbiguous,
+	ConditionReasonNotSelected:         ConditionCodeTargetUnsupported,
+	ConditionReasonUnsupportedTarget:   ConditionCodeTargetUnsupported,
+	ConditionReasonDeniedAlreadyFrozen: ConditionCodeOwnershipConflict,
+	ConditionReasonLost:                ConditionCodeOwnershipConflict,
+	ConditionReasonAcquireTimeout:      ConditionCodeOwnershipConflict,
+	ConditionReasonPreempted:           ConditionCodeOwnershipConflict,
+	ConditionReasonPolicyDenied:        ConditionCodePolicyDenied,
+	ConditionReasonProtectedNamespace:  ConditionCodePolicyDenied,
+	ConditionReasonInvalidWindow:       ConditionCodeInvalidSpec,
+	ConditionReasonPlanRejected:        ConditionCodeInvalidSpec,
+	ConditionReasonAwaitingPDB:         ConditionCodeScaleDownBlocked,
+	ConditionReasonScaleDownFailed:     ConditionCodeScaleDownBlocked,
+	ConditionReasonQuotaExceeded:       ConditionCodeRestoreFailed,
+	ConditionReasonPartialRestore:      ConditionCodeRestoreFailed,
+	ConditionReasonAnnotationDrift:     ConditionCodeTargetDrifted,
+	ConditionReasonReplicasDrift:       ConditionCodeTargetDrifted,
+	ConditionReasonCrashLooping:        ConditionCodeWorkloadUnhealthy,
+	ConditionReasonUnavailable:         ConditionCodeWorkloadUnhealthy,
+	ConditionReasonDegraded:            ConditionCodeAPIError,
+	ConditionReasonAPIConflict:         ConditionCodeAPIError,
+	ConditionReasonRBACDenied:          ConditionCodeForbidden,
+	ConditionReasonKillSwitch:          ConditionCodeKillSwitch,
+	ConditionReasonNoRoute:             ConditionCodeTrafficUnroutable,
+	ConditionReasonNotApplied:          ConditionCodePropagationFailed,
+}
+
+// Code returns the code of a failure reason, or "" for reasons that report progress or success.
+func (r ConditionReason) Code() ConditionCode {
+	return reasonCodes[r]
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RequestedDuration returns the freeze window set by spec.duration or spec.durationSeconds,
+// in whole seconds, or 0 if neither is set.
+func (s *DeploymentFreezerSpec) RequestedDuration() time.Duration {
+	if s.Duration != nil {
+		return wholeSeconds(s.Duration)
+	}
+	return time.Duration(s.DurationSeconds) * time.Second
+}
+
+// SetRequestedDuration sets the freeze window in the fields the spec already uses, so a
+// DeploymentFreezer written with spec.duration keeps it; spec.durationSeconds otherwise.
+// When both are set they are both updated and stay consistent.
+func (s *DeploymentFreezerSpec) SetRequestedDuration(d time.Duration) {
+	d = d.Truncate(time.Second)
+	if s.Duration != nil {
+		s.Duration = &metav1.Duration{Duration: d}
+		if s.DurationSeconds == 0 {
+			return
+		}
+	}
+	s.DurationSeconds = int64(d / time.Second)
+}
+
+// SyncDurations fills whichever of spec.duration and spec.durationSeconds is unset from the
+// other, so clients reading either field see the same window. On an update, old is the stored
+// spec: a field changed on its own carries the other one along, so clients that only know
+// durationSeconds keep working against objects that also have duration; a field removed on its
+// own is refilled from the other. Two set fields that disagree are left alone for validation
+// to reject.
+func (s *DeploymentFreezerSpec) SyncDurations(old *DeploymentFreezerSpec) {
+	if old != nil {
+		durationChanged := wholeSeconds(s.Duration) != wholeSeconds(old.Duration)
+		secondsChanged := s.DurationSeconds != old.DurationSeconds
+		switch {
+		case secondsChanged && !durationChanged && s.DurationSeconds > 0:
+			s.Duration = nil
+		case durationChanged && !secondsChanged && s.Duration != nil:
+			s.DurationSeconds = 0
+		}
+	}
+	switch {
+	case s.Duration == nil && s.DurationSeconds > 0:
+		s.Duration = &metav1.Duration{Duration: time.Duration(s.DurationSeconds) * time.Second}
+	case s.Duration != nil && s.DurationSeconds == 0:
+		s.DurationSeconds = int64(s.RequestedDuration() / time.Second)
+	}
+}
+
+// wholeSeconds returns d truncated to whole seconds, or 0 if d is nil.
+func wholeSeconds(d *metav1.Duration) time.Duration {
+	if d == nil {
+		return 0
+	}
+	return d.Truncate(time.Second)
+}
@@ -0,0 +1,27 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// TargetName returns the name of the target workload: spec.targetRef.name, or the workload
+// spec.targetRef.selector resolved to, as recorded in status.targetRef.name. It is "" while a
+// selector has not been resolved yet.
+func (dfz *DeploymentFreezer) TargetName() string {
+	if dfz.Spec.TargetRef.Name != "" || dfz.Spec.TargetRef.Selector == nil {
+		return dfz.Spec.TargetRef.Name
+	}
+	return dfz.Status.TargetRef.Name
+}
@@ -17,25 +17,277 @@ limitations under the License.
 package v1alpha1
 
 import (
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// AnnoWakeRequested is set on a DeploymentFreezer by the activator when a request for one of its
+// spec.wakeOnRequest hosts arrives while it is Frozen; value: the RFC3339 time of the request.
+const AnnoWakeRequested = "apps.boolfixer.dev/wake-requested"
+
+// AnnoSkipRestore set to "true" on a DeploymentFreezer makes its deletion leave the target as it
+// is and only release ownership, for workloads that are being decommissioned anyway.
+const AnnoSkipRestore = "apps.boolfixer.dev/skip-restore"
+
+// TargetKind is the kind of workload a DeploymentFreezer freezes.
+// +kubebuilder:validation:Enum=Deployment;ReplicaSet;ReplicationController;DaemonSet;AdvancedStatefulSet
+type TargetKind string
+
+const (
+	TargetKindDeployment TargetKind = "Deployment"
+	// TargetKindReplicaSet is a standalone ReplicaSet; one owned by a Deployment is refused.
+	TargetKindReplicaSet TargetKind = "ReplicaSet"
+	// TargetKindReplicationController is a legacy ReplicationController.
+	TargetKindReplicationController TargetKind = "ReplicationController"
+	// TargetKindDaemonSet is a DaemonSet, frozen with a node selector no node matches.
+	TargetKindDaemonSet TargetKind = "DaemonSet"
+	// TargetKindAdvancedStatefulSet is an OpenKruise Advanced StatefulSet (apps.kruise.io/v1beta1).
+	TargetKindAdvancedStatefulSet TargetKind = "AdvancedStatefulSet"
+)
+
+// +kubebuilder:validation:XValidation:rule="has(self.name) != has(self.selector)",message="exactly one of name and selector must be set"
 type DeploymentTargetRef struct {
-	// Name of the target Deployment (same namespace as this CR).
+	// Kind of the target workload. ReplicaSets and ReplicationControllers go through the same
+	// snapshot, scale and restore steps, but have no rollouts, so pauseRollout, gitopsMode, the
+	// Canary unfreeze strategy, maintenancePage, standby and postUnfreezeObservationSeconds need a
+	// Deployment. DaemonSets have no replica count: their pod template gets a node selector that
+	// no node matches, which is removed again on unfreeze. AdvancedStatefulSet is the OpenKruise
+	// StatefulSet, which scales like a ReplicaSet. Defaults to Deployment.
+	// +optional
+	// +kubebuilder:default=Deployment
+	Kind TargetKind `json:"kind,omitempty"`
+
+	// Name of the target workload (same namespace as this CR).
+	// +optional
 	// +kubebuilder:validation:MinLength=1
-	Name string `json:"name"`
+	Name string `json:"name,omitempty"`
+
+	// Selector for the target workload, instead of name, for callers that know its labels but not
+	// its generated name. It must match exactly one workload of the kind in the CR's namespace, or
+	// the CR is Denied. The match is resolved once and kept in status.targetRef.name.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="has(self.propagation) == has(oldSelf.propagation)",message="propagation cannot be added or removed"
+// +kubebuilder:validation:XValidation:rule="!has(self.duration) || !has(self.durationSeconds) || duration(self.duration).getSeconds() == self.durationSeconds",message="duration and durationSeconds must describe the same window"
+// +kubebuilder:validation:XValidation:rule="!has(self.targetRef.kind) || self.targetRef.kind == 'Deployment' || !((has(self.pauseRollout) && self.pauseRollout) || (has(self.gitopsMode) && self.gitopsMode) || (has(self.unfreezeStrategy) && has(self.unfreezeStrategy.type) && self.unfreezeStrategy.type == 'Canary') || has(self.maintenancePage) || has(self.standby) || (has(self.postUnfreezeObservationSeconds) && self.postUnfreezeObservationSeconds > 0))",message="pauseRollout, gitopsMode, the Canary unfreeze strategy, maintenancePage, standby and postUnfreezeObservationSeconds need a Deployment target"
 type DeploymentFreezerSpec struct {
-	// Target Deployment reference.
+	// Target workload reference.
 	TargetRef DeploymentTargetRef `json:"targetRef"`
 
 	// Duration of the freeze window in seconds. After this period, the operator restores the Deployment.
+	// Defaults to the controller's --default-duration and is capped by its --max-duration.
+	// Superseded by duration: the admission webhook keeps the two consistent, and the field is
+	// planned to be dropped in the next API version, with conversion filling duration from it.
+	// +optional
 	// +kubebuilder:validation:Minimum=1
-	DurationSeconds int64 `json:"durationSeconds"`
+	DurationSeconds int64 `json:"durationSeconds,omitempty"`
+
+	// Duration of the freeze window as a duration string such as "90m" or "2h30m", counted in
+	// whole seconds. Takes precedence over durationSeconds; when both are set they must match.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1s')",message="duration must be at least 1s"
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Name of a freeze group. The DeploymentFreezers of a namespace sharing a group unfreeze
+	// together, at the latest of their deadlines, so an application stack is never partly thawed.
+	// +optional
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	FreezeGroup string `json:"freezeGroup,omitempty"`
+
+	// Short free-text note for operators, such as the change ticket behind the freeze
+	// ("CHG-12345"). Shown by `kubectl get df` and `kubectl freeze list`; the controller ignores it.
+	// +optional
+	// +kubebuilder:validation:MaxLength=256
+	Notes string `json:"notes,omitempty"`
+
+	// Controller-wide protections this freeze is exempted from, for emergency procedures. Each
+	// must be granted by a FreezerPolicy rule matching the creator; grants are recorded in
+	// status.exemptions.
+	// +optional
+	// +listType=set
+	Exemptions []Exemption `json:"exemptions,omitempty"`
+
+	// Also pause the Deployment's rollouts (spec.paused=true) while frozen, so queued
+	// rollouts are not deployed the moment replicas are restored. The original value is restored on unfreeze.
+	// +optional
+	PauseRollout bool `json:"pauseRollout,omitempty"`
+
+	// Plan mode: all Deployment patches are sent with server-side dry-run and the
+	// outcome is reported in status.plannedChanges. Nothing is mutated.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="dryRun is immutable"
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// How replicas are restored when the freeze window ends. Defaults to Immediate.
+	// +optional
+	UnfreezeStrategy *UnfreezeStrategy `json:"unfreezeStrategy,omitempty"`
+
+	// GitOps mode: on unfreeze the Deployment is not patched. The controller asks the GitOps
+	// pipeline to restore it (GitOpsSync condition and event) and completes once the Deployment
+	// is back at the snapshotted replicas.
+	// +optional
+	GitOpsMode bool `json:"gitopsMode,omitempty"`
+
+	// Seconds to keep observing the Deployment after replicas are restored. The outcome is
+	// reported in the PostUnfreezeHealthy condition before the CR becomes Completed. 0 disables it.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	PostUnfreezeObservationSeconds int64 `json:"postUnfreezeObservationSeconds,omitempty"`
+
+	// Seconds to wait for the target Deployment while another owner holds it, counted from when
+	// the CR became Pending. When it elapses the CR is Denied. 0 waits forever.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	AcquireTimeoutSeconds int64 `json:"acquireTimeoutSeconds,omitempty"`
+
+	// Priority over other DeploymentFreezers of the same target. One that finds the target held
+	// by a Freezing or Frozen DeploymentFreezer of lower priority preempts it instead of waiting:
+	// the holder is Aborted and hands over its recorded replicas and autoscaling snapshot, so the
+	// target stays at zero throughout. Holders that divert traffic or are paused are waited for.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Priority int32 `json:"priority,omitempty"`
+
+	// Days and hours in which the freeze may end. A freeze that expires outside the window
+	// stays Frozen until the window next opens. Unset lets the freeze end at any time.
+	// +optional
+	UnfreezeWindow *UnfreezeWindow `json:"unfreezeWindow,omitempty"`
+
+	// Route Ingress traffic to a maintenance page while the Deployment is scaled down, instead of
+	// letting users hit 503s. The original backends are restored once the Deployment is back.
+	// +optional
+	MaintenancePage *MaintenancePage `json:"maintenancePage,omitempty"`
+
+	// Swap a Service's selector to a standby Deployment while the Deployment is scaled down,
+	// and back once it is restored. The original selector is recorded in status.standby.
+	// +optional
+	Standby *Standby `json:"standby,omitempty"`
+
+	// End the freeze early when the activator receives a request for one of these hosts. Route
+	// them to the activator while frozen, e.g. with spec.maintenancePage.
+	// +optional
+	WakeOnRequest *WakeOnRequest `json:"wakeOnRequest,omitempty"`
+
+	// Freeze the target on managed clusters instead of this one. The controller copies this
+	// DeploymentFreezer, without propagation, to each cluster and reports their phases in
+	// status.clusters. Cannot be added or removed once set.
+	// +optional
+	Propagation *Propagation `json:"propagation,omitempty"`
+
+	// Which events are recorded for this DeploymentFreezer: All (default), Warnings, or None.
+	// The controller's --events flag can make it quieter still, never louder. Status conditions
+	// are written either way.
+	// +optional
+	EventPolicy EventPolicy `json:"eventPolicy,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=All;Warnings;None
+type EventPolicy string
+
+const (
+	// EventPolicyAll records every event.
+	EventPolicyAll EventPolicy = "All"
+	// EventPolicyWarnings drops the Normal events, such as phase transitions.
+	EventPolicyWarnings EventPolicy = "Warnings"
+	// EventPolicyNone records no events, for high-frequency automated freezes.
+	EventPolicyNone EventPolicy = "None"
+)
+
+type Propagation struct {
+	// Names of the managed clusters to freeze the target on. Removing a cluster deletes its copy,
+	// which unfreezes the target there.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:MinLength=1
+	// +listType=set
+	Clusters []string `json:"clusters"`
+}
+
+type WakeOnRequest struct {
+	// Hosts served by the Deployment, matched against the Host header of requests reaching
+	// the activator.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	Hosts []string `json:"hosts"`
+}
+
+type Standby struct {
+	// Service to swap, in the namespace of this CR.
+	// +kubebuilder:validation:MinLength=1
+	ServiceName string `json:"serviceName"`
+
+	// Deployment serving the Service while frozen, in the namespace of this CR. Its
+	// spec.selector.matchLabels becomes the Service selector; matchExpressions are not supported.
+	// +kubebuilder:validation:MinLength=1
+	DeploymentName string `json:"deploymentName"`
+}
+
+type MaintenancePage struct {
+	// Ingress to switch, in the namespace of this CR; all of its Service backends are replaced.
+	// When empty, every Ingress of the namespace is switched where it routes to a Service
+	// selecting the Deployment's Pods.
+	// +optional
+	IngressName string `json:"ingressName,omitempty"`
+
+	// Service serving the maintenance page, in the namespace of this CR.
+	Backend networkingv1.IngressServiceBackend `json:"backend"`
+}
+
+// +kubebuilder:validation:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+type Weekday string
+
+type UnfreezeWindow struct {
+	// Days of the week on which the window opens. Empty means every day.
+	// +optional
+	// +listType=set
+	Days []Weekday `json:"days,omitempty"`
+
+	// Time of day the window opens, as HH:MM in timeZone.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// Time of day the window closes, as HH:MM in timeZone. An end at or before start closes
+	// the window on the following day.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	End string `json:"end"`
+
+	// IANA time zone the window is defined in, such as Europe/Berlin. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+type UnfreezeStrategyType string
+
+const (
+	// UnfreezeStrategyImmediate restores the original replica count in one step.
+	UnfreezeStrategyImmediate UnfreezeStrategyType = "Immediate"
+	// UnfreezeStrategyCanary restores a single replica first and the rest once it is Ready and stable.
+	UnfreezeStrategyCanary UnfreezeStrategyType = "Canary"
+)
+
+type UnfreezeStrategy struct {
+	// Strategy type.
+	// +kubebuilder:validation:Enum=Immediate;Canary
+	// +kubebuilder:default=Immediate
+	Type UnfreezeStrategyType `json:"type,omitempty"`
+
+	// Canary only: seconds the canary replica must stay Ready before the full count is restored.
+	// +optional
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=0
+	StableSeconds int64 `json:"stableSeconds,omitempty"`
+
+	// Canary only: seconds to wait for the canary replica to become Ready. 0 waits forever.
+	// +optional
+	// +kubebuilder:default=600
+	// +kubebuilder:validation:Minimum=0
+	ReadyTimeoutSeconds int64 `json:"readyTimeoutSeconds,omitempty"`
 }
 
 type Phase string
@@ -59,6 +311,26 @@ const (
 	ConditionTypeUnfreezeProgress        ConditionType = "UnfreezeProgress"
 	ConditionTypeHealth                  ConditionType = "Health"
 	ConditionTypeSpecChangedDuringFreeze ConditionType = "SpecChangedDuringFreeze"
+	ConditionTypeDryRun                  ConditionType = "DryRun"
+	ConditionTypePolicy                  ConditionType = "Policy"
+	ConditionTypeDriftDetected           ConditionType = "DriftDetected"
+	ConditionTypePostUnfreezeHealthy     ConditionType = "PostUnfreezeHealthy"
+	ConditionTypeGitOpsSync              ConditionType = "GitOpsSync"
+	ConditionTypeReconciliationPaused    ConditionType = "ReconciliationPaused"
+	ConditionTypeWaitingForOwnership     ConditionType = "WaitingForOwnership"
+	ConditionTypeBlackout                ConditionType = "Blackout"
+	ConditionTypeTraffic                 ConditionType = "Traffic"
+	ConditionTypePropagated              ConditionType = "Propagated"
+	ConditionTypeRestartRequested        ConditionType = "RestartRequested"
+
+	// Positively-polarized conditions with fixed semantics, meant for `kubectl wait`.
+	// Their reason is always the current phase.
+	ConditionTypeFrozen    ConditionType = "Frozen"
+	ConditionTypeCompleted ConditionType = "Completed"
+
+	// Progressing is True while the controller has not yet applied the latest spec, or while the
+	// Deployment is being scaled down or back up. Its reason is NewGeneration or the current phase.
+	ConditionTypeProgressing ConditionType = "Progressing"
 )
 
 type ConditionStatus string
@@ -69,6 +341,9 @@ const (
 	ConditionStatusUnknown ConditionStatus = "Unknown"
 )
 
+// ConditionReason is a short CamelCase reason; the set is open, see ConditionCode.
+// +kubebuilder:validation:MaxLength=1024
+// +kubebuilder:validation:Pattern=`^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$`
 type ConditionReason string
 
 const (
@@ -76,34 +351,115 @@ const (
 	ConditionReasonFound       ConditionReason = "Found"
 	ConditionReasonNotFound    ConditionReason = "NotFound"
 	ConditionReasonUIDMismatch ConditionReason = "UIDMismatch"
+	ConditionReasonNotSelected ConditionReason = "NotSelected"
+	// spec.targetRef.selector matches more than one workload.
+	ConditionReasonAmbiguousTarget ConditionReason = "AmbiguousTarget"
+	// The target is controlled by another workload, or the spec uses a feature its kind lacks.
+	ConditionReasonUnsupportedTarget ConditionReason = "UnsupportedTarget"
 
 	// Ownership reasons
 	ConditionReasonAcquired            ConditionReason = "Acquired"
 	ConditionReasonDeniedAlreadyFrozen ConditionReason = "DeniedAlreadyFrozen"
 	ConditionReasonLost                ConditionReason = "Lost"
 	ConditionReasonReleased            ConditionReason = "Released"
+	// A DeploymentFreezer of higher priority took the target over.
+	ConditionReasonPreempted ConditionReason = "Preempted"
 
 	// FreezeProgress reasons
 	ConditionReasonScalingDown  ConditionReason = "ScalingDown"
 	ConditionReasonScaledToZero ConditionReason = "ScaledToZero"
-	ConditionReasonAwaitingPDB  ConditionReason = "AwaitingPDB"
+	// The scale-down was refused while a PodDisruptionBudget of the target allows no disruption.
+	ConditionReasonAwaitingPDB ConditionReason = "AwaitingPDB"
+	// The scale-down was refused for another reason; the message has the API error.
+	ConditionReasonScaleDownFailed ConditionReason = "ScaleDownFailed"
+	// Status counters read zero, but Pods of the target are still running.
+	ConditionReasonPodsRemaining ConditionReason = "PodsRemaining"
 
 	// UnfreezeProgress reasons
 	ConditionReasonScalingUp      ConditionReason = "ScalingUp"
 	ConditionReasonScaledUp       ConditionReason = "ScaledUp"
 	ConditionReasonQuotaExceeded  ConditionReason = "QuotaExceeded"
 	ConditionReasonPartialRestore ConditionReason = "PartialRestore"
+	ConditionReasonCanary         ConditionReason = "Canary"
+	ConditionReasonThrottled      ConditionReason = "Throttled"
+	ConditionReasonOutsideWindow  ConditionReason = "OutsideUnfreezeWindow"
+	ConditionReasonInvalidWindow  ConditionReason = "InvalidUnfreezeWindow"
 
 	// Health reasons
 	ConditionReasonNormal      ConditionReason = "Normal"
 	ConditionReasonDegraded    ConditionReason = "Degraded"
 	ConditionReasonAPIConflict ConditionReason = "APIConflict"
 	ConditionReasonRBACDenied  ConditionReason = "RBACDenied"
+	ConditionReasonKillSwitch  ConditionReason = "KillSwitch"
 
 	// SpecChangedDuringFreeze reasons
 	ConditionReasonObserved ConditionReason = "Observed"
+
+	// Progressing reasons, besides the phases
+	ConditionReasonNewGeneration ConditionReason = "NewGeneration"
+
+	// DryRun reasons
+	ConditionReasonPlanned      ConditionReason = "Planned"
+	ConditionReasonPlanRejected ConditionReason = "PlanRejected"
+
+	// DriftDetected reasons
+	ConditionReasonNoDrift         ConditionReason = "NoDrift"
+	ConditionReasonAnnotationDrift ConditionReason = "AnnotationDrift"
+	ConditionReasonReplicasDrift   ConditionReason = "ReplicasDrift"
+
+	// RestartRequested reasons
+	// RolloutRestart: the kubectl.kubernetes.io/restartedAt template annotation changed while
+	// frozen; the restart takes effect once replicas are restored.
+	ConditionReasonRolloutRestart ConditionReason = "RolloutRestart"
+
+	// PostUnfreezeHealthy reasons
+	ConditionReasonObserving    ConditionReason = "Observing"
+	ConditionReasonHealthy      ConditionReason = "Healthy"
+	ConditionReasonCrashLooping ConditionReason = "CrashLooping"
+	ConditionReasonUnavailable  ConditionReason = "Unavailable"
+
+	// GitOpsSync reasons
+	ConditionReasonAwaitingGitOps ConditionReason = "AwaitingGitOps"
+	ConditionReasonSynced         ConditionReason = "Synced"
+
+	// Policy reasons
+	ConditionReasonAllowed            ConditionReason = "Allowed"
+	ConditionReasonPolicyDenied       ConditionReason = "PolicyDenied"
+	ConditionReasonProtectedNamespace ConditionReason = "ProtectedNamespace"
+
+	// ReconciliationPaused reasons
+	ConditionReasonPaused  ConditionReason = "Paused"
+	ConditionReasonResumed ConditionReason = "Resumed"
+
+	// WaitingForOwnership reasons
+	ConditionReasonHeldByOtherOwner ConditionReason = "HeldByOtherOwner"
+	ConditionReasonOwnerReleased    ConditionReason = "OwnerReleased"
+	ConditionReasonAcquireTimeout   ConditionReason = "AcquireTimeout"
+
+	// Blackout reasons
+	ConditionReasonInBlackout    ConditionReason = "InBlackout"
+	ConditionReasonBlackoutEnded ConditionReason = "BlackoutEnded"
+
+	// Traffic reasons
+	ConditionReasonMaintenance     ConditionReason = "Maintenance"
+	ConditionReasonNoRoute         ConditionReason = "NoRoute"
+	ConditionReasonAwaitingBackend ConditionReason = "AwaitingBackend"
+	ConditionReasonTrafficRestored ConditionReason = "TrafficRestored"
+
+	// Propagated reasons
+	ConditionReasonApplied             ConditionReason = "Applied"
+	ConditionReasonNotApplied          ConditionReason = "NotApplied"
+	ConditionReasonPropagationDisabled ConditionReason = "PropagationDisabled"
 )
 
+// ExemptionGrant records which FreezerPolicy granted an exemption.
+type ExemptionGrant struct {
+	Exemption Exemption `json:"exemption"`
+
+	// Name of the FreezerPolicy whose rule granted the exemption.
+	Policy string `json:"policy"`
+}
+
 type StatusTargetRef struct {
 	// Cached name of the target Deployment.
 	// +kubebuilder:validation:MinLength=1
@@ -114,10 +470,105 @@ type StatusTargetRef struct {
 	UID types.UID `json:"uid,omitempty"`
 }
 
+type AutoscalingSnapshot struct {
+	// Deployment spec.paused. Recorded only; a pause set by the freeze is undone through
+	// status.originalPaused.
+	Paused bool `json:"paused"`
+
+	// HorizontalPodAutoscaler scaling the Deployment, if any. Recorded only: the freeze does not
+	// change it, so it is not written back.
+	HPA *HPASnapshot `json:"hpa,omitempty"`
+
+	// KEDA ScaledObject scaling the Deployment, if any. It is paused while frozen.
+	ScaledObject *ScaledObjectSnapshot `json:"scaledObject,omitempty"`
+
+	// VerticalPodAutoscaler targeting the Deployment, if any. Its updates are turned off while frozen.
+	VPA *VPASnapshot `json:"vpa,omitempty"`
+}
+
+type HPASnapshot struct {
+	// Name of the HorizontalPodAutoscaler.
+	Name string `json:"name"`
+
+	// spec.minReplicas of the HorizontalPodAutoscaler.
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// spec.maxReplicas of the HorizontalPodAutoscaler.
+	MaxReplicas int32 `json:"maxReplicas"`
+}
+
+type ScaledObjectSnapshot struct {
+	// Name of the ScaledObject.
+	Name string `json:"name"`
+
+	// Value of its autoscaling.keda.sh/paused annotation; unset if it had none.
+	Paused *string `json:"paused,omitempty"`
+}
+
+type VPASnapshot struct {
+	// Name of the VerticalPodAutoscaler.
+	Name string `json:"name"`
+
+	// spec.updatePolicy.updateMode of the VerticalPodAutoscaler; unset if it had none.
+	UpdateMode *string `json:"updateMode,omitempty"`
+}
+
+type CanaryStatus struct {
+	// When the canary replica was requested.
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+
+	// When the canary replica became Ready; cleared if it stops being Ready.
+	ReadySince *metav1.Time `json:"readySince,omitempty"`
+
+	// The canary failed; the unfreeze is paused at one replica until
+	// spec.unfreezeStrategy.type is changed to Immediate or the CR is deleted.
+	Failed bool `json:"failed,omitempty"`
+}
+
+type StandbyStatus struct {
+	// Name of the swapped Service.
+	ServiceName string `json:"serviceName"`
+
+	// Selector of the Service before the swap, restored on unfreeze.
+	OriginalSelector map[string]string `json:"originalSelector,omitempty"`
+}
+
+type PostUnfreezeStatus struct {
+	// When replicas were restored and observation started.
+	RestoredAt *metav1.Time `json:"restoredAt,omitempty"`
+
+	// Highest total container restart count seen across the Deployment's Pods.
+	Restarts int32 `json:"restarts,omitempty"`
+}
+
+// ResourcesFreed is what a freeze removed from the cluster: the Pods of the target and their
+// requests, as computed from the pod template when the target was drained.
+type ResourcesFreed struct {
+	// Pods removed, from status.originalReplicas.
+	Replicas int32 `json:"replicas"`
+
+	// CPU requests of the removed Pods, all replicas together.
+	CPU resource.Quantity `json:"cpu"`
+
+	// Memory requests of the removed Pods, all replicas together.
+	Memory resource.Quantity `json:"memory"`
+}
+
+type RestoreProgress struct {
+	// Replicas being restored, from status.originalReplicas.
+	Replicas int32 `json:"replicas"`
+
+	// status.readyReplicas of the target at the last reconcile.
+	ReadyReplicas int32 `json:"readyReplicas"`
+
+	// status.availableReplicas of the target at the last reconcile.
+	AvailableReplicas int32 `json:"availableReplicas"`
+}
+
 type Condition struct {
 	// Category of fact.
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=TargetFound;Ownership;FreezeProgress;UnfreezeProgress;Health;SpecChangedDuringFreeze
+	// +kubebuilder:validation:Enum=TargetFound;Ownership;FreezeProgress;UnfreezeProgress;Health;SpecChangedDuringFreeze;DryRun;Policy;DriftDetected;PostUnfreezeHealthy;GitOpsSync;Blackout;Traffic;Propagated;RestartRequested;Frozen;Completed;Progressing
 	Type ConditionType `json:"type"`
 
 	// Whether the condition is satisfied.
@@ -125,11 +576,17 @@ type Condition struct {
 	// +kubebuilder:validation:Enum=True;False;Unknown
 	Status ConditionStatus `json:"status"`
 
-	// Short CamelCase reason for the last transition.
+	// Short CamelCase reason for the last transition. Reasons are not a closed set: new ones
+	// may be added and existing ones refined without an API change; switch on code instead.
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:validation:Enum=Found;NotFound;UIDMismatch;Acquired;DeniedAlreadyFrozen;Lost;Released;ScalingDown;ScaledToZero;AwaitingPDB;ScalingUp;ScaledUp;QuotaExceeded;PartialRestore;Normal;Degraded;APIConflict;RBACDenied;Observed
 	Reason ConditionReason `json:"reason,omitempty"`
 
+	// Machine-readable classification of the failure the condition reports; empty while
+	// nothing is wrong.
+	// +optional
+	// +kubebuilder:validation:Enum=TargetNotFound;TargetReplaced;TargetAmbiguous;TargetUnsupported;OwnershipConflict;PolicyDenied;InvalidSpec;ScaleDownBlocked;RestoreFailed;TargetDrifted;WorkloadUnhealthy;APIError;Forbidden;KillSwitch;TrafficUnroutable;PropagationFailed
+	Code ConditionCode `json:"code,omitempty"`
+
 	// Human-readable message (for operators/users).
 	// +kubebuilder:validation:MaxLength=2048
 	Message string `json:"message,omitempty"`
@@ -138,33 +595,194 @@ type Condition struct {
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 }
 
+type OperationError struct {
+	// Operation that failed, e.g. RestoreReplicas or PatchMetadata.
+	Operation string `json:"operation"`
+
+	// Error message of the last attempt.
+	// +kubebuilder:validation:MaxLength=2048
+	Message string `json:"message"`
+
+	// Failed attempts of the operation in a row; a failure of another operation starts over at 1.
+	Attempts int32 `json:"attempts"`
+
+	// Time of the first failed attempt in a row.
+	FirstFailureTime metav1.Time `json:"firstFailureTime"`
+
+	// Time of the last failed attempt.
+	LastFailureTime metav1.Time `json:"lastFailureTime"`
+}
+
+type RetryCount struct {
+	// Failed attempts to freeze the Deployment; reset once it is Frozen.
+	// +optional
+	Freeze int32 `json:"freeze,omitempty"`
+
+	// Failed attempts to restore the Deployment; reset once the CR is Completed.
+	// +optional
+	Unfreeze int32 `json:"unfreeze,omitempty"`
+
+	// Failed attempts to claim or release the ownership annotation; reset once it is claimed
+	// or released.
+	// +optional
+	Ownership int32 `json:"ownership,omitempty"`
+}
+
 type DeploymentFreezerStatus struct {
 	// High-level lifecycle summary.
 	// +kubebuilder:validation:Enum=Pending;Freezing;Frozen;Unfreezing;Completed;Denied;Aborted
 	Phase Phase `json:"phase,omitempty"`
 
-	// Last observed generation of the CR's spec.
+	// Time each phase was first entered. Unlike condition timestamps these never move.
+	PhaseTransitionTimes map[Phase]metav1.Time `json:"phaseTransitionTimes,omitempty"`
+
+	// Generation of the spec the controller last applied: it only moves once a reconcile acted
+	// on that spec without error. Compare it with metadata.generation, or check that the
+	// Progressing condition is not NewGeneration, to know a change was applied.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
+	// Build of the controller that last wrote this status, as "<version> (<commit>)". Written
+	// only along with another change, so it can lag behind a rollout of the operator.
+	// +optional
+	ControllerVersion string `json:"controllerVersion,omitempty"`
+
 	// Cached target info recorded when the freeze started.
 	TargetRef StatusTargetRef `json:"targetRef,omitempty"`
 
+	// Hash of the target's pod template when the freeze started. SpecChangedDuringFreeze is
+	// raised once the template no longer matches it.
+	// +optional
+	TemplateHash string `json:"templateHash,omitempty"`
+
 	// Replicas before freezing (for deterministic restore).
 	OriginalReplicas *int32 `json:"originalReplicas,omitempty"`
 
-	// Absolute time when the Deployment should be unfrozen.
+	// Deployment spec.paused before freezing; set only when spec.pauseRollout paused the
+	// Deployment itself. Only then is spec.paused restored on unfreeze.
+	OriginalPaused *bool `json:"originalPaused,omitempty"`
+
+	// Autoscaling context of the Deployment before it was frozen, restored on unfreeze.
+	Snapshot *AutoscalingSnapshot `json:"snapshot,omitempty"`
+
+	// Pods and requests the freeze removed, recorded when Frozen is reached.
+	// +optional
+	ResourcesFreed *ResourcesFreed `json:"resourcesFreed,omitempty"`
+
+	// The lower-priority DeploymentFreezer whose target, replicas and snapshot this one took over.
+	// +optional
+	Preempted *PreemptedFreeze `json:"preempted,omitempty"`
+
+	// Absolute time when the Deployment should be unfrozen. With spec.freezeGroup, the latest
+	// deadline of the group's Frozen members.
 	FreezeUntil *metav1.Time `json:"freezeUntil,omitempty"`
 
+	// With spec.freezeGroup: this DeploymentFreezer's own deadline, from its duration.
+	// +optional
+	MemberFreezeUntil *metav1.Time `json:"memberFreezeUntil,omitempty"`
+
+	// Exemptions declared in spec.exemptions and the FreezerPolicy that granted each, kept for audit.
+	// +optional
+	// +listType=map
+	// +listMapKey=exemption
+	Exemptions []ExemptionGrant `json:"exemptions,omitempty"`
+
+	// Last time the controller confirmed the freeze while Frozen, refreshed every few minutes.
+	// A value falling behind means no controller is processing this DeploymentFreezer.
+	// +optional
+	LastHeartbeatTime *metav1.Time `json:"lastHeartbeatTime,omitempty"`
+
+	// Progress of a Canary unfreeze.
+	Canary *CanaryStatus `json:"canary,omitempty"`
+
+	// Post-unfreeze observation progress; set once replicas are restored.
+	PostUnfreeze *PostUnfreezeStatus `json:"postUnfreeze,omitempty"`
+
+	// Ready and available replicas of the target while Unfreezing, refreshed on every
+	// reconcile so the restore can be followed on the DeploymentFreezer alone. Kept as last
+	// seen once the unfreeze completes.
+	// +optional
+	RestoreProgress *RestoreProgress `json:"restoreProgress,omitempty"`
+
+	// Ingresses switched to the maintenance page. Their original backends are kept in an
+	// annotation on each Ingress.
+	// +optional
+	// +listType=set
+	MaintenanceIngresses []string `json:"maintenanceIngresses,omitempty"`
+
+	// Service swapped to spec.standby and the selector it had.
+	// +optional
+	Standby *StandbyStatus `json:"standby,omitempty"`
+
+	// Last operational error, such as a failed restore or annotation patch. Kept after the
+	// operation succeeds, so it can be inspected later.
+	// +optional
+	LastError *OperationError `json:"lastError,omitempty"`
+
+	// Failed attempts per operation class, each reset once its operation succeeds.
+	// +optional
+	RetryCount *RetryCount `json:"retryCount,omitempty"`
+
 	// Fine-grained condition set.
 	Conditions []Condition `json:"conditions,omitempty"`
+
+	// Changes the controller would make to the target, as accepted by the API server
+	// in dry-run (plan) mode.
+	PlannedChanges []string `json:"plannedChanges,omitempty"`
+
+	// State of the copies on managed clusters, with spec.propagation.
+	// +optional
+	// +listType=map
+	// +listMapKey=cluster
+	Clusters []ClusterFreeze `json:"clusters,omitempty"`
+}
+
+// PreemptedFreeze names a DeploymentFreezer preempted by a higher-priority one.
+type PreemptedFreeze struct {
+	// Name of the preempted DeploymentFreezer, in the same namespace.
+	Name string `json:"name"`
+
+	// UID of the preempted DeploymentFreezer.
+	UID types.UID `json:"uid"`
+
+	// Priority of the preempted DeploymentFreezer.
+	Priority int32 `json:"priority"`
+
+	// When the target was taken over. Unset while the takeover is prepared: its replicas and
+	// snapshot are copied first, so they are never lost in between.
+	// +optional
+	Time *metav1.Time `json:"time,omitempty"`
+}
+
+// ClusterFreeze is the state of a propagated DeploymentFreezer on a managed cluster.
+type ClusterFreeze struct {
+	// Name of the managed cluster.
+	Cluster string `json:"cluster"`
+
+	// Last phase reported by the cluster; empty until it reports one.
+	// +optional
+	Phase Phase `json:"phase,omitempty"`
+
+	// Why the copy could not be applied on the cluster.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
+// +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:categories=all,shortName=df
 // +kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.targetRef.name`
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
 // +kubebuilder:printcolumn:name="FreezeUntil",type=string,JSONPath=`.status.freezeUntil`
+// +kubebuilder:printcolumn:name="Notes",type=string,JSONPath=`.spec.notes`
+// +kubebuilder:printcolumn:name="Freeze Retries",type=integer,JSONPath=`.status.retryCount.freeze`,priority=1
+// +kubebuilder:printcolumn:name="Unfreeze Retries",type=integer,JSONPath=`.status.retryCount.unfreeze`,priority=1
+// +kubebuilder:printcolumn:name="Ownership Retries",type=integer,JSONPath=`.status.retryCount.ownership`,priority=1
+// +kubebuilder:printcolumn:name="Heartbeat",type=date,JSONPath=`.status.lastHeartbeatTime`,priority=1
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=`.status.restoreProgress.readyReplicas`,priority=1
+// +kubebuilder:printcolumn:name="Available",type=integer,JSONPath=`.status.restoreProgress.availableReplicas`,priority=1
+// +kubebuilder:printcolumn:name="CPU Freed",type=string,JSONPath=`.status.resourcesFreed.cpu`,priority=1
+// +kubebuilder:printcolumn:name="Memory Freed",type=string,JSONPath=`.status.resourcesFreed.memory`,priority=1
 type DeploymentFreezer struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -0,0 +1,193 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+type PolicyAction string
+
+const (
+	PolicyActionAllow PolicyAction = "Allow"
+	PolicyActionDeny  PolicyAction = "Deny"
+)
+
+// Exemption names a controller-wide protection a DeploymentFreezer can be exempted from.
+// +kubebuilder:validation:Enum=ProtectedNamespace;MaxDuration
+type Exemption string
+
+const (
+	// ExemptionProtectedNamespace allows freezing in a protected namespace, kube-system included.
+	ExemptionProtectedNamespace Exemption = "ProtectedNamespace"
+	// ExemptionMaxDuration lifts the controller's --max-duration; maxDurationSeconds of the
+	// matching FreezerPolicy rules still applies.
+	ExemptionMaxDuration Exemption = "MaxDuration"
+)
+
+// FreezerPolicyRule decides whether DeploymentFreezers in some namespaces may be used, and by whom.
+type FreezerPolicyRule struct {
+	// Whether matching DeploymentFreezers are allowed or denied.
+	// +kubebuilder:validation:Enum=Allow;Deny
+	Action PolicyAction `json:"action"`
+
+	// Namespaces matched by name. "*" matches every namespace.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Namespaces matched by label. A rule matches a namespace listed in Namespaces
+	// or selected by NamespaceSelector.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Users, groups or service accounts the rule applies to, matched against the creator
+	// of the DeploymentFreezer. Empty matches everyone.
+	// +optional
+	Subjects []rbacv1.Subject `json:"subjects,omitempty"`
+
+	// Maximum freeze duration allowed by this rule (Allow only). 0 means no limit.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxDurationSeconds int64 `json:"maxDurationSeconds,omitempty"`
+
+	// Cap on the cumulative frozen time of each namespace matched by this rule (Allow only).
+	// All DeploymentFreezers in the namespace count, whoever created them.
+	// +optional
+	Quota *FreezeQuota `json:"quota,omitempty"`
+
+	// Exemptions that DeploymentFreezers matching this rule may declare in spec.exemptions
+	// (Allow only). A DeploymentFreezer declaring an exemption no matching rule grants is denied.
+	// +optional
+	// +listType=set
+	Exemptions []Exemption `json:"exemptions,omitempty"`
+}
+
+// DefaultQuotaWindowSeconds is the rolling window of a FreezeQuota when none is set (30 days).
+const DefaultQuotaWindowSeconds = 30 * 24 * 60 * 60
+
+// FreezeQuota caps how long Deployments of one namespace may be frozen within a rolling window.
+type FreezeQuota struct {
+	// Maximum cumulative frozen time per namespace within the window, in seconds.
+	// +kubebuilder:validation:Minimum=1
+	MaxFrozenSeconds int64 `json:"maxFrozenSeconds"`
+
+	// Length of the rolling window in seconds. Defaults to 30 days.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	WindowSeconds int64 `json:"windowSeconds,omitempty"`
+}
+
+// BlackoutWindow is a period, such as a company-wide release freeze, during which no Deployment
+// in the matched namespaces is scaled down or up: every DeploymentFreezer stays in its phase,
+// whatever its own timing, and resumes once the window ends.
+// +kubebuilder:validation:XValidation:rule="self.end > self.start",message="end must be after start"
+type BlackoutWindow struct {
+	// Name of the blackout, reported in the conditions and events of the DeploymentFreezers it holds.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// When the blackout starts.
+	Start metav1.Time `json:"start"`
+
+	// When the blackout ends.
+	End metav1.Time `json:"end"`
+
+	// Namespaces matched by name. "*" matches every namespace.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Namespaces matched by label. Without Namespaces and NamespaceSelector the blackout
+	// covers the whole cluster.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+type FreezerPolicySpec struct {
+	// Rules of this policy. A Deny rule matching a DeploymentFreezer always wins; otherwise it
+	// must match at least one Allow rule of any FreezerPolicy. A policy without rules, e.g. one
+	// that only declares blackouts, allows and denies nothing.
+	// +optional
+	Rules []FreezerPolicyRule `json:"rules,omitempty"`
+
+	// Blackouts during which no Deployment is scaled down or up.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Blackouts []BlackoutWindow `json:"blackouts,omitempty"`
+}
+
+// FrozenPeriod is a finished freeze counted against a quota.
+type FrozenPeriod struct {
+	// Name of the DeploymentFreezer.
+	Freezer string `json:"freezer"`
+
+	// UID of the DeploymentFreezer, so a period is recorded only once.
+	UID types.UID `json:"uid"`
+
+	// When scaling down started.
+	Start metav1.Time `json:"start"`
+
+	// When the Deployment was restored (or the freeze ended otherwise).
+	End metav1.Time `json:"end"`
+}
+
+// NamespaceUsage is the freeze history of one namespace.
+type NamespaceUsage struct {
+	Namespace string `json:"namespace"`
+
+	// +optional
+	Periods []FrozenPeriod `json:"periods,omitempty"`
+}
+
+type FreezerPolicyStatus struct {
+	// Finished freezes of namespaces governed by a quota of this policy, pruned to the quota window.
+	// +optional
+	// +listType=map
+	// +listMapKey=namespace
+	Usage []NamespaceUsage `json:"usage,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=fzp
+
+// FreezerPolicy declares which namespaces may be frozen, by whom and for how long.
+// When no FreezerPolicy exists every DeploymentFreezer is allowed.
+type FreezerPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FreezerPolicySpec   `json:"spec,omitempty"`
+	Status FreezerPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+type FreezerPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FreezerPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FreezerPolicy{}, &FreezerPolicyList{})
+}
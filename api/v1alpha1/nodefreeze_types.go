@@ -0,0 +1,103 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// LabelNodeFreeze is set on every DeploymentFreezer created by a NodeFreeze; value: the NodeFreeze name.
+const LabelNodeFreeze = "apps.boolfixer.dev/node-freeze"
+
+type NodeFreezeSpec struct {
+	// Name of the Node whose workloads are frozen.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="nodeName is immutable"
+	NodeName string `json:"nodeName"`
+
+	// Duration of the freeze window in seconds, passed to every DeploymentFreezer created.
+	// Defaults to the controller's --default-duration and is capped by its --max-duration.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	DurationSeconds int64 `json:"durationSeconds,omitempty"`
+}
+
+// ChildFreeze links a NodeFreeze to a DeploymentFreezer it created.
+type ChildFreeze struct {
+	Namespace string `json:"namespace"`
+
+	// Name of the DeploymentFreezer.
+	Name string `json:"name"`
+
+	// Name of the frozen Deployment.
+	Deployment string `json:"deployment"`
+
+	// Last observed phase of the DeploymentFreezer; empty when it was refused or is gone.
+	// +optional
+	Phase Phase `json:"phase,omitempty"`
+
+	// Why the DeploymentFreezer could not be created or is gone.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+type NodeFreezeStatus struct {
+	// Aggregated phase of the child freezes: Pending until Deployments are discovered, then
+	// Freezing, Frozen or Unfreezing while any child is, and Completed once all of them finished.
+	// +optional
+	Phase Phase `json:"phase,omitempty"`
+
+	// When the Deployments running on the node were discovered. Discovery happens once.
+	// +optional
+	DiscoveredAt *metav1.Time `json:"discoveredAt,omitempty"`
+
+	// DeploymentFreezers created for the Deployments that had pods on the node.
+	// +optional
+	Freezes []ChildFreeze `json:"freezes,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=nfz
+// +kubebuilder:printcolumn:name="Node",type=string,JSONPath=`.spec.nodeName`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NodeFreeze freezes every Deployment that has pods on a node, for node maintenance.
+// It creates and owns one DeploymentFreezer per Deployment; deleting it unfreezes them.
+type NodeFreeze struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodeFreezeSpec   `json:"spec,omitempty"`
+	Status NodeFreezeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+type NodeFreezeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NodeFreeze `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NodeFreeze{}, &NodeFreezeList{})
+}
@@ -21,27 +21,956 @@ limitations under the License.
 package v1alpha1
 
 import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoFreezePolicy) DeepCopyInto(out *AutoFreezePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoFreezePolicy.
+func (in *AutoFreezePolicy) DeepCopy() *AutoFreezePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoFreezePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoFreezePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoFreezePolicyList) DeepCopyInto(out *AutoFreezePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AutoFreezePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoFreezePolicyList.
+func (in *AutoFreezePolicyList) DeepCopy() *AutoFreezePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoFreezePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoFreezePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoFreezePolicySpec) DeepCopyInto(out *AutoFreezePolicySpec) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	if in.CrashLoop != nil {
+		in, out := &in.CrashLoop, &out.CrashLoop
+		*out = new(CrashLoopTrigger)
+		**out = **in
+	}
+	if in.Metric != nil {
+		in, out := &in.Metric, &out.Metric
+		*out = new(MetricTrigger)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoFreezePolicySpec.
+func (in *AutoFreezePolicySpec) DeepCopy() *AutoFreezePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoFreezePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoFreezePolicyStatus) DeepCopyInto(out *AutoFreezePolicyStatus) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]AutoFreezeTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoFreezePolicyStatus.
+func (in *AutoFreezePolicyStatus) DeepCopy() *AutoFreezePolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoFreezePolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoFreezeTarget) DeepCopyInto(out *AutoFreezeTarget) {
+	*out = *in
+	if in.UnhealthySince != nil {
+		in, out := &in.UnhealthySince, &out.UnhealthySince
+		*out = (*in).DeepCopy()
+	}
+	if in.FrozenAt != nil {
+		in, out := &in.FrozenAt, &out.FrozenAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoFreezeTarget.
+func (in *AutoFreezeTarget) DeepCopy() *AutoFreezeTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoFreezeTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingSnapshot) DeepCopyInto(out *AutoscalingSnapshot) {
+	*out = *in
+	if in.HPA != nil {
+		in, out := &in.HPA, &out.HPA
+		*out = new(HPASnapshot)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScaledObject != nil {
+		in, out := &in.ScaledObject, &out.ScaledObject
+		*out = new(ScaledObjectSnapshot)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VPA != nil {
+		in, out := &in.VPA, &out.VPA
+		*out = new(VPASnapshot)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingSnapshot.
+func (in *AutoscalingSnapshot) DeepCopy() *AutoscalingSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackoutWindow) DeepCopyInto(out *BlackoutWindow) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	in.End.DeepCopyInto(&out.End)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackoutWindow.
+func (in *BlackoutWindow) DeepCopy() *BlackoutWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackoutWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryStatus) DeepCopyInto(out *CanaryStatus) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ReadySince != nil {
+		in, out := &in.ReadySince, &out.ReadySince
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryStatus.
+func (in *CanaryStatus) DeepCopy() *CanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChildFreeze) DeepCopyInto(out *ChildFreeze) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChildFreeze.
+func (in *ChildFreeze) DeepCopy() *ChildFreeze {
+	if in == nil {
+		return nil
+	}
+	out := new(ChildFreeze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterFreeze) DeepCopyInto(out *ClusterFreeze) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterFreeze.
+func (in *ClusterFreeze) DeepCopy() *ClusterFreeze {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterFreeze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterFreezeReport) DeepCopyInto(out *ClusterFreezeReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterFreezeReport.
+func (in *ClusterFreezeReport) DeepCopy() *ClusterFreezeReport {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterFreezeReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterFreezeReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterFreezeReportList) DeepCopyInto(out *ClusterFreezeReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterFreezeReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterFreezeReportList.
+func (in *ClusterFreezeReportList) DeepCopy() *ClusterFreezeReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterFreezeReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterFreezeReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterFreezeReportSpec) DeepCopyInto(out *ClusterFreezeReportSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterFreezeReportSpec.
+func (in *ClusterFreezeReportSpec) DeepCopy() *ClusterFreezeReportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterFreezeReportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterFreezeReportStatus) DeepCopyInto(out *ClusterFreezeReportStatus) {
+	*out = *in
+	if in.LastUpdated != nil {
+		in, out := &in.LastUpdated, &out.LastUpdated
+		*out = (*in).DeepCopy()
+	}
+	if in.Phases != nil {
+		in, out := &in.Phases, &out.Phases
+		*out = make(map[Phase]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = make([]FreezeSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UpcomingUnfreezes != nil {
+		in, out := &in.UpcomingUnfreezes, &out.UpcomingUnfreezes
+		*out = make([]FreezeSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RecentAborts != nil {
+		in, out := &in.RecentAborts, &out.RecentAborts
+		*out = make([]FreezeSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterFreezeReportStatus.
+func (in *ClusterFreezeReportStatus) DeepCopy() *ClusterFreezeReportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterFreezeReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrashLoopTrigger) DeepCopyInto(out *CrashLoopTrigger) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrashLoopTrigger.
+func (in *CrashLoopTrigger) DeepCopy() *CrashLoopTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(CrashLoopTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentFreezer) DeepCopyInto(out *DeploymentFreezer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentFreezer.
+func (in *DeploymentFreezer) DeepCopy() *DeploymentFreezer {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentFreezer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DeploymentFreezer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentFreezerList) DeepCopyInto(out *DeploymentFreezerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DeploymentFreezer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentFreezerList.
+func (in *DeploymentFreezerList) DeepCopy() *DeploymentFreezerList {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentFreezerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DeploymentFreezerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentFreezerSpec) DeepCopyInto(out *DeploymentFreezerSpec) {
+	*out = *in
+	in.TargetRef.DeepCopyInto(&out.TargetRef)
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Exemptions != nil {
+		in, out := &in.Exemptions, &out.Exemptions
+		*out = make([]Exemption, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnfreezeStrategy != nil {
+		in, out := &in.UnfreezeStrategy, &out.UnfreezeStrategy
+		*out = new(UnfreezeStrategy)
+		**out = **in
+	}
+	if in.UnfreezeWindow != nil {
+		in, out := &in.UnfreezeWindow, &out.UnfreezeWindow
+		*out = new(UnfreezeWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenancePage != nil {
+		in, out := &in.MaintenancePage, &out.MaintenancePage
+		*out = new(MaintenancePage)
+		**out = **in
+	}
+	if in.Standby != nil {
+		in, out := &in.Standby, &out.Standby
+		*out = new(Standby)
+		**out = **in
+	}
+	if in.WakeOnRequest != nil {
+		in, out := &in.WakeOnRequest, &out.WakeOnRequest
+		*out = new(WakeOnRequest)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Propagation != nil {
+		in, out := &in.Propagation, &out.Propagation
+		*out = new(Propagation)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentFreezerSpec.
+func (in *DeploymentFreezerSpec) DeepCopy() *DeploymentFreezerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentFreezerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentFreezerStatus) DeepCopyInto(out *DeploymentFreezerStatus) {
+	*out = *in
+	if in.PhaseTransitionTimes != nil {
+		in, out := &in.PhaseTransitionTimes, &out.PhaseTransitionTimes
+		*out = make(map[Phase]v1.Time, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	out.TargetRef = in.TargetRef
+	if in.OriginalReplicas != nil {
+		in, out := &in.OriginalReplicas, &out.OriginalReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.OriginalPaused != nil {
+		in, out := &in.OriginalPaused, &out.OriginalPaused
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Snapshot != nil {
+		in, out := &in.Snapshot, &out.Snapshot
+		*out = new(AutoscalingSnapshot)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourcesFreed != nil {
+		in, out := &in.ResourcesFreed, &out.ResourcesFreed
+		*out = new(ResourcesFreed)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Preempted != nil {
+		in, out := &in.Preempted, &out.Preempted
+		*out = new(PreemptedFreeze)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FreezeUntil != nil {
+		in, out := &in.FreezeUntil, &out.FreezeUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.MemberFreezeUntil != nil {
+		in, out := &in.MemberFreezeUntil, &out.MemberFreezeUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.Exemptions != nil {
+		in, out := &in.Exemptions, &out.Exemptions
+		*out = make([]ExemptionGrant, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastHeartbeatTime != nil {
+		in, out := &in.LastHeartbeatTime, &out.LastHeartbeatTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostUnfreeze != nil {
+		in, out := &in.PostUnfreeze, &out.PostUnfreeze
+		*out = new(PostUnfreezeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestoreProgress != nil {
+		in, out := &in.RestoreProgress, &out.RestoreProgress
+		*out = new(RestoreProgress)
+		**out = **in
+	}
+	if in.MaintenanceIngresses != nil {
+		in, out := &in.MaintenanceIngresses, &out.MaintenanceIngresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Standby != nil {
+		in, out := &in.Standby, &out.Standby
+		*out = new(StandbyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastError != nil {
+		in, out := &in.LastError, &out.LastError
+		*out = new(OperationError)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RetryCount != nil {
+		in, out := &in.RetryCount, &out.RetryCount
+		*out = new(RetryCount)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PlannedChanges != nil {
+		in, out := &in.PlannedChanges, &out.PlannedChanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterFreeze, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentFreezerStatus.
+func (in *DeploymentFreezerStatus) DeepCopy() *DeploymentFreezerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentFreezerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentTargetRef) DeepCopyInto(out *DeploymentTargetRef) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentTargetRef.
+func (in *DeploymentTargetRef) DeepCopy() *DeploymentTargetRef {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentTargetRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExemptionGrant) DeepCopyInto(out *ExemptionGrant) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExemptionGrant.
+func (in *ExemptionGrant) DeepCopy() *ExemptionGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(ExemptionGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeQuota) DeepCopyInto(out *FreezeQuota) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeQuota.
+func (in *FreezeQuota) DeepCopy() *FreezeQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeSummary) DeepCopyInto(out *FreezeSummary) {
+	*out = *in
+	if in.Since != nil {
+		in, out := &in.Since, &out.Since
+		*out = (*in).DeepCopy()
+	}
+	if in.FreezeUntil != nil {
+		in, out := &in.FreezeUntil, &out.FreezeUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.ResourcesFreed != nil {
+		in, out := &in.ResourcesFreed, &out.ResourcesFreed
+		*out = new(ResourcesFreed)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeSummary.
+func (in *FreezeSummary) DeepCopy() *FreezeSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezerPolicy) DeepCopyInto(out *FreezerPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezerPolicy.
+func (in *FreezerPolicy) DeepCopy() *FreezerPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezerPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FreezerPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezerPolicyList) DeepCopyInto(out *FreezerPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FreezerPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezerPolicyList.
+func (in *FreezerPolicyList) DeepCopy() *FreezerPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezerPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FreezerPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezerPolicyRule) DeepCopyInto(out *FreezerPolicyRule) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]rbacv1.Subject, len(*in))
+		copy(*out, *in)
+	}
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		*out = new(FreezeQuota)
+		**out = **in
+	}
+	if in.Exemptions != nil {
+		in, out := &in.Exemptions, &out.Exemptions
+		*out = make([]Exemption, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezerPolicyRule.
+func (in *FreezerPolicyRule) DeepCopy() *FreezerPolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezerPolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezerPolicySpec) DeepCopyInto(out *FreezerPolicySpec) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]FreezerPolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Blackouts != nil {
+		in, out := &in.Blackouts, &out.Blackouts
+		*out = make([]BlackoutWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezerPolicySpec.
+func (in *FreezerPolicySpec) DeepCopy() *FreezerPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezerPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezerPolicyStatus) DeepCopyInto(out *FreezerPolicyStatus) {
+	*out = *in
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = make([]NamespaceUsage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezerPolicyStatus.
+func (in *FreezerPolicyStatus) DeepCopy() *FreezerPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezerPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrozenPeriod) DeepCopyInto(out *FrozenPeriod) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	in.End.DeepCopyInto(&out.End)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
-func (in *Condition) DeepCopy() *Condition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrozenPeriod.
+func (in *FrozenPeriod) DeepCopy() *FrozenPeriod {
 	if in == nil {
 		return nil
 	}
-	out := new(Condition)
+	out := new(FrozenPeriod)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeploymentFreezer) DeepCopyInto(out *DeploymentFreezer) {
+func (in *HPASnapshot) DeepCopyInto(out *HPASnapshot) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HPASnapshot.
+func (in *HPASnapshot) DeepCopy() *HPASnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(HPASnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenancePage) DeepCopyInto(out *MaintenancePage) {
+	*out = *in
+	out.Backend = in.Backend
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenancePage.
+func (in *MaintenancePage) DeepCopy() *MaintenancePage {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenancePage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricTrigger) DeepCopyInto(out *MetricTrigger) {
+	*out = *in
+	out.Threshold = in.Threshold.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricTrigger.
+func (in *MetricTrigger) DeepCopy() *MetricTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceUsage) DeepCopyInto(out *NamespaceUsage) {
+	*out = *in
+	if in.Periods != nil {
+		in, out := &in.Periods, &out.Periods
+		*out = make([]FrozenPeriod, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceUsage.
+func (in *NamespaceUsage) DeepCopy() *NamespaceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeFreeze) DeepCopyInto(out *NodeFreeze) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -49,18 +978,18 @@ func (in *DeploymentFreezer) DeepCopyInto(out *DeploymentFreezer) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentFreezer.
-func (in *DeploymentFreezer) DeepCopy() *DeploymentFreezer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeFreeze.
+func (in *NodeFreeze) DeepCopy() *NodeFreeze {
 	if in == nil {
 		return nil
 	}
-	out := new(DeploymentFreezer)
+	out := new(NodeFreeze)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DeploymentFreezer) DeepCopyObject() runtime.Object {
+func (in *NodeFreeze) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -68,31 +997,31 @@ func (in *DeploymentFreezer) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeploymentFreezerList) DeepCopyInto(out *DeploymentFreezerList) {
+func (in *NodeFreezeList) DeepCopyInto(out *NodeFreezeList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DeploymentFreezer, len(*in))
+		*out = make([]NodeFreeze, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentFreezerList.
-func (in *DeploymentFreezerList) DeepCopy() *DeploymentFreezerList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeFreezeList.
+func (in *NodeFreezeList) DeepCopy() *NodeFreezeList {
 	if in == nil {
 		return nil
 	}
-	out := new(DeploymentFreezerList)
+	out := new(NodeFreezeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DeploymentFreezerList) DeepCopyObject() runtime.Object {
+func (in *NodeFreezeList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -100,64 +1029,219 @@ func (in *DeploymentFreezerList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeploymentFreezerSpec) DeepCopyInto(out *DeploymentFreezerSpec) {
+func (in *NodeFreezeSpec) DeepCopyInto(out *NodeFreezeSpec) {
 	*out = *in
-	out.TargetRef = in.TargetRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentFreezerSpec.
-func (in *DeploymentFreezerSpec) DeepCopy() *DeploymentFreezerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeFreezeSpec.
+func (in *NodeFreezeSpec) DeepCopy() *NodeFreezeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DeploymentFreezerSpec)
+	out := new(NodeFreezeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeploymentFreezerStatus) DeepCopyInto(out *DeploymentFreezerStatus) {
+func (in *NodeFreezeStatus) DeepCopyInto(out *NodeFreezeStatus) {
 	*out = *in
-	out.TargetRef = in.TargetRef
-	if in.OriginalReplicas != nil {
-		in, out := &in.OriginalReplicas, &out.OriginalReplicas
-		*out = new(int32)
-		**out = **in
+	if in.DiscoveredAt != nil {
+		in, out := &in.DiscoveredAt, &out.DiscoveredAt
+		*out = (*in).DeepCopy()
 	}
-	if in.FreezeUntil != nil {
-		in, out := &in.FreezeUntil, &out.FreezeUntil
+	if in.Freezes != nil {
+		in, out := &in.Freezes, &out.Freezes
+		*out = make([]ChildFreeze, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeFreezeStatus.
+func (in *NodeFreezeStatus) DeepCopy() *NodeFreezeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeFreezeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperationError) DeepCopyInto(out *OperationError) {
+	*out = *in
+	in.FirstFailureTime.DeepCopyInto(&out.FirstFailureTime)
+	in.LastFailureTime.DeepCopyInto(&out.LastFailureTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperationError.
+func (in *OperationError) DeepCopy() *OperationError {
+	if in == nil {
+		return nil
+	}
+	out := new(OperationError)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostUnfreezeStatus) DeepCopyInto(out *PostUnfreezeStatus) {
+	*out = *in
+	if in.RestoredAt != nil {
+		in, out := &in.RestoredAt, &out.RestoredAt
 		*out = (*in).DeepCopy()
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostUnfreezeStatus.
+func (in *PostUnfreezeStatus) DeepCopy() *PostUnfreezeStatus {
+	if in == nil {
+		return nil
 	}
+	out := new(PostUnfreezeStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentFreezerStatus.
-func (in *DeploymentFreezerStatus) DeepCopy() *DeploymentFreezerStatus {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreemptedFreeze) DeepCopyInto(out *PreemptedFreeze) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreemptedFreeze.
+func (in *PreemptedFreeze) DeepCopy() *PreemptedFreeze {
 	if in == nil {
 		return nil
 	}
-	out := new(DeploymentFreezerStatus)
+	out := new(PreemptedFreeze)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeploymentTargetRef) DeepCopyInto(out *DeploymentTargetRef) {
+func (in *Propagation) DeepCopyInto(out *Propagation) {
 	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentTargetRef.
-func (in *DeploymentTargetRef) DeepCopy() *DeploymentTargetRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Propagation.
+func (in *Propagation) DeepCopy() *Propagation {
 	if in == nil {
 		return nil
 	}
-	out := new(DeploymentTargetRef)
+	out := new(Propagation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcesFreed) DeepCopyInto(out *ResourcesFreed) {
+	*out = *in
+	out.CPU = in.CPU.DeepCopy()
+	out.Memory = in.Memory.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcesFreed.
+func (in *ResourcesFreed) DeepCopy() *ResourcesFreed {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcesFreed)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreProgress) DeepCopyInto(out *RestoreProgress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreProgress.
+func (in *RestoreProgress) DeepCopy() *RestoreProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryCount) DeepCopyInto(out *RetryCount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryCount.
+func (in *RetryCount) DeepCopy() *RetryCount {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaledObjectSnapshot) DeepCopyInto(out *ScaledObjectSnapshot) {
+	*out = *in
+	if in.Paused != nil {
+		in, out := &in.Paused, &out.Paused
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledObjectSnapshot.
+func (in *ScaledObjectSnapshot) DeepCopy() *ScaledObjectSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaledObjectSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Standby) DeepCopyInto(out *Standby) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Standby.
+func (in *Standby) DeepCopy() *Standby {
+	if in == nil {
+		return nil
+	}
+	out := new(Standby)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StandbyStatus) DeepCopyInto(out *StandbyStatus) {
+	*out = *in
+	if in.OriginalSelector != nil {
+		in, out := &in.OriginalSelector, &out.OriginalSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StandbyStatus.
+func (in *StandbyStatus) DeepCopy() *StandbyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StandbyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -176,3 +1260,78 @@ func (in *StatusTargetRef) DeepCopy() *StatusTargetRef {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnfreezeStrategy) DeepCopyInto(out *UnfreezeStrategy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnfreezeStrategy.
+func (in *UnfreezeStrategy) DeepCopy() *UnfreezeStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(UnfreezeStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnfreezeWindow) DeepCopyInto(out *UnfreezeWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]Weekday, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnfreezeWindow.
+func (in *UnfreezeWindow) DeepCopy() *UnfreezeWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(UnfreezeWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPASnapshot) DeepCopyInto(out *VPASnapshot) {
+	*out = *in
+	if in.UpdateMode != nil {
+		in, out := &in.UpdateMode, &out.UpdateMode
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPASnapshot.
+func (in *VPASnapshot) DeepCopy() *VPASnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(VPASnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WakeOnRequest) DeepCopyInto(out *WakeOnRequest) {
+	*out = *in
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WakeOnRequest.
+func (in *WakeOnRequest) DeepCopy() *WakeOnRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(WakeOnRequest)
+	in.DeepCopyInto(out)
+	return out
+}
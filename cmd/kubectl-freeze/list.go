@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+)
+
+// listCommand prints the DeploymentFreezers of the namespace, or of all namespaces.
+func listCommand() command {
+	var all bool
+	return command{
+		flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&all, "all-namespaces", false, "List the DeploymentFreezers of every namespace.")
+			fs.BoolVar(&all, "A", false, "Shorthand for --all-namespaces.")
+		},
+		run: func(ctx context.Context, p *plugin, args []string) error {
+			if len(args) > 0 {
+				return fmt.Errorf("unexpected arguments %q", args)
+			}
+			var opts []client.ListOption
+			if !all {
+				opts = append(opts, client.InNamespace(p.namespace))
+			}
+			var list freezerv1alpha1.DeploymentFreezerList
+			if err := p.client.List(ctx, &list, opts...); err != nil {
+				return err
+			}
+			return writeList(p.out, list.Items, all)
+		},
+	}
+}
+
+// writeList prints one row per DeploymentFreezer, with the namespace column when listing all
+// namespaces. Notes are printed on one line.
+func writeList(out io.Writer, items []freezerv1alpha1.DeploymentFreezer, namespaces bool) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	header := "NAME\tTARGET\tPHASE\tFREEZE-UNTIL\tNOTES"
+	if namespaces {
+		header = "NAMESPACE\t" + header
+	}
+	fmt.Fprintln(w, header)
+	dash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	for i := range items {
+		dfz := &items[i]
+		until := ""
+		if dfz.Status.FreezeUntil != nil {
+			until = dfz.Status.FreezeUntil.UTC().Format(time.RFC3339)
+		}
+		if namespaces {
+			fmt.Fprintf(w, "%s\t", dfz.Namespace)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", dfz.Name, dash(dfz.TargetName()), dash(string(dfz.Status.Phase)),
+			dash(until), dash(strings.Join(strings.Fields(dfz.Spec.Notes), " ")))
+	}
+	return w.Flush()
+}
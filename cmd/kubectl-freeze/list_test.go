@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+)
+
+func TestList(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	until := metav1.NewTime(time.Date(2025, 1, 1, 2, 0, 0, 0, time.UTC))
+	web := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web-freeze"}}
+	web.Spec.TargetRef.Name = "web"
+	web.Spec.Notes = "CHG-12345\nrelease freeze"
+	web.Status.Phase = freezerv1alpha1.PhaseFrozen
+	web.Status.FreezeUntil = &until
+	batch := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "jobs", Name: "batch-freeze"}}
+	batch.Spec.TargetRef.Name = "batch"
+
+	run := func(t *testing.T, args ...string) string {
+		out := &bytes.Buffer{}
+		p := &plugin{client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(web, batch).Build(), namespace: "shop", out: out}
+		cmd := listCommand()
+		fs := flag.NewFlagSet("list", flag.ContinueOnError)
+		cmd.flags(fs)
+		positional, err := parseInterspersed(fs, args)
+		require.NoError(t, err)
+		require.NoError(t, cmd.run(context.Background(), p, positional))
+		return out.String()
+	}
+
+	t.Run("Namespace_ShowsNotes", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, `NAME        TARGET  PHASE   FREEZE-UNTIL          NOTES
+web-freeze  web     Frozen  2025-01-01T02:00:00Z  CHG-12345 release freeze
+`, run(t))
+	})
+
+	t.Run("AllNamespaces", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, `NAMESPACE  NAME          TARGET  PHASE   FREEZE-UNTIL          NOTES
+jobs       batch-freeze  batch   -       -                     -
+shop       web-freeze    web     Frozen  2025-01-01T02:00:00Z  CHG-12345 release freeze
+`, run(t, "-A"))
+	})
+}
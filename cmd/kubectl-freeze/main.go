@@ -0,0 +1,190 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command kubectl-freeze is a kubectl plugin for working with DeploymentFreezers. Installed on
+// the PATH it runs as `kubectl freeze <command>`.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.).
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+)
+
+const usage = `Usage: kubectl freeze <command> [flags]
+
+Commands:
+  extend NAME --by DURATION   Lengthen the freeze window of a DeploymentFreezer.
+  shorten NAME --by DURATION  Shorten the freeze window of a DeploymentFreezer.
+  cancel NAME                 Delete a DeploymentFreezer; its Deployment is restored right away unless --skip-restore.
+  list [-A]                   List DeploymentFreezers with their phase, unfreeze time and notes.
+  plan -f FILE | -l SELECTOR  Preview the Deployments a manifest or selector would freeze; creates nothing.
+
+Run 'kubectl freeze <command> -h' for the flags of a command.
+`
+
+// errAborted is returned when the user does not confirm a change.
+var errAborted = errors.New("aborted")
+
+// plugin holds what every command needs.
+type plugin struct {
+	client    client.Client
+	namespace string
+	// yes skips the confirmation prompt.
+	yes bool
+	in  *bufio.Reader
+	out io.Writer
+	now func() time.Time
+}
+
+// command runs one subcommand with its positional arguments.
+type command struct {
+	// flags registers the command's own flags.
+	flags func(fs *flag.FlagSet)
+	run   func(ctx context.Context, p *plugin, args []string) error
+}
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, in io.Reader, out io.Writer) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		fmt.Fprint(out, usage)
+		return nil
+	}
+	commands := map[string]command{
+		"extend":  windowCommand(extendWindow),
+		"shorten": windowCommand(shortenWindow),
+		"cancel":  cancelCommand(),
+		"list":    listCommand(),
+		"plan":    planCommand(),
+	}
+	name := args[0]
+	cmd, ok := commands[name]
+	if !ok {
+		return fmt.Errorf("unknown command %q\n\n%s", name, usage)
+	}
+
+	fs := flag.NewFlagSet("kubectl freeze "+name, flag.ContinueOnError)
+	fs.SetOutput(out)
+	var kubeconfig, kubeContext, namespace string
+	var yes bool
+	fs.StringVar(&kubeconfig, "kubeconfig", "", "Path to the kubeconfig file.")
+	fs.StringVar(&kubeContext, "context", "", "The kubeconfig context to use.")
+	fs.StringVar(&namespace, "namespace", "", "Namespace of the DeploymentFreezer; the context's namespace by default.")
+	fs.StringVar(&namespace, "n", "", "Shorthand for --namespace.")
+	fs.BoolVar(&yes, "yes", false, "Apply the change without asking for confirmation.")
+	fs.BoolVar(&yes, "y", false, "Shorthand for --yes.")
+	if cmd.flags != nil {
+		cmd.flags(fs)
+	}
+	positional, err := parseInterspersed(fs, args[1:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = kubeconfig
+	kc := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules,
+		&clientcmd.ConfigOverrides{CurrentContext: kubeContext})
+	if namespace == "" {
+		if namespace, _, err = kc.Namespace(); err != nil {
+			return err
+		}
+	}
+	cfg, err := kc.ClientConfig()
+	if err != nil {
+		return err
+	}
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return err
+	}
+	if err := freezerv1alpha1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+
+	p := &plugin{client: c, namespace: namespace, yes: yes, in: bufio.NewReader(in), out: out, now: time.Now}
+	return cmd.run(ctx, p, positional)
+}
+
+// parseInterspersed parses flags given before and after the positional arguments, as kubectl
+// does, and returns the positional arguments.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// confirm asks the user to confirm the change described by prompt, unless --yes was given.
+func (p *plugin) confirm(prompt string) error {
+	if p.yes {
+		return nil
+	}
+	fmt.Fprintf(p.out, "%s [y/N]: ", prompt)
+	answer, err := p.in.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+	return errAborted
+}
+
+// oneName checks that a command got exactly one DeploymentFreezer name.
+func oneName(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("expected one DeploymentFreezer name, got %d arguments", len(args))
+	}
+	return args[0], nil
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/internal/policy"
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
+)
+
+// planCommand previews the Deployments a manifest, or a label selector, would freeze.
+func planCommand() command {
+	var file, selector string
+	return command{
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&file, "filename", "", "Manifest of DeploymentFreezers, AutoFreezePolicies or NodeFreezes; - reads stdin.")
+			fs.StringVar(&file, "f", "", "Shorthand for --filename.")
+			fs.StringVar(&selector, "selector", "", "Plan a freeze of the Deployments of the namespace matching this label selector.")
+			fs.StringVar(&selector, "l", "", "Shorthand for --selector.")
+		},
+		run: func(ctx context.Context, p *plugin, args []string) error {
+			if len(args) > 0 {
+				return fmt.Errorf("unexpected arguments %q", args)
+			}
+			if (file == "") == (selector == "") {
+				return errors.New("exactly one of --filename and --selector is required")
+			}
+			var sources []planSource
+			if selector != "" {
+				sel, err := labels.Parse(selector)
+				if err != nil {
+					return fmt.Errorf("--selector: %w", err)
+				}
+				sources = []planSource{{
+					name:      "selector " + sel.String(),
+					namespace: p.namespace,
+					selector:  sel,
+				}}
+			} else {
+				var err error
+				if sources, err = p.readManifest(file); err != nil {
+					return err
+				}
+			}
+			rows, err := p.plan(ctx, sources)
+			if err != nil {
+				return err
+			}
+			return writePlan(p.out, rows)
+		},
+	}
+}
+
+// planSource is one object of the manifest, reduced to how it picks Deployments.
+type planSource struct {
+	// name describes the object, e.g. "DeploymentFreezer shop/web-freeze".
+	name      string
+	namespace string
+	// Exactly one of deployment, selector and node is set.
+	deployment string
+	selector   labels.Selector
+	node       string
+	// single requires the selector to pick exactly one Deployment, as spec.targetRef.selector does.
+	single bool
+	// owner is the frozen-by value a DeploymentFreezer would write, without its UID.
+	owner string
+}
+
+// planRow is one Deployment of the plan.
+type planRow struct {
+	source     string
+	namespace  string
+	deployment string
+	replicas   *int32
+	holder     string
+	hpa        string
+	note       string
+}
+
+// readManifest decodes the DeploymentFreezers, AutoFreezePolicies and NodeFreezes of a YAML or
+// JSON file with one or more documents.
+func (p *plugin) readManifest(file string) ([]planSource, error) {
+	var r io.Reader
+	if file == "-" {
+		r = p.in
+	} else {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+
+	decoder := serializer.NewCodecFactory(p.client.Scheme()).UniversalDeserializer()
+	docs := utilyaml.NewYAMLReader(bufio.NewReader(r))
+	var sources []planSource
+	for {
+		doc, err := docs.Read()
+		if errors.Is(err, io.EOF) {
+			return sources, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(string(doc)) == "" {
+			continue
+		}
+		obj, gvk, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		switch o := obj.(type) {
+		case *freezerv1alpha1.DeploymentFreezer:
+			ns := p.namespaceOf(o)
+			src := planSource{
+				name:       fmt.Sprintf("DeploymentFreezer %s/%s", ns, o.Name),
+				namespace:  ns,
+				deployment: o.Spec.TargetRef.Name,
+				owner:      ns + "/" + o.Name,
+			}
+			if o.Spec.TargetRef.Selector != nil {
+				if src.selector, err = metav1.LabelSelectorAsSelector(o.Spec.TargetRef.Selector); err != nil {
+					return nil, fmt.Errorf("%s: %w", src.name, err)
+				}
+				src.single = true
+			}
+			sources = append(sources, src)
+		case *freezerv1alpha1.AutoFreezePolicy:
+			sel, err := metav1.LabelSelectorAsSelector(&o.Spec.Selector)
+			if err != nil {
+				return nil, fmt.Errorf("AutoFreezePolicy %s: %w", o.Name, err)
+			}
+			ns := p.namespaceOf(o)
+			sources = append(sources, planSource{
+				name:      fmt.Sprintf("AutoFreezePolicy %s/%s", ns, o.Name),
+				namespace: ns,
+				selector:  sel,
+			})
+		case *freezerv1alpha1.NodeFreeze:
+			sources = append(sources, planSource{name: "NodeFreeze " + o.Name, node: o.Spec.NodeName})
+		default:
+			return nil, fmt.Errorf("cannot plan a %s; want DeploymentFreezer, AutoFreezePolicy or NodeFreeze", gvk.Kind)
+		}
+	}
+}
+
+func (p *plugin) namespaceOf(obj client.Object) string {
+	if ns := obj.GetNamespace(); ns != "" {
+		return ns
+	}
+	return p.namespace
+}
+
+// plan reads the Deployments each source would freeze. Nothing is written.
+func (p *plugin) plan(ctx context.Context, sources []planSource) ([]planRow, error) {
+	var rows []planRow
+	for _, src := range sources {
+		keys, err := p.deploymentsOf(ctx, src)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", src.name, err)
+		}
+		if len(keys) == 0 {
+			rows = append(rows, planRow{source: src.name, namespace: src.namespace, note: "selects no Deployment"})
+		}
+		if src.single && len(keys) > 1 {
+			note := fmt.Sprintf("selects %d Deployments, the freeze would be Denied", len(keys))
+			rows = append(rows, planRow{source: src.name, namespace: src.namespace, note: note})
+			continue
+		}
+		for _, key := range keys {
+			row, err := p.planDeployment(ctx, src, key)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", src.name, err)
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+// deploymentsOf returns the Deployments src picks, sorted.
+func (p *plugin) deploymentsOf(ctx context.Context, src planSource) ([]types.NamespacedName, error) {
+	switch {
+	case src.deployment != "":
+		return []types.NamespacedName{{Namespace: src.namespace, Name: src.deployment}}, nil
+	case src.selector != nil:
+		var list appsv1.DeploymentList
+		if err := p.client.List(ctx, &list, client.InNamespace(src.namespace),
+			client.MatchingLabelsSelector{Selector: src.selector}); err != nil {
+			return nil, err
+		}
+		keys := make([]types.NamespacedName, 0, len(list.Items))
+		for _, d := range list.Items {
+			keys = append(keys, client.ObjectKeyFromObject(&d))
+		}
+		slices.SortFunc(keys, compareKeys)
+		return keys, nil
+	default:
+		return p.deploymentsOnNode(ctx, src.node)
+	}
+}
+
+// deploymentsOnNode follows the Pods running on node to their Deployments, as NodeFreeze does.
+func (p *plugin) deploymentsOnNode(ctx context.Context, node string) ([]types.NamespacedName, error) {
+	var pods corev1.PodList
+	if err := p.client.List(ctx, &pods, client.MatchingFields{"spec.nodeName": node}); err != nil {
+		return nil, err
+	}
+	var keys []types.NamespacedName
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		ref := metav1.GetControllerOf(&pod)
+		if ref == nil || ref.Kind != "ReplicaSet" {
+			continue
+		}
+		var rs appsv1.ReplicaSet
+		if err := p.client.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: ref.Name}, &rs); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if ref := metav1.GetControllerOf(&rs); ref != nil && ref.Kind == "Deployment" {
+			key := types.NamespacedName{Namespace: pod.Namespace, Name: ref.Name}
+			if !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	slices.SortFunc(keys, compareKeys)
+	return keys, nil
+}
+
+// planDeployment describes what freezing one Deployment would do.
+func (p *plugin) planDeployment(ctx context.Context, src planSource, key types.NamespacedName) (planRow, error) {
+	row := planRow{source: src.name, namespace: key.Namespace, deployment: key.Name}
+	if policy.IsProtected(key.Namespace, nil) {
+		row.note = "protected namespace, refused"
+		return row, nil
+	}
+	var d appsv1.Deployment
+	if err := p.client.Get(ctx, key, &d); err != nil {
+		if apierrors.IsNotFound(err) {
+			row.note = "not found, the freeze would be Aborted"
+			return row, nil
+		}
+		return row, err
+	}
+	row.replicas = ptr.To(ptr.Deref(d.Spec.Replicas, 1))
+	row.holder = freeze.Holder(&d)
+
+	var hpas autoscalingv2.HorizontalPodAutoscalerList
+	if err := p.client.List(ctx, &hpas, client.InNamespace(key.Namespace)); err != nil {
+		return row, err
+	}
+	for _, hpa := range hpas.Items {
+		if ref := hpa.Spec.ScaleTargetRef; ref.Kind == "Deployment" && ref.Name == key.Name {
+			row.hpa = hpa.Name
+			break
+		}
+	}
+
+	switch {
+	case row.holder != "" && (src.owner == "" || !strings.HasPrefix(row.holder+"/", src.owner+"/")):
+		row.note = "held by another freeze, would wait for it"
+	case *row.replicas == 0:
+		row.note = fmt.Sprintf("already at 0 replicas, restored to %d", freeze.DefaultReplicas)
+	case row.hpa != "":
+		row.note = "HPA bounds recorded, left unchanged"
+	}
+	return row, nil
+}
+
+func writePlan(out io.Writer, rows []planRow) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "SOURCE\tNAMESPACE\tDEPLOYMENT\tREPLICAS\tFROZEN-BY\tHPA\tNOTE")
+	dash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	for _, r := range rows {
+		replicas := "-"
+		if r.replicas != nil {
+			replicas = fmt.Sprint(*r.replicas)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.source, dash(r.namespace), dash(r.deployment), replicas, dash(r.holder), dash(r.hpa), dash(r.note))
+	}
+	return w.Flush()
+}
+
+func compareKeys(a, b types.NamespacedName) int {
+	return strings.Compare(a.String(), b.String())
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
+)
+
+func TestPlan(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	deployment := func(name string, replicas int32, lbls map[string]string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: name, Labels: lbls, UID: k8stypes.UID("uid-" + name)},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(replicas)},
+		}
+	}
+	web := deployment("web", 3, map[string]string{"tier": "front"})
+	cart := deployment("cart", 2, map[string]string{"tier": "front"})
+	cart.Annotations = map[string]string{freeze.AnnotationFrozenBy: "shop/other/uid-9"}
+	idle := deployment("idle", 0, nil)
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web"},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: "web"},
+		},
+	}
+	rs := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "shop", Name: "web-abc",
+		OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", UID: web.UID, Controller: ptr.To(true)}},
+	}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "shop", Name: "web-abc-1",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-abc", Controller: ptr.To(true)}},
+		},
+		Spec: corev1.PodSpec{NodeName: "node-1"},
+	}
+
+	newPlugin := func(manifest string) (*plugin, *bytes.Buffer) {
+		out := &bytes.Buffer{}
+		c := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(web, cart, idle, hpa, rs, pod).
+			WithIndex(&corev1.Pod{}, "spec.nodeName", func(o client.Object) []string {
+				return []string{o.(*corev1.Pod).Spec.NodeName}
+			}).Build()
+		return &plugin{client: c, namespace: "shop", in: bufio.NewReader(strings.NewReader(manifest)), out: out}, out
+	}
+
+	t.Run("Manifest_DescribesEveryDeployment", func(t *testing.T) {
+		t.Parallel()
+		p, _ := newPlugin(`apiVersion: apps.boolfixer.dev/v1alpha1
+kind: DeploymentFreezer
+metadata:
+  name: web-freeze
+spec:
+  targetRef:
+    name: web
+---
+apiVersion: apps.boolfixer.dev/v1alpha1
+kind: AutoFreezePolicy
+metadata:
+  name: front
+spec:
+  selector:
+    matchLabels:
+      tier: front
+  durationSeconds: 600
+---
+apiVersion: apps.boolfixer.dev/v1alpha1
+kind: NodeFreeze
+metadata:
+  name: drain
+spec:
+  nodeName: node-1
+---
+apiVersion: apps.boolfixer.dev/v1alpha1
+kind: DeploymentFreezer
+metadata:
+  name: gone
+  namespace: kube-system
+spec:
+  targetRef:
+    name: coredns
+`)
+		sources, err := p.readManifest("-")
+		require.NoError(t, err)
+		rows, err := p.plan(context.Background(), sources)
+		require.NoError(t, err)
+
+		assert.Equal(t, []planRow{
+			{source: "DeploymentFreezer shop/web-freeze", namespace: "shop", deployment: "web",
+				replicas: ptr.To(int32(3)), hpa: "web", note: "HPA bounds recorded, left unchanged"},
+			{source: "AutoFreezePolicy shop/front", namespace: "shop", deployment: "cart",
+				replicas: ptr.To(int32(2)), holder: "shop/other/uid-9", note: "held by another freeze, would wait for it"},
+			{source: "AutoFreezePolicy shop/front", namespace: "shop", deployment: "web",
+				replicas: ptr.To(int32(3)), hpa: "web", note: "HPA bounds recorded, left unchanged"},
+			{source: "NodeFreeze drain", namespace: "shop", deployment: "web",
+				replicas: ptr.To(int32(3)), hpa: "web", note: "HPA bounds recorded, left unchanged"},
+			{source: "DeploymentFreezer kube-system/gone", namespace: "kube-system", deployment: "coredns",
+				note: "protected namespace, refused"},
+		}, rows)
+	})
+
+	t.Run("TargetSelector_MustPickOne", func(t *testing.T) {
+		t.Parallel()
+		p, _ := newPlugin(`apiVersion: apps.boolfixer.dev/v1alpha1
+kind: DeploymentFreezer
+metadata:
+  name: front
+spec:
+  targetRef:
+    selector:
+      matchLabels:
+        tier: front
+---
+apiVersion: apps.boolfixer.dev/v1alpha1
+kind: DeploymentFreezer
+metadata:
+  name: idle
+spec:
+  targetRef:
+    selector:
+      matchExpressions:
+      - {key: tier, operator: DoesNotExist}
+`)
+		sources, err := p.readManifest("-")
+		require.NoError(t, err)
+		rows, err := p.plan(context.Background(), sources)
+		require.NoError(t, err)
+
+		assert.Equal(t, []planRow{
+			{source: "DeploymentFreezer shop/front", namespace: "shop", note: "selects 2 Deployments, the freeze would be Denied"},
+			{source: "DeploymentFreezer shop/idle", namespace: "shop", deployment: "idle",
+				replicas: ptr.To(int32(0)), note: "already at 0 replicas, restored to 1"},
+		}, rows)
+	})
+
+	t.Run("Selector_PrintsTable", func(t *testing.T) {
+		t.Parallel()
+		p, out := newPlugin("")
+		cmd := planCommand()
+		fs := flag.NewFlagSet("plan", flag.ContinueOnError)
+		cmd.flags(fs)
+		args, err := parseInterspersed(fs, []string{"-l", "!tier"})
+		require.NoError(t, err)
+		require.NoError(t, cmd.run(context.Background(), p, args))
+		assert.Equal(t, `SOURCE          NAMESPACE  DEPLOYMENT  REPLICAS  FROZEN-BY  HPA  NOTE
+selector !tier  shop       idle        0         -          -    already at 0 replicas, restored to 1
+`, out.String())
+	})
+
+	t.Run("UnsupportedKind_Rejected", func(t *testing.T) {
+		t.Parallel()
+		p, _ := newPlugin("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: x\n")
+		_, err := p.readManifest("-")
+		assert.ErrorContains(t, err, "cannot plan a ConfigMap")
+	})
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+)
+
+// resize computes the new spec.durationSeconds from the current one and the requested change.
+type resize func(current, by time.Duration) (time.Duration, error)
+
+func extendWindow(current, by time.Duration) (time.Duration, error) {
+	return current + by, nil
+}
+
+func shortenWindow(current, by time.Duration) (time.Duration, error) {
+	if by >= current {
+		return 0, fmt.Errorf("shortening a %s window by %s leaves nothing; use 'kubectl freeze cancel' to end the freeze", current, by)
+	}
+	return current - by, nil
+}
+
+// windowCommand builds extend or shorten: both change spec.durationSeconds, from which the
+// controller moves status.freezeUntil. The window keeps starting when the Deployment was frozen.
+func windowCommand(change resize) command {
+	var by time.Duration
+	return command{
+		flags: func(fs *flag.FlagSet) {
+			fs.DurationVar(&by, "by", 0, "How much to change the freeze window by, e.g. 30m or 2h. Whole seconds.")
+		},
+		run: func(ctx context.Context, p *plugin, args []string) error {
+			name, err := oneName(args)
+			if err != nil {
+				return err
+			}
+			if by < time.Second || by%time.Second != 0 {
+				return fmt.Errorf("--by must be a positive number of whole seconds, got %s", by)
+			}
+			return p.resizeWindow(ctx, name, by, change)
+		},
+	}
+}
+
+func (p *plugin) resizeWindow(ctx context.Context, name string, by time.Duration, change resize) error {
+	dfz, err := p.get(ctx, name)
+	if err != nil {
+		return err
+	}
+	current := dfz.Spec.RequestedDuration()
+	if current <= 0 {
+		return fmt.Errorf("%s has no spec.durationSeconds or spec.duration; set one explicitly", describe(dfz))
+	}
+	next, err := change(current, by)
+	if err != nil {
+		return err
+	}
+
+	prompt := fmt.Sprintf("Change the freeze window of %s from %s to %s", describe(dfz), current, next)
+	if frozenAt, ok := dfz.Status.PhaseTransitionTimes[freezerv1alpha1.PhaseFrozen]; ok {
+		until := frozenAt.Add(next)
+		prompt += fmt.Sprintf(", unfreezing at %s", until.UTC().Format(time.RFC3339))
+		if dfz.Status.FreezeUntil != nil {
+			prompt += fmt.Sprintf(" instead of %s", dfz.Status.FreezeUntil.UTC().Format(time.RFC3339))
+		}
+		if !until.After(p.now()) {
+			prompt += " (now)"
+		}
+	} else {
+		prompt += " (counted from when the Deployment is frozen)"
+	}
+	if err := p.confirm(prompt + "?"); err != nil {
+		return err
+	}
+
+	// The optimistic lock makes sure the new duration is computed from the one just shown.
+	orig := dfz.DeepCopy()
+	dfz.Spec.SetRequestedDuration(next)
+	if err := p.client.Patch(ctx, dfz, client.MergeFromWithOptions(orig, client.MergeFromWithOptimisticLock{})); err != nil {
+		if apierrors.IsConflict(err) {
+			return fmt.Errorf("%s changed meanwhile; run the command again", describe(dfz))
+		}
+		return err
+	}
+	fmt.Fprintf(p.out, "%s: freeze window is now %s\n", describe(dfz), next)
+	return nil
+}
+
+// cancelCommand deletes a DeploymentFreezer; its finalizer restores the Deployment unless
+// --skip-restore is given.
+func cancelCommand() command {
+	var skipRestore bool
+	return command{
+		flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&skipRestore, "skip-restore", false,
+				"Leave the Deployment scaled down and only release it, e.g. when it is being decommissioned.")
+		},
+		run: func(ctx context.Context, p *plugin, args []string) error {
+			return cancel(ctx, p, args, skipRestore)
+		},
+	}
+}
+
+// cancel deletes the DeploymentFreezer. With skipRestore it is annotated first, so its finalizer
+// only releases the Deployment.
+func cancel(ctx context.Context, p *plugin, args []string, skipRestore bool) error {
+	name, err := oneName(args)
+	if err != nil {
+		return err
+	}
+	dfz, err := p.get(ctx, name)
+	if err != nil {
+		return err
+	}
+	prompt := fmt.Sprintf("Cancel %s and restore Deployment %s", describe(dfz), dfz.TargetName())
+	if r := dfz.Status.OriginalReplicas; r != nil {
+		prompt += fmt.Sprintf(" to %d replicas", *r)
+	}
+	prompt += " now?"
+	if skipRestore {
+		prompt = fmt.Sprintf("Cancel %s and leave Deployment %s as it is, without restoring it?",
+			describe(dfz), dfz.TargetName())
+	}
+	if err := p.confirm(prompt); err != nil {
+		return err
+	}
+	if skipRestore {
+		orig := dfz.DeepCopy()
+		if dfz.Annotations == nil {
+			dfz.Annotations = map[string]string{}
+		}
+		dfz.Annotations[freezerv1alpha1.AnnoSkipRestore] = "true"
+		if err := p.client.Patch(ctx, dfz, client.MergeFrom(orig)); err != nil {
+			return err
+		}
+	}
+	if err := p.client.Delete(ctx, dfz, client.Preconditions{UID: &dfz.UID}); err != nil {
+		return err
+	}
+	fmt.Fprintf(p.out, "%s: cancelled\n", describe(dfz))
+	return nil
+}
+
+// get reads a DeploymentFreezer that can still be changed.
+func (p *plugin) get(ctx context.Context, name string) (*freezerv1alpha1.DeploymentFreezer, error) {
+	dfz := &freezerv1alpha1.DeploymentFreezer{}
+	if err := p.client.Get(ctx, client.ObjectKey{Namespace: p.namespace, Name: name}, dfz); err != nil {
+		return nil, err
+	}
+	switch {
+	case !dfz.DeletionTimestamp.IsZero():
+		return nil, errors.New(describe(dfz) + " is being deleted")
+	case finished(dfz):
+		return nil, fmt.Errorf("%s has already finished (%s)", describe(dfz), dfz.Status.Phase)
+	}
+	return dfz, nil
+}
+
+func finished(dfz *freezerv1alpha1.DeploymentFreezer) bool {
+	switch dfz.Status.Phase {
+	case freezerv1alpha1.PhaseCompleted, freezerv1alpha1.PhaseDenied, freezerv1alpha1.PhaseAborted:
+		return true
+	}
+	return false
+}
+
+func describe(dfz *freezerv1alpha1.DeploymentFreezer) string {
+	return fmt.Sprintf("deploymentfreezer %s/%s", dfz.Namespace, dfz.Name)
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"flag"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+)
+
+func TestWindowCommands(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	frozenAt := time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)
+	newDFZ := func() *freezerv1alpha1.DeploymentFreezer {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web-freeze", UID: "uid-1"}}
+		dfz.Spec.TargetRef.Name = "web"
+		dfz.Spec.DurationSeconds = 3600
+		dfz.Status.Phase = freezerv1alpha1.PhaseFrozen
+		dfz.Status.PhaseTransitionTimes = map[freezerv1alpha1.Phase]metav1.Time{freezerv1alpha1.PhaseFrozen: metav1.NewTime(frozenAt)}
+		until := metav1.NewTime(frozenAt.Add(time.Hour))
+		dfz.Status.FreezeUntil = &until
+		return dfz
+	}
+	newPlugin := func(answer string, objs ...client.Object) (*plugin, *bytes.Buffer) {
+		out := &bytes.Buffer{}
+		return &plugin{
+			client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+			namespace: "shop",
+			in:        bufio.NewReader(strings.NewReader(answer)),
+			out:       out,
+			now:       func() time.Time { return frozenAt.Add(10 * time.Minute) },
+		}, out
+	}
+	duration := func(t *testing.T, p *plugin) int64 {
+		var got freezerv1alpha1.DeploymentFreezer
+		require.NoError(t, p.client.Get(context.Background(), client.ObjectKey{Namespace: "shop", Name: "web-freeze"}, &got))
+		return got.Spec.DurationSeconds
+	}
+
+	t.Run("Extend_ShowsNewUnfreezeTime", func(t *testing.T) {
+		t.Parallel()
+		p, out := newPlugin("y\n", newDFZ())
+		require.NoError(t, p.resizeWindow(context.Background(), "web-freeze", 30*time.Minute, extendWindow))
+		assert.Equal(t, int64(5400), duration(t, p))
+		assert.Contains(t, out.String(), "from 1h0m0s to 1h30m0s, unfreezing at 2025-01-01T02:30:00Z instead of 2025-01-01T02:00:00Z?")
+	})
+
+	t.Run("Extend_KeepsDurationString", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ()
+		dfz.Spec.DurationSeconds = 0
+		dfz.Spec.Duration = &metav1.Duration{Duration: time.Hour}
+		p, _ := newPlugin("y\n", dfz)
+		require.NoError(t, p.resizeWindow(context.Background(), "web-freeze", 90*time.Minute, extendWindow))
+
+		var got freezerv1alpha1.DeploymentFreezer
+		require.NoError(t, p.client.Get(context.Background(), client.ObjectKeyFromObject(dfz), &got))
+		assert.Zero(t, got.Spec.DurationSeconds)
+		assert.Equal(t, &metav1.Duration{Duration: 150 * time.Minute}, got.Spec.Duration)
+	})
+
+	t.Run("Shorten_PastNow_Warns", func(t *testing.T) {
+		t.Parallel()
+		p, out := newPlugin("yes\n", newDFZ())
+		require.NoError(t, p.resizeWindow(context.Background(), "web-freeze", 55*time.Minute, shortenWindow))
+		assert.Equal(t, int64(300), duration(t, p))
+		assert.Contains(t, out.String(), "unfreezing at 2025-01-01T01:05:00Z instead of 2025-01-01T02:00:00Z (now)?")
+	})
+
+	t.Run("Shorten_WholeWindow_Rejected", func(t *testing.T) {
+		t.Parallel()
+		p, _ := newPlugin("y\n", newDFZ())
+		err := p.resizeWindow(context.Background(), "web-freeze", time.Hour, shortenWindow)
+		assert.ErrorContains(t, err, "kubectl freeze cancel")
+		assert.Equal(t, int64(3600), duration(t, p))
+	})
+
+	t.Run("NotConfirmed_Unchanged", func(t *testing.T) {
+		t.Parallel()
+		p, _ := newPlugin("\n", newDFZ())
+		err := p.resizeWindow(context.Background(), "web-freeze", time.Hour, extendWindow)
+		assert.ErrorIs(t, err, errAborted)
+		assert.Equal(t, int64(3600), duration(t, p))
+	})
+
+	t.Run("Finished_Rejected", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ()
+		dfz.Status.Phase = freezerv1alpha1.PhaseCompleted
+		p, _ := newPlugin("y\n", dfz)
+		assert.ErrorContains(t, p.resizeWindow(context.Background(), "web-freeze", time.Hour, extendWindow), "already finished")
+		assert.ErrorContains(t, cancel(context.Background(), p, []string{"web-freeze"}, false), "already finished")
+	})
+
+	t.Run("Cancel_Deletes", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ()
+		dfz.Status.OriginalReplicas = ptr.To(int32(3))
+		p, out := newPlugin("y\n", dfz)
+		require.NoError(t, cancel(context.Background(), p, []string{"web-freeze"}, false))
+		assert.Contains(t, out.String(), "restore Deployment web to 3 replicas now?")
+		err := p.client.Get(context.Background(), client.ObjectKeyFromObject(dfz), &freezerv1alpha1.DeploymentFreezer{})
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("CancelSkipRestore_AnnotatedThenDeleted", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ()
+		dfz.Finalizers = []string{"apps.boolfixer.dev/finalizer"}
+		p, out := newPlugin("y\n", dfz)
+		require.NoError(t, cancel(context.Background(), p, []string{"web-freeze"}, true))
+		assert.Contains(t, out.String(), "leave Deployment web as it is, without restoring it?")
+		got := &freezerv1alpha1.DeploymentFreezer{}
+		require.NoError(t, p.client.Get(context.Background(), client.ObjectKeyFromObject(dfz), got))
+		assert.Equal(t, "true", got.Annotations[freezerv1alpha1.AnnoSkipRestore])
+		assert.False(t, got.DeletionTimestamp.IsZero())
+	})
+
+	t.Run("ParseInterspersed", func(t *testing.T) {
+		t.Parallel()
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		by := fs.Duration("by", 0, "")
+		yes := fs.Bool("y", false, "")
+		args, err := parseInterspersed(fs, []string{"web-freeze", "--by", "30m", "-y"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"web-freeze"}, args)
+		assert.Equal(t, 30*time.Minute, *by)
+		assert.True(t, *yes)
+	})
+}
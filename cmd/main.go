@@ -17,20 +17,40 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
+	"context"
 	"crypto/tls"
 	"flag"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	// The distroless image has no zoneinfo; unfreeze windows are defined in IANA time zones.
+	_ "time/tzdata"
+
+	"golang.org/x/time/rate"
+	appsv1 "k8s.io/api/apps/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -38,10 +58,24 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	appsv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/internal/activator"
+	"github.com/boolfixer/deployment-freezer/internal/audit"
+	"github.com/boolfixer/deployment-freezer/internal/certs"
 	"github.com/boolfixer/deployment-freezer/internal/controller"
+	"github.com/boolfixer/deployment-freezer/internal/datadog"
+	"github.com/boolfixer/deployment-freezer/internal/health"
+	"github.com/boolfixer/deployment-freezer/internal/httpapi"
+	"github.com/boolfixer/deployment-freezer/internal/ocm"
+	"github.com/boolfixer/deployment-freezer/internal/prometheus"
+	"github.com/boolfixer/deployment-freezer/internal/version"
+	webhookv1 "github.com/boolfixer/deployment-freezer/internal/webhook/v1"
+	webhookv1alpha1 "github.com/boolfixer/deployment-freezer/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
 
+// propagationManifestWork is the --propagation value selecting Open Cluster Management.
+const propagationManifestWork = "manifestwork"
+
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
@@ -59,28 +93,203 @@ func main() {
 	var metricsAddr string
 	var metricsCertPath, metricsCertName, metricsCertKey string
 	var webhookCertPath, webhookCertName, webhookCertKey string
+	var webhookCertSecret, webhookService, webhookConfigurations string
 	var enableLeaderElection bool
+	var leaseDuration, renewDeadline, retryPeriod, gracefulShutdownTimeout time.Duration
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
 	var tlsOpts []func(*tls.Config)
+	var shardCount, shardID int
+	var shardMode string
+	var simulateClockScale float64
+	var simulateClockStart string
+	var dryRun bool
+	var leanRBAC bool
+	var defaultDuration, maxDuration time.Duration
+	var unfreezeRate float64
+	var unfreezeBurst int
+	var maxConcurrentUnfreezes int
+	var killSwitch string
+	var protectedNamespaces string
+	var watchNamespaces string
+	var eventPolicy controller.EventPolicy
+	var syncPeriod, resyncInterval time.Duration
+	var apiOpts managementAPIOptions
+	var enableAutoFreeze bool
+	var deploymentLabelSelector string
+	var activatorAddr string
+	var activatorWait time.Duration
+	var activatorRate float64
+	var activatorMaxWaiting int
+	var prometheusURL string
+	var stateConfigMap string
+	var auditOpts auditOptions
+	var datadogOpts datadogOptions
+	var propagation string
+	var blockRolloutRestart bool
+	var driftActorAnnotation string
+	var messageCatalogFile string
+	var targetNotFoundPatience time.Duration
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.DurationVar(&leaseDuration, "leader-elect-lease-duration", 15*time.Second,
+		"How long a standby replica waits before taking over a lease that is no longer renewed.")
+	flag.DurationVar(&renewDeadline, "leader-elect-renew-deadline", 10*time.Second,
+		"How long the leader keeps retrying to renew its lease before giving up leadership.")
+	flag.DurationVar(&retryPeriod, "leader-elect-retry-period", 2*time.Second,
+		"How often replicas try to acquire or renew the lease.")
+	flag.DurationVar(&gracefulShutdownTimeout, "graceful-shutdown-timeout", 30*time.Second,
+		"How long in-flight reconciles may run on shutdown before the leader lease is released.")
 	flag.BoolVar(&secureMetrics, "metrics-secure", true,
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.StringVar(&webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
 	flag.StringVar(&webhookCertName, "webhook-cert-name", "tls.crt", "The name of the webhook certificate file.")
 	flag.StringVar(&webhookCertKey, "webhook-cert-key", "tls.key", "The name of the webhook key file.")
+	flag.StringVar(&webhookCertSecret, "webhook-cert-secret", "",
+		"namespace/name of a Secret the webhook serving certificate and its CA are generated into and rotated "+
+			"from, for clusters without cert-manager. The CA is injected into --webhook-configurations. "+
+			"Cannot be combined with --webhook-cert-path.")
+	flag.StringVar(&webhookService, "webhook-service", "deployment-freezer-webhook-service",
+		"Name of the webhook Service, in the namespace of --webhook-cert-secret, the generated certificate is issued for.")
+	flag.StringVar(&webhookConfigurations, "webhook-configurations",
+		"deployment-freezer-mutating-webhook-configuration,deployment-freezer-validating-webhook-configuration",
+		"Comma-separated mutating and validating webhook configurations the generated CA is injected into.")
 	flag.StringVar(&metricsCertPath, "metrics-cert-path", "",
 		"The directory that contains the metrics server certificate.")
 	flag.StringVar(&metricsCertName, "metrics-cert-name", "tls.crt", "The name of the metrics server certificate file.")
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.IntVar(&shardCount, "shard-count", 1,
+		"Total number of controller shards. Each shard reconciles a disjoint subset of DeploymentFreezers.")
+	flag.IntVar(&shardID, "shard-id", -1,
+		"Shard handled by this replica, in [0, shard-count). "+
+			"If negative, it is derived from the ordinal suffix of the pod hostname (StatefulSet).")
+	flag.StringVar(&shardMode, "shard-mode", controller.ShardModeNamespace,
+		"How DeploymentFreezers are assigned to shards: 'namespace' (hash of the namespace) "+
+			"or 'label' (value of the apps.boolfixer.dev/shard label, falling back to the namespace hash when unset).")
+	flag.Float64Var(&simulateClockScale, "simulate-clock-scale", 0,
+		"Simulation mode: run the controller against a fake clock advancing this many simulated seconds per "+
+			"real second (e.g. 60 turns a 1h freeze into 1m). 0 uses the real clock.")
+	flag.StringVar(&simulateClockStart, "simulate-clock-start", "",
+		"Simulation mode: RFC3339 start time of the fake clock. Defaults to the current time.")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"Plan mode: send the freeze of every new DeploymentFreezer with server-side dry-run and report the "+
+			"planned changes in its status instead of applying them. Freezes already started are still "+
+			"unfrozen, restored and released for real.")
+	flag.BoolVar(&leanRBAC, "lean-rbac", false,
+		"Only scale Deployments through the deployments/scale subresource and send metadata-only patches, "+
+			"so the controller needs no update rights on Deployments (see config/lean). "+
+			"spec.pauseRollout is not supported in this mode.")
+	flag.DurationVar(&defaultDuration, "default-duration", time.Hour,
+		"Freeze duration used when a DeploymentFreezer does not set spec.durationSeconds.")
+	flag.DurationVar(&maxDuration, "max-duration", 0,
+		"Maximum freeze duration. Longer DeploymentFreezers are rejected at admission and capped by the "+
+			"controller. 0 means unlimited.")
+	flag.Float64Var(&unfreezeRate, "unfreeze-rate", 0,
+		"Maximum number of Deployments per second that start scaling back up, shared by all DeploymentFreezers "+
+			"handled by this replica. 0 means unlimited.")
+	flag.IntVar(&unfreezeBurst, "unfreeze-burst", 10,
+		"Number of Deployments that may start scaling back up at once before --unfreeze-rate applies.")
+	flag.IntVar(&maxConcurrentUnfreezes, "max-concurrent-unfreezes", 0,
+		"Maximum number of DeploymentFreezers in the cluster restoring at once, from the scale-up until the "+
+			"target is available again. 0 means unlimited.")
+	flag.StringVar(&killSwitch, "kill-switch-configmap", "",
+		"namespace/name of a ConfigMap acting as kill switch: while its '"+controller.KillSwitchKey+
+			"' key is 'true' no DeploymentFreezer starts scaling down; restores continue. Empty disables it.")
+	flag.StringVar(&protectedNamespaces, "protected-namespaces", "",
+		"Comma-separated namespaces whose Deployments can never be frozen. kube-system is always protected.")
+	flag.StringVar(&watchNamespaces, "watch-namespaces", "",
+		"Comma-separated namespaces the controller watches and freezes Deployments in. Empty watches all namespaces.")
+	flag.StringVar(&eventPolicy.Verbosity, "events", controller.EventsAll,
+		"Which DeploymentFreezer events are recorded: '"+controller.EventsAll+"' or '"+controller.EventsErrors+
+			"' (Warning events only).")
+	flag.DurationVar(&eventPolicy.AggregationWindow, "event-aggregation-window", 5*time.Minute,
+		"Identical Warning events of a DeploymentFreezer within this window are counted instead of recorded, "+
+			"and the count is added to the next one. 0 records every event.")
+	flag.DurationVar(&syncPeriod, "sync-period", 10*time.Hour,
+		"How often the informers replay every cached object to the controllers as an update. The "+
+			"DeploymentFreezer watches filter most of these out; see --resync-interval.")
+	flag.DurationVar(&resyncInterval, "resync-interval", 10*time.Minute,
+		"How often the leader enqueues every DeploymentFreezer still in progress, so it recovers from missed "+
+			"watch events. 0 only does so when it starts leading.")
+	flag.BoolVar(&blockRolloutRestart, "block-rollout-restart", false,
+		"Serve the Deployment webhook that refuses `kubectl rollout restart` of frozen Deployments. "+
+			"Without it a restart is only reported on the DeploymentFreezer.")
+	flag.DurationVar(&targetNotFoundPatience, "target-not-found-patience", 0,
+		"How long a Pending DeploymentFreezer waits for its missing target to be created before it is Aborted. "+
+			"0 aborts it at once.")
+	flag.StringVar(&messageCatalogFile, "message-catalog", "",
+		"YAML file of templates replacing DeploymentFreezer condition and event messages by reason, "+
+			"such as a mounted ConfigMap key. Read at startup.")
+	flag.StringVar(&driftActorAnnotation, "drift-actor-annotation", "",
+		"Deployment annotation naming the user behind its last change, as stamped by an admission policy. "+
+			"Reported with replica drift next to the field manager. Empty disables it.")
+	flag.BoolVar(&enableAutoFreeze, "enable-auto-freeze", false,
+		"Create a DeploymentFreezer for every Deployment annotated with "+controller.AnnoFreezeFor+
+			" and delete it when the annotation is removed.")
+	flag.StringVar(&prometheusURL, "prometheus-url", "",
+		"Prometheus server evaluating AutoFreezePolicy metric triggers, e.g. http://prometheus.monitoring:9090. "+
+			"Empty leaves metric triggers unevaluated.")
+	flag.StringVar(&stateConfigMap, "state-configmap", "",
+		"Name of a ConfigMap mirroring the active freezes of each namespace, for consumers without access to "+
+			"DeploymentFreezers. Empty disables it.")
+	flag.StringVar(&deploymentLabelSelector, "deployment-label-selector", "",
+		"Label selector restricting the Deployments the controller caches and can freeze. Empty caches all.")
+	flag.StringVar(&apiOpts.addr, "api-bind-address", "0",
+		"The address the HTTP management API binds to. Use 0 to disable it. Callers authenticate with their "+
+			"Kubernetes bearer token and need RBAC rights on deploymentfreezers.")
+	flag.StringVar(&apiOpts.certPath, "api-cert-path", "",
+		"The directory that contains the management API certificate. Required unless --api-bind-address is "+
+			"a loopback address.")
+	flag.StringVar(&apiOpts.certName, "api-cert-name", "tls.crt", "The name of the management API certificate file.")
+	flag.StringVar(&apiOpts.certKey, "api-cert-key", "tls.key", "The name of the management API key file.")
+	flag.StringVar(&apiOpts.hookConfigFile, "ci-hook-config", "",
+		"File mapping CI events to Deployments. Enables the CI webhook receiver on the management API.")
+	flag.StringVar(&apiOpts.hookSecretFile, "ci-hook-secret-file", "",
+		"File holding the secret CI webhooks are signed with (HMAC-SHA256). Required with --ci-hook-config.")
+	flag.StringVar(&apiOpts.alertConfigFile, "alertmanager-config", "",
+		"File mapping Alertmanager alerts to Deployments. Enables the Alertmanager receiver on the management API.")
+	flag.StringVar(&apiOpts.alertTokenFile, "alertmanager-token-file", "",
+		"File holding the bearer token Alertmanager sends to its receiver. Required with --alertmanager-config.")
+	flag.StringVar(&activatorAddr, "activator-bind-address", "0",
+		"The address the wake-on-request activator binds to. Use 0 to disable it.")
+	flag.DurationVar(&activatorWait, "activator-wait", 30*time.Second,
+		"How long the activator holds a request while its Deployment wakes before answering 503.")
+	flag.Float64Var(&activatorRate, "activator-rate", 20,
+		"Requests per second the activator serves; more get a 429. 0 means unlimited.")
+	flag.IntVar(&activatorMaxWaiting, "activator-max-waiting", 100,
+		"Requests the activator holds at once while Deployments wake; more get a 503. 0 means unlimited.")
+	flag.StringVar(&auditOpts.url, "audit-url", "",
+		"Endpoint a JSON record of every DeploymentFreezer phase transition is POSTed to. Empty disables the audit export.")
+	flag.StringVar(&auditOpts.tokenFile, "audit-token-file", "",
+		"File holding the bearer token sent to --audit-url.")
+	flag.StringVar(&auditOpts.bufferDir, "audit-buffer-dir", "",
+		"Directory audit records are buffered in until --audit-url accepts them. Required with --audit-url; "+
+			"mount a volume there to keep buffered records across pod restarts.")
+	flag.IntVar(&auditOpts.bufferSize, "audit-buffer-size", 10000,
+		"Maximum number of buffered audit records; the oldest are dropped beyond it.")
+	flag.StringVar(&datadogOpts.apiKeyFile, "datadog-api-key-file", "",
+		"File holding the Datadog API key, e.g. mounted from a Secret. Enables muting the Datadog monitors "+
+			"of frozen Deployments.")
+	flag.StringVar(&datadogOpts.appKeyFile, "datadog-app-key-file", "",
+		"File holding the Datadog application key. Required with --datadog-api-key-file.")
+	flag.StringVar(&datadogOpts.site, "datadog-site", datadog.DefaultSite,
+		"The Datadog site, e.g. datadoghq.eu or us5.datadoghq.com.")
+	flag.StringVar(&datadogOpts.monitorTags, "datadog-monitor-tags", "kube_namespace:{namespace},kube_deployment:{deployment}",
+		"Comma-separated tags a Datadog monitor must all carry to be muted while a Deployment is frozen. "+
+			"{namespace}, {deployment} and {name} are replaced with those of the DeploymentFreezer.")
+	flag.StringVar(&datadogOpts.scope, "datadog-mute-scope", "",
+		"Scope the mute is restricted to, with the same placeholders as --datadog-monitor-tags, e.g. "+
+			"kube_deployment:{deployment}. Empty mutes the whole monitor.")
+	flag.StringVar(&propagation, "propagation", "",
+		"How DeploymentFreezers with spec.propagation reach their managed clusters: "+propagationManifestWork+
+			" wraps them in Open Cluster Management ManifestWorks. Empty denies them.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -89,6 +298,157 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if maxDuration > 0 && defaultDuration > maxDuration {
+		setupLog.Error(fmt.Errorf("--default-duration %s exceeds --max-duration %s", defaultDuration, maxDuration),
+			"invalid duration configuration")
+		os.Exit(1)
+	}
+
+	if propagation != "" && propagation != propagationManifestWork {
+		setupLog.Error(fmt.Errorf("unknown --propagation %q", propagation), "invalid propagation configuration")
+		os.Exit(1)
+	}
+
+	if renewDeadline >= leaseDuration || retryPeriod >= renewDeadline {
+		setupLog.Error(fmt.Errorf("need --leader-elect-retry-period %s < --leader-elect-renew-deadline %s "+
+			"< --leader-elect-lease-duration %s", retryPeriod, renewDeadline, leaseDuration),
+			"invalid leader election configuration")
+		os.Exit(1)
+	}
+
+	var unfreezeLimiter *rate.Limiter
+	if unfreezeRate > 0 {
+		if unfreezeBurst < 1 {
+			setupLog.Error(fmt.Errorf("--unfreeze-burst must be at least 1, got %d", unfreezeBurst),
+				"invalid unfreeze rate configuration")
+			os.Exit(1)
+		}
+		unfreezeLimiter = rate.NewLimiter(rate.Limit(unfreezeRate), unfreezeBurst)
+	}
+	if maxConcurrentUnfreezes < 0 {
+		setupLog.Error(fmt.Errorf("--max-concurrent-unfreezes must not be negative, got %d", maxConcurrentUnfreezes),
+			"invalid unfreeze rate configuration")
+		os.Exit(1)
+	}
+
+	protected := splitList(protectedNamespaces)
+	killSwitchRef, err := parseNamespacedName(killSwitch)
+	if err != nil {
+		setupLog.Error(err, "invalid --kill-switch-configmap")
+		os.Exit(1)
+	}
+
+	watched, err := parseWatchNamespaces(watchNamespaces)
+	if err != nil {
+		setupLog.Error(err, "invalid --watch-namespaces")
+		os.Exit(1)
+	}
+	if err := eventPolicy.Validate(); err != nil {
+		setupLog.Error(err, "invalid event configuration")
+		os.Exit(1)
+	}
+	if messageCatalogFile != "" {
+		data, err := os.ReadFile(messageCatalogFile)
+		if err != nil {
+			setupLog.Error(err, "unable to read the message catalog")
+			os.Exit(1)
+		}
+		catalog, err := controller.LoadMessageCatalog(data)
+		if err != nil {
+			setupLog.Error(err, "invalid message catalog", "file", messageCatalogFile)
+			os.Exit(1)
+		}
+		controller.SetMessageCatalog(catalog)
+	}
+	if resyncInterval < 0 {
+		setupLog.Error(fmt.Errorf("--resync-interval must not be negative, got %s", resyncInterval), "invalid resync interval")
+		os.Exit(1)
+	}
+	if syncPeriod <= 0 {
+		setupLog.Error(fmt.Errorf("--sync-period must be positive, got %s", syncPeriod), "invalid sync period")
+		os.Exit(1)
+	}
+
+	deploymentSelector, err := labels.Parse(deploymentLabelSelector)
+	if err != nil {
+		setupLog.Error(err, "invalid --deployment-label-selector")
+		os.Exit(1)
+	}
+
+	shard, err := resolveShard(shardCount, shardID, shardMode)
+	if err != nil {
+		setupLog.Error(err, "invalid sharding configuration")
+		os.Exit(1)
+	}
+	leaderElectionID := "293dcfd6.boolfixer.dev"
+	// Cached objects never need their managedFields; Deployments, by far the most numerous,
+	// also lose the `kubectl apply` annotation. Pods, only cached once an AutoFreezePolicy
+	// checks for crash loops, keep little more than their container statuses.
+	cacheOptions := cache.Options{
+		DefaultTransform: cache.TransformStripManagedFields(),
+		ByObject: map[client.Object]cache.ByObject{
+			&appsv1.Deployment{}: {Transform: controller.StripDeployment()},
+			&corev1.Pod{}:        {Transform: controller.StripPod()},
+		},
+		SyncPeriod: &syncPeriod,
+	}
+	if len(watched) > 0 {
+		// Namespaced objects outside these namespaces never reach the cache, so they are not reconciled.
+		setupLog.Info("restricting the watched namespaces", "namespaces", watchNamespaces)
+		cacheOptions.DefaultNamespaces = watched
+	}
+	var shardNamespaces map[string]cache.Config
+	// shardedFreezers is set when the cache only holds the DeploymentFreezers of this shard.
+	shardedFreezers := false
+	if shard.Enabled() {
+		setupLog.Info("sharding enabled", "shard-id", shard.ID, "shard-count", shard.Count, "shard-mode", shard.Mode)
+		// Every shard elects its own leader so shards reconcile in parallel.
+		leaderElectionID = fmt.Sprintf("shard-%d.%s", shard.ID, leaderElectionID)
+		switch {
+		case shard.Mode == controller.ShardModeLabel:
+			// Only cache DeploymentFreezers this shard may own: labeled for it, or without a
+			// shard label and left to the namespace hash.
+			selector, err := shard.LabelSelector()
+			if err != nil {
+				setupLog.Error(err, "invalid sharding configuration")
+				os.Exit(1)
+			}
+			cacheOptions.ByObject[&appsv1alpha1.DeploymentFreezer{}] = cache.ByObject{Label: selector}
+			shardedFreezers = true
+		case shard.ID != 0:
+			// Only cache the watched namespaces hashing to this shard; shard 0 keeps every
+			// DeploymentFreezer for the cluster report. Without --watch-namespaces the
+			// namespaces are not known up front and the predicates filter instead.
+			if shardNamespaces = shard.OwnedNamespaces(watched); shardNamespaces != nil {
+				cacheOptions.ByObject[&appsv1alpha1.DeploymentFreezer{}] = cache.ByObject{Namespaces: shardNamespaces}
+				shardedFreezers = true
+			}
+		}
+	}
+
+	if !deploymentSelector.Empty() {
+		// Only cache the Deployments that may be frozen; the others never reach the informer.
+		setupLog.Info("restricting the Deployment cache", "selector", deploymentSelector.String())
+		cacheOptions.ByObject[&appsv1.Deployment{}] = cache.ByObject{
+			Namespaces: shardNamespaces,
+			Label:      deploymentSelector,
+			Transform:  controller.StripDeployment(),
+		}
+	} else if shardNamespaces != nil {
+		cacheOptions.ByObject[&appsv1.Deployment{}] = cache.ByObject{
+			Namespaces: shardNamespaces,
+			Transform:  controller.StripDeployment(),
+		}
+	}
+
+	if killSwitchRef.Name != "" {
+		// Only cache the kill switch ConfigMap, not every ConfigMap in the cluster.
+		cacheOptions.ByObject[&corev1.ConfigMap{}] = cache.ByObject{
+			Namespaces: map[string]cache.Config{killSwitchRef.Namespace: {}},
+			Field:      fields.OneTermEqualSelector("metadata.name", killSwitchRef.Name),
+		}
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -129,6 +489,29 @@ func main() {
 		})
 	}
 
+	var certRotator *certs.Rotator
+	if webhookCertSecret != "" {
+		if len(webhookCertPath) > 0 {
+			setupLog.Error(nil, "--webhook-cert-secret cannot be combined with --webhook-cert-path")
+			os.Exit(1)
+		}
+		secretRef, err := parseNamespacedName(webhookCertSecret)
+		if err != nil {
+			setupLog.Error(err, "invalid --webhook-cert-secret")
+			os.Exit(1)
+		}
+		setupLog.Info("Managing the webhook certificate", "secret", secretRef, "service", webhookService)
+		service := types.NamespacedName{Namespace: secretRef.Namespace, Name: webhookService}
+		certRotator = &certs.Rotator{
+			Secret:                secretRef,
+			DNSNames:              certs.ServiceDNSNames(service),
+			WebhookConfigurations: splitList(webhookConfigurations),
+		}
+		webhookTLSOpts = append(webhookTLSOpts, func(config *tls.Config) {
+			config.GetCertificate = certRotator.GetCertificate
+		})
+	}
+
 	webhookServer := webhook.NewServer(webhook.Options{
 		TLSOpts: webhookTLSOpts,
 	})
@@ -183,32 +566,180 @@ func main() {
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
+		Cache:                  cacheOptions,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "293dcfd6.boolfixer.dev",
-		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
-		// when the Manager ends. This requires the binary to immediately end when the
-		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
-		// speeds up voluntary leader transitions as the new leader don't have to wait
-		// LeaseDuration time first.
-		//
-		// In the default scaffold provided, the program ends immediately after
-		// the manager stops, so would be fine to enable this option. However,
-		// if you are doing or is intended to do any operation such as perform cleanups
-		// after the manager stops then its usage might be unsafe.
-		// LeaderElectionReleaseOnCancel: true,
+		LeaderElectionID:       leaderElectionID,
+		LeaseDuration:          &leaseDuration,
+		RenewDeadline:          &renewDeadline,
+		RetryPeriod:            &retryPeriod,
+		// The binary exits as soon as the manager stops, so the lease can be released right away
+		// and the next leader does not wait out LeaseDuration. In-flight reconciles finish first,
+		// within GracefulShutdownTimeout, and write their status even though their context ends.
+		LeaderElectionReleaseOnCancel: true,
+		GracefulShutdownTimeout:       &gracefulShutdownTimeout,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	var clk clock.Clock = clock.RealClock{}
+	if simulateClockScale > 0 {
+		start := time.Now().UTC()
+		if simulateClockStart != "" {
+			if start, err = time.Parse(time.RFC3339, simulateClockStart); err != nil {
+				setupLog.Error(err, "invalid --simulate-clock-start")
+				os.Exit(1)
+			}
+		}
+		simClock := controller.NewSimulatedClock(start, simulateClockScale)
+		if err := mgr.Add(simClock); err != nil {
+			setupLog.Error(err, "unable to add simulated clock to manager")
+			os.Exit(1)
+		}
+		setupLog.Info("simulation mode enabled", "start", start, "scale", simulateClockScale)
+		clk = simClock
+	}
+
+	var hooks controller.TransitionHooks
+	if auditOpts.url != "" {
+		hook, err := setupAudit(mgr, auditOpts, clk)
+		if err != nil {
+			setupLog.Error(err, "unable to set up the audit export")
+			os.Exit(1)
+		}
+		hooks.Post = append(hooks.Post, hook)
+	}
+	if datadogOpts.apiKeyFile != "" {
+		hook, err := setupDatadog(datadogOpts)
+		if err != nil {
+			setupLog.Error(err, "unable to set up Datadog monitor muting")
+			os.Exit(1)
+		}
+		hooks.Post = append(hooks.Post, hook)
+	}
+
 	if err := (&controller.DeploymentFreezerReconciler{
+		Client:                 mgr.GetClient(),
+		Scheme:                 mgr.GetScheme(),
+		Clock:                  clk,
+		Shard:                  shard,
+		DryRun:                 dryRun,
+		LeanRBAC:               leanRBAC,
+		DefaultDuration:        defaultDuration,
+		MaxDuration:            maxDuration,
+		UnfreezeLimiter:        unfreezeLimiter,
+		MaxConcurrentUnfreezes: maxConcurrentUnfreezes,
+		KillSwitch:             killSwitchRef,
+		ProtectedNamespaces:    protected,
+		DeploymentSelector:     deploymentSelector,
+		DriftActorAnnotation:   driftActorAnnotation,
+		TargetNotFoundPatience: targetNotFoundPatience,
+		ResyncInterval:         resyncInterval,
+		Events:                 eventPolicy,
+		Hooks:                  hooks,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DeploymentFreezer")
+		os.Exit(1)
+	}
+	if enableAutoFreeze {
+		if err := (&controller.AutoFreezeReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+			Shard:  shard,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "AutoFreeze")
+			os.Exit(1)
+		}
+	}
+	if err := (&controller.NodeFreezeReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
+		Clock:  clk,
+		Shard:  shard,
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "DeploymentFreezer")
+		setupLog.Error(err, "unable to create controller", "controller", "NodeFreeze")
+		os.Exit(1)
+	}
+	propagations := &controller.PropagationReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Clock:  clk,
+		Shard:  shard,
+	}
+	if propagation == propagationManifestWork {
+		propagations.Propagator = &ocm.ManifestWorks{Client: mgr.GetClient()}
+	}
+	if err := propagations.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Propagation")
 		os.Exit(1)
 	}
+	autoFreezePolicies := &controller.AutoFreezePolicyReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Clock:  clk,
+		Shard:  shard,
+	}
+	if prometheusURL != "" {
+		autoFreezePolicies.Metrics = &prometheus.Client{URL: prometheusURL}
+	}
+	if err := autoFreezePolicies.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AutoFreezePolicy")
+		os.Exit(1)
+	}
+	// The report needs every DeploymentFreezer in the cache: only one shard maintains it,
+	// and not in label mode where each shard caches its own DeploymentFreezers only.
+	if !shard.Enabled() || (shard.ID == 0 && shard.Mode == controller.ShardModeNamespace) {
+		if err := (&controller.ClusterFreezeReportReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+			Clock:  clk,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ClusterFreezeReport")
+			os.Exit(1)
+		}
+	}
+	if stateConfigMap != "" {
+		// A namespace's ConfigMap needs all of its DeploymentFreezers, which label sharding splits.
+		if shard.Enabled() && shard.Mode != controller.ShardModeNamespace {
+			setupLog.Error(fmt.Errorf("--state-configmap needs --shard-mode=%s", controller.ShardModeNamespace),
+				"invalid state ConfigMap configuration")
+			os.Exit(1)
+		}
+		if errs := validation.IsDNS1123Subdomain(stateConfigMap); len(errs) > 0 {
+			setupLog.Error(fmt.Errorf("--state-configmap %q: %s", stateConfigMap, strings.Join(errs, ", ")),
+				"invalid state ConfigMap configuration")
+			os.Exit(1)
+		}
+		if err := (&controller.StateMirrorReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+			Name:   stateConfigMap,
+			Shard:  shard,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "StateMirror")
+			os.Exit(1)
+		}
+	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err := webhookv1alpha1.SetupDeploymentFreezerWebhookWithManager(
+			mgr, defaultDuration, maxDuration, protected, deploymentSelector, selfUsername(mgr.GetClient()),
+		); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DeploymentFreezer")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupNodeFreezeWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "NodeFreeze")
+			os.Exit(1)
+		}
+		if blockRolloutRestart {
+			if err := webhookv1.SetupDeploymentWebhookWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create webhook", "webhook", "Deployment")
+				os.Exit(1)
+			}
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if metricsCertWatcher != nil {
@@ -227,18 +758,345 @@ func main() {
 		}
 	}
 
+	if certRotator != nil {
+		certRotator.Client, certRotator.Reader = mgr.GetClient(), mgr.GetAPIReader()
+		if err := mgr.Add(certRotator); err != nil {
+			setupLog.Error(err, "unable to add webhook certificate rotator to manager")
+			os.Exit(1)
+		}
+	}
+
+	if apiOpts.addr != "0" {
+		if err := setupManagementAPI(mgr, apiOpts, tlsOpts, defaultDuration); err != nil {
+			setupLog.Error(err, "unable to set up the management API")
+			os.Exit(1)
+		}
+	}
+
+	if activatorAddr != "0" {
+		reader := client.Reader(mgr.GetClient())
+		if shardedFreezers {
+			// A wake request may reach any replica, so look up the DeploymentFreezers of every shard.
+			wakeCache, err := activator.NewCache(context.Background(), mgr.GetConfig(), cache.Options{
+				Scheme:            mgr.GetScheme(),
+				Mapper:            mgr.GetRESTMapper(),
+				DefaultNamespaces: watched,
+				DefaultTransform:  cache.TransformStripManagedFields(),
+			})
+			if err == nil {
+				err = mgr.Add(wakeCache)
+			}
+			if err != nil {
+				setupLog.Error(err, "unable to set up the activator")
+				os.Exit(1)
+			}
+			reader = wakeCache
+		} else if err := mgr.GetFieldIndexer().IndexField(context.Background(), &appsv1alpha1.DeploymentFreezer{},
+			activator.WakeHostField, activator.WakeHosts); err != nil {
+			setupLog.Error(err, "unable to set up the activator")
+			os.Exit(1)
+		}
+		var limiter *rate.Limiter
+		if activatorRate > 0 {
+			limiter = rate.NewLimiter(rate.Limit(activatorRate), max(1, int(2*activatorRate)))
+		}
+		if err := mgr.Add(&activator.Activator{
+			Client:       mgr.GetClient(),
+			Reader:       reader,
+			BindAddress:  activatorAddr,
+			Wait:         activatorWait,
+			PollInterval: time.Second,
+			Limiter:      limiter,
+			MaxWaiting:   activatorMaxWaiting,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up the activator")
+			os.Exit(1)
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
 	}
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+	var leaderLease types.NamespacedName
+	if enableLeaderElection {
+		leaderLease = types.NamespacedName{Namespace: os.Getenv("POD_NAMESPACE"), Name: leaderElectionID}
+	}
+	loadWebhookCert := health.CertificateFiles(
+		filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs", "tls.crt"),
+		filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs", "tls.key"),
+	)
+	switch {
+	case certRotator != nil:
+		loadWebhookCert = func() (*tls.Certificate, error) { return certRotator.GetCertificate(nil) }
+	case webhookCertWatcher != nil:
+		loadWebhookCert = func() (*tls.Certificate, error) { return webhookCertWatcher.GetCertificate(nil) }
+	}
+	webhooks := os.Getenv("ENABLE_WEBHOOKS") != "false"
+	if err := setupReadyzChecks(mgr, loadWebhookCert, webhooks, leaderLease); err != nil {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
 
-	setupLog.Info("starting manager")
+	build := version.Get()
+	setupLog.Info("starting manager", "version", build.Version, "gitCommit", build.GitCommit, "goVersion", build.GoVersion)
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
+
+// setupReadyzChecks adds the readiness checks: the informer caches synced, the field index of
+// DeploymentFreezers by target registered, the webhook server serving a valid certificate, and a
+// live leader. The Lease is only checked with leader election and a known namespace. The liveness
+// check stays a ping: none of these is fixed by restarting the container.
+func setupReadyzChecks(
+	mgr ctrl.Manager,
+	loadWebhookCert func() (*tls.Certificate, error),
+	webhooks bool,
+	leaderLease types.NamespacedName,
+) error {
+	checks := map[string]healthz.Checker{
+		"readyz":     healthz.Ping,
+		"cache-sync": health.CacheSynced(mgr.GetCache()),
+		"field-index": health.FieldIndexed(mgr.GetCache(), &appsv1alpha1.DeploymentFreezerList{},
+			controller.TargetNameIndex),
+	}
+	if webhooks {
+		checks["webhook"] = mgr.GetWebhookServer().StartedChecker()
+		checks["webhook-certificate"] = health.CertificateValid(loadWebhookCert, clock.RealClock{})
+	}
+	if leaderLease.Namespace != "" {
+		checks["leader"] = health.LeaderElected(mgr.Elected(), mgr.GetAPIReader(), leaderLease, clock.RealClock{})
+	}
+	for name, check := range checks {
+		if err := mgr.AddReadyzCheck(name, check); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// managementAPIOptions holds the flags of the HTTP management API and its webhook receivers.
+type managementAPIOptions struct {
+	addr                            string
+	certPath, certName, certKey     string
+	hookConfigFile, hookSecretFile  string
+	alertConfigFile, alertTokenFile string
+}
+
+// setupManagementAPI adds the HTTP management API, and the watcher of its certificate, to the manager.
+// Plain HTTP is only served on a loopback address.
+func setupManagementAPI(
+	mgr ctrl.Manager,
+	o managementAPIOptions,
+	tlsOpts []func(*tls.Config),
+	defaultDuration time.Duration,
+) error {
+	server := &httpapi.Server{
+		Client:          mgr.GetClient(),
+		Reader:          mgr.GetAPIReader(),
+		BindAddress:     o.addr,
+		DefaultDuration: defaultDuration,
+	}
+	if o.hookConfigFile != "" {
+		if o.hookSecretFile == "" {
+			return fmt.Errorf("--ci-hook-secret-file is required with --ci-hook-config")
+		}
+		data, err := os.ReadFile(o.hookConfigFile)
+		if err != nil {
+			return err
+		}
+		if server.Hooks, err = httpapi.LoadHookConfig(data); err != nil {
+			return fmt.Errorf("%s: %w", o.hookConfigFile, err)
+		}
+		if server.HookSecret, err = readSecretFile(o.hookSecretFile); err != nil {
+			return err
+		}
+	}
+	if o.alertConfigFile != "" {
+		if o.alertTokenFile == "" {
+			return fmt.Errorf("--alertmanager-token-file is required with --alertmanager-config")
+		}
+		data, err := os.ReadFile(o.alertConfigFile)
+		if err != nil {
+			return err
+		}
+		if server.Alerts, err = httpapi.LoadAlertConfig(data); err != nil {
+			return fmt.Errorf("%s: %w", o.alertConfigFile, err)
+		}
+		token, err := readSecretFile(o.alertTokenFile)
+		if err != nil {
+			return err
+		}
+		server.AlertToken = string(token)
+	}
+
+	if o.certPath != "" {
+		certWatcher, err := certwatcher.New(filepath.Join(o.certPath, o.certName), filepath.Join(o.certPath, o.certKey))
+		if err != nil {
+			return err
+		}
+		if err := mgr.Add(certWatcher); err != nil {
+			return err
+		}
+		server.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12, GetCertificate: certWatcher.GetCertificate}
+		for _, opt := range tlsOpts {
+			opt(server.TLSConfig)
+		}
+	} else if !httpapi.Loopback(o.addr) {
+		return fmt.Errorf("--api-cert-path is required unless --api-bind-address is a loopback address")
+	}
+	return mgr.Add(server)
+}
+
+// auditOptions holds the flags of the audit export.
+type auditOptions struct {
+	url, tokenFile, bufferDir string
+	bufferSize                int
+}
+
+// setupAudit adds the audit exporter to the manager and returns the hook that feeds it the phase
+// transitions once they are written to status.
+func setupAudit(mgr ctrl.Manager, o auditOptions, clk clock.PassiveClock) (controller.TransitionHook, error) {
+	if o.bufferDir == "" {
+		return nil, fmt.Errorf("--audit-buffer-dir is required with --audit-url")
+	}
+	if o.bufferSize < 1 {
+		return nil, fmt.Errorf("--audit-buffer-size must be at least 1, got %d", o.bufferSize)
+	}
+	exporter := &audit.Exporter{URL: o.url, Dir: o.bufferDir, MaxRecords: o.bufferSize}
+	if o.tokenFile != "" {
+		token, err := readSecretFile(o.tokenFile)
+		if err != nil {
+			return nil, err
+		}
+		exporter.Token = string(token)
+	}
+	if err := mgr.Add(exporter); err != nil {
+		return nil, err
+	}
+	return func(_ context.Context, dfz *appsv1alpha1.DeploymentFreezer, t controller.Transition) {
+		exporter.Export(audit.NewRecord(dfz, t.From, t.To, clk.Now()))
+	}, nil
+}
+
+// datadogOptions holds the flags of the Datadog monitor muting.
+type datadogOptions struct {
+	apiKeyFile, appKeyFile   string
+	site, monitorTags, scope string
+}
+
+// setupDatadog returns the hook muting the Datadog monitors of Deployments while they are frozen.
+func setupDatadog(o datadogOptions) (controller.TransitionHook, error) {
+	if o.appKeyFile == "" {
+		return nil, fmt.Errorf("--datadog-app-key-file is required with --datadog-api-key-file")
+	}
+	apiKey, err := readSecretFile(o.apiKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	appKey, err := readSecretFile(o.appKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	muter := &datadog.Muter{
+		Site:        o.site,
+		APIKey:      string(apiKey),
+		AppKey:      string(appKey),
+		MonitorTags: splitList(o.monitorTags),
+		Scope:       o.scope,
+	}
+	if err := muter.Validate(); err != nil {
+		return nil, err
+	}
+	return func(_ context.Context, dfz *appsv1alpha1.DeploymentFreezer, t controller.Transition) {
+		muter.OnTransition(dfz, t.From, t.To)
+	}, nil
+}
+
+// selfUsername returns the user the manager authenticates as, so the webhook recognizes the
+// DeploymentFreezers the controllers create on behalf of someone else. It is empty if the API
+// server cannot tell; those DeploymentFreezers then have the manager as their requester.
+func selfUsername(c client.Client) string {
+	review := &authenticationv1.SelfSubjectReview{}
+	if err := c.Create(context.Background(), review); err != nil {
+		setupLog.Error(err, "unable to determine the manager's own user; "+
+			"DeploymentFreezers it creates are requested by itself")
+		return ""
+	}
+	return review.Status.UserInfo.Username
+}
+
+// readSecretFile reads a token or secret, ignoring surrounding whitespace; an empty file is an error.
+func readSecretFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%s is empty", path)
+	}
+	return data, nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseWatchNamespaces parses --watch-namespaces into the cache's DefaultNamespaces.
+func parseWatchNamespaces(s string) (map[string]cache.Config, error) {
+	namespaces := map[string]cache.Config{}
+	for _, ns := range splitList(s) {
+		if errs := validation.IsDNS1123Label(ns); len(errs) > 0 {
+			return nil, fmt.Errorf("namespace %q: %s", ns, strings.Join(errs, ", "))
+		}
+		namespaces[ns] = cache.Config{}
+	}
+	return namespaces, nil
+}
+
+// parseNamespacedName parses a namespace/name object reference flag; empty yields the zero value.
+func parseNamespacedName(ref string) (types.NamespacedName, error) {
+	if ref == "" {
+		return types.NamespacedName{}, nil
+	}
+	ns, name, ok := strings.Cut(ref, "/")
+	if !ok || ns == "" || name == "" {
+		return types.NamespacedName{}, fmt.Errorf("expected namespace/name, got %q", ref)
+	}
+	return types.NamespacedName{Namespace: ns, Name: name}, nil
+}
+
+// resolveShard validates the sharding flags. A negative id is taken from the
+// ordinal suffix of the hostname, as assigned to StatefulSet pods.
+func resolveShard(count, id int, mode string) (controller.Shard, error) {
+	if count <= 1 {
+		return controller.Shard{}, nil
+	}
+	if mode != controller.ShardModeNamespace && mode != controller.ShardModeLabel {
+		return controller.Shard{}, fmt.Errorf("unknown shard mode %q", mode)
+	}
+	if id < 0 {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return controller.Shard{}, err
+		}
+		ordinal := hostname[strings.LastIndex(hostname, "-")+1:]
+		if id, err = strconv.Atoi(ordinal); err != nil {
+			return controller.Shard{}, fmt.Errorf("cannot derive shard id from hostname %q", hostname)
+		}
+	}
+	if id >= count {
+		return controller.Shard{}, fmt.Errorf("shard id %d out of range [0, %d)", id, count)
+	}
+	return controller.Shard{ID: id, Count: count, Mode: mode}, nil
+}
@@ -0,0 +1,44 @@
+#!/usr/bin/env bash
+# Regenerates the typed clientset, listers, informers and apply configurations under pkg/client
+# from the API types in api/v1alpha1.
+set -o errexit -o nounset -o pipefail
+
+CODE_GENERATOR_VERSION=${CODE_GENERATOR_VERSION:-v0.33.0}
+MODULE=github.com/boolfixer/deployment-freezer
+ROOT=$(cd "$(dirname "${BASH_SOURCE[0]}")/.." && pwd)
+OUT=${ROOT}/pkg/client
+APIS=${MODULE}/api/v1alpha1
+
+gen() {
+	local tool=$1
+	shift
+	go run "k8s.io/code-generator/cmd/${tool}@${CODE_GENERATOR_VERSION}" \
+		--go-header-file "${ROOT}/hack/boilerplate.go.txt" "$@"
+}
+
+rm -rf "${OUT}/applyconfiguration" "${OUT}/clientset" "${OUT}/listers" "${OUT}/informers"
+
+gen applyconfiguration-gen \
+	--output-dir "${OUT}/applyconfiguration" \
+	--output-pkg "${MODULE}/pkg/client/applyconfiguration" \
+	"${APIS}"
+
+gen client-gen \
+	--clientset-name versioned \
+	--input-base "" \
+	--input "${APIS}" \
+	--apply-configuration-package "${MODULE}/pkg/client/applyconfiguration" \
+	--output-dir "${OUT}/clientset" \
+	--output-pkg "${MODULE}/pkg/client/clientset"
+
+gen lister-gen \
+	--output-dir "${OUT}/listers" \
+	--output-pkg "${MODULE}/pkg/client/listers" \
+	"${APIS}"
+
+gen informer-gen \
+	--versioned-clientset-package "${MODULE}/pkg/client/clientset/versioned" \
+	--listers-package "${MODULE}/pkg/client/listers" \
+	--output-dir "${OUT}/informers" \
+	--output-pkg "${MODULE}/pkg/client/informers" \
+	"${APIS}"
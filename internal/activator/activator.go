@@ -0,0 +1,295 @@
+// Package activator wakes frozen Deployments on demand. Requests routed to it while a Deployment
+// is frozen end the freeze early; each request is held until traffic is restored and then
+// redirected to its own URL, so the retried request reaches the Deployment. A request that comes
+// back after MaxRedirects redirects is refused, so routes that still point at the activator do
+// not loop the client.
+package activator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	readHeaderTimeout = 10 * time.Second
+	shutdownTimeout   = 10 * time.Second
+	// retryAfterSeconds is sent with 503 and 429 responses to requests that waited in vain.
+	retryAfterSeconds = "5"
+	// RedirectParam counts the redirects of a request in its query, so a loop can be detected.
+	RedirectParam = "freezer-wake-redirects"
+	// DefaultMaxRedirects is used when MaxRedirects is 0.
+	DefaultMaxRedirects = 3
+)
+
+var log = logf.Log.WithName("activator")
+
+// Activator serves the wake-on-request endpoint. It implements manager.Runnable and runs on every replica.
+type Activator struct {
+	// Client sets the wake-requested annotation on DeploymentFreezers.
+	Client client.Client
+	// Reader reads DeploymentFreezers; it is polled while requests wait, so it should be cached.
+	// It must hold every DeploymentFreezer, not one shard's; see NewCache.
+	Reader client.Reader
+	// BindAddress is the address the activator listens on.
+	BindAddress string
+	// Wait bounds how long a request is held while its Deployment wakes.
+	Wait time.Duration
+	// PollInterval is how often a waiting request re-reads its DeploymentFreezer.
+	PollInterval time.Duration
+	// Now returns the current time; time.Now when nil.
+	Now func() time.Time
+	// Limiter bounds the rate of requests served; requests beyond it get a 429. Nil means unlimited.
+	Limiter *rate.Limiter
+	// MaxWaiting bounds the requests held at once; requests beyond it get a 503. 0 means unlimited.
+	MaxWaiting int
+	// MaxRedirects bounds how often the same request is redirected; DefaultMaxRedirects when 0.
+	MaxRedirects int
+
+	waiting chan struct{}
+}
+
+// Start serves requests until ctx is done.
+func (a *Activator) Start(ctx context.Context) error {
+	if a.MaxWaiting > 0 {
+		a.waiting = make(chan struct{}, a.MaxWaiting)
+	}
+	srv := &http.Server{
+		Addr:              a.BindAddress,
+		Handler:           a,
+		ReadHeaderTimeout: readHeaderTimeout,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		log.Info("serving activator", "address", a.BindAddress)
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+// NeedLeaderElection lets every replica serve requests. It implements manager.LeaderElectionRunnable.
+func (a *Activator) NeedLeaderElection() bool {
+	return false
+}
+
+// ServeHTTP wakes the DeploymentFreezer whose spec.wakeOnRequest lists the request's host and
+// redirects the request to itself once traffic is restored, or answers 503 after Wait.
+func (a *Activator) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if a.Limiter != nil && !a.Limiter.Allow() {
+		w.Header().Set("Retry-After", retryAfterSeconds)
+		http.Error(w, "too many requests", http.StatusTooManyRequests)
+		return
+	}
+	redirects, _ := strconv.Atoi(req.URL.Query().Get(RedirectParam))
+	if redirects >= a.maxRedirects() {
+		unavailable(w, fmt.Sprintf("still routed to the activator after %d redirects", redirects))
+		return
+	}
+	host := req.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	dfz, err := a.find(req.Context(), host)
+	switch {
+	case errors.Is(err, errAmbiguousHost):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		log.Error(err, "failed to look up DeploymentFreezers", "host", host)
+		unavailable(w, "cannot look up the Deployment for this host")
+		return
+	case dfz == nil:
+		http.Error(w, fmt.Sprintf("no Deployment wakes on requests for %s", host), http.StatusNotFound)
+		return
+	}
+	if a.waiting != nil {
+		select {
+		case a.waiting <- struct{}{}:
+			defer func() { <-a.waiting }()
+		default:
+			unavailable(w, "too many requests waiting for Deployments to wake")
+			return
+		}
+	}
+	if err := a.wake(req.Context(), dfz); err != nil {
+		log.Error(err, "failed to wake DeploymentFreezer", "namespace", dfz.Namespace, "name", dfz.Name)
+		unavailable(w, "cannot wake the Deployment")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), a.Wait)
+	defer cancel()
+	key := client.ObjectKeyFromObject(dfz)
+	if err := a.awaitTraffic(ctx, key); err != nil {
+		unavailable(w, "the Deployment is waking up; retry shortly")
+		return
+	}
+	// The routes point back at the Deployment now; the same URL reaches it. 307 keeps the method
+	// and body; the redirect count in the query ends a loop if the routes still lead here.
+	u := *req.URL
+	q := u.Query()
+	q.Set(RedirectParam, strconv.Itoa(redirects+1))
+	u.RawQuery = q.Encode()
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, req, u.RequestURI(), http.StatusTemporaryRedirect)
+}
+
+func (a *Activator) maxRedirects() int {
+	if a.MaxRedirects > 0 {
+		return a.MaxRedirects
+	}
+	return DefaultMaxRedirects
+}
+
+// errAmbiguousHost is returned by find when DeploymentFreezers of several namespaces wake on a host.
+var errAmbiguousHost = errors.New("DeploymentFreezers of several namespaces wake on this host")
+
+// find returns the DeploymentFreezer waking on host. One that has not finished is preferred, so
+// a freeze that just completed still sends its stragglers back to the Deployment. The admission
+// webhook keeps hosts unique among unfinished DeploymentFreezers; should several namespaces still
+// claim host, none of them is woken, so one namespace cannot wake another's Deployment.
+func (a *Activator) find(ctx context.Context, host string) (*freezerv1alpha1.DeploymentFreezer, error) {
+	var list freezerv1alpha1.DeploymentFreezerList
+	if err := a.Reader.List(ctx, &list, client.MatchingFields{WakeHostField: host}); err != nil {
+		return nil, err
+	}
+	var active, found *freezerv1alpha1.DeploymentFreezer
+	for i := range list.Items {
+		dfz := &list.Items[i]
+		if !WakesOn(dfz, host) {
+			continue
+		}
+		if finished(dfz) {
+			found = dfz
+			continue
+		}
+		if active != nil && active.Namespace != dfz.Namespace {
+			return nil, errAmbiguousHost
+		}
+		if active == nil {
+			active = dfz
+		}
+	}
+	if active != nil {
+		return active, nil
+	}
+	return found, nil
+}
+
+// WakeHostField indexes DeploymentFreezers by the hosts of spec.wakeOnRequest.
+const WakeHostField = "spec.wakeOnRequest.hosts"
+
+// NewCache returns a cache of DeploymentFreezers indexed by WakeHostField, to serve as Reader when
+// the manager's cache only holds the DeploymentFreezers of one shard: a wake request may reach
+// any replica. Add it to the manager, which starts it.
+func NewCache(ctx context.Context, cfg *rest.Config, opts cache.Options) (cache.Cache, error) {
+	c, err := cache.New(cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.IndexField(ctx, &freezerv1alpha1.DeploymentFreezer{}, WakeHostField, WakeHosts); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// WakeHosts is the indexer of WakeHostField.
+func WakeHosts(obj client.Object) []string {
+	dfz, ok := obj.(*freezerv1alpha1.DeploymentFreezer)
+	if !ok || dfz.Spec.WakeOnRequest == nil {
+		return nil
+	}
+	return dfz.Spec.WakeOnRequest.Hosts
+}
+
+// WakesOn reports whether dfz wakes on requests for host.
+func WakesOn(dfz *freezerv1alpha1.DeploymentFreezer, host string) bool {
+	return dfz.Spec.WakeOnRequest != nil && slices.Contains(dfz.Spec.WakeOnRequest.Hosts, host)
+}
+
+// wake asks the controller to end a Frozen DeploymentFreezer's freeze. Other phases are left
+// alone: a freeze still in progress is not undone, and one that is unfreezing needs no help.
+func (a *Activator) wake(ctx context.Context, dfz *freezerv1alpha1.DeploymentFreezer) error {
+	if dfz.Status.Phase != freezerv1alpha1.PhaseFrozen || dfz.Annotations[freezerv1alpha1.AnnoWakeRequested] != "" {
+		return nil
+	}
+	now := time.Now
+	if a.Now != nil {
+		now = a.Now
+	}
+	orig := dfz.DeepCopy()
+	if dfz.Annotations == nil {
+		dfz.Annotations = map[string]string{}
+	}
+	dfz.Annotations[freezerv1alpha1.AnnoWakeRequested] = now().UTC().Format(time.RFC3339)
+	if err := a.Client.Patch(ctx, dfz, client.MergeFrom(orig)); err != nil {
+		return err
+	}
+	log.Info("woke DeploymentFreezer", "namespace", dfz.Namespace, "name", dfz.Name)
+	return nil
+}
+
+// awaitTraffic polls the DeploymentFreezer until traffic goes back to the Deployment.
+func (a *Activator) awaitTraffic(ctx context.Context, key types.NamespacedName) error {
+	return wait.PollUntilContextCancel(ctx, a.PollInterval, true, func(ctx context.Context) (bool, error) {
+		var dfz freezerv1alpha1.DeploymentFreezer
+		if err := a.Reader.Get(ctx, key, &dfz); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		return restored(&dfz), nil
+	})
+}
+
+// restored reports whether the DeploymentFreezer no longer diverts traffic: it finished, or it
+// is unfreezing and the Traffic condition says its routes are back.
+func restored(dfz *freezerv1alpha1.DeploymentFreezer) bool {
+	if finished(dfz) {
+		return true
+	}
+	if dfz.Status.Phase != freezerv1alpha1.PhaseUnfreezing {
+		return false
+	}
+	for _, c := range dfz.Status.Conditions {
+		if c.Type == freezerv1alpha1.ConditionTypeTraffic {
+			return c.Reason == freezerv1alpha1.ConditionReasonTrafficRestored ||
+				c.Reason == freezerv1alpha1.ConditionReasonNoRoute
+		}
+	}
+	return false
+}
+
+func finished(dfz *freezerv1alpha1.DeploymentFreezer) bool {
+	switch dfz.Status.Phase {
+	case freezerv1alpha1.PhaseCompleted, freezerv1alpha1.PhaseDenied, freezerv1alpha1.PhaseAborted:
+		return true
+	}
+	return false
+}
+
+func unavailable(w http.ResponseWriter, msg string) {
+	w.Header().Set("Retry-After", retryAfterSeconds)
+	http.Error(w, msg, http.StatusServiceUnavailable)
+}
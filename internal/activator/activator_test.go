@@ -0,0 +1,175 @@
+package activator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestActivator(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	newDFZ := func(name string, phase freezerv1alpha1.Phase) *freezerv1alpha1.DeploymentFreezer {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "dev", Name: name}}
+		dfz.Spec.TargetRef.Name = "web"
+		dfz.Spec.WakeOnRequest = &freezerv1alpha1.WakeOnRequest{Hosts: []string{"web.dev.example.com"}}
+		dfz.Status.Phase = phase
+		return dfz
+	}
+	newActivator := func(funcs interceptor.Funcs, objs ...client.Object) *Activator {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).WithInterceptorFuncs(funcs).
+			WithIndex(&freezerv1alpha1.DeploymentFreezer{}, WakeHostField, WakeHosts).Build()
+		return &Activator{
+			Client:       c,
+			Reader:       c,
+			Wait:         200 * time.Millisecond,
+			PollInterval: 10 * time.Millisecond,
+			Now:          func() time.Time { return now },
+		}
+	}
+	serveURL := func(a *Activator, url string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, url, nil)
+		rec := httptest.NewRecorder()
+		a.ServeHTTP(rec, req)
+		return rec
+	}
+	serve := func(a *Activator, host string) *httptest.ResponseRecorder {
+		return serveURL(a, "http://"+host+"/cart?id=1")
+	}
+	getDFZ := func(t *testing.T, a *Activator, name string) *freezerv1alpha1.DeploymentFreezer {
+		var dfz freezerv1alpha1.DeploymentFreezer
+		require.NoError(t, a.Reader.Get(context.Background(), client.ObjectKey{Namespace: "dev", Name: name}, &dfz))
+		return &dfz
+	}
+
+	t.Run("UnknownHost_NotFound", func(t *testing.T) {
+		t.Parallel()
+		a := newActivator(interceptor.Funcs{}, newDFZ("dfz", freezerv1alpha1.PhaseFrozen))
+		assert.Equal(t, http.StatusNotFound, serve(a, "other.example.com").Code)
+	})
+
+	t.Run("Frozen_WakesAndRedirectsOnceRestored", func(t *testing.T) {
+		t.Parallel()
+		// The controller is simulated by finishing the unfreeze on the first read after the wake.
+		a := newActivator(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if err := c.Get(ctx, key, obj, opts...); err != nil {
+					return err
+				}
+				if dfz := obj.(*freezerv1alpha1.DeploymentFreezer); dfz.Annotations[freezerv1alpha1.AnnoWakeRequested] != "" {
+					dfz.Status.Phase = freezerv1alpha1.PhaseUnfreezing
+					dfz.Status.Conditions = []freezerv1alpha1.Condition{{
+						Type:   freezerv1alpha1.ConditionTypeTraffic,
+						Status: freezerv1alpha1.ConditionStatusFalse,
+						Reason: freezerv1alpha1.ConditionReasonTrafficRestored,
+					}}
+				}
+				return nil
+			},
+		}, newDFZ("dfz", freezerv1alpha1.PhaseFrozen))
+
+		rec := serve(a, "web.dev.example.com:80")
+		assert.Equal(t, http.StatusTemporaryRedirect, rec.Code)
+		assert.Equal(t, "/cart?freezer-wake-redirects=1&id=1", rec.Header().Get("Location"))
+		assert.Equal(t, now.Format(time.RFC3339), getDFZ(t, a, "dfz").Annotations[freezerv1alpha1.AnnoWakeRequested])
+	})
+
+	t.Run("StillDiverted_Unavailable", func(t *testing.T) {
+		t.Parallel()
+		a := newActivator(interceptor.Funcs{}, newDFZ("dfz", freezerv1alpha1.PhaseFrozen))
+		rec := serve(a, "web.dev.example.com")
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, retryAfterSeconds, rec.Header().Get("Retry-After"))
+	})
+
+	t.Run("Freezing_NotWoken", func(t *testing.T) {
+		t.Parallel()
+		a := newActivator(interceptor.Funcs{}, newDFZ("dfz", freezerv1alpha1.PhaseFreezing))
+		assert.Equal(t, http.StatusServiceUnavailable, serve(a, "web.dev.example.com").Code)
+		assert.NotContains(t, getDFZ(t, a, "dfz").Annotations, freezerv1alpha1.AnnoWakeRequested)
+	})
+
+	t.Run("CompletedFreeze_Redirects", func(t *testing.T) {
+		t.Parallel()
+		a := newActivator(interceptor.Funcs{}, newDFZ("old", freezerv1alpha1.PhaseCompleted))
+		assert.Equal(t, http.StatusTemporaryRedirect, serve(a, "web.dev.example.com").Code)
+	})
+
+	t.Run("RedirectLoop_Capped", func(t *testing.T) {
+		t.Parallel()
+		a := newActivator(interceptor.Funcs{}, newDFZ("old", freezerv1alpha1.PhaseCompleted))
+		rec := serveURL(a, "http://web.dev.example.com/cart?freezer-wake-redirects=2")
+		assert.Equal(t, http.StatusTemporaryRedirect, rec.Code)
+		assert.Equal(t, "/cart?freezer-wake-redirects=3", rec.Header().Get("Location"))
+
+		rec = serveURL(a, "http://web.dev.example.com/cart?freezer-wake-redirects=3")
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+
+	t.Run("HostOfSeveralNamespaces_Conflict", func(t *testing.T) {
+		t.Parallel()
+		other := newDFZ("dfz", freezerv1alpha1.PhaseFrozen)
+		other.Namespace = "other"
+		a := newActivator(interceptor.Funcs{}, newDFZ("dfz", freezerv1alpha1.PhaseFrozen), other)
+		assert.Equal(t, http.StatusConflict, serve(a, "web.dev.example.com").Code)
+		assert.NotContains(t, getDFZ(t, a, "dfz").Annotations, freezerv1alpha1.AnnoWakeRequested)
+	})
+
+	t.Run("RateLimited_TooManyRequests", func(t *testing.T) {
+		t.Parallel()
+		a := newActivator(interceptor.Funcs{}, newDFZ("old", freezerv1alpha1.PhaseCompleted))
+		a.Limiter = rate.NewLimiter(rate.Every(time.Hour), 1)
+		assert.Equal(t, http.StatusTemporaryRedirect, serve(a, "web.dev.example.com").Code)
+		rec := serve(a, "web.dev.example.com")
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, retryAfterSeconds, rec.Header().Get("Retry-After"))
+	})
+
+	t.Run("TooManyWaiting_Unavailable", func(t *testing.T) {
+		t.Parallel()
+		a := newActivator(interceptor.Funcs{}, newDFZ("old", freezerv1alpha1.PhaseCompleted))
+		a.waiting = make(chan struct{}, 1)
+		a.waiting <- struct{}{}
+		assert.Equal(t, http.StatusServiceUnavailable, serve(a, "web.dev.example.com").Code)
+	})
+
+	t.Run("ShardedCache_OtherShardsWoken", func(t *testing.T) {
+		t.Parallel()
+		api := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newDFZ("dfz", freezerv1alpha1.PhaseFrozen)).
+			WithIndex(&freezerv1alpha1.DeploymentFreezer{}, WakeHostField, WakeHosts).Build()
+		// The manager's cache of a shard that does not own namespace dev.
+		shard := interceptor.NewClient(api, interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if err := c.List(ctx, list, opts...); err != nil {
+					return err
+				}
+				if l, ok := list.(*freezerv1alpha1.DeploymentFreezerList); ok {
+					l.Items = nil
+				}
+				return nil
+			},
+		})
+		a := &Activator{Client: shard, Reader: shard, Wait: 50 * time.Millisecond, PollInterval: 10 * time.Millisecond}
+		require.Equal(t, http.StatusNotFound, serve(a, "web.dev.example.com").Code)
+
+		a.Reader = api
+		assert.Equal(t, http.StatusServiceUnavailable, serve(a, "web.dev.example.com").Code)
+		assert.NotEmpty(t, getDFZ(t, a, "dfz").Annotations[freezerv1alpha1.AnnoWakeRequested])
+	})
+}
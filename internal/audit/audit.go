@@ -0,0 +1,80 @@
+// Package audit exports a JSON record of every DeploymentFreezer phase transition to an external
+// HTTP endpoint, for compliance pipelines that cannot consume Kubernetes events. Records are
+// spooled to disk before they are sent and only removed once the endpoint accepted them, so an
+// unreachable endpoint or a controller restart loses none.
+package audit
+
+import (
+	"fmt"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/internal/policy"
+	"github.com/boolfixer/deployment-freezer/internal/version"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Record is the JSON document sent for one phase transition.
+type Record struct {
+	// ID identifies the transition; a record retried after a lost response is sent with the same
+	// ID, so the endpoint can drop duplicates.
+	ID   string    `json:"id"`
+	Time time.Time `json:"time"`
+	// Who: the identity that created the DeploymentFreezer, as recorded by the admission webhook.
+	RequestedBy       string   `json:"requestedBy,omitempty"`
+	RequestedByGroups []string `json:"requestedByGroups,omitempty"`
+	// What: the DeploymentFreezer and its Deployment.
+	Namespace  string    `json:"namespace"`
+	Name       string    `json:"name"`
+	UID        types.UID `json:"uid"`
+	Deployment string    `json:"deployment"`
+	// The phase left, empty for a new DeploymentFreezer, and the phase entered.
+	From freezerv1alpha1.Phase `json:"from,omitempty"`
+	To   freezerv1alpha1.Phase `json:"to"`
+	// Outcome: the reason, code and message of the condition changed last, and the last
+	// failed operation, if any.
+	Reason           freezerv1alpha1.ConditionReason `json:"reason,omitempty"`
+	Code             freezerv1alpha1.ConditionCode   `json:"code,omitempty"`
+	Message          string                          `json:"message,omitempty"`
+	Error            string                          `json:"error,omitempty"`
+	OriginalReplicas *int32                          `json:"originalReplicas,omitempty"`
+	FreezeUntil      *time.Time                      `json:"freezeUntil,omitempty"`
+	// ControllerVersion is the build of the controller that made the transition.
+	ControllerVersion string `json:"controllerVersion"`
+}
+
+// NewRecord describes the transition of dfz from one phase to another at now.
+func NewRecord(dfz *freezerv1alpha1.DeploymentFreezer, from, to freezerv1alpha1.Phase, now time.Time) Record {
+	requester := policy.RequesterFromAnnotations(dfz)
+	rec := Record{
+		ID:                fmt.Sprintf("%s/%s/%d", dfz.UID, to, now.UnixNano()),
+		Time:              now.UTC(),
+		RequestedBy:       requester.Username,
+		RequestedByGroups: requester.Groups,
+		Namespace:         dfz.Namespace,
+		Name:              dfz.Name,
+		UID:               dfz.UID,
+		Deployment:        dfz.TargetName(),
+		From:              from,
+		To:                to,
+		OriginalReplicas:  dfz.Status.OriginalReplicas,
+		ControllerVersion: version.Get().String(),
+	}
+	if dfz.Status.FreezeUntil != nil {
+		until := dfz.Status.FreezeUntil.UTC()
+		rec.FreezeUntil = &until
+	}
+	var latest *freezerv1alpha1.Condition
+	for i, c := range dfz.Status.Conditions {
+		if latest == nil || latest.LastTransitionTime.Before(&c.LastTransitionTime) {
+			latest = &dfz.Status.Conditions[i]
+		}
+	}
+	if latest != nil {
+		rec.Reason, rec.Code, rec.Message = latest.Reason, latest.Code, latest.Message
+	}
+	if e := dfz.Status.LastError; e != nil {
+		rec.Error = e.Operation + ": " + e.Message
+	}
+	return rec
+}
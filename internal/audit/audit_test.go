@@ -0,0 +1,158 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/internal/policy"
+	"github.com/boolfixer/deployment-freezer/internal/version"
+)
+
+func TestAudit(t *testing.T) {
+	now := time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)
+
+	// endpoint answers the requests with codes in turn, then with 200, and keeps the accepted records.
+	type endpoint struct {
+		mu       sync.Mutex
+		codes    []int
+		accepted []Record
+		auth     string
+	}
+	newEndpoint := func(t *testing.T, codes ...int) (*endpoint, *httptest.Server) {
+		ep := &endpoint{codes: codes}
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			ep.mu.Lock()
+			defer ep.mu.Unlock()
+			ep.auth = req.Header.Get("Authorization")
+			if len(ep.codes) > 0 {
+				code := ep.codes[0]
+				ep.codes = ep.codes[1:]
+				if code != http.StatusOK {
+					w.WriteHeader(code)
+					return
+				}
+			}
+			var rec Record
+			if err := json.NewDecoder(req.Body).Decode(&rec); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			ep.accepted = append(ep.accepted, rec)
+		}))
+		t.Cleanup(srv.Close)
+		return ep, srv
+	}
+	ids := func(ep *endpoint) []string {
+		ep.mu.Lock()
+		defer ep.mu.Unlock()
+		var ids []string
+		for _, rec := range ep.accepted {
+			ids = append(ids, rec.ID)
+		}
+		return ids
+	}
+	start := func(t *testing.T, e *Exporter) {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() { done <- e.Start(ctx) }()
+		t.Cleanup(func() {
+			cancel()
+			require.NoError(t, <-done)
+		})
+	}
+	spooled := func(t *testing.T, e *Exporter) int {
+		entries, err := os.ReadDir(e.Dir)
+		require.NoError(t, err)
+		return len(entries)
+	}
+
+	t.Run("NewRecord_WhoWhatOutcome", func(t *testing.T) {
+		t.Parallel()
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web-freeze", UID: "uid-1"}}
+		policy.Requester{Username: "alice", Groups: []string{"sre"}}.Annotate(dfz)
+		dfz.Spec.TargetRef.Name = "web"
+		dfz.Status.OriginalReplicas = ptr.To(int32(3))
+		dfz.Status.Conditions = []freezerv1alpha1.Condition{
+			{Type: freezerv1alpha1.ConditionTypeTargetFound, Reason: freezerv1alpha1.ConditionReasonFound,
+				LastTransitionTime: metav1.NewTime(now.Add(-time.Minute))},
+			{Type: freezerv1alpha1.ConditionTypeFreezeProgress, Reason: freezerv1alpha1.ConditionReasonScaledToZero,
+				Message: "scaled to 0", LastTransitionTime: metav1.NewTime(now)},
+		}
+
+		rec := NewRecord(dfz, freezerv1alpha1.PhaseFreezing, freezerv1alpha1.PhaseFrozen, now)
+		assert.Equal(t, Record{
+			ID: "uid-1/Frozen/1735693200000000000", Time: now,
+			RequestedBy: "alice", RequestedByGroups: []string{"sre"},
+			Namespace: "shop", Name: "web-freeze", UID: "uid-1", Deployment: "web",
+			From: freezerv1alpha1.PhaseFreezing, To: freezerv1alpha1.PhaseFrozen,
+			Reason: freezerv1alpha1.ConditionReasonScaledToZero, Message: "scaled to 0",
+			OriginalReplicas: ptr.To(int32(3)), ControllerVersion: version.Get().String(),
+		}, rec)
+	})
+
+	t.Run("Send_InOrderWithToken", func(t *testing.T) {
+		t.Parallel()
+		ep, srv := newEndpoint(t)
+		e := &Exporter{URL: srv.URL, Token: "s3cret", Dir: t.TempDir()}
+		e.Export(Record{ID: "a"})
+		e.Export(Record{ID: "b"})
+		start(t, e)
+		e.Export(Record{ID: "c"})
+
+		require.Eventually(t, func() bool { return len(ids(ep)) == 3 }, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, []string{"a", "b", "c"}, ids(ep))
+		assert.Equal(t, "Bearer s3cret", ep.auth)
+		require.Eventually(t, func() bool { return spooled(t, e) == 0 }, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Unavailable_RetriedFromDisk", func(t *testing.T) {
+		t.Parallel()
+		ep, srv := newEndpoint(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+		e := &Exporter{URL: srv.URL, Dir: t.TempDir(), MinBackoff: time.Millisecond}
+		e.Export(Record{ID: "a"})
+		e.Export(Record{ID: "b"})
+		assert.Equal(t, 2, spooled(t, e))
+		start(t, e)
+
+		require.Eventually(t, func() bool { return len(ids(ep)) == 2 }, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, []string{"a", "b"}, ids(ep))
+	})
+
+	t.Run("Rejected_Dropped", func(t *testing.T) {
+		t.Parallel()
+		ep, srv := newEndpoint(t, http.StatusBadRequest)
+		e := &Exporter{URL: srv.URL, Dir: t.TempDir()}
+		e.Export(Record{ID: "a"})
+		e.Export(Record{ID: "b"})
+		start(t, e)
+
+		require.Eventually(t, func() bool { return spooled(t, e) == 0 }, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, []string{"b"}, ids(ep))
+	})
+
+	t.Run("FullBuffer_DropsOldest", func(t *testing.T) {
+		t.Parallel()
+		ep, srv := newEndpoint(t)
+		e := &Exporter{URL: srv.URL, Dir: t.TempDir(), MaxRecords: 2}
+		for _, id := range []string{"a", "b", "c"} {
+			e.Export(Record{ID: id})
+		}
+		assert.Equal(t, 2, spooled(t, e))
+		start(t, e)
+
+		require.Eventually(t, func() bool { return len(ids(ep)) == 2 }, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, []string{"b", "c"}, ids(ep))
+	})
+}
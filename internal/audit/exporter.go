@@ -0,0 +1,279 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	defaultMaxRecords = 10000
+	defaultMinBackoff = time.Second
+	defaultMaxBackoff = 5 * time.Minute
+	spoolSuffix       = ".json"
+)
+
+var log = logf.Log.WithName("audit")
+
+var defaultHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+var (
+	// recordsTotal counts audit records by how they left the spool.
+	recordsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "deploymentfreezer_audit_records_total",
+		Help: "Number of audit records, by result: sent, rejected by the endpoint, or dropped from a full buffer.",
+	}, []string{"result"})
+
+	// bufferedRecords is the number of audit records waiting to be sent.
+	bufferedRecords = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "deploymentfreezer_audit_buffered_records",
+		Help: "Number of audit records buffered on disk, waiting to be sent.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(recordsTotal, bufferedRecords)
+}
+
+// Exporter POSTs audit records to an HTTP endpoint, oldest first. Export spools a record to Dir;
+// Start sends the spooled records and retries with exponential backoff while the endpoint fails.
+// It implements manager.Runnable and runs on every replica, so records spooled by a leader that
+// lost its lease are still sent.
+type Exporter struct {
+	// URL of the endpoint each record is POSTed to as application/json.
+	URL string
+	// Token is sent as a bearer token when set.
+	Token string
+	// Dir is the spool directory. Mount a volume there for records to survive a restart of the pod.
+	Dir string
+	// MaxRecords bounds the spool; the oldest records are dropped beyond it. Defaults to 10000.
+	MaxRecords int
+	// MinBackoff and MaxBackoff bound the delay between retries; they default to 1s and 5m.
+	MinBackoff, MaxBackoff time.Duration
+	// HTTP is the client used for requests; defaults to one with a 10s timeout.
+	HTTP *http.Client
+	// Now returns the current time; time.Now when nil.
+	Now func() time.Time
+
+	mu   sync.Mutex
+	seq  uint64
+	once sync.Once
+	wake chan struct{}
+}
+
+// errRejected is returned for a record the endpoint will never accept.
+var errRejected = errors.New("rejected")
+
+// Export spools rec and wakes the sender. A record that cannot be spooled is logged and lost:
+// the transition it describes has already been written and is not retried.
+func (e *Exporter) Export(rec Record) {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		log.Error(err, "failed to encode audit record", "id", rec.ID)
+		return
+	}
+	if err := e.spool(data); err != nil {
+		log.Error(err, "failed to buffer audit record", "id", rec.ID)
+		return
+	}
+	select {
+	case e.wakeCh() <- struct{}{}:
+	default:
+	}
+}
+
+// Start sends spooled records until ctx is done.
+func (e *Exporter) Start(ctx context.Context) error {
+	if err := os.MkdirAll(e.Dir, 0o700); err != nil {
+		return err
+	}
+	if files, err := e.spooled(); err == nil && len(files) > 0 {
+		log.Info("sending audit records buffered before the restart", "records", len(files))
+		bufferedRecords.Set(float64(len(files)))
+	}
+	backoff := e.minBackoff()
+	for {
+		if err := e.flush(ctx); err != nil {
+			log.Error(err, "failed to send audit records, retrying", "after", backoff)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(backoff):
+			}
+			backoff = min(2*backoff, e.maxBackoff())
+			continue
+		}
+		backoff = e.minBackoff()
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-e.wakeCh():
+		}
+	}
+}
+
+// NeedLeaderElection lets every replica drain its spool. It implements manager.LeaderElectionRunnable.
+func (e *Exporter) NeedLeaderElection() bool {
+	return false
+}
+
+// flush sends the spooled records in order and stops at the first one that fails.
+func (e *Exporter) flush(ctx context.Context) error {
+	files, err := e.spooled()
+	if err != nil {
+		return err
+	}
+	for _, name := range files {
+		path := filepath.Join(e.Dir, name)
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			// Dropped from a full spool meanwhile.
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		switch err := e.send(ctx, data); {
+		case errors.Is(err, errRejected):
+			log.Error(err, "audit endpoint rejected a record; dropping it", "record", string(data))
+			recordsTotal.WithLabelValues("rejected").Inc()
+		case err != nil:
+			return err
+		default:
+			recordsTotal.WithLabelValues("sent").Inc()
+		}
+		switch err := os.Remove(path); {
+		case err == nil:
+			bufferedRecords.Dec()
+		case !errors.Is(err, os.ErrNotExist):
+			return err
+		}
+	}
+	return nil
+}
+
+// send POSTs one record. A 4xx answer other than 408 and 429 means the record is invalid and
+// wraps errRejected; anything else but a 2xx is retried.
+func (e *Exporter) send(ctx context.Context, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+e.Token)
+	}
+	httpClient := e.HTTP
+	if httpClient == nil {
+		httpClient = defaultHTTPClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+	switch code := resp.StatusCode; {
+	case code >= 200 && code < 300:
+		return nil
+	case code >= 400 && code < 500 && code != http.StatusRequestTimeout && code != http.StatusTooManyRequests:
+		return fmt.Errorf("%w with HTTP %d: %s", errRejected, code, strings.TrimSpace(string(body)))
+	default:
+		return fmt.Errorf("HTTP %d: %s", code, strings.TrimSpace(string(body)))
+	}
+}
+
+// spool writes a record to a new file of Dir, named so that files sort in the order they were
+// written, and drops the oldest records beyond MaxRecords.
+func (e *Exporter) spool(data []byte) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := os.MkdirAll(e.Dir, 0o700); err != nil {
+		return err
+	}
+	e.seq++
+	name := fmt.Sprintf("%020d-%06d%s", e.now().UnixNano(), e.seq%1000000, spoolSuffix)
+	// Written under a temporary name and renamed, so the sender never reads a partial record.
+	tmp := filepath.Join(e.Dir, "."+name)
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, filepath.Join(e.Dir, name)); err != nil {
+		return err
+	}
+
+	files, err := e.spooled()
+	if err != nil {
+		return err
+	}
+	maxRecords := e.MaxRecords
+	if maxRecords <= 0 {
+		maxRecords = defaultMaxRecords
+	}
+	for _, old := range files[:max(0, len(files)-maxRecords)] {
+		if err := os.Remove(filepath.Join(e.Dir, old)); err == nil {
+			log.Info("audit buffer full; dropped the oldest record", "file", old)
+			recordsTotal.WithLabelValues("dropped").Inc()
+		}
+	}
+	bufferedRecords.Set(float64(min(len(files), maxRecords)))
+	return nil
+}
+
+// spooled lists the record files of Dir, oldest first.
+func (e *Exporter) spooled() ([]string, error) {
+	entries, err := os.ReadDir(e.Dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		if name := entry.Name(); entry.Type().IsRegular() && !strings.HasPrefix(name, ".") &&
+			strings.HasSuffix(name, spoolSuffix) {
+			files = append(files, name)
+		}
+	}
+	slices.Sort(files)
+	return files, nil
+}
+
+func (e *Exporter) wakeCh() chan struct{} {
+	e.once.Do(func() { e.wake = make(chan struct{}, 1) })
+	return e.wake
+}
+
+func (e *Exporter) now() time.Time {
+	if e.Now != nil {
+		return e.Now()
+	}
+	return time.Now()
+}
+
+func (e *Exporter) minBackoff() time.Duration {
+	if e.MinBackoff > 0 {
+		return e.MinBackoff
+	}
+	return defaultMinBackoff
+}
+
+func (e *Exporter) maxBackoff() time.Duration {
+	if e.MaxBackoff > 0 {
+		return e.MaxBackoff
+	}
+	return defaultMaxBackoff
+}
@@ -0,0 +1,189 @@
+package certs
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"maps"
+	"math/big"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	caValidity      = 10 * 365 * 24 * time.Hour
+	servingValidity = 365 * 24 * time.Hour
+	// backdate covers clock skew between the replicas and the API server.
+	backdate = time.Hour
+)
+
+// renew returns the Secret data with the CA and serving certificate in data, renewing those
+// past two thirds of their lifetime, or not matching dnsNames, and reports whether it changed.
+// A renewed CA also renews the serving certificate, and stays in the bundle until it expires.
+func renew(data map[string][]byte, dnsNames []string, now time.Time) (map[string][]byte, bool, error) {
+	out := maps.Clone(data)
+	if out == nil {
+		out = map[string][]byte{}
+	}
+
+	cas := parseCertificates(data[CAKey])
+	caKey, _ := parseKey(data[CAPrivateKey])
+	renewCA := len(cas) == 0 || caKey == nil || due(cas[0], now) || !matches(cas[0], caKey)
+	if renewCA {
+		ca, key, err := newCA(now)
+		if err != nil {
+			return nil, false, err
+		}
+		cas = append([]*x509.Certificate{ca}, cas...)
+		caKey = key
+		if out[CAPrivateKey], err = encodeKey(key); err != nil {
+			return nil, false, err
+		}
+	}
+	// Drop the replaced CAs that expired: nothing they signed is served anymore.
+	current := cas[0]
+	cas = append(cas[:1], slices.DeleteFunc(cas[1:], func(c *x509.Certificate) bool { return now.After(c.NotAfter) })...)
+	out[CAKey] = encodeCertificates(cas)
+
+	serving := parseCertificates(data[corev1.TLSCertKey])
+	servingKey, _ := parseKey(data[corev1.TLSPrivateKeyKey])
+	if renewCA || servingKey == nil || !valid(serving, servingKey, current, dnsNames, now) {
+		cert, key, err := newServingCertificate(current, caKey, dnsNames, now)
+		if err != nil {
+			return nil, false, err
+		}
+		out[corev1.TLSCertKey] = encodeCertificates([]*x509.Certificate{cert})
+		if out[corev1.TLSPrivateKeyKey], err = encodeKey(key); err != nil {
+			return nil, false, err
+		}
+	}
+
+	changed := !maps.EqualFunc(data, out, bytes.Equal)
+	return out, changed, nil
+}
+
+// due reports whether cert is past two thirds of its lifetime at now.
+func due(cert *x509.Certificate, now time.Time) bool {
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	return now.After(cert.NotBefore.Add(lifetime * 2 / 3))
+}
+
+// valid reports whether the serving certificate chain is signed by ca with key, is valid for
+// dnsNames and is not due at now.
+func valid(
+	chain []*x509.Certificate,
+	key *ecdsa.PrivateKey,
+	ca *x509.Certificate,
+	dnsNames []string,
+	now time.Time,
+) bool {
+	if len(chain) == 0 || due(chain[0], now) || !slices.Equal(chain[0].DNSNames, dnsNames) {
+		return false
+	}
+	return matches(chain[0], key) && chain[0].CheckSignatureFrom(ca) == nil
+}
+
+// matches reports whether key is the private key of cert.
+func matches(cert *x509.Certificate, key *ecdsa.PrivateKey) bool {
+	pub, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	return ok && pub.Equal(key.Public())
+}
+
+func newCA(now time.Time) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	tpl := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: fmt.Sprintf("deployment-freezer-webhook-ca@%d", now.Unix())},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	return sign(tpl, nil, nil, now, caValidity)
+}
+
+func newServingCertificate(
+	ca *x509.Certificate,
+	caKey *ecdsa.PrivateKey,
+	dnsNames []string,
+	now time.Time,
+) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	tpl := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:    dnsNames,
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	return sign(tpl, ca, caKey, now, servingValidity)
+}
+
+// sign issues tpl with a new key, signed by parent with parentKey, or self-signed when parent is nil.
+func sign(
+	tpl, parent *x509.Certificate,
+	parentKey *ecdsa.PrivateKey,
+	now time.Time,
+	validity time.Duration,
+) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+	tpl.SerialNumber = serial
+	tpl.NotBefore = now.Add(-backdate)
+	tpl.NotAfter = now.Add(validity)
+	if parent == nil {
+		parent, parentKey = tpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	return cert, key, err
+}
+
+// parseCertificates returns the certificates of a PEM bundle, skipping those it cannot parse.
+func parseCertificates(data []byte) []*x509.Certificate {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		if block, data = pem.Decode(data); block == nil {
+			return certs
+		}
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil && block.Type == "CERTIFICATE" {
+			certs = append(certs, cert)
+		}
+	}
+}
+
+func encodeCertificates(certs []*x509.Certificate) []byte {
+	var buf bytes.Buffer
+	for _, c := range certs {
+		_ = pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: c.Raw})
+	}
+	return buf.Bytes()
+}
+
+func parseKey(data []byte) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM key")
+	}
+	return x509.ParseECPrivateKey(block.Bytes)
+}
+
+func encodeKey(key *ecdsa.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), nil
+}
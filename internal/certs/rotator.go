@@ -0,0 +1,204 @@
+// Package certs generates and rotates the serving certificate of the admission webhooks, for
+// clusters without cert-manager. A self-signed CA and the serving certificate it signs are kept
+// in a Secret shared by the replicas; the CA is injected into the caBundle of the webhook
+// configurations, and the serving certificate is handed to the webhook server from memory.
+package certs
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;create;update
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations;validatingwebhookconfigurations,verbs=get;update
+
+// Keys of the Secret, besides the serving certificate and key under tls.crt and tls.key.
+const (
+	// CAKey holds the CA bundle: the current CA first, then the CAs it replaced until they expire,
+	// so the caBundle still trusts serving certificates they signed.
+	CAKey = "ca.crt"
+	// CAPrivateKey holds the key of the current CA.
+	CAPrivateKey = "ca.key"
+)
+
+const (
+	// DefaultCheckInterval is how often the Secret is checked for a certificate to renew, and
+	// re-read for one renewed by another replica.
+	DefaultCheckInterval = time.Hour
+	// retryInterval is how often a failed sync is retried.
+	retryInterval = 10 * time.Second
+)
+
+var log = logf.Log.WithName("certs")
+
+// Rotator keeps the webhook serving certificate valid. It implements manager.Runnable and runs on
+// every replica; the replicas agree through the Secret, whose updates are optimistic.
+type Rotator struct {
+	// Client writes the Secret and the webhook configurations.
+	Client client.Client
+	// Reader reads them. It should not be cached: caching Secrets would cache those of the whole
+	// cluster.
+	Reader client.Reader
+	// Secret holds the CA and the serving certificate.
+	Secret types.NamespacedName
+	// DNSNames are the names the serving certificate is valid for, those of the webhook Service.
+	DNSNames []string
+	// WebhookConfigurations are the names of the mutating and validating webhook configurations
+	// the CA is injected into. Names without a configuration of either kind are skipped.
+	WebhookConfigurations []string
+	// CheckInterval defaults to DefaultCheckInterval.
+	CheckInterval time.Duration
+	// Clock defaults to the real clock.
+	Clock clock.PassiveClock
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+}
+
+// ServiceDNSNames returns the DNS names of a Service, as the API server addresses webhooks.
+func ServiceDNSNames(service types.NamespacedName) []string {
+	host := service.Name + "." + service.Namespace + ".svc"
+	return []string{host, host + ".cluster.local"}
+}
+
+// GetCertificate returns the serving certificate, for tls.Config.GetCertificate. It fails until
+// the first sync.
+func (r *Rotator) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.cert == nil {
+		return nil, errors.New("webhook serving certificate not generated yet")
+	}
+	return r.cert, nil
+}
+
+// Start syncs the certificate until ctx is done, retrying failed syncs sooner.
+func (r *Rotator) Start(ctx context.Context) error {
+	interval := r.CheckInterval
+	if interval <= 0 {
+		interval = DefaultCheckInterval
+	}
+	for {
+		wait := interval
+		if err := r.Sync(ctx); err != nil {
+			log.Error(err, "failed to sync the webhook serving certificate", "secret", r.Secret)
+			wait = retryInterval
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+// NeedLeaderElection lets every replica load the certificate. It implements manager.LeaderElectionRunnable.
+func (r *Rotator) NeedLeaderElection() bool {
+	return false
+}
+
+// Sync makes the Secret hold a CA and a serving certificate that are not due for renewal,
+// generating them as needed, then serves the certificate and injects the CA bundle.
+func (r *Rotator) Sync(ctx context.Context) error {
+	var secret corev1.Secret
+	err := r.Reader.Get(ctx, r.Secret, &secret)
+	switch {
+	case apierrors.IsNotFound(err):
+		secret = corev1.Secret{Type: corev1.SecretTypeTLS}
+		secret.Namespace, secret.Name = r.Secret.Namespace, r.Secret.Name
+	case err != nil:
+		return err
+	}
+
+	data, renewed, err := renew(secret.Data, r.DNSNames, r.now())
+	if err != nil {
+		return err
+	}
+	if renewed {
+		secret.Data = data
+		if secret.ResourceVersion == "" {
+			err = r.Client.Create(ctx, &secret)
+		} else {
+			err = r.Client.Update(ctx, &secret)
+		}
+		if err != nil {
+			// Another replica renewed it first: take its certificate on the retry.
+			return fmt.Errorf("writing Secret %s: %w", r.Secret, err)
+		}
+		log.Info("renewed the webhook serving certificate", "secret", r.Secret)
+	}
+
+	cert, err := tls.X509KeyPair(data[corev1.TLSCertKey], data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	r.cert = &cert
+	r.mu.Unlock()
+	return r.injectCABundle(ctx, data[CAKey])
+}
+
+// injectCABundle sets bundle as the caBundle of every webhook of the configurations.
+func (r *Rotator) injectCABundle(ctx context.Context, bundle []byte) error {
+	for _, name := range r.WebhookConfigurations {
+		key := types.NamespacedName{Name: name}
+		var mutating admissionregistrationv1.MutatingWebhookConfiguration
+		if err := r.Reader.Get(ctx, key, &mutating); err == nil {
+			changed := false
+			for i := range mutating.Webhooks {
+				changed = setCABundle(&mutating.Webhooks[i].ClientConfig, bundle) || changed
+			}
+			if changed {
+				if err := r.Client.Update(ctx, &mutating); err != nil {
+					return err
+				}
+			}
+		} else if !apierrors.IsNotFound(err) {
+			return err
+		}
+		var validating admissionregistrationv1.ValidatingWebhookConfiguration
+		if err := r.Reader.Get(ctx, key, &validating); err == nil {
+			changed := false
+			for i := range validating.Webhooks {
+				changed = setCABundle(&validating.Webhooks[i].ClientConfig, bundle) || changed
+			}
+			if changed {
+				if err := r.Client.Update(ctx, &validating); err != nil {
+					return err
+				}
+			}
+		} else if !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// setCABundle sets the caBundle of a webhook, reporting whether it changed.
+func setCABundle(cfg *admissionregistrationv1.WebhookClientConfig, bundle []byte) bool {
+	if bytes.Equal(cfg.CABundle, bundle) {
+		return false
+	}
+	cfg.CABundle = bundle
+	return true
+}
+
+func (r *Rotator) now() time.Time {
+	if r.Clock == nil {
+		return time.Now()
+	}
+	return r.Clock.Now()
+}
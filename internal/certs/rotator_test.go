@@ -0,0 +1,143 @@
+package certs
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	testingclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRotator(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	secretKey := types.NamespacedName{Namespace: "system", Name: "webhook-cert"}
+	dnsNames := ServiceDNSNames(types.NamespacedName{Namespace: "system", Name: "webhook-service"})
+
+	newRotator := func() (*Rotator, *testingclock.FakeClock) {
+		clk := testingclock.NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&admissionregistrationv1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "mutating"},
+				Webhooks:   []admissionregistrationv1.MutatingWebhook{{Name: "m.kb.io"}},
+			},
+			&admissionregistrationv1.ValidatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "validating"},
+				Webhooks:   []admissionregistrationv1.ValidatingWebhook{{Name: "v1.kb.io"}, {Name: "v2.kb.io"}},
+			},
+		).Build()
+		return &Rotator{
+			Client:                c,
+			Reader:                c,
+			Secret:                secretKey,
+			DNSNames:              dnsNames,
+			WebhookConfigurations: []string{"mutating", "validating", "absent"},
+			Clock:                 clk,
+		}, clk
+	}
+	secret := func(t *testing.T, r *Rotator) *corev1.Secret {
+		var s corev1.Secret
+		require.NoError(t, r.Reader.Get(context.Background(), secretKey, &s))
+		return &s
+	}
+	// verify checks that the served certificate is trusted by the injected bundle at now.
+	verify := func(t *testing.T, r *Rotator, now time.Time) {
+		cert, err := r.GetCertificate(nil)
+		require.NoError(t, err)
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		require.NoError(t, err)
+
+		var validating admissionregistrationv1.ValidatingWebhookConfiguration
+		require.NoError(t, r.Reader.Get(context.Background(), client.ObjectKey{Name: "validating"}, &validating))
+		var mutating admissionregistrationv1.MutatingWebhookConfiguration
+		require.NoError(t, r.Reader.Get(context.Background(), client.ObjectKey{Name: "mutating"}, &mutating))
+		bundle := mutating.Webhooks[0].ClientConfig.CABundle
+		for _, w := range validating.Webhooks {
+			assert.Equal(t, bundle, w.ClientConfig.CABundle, w.Name)
+		}
+		assert.Equal(t, secret(t, r).Data[CAKey], bundle)
+
+		roots := x509.NewCertPool()
+		require.True(t, roots.AppendCertsFromPEM(bundle))
+		_, err = leaf.Verify(x509.VerifyOptions{
+			DNSName: dnsNames[0], Roots: roots, CurrentTime: now,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		})
+		assert.NoError(t, err)
+	}
+
+	t.Run("NotSynced_NoCertificate", func(t *testing.T) {
+		t.Parallel()
+		r, _ := newRotator()
+		_, err := r.GetCertificate(nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("FirstSync_GeneratedAndInjected", func(t *testing.T) {
+		t.Parallel()
+		r, clk := newRotator()
+		require.NoError(t, r.Sync(context.Background()))
+		verify(t, r, clk.Now())
+		assert.Equal(t, corev1.SecretTypeTLS, secret(t, r).Type)
+
+		version := secret(t, r).ResourceVersion
+		require.NoError(t, r.Sync(context.Background()))
+		assert.Equal(t, version, secret(t, r).ResourceVersion, "a valid certificate is not renewed")
+	})
+
+	t.Run("ServingDue_RenewedWithSameCA", func(t *testing.T) {
+		t.Parallel()
+		r, clk := newRotator()
+		require.NoError(t, r.Sync(context.Background()))
+		before := secret(t, r)
+
+		clk.Step(250 * 24 * time.Hour)
+		require.NoError(t, r.Sync(context.Background()))
+		after := secret(t, r)
+		assert.Equal(t, before.Data[CAKey], after.Data[CAKey])
+		assert.NotEqual(t, before.Data[corev1.TLSCertKey], after.Data[corev1.TLSCertKey])
+		verify(t, r, clk.Now())
+	})
+
+	t.Run("CADue_RenewedAndOldOneKeptUntilExpiry", func(t *testing.T) {
+		t.Parallel()
+		r, clk := newRotator()
+		require.NoError(t, r.Sync(context.Background()))
+
+		clk.Step(7 * 365 * 24 * time.Hour)
+		require.NoError(t, r.Sync(context.Background()))
+		assert.Len(t, parseCertificates(secret(t, r).Data[CAKey]), 2)
+		verify(t, r, clk.Now())
+
+		clk.Step(4 * 365 * 24 * time.Hour)
+		require.NoError(t, r.Sync(context.Background()))
+		assert.Len(t, parseCertificates(secret(t, r).Data[CAKey]), 1, "the expired CA is dropped")
+		verify(t, r, clk.Now())
+	})
+
+	t.Run("ServiceRenamed_Reissued", func(t *testing.T) {
+		t.Parallel()
+		r, clk := newRotator()
+		require.NoError(t, r.Sync(context.Background()))
+
+		r.DNSNames = ServiceDNSNames(types.NamespacedName{Namespace: "system", Name: "renamed"})
+		require.NoError(t, r.Sync(context.Background()))
+		cert, err := r.GetCertificate(nil)
+		require.NoError(t, err)
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		require.NoError(t, err)
+		assert.Equal(t, []string{"renamed.system.svc", "renamed.system.svc.cluster.local"}, leaf.DNSNames)
+		assert.True(t, leaf.NotBefore.Before(clk.Now()))
+	})
+}
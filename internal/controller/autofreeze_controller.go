@@ -0,0 +1,215 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	// AnnoFreezeFor on a Deployment requests a freeze of that many seconds (or a Go duration such as "30m").
+	// The same annotation on the DFZ records the value it was created for.
+	AnnoFreezeFor = "apps.boolfixer.dev/freeze-for"
+	// AnnoFreezeForConsumed on a Deployment records the freeze-for value a DFZ was created for,
+	// so the same value freezes at most once, even if that DFZ is deleted.
+	AnnoFreezeForConsumed = "apps.boolfixer.dev/freeze-for-consumed"
+	// LabelAutoFreeze marks DFZs created from the freeze-for annotation.
+	LabelAutoFreeze = "apps.boolfixer.dev/auto-freeze"
+
+	// autoFreezePrefix prefixes the names of DFZs created from the freeze-for annotation.
+	autoFreezePrefix = "auto"
+)
+
+// AutoFreezeReconciler turns the freeze-for annotation of a Deployment into a DFZ and
+// deletes that DFZ again once the annotation is removed.
+type AutoFreezeReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// Shard selects the Deployments handled by this replica; in label mode the Deployment
+	// must carry the shard label, which its DFZ inherits.
+	Shard Shard
+}
+
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=apps,resources=deployments/finalizers,verbs=update
+// +kubebuilder:rbac:groups=apps.boolfixer.dev,resources=deploymentfreezers,verbs=get;list;watch;create;update;delete
+
+func (r *AutoFreezeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	nn := types.NamespacedName{Namespace: req.Namespace, Name: childFreezeName(autoFreezePrefix, req.Name)}
+	var dfz freezerv1alpha1.DeploymentFreezer
+	if err := r.Get(ctx, nn, &dfz); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		dfz = freezerv1alpha1.DeploymentFreezer{}
+	}
+	exists := dfz.UID != ""
+	if exists && (dfz.Labels[LabelAutoFreeze] != "true" || dfz.Spec.TargetRef.Name != req.Name) {
+		// Created by someone else under our name; leave it alone.
+		return ctrl.Result{}, nil
+	}
+
+	var deploy appsv1.Deployment
+	if err := r.Get(ctx, req.NamespacedName, &deploy); err != nil {
+		// A deleted Deployment takes its DFZ along through the owner reference.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	value, requested := deploy.Annotations[AnnoFreezeFor]
+	switch {
+	case !requested:
+		if exists {
+			if err := r.deleteAutoFreeze(ctx, &dfz); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		// Setting the same value again is a new request.
+		return ctrl.Result{}, r.markConsumed(ctx, &deploy, "")
+	case exists && dfz.Annotations[AnnoFreezeFor] == value:
+		// Already handled this request; a finished DFZ stays until the annotation changes.
+		return ctrl.Result{}, r.markConsumed(ctx, &deploy, value)
+	case !exists && deploy.Annotations[AnnoFreezeForConsumed] == value:
+		// The DFZ created for this value was deleted; do not freeze again.
+		return ctrl.Result{}, nil
+	}
+
+	duration, err := parseFreezeFor(value)
+	if err != nil {
+		r.Recorder.Eventf(&deploy, corev1.EventTypeWarning, ReasonInvalidFreezeFor, msgInvalidFreezeFor, AnnoFreezeFor, err)
+		return ctrl.Result{}, nil
+	}
+
+	if !exists {
+		return ctrl.Result{}, r.createAutoFreeze(ctx, &deploy, nn, value, duration)
+	}
+	if isTerminalPhase(dfz.Status.Phase) {
+		// A new request after the previous freeze finished: start over.
+		return ctrl.Result{RequeueAfter: requeueShort}, r.deleteAutoFreeze(ctx, &dfz)
+	}
+	// A changed request while the freeze is running resizes its window.
+	if dfz.Annotations == nil {
+		dfz.Annotations = map[string]string{}
+	}
+	dfz.Annotations[AnnoFreezeFor] = value
+	dfz.Spec.DurationSeconds = int64(duration / time.Second)
+	if err := r.Update(ctx, &dfz); err != nil {
+		return ctrl.Result{}, r.refused(&deploy, err)
+	}
+	return ctrl.Result{}, r.markConsumed(ctx, &deploy, value)
+}
+
+func (r *AutoFreezeReconciler) createAutoFreeze(
+	ctx context.Context,
+	deploy *appsv1.Deployment,
+	nn types.NamespacedName,
+	value string,
+	duration time.Duration,
+) error {
+	labels := map[string]string{LabelAutoFreeze: "true"}
+	if v, ok := deploy.Labels[LabelShard]; ok {
+		labels[LabelShard] = v
+	}
+	dfz := &freezerv1alpha1.DeploymentFreezer{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   nn.Namespace,
+			Name:        nn.Name,
+			Labels:      labels,
+			Annotations: map[string]string{AnnoFreezeFor: value},
+		},
+		Spec: freezerv1alpha1.DeploymentFreezerSpec{
+			TargetRef:       freezerv1alpha1.DeploymentTargetRef{Name: deploy.Name},
+			DurationSeconds: int64(duration / time.Second),
+		},
+	}
+	if err := controllerutil.SetControllerReference(deploy, dfz, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, dfz); err != nil {
+		return r.refused(deploy, client.IgnoreAlreadyExists(err))
+	}
+	r.Recorder.Eventf(deploy, corev1.EventTypeNormal, ReasonAutoFreezeCreated, msgAutoFreezeCreated, nn.Name, duration)
+	return r.markConsumed(ctx, deploy, value)
+}
+
+// markConsumed records value as the consumed freeze-for value of the Deployment, or removes the
+// record when value is empty. It is written after the DFZ, so a failed create is retried.
+func (r *AutoFreezeReconciler) markConsumed(ctx context.Context, deploy *appsv1.Deployment, value string) error {
+	if deploy.Annotations[AnnoFreezeForConsumed] == value {
+		return nil
+	}
+	orig := deploy.DeepCopy()
+	if value == "" {
+		delete(deploy.Annotations, AnnoFreezeForConsumed)
+	} else {
+		if deploy.Annotations == nil {
+			deploy.Annotations = map[string]string{}
+		}
+		deploy.Annotations[AnnoFreezeForConsumed] = value
+	}
+	return client.IgnoreNotFound(r.Patch(ctx, deploy, client.MergeFrom(orig)))
+}
+
+// refused reports an admission refusal (policy, protected namespace, maximum duration) on the
+// Deployment, where the app team sees it, instead of retrying; other errors are returned.
+func (r *AutoFreezeReconciler) refused(deploy *appsv1.Deployment, err error) error {
+	if apierrors.IsForbidden(err) || apierrors.IsInvalid(err) {
+		r.Recorder.Eventf(deploy, corev1.EventTypeWarning, ReasonAutoFreezeRefused, msgAutoFreezeRefused, err)
+		return nil
+	}
+	return err
+}
+
+// deleteAutoFreeze deletes the DFZ; its finalizer restores the Deployment if it is still frozen.
+func (r *AutoFreezeReconciler) deleteAutoFreeze(ctx context.Context, dfz *freezerv1alpha1.DeploymentFreezer) error {
+	if !dfz.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	return client.IgnoreNotFound(r.Delete(ctx, dfz, client.Preconditions{UID: &dfz.UID}))
+}
+
+// parseFreezeFor accepts whole seconds or a Go duration.
+func parseFreezeFor(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if seconds, convErr := strconv.ParseInt(value, 10, 64); convErr == nil {
+		d, err = time.Duration(seconds)*time.Second, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if d < time.Second {
+		return 0, fmt.Errorf("must be at least one second, got %s", d)
+	}
+	return d, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AutoFreezeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Recorder = mgr.GetEventRecorderFor("deployment-freezer-autofreeze")
+	return ctrl.NewControllerManagedBy(mgr).
+		For(
+			&appsv1.Deployment{},
+			// The request lives in an annotation, which does not bump the generation.
+			builder.WithPredicates(predicate.AnnotationChangedPredicate{}, r.Shard.Predicate()),
+		).
+		Owns(&freezerv1alpha1.DeploymentFreezer{}, builder.WithPredicates(predicate.NewPredicateFuncs(
+			func(obj client.Object) bool { return obj.GetLabels()[LabelAutoFreeze] == "true" },
+		))).
+		Named("autofreeze").
+		Complete(r)
+}
@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAutoFreeze(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "web"}}
+	dfzKey := types.NamespacedName{Namespace: "ns", Name: "auto-web"}
+
+	newDeploy := func(freezeFor string) *appsv1.Deployment {
+		d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web", UID: "deploy-uid"}}
+		if freezeFor != "" {
+			d.Annotations = map[string]string{AnnoFreezeFor: freezeFor}
+		}
+		return d
+	}
+	newAutoDFZ := func(freezeFor string, phase freezerv1alpha1.Phase) *freezerv1alpha1.DeploymentFreezer {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   dfzKey.Namespace,
+			Name:        dfzKey.Name,
+			UID:         "dfz-uid",
+			Labels:      map[string]string{LabelAutoFreeze: "true"},
+			Annotations: map[string]string{AnnoFreezeFor: freezeFor},
+		}}
+		dfz.Spec.TargetRef.Name = "web"
+		dfz.Spec.DurationSeconds = 600
+		dfz.Status.Phase = phase
+		return dfz
+	}
+	newReconciler := func(objs ...client.Object) (*AutoFreezeReconciler, *record.FakeRecorder) {
+		rec := record.NewFakeRecorder(10)
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+			WithStatusSubresource(&freezerv1alpha1.DeploymentFreezer{}).Build()
+		return &AutoFreezeReconciler{Client: c, Scheme: scheme, Recorder: rec}, rec
+	}
+	getDFZ := func(r *AutoFreezeReconciler) (*freezerv1alpha1.DeploymentFreezer, error) {
+		var dfz freezerv1alpha1.DeploymentFreezer
+		err := r.Get(context.Background(), dfzKey, &dfz)
+		return &dfz, err
+	}
+
+	t.Run("Annotated_CreatesOwnedDFZ", func(t *testing.T) {
+		t.Parallel()
+		r, rec := newReconciler(newDeploy("30m"))
+		_, err := r.Reconcile(context.Background(), req)
+		require.NoError(t, err)
+
+		dfz, err := getDFZ(r)
+		require.NoError(t, err)
+		assert.Equal(t, "web", dfz.Spec.TargetRef.Name)
+		assert.Equal(t, int64(1800), dfz.Spec.DurationSeconds)
+		assert.Equal(t, "30m", dfz.Annotations[AnnoFreezeFor])
+		require.NotNil(t, metav1.GetControllerOf(dfz))
+		assert.Equal(t, "web", metav1.GetControllerOf(dfz).Name)
+		assert.Contains(t, <-rec.Events, ReasonAutoFreezeCreated)
+
+		var deploy appsv1.Deployment
+		require.NoError(t, r.Get(context.Background(), req.NamespacedName, &deploy))
+		assert.Equal(t, "30m", deploy.Annotations[AnnoFreezeForConsumed])
+	})
+
+	t.Run("ConsumedValue_DeletedDFZNotRecreated", func(t *testing.T) {
+		t.Parallel()
+		deploy := newDeploy("600")
+		deploy.Annotations[AnnoFreezeForConsumed] = "600"
+		r, _ := newReconciler(deploy)
+		_, err := r.Reconcile(context.Background(), req)
+		require.NoError(t, err)
+		_, err = getDFZ(r)
+		assert.True(t, apierrors.IsNotFound(err))
+
+		// Removing the annotation forgets the value, so setting it again freezes again.
+		require.NoError(t, r.Get(context.Background(), req.NamespacedName, deploy))
+		delete(deploy.Annotations, AnnoFreezeFor)
+		require.NoError(t, r.Update(context.Background(), deploy))
+		_, err = r.Reconcile(context.Background(), req)
+		require.NoError(t, err)
+		require.NoError(t, r.Get(context.Background(), req.NamespacedName, deploy))
+		assert.NotContains(t, deploy.Annotations, AnnoFreezeForConsumed)
+
+		metav1.SetMetaDataAnnotation(&deploy.ObjectMeta, AnnoFreezeFor, "600")
+		require.NoError(t, r.Update(context.Background(), deploy))
+		_, err = r.Reconcile(context.Background(), req)
+		require.NoError(t, err)
+		_, err = getDFZ(r)
+		assert.NoError(t, err)
+	})
+
+	t.Run("SameValue_NoOp", func(t *testing.T) {
+		t.Parallel()
+		r, _ := newReconciler(newDeploy("600"), newAutoDFZ("600", freezerv1alpha1.PhaseCompleted))
+		_, err := r.Reconcile(context.Background(), req)
+		require.NoError(t, err)
+		dfz, err := getDFZ(r)
+		require.NoError(t, err)
+		assert.Equal(t, freezerv1alpha1.PhaseCompleted, dfz.Status.Phase)
+	})
+
+	t.Run("ChangedValueWhileActive_ResizesWindow", func(t *testing.T) {
+		t.Parallel()
+		r, _ := newReconciler(newDeploy("3600"), newAutoDFZ("600", freezerv1alpha1.PhaseFrozen))
+		_, err := r.Reconcile(context.Background(), req)
+		require.NoError(t, err)
+		dfz, err := getDFZ(r)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3600), dfz.Spec.DurationSeconds)
+		assert.Equal(t, "3600", dfz.Annotations[AnnoFreezeFor])
+	})
+
+	t.Run("ChangedValueAfterFinish_StartsOver", func(t *testing.T) {
+		t.Parallel()
+		r, _ := newReconciler(newDeploy("3600"), newAutoDFZ("600", freezerv1alpha1.PhaseCompleted))
+		res, err := r.Reconcile(context.Background(), req)
+		require.NoError(t, err)
+		assert.Positive(t, res.RequeueAfter)
+		_, err = getDFZ(r)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("AnnotationRemoved_DeletesDFZ", func(t *testing.T) {
+		t.Parallel()
+		r, _ := newReconciler(newDeploy(""), newAutoDFZ("600", freezerv1alpha1.PhaseFrozen))
+		_, err := r.Reconcile(context.Background(), req)
+		require.NoError(t, err)
+		_, err = getDFZ(r)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("ForeignDFZ_LeftAlone", func(t *testing.T) {
+		t.Parallel()
+		foreign := newAutoDFZ("600", freezerv1alpha1.PhaseFrozen)
+		foreign.Labels = nil
+		r, _ := newReconciler(newDeploy(""), foreign)
+		_, err := r.Reconcile(context.Background(), req)
+		require.NoError(t, err)
+		_, err = getDFZ(r)
+		assert.NoError(t, err)
+	})
+
+	t.Run("InvalidValue_Event", func(t *testing.T) {
+		t.Parallel()
+		r, rec := newReconciler(newDeploy("soon"))
+		_, err := r.Reconcile(context.Background(), req)
+		require.NoError(t, err)
+		assert.Contains(t, <-rec.Events, ReasonInvalidFreezeFor)
+		_, err = getDFZ(r)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("ParseFreezeFor", func(t *testing.T) {
+		t.Parallel()
+		d, err := parseFreezeFor("1800")
+		require.NoError(t, err)
+		assert.Equal(t, 30*time.Minute, d)
+		d, err = parseFreezeFor("1h30m")
+		require.NoError(t, err)
+		assert.Equal(t, 90*time.Minute, d)
+		_, err = parseFreezeFor("0")
+		assert.Error(t, err)
+		_, err = parseFreezeFor("-5m")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,284 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	// AnnoAutoFreezeReason on a DFZ created by an AutoFreezePolicy records why it tripped.
+	AnnoAutoFreezeReason = "apps.boolfixer.dev/auto-freeze-reason"
+
+	// autoFreezeInterval is how often the Deployments selected by an AutoFreezePolicy are checked.
+	autoFreezeInterval = 30 * time.Second
+	// crashLoopBackOff is the waiting reason of a container the kubelet keeps restarting.
+	crashLoopBackOff = "CrashLoopBackOff"
+)
+
+// MetricSource evaluates the queries of AutoFreezePolicy metric triggers, returning the highest
+// sample and whether the result was empty.
+type MetricSource interface {
+	Query(ctx context.Context, query string) (float64, bool, error)
+}
+
+// AutoFreezePolicyReconciler freezes the unhealthy Deployments selected by AutoFreezePolicies.
+// It polls: Pods are read from the cache, but neither they nor metrics trigger a reconcile.
+type AutoFreezePolicyReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// Clock is the time source for the trigger and cooldown timers; defaults to the real clock.
+	Clock clock.Clock
+	// Shard selects the policies handled by this replica; the DFZs they create inherit the shard label.
+	Shard Shard
+	// Metrics evaluates metric triggers; nil leaves them unevaluated.
+	Metrics MetricSource
+}
+
+// +kubebuilder:rbac:groups=apps.boolfixer.dev,resources=autofreezepolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.boolfixer.dev,resources=autofreezepolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.boolfixer.dev,resources=autofreezepolicies/finalizers,verbs=update
+// +kubebuilder:rbac:groups=apps.boolfixer.dev,resources=deploymentfreezers,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+
+func (r *AutoFreezePolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var afp freezerv1alpha1.AutoFreezePolicy
+	if err := r.Get(ctx, req.NamespacedName, &afp); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !afp.DeletionTimestamp.IsZero() {
+		// The DFZs are garbage collected and restore their Deployments through their finalizers.
+		return ctrl.Result{}, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&afp.Spec.Selector)
+	if err != nil {
+		r.Recorder.Eventf(&afp, corev1.EventTypeWarning, ReasonInvalidSelector, msgInvalidSelector, err)
+		return ctrl.Result{}, nil
+	}
+	var deploys appsv1.DeploymentList
+	if err := r.List(ctx, &deploys, client.InNamespace(afp.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	orig := afp.DeepCopy()
+	known := map[string]freezerv1alpha1.AutoFreezeTarget{}
+	for _, t := range afp.Status.Targets {
+		known[t.Deployment] = t
+	}
+	now := r.Clock.Now()
+	requeue := autoFreezeInterval
+	targets := make([]freezerv1alpha1.AutoFreezeTarget, 0, len(deploys.Items))
+	// A Deployment that cannot be evaluated or frozen keeps its previous target and does not
+	// hold back the others; the errors are returned once the status is written.
+	var errs []error
+	for i := range deploys.Items {
+		deploy := &deploys.Items[i]
+		t, ok := known[deploy.Name]
+		if !ok {
+			t = freezerv1alpha1.AutoFreezeTarget{Deployment: deploy.Name}
+		}
+		msg, err := r.evaluate(ctx, &afp, deploy)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("deployment %s: %w", deploy.Name, err))
+		} else if msg == "" {
+			t.UnhealthySince = nil
+		} else {
+			if t.UnhealthySince == nil {
+				t.UnhealthySince = ptr.To(metav1.NewTime(now.UTC()))
+			}
+			t.Message = msg
+			wait := time.Duration(afp.Spec.ForSeconds)*time.Second - now.Sub(t.UnhealthySince.Time)
+			if wait > 0 {
+				requeue = min(requeue, wait)
+			} else if retry, err := r.trip(ctx, &afp, deploy, &t, now); err != nil {
+				errs = append(errs, fmt.Errorf("deployment %s: %w", deploy.Name, err))
+			} else if retry {
+				requeue = requeueShort
+			}
+		}
+		if t.UnhealthySince != nil || t.Freezer != "" {
+			targets = append(targets, t)
+		}
+	}
+	afp.Status.Targets = targets
+
+	if !equality.Semantic.DeepEqual(orig.Status, afp.Status) {
+		if err := r.Status().Patch(ctx, &afp, client.MergeFrom(orig)); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: requeue}, nil
+}
+
+// evaluate returns why a trigger of the policy trips for the Deployment, or "" when none does.
+// A failed metric query is reported on the policy and does not trip.
+func (r *AutoFreezePolicyReconciler) evaluate(
+	ctx context.Context,
+	afp *freezerv1alpha1.AutoFreezePolicy,
+	deploy *appsv1.Deployment,
+) (string, error) {
+	if afp.Spec.CrashLoop != nil {
+		msg, err := r.crashLooping(ctx, deploy, afp.Spec.CrashLoop.MinRestarts)
+		if err != nil || msg != "" {
+			return msg, err
+		}
+	}
+	if m := afp.Spec.Metric; m != nil {
+		if r.Metrics == nil {
+			r.Recorder.Event(afp, corev1.EventTypeWarning, ReasonMetricQueryFailed, msgMetricSourceMissing)
+			return "", nil
+		}
+		query := strings.NewReplacer("$namespace", deploy.Namespace, "$deployment", deploy.Name).Replace(m.Query)
+		value, ok, err := r.Metrics.Query(ctx, query)
+		if err != nil {
+			r.Recorder.Eventf(afp, corev1.EventTypeWarning, ReasonMetricQueryFailed, msgMetricQueryFailed, deploy.Name, err)
+			return "", nil
+		}
+		if ok && value > m.Threshold.AsApproximateFloat64() {
+			return fmt.Sprintf(msgMetricAboveThresholdFmt, value, m.Threshold.String()), nil
+		}
+	}
+	return "", nil
+}
+
+// crashLooping describes the first container of the Deployment's Pods in CrashLoopBackOff with at
+// least minRestarts restarts. Pods come from the cache, stripped down by StripPod.
+func (r *AutoFreezePolicyReconciler) crashLooping(ctx context.Context, deploy *appsv1.Deployment, minRestarts int32) (string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deploy.Spec.Selector)
+	if err != nil {
+		return "", nil
+	}
+	var pods corev1.PodList
+	if err := r.List(
+		ctx,
+		&pods,
+		client.InNamespace(deploy.Namespace),
+		client.MatchingLabelsSelector{Selector: selector},
+	); err != nil {
+		return "", err
+	}
+	for _, pod := range pods.Items {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.State.Waiting != nil && cs.State.Waiting.Reason == crashLoopBackOff && cs.RestartCount >= minRestarts {
+				return fmt.Sprintf(msgCrashLoopingFmt, cs.Name, pod.Name, cs.RestartCount), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// trip freezes the Deployment unless it is cooling down from a previous freeze or still frozen.
+// It reports whether a finished DFZ was deleted to make room for the new one.
+func (r *AutoFreezePolicyReconciler) trip(
+	ctx context.Context,
+	afp *freezerv1alpha1.AutoFreezePolicy,
+	deploy *appsv1.Deployment,
+	t *freezerv1alpha1.AutoFreezeTarget,
+	now time.Time,
+) (bool, error) {
+	if t.FrozenAt != nil && now.Before(t.FrozenAt.Add(time.Duration(afp.Spec.CooldownSeconds)*time.Second)) {
+		return false, nil
+	}
+
+	nn := types.NamespacedName{Namespace: afp.Namespace, Name: childFreezeName(afp.Name, deploy.Name)}
+	var existing freezerv1alpha1.DeploymentFreezer
+	err := r.Get(ctx, nn, &existing)
+	switch {
+	case err == nil && !metav1.IsControlledBy(&existing, afp):
+		// Created by someone else under our name; leave it alone.
+		return false, nil
+	case err == nil && !isTerminalPhase(existing.Status.Phase):
+		return false, nil
+	case err == nil:
+		if !existing.DeletionTimestamp.IsZero() {
+			return true, nil
+		}
+		return true, client.IgnoreNotFound(r.Delete(ctx, &existing, client.Preconditions{UID: &existing.UID}))
+	case !apierrors.IsNotFound(err):
+		return false, err
+	}
+
+	labels := map[string]string{freezerv1alpha1.LabelAutoFreezePolicy: afp.Name}
+	if v, ok := afp.Labels[LabelShard]; ok {
+		labels[LabelShard] = v
+	}
+	dfz := &freezerv1alpha1.DeploymentFreezer{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   nn.Namespace,
+			Name:        nn.Name,
+			Labels:      labels,
+			Annotations: map[string]string{AnnoAutoFreezeReason: t.Message},
+		},
+		Spec: freezerv1alpha1.DeploymentFreezerSpec{
+			TargetRef:       freezerv1alpha1.DeploymentTargetRef{Name: deploy.Name},
+			DurationSeconds: afp.Spec.DurationSeconds,
+		},
+	}
+	if err := controllerutil.SetControllerReference(afp, dfz, r.Scheme); err != nil {
+		return false, err
+	}
+	if err := r.Create(ctx, dfz); err != nil {
+		switch {
+		case apierrors.IsForbidden(err) || apierrors.IsInvalid(err):
+			// A refused freeze also starts the cooldown, so it is not retried every interval.
+			t.FrozenAt = ptr.To(metav1.NewTime(now.UTC()))
+			r.Recorder.Eventf(deploy, corev1.EventTypeWarning, ReasonAutoFreezeRefused, msgAutoFreezeRefused, err)
+			return false, nil
+		case apierrors.IsAlreadyExists(err):
+			t.FrozenAt = ptr.To(metav1.NewTime(now.UTC()))
+			return false, nil
+		}
+		// Any other failure is retried without a cooldown.
+		return false, err
+	}
+	t.FrozenAt = ptr.To(metav1.NewTime(now.UTC()))
+	t.Freezer = nn.Name
+	duration := time.Duration(afp.Spec.DurationSeconds) * time.Second
+	// Warn the owners where they look: on their Deployment, and on the policy.
+	r.Recorder.Eventf(deploy, corev1.EventTypeWarning, ReasonAutoFreezeTripped, msgAutoFreezeTripped,
+		duration, afp.Name, t.Message)
+	r.Recorder.Eventf(afp, corev1.EventTypeWarning, ReasonAutoFreezeTripped, msgAutoFreezeTrippedPolicy,
+		deploy.Name, duration, t.Message)
+	return false, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AutoFreezePolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Clock == nil {
+		r.Clock = clock.RealClock{}
+	}
+	r.Recorder = mgr.GetEventRecorderFor("autofreezepolicy")
+	return ctrl.NewControllerManagedBy(mgr).
+		For(
+			&freezerv1alpha1.AutoFreezePolicy{},
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}, r.Shard.Predicate()),
+		).
+		Owns(&freezerv1alpha1.DeploymentFreezer{}).
+		Named("autofreezepolicy").
+		Complete(r)
+}
@@ -0,0 +1,236 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// fakeMetrics answers every query with the same result and records the queries.
+type fakeMetrics struct {
+	value   float64
+	ok      bool
+	err     error
+	queries []string
+}
+
+func (m *fakeMetrics) Query(_ context.Context, query string) (float64, bool, error) {
+	m.queries = append(m.queries, query)
+	return m.value, m.ok, m.err
+}
+
+func TestAutoFreezePolicy(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	afpKey := types.NamespacedName{Namespace: "shop", Name: "breaker"}
+	dfzKey := types.NamespacedName{Namespace: "shop", Name: "breaker-web"}
+	newPolicy := func() *freezerv1alpha1.AutoFreezePolicy {
+		return &freezerv1alpha1.AutoFreezePolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: afpKey.Namespace, Name: afpKey.Name, UID: "afp-uid"},
+			Spec: freezerv1alpha1.AutoFreezePolicySpec{
+				Selector:        metav1.LabelSelector{MatchLabels: map[string]string{"breaker": "on"}},
+				CrashLoop:       &freezerv1alpha1.CrashLoopTrigger{MinRestarts: 3},
+				ForSeconds:      300,
+				DurationSeconds: 1800,
+				CooldownSeconds: 3600,
+			},
+		}
+	}
+	newDeployment := func(name string, labels map[string]string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: name, Labels: labels},
+			Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}}},
+		}
+	}
+	newPod := func(app string, restarts int32, waiting string) *corev1.Pod {
+		p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: app + "-1", Labels: map[string]string{"app": app}}}
+		cs := corev1.ContainerStatus{Name: "app", RestartCount: restarts}
+		if waiting != "" {
+			cs.State.Waiting = &corev1.ContainerStateWaiting{Reason: waiting}
+		}
+		p.Status.ContainerStatuses = []corev1.ContainerStatus{cs}
+		return p
+	}
+	newReconciler := func(objs ...client.Object) (*AutoFreezePolicyReconciler, *testingclock.FakeClock) {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+			WithStatusSubresource(&freezerv1alpha1.AutoFreezePolicy{}, &freezerv1alpha1.DeploymentFreezer{}).Build()
+		clk := testingclock.NewFakeClock(now)
+		return &AutoFreezePolicyReconciler{
+			Client:   c,
+			Scheme:   scheme,
+			Recorder: record.NewFakeRecorder(10),
+			Clock:    clk,
+		}, clk
+	}
+	reconcile := func(t *testing.T, r *AutoFreezePolicyReconciler) ctrl.Result {
+		t.Helper()
+		res, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: afpKey})
+		require.NoError(t, err)
+		return res
+	}
+	getPolicy := func(t *testing.T, r *AutoFreezePolicyReconciler) *freezerv1alpha1.AutoFreezePolicy {
+		t.Helper()
+		afp := &freezerv1alpha1.AutoFreezePolicy{}
+		require.NoError(t, r.Get(context.Background(), afpKey, afp))
+		return afp
+	}
+	breaker := map[string]string{"breaker": "on"}
+
+	t.Run("CrashLoop_FreezesAfterForSeconds", func(t *testing.T) {
+		t.Parallel()
+		r, clk := newReconciler(newPolicy(), newDeployment("web", breaker), newPod("web", 5, crashLoopBackOff))
+
+		res := reconcile(t, r)
+		assert.Equal(t, autoFreezeInterval, res.RequeueAfter)
+		targets := getPolicy(t, r).Status.Targets
+		require.Len(t, targets, 1)
+		assert.Equal(t, "web", targets[0].Deployment)
+		assert.Contains(t, targets[0].Message, "CrashLoopBackOff after 5 restarts")
+		err := r.Get(context.Background(), dfzKey, &freezerv1alpha1.DeploymentFreezer{})
+		assert.True(t, client.IgnoreNotFound(err) == nil && err != nil, "no freeze before forSeconds")
+
+		clk.Step(280 * time.Second)
+		assert.Equal(t, 20*time.Second, reconcile(t, r).RequeueAfter)
+
+		clk.Step(20 * time.Second)
+		reconcile(t, r)
+		dfz := &freezerv1alpha1.DeploymentFreezer{}
+		require.NoError(t, r.Get(context.Background(), dfzKey, dfz))
+		assert.Equal(t, "web", dfz.Spec.TargetRef.Name)
+		assert.Equal(t, int64(1800), dfz.Spec.DurationSeconds)
+		assert.Equal(t, "breaker", dfz.Labels[freezerv1alpha1.LabelAutoFreezePolicy])
+		assert.Contains(t, dfz.Annotations[AnnoAutoFreezeReason], "CrashLoopBackOff")
+		assert.True(t, metav1.IsControlledBy(dfz, getPolicy(t, r)))
+		target := getPolicy(t, r).Status.Targets[0]
+		assert.Equal(t, dfzKey.Name, target.Freezer)
+		assert.True(t, target.FrozenAt.Time.Equal(clk.Now()))
+		assert.Len(t, r.Recorder.(*record.FakeRecorder).Events, 2, "owners are warned on the Deployment and the policy")
+	})
+
+	t.Run("CrashLoop_BelowMinRestartsOrRecovered", func(t *testing.T) {
+		t.Parallel()
+		r, _ := newReconciler(
+			newPolicy(),
+			newDeployment("web", breaker), newPod("web", 2, crashLoopBackOff),
+			newDeployment("api", breaker), newPod("api", 9, ""),
+			newDeployment("db", nil), newPod("db", 9, crashLoopBackOff),
+		)
+		reconcile(t, r)
+		assert.Empty(t, getPolicy(t, r).Status.Targets)
+	})
+
+	t.Run("Cooldown_NoSecondFreezeUntilItEnds", func(t *testing.T) {
+		t.Parallel()
+		afp := newPolicy()
+		afp.Spec.ForSeconds = 0
+		r, clk := newReconciler(afp, newDeployment("web", breaker), newPod("web", 5, crashLoopBackOff))
+		reconcile(t, r)
+		dfz := &freezerv1alpha1.DeploymentFreezer{}
+		require.NoError(t, r.Get(context.Background(), dfzKey, dfz))
+		dfz.Status.Phase = freezerv1alpha1.PhaseCompleted
+		require.NoError(t, r.Status().Update(context.Background(), dfz))
+
+		clk.Step(30 * time.Minute)
+		reconcile(t, r)
+		require.NoError(t, r.Get(context.Background(), dfzKey, dfz))
+		assert.Equal(t, freezerv1alpha1.PhaseCompleted, dfz.Status.Phase, "still cooling down")
+
+		// Once the cooldown is over the finished freeze makes room for a new one.
+		clk.Step(30 * time.Minute)
+		assert.Equal(t, requeueShort, reconcile(t, r).RequeueAfter)
+		reconcile(t, r)
+		require.NoError(t, r.Get(context.Background(), dfzKey, dfz))
+		assert.Empty(t, dfz.Status.Phase)
+		assert.True(t, getPolicy(t, r).Status.Targets[0].FrozenAt.Time.Equal(clk.Now()))
+	})
+
+	t.Run("Metric_ThresholdAndSubstitution", func(t *testing.T) {
+		t.Parallel()
+		afp := newPolicy()
+		afp.Spec.CrashLoop = nil
+		afp.Spec.ForSeconds = 0
+		afp.Spec.Metric = &freezerv1alpha1.MetricTrigger{
+			Query:     `burn_rate{namespace="$namespace", deployment="$deployment"}`,
+			Threshold: resource.MustParse("14.4"),
+		}
+		r, _ := newReconciler(afp, newDeployment("web", breaker))
+		metrics := &fakeMetrics{value: 10, ok: true}
+		r.Metrics = metrics
+
+		reconcile(t, r)
+		assert.Equal(t, []string{`burn_rate{namespace="shop", deployment="web"}`}, metrics.queries)
+		assert.Empty(t, getPolicy(t, r).Status.Targets)
+
+		metrics.err = errors.New("connection refused")
+		metrics.value = 20
+		reconcile(t, r)
+		assert.Empty(t, getPolicy(t, r).Status.Targets, "a failed query never trips")
+
+		metrics.err = nil
+		reconcile(t, r)
+		require.NoError(t, r.Get(context.Background(), dfzKey, &freezerv1alpha1.DeploymentFreezer{}))
+		assert.Contains(t, getPolicy(t, r).Status.Targets[0].Message, "above the threshold of 14400m")
+	})
+
+	t.Run("ForeignFreeze_LeftAlone", func(t *testing.T) {
+		t.Parallel()
+		afp := newPolicy()
+		afp.Spec.ForSeconds = 0
+		foreign := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: dfzKey.Name}}
+		foreign.Status.Phase = freezerv1alpha1.PhaseCompleted
+		r, _ := newReconciler(afp, foreign, newDeployment("web", breaker), newPod("web", 5, crashLoopBackOff))
+		reconcile(t, r)
+		require.NoError(t, r.Get(context.Background(), dfzKey, &freezerv1alpha1.DeploymentFreezer{}))
+		assert.Empty(t, getPolicy(t, r).Status.Targets[0].Freezer)
+	})
+
+	t.Run("FailedDeployment_OthersStillFrozen", func(t *testing.T) {
+		t.Parallel()
+		afp := newPolicy()
+		afp.Spec.ForSeconds = 0
+		r, _ := newReconciler(afp,
+			newDeployment("api", breaker), newPod("api", 5, crashLoopBackOff),
+			newDeployment("web", breaker), newPod("web", 5, crashLoopBackOff),
+		)
+		r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if obj.GetName() == "breaker-api" {
+					return errors.New("boom")
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		})
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: afpKey})
+		require.ErrorContains(t, err, "deployment api: boom")
+		require.NoError(t, r.Get(context.Background(), dfzKey, &freezerv1alpha1.DeploymentFreezer{}))
+		targets := getPolicy(t, r).Status.Targets
+		require.Len(t, targets, 2)
+		assert.Equal(t, "api", targets[0].Deployment)
+		assert.Empty(t, targets[0].Freezer)
+		assert.Nil(t, targets[0].FrozenAt, "a failed create does not start the cooldown")
+		assert.Equal(t, "breaker-web", targets[1].Freezer)
+	})
+}
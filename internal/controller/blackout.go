@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/internal/policy"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// holdForBlackout keeps the DFZ in its current phase while a FreezerPolicy blackout covers its
+// namespace, and reports whether it did. It is called right before each step that would scale
+// the Deployment, so blackouts take precedence over the DFZ's own timing. Like the kill switch,
+// blackouts that cannot be read hold the DFZ too.
+func (r *DeploymentFreezerReconciler) holdForBlackout(
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+) (ctrl.Result, bool) {
+	held := hasCondition(
+		dfz,
+		freezerv1alpha1.ConditionTypeBlackout,
+		freezerv1alpha1.ConditionStatusTrue,
+		freezerv1alpha1.ConditionReasonInBlackout,
+	)
+	blackout, active, err := policy.ActiveBlackout(ctx, r.Client, dfz.Namespace, r.Clock.Now())
+	switch {
+	case err != nil:
+		r.operationFailed(dfz, opEvaluatePolicy, fmt.Sprintf(msgBlackoutReadFailedFmt, err))
+		return ctrl.Result{RequeueAfter: requeueShort}, true
+	case active:
+		end := blackout.End.Format(time.RFC3339)
+		if !held {
+			r.Recorder.Eventf(dfz, corev1.EventTypeNormal, ReasonBlackoutHold, msgBlackoutHold,
+				dfz.Status.Phase, blackout.Name, blackout.Policy, end)
+		}
+		setStableCondition(
+			dfz,
+			freezerv1alpha1.ConditionTypeBlackout,
+			freezerv1alpha1.ConditionStatusTrue,
+			freezerv1alpha1.ConditionReasonInBlackout,
+			fmt.Sprintf(msgInBlackoutFmt, blackout.Name, blackout.Policy, end),
+		)
+		// Blackouts are not watched: recheck regularly so a shortened or deleted one is noticed.
+		return ctrl.Result{RequeueAfter: min(r.untilTime(blackout.End), driftCheckInterval)}, true
+	case held:
+		setCondition(
+			dfz,
+			freezerv1alpha1.ConditionTypeBlackout,
+			freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonBlackoutEnded,
+			msgBlackoutEnded,
+		)
+	}
+	return ctrl.Result{}, false
+}
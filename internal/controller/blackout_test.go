@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestBlackout(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	start := time.Date(2026, 11, 20, 0, 0, 0, 0, time.UTC)
+	end := start.Add(72 * time.Hour)
+	releaseFreeze := &freezerv1alpha1.FreezerPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "company"},
+		Spec: freezerv1alpha1.FreezerPolicySpec{Blackouts: []freezerv1alpha1.BlackoutWindow{{
+			Name:  "black-friday",
+			Start: metav1.NewTime(start),
+			End:   metav1.NewTime(end),
+		}}},
+	}
+	newReconciler := func(now time.Time, objs ...client.Object) (*DeploymentFreezerReconciler, *testingclock.FakeClock) {
+		clk := testingclock.NewFakeClock(now)
+		return &DeploymentFreezerReconciler{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+			Recorder: record.NewFakeRecorder(10),
+			Clock:    clk,
+		}, clk
+	}
+	newFrozen := func() (*freezerv1alpha1.DeploymentFreezer, *appsv1.Deployment) {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "dfz", UID: "dfz-uid"}}
+		dfz.Status.Phase = freezerv1alpha1.PhaseFrozen
+		dfz.Status.FreezeUntil = ptr.To(metav1.NewTime(start.Add(time.Hour)))
+		deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "shop",
+			Name:        "web",
+			Annotations: map[string]string{annoFrozenBy: frozenByValue(dfz)},
+		}}
+		deploy.Spec.Replicas = ptr.To(int32(0))
+		return dfz, deploy
+	}
+
+	t.Run("Frozen_HeldPastFreezeUntilThenResumes", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		r, clk := newReconciler(start.Add(2*time.Hour), releaseFreeze)
+		rec := r.Recorder.(*record.FakeRecorder)
+		dfz, deploy := newFrozen()
+
+		res, err := r.handleFrozen(ctx, dfz, deploy, freezeTarget(t, deploy))
+		require.NoError(t, err)
+		assert.Equal(t, freezerv1alpha1.PhaseFrozen, dfz.Status.Phase)
+		assert.Equal(t, driftCheckInterval, res.RequeueAfter)
+		assert.True(t, hasCondition(dfz, freezerv1alpha1.ConditionTypeBlackout,
+			freezerv1alpha1.ConditionStatusTrue, freezerv1alpha1.ConditionReasonInBlackout))
+		assert.True(t, hasCondition(dfz, freezerv1alpha1.ConditionTypeDriftDetected,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonNoDrift))
+
+		// The hold is announced once.
+		_, err = r.handleFrozen(ctx, dfz, deploy, freezeTarget(t, deploy))
+		require.NoError(t, err)
+		assert.Len(t, rec.Events, 1)
+
+		clk.SetTime(end.Add(-10 * time.Second))
+		res, err = r.handleFrozen(ctx, dfz, deploy, freezeTarget(t, deploy))
+		require.NoError(t, err)
+		assert.Equal(t, 10*time.Second, res.RequeueAfter)
+
+		clk.SetTime(end)
+		_, err = r.handleFrozen(ctx, dfz, deploy, freezeTarget(t, deploy))
+		require.NoError(t, err)
+		assert.Equal(t, freezerv1alpha1.PhaseUnfreezing, dfz.Status.Phase)
+		assert.True(t, hasCondition(dfz, freezerv1alpha1.ConditionTypeBlackout,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonBlackoutEnded))
+	})
+
+	t.Run("Pending_NoScaleDown", func(t *testing.T) {
+		t.Parallel()
+		deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web"}}
+		deploy.Spec.Replicas = ptr.To(int32(3))
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "dfz"}}
+		dfz.Status.Phase = freezerv1alpha1.PhasePending
+		r, _ := newReconciler(start, releaseFreeze, deploy)
+		target, err := r.freezer().Target(deploy)
+		require.NoError(t, err)
+
+		_, err = r.handlePendingOrFreezing(context.Background(), dfz, deploy, target)
+		require.NoError(t, err)
+		assert.Equal(t, freezerv1alpha1.PhasePending, dfz.Status.Phase)
+		// A policy declaring only blackouts allows every freeze.
+		assert.True(t, hasCondition(dfz, freezerv1alpha1.ConditionTypePolicy,
+			freezerv1alpha1.ConditionStatusTrue, freezerv1alpha1.ConditionReasonAllowed))
+
+		got := &appsv1.Deployment{}
+		require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(deploy), got))
+		assert.Equal(t, int32(3), *got.Spec.Replicas)
+		assert.NotContains(t, got.Annotations, freeze.AnnotationFrozenBy)
+	})
+
+	t.Run("OtherNamespace_NotHeld", func(t *testing.T) {
+		t.Parallel()
+		scoped := releaseFreeze.DeepCopy()
+		scoped.Spec.Blackouts[0].Namespaces = []string{"payments"}
+		r, _ := newReconciler(start, scoped)
+		dfz, _ := newFrozen()
+		_, held := r.holdForBlackout(context.Background(), dfz)
+		assert.False(t, held)
+		assert.Empty(t, dfz.Status.Conditions)
+	})
+}
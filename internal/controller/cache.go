@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// StripDeployment is the cache transform for Deployments. Besides managedFields it drops the
+// `kubectl apply` annotation, a full copy of the object that no controller reads. Deployments
+// are only ever patched with merge patches, so the stripped fields are never written back.
+func StripDeployment() toolscache.TransformFunc {
+	return func(in any) (any, error) {
+		d, ok := in.(*appsv1.Deployment)
+		if !ok {
+			return in, nil
+		}
+		d.SetManagedFields(nil)
+		delete(d.Annotations, corev1.LastAppliedConfigAnnotation)
+		return d, nil
+	}
+}
+
+// StripPod is the cache transform for Pods, read by the AutoFreezePolicy controller only. It
+// keeps the metadata, the phase and the container statuses, and drops the spec and the rest of
+// the status. Pods are never written, and the other controllers list them from the API server.
+func StripPod() toolscache.TransformFunc {
+	return func(in any) (any, error) {
+		p, ok := in.(*corev1.Pod)
+		if !ok {
+			return in, nil
+		}
+		p.SetManagedFields(nil)
+		p.Annotations = nil
+		p.Spec = corev1.PodSpec{}
+		p.Status = corev1.PodStatus{Phase: p.Status.Phase, ContainerStatuses: p.Status.ContainerStatuses}
+		return p, nil
+	}
+}
+
+// targetOutsideCache reports whether a Deployment missing from the cache exists on the API server
+// but does not match DeploymentSelector. Only its metadata is read.
+func (r *DeploymentFreezerReconciler) targetOutsideCache(ctx context.Context, key types.NamespacedName) (bool, error) {
+	if r.DeploymentSelector == nil || r.DeploymentSelector.Empty() {
+		return false, nil
+	}
+	var meta metav1.PartialObjectMetadata
+	meta.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("Deployment"))
+	if err := r.APIReader.Get(ctx, key, &meta); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return !r.DeploymentSelector.Matches(labels.Set(meta.Labels)), nil
+}
+
+// markNotSelected keeps the DFZ where it is until its target is labeled into the cache. A DFZ
+// being deleted keeps its finalizer too, so a frozen Deployment is still restored.
+func (r *DeploymentFreezerReconciler) markNotSelected(dfz *freezerv1alpha1.DeploymentFreezer) {
+	if dfz.Status.Phase == "" {
+		r.setPhase(dfz, freezerv1alpha1.PhasePending)
+	}
+	setStableCondition(
+		dfz,
+		freezerv1alpha1.ConditionTypeTargetFound,
+		freezerv1alpha1.ConditionStatusFalse,
+		freezerv1alpha1.ConditionReasonNotSelected,
+		fmt.Sprintf(msgTargetNotSelectedFmt, r.DeploymentSelector.String()),
+	)
+}
+
+// markSelected clears NotSelected once the target shows up in the cache.
+func markSelected(dfz *freezerv1alpha1.DeploymentFreezer) {
+	if hasCondition(
+		dfz,
+		freezerv1alpha1.ConditionTypeTargetFound,
+		freezerv1alpha1.ConditionStatusFalse,
+		freezerv1alpha1.ConditionReasonNotSelected,
+	) {
+		setCondition(
+			dfz,
+			freezerv1alpha1.ConditionTypeTargetFound,
+			freezerv1alpha1.ConditionStatusTrue,
+			freezerv1alpha1.ConditionReasonFound,
+			msgTargetSelected,
+		)
+	}
+}
@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestStripDeployment(t *testing.T) {
+	t.Run("Deployment_DropsManagedFieldsAndLastApplied", func(t *testing.T) {
+		t.Parallel()
+		in := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name:          "web",
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+			Annotations: map[string]string{
+				corev1.LastAppliedConfigAnnotation: `{"big":"json"}`,
+				annoFrozenBy:                       "ns/dfz/uid",
+			},
+		}}
+		out, err := StripDeployment()(in)
+		require.NoError(t, err)
+
+		d := out.(*appsv1.Deployment)
+		assert.Nil(t, d.ManagedFields)
+		assert.Equal(t, map[string]string{annoFrozenBy: "ns/dfz/uid"}, d.Annotations)
+	})
+
+	t.Run("Tombstone_Unchanged", func(t *testing.T) {
+		t.Parallel()
+		in := toolscache.DeletedFinalStateUnknown{Key: "ns/web"}
+		out, err := StripDeployment()(in)
+		require.NoError(t, err)
+		assert.Equal(t, in, out)
+	})
+}
+
+func TestDeploymentSelector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	selector := labels.SelectorFromSet(labels.Set{"freezable": "true"})
+	newDFZ := func() *freezerv1alpha1.DeploymentFreezer {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "freeze"}}
+		dfz.Spec.TargetRef.Name = "web"
+		return dfz
+	}
+	// The cache (Client) holds only the DFZ; the API server (APIReader) also has the Deployment.
+	reconcile := func(t *testing.T, sel labels.Selector, apiObjs ...client.Object) *freezerv1alpha1.DeploymentFreezer {
+		dfz := newDFZ()
+		r := &DeploymentFreezerReconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(dfz).
+				WithStatusSubresource(&freezerv1alpha1.DeploymentFreezer{}).Build(),
+			APIReader:          fake.NewClientBuilder().WithScheme(scheme).WithObjects(apiObjs...).Build(),
+			Recorder:           record.NewFakeRecorder(10),
+			Clock:              testingclock.NewFakeClock(time.Now()),
+			DeploymentSelector: sel,
+		}
+		res, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(dfz)})
+		require.NoError(t, err)
+		assert.Zero(t, res)
+
+		var got freezerv1alpha1.DeploymentFreezer
+		require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(dfz), &got))
+		return &got
+	}
+	unlabeled := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web"}}
+
+	t.Run("NotSelected_WaitsPending", func(t *testing.T) {
+		t.Parallel()
+		got := reconcile(t, selector, unlabeled)
+		assert.Equal(t, freezerv1alpha1.PhasePending, got.Status.Phase)
+		assert.True(t, hasCondition(got, freezerv1alpha1.ConditionTypeTargetFound,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonNotSelected))
+		assert.Empty(t, got.Finalizers)
+	})
+
+	t.Run("Missing_Aborted", func(t *testing.T) {
+		t.Parallel()
+		got := reconcile(t, selector)
+		assert.Equal(t, freezerv1alpha1.PhaseAborted, got.Status.Phase)
+		assert.True(t, hasCondition(got, freezerv1alpha1.ConditionTypeTargetFound,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonNotFound))
+	})
+
+	t.Run("NoSelector_Aborted", func(t *testing.T) {
+		t.Parallel()
+		got := reconcile(t, nil, unlabeled)
+		assert.Equal(t, freezerv1alpha1.PhaseAborted, got.Status.Phase)
+	})
+
+	t.Run("Selected_ClearsNotSelected", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ()
+		r := &DeploymentFreezerReconciler{DeploymentSelector: selector}
+		r.markNotSelected(dfz)
+		markSelected(dfz)
+		assert.True(t, hasCondition(dfz, freezerv1alpha1.ConditionTypeTargetFound,
+			freezerv1alpha1.ConditionStatusTrue, freezerv1alpha1.ConditionReasonFound))
+	})
+}
+
+func TestStripPod(t *testing.T) {
+	in := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          "web-1",
+			Labels:        map[string]string{"app": "web"},
+			Annotations:   map[string]string{"a": "b"},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubelet"}},
+		},
+		Spec: corev1.PodSpec{NodeName: "node-1", Containers: []corev1.Container{{Name: "app"}}},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			PodIP:             "10.0.0.1",
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "app", RestartCount: 4}},
+		},
+	}
+	out, err := StripPod()(in)
+	require.NoError(t, err)
+	pod := out.(*corev1.Pod)
+	assert.Equal(t, map[string]string{"app": "web"}, pod.Labels)
+	assert.Nil(t, pod.Annotations)
+	assert.Nil(t, pod.ManagedFields)
+	assert.Empty(t, pod.Spec.Containers)
+	assert.Empty(t, pod.Status.PodIP)
+	assert.Equal(t, corev1.PodRunning, pod.Status.Phase)
+	assert.Equal(t, int32(4), pod.Status.ContainerStatuses[0].RestartCount)
+}
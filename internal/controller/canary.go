@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// canaryReplicas is the number of replicas restored before the rest of a Canary unfreeze.
+const canaryReplicas int32 = 1
+
+// canaryUnfreeze reports whether the DFZ restores replicas through a canary step.
+func canaryUnfreeze(dfz *freezerv1alpha1.DeploymentFreezer) bool {
+	s := dfz.Spec.UnfreezeStrategy
+	return s != nil && s.Type == freezerv1alpha1.UnfreezeStrategyCanary
+}
+
+// runCanary drives the canary step of a Canary unfreeze. It returns done=true once the canary
+// replica has been Ready for spec.unfreezeStrategy.stableSeconds and the full count may be restored.
+// A failed canary sets Health=Degraded and pauses the unfreeze at one replica.
+func (r *DeploymentFreezerReconciler) runCanary(
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	deploy *appsv1.Deployment,
+) (ctrl.Result, bool) {
+	if dfz.Status.Canary == nil {
+		dfz.Status.Canary = &freezerv1alpha1.CanaryStatus{}
+	}
+	canary := dfz.Status.Canary
+	if canary.Failed {
+		// Paused; a spec change (e.g. switching to Immediate) triggers the next reconcile.
+		return ctrl.Result{}, false
+	}
+
+	now := r.Clock.Now()
+	if canary.StartedAt == nil {
+		t := metav1.NewTime(now.UTC())
+		canary.StartedAt = &t
+	}
+
+	if ptr.Deref(deploy.Spec.Replicas, 1) < canaryReplicas {
+		change := freeze.Change{Replicas: ptr.To(canaryReplicas)}
+		if err := r.freezer().Update(ctx, deploy, change, r.patchOpts(dfz)...); err != nil {
+			setCondition(
+				dfz,
+				freezerv1alpha1.ConditionTypeUnfreezeProgress,
+				freezerv1alpha1.ConditionStatusFalse,
+				freezerv1alpha1.ConditionReasonQuotaExceeded,
+				fmt.Sprintf(msgFailedRestoreReplicasFmt, canaryReplicas, err),
+			)
+			return ctrl.Result{RequeueAfter: requeueMedium}, false
+		}
+		setCondition(
+			dfz,
+			freezerv1alpha1.ConditionTypeUnfreezeProgress,
+			freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonCanary,
+			msgCanaryStarted,
+		)
+		r.Recorder.Event(dfz, corev1.EventTypeNormal, ReasonCanaryStarted, msgCanaryStartedEvent)
+		return ctrl.Result{RequeueAfter: requeueShort}, false
+	}
+
+	// The Deployment watch ignores status updates, so the canary is polled.
+	if deploy.Status.ObservedGeneration < deploy.Generation {
+		return ctrl.Result{RequeueAfter: requeueShort}, false
+	}
+
+	strategy := dfz.Spec.UnfreezeStrategy
+	if failure := canaryFailure(deploy, canary, strategy, now); failure != "" {
+		canary.Failed = true
+		canary.ReadySince = nil
+		setCondition(
+			dfz,
+			freezerv1alpha1.ConditionTypeHealth,
+			freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonDegraded,
+			failure,
+		)
+		setCondition(
+			dfz,
+			freezerv1alpha1.ConditionTypeUnfreezeProgress,
+			freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonCanary,
+			msgCanaryPaused,
+		)
+		r.Recorder.Event(dfz, corev1.EventTypeWarning, ReasonCanaryFailed, failure)
+		return ctrl.Result{}, false
+	}
+
+	if deploy.Status.ReadyReplicas < canaryReplicas {
+		setCondition(
+			dfz,
+			freezerv1alpha1.ConditionTypeUnfreezeProgress,
+			freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonCanary,
+			msgCanaryWaitingReady,
+		)
+		return ctrl.Result{RequeueAfter: requeueShort}, false
+	}
+
+	if canary.ReadySince == nil {
+		t := metav1.NewTime(now.UTC())
+		canary.ReadySince = &t
+	}
+	stableAt := canary.ReadySince.Add(time.Duration(strategy.StableSeconds) * time.Second)
+	if now.Before(stableAt) {
+		setCondition(
+			dfz,
+			freezerv1alpha1.ConditionTypeUnfreezeProgress,
+			freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonCanary,
+			fmt.Sprintf(msgCanaryWaitingStableFmt, stableAt.UTC().Format(time.RFC3339)),
+		)
+		// Keep polling while waiting so a replica that stops being Ready is noticed.
+		return ctrl.Result{RequeueAfter: min(r.untilTime(stableAt), requeueShort)}, false
+	}
+
+	return ctrl.Result{}, true
+}
+
+// canaryFailure returns why the canary failed, or "" if it has not (yet) failed.
+func canaryFailure(
+	deploy *appsv1.Deployment,
+	canary *freezerv1alpha1.CanaryStatus,
+	strategy *freezerv1alpha1.UnfreezeStrategy,
+	now time.Time,
+) string {
+	for _, c := range deploy.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Status == corev1.ConditionFalse &&
+			c.Reason == "ProgressDeadlineExceeded" {
+			return fmt.Sprintf(msgCanaryProgressDeadlineFmt, c.Message)
+		}
+	}
+
+	if deploy.Status.ReadyReplicas >= canaryReplicas {
+		return ""
+	}
+	if canary.ReadySince != nil {
+		return msgCanaryNotStable
+	}
+	if timeout := time.Duration(strategy.ReadyTimeoutSeconds) * time.Second; timeout > 0 &&
+		canary.StartedAt != nil && !now.Before(canary.StartedAt.Add(timeout)) {
+		return fmt.Sprintf(msgCanaryReadyTimeoutFmt, timeout)
+	}
+	return ""
+}
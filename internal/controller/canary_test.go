@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCanaryFailure(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	strategy := &freezerv1alpha1.UnfreezeStrategy{
+		Type:                freezerv1alpha1.UnfreezeStrategyCanary,
+		StableSeconds:       60,
+		ReadyTimeoutSeconds: 600,
+	}
+	started := metav1.NewTime(start)
+
+	newDeploy := func(ready int32) *appsv1.Deployment {
+		d := &appsv1.Deployment{}
+		d.Status.ReadyReplicas = ready
+		return d
+	}
+
+	t.Run("NotReadyWithinTimeout_Waits", func(t *testing.T) {
+		t.Parallel()
+		canary := &freezerv1alpha1.CanaryStatus{StartedAt: &started}
+		assert.Empty(t, canaryFailure(newDeploy(0), canary, strategy, start.Add(time.Minute)))
+	})
+
+	t.Run("NotReadyAfterTimeout_Fails", func(t *testing.T) {
+		t.Parallel()
+		canary := &freezerv1alpha1.CanaryStatus{StartedAt: &started}
+		assert.NotEmpty(t, canaryFailure(newDeploy(0), canary, strategy, start.Add(10*time.Minute)))
+	})
+
+	t.Run("ZeroTimeout_WaitsForever", func(t *testing.T) {
+		t.Parallel()
+		noTimeout := *strategy
+		noTimeout.ReadyTimeoutSeconds = 0
+		canary := &freezerv1alpha1.CanaryStatus{StartedAt: &started}
+		assert.Empty(t, canaryFailure(newDeploy(0), canary, &noTimeout, start.Add(24*time.Hour)))
+	})
+
+	t.Run("Ready_NoFailure", func(t *testing.T) {
+		t.Parallel()
+		canary := &freezerv1alpha1.CanaryStatus{StartedAt: &started}
+		assert.Empty(t, canaryFailure(newDeploy(1), canary, strategy, start.Add(time.Hour)))
+	})
+
+	t.Run("ReadyLost_Fails", func(t *testing.T) {
+		t.Parallel()
+		readySince := metav1.NewTime(start.Add(time.Minute))
+		canary := &freezerv1alpha1.CanaryStatus{StartedAt: &started, ReadySince: &readySince}
+		assert.Equal(t, msgCanaryNotStable, canaryFailure(newDeploy(0), canary, strategy, start.Add(90*time.Second)))
+	})
+
+	t.Run("ProgressDeadlineExceeded_Fails", func(t *testing.T) {
+		t.Parallel()
+		d := newDeploy(1)
+		d.Status.Conditions = []appsv1.DeploymentCondition{{
+			Type:   appsv1.DeploymentProgressing,
+			Status: corev1.ConditionFalse,
+			Reason: "ProgressDeadlineExceeded",
+		}}
+		canary := &freezerv1alpha1.CanaryStatus{StartedAt: &started}
+		assert.NotEmpty(t, canaryFailure(d, canary, strategy, start))
+	})
+}
@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"text/template"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// maxConditionMessage is the longest condition message the CRD accepts.
+const maxConditionMessage = 2048
+
+// MessageCatalog replaces the condition and event messages of DeploymentFreezers, by reason,
+// with text templates rendered from the built-in message. It lets an organization translate
+// messages or append links to its runbooks.
+type MessageCatalog struct {
+	conditions map[freezerv1alpha1.ConditionReason]*template.Template
+	events     map[string]*template.Template
+}
+
+// MessageData is what a catalog template is rendered with.
+type MessageData struct {
+	// Message is the built-in message.
+	Message string
+	// Reason is the condition or event reason the template is keyed by.
+	Reason string
+	// Type is the condition type, or the event type (Normal or Warning).
+	Type string
+	// Namespace and Name are those of the object the message is about.
+	Namespace string
+	Name      string
+	// Target is the name of the DeploymentFreezer's target, and Phase its phase; both are empty
+	// for events about other objects.
+	Target string
+	Phase  string
+}
+
+// messageCatalogFile is the format of a catalog file: templates by condition and event reason.
+type messageCatalogFile struct {
+	Conditions map[string]string `json:"conditions,omitempty"`
+	Events     map[string]string `json:"events,omitempty"`
+}
+
+// LoadMessageCatalog parses a catalog file. Every template is rendered once with empty data, so
+// a reference to an unknown field is reported here rather than when the message is written.
+func LoadMessageCatalog(data []byte) (*MessageCatalog, error) {
+	var file messageCatalogFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, err
+	}
+	c := &MessageCatalog{
+		conditions: map[freezerv1alpha1.ConditionReason]*template.Template{},
+		events:     map[string]*template.Template{},
+	}
+	for reason, text := range file.Conditions {
+		tpl, err := parseMessageTemplate("conditions."+reason, text)
+		if err != nil {
+			return nil, err
+		}
+		c.conditions[freezerv1alpha1.ConditionReason(reason)] = tpl
+	}
+	for reason, text := range file.Events {
+		tpl, err := parseMessageTemplate("events."+reason, text)
+		if err != nil {
+			return nil, err
+		}
+		c.events[reason] = tpl
+	}
+	return c, nil
+}
+
+func parseMessageTemplate(name, text string) (*template.Template, error) {
+	tpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if err := tpl.Execute(&strings.Builder{}, MessageData{}); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return tpl, nil
+}
+
+// messageCatalog is the catalog in use; nil keeps the built-in messages. It is package state
+// rather than a reconciler field because conditions are set from plain functions throughout
+// the package.
+var messageCatalog atomic.Pointer[MessageCatalog]
+
+// SetMessageCatalog makes c override the messages of every controller of the package; nil
+// restores the built-in messages.
+func SetMessageCatalog(c *MessageCatalog) {
+	messageCatalog.Store(c)
+}
+
+// conditionMessage returns the catalog's message for a condition of dfz, or message when the
+// catalog has none for reason.
+func conditionMessage(
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	condType freezerv1alpha1.ConditionType,
+	reason freezerv1alpha1.ConditionReason,
+	message string,
+) string {
+	c := messageCatalog.Load()
+	if c == nil || c.conditions[reason] == nil {
+		return message
+	}
+	data := dfzMessageData(dfz, message)
+	data.Reason, data.Type = string(reason), string(condType)
+	out := render(c.conditions[reason], data, message)
+	if r := []rune(out); len(r) > maxConditionMessage {
+		out = string(r[:maxConditionMessage])
+	}
+	return out
+}
+
+// eventMessage returns the catalog's message for an event about obj, or message when the
+// catalog has none for reason.
+func eventMessage(obj runtime.Object, eventtype, reason, message string) string {
+	c := messageCatalog.Load()
+	if c == nil || c.events[reason] == nil {
+		return message
+	}
+	var data MessageData
+	if dfz, ok := obj.(*freezerv1alpha1.DeploymentFreezer); ok {
+		data = dfzMessageData(dfz, message)
+	} else if accessor, err := meta.Accessor(obj); err == nil {
+		data = MessageData{Message: message, Namespace: accessor.GetNamespace(), Name: accessor.GetName()}
+	}
+	data.Reason, data.Type = reason, eventtype
+	return render(c.events[reason], data, message)
+}
+
+func dfzMessageData(dfz *freezerv1alpha1.DeploymentFreezer, message string) MessageData {
+	return MessageData{
+		Message:   message,
+		Namespace: dfz.Namespace,
+		Name:      dfz.Name,
+		Target:    dfz.TargetName(),
+		Phase:     string(dfz.Status.Phase),
+	}
+}
+
+// render executes tpl, falling back to the built-in message should it fail.
+func render(tpl *template.Template, data MessageData, fallback string) string {
+	var b strings.Builder
+	if err := tpl.Execute(&b, data); err != nil {
+		return fallback
+	}
+	return b.String()
+}
@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+// TestMessageCatalog sets the package catalog, so its subtests do not run in parallel.
+func TestMessageCatalog(t *testing.T) {
+	catalog, err := LoadMessageCatalog([]byte(`
+conditions:
+  AwaitingPDB: "{{ .Message }}. Runbook: https://runbooks.example.com/pdb?dfz={{ .Namespace }}/{{ .Name }}"
+  ScaledToZero: "{{ .Target }} ist auf null skaliert"
+events:
+  Frozen: "{{ .Type }}: {{ .Target }} eingefroren ({{ .Message }})"
+  FreezeGroupSynced: "{{ .Namespace }}/{{ .Name }}: {{ .Message }}"
+`))
+	require.NoError(t, err)
+	SetMessageCatalog(catalog)
+	t.Cleanup(func() { SetMessageCatalog(nil) })
+
+	newDFZ := func() *freezerv1alpha1.DeploymentFreezer {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "freeze"}}
+		dfz.Spec.TargetRef.Name = "web"
+		return dfz
+	}
+	message := func(dfz *freezerv1alpha1.DeploymentFreezer, condType freezerv1alpha1.ConditionType) string {
+		for _, c := range dfz.Status.Conditions {
+			if c.Type == condType {
+				return c.Message
+			}
+		}
+		return ""
+	}
+
+	t.Run("Condition_Templated", func(t *testing.T) {
+		dfz := newDFZ()
+		setCondition(dfz, freezerv1alpha1.ConditionTypeFreezeProgress, freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonAwaitingPDB, "scale-down blocked")
+		assert.Equal(t, "scale-down blocked. Runbook: https://runbooks.example.com/pdb?dfz=shop/freeze",
+			message(dfz, freezerv1alpha1.ConditionTypeFreezeProgress))
+
+		setStableCondition(dfz, freezerv1alpha1.ConditionTypeFreezeProgress, freezerv1alpha1.ConditionStatusTrue,
+			freezerv1alpha1.ConditionReasonScaledToZero, msgDeploymentFullyScaledToZero)
+		assert.Equal(t, "web ist auf null skaliert", message(dfz, freezerv1alpha1.ConditionTypeFreezeProgress))
+	})
+
+	t.Run("Condition_NoTemplate_BuiltIn", func(t *testing.T) {
+		dfz := newDFZ()
+		setCondition(dfz, freezerv1alpha1.ConditionTypeTargetFound, freezerv1alpha1.ConditionStatusTrue,
+			freezerv1alpha1.ConditionReasonFound, "found")
+		assert.Equal(t, "found", message(dfz, freezerv1alpha1.ConditionTypeTargetFound))
+	})
+
+	t.Run("Condition_TruncatedToCRDLimit", func(t *testing.T) {
+		dfz := newDFZ()
+		setCondition(dfz, freezerv1alpha1.ConditionTypeFreezeProgress, freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonAwaitingPDB, strings.Repeat("ü", 3000))
+		assert.Len(t, []rune(message(dfz, freezerv1alpha1.ConditionTypeFreezeProgress)), maxConditionMessage)
+	})
+
+	t.Run("Event_Templated", func(t *testing.T) {
+		rec := record.NewFakeRecorder(10)
+		r := EventPolicy{}.Wrap(rec, testingclock.NewFakeClock(time.Now()))
+		r.Eventf(newDFZ(), corev1.EventTypeNormal, ReasonFrozen, msgFrozenUntil, "noon")
+		r.Event(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web"}},
+			corev1.EventTypeNormal, ReasonFreezeGroupSynced, "synced")
+		r.Event(newDFZ(), corev1.EventTypeWarning, ReasonRestoreFailed, "boom")
+		assert.Equal(t, []string{
+			"Normal Frozen Normal: web eingefroren (Deployment frozen until noon)",
+			"Normal FreezeGroupSynced shop/web: synced",
+			"Warning RestoreReplicasFailed boom",
+		}, drainEvents(rec))
+	})
+}
+
+func TestLoadMessageCatalog(t *testing.T) {
+	for name, data := range map[string]string{
+		"UnknownSection": "reasons: {}",
+		"BadSyntax":      "conditions:\n  AwaitingPDB: '{{ .Message'",
+		"UnknownField":   "events:\n  Frozen: '{{ .Deployment }}'",
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := LoadMessageCatalog([]byte(data))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/utils/clock"
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+// simulationTick is how often the simulated clock is advanced.
+const simulationTick = 100 * time.Millisecond
+
+// SimulatedClock is a fake clock that runs Scale times faster than wall time.
+// It lets long freeze windows be exercised in minutes instead of hours.
+type SimulatedClock struct {
+	*testingclock.FakeClock
+	// Scale is the number of simulated seconds per real second.
+	Scale float64
+}
+
+var _ clock.Clock = &SimulatedClock{}
+
+// NewSimulatedClock returns a clock starting at start and advancing Scale times faster than real time
+// once started by the manager.
+func NewSimulatedClock(start time.Time, scale float64) *SimulatedClock {
+	return &SimulatedClock{FakeClock: testingclock.NewFakeClock(start), Scale: scale}
+}
+
+// Start advances the clock until ctx is done. It implements manager.Runnable.
+func (c *SimulatedClock) Start(ctx context.Context) error {
+	ticker := time.NewTicker(simulationTick)
+	defer ticker.Stop()
+	last := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case t := <-ticker.C:
+			c.Step(c.simulated(t.Sub(last)))
+			last = t
+		}
+	}
+}
+
+// NeedLeaderElection lets the clock run on every replica. It implements manager.LeaderElectionRunnable.
+func (c *SimulatedClock) NeedLeaderElection() bool {
+	return false
+}
+
+// RealDuration converts a simulated duration into the wall-clock time it takes to elapse.
+func (c *SimulatedClock) RealDuration(d time.Duration) time.Duration {
+	if c.Scale <= 0 {
+		return d
+	}
+	return time.Duration(float64(d) / c.Scale)
+}
+
+func (c *SimulatedClock) simulated(d time.Duration) time.Duration {
+	if c.Scale <= 0 {
+		return d
+	}
+	return time.Duration(float64(d) * c.Scale)
+}
+
+// now reads the reconciler clock, which is only unset in tests that build a reconciler by hand.
+func (r *DeploymentFreezerReconciler) now() time.Time {
+	if r.Clock == nil {
+		return time.Now()
+	}
+	return r.Clock.Now()
+}
+
+// untilTime returns how long the work queue should wait before t is reached on the reconciler clock.
+func (r *DeploymentFreezerReconciler) untilTime(t time.Time) time.Duration {
+	d := t.Sub(r.Clock.Now())
+	if sc, ok := r.Clock.(*SimulatedClock); ok {
+		d = sc.RealDuration(d)
+	}
+	return d
+}
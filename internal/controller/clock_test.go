@@ -0,0 +1,32 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+func TestUntilTime(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("FakeClock_SimulatedEqualsReal", func(t *testing.T) {
+		t.Parallel()
+		r := &DeploymentFreezerReconciler{Clock: testingclock.NewFakeClock(start)}
+		assert.Equal(t, time.Hour, r.untilTime(start.Add(time.Hour)))
+	})
+
+	t.Run("SimulatedClock_ScalesDown", func(t *testing.T) {
+		t.Parallel()
+		r := &DeploymentFreezerReconciler{Clock: NewSimulatedClock(start, 60)}
+		assert.Equal(t, time.Minute, r.untilTime(start.Add(time.Hour)))
+	})
+
+	t.Run("SimulatedClock_StepAdvancesNow", func(t *testing.T) {
+		t.Parallel()
+		c := NewSimulatedClock(start, 10)
+		c.Step(c.simulated(time.Second))
+		assert.Equal(t, start.Add(10*time.Second), c.Now())
+	})
+}
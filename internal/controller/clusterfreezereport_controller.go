@@ -0,0 +1,187 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// reportMaxItems caps every list of a ClusterFreezeReport to keep the object small.
+	reportMaxItems = 100
+	// reportRefreshInterval rebuilds reports periodically, since their time windows move.
+	reportRefreshInterval = time.Minute
+
+	defaultReportUpcomingWindow = time.Hour
+	defaultReportRecentWindow   = 24 * time.Hour
+)
+
+// ClusterFreezeReportReconciler keeps ClusterFreezeReports up to date with all DeploymentFreezers.
+type ClusterFreezeReportReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Clock is the time source for the report windows; defaults to the real clock.
+	Clock clock.Clock
+}
+
+// +kubebuilder:rbac:groups=apps.boolfixer.dev,resources=clusterfreezereports,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=apps.boolfixer.dev,resources=clusterfreezereports/status,verbs=get;update;patch
+
+func (r *ClusterFreezeReportReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var report freezerv1alpha1.ClusterFreezeReport
+	if err := r.Get(ctx, req.NamespacedName, &report); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	var list freezerv1alpha1.DeploymentFreezerList
+	if err := r.List(ctx, &list); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	now := r.Clock.Now()
+	status := buildReport(&report.Spec, list.Items, now)
+	// LastUpdated only moves when the content changes, so unchanged rebuilds cause no writes.
+	status.LastUpdated = report.Status.LastUpdated
+	if !equality.Semantic.DeepEqual(status, report.Status) {
+		t := metav1.NewTime(now.UTC())
+		status.LastUpdated = &t
+		orig := report.DeepCopy()
+		report.Status = status
+		if err := r.Status().Patch(ctx, &report, client.MergeFrom(orig)); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{RequeueAfter: reportRefreshInterval}, nil
+}
+
+// buildReport summarizes the DFZs as seen at now.
+func buildReport(
+	spec *freezerv1alpha1.ClusterFreezeReportSpec,
+	dfzs []freezerv1alpha1.DeploymentFreezer,
+	now time.Time,
+) freezerv1alpha1.ClusterFreezeReportStatus {
+	upcomingUntil := now.Add(windowOrDefault(spec.UpcomingWindowSeconds, defaultReportUpcomingWindow))
+	recentSince := now.Add(-windowOrDefault(spec.RecentWindowSeconds, defaultReportRecentWindow))
+
+	status := freezerv1alpha1.ClusterFreezeReportStatus{}
+	for i := range dfzs {
+		dfz := &dfzs[i]
+		phase := cmp.Or(dfz.Status.Phase, freezerv1alpha1.PhasePending)
+		if status.Phases == nil {
+			status.Phases = map[freezerv1alpha1.Phase]int32{}
+		}
+		status.Phases[phase]++
+
+		summary := summarize(dfz, phase)
+		switch phase {
+		case freezerv1alpha1.PhaseFreezing, freezerv1alpha1.PhaseFrozen, freezerv1alpha1.PhaseUnfreezing:
+			status.Active = append(status.Active, summary)
+			if phase == freezerv1alpha1.PhaseFrozen && summary.FreezeUntil != nil &&
+				summary.FreezeUntil.Time.Before(upcomingUntil) {
+				status.UpcomingUnfreezes = append(status.UpcomingUnfreezes, summary)
+			}
+		case freezerv1alpha1.PhaseAborted:
+			if summary.Since != nil && !summary.Since.Time.Before(recentSince) {
+				status.RecentAborts = append(status.RecentAborts, summary)
+			}
+		}
+	}
+
+	slices.SortFunc(status.Active, func(a, b freezerv1alpha1.FreezeSummary) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+	slices.SortFunc(status.UpcomingUnfreezes, func(a, b freezerv1alpha1.FreezeSummary) int {
+		return a.FreezeUntil.Time.Compare(b.FreezeUntil.Time)
+	})
+	slices.SortFunc(status.RecentAborts, func(a, b freezerv1alpha1.FreezeSummary) int {
+		return b.Since.Time.Compare(a.Since.Time)
+	})
+
+	for _, items := range []*[]freezerv1alpha1.FreezeSummary{
+		&status.Active, &status.UpcomingUnfreezes, &status.RecentAborts,
+	} {
+		if len(*items) > reportMaxItems {
+			*items = (*items)[:reportMaxItems]
+			status.Truncated = true
+		}
+	}
+	return status
+}
+
+func summarize(dfz *freezerv1alpha1.DeploymentFreezer, phase freezerv1alpha1.Phase) freezerv1alpha1.FreezeSummary {
+	s := freezerv1alpha1.FreezeSummary{
+		Namespace:      dfz.Namespace,
+		Name:           dfz.Name,
+		Target:         dfz.TargetName(),
+		Phase:          phase,
+		FreezeUntil:    dfz.Status.FreezeUntil,
+		ResourcesFreed: dfz.Status.ResourcesFreed,
+	}
+	if t, ok := dfz.Status.PhaseTransitionTimes[phase]; ok {
+		s.Since = &t
+	}
+	return s
+}
+
+func windowOrDefault(seconds int64, def time.Duration) time.Duration {
+	if seconds <= 0 {
+		return def
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterFreezeReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Clock == nil {
+		r.Clock = clock.RealClock{}
+	}
+	if err := mgr.Add(manager.RunnableFunc(r.ensureDefaultReport)); err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&freezerv1alpha1.ClusterFreezeReport{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&freezerv1alpha1.DeploymentFreezer{}, handler.EnqueueRequestsFromMapFunc(r.allReports)).
+		Named("clusterfreezereport").
+		Complete(r)
+}
+
+// ensureDefaultReport creates the default ClusterFreezeReport if it does not exist yet.
+// A failure is only logged: the report is informational and must not stop the manager.
+func (r *ClusterFreezeReportReconciler) ensureDefaultReport(ctx context.Context) error {
+	report := &freezerv1alpha1.ClusterFreezeReport{
+		ObjectMeta: metav1.ObjectMeta{Name: freezerv1alpha1.DefaultClusterFreezeReportName},
+	}
+	if err := r.Create(ctx, report); err != nil && !apierrors.IsAlreadyExists(err) {
+		ctrl.Log.WithName("clusterfreezereport").Error(err, "unable to create the default ClusterFreezeReport")
+	}
+	return nil
+}
+
+// allReports enqueues every ClusterFreezeReport; any DFZ change may affect all of them.
+func (r *ClusterFreezeReportReconciler) allReports(ctx context.Context, _ client.Object) []reconcile.Request {
+	var reports freezerv1alpha1.ClusterFreezeReportList
+	if err := r.List(ctx, &reports); err != nil {
+		return nil
+	}
+	reqs := make([]reconcile.Request, 0, len(reports.Items))
+	for _, report := range reports.Items {
+		reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: report.Name}})
+	}
+	return reqs
+}
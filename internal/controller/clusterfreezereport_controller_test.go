@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildReport(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	spec := &freezerv1alpha1.ClusterFreezeReportSpec{UpcomingWindowSeconds: 3600, RecentWindowSeconds: 86400}
+
+	newDFZ := func(name string, phase freezerv1alpha1.Phase, since time.Time, until *time.Time) freezerv1alpha1.DeploymentFreezer {
+		dfz := freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name}}
+		dfz.Spec.TargetRef.Name = name
+		dfz.Status.Phase = phase
+		dfz.Status.PhaseTransitionTimes = map[freezerv1alpha1.Phase]metav1.Time{phase: metav1.NewTime(since)}
+		if until != nil {
+			ts := metav1.NewTime(*until)
+			dfz.Status.FreezeUntil = &ts
+		}
+		return dfz
+	}
+	at := func(d time.Duration) *time.Time {
+		ts := now.Add(d)
+		return &ts
+	}
+
+	t.Run("Classifies_DeploymentFreezers", func(t *testing.T) {
+		t.Parallel()
+		status := buildReport(spec, []freezerv1alpha1.DeploymentFreezer{
+			newDFZ("late", freezerv1alpha1.PhaseFrozen, now, at(50*time.Minute)),
+			newDFZ("soon", freezerv1alpha1.PhaseFrozen, now, at(10*time.Minute)),
+			newDFZ("far", freezerv1alpha1.PhaseFrozen, now, at(5*time.Hour)),
+			newDFZ("freezing", freezerv1alpha1.PhaseFreezing, now, nil),
+			newDFZ("aborted-old", freezerv1alpha1.PhaseAborted, now.Add(-48*time.Hour), nil),
+			newDFZ("aborted-1", freezerv1alpha1.PhaseAborted, now.Add(-2*time.Hour), nil),
+			newDFZ("aborted-2", freezerv1alpha1.PhaseAborted, now.Add(-time.Hour), nil),
+			newDFZ("done", freezerv1alpha1.PhaseCompleted, now, nil),
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "new"}},
+		}, now)
+
+		assert.Equal(t, map[freezerv1alpha1.Phase]int32{
+			freezerv1alpha1.PhaseFrozen:    3,
+			freezerv1alpha1.PhaseFreezing:  1,
+			freezerv1alpha1.PhaseAborted:   3,
+			freezerv1alpha1.PhaseCompleted: 1,
+			freezerv1alpha1.PhasePending:   1,
+		}, status.Phases)
+		assert.Len(t, status.Active, 4)
+		require.Len(t, status.UpcomingUnfreezes, 2)
+		assert.Equal(t, "soon", status.UpcomingUnfreezes[0].Name)
+		require.Len(t, status.RecentAborts, 2)
+		assert.Equal(t, "aborted-2", status.RecentAborts[0].Name)
+		assert.False(t, status.Truncated)
+	})
+
+	t.Run("LongLists_Truncated", func(t *testing.T) {
+		t.Parallel()
+		var dfzs []freezerv1alpha1.DeploymentFreezer
+		for i := range reportMaxItems + 1 {
+			dfzs = append(dfzs, newDFZ(fmt.Sprintf("dfz-%03d", i), freezerv1alpha1.PhaseFreezing, now, nil))
+		}
+		status := buildReport(spec, dfzs, now)
+		assert.Len(t, status.Active, reportMaxItems)
+		assert.True(t, status.Truncated)
+		assert.Equal(t, int32(reportMaxItems+1), status.Phases[freezerv1alpha1.PhaseFreezing])
+	})
+}
@@ -2,15 +2,18 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
-	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
 	appsv1 "k8s.io/api/apps/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -24,24 +27,84 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	"golang.org/x/time/rate"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 )
 
 const (
 	finalizerName        = "apps.boolfixer.dev/finalizer"
-	annoFrozenBy         = "apps.boolfixer.dev/frozen-by"     // value: "<namespace>/<name>"
-	annoTemplateHash     = "apps.boolfixer.dev/template-hash" // stored on DFZ .metadata.annotations for spec-change detection
+	annoFrozenBy         = freeze.AnnotationFrozenBy          // value: "<namespace>/<name>/<uid>"
+	annoTemplateHash     = "apps.boolfixer.dev/template-hash" // template hash of DFZs created before status.templateHash
+	annoPaused           = "apps.boolfixer.dev/paused"        // "true" on a DFZ skips it until removed
+	annoFreezeState      = "apps.boolfixer.dev/freeze-state"  // human-readable freeze state on the Deployment
+	annoRestartedAt      = "apps.boolfixer.dev/restarted-at"  // target's restartedAt template annotation when the freeze began
+	annoKubectlRestart   = "kubectl.kubernetes.io/restartedAt"
 	requeueShort         = 2 * time.Second
 	requeueMedium        = 5 * time.Second
-	defaultReplicasCount = int32(1)
+	driftCheckInterval   = time.Minute
+	heartbeatInterval    = 5 * time.Minute
+	defaultReplicasCount = freeze.DefaultReplicas
 )
 
+// TargetNameIndex is the cache field index of DeploymentFreezers by target name.
+const TargetNameIndex = ".spec.targetRef.name"
+
 // DeploymentFreezerReconciler reconciles a DeploymentFreezer object
 type DeploymentFreezerReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
-	now      func() time.Time
+	// Clock is the time source for freeze windows; defaults to the real clock.
+	Clock clock.Clock
+	// Shard restricts this replica to a subset of DFZs; the zero value handles all of them.
+	Shard Shard
+	// DryRun puts the whole controller in plan mode: Deployment patches are only dry-run.
+	DryRun bool
+	// LeanRBAC scales through the deployments/scale subresource and only sends
+	// metadata patches to Deployments, so no write access to the Deployment spec is needed.
+	LeanRBAC bool
+	// DefaultDuration is used when spec.durationSeconds is unset.
+	DefaultDuration time.Duration
+	// MaxDuration caps every freeze window; 0 means unlimited.
+	MaxDuration time.Duration
+	// ProtectedNamespaces may never be frozen, in addition to kube-system, unless a FreezerPolicy
+	// grants the DFZ the ProtectedNamespace exemption.
+	ProtectedNamespaces []string
+	// KillSwitch is the ConfigMap whose KillSwitchKey stops all new scale-downs; restores continue.
+	// An empty name disables the kill switch.
+	KillSwitch types.NamespacedName
+	// UnfreezeLimiter spreads restores over time so that many freezes ending together do not
+	// scale up all at once; nil means unlimited.
+	UnfreezeLimiter *rate.Limiter
+	// MaxConcurrentUnfreezes caps the DFZs of the whole cluster restoring at once: from the
+	// scale-up until the target is available again. 0 means unlimited.
+	MaxConcurrentUnfreezes int
+	// APIReader reads objects that are not cached, such as Pods; defaults to the manager's API reader.
+	APIReader client.Reader
+	// DeploymentSelector is the label selector the Deployment cache is restricted to; nil caches all
+	// Deployments. A target outside it waits with TargetFound=False/NotSelected until it is labeled.
+	DeploymentSelector labels.Selector
+	// TargetNotFoundPatience is how long a Pending DFZ waits for a missing target to be created
+	// before it is Aborted; 0 aborts it at once.
+	TargetNotFoundPatience time.Duration
+	// DriftActorAnnotation is a target annotation naming the user behind its last change, as
+	// stamped by an admission policy; it is reported with replica drift. Empty disables it.
+	DriftActorAnnotation string
+	// ResyncInterval is how often every DFZ still in progress is enqueued again, to recover
+	// from missed watch events; 0 only does so once the leader's cache is synced.
+	ResyncInterval time.Duration
+	// Hooks run on every phase change of a DFZ, around the status write that records it.
+	Hooks TransitionHooks
+	// Events filters and aggregates the events recorded for DFZs; the zero value records all of them.
+	Events EventPolicy
+	// deadlines feeds unfreeze deadlines to the work queue for prioritization.
+	deadlines *deadlineTracker
+	// targets serializes the reconciles of DFZs with the same target in this process, whatever the
+	// worker count; see targetLocks.
+	targets targetLocks
+	// unfreezeSlots counts the unfreezes admitted by this replica under MaxConcurrentUnfreezes.
+	unfreezeSlots unfreezeSlots
 }
 
 // RBAC markers (adjust group/name if they differ in your repo)
@@ -49,24 +112,78 @@ type DeploymentFreezerReconciler struct {
 // +kubebuilder:rbac:groups=apps.boolfixer.dev,resources=deploymentfreezers/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps.boolfixer.dev,resources=deploymentfreezers/finalizers,verbs=update
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=apps,resources=deployments/scale,verbs=get;update
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
-
-func (r *DeploymentFreezerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+// +kubebuilder:rbac:groups=apps.boolfixer.dev,resources=freezerpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.boolfixer.dev,resources=freezerpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=list
+// +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=get;list;patch
+// +kubebuilder:rbac:groups=autoscaling.k8s.io,resources=verticalpodautoscalers,verbs=get;list;patch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;patch
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;patch
+
+func (r *DeploymentFreezerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
 	lg := log.FromContext(ctx).WithValues("dfz", req.NamespacedName)
 	ctx = log.IntoContext(ctx, lg)
+	defer observeReconcile(req.Namespace, time.Now())
 
 	var dfz freezerv1alpha1.DeploymentFreezer
 	if err := r.Get(ctx, req.NamespacedName, &dfz); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.deadlines.forget(req.NamespacedName)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	if !r.Shard.Owns(&dfz) {
+		return ctrl.Result{}, nil
+	}
+	if dfz.Spec.Propagation != nil {
+		// The target lives on the managed clusters; the PropagationReconciler handles the DFZ.
+		return ctrl.Result{}, nil
+	}
+	if isPaused(&dfz) {
+		return ctrl.Result{}, r.pause(ctx, &dfz)
+	}
+	defer r.deadlines.observe(&dfz)
 
 	// Track status changes and write once at the end
 	st := newStatusTracker(&dfz)
-	defer func() { r.commitStatus(ctx, &dfz, st) }()
-
-	deploymentName := dfz.Spec.TargetRef.Name
-	if deploymentName == "" {
-		setPhase(&dfz, freezerv1alpha1.PhaseDenied)
+	defer func() {
+		if chargeErr := r.chargeUsageOnFinish(ctx, &dfz, st.orig.Phase); chargeErr != nil && err == nil {
+			res, err = ctrl.Result{}, chargeErr
+		}
+		observeGeneration(&dfz, st, err)
+		syncWaitConditions(&dfz)
+		if commitErr := r.commitStatus(ctx, &dfz, st); commitErr != nil && err == nil {
+			res, err = ctrl.Result{}, commitErr
+		}
+	}()
+	r.resume(&dfz)
+
+	kind := targetKind(&dfz)
+	targetName := dfz.TargetName()
+	if targetName == "" && dfz.Spec.TargetRef.Selector != nil {
+		if isTerminalPhase(dfz.Status.Phase) {
+			// Denied before the selector matched a single workload; keep the reason it gave.
+			return ctrl.Result{}, nil
+		}
+		name, reason, msg, err := r.selectTarget(ctx, &dfz, kind)
+		switch {
+		case err != nil:
+			r.operationFailed(&dfz, opRead, fmt.Sprintf(msgReadErrorFmt, err))
+			return ctrl.Result{RequeueAfter: requeueShort}, nil
+		case name == "":
+			r.setPhase(&dfz, freezerv1alpha1.PhaseDenied)
+			setCondition(&dfz, freezerv1alpha1.ConditionTypeTargetFound, freezerv1alpha1.ConditionStatusFalse, reason, msg)
+			return ctrl.Result{}, nil
+		}
+		targetName = name
+	}
+	if targetName == "" {
+		r.setPhase(&dfz, freezerv1alpha1.PhaseDenied)
 		setCondition(
 			&dfz,
 			freezerv1alpha1.ConditionTypeTargetFound,
@@ -76,52 +193,165 @@ func (r *DeploymentFreezerReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		)
 		return ctrl.Result{}, nil
 	}
+	if fields := deploymentOnlyFields(&dfz.Spec); kind != freezerv1alpha1.TargetKindDeployment && fields != "" &&
+		!isTerminalPhase(dfz.Status.Phase) {
+		r.setPhase(&dfz, freezerv1alpha1.PhaseDenied)
+		setCondition(
+			&dfz,
+			freezerv1alpha1.ConditionTypeTargetFound,
+			freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonUnsupportedTarget,
+			fmt.Sprintf(msgDeploymentOnlyFieldsFmt, kind, fields),
+		)
+		return ctrl.Result{}, nil
+	}
 
-	var deployment appsv1.Deployment
-	if err := r.Get(ctx, types.NamespacedName{Namespace: dfz.Namespace, Name: deploymentName}, &deployment); err != nil {
+	obj, cached := newTargetObject(kind)
+	reader := client.Reader(r.Client)
+	if !cached {
+		reader = r.APIReader
+	}
+	targetKey := types.NamespacedName{Namespace: dfz.Namespace, Name: targetName}
+	defer r.targets.lock(kind, targetKey)()
+	if err := reader.Get(ctx, targetKey, obj); err != nil {
 		if apierrors.IsNotFound(err) {
-			setPhase(&dfz, freezerv1alpha1.PhaseAborted)
+			outside := false
+			var lookupErr error
+			if cached {
+				outside, lookupErr = r.targetOutsideCache(ctx, targetKey)
+			}
+			switch {
+			case lookupErr != nil:
+				err = lookupErr
+			case outside:
+				// Labeling the Deployment adds it to the cache, which enqueues this DFZ.
+				r.markNotSelected(&dfz)
+				return ctrl.Result{}, nil
+			default:
+				if wait := r.awaitTarget(&dfz, kind); wait > 0 {
+					// A watched target wakes the DFZ up when it is created; others are polled.
+					if !cached {
+						wait = min(wait, driftCheckInterval)
+					}
+					return ctrl.Result{RequeueAfter: wait}, nil
+				}
+				r.setPhase(&dfz, freezerv1alpha1.PhaseAborted)
+				setCondition(
+					&dfz,
+					freezerv1alpha1.ConditionTypeTargetFound,
+					freezerv1alpha1.ConditionStatusFalse,
+					freezerv1alpha1.ConditionReasonNotFound,
+					fmt.Sprintf(msgTargetNotExistFmt, kind),
+				)
+				return ctrl.Result{}, nil
+			}
+		}
+		// The CRD of the kind, such as OpenKruise's, is not installed.
+		if apimeta.IsNoMatchError(err) {
+			if !dfz.DeletionTimestamp.IsZero() {
+				return ctrl.Result{}, r.removeFinalizer(ctx, &dfz)
+			}
+			if !isTerminalPhase(dfz.Status.Phase) {
+				r.setPhase(&dfz, freezerv1alpha1.PhaseDenied)
+			}
 			setCondition(
 				&dfz,
 				freezerv1alpha1.ConditionTypeTargetFound,
 				freezerv1alpha1.ConditionStatusFalse,
-				freezerv1alpha1.ConditionReasonNotFound,
-				msgTargetDeploymentNotExist,
+				freezerv1alpha1.ConditionReasonUnsupportedTarget,
+				fmt.Sprintf(msgKindNotServedFmt, kind),
 			)
 			return ctrl.Result{}, nil
 		}
-		setCondition(
-			&dfz,
-			freezerv1alpha1.ConditionTypeHealth,
-			freezerv1alpha1.ConditionStatusFalse,
-			freezerv1alpha1.ConditionReasonAPIConflict,
-			fmt.Sprintf(msgReadErrorFmt, err),
-		)
+		r.operationFailed(&dfz, opRead, fmt.Sprintf(msgReadErrorFmt, err))
 		return ctrl.Result{RequeueAfter: requeueShort}, nil
 	}
+	// Deployment-only steps get a nil Deployment for other kinds; the spec fields that would
+	// reach them were refused above.
+	deployment, _ := obj.(*appsv1.Deployment)
+
+	markSelected(&dfz)
+	markFound(&dfz)
+	if obj.GetAnnotations() == nil {
+		obj.SetAnnotations(map[string]string{})
+	}
 
-	if deployment.Annotations == nil {
-		deployment.Annotations = map[string]string{}
+	// Every write to the target below goes through its freeze target.
+	target, err := r.freezer().Target(obj)
+	if errors.Is(err, freeze.ErrControlled) {
+		if dfz.DeletionTimestamp.IsZero() {
+			if !isTerminalPhase(dfz.Status.Phase) {
+				r.setPhase(&dfz, freezerv1alpha1.PhaseDenied)
+			}
+			setCondition(
+				&dfz,
+				freezerv1alpha1.ConditionTypeTargetFound,
+				freezerv1alpha1.ConditionStatusFalse,
+				freezerv1alpha1.ConditionReasonUnsupportedTarget,
+				fmt.Sprintf(msgTargetControlledFmt, err),
+			)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, r.removeFinalizer(ctx, &dfz)
+	}
+	if err != nil {
+		return ctrl.Result{}, err
 	}
 
-	owner := fmt.Sprintf("%s/%s", dfz.Namespace, dfz.Name)
-	frozenBy, ok := deployment.Annotations[annoFrozenBy]
-	if ok && frozenBy != owner {
-		setPhase(&dfz, freezerv1alpha1.PhaseDenied)
-		setCondition(
-			&dfz,
-			freezerv1alpha1.ConditionTypeOwnership,
-			freezerv1alpha1.ConditionStatusFalse,
-			freezerv1alpha1.ConditionReasonLost,
-			fmt.Sprintf(msgDeploymentAlreadyOwnedFmt, frozenBy),
-		)
-		r.Recorder.Eventf(&dfz, corev1.EventTypeWarning, ReasonOwnershipDenied, msgOwnershipDenied, deployment.Namespace, deployment.Name, frozenBy)
-		return ctrl.Result{}, nil
+	// A DFZ being deleted goes on to its finalizer, which leaves a target it does not own alone;
+	// a finished one keeps its outcome when another DFZ freezes the target later.
+	frozenBy, ok := obj.GetAnnotations()[annoFrozenBy]
+	if ok && !isFrozenBy(frozenBy, &dfz) && dfz.DeletionTimestamp.IsZero() && !isTerminalPhase(dfz.Status.Phase) {
+		if hasAcquired(&dfz) {
+			by, err := r.preemptedBy(ctx, &dfz, frozenBy)
+			if err != nil {
+				r.operationFailed(&dfz, opRead, fmt.Sprintf(msgReadErrorFmt, err))
+				return ctrl.Result{RequeueAfter: requeueShort}, nil
+			}
+			if by != nil {
+				r.markPreempted(&dfz, obj, by)
+				return ctrl.Result{}, nil
+			}
+			r.setPhase(&dfz, freezerv1alpha1.PhaseDenied)
+			setCondition(
+				&dfz,
+				freezerv1alpha1.ConditionTypeOwnership,
+				freezerv1alpha1.ConditionStatusFalse,
+				freezerv1alpha1.ConditionReasonLost,
+				fmt.Sprintf(msgDeploymentAlreadyOwnedFmt, frozenBy),
+			)
+			r.ownershipLost(&dfz, obj, frozenBy)
+			return ctrl.Result{}, nil
+		}
+
+		held, err := r.ownershipHeld(ctx, dfz.Namespace, frozenBy)
+		if err != nil {
+			r.operationFailed(&dfz, opRead, fmt.Sprintf(msgReadErrorFmt, err))
+			return ctrl.Result{RequeueAfter: requeueShort}, nil
+		}
+		if held {
+			if res, preempting := r.preempt(ctx, &dfz, target, frozenBy); preempting {
+				return res, nil
+			}
+			// The release of a Deployment or the end of its owner wakes us up; the only
+			// requeue is for the acquire timeout. Targets that are not watched are polled.
+			res := r.waitForOwnership(&dfz, obj, frozenBy)
+			if !cached && (res.RequeueAfter == 0 || res.RequeueAfter > requeueMedium) && !isTerminalPhase(dfz.Status.Phase) {
+				res.RequeueAfter = requeueMedium
+			}
+			return res, nil
+		}
+		countOwnershipConflict(&dfz, conflictStaleTakeover)
+		r.Recorder.Eventf(&dfz, corev1.EventTypeWarning, ReasonStaleOwnership, msgStaleOwnership,
+			obj.GetNamespace(), obj.GetName(), frozenBy)
 	}
 
+	r.settlePreemption(&dfz, target.Owner())
+	stopWaitingForOwnership(&dfz)
+
 	// UID pinning / recreation detection
-	if dfz.Status.TargetRef.UID != "" && deployment.UID != dfz.Status.TargetRef.UID {
-		setPhase(&dfz, freezerv1alpha1.PhaseAborted)
+	if dfz.Status.TargetRef.UID != "" && obj.GetUID() != dfz.Status.TargetRef.UID {
+		r.setPhase(&dfz, freezerv1alpha1.PhaseAborted)
 		setCondition(
 			&dfz,
 			freezerv1alpha1.ConditionTypeTargetFound,
@@ -133,60 +363,63 @@ func (r *DeploymentFreezerReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	}
 
 	// Finalizer handling
-	if dfz.DeletionTimestamp.IsZero() {
-		if err := r.ensureFinalizer(ctx, &dfz); err != nil {
-			return ctrl.Result{}, err
+	if !dfz.DeletionTimestamp.IsZero() {
+		if !isTerminalPhase(dfz.Status.Phase) {
+			if err := r.recordFreezeUsage(ctx, &dfz); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		// The finalizer stays until the target is restored and released.
+		if err := r.reconcileDelete(ctx, target, &dfz); err != nil {
+			return ctrl.Result{RequeueAfter: requeueShort}, nil
 		}
-	} else {
-		r.reconcileDelete(ctx, &deployment, &dfz)
+		r.syncFreezeState(ctx, &dfz, target)
 		err := r.removeFinalizer(ctx, &dfz)
 		return ctrl.Result{}, err
 	}
 
-	// Cache UID/name into status if not set
-	if dfz.Status.TargetRef.UID == "" {
-		dfz.Status.TargetRef.Name = deployment.Name
-		dfz.Status.TargetRef.UID = deployment.UID
-	}
-
-	// Compute/remember template hash to detect spec changes while frozen
-	if err := r.ensureTemplateHashAnno(ctx, &dfz, &deployment); err != nil {
-		setCondition(
-			&dfz,
-			freezerv1alpha1.ConditionTypeHealth,
-			freezerv1alpha1.ConditionStatusFalse,
-			freezerv1alpha1.ConditionReasonAPIConflict,
-			fmt.Sprintf(msgTemplateHashPatchFailedFmt, err),
-		)
+	// Add the finalizer and remember the template hash to detect spec changes while frozen
+	if err := r.ensureMetadata(ctx, &dfz, obj); err != nil {
+		r.operationFailed(&dfz, opPatchMetadata, fmt.Sprintf(msgMetadataPatchFailedFmt, err))
 		return ctrl.Result{RequeueAfter: requeueShort}, nil
 	}
 
-	// Record observedGeneration only after successfully processing current spec
-	if dfz.Status.ObservedGeneration != dfz.GetGeneration() {
-		dfz.Status.ObservedGeneration = dfz.GetGeneration()
+	// Cache UID/name into status if not set; this also pins a selector to the workload it matched.
+	if dfz.Status.TargetRef.UID == "" {
+		dfz.Status.TargetRef.Name = obj.GetName()
+		dfz.Status.TargetRef.UID = obj.GetUID()
 	}
 
 	// Phase router
 	if dfz.Status.Phase == "" {
-		setPhase(&dfz, freezerv1alpha1.PhasePending)
+		r.setPhase(&dfz, freezerv1alpha1.PhasePending)
+	}
+
+	if r.dryRun(&dfz) && !isTerminalPhase(dfz.Status.Phase) {
+		return r.handlePlan(ctx, &dfz, target)
 	}
 
-	switch dfz.Status.Phase {
-	case freezerv1alpha1.PhasePending, freezerv1alpha1.PhaseFreezing:
-		return r.handlePendingOrFreezing(ctx, &dfz, &deployment)
-	case freezerv1alpha1.PhaseFrozen:
-		return r.handleFrozen(&dfz), nil
-	case freezerv1alpha1.PhaseUnfreezing:
-		return r.handleUnfreezing(ctx, &dfz, &deployment)
-	case freezerv1alpha1.PhaseDenied, freezerv1alpha1.PhaseCompleted, freezerv1alpha1.PhaseAborted:
+	state, ok := lifecycle[dfz.Status.Phase]
+	switch {
+	case !ok:
+		return ctrl.Result{RequeueAfter: requeueShort}, nil
+	case state.handle == nil:
 		return ctrl.Result{}, nil
 	default:
-		return ctrl.Result{RequeueAfter: requeueShort}, nil
+		res, err := state.handle(r, ctx, &dfz, deployment, target)
+		r.syncFreezeState(ctx, &dfz, target)
+		return res, err
 	}
 }
 
 func (r *DeploymentFreezerReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	r.now = func() time.Time { return time.Now().UTC() }
+	if r.Clock == nil {
+		r.Clock = clock.RealClock{}
+	}
+	if r.APIReader == nil {
+		r.APIReader = mgr.GetAPIReader()
+	}
+	r.deadlines = newDeadlineTracker()
 
 	// 1) Index fields for efficient lookups
 	if err := r.setupFieldIndex(context.Background(), mgr); err != nil {
@@ -194,16 +427,16 @@ func (r *DeploymentFreezerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}
 
 	// 2) Build controller and register watches
-	startupCh := make(chan event.GenericEvent)
-	if _, err := r.buildController(mgr, startupCh); err != nil {
+	resyncCh := make(chan event.GenericEvent)
+	if _, err := r.buildController(mgr, resyncCh); err != nil {
 		return err
 	}
 
 	// 3) Initialize event recorder for this controller
-	r.Recorder = mgr.GetEventRecorderFor("deployment-freezer")
+	r.Recorder = r.Events.Wrap(mgr.GetEventRecorderFor("deployment-freezer"), clock.RealClock{})
 
-	// 4) Register a startup runnable to enqueue overdue frozen items
-	if err := r.registerStartupRunnable(mgr, startupCh); err != nil {
+	// 4) Register the runnable that enqueues DFZs in progress at startup and every ResyncInterval
+	if err := r.registerResyncRunnable(mgr, resyncCh); err != nil {
 		return err
 	}
 
@@ -214,29 +447,47 @@ func (r *DeploymentFreezerReconciler) setupFieldIndex(ctx context.Context, mgr c
 	return mgr.GetFieldIndexer().IndexField(
 		ctx,
 		&freezerv1alpha1.DeploymentFreezer{},
-		".spec.targetRef.name",
+		TargetNameIndex,
 		func(raw client.Object) []string {
 			dfz := raw.(*freezerv1alpha1.DeploymentFreezer)
-			if dfz.Spec.TargetRef.Name == "" {
+			if dfz.TargetName() == "" {
 				return nil
 			}
-			return []string{dfz.Spec.TargetRef.Name}
+			return []string{dfz.TargetName()}
 		},
 	)
 }
 
-func (r *DeploymentFreezerReconciler) buildController(mgr ctrl.Manager, startupCh <-chan event.GenericEvent) (controller.Controller, error) {
+func (r *DeploymentFreezerReconciler) buildController(mgr ctrl.Manager, resyncCh <-chan event.GenericEvent) (controller.Controller, error) {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&freezerv1alpha1.DeploymentFreezer{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		For(
+			&freezerv1alpha1.DeploymentFreezer{},
+			builder.WithPredicates(
+				predicate.Or[client.Object](predicate.GenerationChangedPredicate{}, pauseToggled, wakeRequestedAdded),
+				r.Shard.Predicate(),
+			),
+		).
 		Watches(
 			&appsv1.Deployment{},
 			handler.EnqueueRequestsFromMapFunc(r.deploymentToDFZMapper),
-			// Only react to Deployment spec changes (generation changes), ignore status-only updates
-			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+			// React to Deployment spec changes (generation changes), ignore status-only updates.
+			// Releasing ownership only changes an annotation, which waiting DFZs need to see.
+			builder.WithPredicates(
+				predicate.Or[client.Object](predicate.GenerationChangedPredicate{}, ownershipReleased),
+				r.Shard.DeploymentPredicate(),
+			),
 		).
-		// Watch a channel so we can push GenericEvents on startup
-		WatchesRawSource(source.Channel(startupCh, &handler.EnqueueRequestForObject{})).
-		WithOptions(controller.Options{MaxConcurrentReconciles: 2}).
+		// An owner that finishes or disappears without releasing its Deployment unblocks waiting DFZs.
+		Watches(
+			&freezerv1alpha1.DeploymentFreezer{},
+			handler.EnqueueRequestsFromMapFunc(r.ownershipWaiters),
+			builder.WithPredicates(ownerFinished),
+		).
+		// Watch a channel so the resync can push GenericEvents
+		WatchesRawSource(source.Channel(resyncCh, &handler.EnqueueRequestForObject{})).
+		// Deadline-aware, namespace-fair queue: overdue unfreezes are processed ahead of new freezes,
+		// and one namespace's backlog does not hold up the others.
+		WithOptions(controller.Options{MaxConcurrentReconciles: 2, NewQueue: r.newDeadlineQueue}).
 		Build(r)
 }
 
@@ -252,50 +503,22 @@ func (r *DeploymentFreezerReconciler) deploymentToDFZMapper(ctx context.Context,
 		ctx,
 		&list,
 		client.InNamespace(d.Namespace),
-		client.MatchingFields{".spec.targetRef.name": d.Name},
+		client.MatchingFields{TargetNameIndex: d.Name},
 	); err != nil {
 		return nil
 	}
 
-	reqs := make([]reconcile.Request, len(list.Items))
+	reqs := make([]reconcile.Request, 0, len(list.Items))
 	for i := range list.Items {
-		reqs[i] = reconcile.Request{
+		if !r.Shard.Owns(&list.Items[i]) {
+			continue
+		}
+		reqs = append(reqs, reconcile.Request{
 			NamespacedName: types.NamespacedName{
 				Namespace: list.Items[i].Namespace,
 				Name:      list.Items[i].Name,
 			},
-		}
+		})
 	}
 	return reqs
 }
-
-func (r *DeploymentFreezerReconciler) registerStartupRunnable(mgr ctrl.Manager, startupCh chan event.GenericEvent) error {
-	return mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
-		// Ensure cache is synced before we list
-		if ok := mgr.GetCache().WaitForCacheSync(ctx); !ok {
-			return ctx.Err()
-		}
-
-		var list freezerv1alpha1.DeploymentFreezerList
-		if err := r.List(ctx, &list); err != nil {
-			return err
-		}
-
-		now := r.now()
-		for i := range list.Items {
-			dfz := list.Items[i]
-			if dfz.Status.Phase == freezerv1alpha1.PhaseFrozen &&
-				dfz.Status.FreezeUntil != nil &&
-				!dfz.Status.FreezeUntil.After(now) {
-				// Push a GenericEvent to enqueue this object immediately
-				// Important: pass a pointer to a distinct object per loop
-				obj := dfz // copy
-				startupCh <- event.GenericEvent{Object: &obj}
-			}
-		}
-
-		// Close the channel to avoid leaks; watch remains registered but idle.
-		close(startupCh)
-		return nil
-	}))
-}
@@ -18,6 +18,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -39,10 +40,11 @@ var _ = Describe("DeploymentFreezer Controller", Ordered, func() {
 
 	newReconciler := func(now time.Time) *DeploymentFreezerReconciler {
 		r := &DeploymentFreezerReconciler{
-			Client:   k8sClient,
-			Scheme:   k8sClient.Scheme(),
-			Recorder: record.NewFakeRecorder(64),
-			now:      func() time.Time { return now },
+			Client:    k8sClient,
+			Scheme:    k8sClient.Scheme(),
+			Recorder:  record.NewFakeRecorder(64),
+			Clock:     testingclock.NewFakeClock(now),
+			APIReader: k8sClient,
 		}
 		return r
 	}
@@ -151,7 +153,7 @@ var _ = Describe("DeploymentFreezer Controller", Ordered, func() {
 		Expect(refreshed.Status.Conditions[0].Type).To(Equal(appsv1alpha1.ConditionTypeTargetFound))
 		Expect(refreshed.Status.Conditions[0].Status).To(Equal(appsv1alpha1.ConditionStatusFalse))
 		Expect(refreshed.Status.Conditions[0].Reason).To(Equal(appsv1alpha1.ConditionReasonNotFound))
-		Expect(refreshed.Status.Conditions[0].Message).To(Equal(msgTargetDeploymentNotExist))
+		Expect(refreshed.Status.Conditions[0].Message).To(Equal(fmt.Sprintf(msgTargetNotExistFmt, "Deployment")))
 	})
 
 	It("freezes and then unfreezes the Deployment, restoring replicas and clearing ownership", func() {
@@ -204,10 +206,10 @@ var _ = Describe("DeploymentFreezer Controller", Ordered, func() {
 		var curDep appsv1.Deployment
 		Expect(get(types.NamespacedName{Namespace: ns, Name: deployName}, &curDep)).To(Succeed())
 		Expect(*curDep.Spec.Replicas).To(Equal(int32(0)))
-		Expect(curDep.Annotations[annoFrozenBy]).To(Equal(fmt.Sprintf("%s/%s", ns, dfzName)))
+		Expect(curDep.Annotations[annoFrozenBy]).To(Equal(frozenByValue(&curDFZ)))
 
 		// 3) Advance time to trigger unfreeze path
-		r.now = func() time.Time { return curDFZ.Status.FreezeUntil.Add(1 * time.Second).UTC() }
+		r.Clock = testingclock.NewFakeClock(curDFZ.Status.FreezeUntil.Add(1 * time.Second).UTC())
 
 		// Transition to Unfreezing
 		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: dfzName}})
@@ -244,8 +246,13 @@ var _ = Describe("DeploymentFreezer Controller", Ordered, func() {
 		Expect(curDep.Annotations[annoFrozenBy]).To(BeEmpty())
 	})
 
-	It("denies ownership if the Deployment is already frozen by another owner", func() {
-		By("creating target Deployment already annotated as frozen by someone else")
+	It("waits for ownership while another DFZ holds the Deployment", func() {
+		By("creating the DFZ that holds the Deployment")
+		holder := makeDFZ("other", deployName, 10)
+		Expect(k8sClient.Create(ctx, holder)).To(Succeed())
+		DeferCleanup(func() { _ = k8sClient.Delete(ctx, holder) })
+
+		By("creating target Deployment already annotated as frozen by the holder")
 		dep := makeDeployment(deployName, 1, map[string]string{annoFrozenBy: otherOwner})
 		Expect(k8sClient.Create(ctx, dep)).To(Succeed())
 
@@ -255,21 +262,36 @@ var _ = Describe("DeploymentFreezer Controller", Ordered, func() {
 
 		r := newReconciler(time.Now().UTC())
 
-		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: dfzName}})
+		res, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: dfzName}})
 		Expect(err).NotTo(HaveOccurred())
+		Expect(res.RequeueAfter).To(BeZero())
 
-		// DFZ should be Denied and Deployment annotation unchanged
+		// DFZ should wait and the Deployment annotation stay unchanged
 		var curDFZ appsv1alpha1.DeploymentFreezer
 		Expect(get(types.NamespacedName{Namespace: ns, Name: dfzName}, &curDFZ)).To(Succeed())
-		Expect(curDFZ.Status.Phase).To(Equal(appsv1alpha1.PhaseDenied))
+		Expect(curDFZ.Status.Phase).To(Equal(appsv1alpha1.PhasePending))
 		Expect(curDFZ.Status.Conditions[0].Type).To(Equal(appsv1alpha1.ConditionTypeOwnership))
 		Expect(curDFZ.Status.Conditions[0].Status).To(Equal(appsv1alpha1.ConditionStatusFalse))
-		Expect(curDFZ.Status.Conditions[0].Reason).To(Equal(appsv1alpha1.ConditionReasonLost))
+		Expect(curDFZ.Status.Conditions[0].Reason).To(Equal(appsv1alpha1.ConditionReasonDeniedAlreadyFrozen))
 		Expect(curDFZ.Status.Conditions[0].Message).To(Equal(fmt.Sprintf(msgDeploymentAlreadyOwnedFmt, otherOwner)))
+		Expect(curDFZ.Status.Conditions[1].Type).To(Equal(appsv1alpha1.ConditionTypeWaitingForOwnership))
+		Expect(curDFZ.Status.Conditions[1].Status).To(Equal(appsv1alpha1.ConditionStatusTrue))
 
 		var curDep appsv1.Deployment
 		Expect(get(types.NamespacedName{Namespace: ns, Name: deployName}, &curDep)).To(Succeed())
 		Expect(curDep.Annotations[annoFrozenBy]).To(Equal(otherOwner))
+
+		By("releasing the Deployment")
+		delete(curDep.Annotations, annoFrozenBy)
+		Expect(k8sClient.Update(ctx, &curDep)).To(Succeed())
+
+		_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: dfzName}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(get(types.NamespacedName{Namespace: ns, Name: dfzName}, &curDFZ)).To(Succeed())
+		Expect(curDFZ.Status.Phase).To(Equal(appsv1alpha1.PhaseFreezing))
+		Expect(curDFZ.Status.Conditions[1].Type).To(Equal(appsv1alpha1.ConditionTypeWaitingForOwnership))
+		Expect(curDFZ.Status.Conditions[1].Status).To(Equal(appsv1alpha1.ConditionStatusFalse))
+		Expect(curDFZ.Status.Conditions[1].Reason).To(Equal(appsv1alpha1.ConditionReasonOwnerReleased))
 	})
 
 	It("denies when spec.targetRef.name is empty", func() {
@@ -482,6 +504,83 @@ var _ = Describe("DeploymentFreezer Controller", Ordered, func() {
 		Expect(curDep.Annotations[annoFrozenBy]).To(BeEmpty())
 	})
 
+	It("pauses rollouts while frozen and restores the original paused value on unfreeze", func() {
+		By("creating the target Deployment")
+		dep := makeDeployment(deployName, origReplicas, nil)
+		Expect(k8sClient.Create(ctx, dep)).To(Succeed())
+
+		By("creating DFZ with pauseRollout")
+		dfz := makeDFZ(dfzName, deployName, 1)
+		dfz.Spec.PauseRollout = true
+		Expect(k8sClient.Create(ctx, dfz)).To(Succeed())
+
+		r := newReconciler(time.Now().UTC())
+		key := types.NamespacedName{Namespace: ns, Name: dfzName}
+
+		// Scale down, then reach Frozen
+		for range 2 {
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		var curDFZ appsv1alpha1.DeploymentFreezer
+		Expect(get(key, &curDFZ)).To(Succeed())
+		Expect(curDFZ.Status.Phase).To(Equal(appsv1alpha1.PhaseFrozen))
+		Expect(curDFZ.Status.OriginalPaused).To(Equal(ptr.To(false)))
+
+		var curDep appsv1.Deployment
+		Expect(get(types.NamespacedName{Namespace: ns, Name: deployName}, &curDep)).To(Succeed())
+		Expect(curDep.Spec.Paused).To(BeTrue())
+
+		By("advancing time past the freeze window")
+		r.Clock = testingclock.NewFakeClock(curDFZ.Status.FreezeUntil.Add(1 * time.Second).UTC())
+		for range 2 {
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		Expect(get(key, &curDFZ)).To(Succeed())
+		Expect(curDFZ.Status.Phase).To(Equal(appsv1alpha1.PhaseCompleted))
+		Expect(get(types.NamespacedName{Namespace: ns, Name: deployName}, &curDep)).To(Succeed())
+		Expect(curDep.Spec.Paused).To(BeFalse())
+		Expect(*curDep.Spec.Replicas).To(Equal(origReplicas))
+	})
+
+	It("plans the freeze with server-side dry-run without mutating the Deployment", func() {
+		By("creating the target Deployment")
+		dep := makeDeployment(deployName, origReplicas, nil)
+		Expect(k8sClient.Create(ctx, dep)).To(Succeed())
+
+		By("creating a dry-run DFZ referencing the Deployment")
+		dfz := makeDFZ(dfzName, deployName, 60)
+		dfz.Spec.DryRun = true
+		Expect(k8sClient.Create(ctx, dfz)).To(Succeed())
+
+		now := time.Now().UTC()
+		r := newReconciler(now)
+
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: dfzName}})
+		Expect(err).NotTo(HaveOccurred())
+
+		var curDFZ appsv1alpha1.DeploymentFreezer
+		Expect(get(types.NamespacedName{Namespace: ns, Name: dfzName}, &curDFZ)).To(Succeed())
+		Expect(curDFZ.Status.Phase).To(Equal(appsv1alpha1.PhasePending))
+		Expect(curDFZ.Status.Conditions[0].Type).To(Equal(appsv1alpha1.ConditionTypeDryRun))
+		Expect(curDFZ.Status.Conditions[0].Status).To(Equal(appsv1alpha1.ConditionStatusTrue))
+		Expect(curDFZ.Status.Conditions[0].Reason).To(Equal(appsv1alpha1.ConditionReasonPlanned))
+		Expect(curDFZ.Status.PlannedChanges).To(Equal([]string{
+			fmt.Sprintf(msgPlanSetAnnotationFmt, annoFrozenBy, frozenByValue(&curDFZ)),
+			fmt.Sprintf(msgPlanScaleFmt, origReplicas, 0),
+			fmt.Sprintf(msgPlanRestoreFmt, origReplicas, now.Add(60*time.Second).Format(time.RFC3339)),
+		}))
+
+		By("verifying the Deployment was not changed")
+		var curDep appsv1.Deployment
+		Expect(get(types.NamespacedName{Namespace: ns, Name: deployName}, &curDep)).To(Succeed())
+		Expect(*curDep.Spec.Replicas).To(Equal(origReplicas))
+		Expect(curDep.Annotations).NotTo(HaveKey(annoFrozenBy))
+	})
+
 	It("moves to Aborted when target Deployment disappears mid-process", func() {
 		By("creating the target Deployment")
 		dep := makeDeployment(deployName, origReplicas, nil)
@@ -528,6 +627,6 @@ var _ = Describe("DeploymentFreezer Controller", Ordered, func() {
 		Expect(curDFZ.Status.Conditions[2].Type).To(Equal(appsv1alpha1.ConditionTypeTargetFound))
 		Expect(curDFZ.Status.Conditions[2].Status).To(Equal(appsv1alpha1.ConditionStatusFalse))
 		Expect(curDFZ.Status.Conditions[2].Reason).To(Equal(appsv1alpha1.ConditionReasonNotFound))
-		Expect(curDFZ.Status.Conditions[2].Message).To(Equal(msgTargetDeploymentNotExist))
+		Expect(curDFZ.Status.Conditions[2].Message).To(Equal(fmt.Sprintf(msgTargetNotExistFmt, "Deployment")))
 	})
 })
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// driftActor describes who last set the replicas of the target, or returns "" when it cannot
+// tell. The cache strips managedFields, so the target's metadata is read from the API server.
+// The field manager is usually the client's name (kubectl, helm, kube-controller-manager for
+// an HPA); the user is only known when DriftActorAnnotation is stamped by an admission policy.
+func (r *DeploymentFreezerReconciler) driftActor(ctx context.Context, obj client.Object) string {
+	gvk, err := apiutil.GVKForObject(obj, r.Client.Scheme())
+	if err != nil {
+		return ""
+	}
+	var meta metav1.PartialObjectMetadata
+	meta.SetGroupVersionKind(gvk)
+	if err := r.APIReader.Get(ctx, client.ObjectKeyFromObject(obj), &meta); err != nil {
+		log.FromContext(ctx).Error(err, "failed to read the managed fields of the target")
+		return ""
+	}
+
+	var actor string
+	if entry := replicasManager(meta.ManagedFields); entry != nil {
+		actor = fmt.Sprintf(msgDriftManagerFmt, entry.Manager)
+		if entry.Subresource != "" {
+			actor += fmt.Sprintf(msgDriftSubresourceFmt, entry.Subresource)
+		}
+		if entry.Time != nil {
+			actor += fmt.Sprintf(msgDriftTimeFmt, entry.Time.UTC().Format(time.RFC3339))
+		}
+	}
+	if user := meta.Annotations[r.DriftActorAnnotation]; r.DriftActorAnnotation != "" && user != "" {
+		if actor != "" {
+			actor += ", "
+		}
+		actor += fmt.Sprintf(msgDriftUserFmt, user)
+	}
+	return actor
+}
+
+// replicasManager returns the managedFields entry that most recently set spec.replicas, or nil.
+// An Update hands the field to the last writer; appliers may share it, so the newest one wins.
+func replicasManager(entries []metav1.ManagedFieldsEntry) *metav1.ManagedFieldsEntry {
+	var latest *metav1.ManagedFieldsEntry
+	for i := range entries {
+		e := &entries[i]
+		if e.FieldsV1 == nil || !ownsReplicas(e.FieldsV1.Raw) {
+			continue
+		}
+		if latest == nil || (e.Time != nil && (latest.Time == nil || latest.Time.Before(e.Time))) {
+			latest = e
+		}
+	}
+	return latest
+}
+
+// ownsReplicas reports whether a FieldsV1 set contains spec.replicas.
+func ownsReplicas(raw []byte) bool {
+	var fields struct {
+		Spec map[string]json.RawMessage `json:"f:spec"`
+	}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return false
+	}
+	_, ok := fields.Spec["f:replicas"]
+	return ok
+}
@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+)
+
+// Event verbosity levels.
+const (
+	// EventsAll records every event.
+	EventsAll = "all"
+	// EventsErrors only records Warning events.
+	EventsErrors = "errors"
+)
+
+// msgRepeatedSuffix is appended to a Warning event that was held back while it repeated.
+const msgRepeatedSuffix = " (repeated %d more times in the last %s)"
+
+// EventPolicy decides which DeploymentFreezer events reach the events API. The zero value
+// records every event a DeploymentFreezer's spec.eventPolicy lets through.
+type EventPolicy struct {
+	// Verbosity is EventsAll or EventsErrors; empty means EventsAll.
+	Verbosity string
+	// AggregationWindow holds back a Warning event identical to one recorded for the same object
+	// less than this long ago, and counts it in the next one recorded after the window. 0 records
+	// every Warning.
+	AggregationWindow time.Duration
+}
+
+// Validate reports an unknown verbosity or a negative window.
+func (p EventPolicy) Validate() error {
+	switch p.Verbosity {
+	case "", EventsAll, EventsErrors:
+	default:
+		return fmt.Errorf("unknown event verbosity %q, want %q or %q", p.Verbosity, EventsAll, EventsErrors)
+	}
+	if p.AggregationWindow < 0 {
+		return fmt.Errorf("event aggregation window must not be negative, got %s", p.AggregationWindow)
+	}
+	return nil
+}
+
+// Wrap applies the policy, and the spec.eventPolicy of the DeploymentFreezer an event is
+// recorded for, to rec. clk measures the aggregation window; it is the real clock in the
+// controller, even in simulation mode, since the window protects the events API.
+func (p EventPolicy) Wrap(rec record.EventRecorder, clk clock.PassiveClock) record.EventRecorder {
+	return &policyRecorder{policy: p, rec: rec, clock: clk, seen: map[eventKey]*repeatedEvent{}}
+}
+
+// eventKey identifies identical events of one object.
+type eventKey struct {
+	uid     types.UID
+	reason  string
+	message string
+}
+
+// repeatedEvent is a Warning recorded at since, and how often it was held back since then.
+type repeatedEvent struct {
+	since time.Time
+	held  int
+}
+
+var _ record.EventRecorder = &policyRecorder{}
+
+type policyRecorder struct {
+	policy EventPolicy
+	rec    record.EventRecorder
+	clock  clock.PassiveClock
+
+	mu        sync.Mutex
+	seen      map[eventKey]*repeatedEvent
+	lastPrune time.Time
+}
+
+func (r *policyRecorder) Event(obj runtime.Object, eventtype, reason, message string) {
+	if message, ok := r.admit(obj, eventtype, reason, eventMessage(obj, eventtype, reason, message)); ok {
+		r.rec.Event(obj, eventtype, reason, message)
+	}
+}
+
+func (r *policyRecorder) Eventf(obj runtime.Object, eventtype, reason, messageFmt string, args ...any) {
+	r.Event(obj, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *policyRecorder) AnnotatedEventf(
+	obj runtime.Object,
+	annotations map[string]string,
+	eventtype, reason, messageFmt string,
+	args ...any,
+) {
+	message := eventMessage(obj, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+	if message, ok := r.admit(obj, eventtype, reason, message); ok {
+		r.rec.AnnotatedEventf(obj, annotations, eventtype, reason, "%s", message)
+	}
+}
+
+// admit decides whether the event is recorded, and returns its message with the count of held
+// back repeats.
+func (r *policyRecorder) admit(obj runtime.Object, eventtype, reason, message string) (string, bool) {
+	if dfz, ok := obj.(*freezerv1alpha1.DeploymentFreezer); ok {
+		switch dfz.Spec.EventPolicy {
+		case freezerv1alpha1.EventPolicyNone:
+			return "", false
+		case freezerv1alpha1.EventPolicyWarnings:
+			if eventtype != corev1.EventTypeWarning {
+				return "", false
+			}
+		}
+	}
+	if eventtype != corev1.EventTypeWarning {
+		return message, r.policy.Verbosity != EventsErrors
+	}
+	window := r.policy.AggregationWindow
+	accessor, err := meta.Accessor(obj)
+	if window == 0 || err != nil {
+		return message, true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.clock.Now()
+	r.prune(now)
+	key := eventKey{uid: accessor.GetUID(), reason: reason, message: message}
+	ev, ok := r.seen[key]
+	if ok && now.Sub(ev.since) < window {
+		ev.held++
+		return "", false
+	}
+	if ok && ev.held > 0 {
+		message += fmt.Sprintf(msgRepeatedSuffix, ev.held, now.Sub(ev.since).Round(time.Second))
+	}
+	r.seen[key] = &repeatedEvent{since: now}
+	return message, true
+}
+
+// prune forgets, at most once per window, the events that stopped repeating. Their held back
+// count is dropped: the failure they reported is over.
+func (r *policyRecorder) prune(now time.Time) {
+	window := r.policy.AggregationWindow
+	if now.Sub(r.lastPrune) < window {
+		return
+	}
+	r.lastPrune = now
+	for key, ev := range r.seen {
+		if now.Sub(ev.since) >= 2*window {
+			delete(r.seen, key)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+func TestEventPolicy(t *testing.T) {
+	newDFZ := func(uid string) *freezerv1alpha1.DeploymentFreezer {
+		return &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: uid, UID: k8stypes.UID("uid-" + uid)}}
+	}
+	drain := func(rec *record.FakeRecorder) []string {
+		var out []string
+		for {
+			select {
+			case e := <-rec.Events:
+				out = append(out, e)
+			default:
+				return out
+			}
+		}
+	}
+
+	t.Run("ZeroValue_RecordsEverything", func(t *testing.T) {
+		t.Parallel()
+		rec := record.NewFakeRecorder(10)
+		r := EventPolicy{}.Wrap(rec, testingclock.NewFakeClock(time.Now()))
+		dfz := newDFZ("a")
+		r.Event(dfz, corev1.EventTypeNormal, ReasonFrozen, "frozen")
+		r.Event(dfz, corev1.EventTypeWarning, ReasonRestoreFailed, "boom")
+		r.Event(dfz, corev1.EventTypeWarning, ReasonRestoreFailed, "boom")
+		assert.Equal(t, []string{
+			"Normal Frozen frozen",
+			"Warning RestoreReplicasFailed boom",
+			"Warning RestoreReplicasFailed boom",
+		}, drain(rec))
+	})
+
+	t.Run("SpecEventPolicy_Honoured", func(t *testing.T) {
+		t.Parallel()
+		rec := record.NewFakeRecorder(10)
+		r := EventPolicy{}.Wrap(rec, testingclock.NewFakeClock(time.Now()))
+		warnings, none := newDFZ("a"), newDFZ("b")
+		warnings.Spec.EventPolicy = freezerv1alpha1.EventPolicyWarnings
+		none.Spec.EventPolicy = freezerv1alpha1.EventPolicyNone
+		for _, dfz := range []*freezerv1alpha1.DeploymentFreezer{warnings, none} {
+			r.Event(dfz, corev1.EventTypeNormal, ReasonFrozen, "frozen")
+			r.Eventf(dfz, corev1.EventTypeWarning, ReasonRestoreFailed, "boom %s", dfz.Name)
+		}
+		assert.Equal(t, []string{"Warning RestoreReplicasFailed boom a"}, drain(rec))
+	})
+
+	t.Run("ErrorsOnly_DropsNormal", func(t *testing.T) {
+		t.Parallel()
+		rec := record.NewFakeRecorder(10)
+		r := EventPolicy{Verbosity: EventsErrors}.Wrap(rec, testingclock.NewFakeClock(time.Now()))
+		dfz := newDFZ("a")
+		r.Event(dfz, corev1.EventTypeNormal, ReasonFrozen, "frozen")
+		r.Eventf(dfz, corev1.EventTypeWarning, ReasonRestoreFailed, msgReplicasRestoreFailed, 3, "quota")
+		assert.Equal(t, []string{"Warning RestoreReplicasFailed Failed to restore replicas to 3: quota"}, drain(rec))
+	})
+
+	t.Run("RepeatedWarning_CountedAfterWindow", func(t *testing.T) {
+		t.Parallel()
+		rec := record.NewFakeRecorder(10)
+		clk := testingclock.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+		r := EventPolicy{AggregationWindow: 5 * time.Minute}.Wrap(rec, clk)
+		dfz, other := newDFZ("a"), newDFZ("b")
+
+		for range 3 {
+			r.Event(dfz, corev1.EventTypeWarning, ReasonRestoreFailed, "boom")
+			clk.Step(time.Minute)
+		}
+		// The same warning of another DFZ and Normal events are not held back.
+		r.Event(other, corev1.EventTypeWarning, ReasonRestoreFailed, "boom")
+		r.Event(dfz, corev1.EventTypeNormal, ReasonRestored, "ok")
+		r.Event(dfz, corev1.EventTypeNormal, ReasonRestored, "ok")
+		assert.Equal(t, []string{
+			"Warning RestoreReplicasFailed boom",
+			"Warning RestoreReplicasFailed boom",
+			"Normal ReplicasRestored ok",
+			"Normal ReplicasRestored ok",
+		}, drain(rec))
+
+		clk.Step(2 * time.Minute)
+		r.Event(dfz, corev1.EventTypeWarning, ReasonRestoreFailed, "boom")
+		assert.Equal(t, []string{"Warning RestoreReplicasFailed boom (repeated 2 more times in the last 5m0s)"}, drain(rec))
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		t.Parallel()
+		assert.NoError(t, EventPolicy{Verbosity: EventsErrors, AggregationWindow: time.Minute}.Validate())
+		assert.Error(t, EventPolicy{Verbosity: "quiet"}.Validate())
+		assert.Error(t, EventPolicy{AggregationWindow: -time.Second}.Validate())
+	})
+}
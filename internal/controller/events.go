@@ -1,27 +1,96 @@
 package controller
 
 const (
-	ReasonOwnershipDenied      = "OwnershipDenied"
-	ReasonFrozen               = "Frozen"
-	ReasonOwnershipLost        = "OwnershipLost"
-	ReasonUnfreezingStarted    = "UnfreezingStarted"
-	ReasonUnfreezeCompleted    = "UnfreezeCompleted"
-	ReasonSkippedNotOwner      = "SkippedNotOwner"
-	ReasonRestoreFailed        = "RestoreReplicasFailed"
-	ReasonRestored             = "ReplicasRestored"
-	ReasonClearOwnershipFailed = "ClearOwnershipFailed"
-	ReasonOwnershipCleared     = "OwnershipCleared"
+	ReasonOwnershipDenied       = "OwnershipDenied"
+	ReasonFrozen                = "Frozen"
+	ReasonOwnershipLost         = "OwnershipLost"
+	ReasonUnfreezingStarted     = "UnfreezingStarted"
+	ReasonUnfreezeCompleted     = "UnfreezeCompleted"
+	ReasonSkippedNotOwner       = "SkippedNotOwner"
+	ReasonRestoreFailed         = "RestoreReplicasFailed"
+	ReasonRestored              = "ReplicasRestored"
+	ReasonClearOwnershipFailed  = "ClearOwnershipFailed"
+	ReasonOwnershipCleared      = "OwnershipCleared"
+	ReasonDurationClamped       = "DurationClamped"
+	ReasonPolicyDenied          = "PolicyDenied"
+	ReasonProtectedNamespace    = "ProtectedNamespace"
+	ReasonDriftDetected         = "DriftDetected"
+	ReasonCanaryStarted         = "CanaryStarted"
+	ReasonCanaryFailed          = "CanaryFailed"
+	ReasonPostUnfreezeUnhealthy = "PostUnfreezeUnhealthy"
+	ReasonAwaitingGitOps        = "AwaitingGitOps"
+	ReasonKillSwitchEngaged     = "KillSwitchEngaged"
+	ReasonNodeFreezeDiscovered  = "NodeFreezeDiscovered"
+	ReasonFreezeWindowChanged   = "FreezeWindowChanged"
+	ReasonAutoFreezeCreated     = "AutoFreezeCreated"
+	ReasonAutoFreezeRefused     = "AutoFreezeRefused"
+	ReasonInvalidFreezeFor      = "InvalidFreezeFor"
+	ReasonReconciliationPaused  = "ReconciliationPaused"
+	ReasonReconciliationResumed = "ReconciliationResumed"
+	ReasonStaleOwnership        = "StaleOwnership"
+	ReasonAcquireTimeout        = "AcquireTimeout"
+	ReasonUnfreezeDeferred      = "UnfreezeDeferred"
+	ReasonBlackoutHold          = "BlackoutHold"
+	ReasonAutoFreezeTripped     = "AutoFreezeTripped"
+	ReasonMetricQueryFailed     = "MetricQueryFailed"
+	ReasonInvalidSelector       = "InvalidSelector"
+	ReasonTrafficDiverted       = "TrafficDiverted"
+	ReasonTrafficRestored       = "TrafficRestored"
+	ReasonAwaitingPDB           = "AwaitingPDB"
+	ReasonRestartDeferred       = "RestartDeferred"
+	ReasonExemptionGranted      = "ExemptionGranted"
+	ReasonFreezeGroupSynced     = "FreezeGroupSynced"
+	ReasonRestoreSkipped        = "RestoreSkipped"
+	ReasonPreempting            = "Preempting"
+	ReasonPreempted             = "Preempted"
+	ReasonPodsRemaining         = "PodsRemaining"
 )
 
 const (
-	msgOwnershipDenied       = "Deployment %s/%s is already owned by %s"
-	msgFrozenUntil           = "Deployment frozen until %s"
-	msgOwnershipLost         = "Ownership annotation lost or overwritten on Deployment %s/%s"
-	msgUnfreezingStarted     = "Freeze window elapsed; starting unfreeze"
-	msgUnfreezeCompleted     = "Unfreeze completed; replicas restored to %d"
-	msgSkippedNotOwner       = "Ownership annotation does not match; expected %q"
-	msgReplicasRestoreFailed = "Failed to restore replicas to %d: %v"
-	msgReplicasRestored      = "Restored replicas to %d"
-	msgClearOwnershipFailed  = "Failed to clear ownership annotation: %v"
-	msgOwnershipCleared      = "Cleared ownership annotation on Deployment %s/%s"
+	msgOwnershipDenied          = "Deployment %s/%s is already owned by %s"
+	msgFrozenUntil              = "Deployment frozen until %s"
+	msgOwnershipLost            = "Ownership annotation lost or overwritten on Deployment %s/%s"
+	msgOwnershipLostToSuffix    = "; now owned by %s"
+	msgFreezeGroupSynced        = "Freeze group %s unfreezes at %s"
+	msgUnfreezingStarted        = "Freeze window elapsed; starting unfreeze"
+	msgWokenByRequest           = "Woken by a request at %s; starting unfreeze"
+	msgUnfreezeCompleted        = "Unfreeze completed; replicas restored to %d"
+	msgSkippedNotOwner          = "Ownership annotation does not match; expected %q"
+	msgReplicasRestoreFailed    = "Failed to restore replicas to %d: %v"
+	msgReplicasRestored         = "Restored replicas to %d"
+	msgReplicasPausedFailed     = "Failed to restore replicas to %d and spec.paused to %t: %v"
+	msgReplicasPausedRestored   = "Restored replicas to %d and spec.paused to %t"
+	msgAutoscalingRestoreFailed = "Failed to restore autoscalers: %v"
+	msgClearOwnershipFailed     = "Failed to clear ownership annotation: %v"
+	msgOwnershipCleared         = "Cleared ownership annotation on Deployment %s/%s"
+	msgRestoreSkipped           = "Restore skipped as requested by the skip-restore annotation; %s/%s left at %d replicas"
+	msgDurationClamped          = "Requested duration %s exceeds the maximum; freezing for %s"
+	msgCanaryStartedEvent       = "Freeze window elapsed; restoring a single canary replica"
+	msgKillSwitchEngagedEvent   = "Scale-down held: kill switch %s is engaged"
+	msgNodeFreezeDiscovered     = "Freezing %d Deployments with pods on node %s"
+	msgFreezeWindowChanged      = "Freeze window changed; unfreezing at %s"
+	msgAutoFreezeCreated        = "Created DeploymentFreezer %s to freeze this Deployment for %s"
+	msgAutoFreezeRefused        = "DeploymentFreezer refused: %v"
+	msgInvalidFreezeFor         = "Invalid %s annotation: %v"
+	msgReconciliationPaused     = "Reconciliation paused by the %s annotation"
+	msgReconciliationResumed    = "Reconciliation resumed"
+	msgStaleOwnership           = "Taking over Deployment %s/%s from %s, which no longer holds it"
+	msgAcquireTimeout           = "Deployment %s/%s is still held by %s after %s; giving up"
+	msgUnfreezeDeferred         = "Freeze window elapsed outside the unfreeze window; unfreezing at %s"
+	msgBlackoutHold             = "Holding in phase %s: blackout %s of FreezerPolicy %s lasts until %s"
+	msgAutoFreezeTripped        = "Freezing for %s by AutoFreezePolicy %s: %s"
+	msgAutoFreezeTrippedPolicy  = "Freezing Deployment %s for %s: %s"
+	msgMetricQueryFailed        = "Metric query for Deployment %s failed: %v"
+	msgMetricSourceMissing      = "Metric trigger not evaluated: the controller runs without --prometheus-url"
+	msgInvalidSelector          = "Invalid selector: %v"
+	msgTrafficDiverted          = "Diverting traffic while frozen: %s"
+	msgTrafficNoRouteEvent      = "Nothing to divert traffic to; freezing without diverting traffic"
+	msgTrafficRestored          = "Restored traffic: %s"
+	msgTrafficRestoreFailed     = "Failed to restore traffic: %v"
+	msgAwaitingPDB              = "Scale-down blocked by PodDisruptionBudgets: %s"
+	msgRestartDeferred          = "Rollout restart requested at %s has no effect while frozen; it rolls out once replicas are restored"
+	msgExemptionGranted         = "Exempted from %s by FreezerPolicy %s"
+	msgPreempting               = "Took Deployment %s/%s over from %s (priority %d) with its recorded replicas"
+	msgPreempted                = "Deployment %s/%s was taken over by %s (priority %d); it stays frozen"
+	msgPodsRemainingTimeout     = "Still waiting for Pods after %s; the target stays Freezing until they are gone: %s"
 )
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// memberFreezeUntil returns the DFZ's own deadline: status.memberFreezeUntil once it joined a
+// freeze group, status.freezeUntil otherwise.
+func memberFreezeUntil(dfz *freezerv1alpha1.DeploymentFreezer) *metav1.Time {
+	if dfz.Status.MemberFreezeUntil != nil {
+		return dfz.Status.MemberFreezeUntil
+	}
+	return dfz.Status.FreezeUntil
+}
+
+// syncFreezeGroup moves FreezeUntil to the latest own deadline of the Frozen DFZs sharing the
+// DFZ's spec.freezeGroup, so that the group unfreezes together. Members are recomputed on every
+// reconcile, so the group deadline drops back when a member is shortened or goes away. Members
+// that are not Frozen yet have no deadline and do not hold the group.
+func (r *DeploymentFreezerReconciler) syncFreezeGroup(ctx context.Context, dfz *freezerv1alpha1.DeploymentFreezer) {
+	group := dfz.Spec.FreezeGroup
+	if group == "" {
+		// Left the group: back to its own deadline.
+		if own := dfz.Status.MemberFreezeUntil; own != nil {
+			dfz.Status.FreezeUntil = own
+			dfz.Status.MemberFreezeUntil = nil
+		}
+		return
+	}
+	if dfz.Status.FreezeUntil == nil {
+		return
+	}
+	if dfz.Status.MemberFreezeUntil == nil {
+		own := *dfz.Status.FreezeUntil
+		dfz.Status.MemberFreezeUntil = &own
+	}
+
+	var list freezerv1alpha1.DeploymentFreezerList
+	if err := r.List(ctx, &list, client.InNamespace(dfz.Namespace)); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list the freeze group", "group", group)
+		return
+	}
+	until := dfz.Status.MemberFreezeUntil.Time
+	for i := range list.Items {
+		m := &list.Items[i]
+		if m.UID == dfz.UID || m.Spec.FreezeGroup != group || m.Status.Phase != freezerv1alpha1.PhaseFrozen {
+			continue
+		}
+		if t := memberFreezeUntil(m); t != nil && t.After(until) {
+			until = t.Time
+		}
+	}
+	if until.Equal(dfz.Status.FreezeUntil.Time) {
+		return
+	}
+	t := metav1.NewTime(until)
+	dfz.Status.FreezeUntil = &t
+	r.Recorder.Eventf(dfz, corev1.EventTypeNormal, ReasonFreezeGroupSynced, msgFreezeGroupSynced,
+		group, until.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestFreezeGroup(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	frozenAt := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	member := func(name, group string, phase freezerv1alpha1.Phase, d time.Duration) *freezerv1alpha1.DeploymentFreezer {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "shop", Name: name, UID: types.UID("uid-" + name),
+		}}
+		dfz.Spec.TargetRef.Name = name
+		dfz.Spec.FreezeGroup = group
+		dfz.Spec.DurationSeconds = int64(d.Seconds())
+		dfz.Status.Phase = phase
+		if phase == freezerv1alpha1.PhaseFrozen {
+			until := metav1.NewTime(frozenAt.Add(d))
+			dfz.Status.FreezeUntil = &until
+			dfz.Status.PhaseTransitionTimes = map[freezerv1alpha1.Phase]metav1.Time{phase: metav1.NewTime(frozenAt)}
+		}
+		return dfz
+	}
+	newReconciler := func(objs ...client.Object) (*DeploymentFreezerReconciler, *record.FakeRecorder) {
+		rec := record.NewFakeRecorder(10)
+		return &DeploymentFreezerReconciler{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+			Recorder: rec,
+			Clock:    testingclock.NewFakeClock(frozenAt.Add(time.Minute)),
+		}, rec
+	}
+
+	t.Run("LatestFrozenMemberWins", func(t *testing.T) {
+		t.Parallel()
+		web := member("web", "stack", freezerv1alpha1.PhaseFrozen, time.Hour)
+		api := member("api", "stack", freezerv1alpha1.PhaseFrozen, 3*time.Hour)
+		r, rec := newReconciler(web, api,
+			member("db", "stack", freezerv1alpha1.PhaseFreezing, 8*time.Hour),
+			member("batch", "other", freezerv1alpha1.PhaseFrozen, 5*time.Hour),
+			member("done", "stack", freezerv1alpha1.PhaseCompleted, 9*time.Hour),
+		)
+
+		r.syncFreezeGroup(context.Background(), web)
+		assert.Equal(t, frozenAt.Add(3*time.Hour), web.Status.FreezeUntil.UTC())
+		assert.Equal(t, frozenAt.Add(time.Hour), web.Status.MemberFreezeUntil.UTC())
+		assert.Equal(t, []string{"Normal FreezeGroupSynced Freeze group stack unfreezes at 2026-01-01T13:00:00Z"},
+			drainEvents(rec))
+
+		// The member holding the group goes away: back to the own deadline.
+		require.NoError(t, r.Delete(context.Background(), api))
+		r.syncFreezeGroup(context.Background(), web)
+		assert.Equal(t, frozenAt.Add(time.Hour), web.Status.FreezeUntil.UTC())
+	})
+
+	t.Run("ResizeMovesOwnDeadline", func(t *testing.T) {
+		t.Parallel()
+		web := member("web", "stack", freezerv1alpha1.PhaseFrozen, time.Hour)
+		api := member("api", "stack", freezerv1alpha1.PhaseFrozen, 3*time.Hour)
+		r, _ := newReconciler(web, api)
+		r.syncFreezeGroup(context.Background(), web)
+
+		web.Spec.DurationSeconds = int64((4 * time.Hour).Seconds())
+		r.resizeFreezeWindow(context.Background(), web)
+		r.syncFreezeGroup(context.Background(), web)
+		assert.Equal(t, frozenAt.Add(4*time.Hour), web.Status.MemberFreezeUntil.UTC())
+		assert.Equal(t, frozenAt.Add(4*time.Hour), web.Status.FreezeUntil.UTC())
+	})
+
+	t.Run("LeftGroup_OwnDeadline", func(t *testing.T) {
+		t.Parallel()
+		web := member("web", "stack", freezerv1alpha1.PhaseFrozen, time.Hour)
+		r, _ := newReconciler(web, member("api", "stack", freezerv1alpha1.PhaseFrozen, 3*time.Hour))
+		r.syncFreezeGroup(context.Background(), web)
+
+		web.Spec.FreezeGroup = ""
+		r.syncFreezeGroup(context.Background(), web)
+		assert.Equal(t, frozenAt.Add(time.Hour), web.Status.FreezeUntil.UTC())
+		assert.Nil(t, web.Status.MemberFreezeUntil)
+	})
+}
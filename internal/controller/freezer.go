@@ -0,0 +1,26 @@
+package controller
+
+import (
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
+)
+
+// freezer returns the pkg/freeze Freezer that performs this reconciler's Deployment and
+// autoscaler writes.
+func (r *DeploymentFreezerReconciler) freezer() *freeze.Freezer {
+	return &freeze.Freezer{Client: r.Client, Reader: r.APIReader, LeanRBAC: r.LeanRBAC}
+}
+
+// freezeState is the part of the DFZ status that pkg/freeze records and restores from.
+func freezeState(dfz *freezerv1alpha1.DeploymentFreezer) *freeze.State {
+	return &freeze.State{
+		OriginalReplicas: dfz.Status.OriginalReplicas,
+		OriginalPaused:   dfz.Status.OriginalPaused,
+		Snapshot:         dfz.Status.Snapshot,
+	}
+}
+
+// pausedToRestore returns the spec.paused value the Deployment must get back on unfreeze, if any.
+func (r *DeploymentFreezerReconciler) pausedToRestore(dfz *freezerv1alpha1.DeploymentFreezer) *bool {
+	return r.freezer().RestorePaused(freezeState(dfz))
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// freezeStateTimeLayout keeps the annotation short: minutes are precise enough for a reader.
+const freezeStateTimeLayout = "2006-01-02T15:04Z07:00"
+
+// describeFreeze describes the DFZ's hold on its Deployment for the freeze-state annotation, e.g.
+// "frozen until 2025-01-01T02:00Z by default/freeze-demo (error rate above 5%)".
+func describeFreeze(dfz *freezerv1alpha1.DeploymentFreezer) string {
+	phase := strings.ToLower(string(dfz.Status.Phase))
+	if dfz.Status.Phase == freezerv1alpha1.PhaseFrozen && dfz.Status.FreezeUntil != nil {
+		phase += " until " + dfz.Status.FreezeUntil.UTC().Format(freezeStateTimeLayout)
+	}
+	state := fmt.Sprintf("%s by %s/%s", phase, dfz.Namespace, dfz.Name)
+	if reason := dfz.Annotations[AnnoAutoFreezeReason]; reason != "" {
+		state += " (" + reason + ")"
+	}
+	return state
+}
+
+// syncFreezeState keeps the freeze-state annotation of the target in line with the DFZ: it
+// describes the freeze while the DFZ holds the target and is removed once the target is
+// released. A target held by another owner is left to that owner. The annotation is only
+// informational, so a failed write is logged and retried on the next reconcile.
+func (r *DeploymentFreezerReconciler) syncFreezeState(
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	target freeze.Freezable,
+) {
+	var want string
+	switch owner := target.Owner(); {
+	case owner == "":
+	case isFrozenBy(owner, dfz):
+		want = describeFreeze(dfz)
+	default:
+		return
+	}
+	obj := target.Object()
+	if obj.GetAnnotations()[annoFreezeState] == want {
+		return
+	}
+	orig := obj.DeepCopyObject().(client.Object)
+	annotations := obj.GetAnnotations()
+	if want == "" {
+		delete(annotations, annoFreezeState)
+	} else {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[annoFreezeState] = want
+	}
+	obj.SetAnnotations(annotations)
+	if err := r.Patch(ctx, obj, client.MergeFrom(orig), r.patchOpts(dfz)...); err != nil {
+		log.FromContext(ctx).Error(err, "failed to update the freeze-state annotation",
+			"namespace", obj.GetNamespace(), "name", obj.GetName())
+	}
+}
@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestFreezeState(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	until := metav1.NewTime(time.Date(2025, 1, 1, 2, 0, 30, 0, time.UTC))
+	newDFZ := func(phase freezerv1alpha1.Phase) *freezerv1alpha1.DeploymentFreezer {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default", Name: "freeze-demo", UID: "uid-1",
+		}}
+		dfz.Status.Phase = phase
+		dfz.Status.FreezeUntil = &until
+		return dfz
+	}
+	// sync runs syncFreezeState against a Deployment held by owner and returns the stored annotations.
+	sync := func(t *testing.T, dfz *freezerv1alpha1.DeploymentFreezer, owner, state string) map[string]string {
+		deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"}}
+		if owner != "" {
+			setAnnotation(&deploy.ObjectMeta, annoFrozenBy, owner)
+		}
+		if state != "" {
+			setAnnotation(&deploy.ObjectMeta, annoFreezeState, state)
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deploy).Build()
+		r := &DeploymentFreezerReconciler{Client: c}
+		ctx := context.Background()
+		require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(deploy), deploy))
+		target, err := r.freezer().Target(deploy)
+		require.NoError(t, err)
+
+		r.syncFreezeState(ctx, dfz, target)
+		got := &appsv1.Deployment{}
+		require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(deploy), got))
+		return got.Annotations
+	}
+
+	t.Run("Frozen_DescribesFreeze", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ(freezerv1alpha1.PhaseFrozen)
+		dfz.Annotations = map[string]string{AnnoAutoFreezeReason: "maintenance"}
+		got := sync(t, dfz, frozenByValue(dfz), "freezing by default/freeze-demo")
+		assert.Equal(t, "frozen until 2025-01-01T02:00Z by default/freeze-demo (maintenance)", got[annoFreezeState])
+	})
+
+	t.Run("Unfreezing_DescribesPhase", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ(freezerv1alpha1.PhaseUnfreezing)
+		got := sync(t, dfz, frozenByValue(dfz), "")
+		assert.Equal(t, "unfreezing by default/freeze-demo", got[annoFreezeState])
+	})
+
+	t.Run("Released_Removed", func(t *testing.T) {
+		t.Parallel()
+		got := sync(t, newDFZ(freezerv1alpha1.PhaseCompleted), "", "unfreezing by default/freeze-demo")
+		assert.NotContains(t, got, annoFreezeState)
+	})
+
+	t.Run("OtherOwner_LeftAlone", func(t *testing.T) {
+		t.Parallel()
+		got := sync(t, newDFZ(freezerv1alpha1.PhaseDenied), "default/other/uid-2", "frozen by default/other")
+		assert.Equal(t, "frozen by default/other", got[annoFreezeState])
+	})
+}
@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// observeGeneration records the spec generation as observed once a reconcile acted on it: the
+// reconcile returned no error and no operation failed on the way. A failed reconcile leaves
+// observedGeneration behind, so clients keep waiting until a retry applies the spec. The
+// Progressing condition is kept in line with it.
+func observeGeneration(dfz *freezerv1alpha1.DeploymentFreezer, st statusTracker, err error) {
+	failed := dfz.Status.LastError != nil && !equality.Semantic.DeepEqual(st.orig.LastError, dfz.Status.LastError)
+	if err == nil && !failed {
+		dfz.Status.ObservedGeneration = dfz.Generation
+	}
+
+	phase := dfz.Status.Phase
+	if phase == "" {
+		phase = freezerv1alpha1.PhasePending
+	}
+	switch observed := dfz.Status.ObservedGeneration; {
+	case observed != dfz.Generation:
+		setStableCondition(dfz, freezerv1alpha1.ConditionTypeProgressing, freezerv1alpha1.ConditionStatusTrue,
+			freezerv1alpha1.ConditionReasonNewGeneration, fmt.Sprintf(msgGenerationPendingFmt, dfz.Generation))
+	case phase == freezerv1alpha1.PhaseFreezing || phase == freezerv1alpha1.PhaseUnfreezing:
+		setStableCondition(dfz, freezerv1alpha1.ConditionTypeProgressing, freezerv1alpha1.ConditionStatusTrue,
+			freezerv1alpha1.ConditionReason(phase), fmt.Sprintf(msgProgressingFmt, observed, strings.ToLower(string(phase))))
+	default:
+		setStableCondition(dfz, freezerv1alpha1.ConditionTypeProgressing, freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReason(phase), fmt.Sprintf(msgSettledFmt, observed, phase))
+	}
+}
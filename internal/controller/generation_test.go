@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"errors"
+	"testing"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestObserveGeneration(t *testing.T) {
+	newDFZ := func(phase freezerv1alpha1.Phase) *freezerv1alpha1.DeploymentFreezer {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+		dfz.Status.Phase = phase
+		dfz.Status.ObservedGeneration = 1
+		return dfz
+	}
+	progressing := func(t *testing.T, dfz *freezerv1alpha1.DeploymentFreezer) freezerv1alpha1.Condition {
+		for _, c := range dfz.Status.Conditions {
+			if c.Type == freezerv1alpha1.ConditionTypeProgressing {
+				return c
+			}
+		}
+		require.Fail(t, "no Progressing condition")
+		return freezerv1alpha1.Condition{}
+	}
+
+	t.Run("Applied_Observed", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ(freezerv1alpha1.PhaseFrozen)
+		observeGeneration(dfz, newStatusTracker(dfz), nil)
+
+		assert.Equal(t, int64(2), dfz.Status.ObservedGeneration)
+		c := progressing(t, dfz)
+		assert.Equal(t, freezerv1alpha1.ConditionStatusFalse, c.Status)
+		assert.Equal(t, freezerv1alpha1.ConditionReason(freezerv1alpha1.PhaseFrozen), c.Reason)
+		assert.Equal(t, "generation 2 applied (phase Frozen)", c.Message)
+	})
+
+	t.Run("Error_NotObserved", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ(freezerv1alpha1.PhaseFrozen)
+		observeGeneration(dfz, newStatusTracker(dfz), errors.New("boom"))
+
+		assert.Equal(t, int64(1), dfz.Status.ObservedGeneration)
+		c := progressing(t, dfz)
+		assert.Equal(t, freezerv1alpha1.ConditionStatusTrue, c.Status)
+		assert.Equal(t, freezerv1alpha1.ConditionReasonNewGeneration, c.Reason)
+	})
+
+	t.Run("OperationFailed_NotObserved", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ(freezerv1alpha1.PhaseFreezing)
+		st := newStatusTracker(dfz)
+		r := &DeploymentFreezerReconciler{}
+		r.operationFailed(dfz, opFreezeTarget, "conflict")
+		observeGeneration(dfz, st, nil)
+
+		assert.Equal(t, int64(1), dfz.Status.ObservedGeneration)
+		assert.Equal(t, freezerv1alpha1.ConditionReasonNewGeneration, progressing(t, dfz).Reason)
+	})
+
+	t.Run("EarlierFailure_DoesNotBlock", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ(freezerv1alpha1.PhaseUnfreezing)
+		dfz.Status.LastError = &freezerv1alpha1.OperationError{Operation: opRestoreReplicas, Attempts: 1}
+		observeGeneration(dfz, newStatusTracker(dfz), nil)
+
+		assert.Equal(t, int64(2), dfz.Status.ObservedGeneration)
+		c := progressing(t, dfz)
+		assert.Equal(t, freezerv1alpha1.ConditionStatusTrue, c.Status)
+		assert.Equal(t, freezerv1alpha1.ConditionReason(freezerv1alpha1.PhaseUnfreezing), c.Reason)
+	})
+}
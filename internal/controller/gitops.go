@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// handleGitOpsUnfreeze leaves the restore to the GitOps pipeline: it reports that the freeze
+// window elapsed and waits until the Deployment is back at the snapshotted state.
+func (r *DeploymentFreezerReconciler) handleGitOpsUnfreeze(
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	deploy *appsv1.Deployment,
+) ctrl.Result {
+	targetReplicas := *dfz.Status.OriginalReplicas
+	if !gitOpsRestored(deploy, targetReplicas, r.pausedToRestore(dfz)) {
+		msg := fmt.Sprintf(msgAwaitingGitOpsFmt, targetReplicas)
+		if !hasCondition(
+			dfz,
+			freezerv1alpha1.ConditionTypeGitOpsSync,
+			freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonAwaitingGitOps,
+		) {
+			r.Recorder.Event(dfz, corev1.EventTypeNormal, ReasonAwaitingGitOps, msg)
+		}
+		setStableCondition(
+			dfz,
+			freezerv1alpha1.ConditionTypeGitOpsSync,
+			freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonAwaitingGitOps,
+			msg,
+		)
+		// Spec changes re-trigger the reconcile; the requeue is only a safety net.
+		return ctrl.Result{RequeueAfter: driftCheckInterval}
+	}
+
+	// The KEDA pause was set by the controller, not by Git, so it is undone here
+	// once replicas are back; earlier KEDA would start scaling on its own.
+	if so := ptr.Deref(dfz.Status.Snapshot, freezerv1alpha1.AutoscalingSnapshot{}).ScaledObject; so != nil {
+		snap := &freezerv1alpha1.AutoscalingSnapshot{ScaledObject: so}
+		if err := r.freezer().RestoreAutoscaling(ctx, dfz.Namespace, snap, r.patchOpts(dfz)...); err != nil {
+			r.operationFailed(dfz, opRestoreAutoscaling, fmt.Sprintf(msgFailedRestoreAutoscalingFmt, err))
+			return ctrl.Result{RequeueAfter: requeueShort}
+		}
+	}
+
+	if res, wait := r.restoreTraffic(ctx, dfz, deploy, targetReplicas); wait {
+		return res
+	}
+
+	if err := r.freezer().Update(ctx, deploy, freeze.Change{FrozenBy: ptr.To("")}, r.patchOpts(dfz)...); err != nil {
+		r.operationFailed(dfz, opReleaseOwnership, fmt.Sprintf(msgFailedClearOwnershipFmt, err))
+		return ctrl.Result{RequeueAfter: requeueShort}
+	}
+
+	setStableCondition(
+		dfz,
+		freezerv1alpha1.ConditionTypeGitOpsSync,
+		freezerv1alpha1.ConditionStatusTrue,
+		freezerv1alpha1.ConditionReasonSynced,
+		fmt.Sprintf(msgGitOpsSyncedFmt, targetReplicas),
+	)
+	return r.releaseAfterUnfreeze(dfz, deploy, targetReplicas)
+}
+
+// gitOpsRestoreOnDelete leaves replicas and spec.paused of a deleted DFZ's target to the GitOps
+// pipeline, as the unfreeze does, and only undoes the KEDA pause set by the controller. The
+// deletion does not wait for Git; the pipeline is asked to restore on the DFZ and the Deployment.
+func (r *DeploymentFreezerReconciler) gitOpsRestoreOnDelete(
+	ctx context.Context,
+	target freeze.Freezable,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+) error {
+	if so := ptr.Deref(dfz.Status.Snapshot, freezerv1alpha1.AutoscalingSnapshot{}).ScaledObject; so != nil {
+		snap := &freezerv1alpha1.AutoscalingSnapshot{ScaledObject: so}
+		if err := r.freezer().RestoreAutoscaling(ctx, dfz.Namespace, snap, r.patchOpts(dfz)...); err != nil {
+			r.Recorder.Eventf(dfz, corev1.EventTypeWarning, ReasonRestoreFailed, msgAutoscalingRestoreFailed, err)
+			return err
+		}
+	}
+	replicas := ptr.Deref(dfz.Status.OriginalReplicas, defaultReplicasCount)
+	obj := target.Object()
+	msg := fmt.Sprintf(msgGitOpsDeletedFmt, dfz.Name, replicas)
+	r.Recorder.Event(dfz, corev1.EventTypeNormal, ReasonAwaitingGitOps, msg)
+	r.Recorder.Event(obj, corev1.EventTypeNormal, ReasonAwaitingGitOps, msg)
+	observeSavings(dfz, obj, r.Clock.Now())
+	return nil
+}
+
+// gitOpsRestored reports whether the Deployment is back at the snapshotted replicas and paused flag.
+func gitOpsRestored(deploy *appsv1.Deployment, replicas int32, paused *bool) bool {
+	if ptr.Deref(deploy.Spec.Replicas, 1) != replicas {
+		return false
+	}
+	return paused == nil || deploy.Spec.Paused == *paused
+}
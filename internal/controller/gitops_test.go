@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestGitOpsRestored(t *testing.T) {
+	newDeploy := func(replicas int32, paused bool) *appsv1.Deployment {
+		d := &appsv1.Deployment{}
+		d.Spec.Replicas = ptr.To(replicas)
+		d.Spec.Paused = paused
+		return d
+	}
+
+	t.Run("StillFrozen_NotRestored", func(t *testing.T) {
+		t.Parallel()
+		assert.False(t, gitOpsRestored(newDeploy(0, false), 3, nil))
+	})
+
+	t.Run("ReplicasMatch_Restored", func(t *testing.T) {
+		t.Parallel()
+		assert.True(t, gitOpsRestored(newDeploy(3, false), 3, nil))
+	})
+
+	t.Run("PausedMismatch_NotRestored", func(t *testing.T) {
+		t.Parallel()
+		assert.False(t, gitOpsRestored(newDeploy(3, true), 3, ptr.To(false)))
+		assert.True(t, gitOpsRestored(newDeploy(3, false), 3, ptr.To(false)))
+	})
+}
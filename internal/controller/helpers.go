@@ -3,15 +3,44 @@ package controller
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func setPhase(dfz *freezerv1alpha1.DeploymentFreezer, phase freezerv1alpha1.Phase) {
+// setPhase sets the phase and records the first time it was entered, on the reconciler clock.
+func (r *DeploymentFreezerReconciler) setPhase(dfz *freezerv1alpha1.DeploymentFreezer, phase freezerv1alpha1.Phase) {
+	recordPhase(dfz, phase, r.now())
+}
+
+// recordPhase sets the phase, recording now as the time the DFZ first entered it.
+func recordPhase(dfz *freezerv1alpha1.DeploymentFreezer, phase freezerv1alpha1.Phase, now time.Time) {
 	dfz.Status.Phase = phase
+	if _, ok := dfz.Status.PhaseTransitionTimes[phase]; ok {
+		return
+	}
+	if dfz.Status.PhaseTransitionTimes == nil {
+		dfz.Status.PhaseTransitionTimes = map[freezerv1alpha1.Phase]metav1.Time{}
+	}
+	dfz.Status.PhaseTransitionTimes[phase] = metav1.NewTime(now)
+}
+
+func isTerminalPhase(phase freezerv1alpha1.Phase) bool {
+	switch phase {
+	case freezerv1alpha1.PhaseDenied, freezerv1alpha1.PhaseCompleted, freezerv1alpha1.PhaseAborted:
+		return true
+	default:
+		return false
+	}
 }
 
 func phaseForNotFound(dfz *freezerv1alpha1.DeploymentFreezer) freezerv1alpha1.Phase {
@@ -32,12 +61,14 @@ func setCondition(
 	message string,
 ) {
 	now := metav1.Now()
+	message = conditionMessage(dfz, condType, condReason, message)
 
 	conds := dfz.Status.Conditions
 	newC := freezerv1alpha1.Condition{
 		Type:               condType,
 		Status:             condStatus,
 		Reason:             condReason,
+		Code:               condReason.Code(),
 		Message:            message,
 		LastTransitionTime: now,
 	}
@@ -48,8 +79,10 @@ func setCondition(
 			if conds[i].Status != condStatus || conds[i].Reason != condReason || conds[i].Message != message {
 				conds[i] = newC
 			} else {
-				// unchanged -> refresh transition time
+				// unchanged -> refresh transition time; backfill the code of conditions written
+				// before codes existed
 				conds[i].LastTransitionTime = now
+				conds[i].Code = newC.Code
 			}
 			replaced = true
 			break
@@ -61,19 +94,139 @@ func setCondition(
 	dfz.Status.Conditions = conds
 }
 
-func hashTemplate(d *appsv1.Deployment) string {
-	h := sha256.New()
-	// Hash the bits of spec that imply rollout: pod template and strategy
-	if _, err := fmt.Fprintf(h, "%v", d.Spec.Template.Spec); err != nil {
-		return ""
+// hashTemplate hashes the parts of a target's spec that imply a rollout, or new Pods: the pod
+// template and, for a Deployment, DaemonSet or Advanced StatefulSet, its strategy. The node
+// selector that freezes a DaemonSet is left out, so the freeze itself is not taken for a spec
+// change, and so are sidecars injected by OpenKruise SidecarSets. Fields at their server default
+// are normalized away and the rest is hashed as JSON, whose map keys are sorted.
+func hashTemplate(obj client.Object) string {
+	var tpl corev1.PodTemplateSpec
+	var strategy any
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		tpl, strategy = o.Spec.Template, defaultedDeploymentStrategy(o.Spec.Strategy)
+	case *appsv1.ReplicaSet:
+		tpl = o.Spec.Template
+	case *corev1.ReplicationController:
+		if o.Spec.Template != nil {
+			tpl = *o.Spec.Template
+		}
+	case *appsv1.DaemonSet:
+		tpl, strategy = freeze.UnfrozenTemplate(o), defaultedDaemonSetStrategy(o.Spec.UpdateStrategy)
+	case *unstructured.Unstructured:
+		if t := podTemplate(o); t != nil {
+			tpl = *t
+		}
+		if s, ok, _ := unstructured.NestedMap(o.Object, "spec", "updateStrategy"); ok {
+			strategy = s
+		}
 	}
-	if _, err := fmt.Fprintf(h, "%v", d.Spec.Template.Labels); err != nil {
+
+	data, err := json.Marshal(struct {
+		Labels   map[string]string `json:"labels,omitempty"`
+		Spec     corev1.PodSpec    `json:"spec"`
+		Strategy any               `json:"strategy,omitempty"`
+	}{tpl.Labels, normalizedPodSpec(withoutInjectedSidecars(tpl.Spec)), strategy})
+	if err != nil {
 		return ""
 	}
-	if _, err := fmt.Fprintf(h, "%v", d.Spec.Strategy); err != nil {
-		return ""
+	sum := sha256.Sum256(data)
+	return templateHashPrefix + hex.EncodeToString(sum[:])
+}
+
+// restartedAt returns the `kubectl rollout restart` time recorded in the target's pod template.
+func restartedAt(obj client.Object) string {
+	if tpl := podTemplate(obj); tpl != nil {
+		return tpl.Annotations[annoKubectlRestart]
+	}
+	return ""
+}
+
+// hasCondition reports whether the DFZ has a condition of the given type, status and reason.
+func hasCondition(
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	condType freezerv1alpha1.ConditionType,
+	condStatus freezerv1alpha1.ConditionStatus,
+	condReason freezerv1alpha1.ConditionReason,
+) bool {
+	for _, c := range dfz.Status.Conditions {
+		if c.Type == condType {
+			return c.Status == condStatus && c.Reason == condReason
+		}
+	}
+	return false
+}
+
+// setStableCondition is setCondition for conditions with fixed semantics: LastTransitionTime
+// only moves when status or reason change, so `kubectl wait` users see real transitions and
+// an unchanged condition does not cause a status write.
+func setStableCondition(
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	condType freezerv1alpha1.ConditionType,
+	condStatus freezerv1alpha1.ConditionStatus,
+	condReason freezerv1alpha1.ConditionReason,
+	message string,
+) {
+	for i := range dfz.Status.Conditions {
+		c := &dfz.Status.Conditions[i]
+		if c.Type == condType && c.Status == condStatus && c.Reason == condReason {
+			c.Message = conditionMessage(dfz, condType, condReason, message)
+			c.Code = condReason.Code()
+			return
+		}
+	}
+	setCondition(dfz, condType, condStatus, condReason, message)
+}
+
+// syncWaitConditions derives the Frozen and Completed conditions from the phase.
+func syncWaitConditions(dfz *freezerv1alpha1.DeploymentFreezer) {
+	phase := dfz.Status.Phase
+	if phase == "" {
+		phase = freezerv1alpha1.PhasePending
+	}
+	reason := freezerv1alpha1.ConditionReason(phase)
+
+	if phase == freezerv1alpha1.PhaseFrozen {
+		setStableCondition(dfz, freezerv1alpha1.ConditionTypeFrozen,
+			freezerv1alpha1.ConditionStatusTrue, reason, msgWaitFrozen)
+	} else {
+		setStableCondition(dfz, freezerv1alpha1.ConditionTypeFrozen,
+			freezerv1alpha1.ConditionStatusFalse, reason, fmt.Sprintf(msgWaitNotFrozenFmt, phase))
+	}
+
+	if phase == freezerv1alpha1.PhaseCompleted {
+		setStableCondition(dfz, freezerv1alpha1.ConditionTypeCompleted,
+			freezerv1alpha1.ConditionStatusTrue, reason, msgWaitCompleted)
+	} else {
+		setStableCondition(dfz, freezerv1alpha1.ConditionTypeCompleted,
+			freezerv1alpha1.ConditionStatusFalse, reason, fmt.Sprintf(msgWaitNotCompletedFmt, phase))
 	}
-	return hex.EncodeToString(h.Sum(nil))
+}
+
+// freezeDuration resolves the requested spec duration against the controller-wide default (used when unset)
+// and maximum (0 means unlimited). It reports whether the requested duration was clamped.
+func freezeDuration(requested, defaultDuration, maxDuration time.Duration) (time.Duration, bool) {
+	d := requested
+	if requested <= 0 {
+		d = defaultDuration
+	}
+	if maxDuration > 0 && d > maxDuration {
+		return maxDuration, true
+	}
+	return d, false
+}
+
+// frozenByValue is the frozen-by annotation value naming dfz as the owner of its Deployment.
+// The UID keeps a DFZ that was deleted and recreated under the same name from passing for
+// the original owner.
+func frozenByValue(dfz *freezerv1alpha1.DeploymentFreezer) string {
+	return fmt.Sprintf("%s/%s/%s", dfz.Namespace, dfz.Name, dfz.UID)
+}
+
+// isFrozenBy reports whether a frozen-by value names dfz. Values written before the UID was
+// added ("<namespace>/<name>") match by name, so freezes survive a controller upgrade.
+func isFrozenBy(value string, dfz *freezerv1alpha1.DeploymentFreezer) bool {
+	return value == frozenByValue(dfz) || value == dfz.Namespace+"/"+dfz.Name
 }
 
 func removeString(sl []string, s string) []string {
@@ -85,3 +238,15 @@ func removeString(sl []string, s string) []string {
 	}
 	return out
 }
+
+// childFreezeName returns a deterministic name for a DFZ that owner creates for the Deployment,
+// shortened with a hash when it would exceed the maximum object name length.
+func childFreezeName(owner, deployment string) string {
+	name := owner + "-" + deployment
+	if len(name) <= validation.DNS1123SubdomainMaxLength {
+		return name
+	}
+	sum := sha256.Sum256([]byte(name))
+	suffix := hex.EncodeToString(sum[:])[:10]
+	return fmt.Sprintf("%s-%s", name[:validation.DNS1123SubdomainMaxLength-len(suffix)-1], suffix)
+}
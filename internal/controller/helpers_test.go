@@ -5,11 +5,13 @@ import (
 	"time"
 
 	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	testingclock "k8s.io/utils/clock/testing"
 )
 
 func TestSetCondition(t *testing.T) {
@@ -101,6 +103,30 @@ func TestSetCondition(t *testing.T) {
 		assert.True(t, got.LastTransitionTime.After(oldTime.Time), "transition time should be refreshed (greater than old)")
 	})
 
+	t.Run("CodeFromReason", func(t *testing.T) {
+		t.Parallel()
+
+		dfz := &freezerv1alpha1.DeploymentFreezer{}
+		setCondition(dfz, freezerv1alpha1.ConditionTypeTargetFound, freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonUIDMismatch, "recreated")
+		assert.Equal(t, freezerv1alpha1.ConditionCodeTargetReplaced, dfz.Status.Conditions[0].Code)
+
+		setCondition(dfz, freezerv1alpha1.ConditionTypeTargetFound, freezerv1alpha1.ConditionStatusTrue,
+			freezerv1alpha1.ConditionReasonFound, "found")
+		assert.Empty(t, dfz.Status.Conditions[0].Code, "progress and success carry no code")
+
+		// A condition written before codes existed gets its code on the next reconcile.
+		dfz.Status.Conditions = []freezerv1alpha1.Condition{{
+			Type:    freezerv1alpha1.ConditionTypeOwnership,
+			Status:  freezerv1alpha1.ConditionStatusFalse,
+			Reason:  freezerv1alpha1.ConditionReasonLost,
+			Message: "lost",
+		}}
+		setCondition(dfz, freezerv1alpha1.ConditionTypeOwnership, freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonLost, "lost")
+		assert.Equal(t, freezerv1alpha1.ConditionCodeOwnershipConflict, dfz.Status.Conditions[0].Code)
+	})
+
 	t.Run("OnlyTargetConditionUpdated_AmongMany", func(t *testing.T) {
 		t.Parallel()
 
@@ -167,19 +193,55 @@ func TestSetCondition(t *testing.T) {
 }
 
 func TestSetPhase(t *testing.T) {
+	r := &DeploymentFreezerReconciler{}
+
 	t.Run("SetToPending", func(t *testing.T) {
 		t.Parallel()
 		dfz := &freezerv1alpha1.DeploymentFreezer{}
-		setPhase(dfz, freezerv1alpha1.PhasePending)
+		r.setPhase(dfz, freezerv1alpha1.PhasePending)
 		assert.Equal(t, freezerv1alpha1.PhasePending, dfz.Status.Phase)
 	})
 
 	t.Run("OverwriteExistingPhase", func(t *testing.T) {
 		t.Parallel()
 		dfz := &freezerv1alpha1.DeploymentFreezer{Status: freezerv1alpha1.DeploymentFreezerStatus{Phase: freezerv1alpha1.PhaseAborted}}
-		setPhase(dfz, freezerv1alpha1.PhaseFrozen)
+		r.setPhase(dfz, freezerv1alpha1.PhaseFrozen)
 		assert.Equal(t, freezerv1alpha1.PhaseFrozen, dfz.Status.Phase)
 	})
+
+	t.Run("RecordsFirstTransitionTime", func(t *testing.T) {
+		t.Parallel()
+		dfz := &freezerv1alpha1.DeploymentFreezer{}
+		before := time.Now()
+		r.setPhase(dfz, freezerv1alpha1.PhaseFreezing)
+
+		entered, ok := dfz.Status.PhaseTransitionTimes[freezerv1alpha1.PhaseFreezing]
+		assert.True(t, ok)
+		assert.False(t, entered.Time.Before(before.Truncate(time.Second)))
+	})
+
+	t.Run("RecordsTimeOnReconcilerClock", func(t *testing.T) {
+		t.Parallel()
+		now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+		r := &DeploymentFreezerReconciler{Clock: testingclock.NewFakeClock(now)}
+		dfz := &freezerv1alpha1.DeploymentFreezer{}
+		r.setPhase(dfz, freezerv1alpha1.PhaseFrozen)
+		assert.Equal(t, now, dfz.Status.PhaseTransitionTimes[freezerv1alpha1.PhaseFrozen].UTC())
+	})
+
+	t.Run("ReenteringPhase_KeepsFirstTime", func(t *testing.T) {
+		t.Parallel()
+		first := metav1.NewTime(time.Unix(1_600_000_000, 0).UTC())
+		dfz := &freezerv1alpha1.DeploymentFreezer{Status: freezerv1alpha1.DeploymentFreezerStatus{
+			Phase:                freezerv1alpha1.PhaseFreezing,
+			PhaseTransitionTimes: map[freezerv1alpha1.Phase]metav1.Time{freezerv1alpha1.PhaseFreezing: first},
+		}}
+		r.setPhase(dfz, freezerv1alpha1.PhaseFreezing)
+		r.setPhase(dfz, freezerv1alpha1.PhaseFrozen)
+
+		assert.Equal(t, first, dfz.Status.PhaseTransitionTimes[freezerv1alpha1.PhaseFreezing])
+		assert.Len(t, dfz.Status.PhaseTransitionTimes, 2)
+	})
 }
 
 func TestPhaseForNotFound(t *testing.T) {
@@ -290,6 +352,19 @@ func TestHashTemplate(t *testing.T) {
 		h2 := hashTemplate(d2)
 		assert.Equal(t, h1, h2)
 	})
+
+	t.Run("DaemonSetFreezeSelector_NoChange", func(t *testing.T) {
+		t.Parallel()
+		ds := &appsv1.DaemonSet{}
+		ds.Spec.Template = newBaseDeployment().Spec.Template
+		ds.Spec.Template.Spec.NodeSelector = map[string]string{"kubernetes.io/os": "linux"}
+		h1 := hashTemplate(ds)
+		frozen := ds.DeepCopy()
+		frozen.Spec.Template.Spec.NodeSelector[freeze.NodeSelectorFrozen] = "true"
+		assert.Equal(t, h1, hashTemplate(frozen))
+		frozen.Spec.Template.Spec.NodeSelector["kubernetes.io/os"] = "windows"
+		assert.NotEqual(t, h1, hashTemplate(frozen))
+	})
 }
 
 func TestRemoveString(t *testing.T) {
@@ -328,3 +403,121 @@ func TestRemoveString(t *testing.T) {
 		assert.Equal(t, []string{"keep1", "keep2", "keep3"}, out)
 	})
 }
+
+func TestIsFrozenBy(t *testing.T) {
+	dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "dfz", UID: "uid-1"}}
+
+	t.Run("SameUID_Matches", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, "ns/dfz/uid-1", frozenByValue(dfz))
+		assert.True(t, isFrozenBy("ns/dfz/uid-1", dfz))
+	})
+
+	t.Run("RecreatedSameName_NoMatch", func(t *testing.T) {
+		t.Parallel()
+		assert.False(t, isFrozenBy("ns/dfz/uid-0", dfz))
+	})
+
+	t.Run("LegacyValue_MatchesByName", func(t *testing.T) {
+		t.Parallel()
+		assert.True(t, isFrozenBy("ns/dfz", dfz))
+		assert.False(t, isFrozenBy("ns/other", dfz))
+	})
+}
+
+func TestFreezeDuration(t *testing.T) {
+	t.Run("DurationString_Used", func(t *testing.T) {
+		t.Parallel()
+		spec := freezerv1alpha1.DeploymentFreezerSpec{Duration: &metav1.Duration{Duration: 2*time.Hour + 30*time.Minute + 500*time.Millisecond}}
+		d, clamped := freezeDuration(spec.RequestedDuration(), time.Hour, 0)
+		assert.Equal(t, 2*time.Hour+30*time.Minute, d)
+		assert.False(t, clamped)
+	})
+
+	t.Run("Unset_UsesDefault", func(t *testing.T) {
+		t.Parallel()
+		d, clamped := freezeDuration(0, time.Hour, 0)
+		assert.Equal(t, time.Hour, d)
+		assert.False(t, clamped)
+	})
+
+	t.Run("Set_OverridesDefault", func(t *testing.T) {
+		t.Parallel()
+		d, clamped := freezeDuration(90*time.Second, time.Hour, 0)
+		assert.Equal(t, 90*time.Second, d)
+		assert.False(t, clamped)
+	})
+
+	t.Run("AboveMax_Clamped", func(t *testing.T) {
+		t.Parallel()
+		d, clamped := freezeDuration(2*time.Hour, time.Hour, 30*time.Minute)
+		assert.Equal(t, 30*time.Minute, d)
+		assert.True(t, clamped)
+	})
+
+	t.Run("DefaultAboveMax_Clamped", func(t *testing.T) {
+		t.Parallel()
+		d, clamped := freezeDuration(0, time.Hour, 30*time.Minute)
+		assert.Equal(t, 30*time.Minute, d)
+		assert.True(t, clamped)
+	})
+}
+
+func TestSyncWaitConditions(t *testing.T) {
+	find := func(dfz *freezerv1alpha1.DeploymentFreezer, typ freezerv1alpha1.ConditionType) freezerv1alpha1.Condition {
+		for _, c := range dfz.Status.Conditions {
+			if c.Type == typ {
+				return c
+			}
+		}
+		t.Fatalf("condition %s not found", typ)
+		return freezerv1alpha1.Condition{}
+	}
+
+	t.Run("EmptyPhase_TreatedAsPending", func(t *testing.T) {
+		t.Parallel()
+		dfz := &freezerv1alpha1.DeploymentFreezer{}
+		syncWaitConditions(dfz)
+
+		frozen := find(dfz, freezerv1alpha1.ConditionTypeFrozen)
+		assert.Equal(t, freezerv1alpha1.ConditionStatusFalse, frozen.Status)
+		assert.Equal(t, freezerv1alpha1.ConditionReason(freezerv1alpha1.PhasePending), frozen.Reason)
+		completed := find(dfz, freezerv1alpha1.ConditionTypeCompleted)
+		assert.Equal(t, freezerv1alpha1.ConditionStatusFalse, completed.Status)
+	})
+
+	t.Run("Frozen_FrozenTrue", func(t *testing.T) {
+		t.Parallel()
+		dfz := &freezerv1alpha1.DeploymentFreezer{}
+		dfz.Status.Phase = freezerv1alpha1.PhaseFrozen
+		syncWaitConditions(dfz)
+
+		assert.Equal(t, freezerv1alpha1.ConditionStatusTrue, find(dfz, freezerv1alpha1.ConditionTypeFrozen).Status)
+		assert.Equal(t, freezerv1alpha1.ConditionStatusFalse, find(dfz, freezerv1alpha1.ConditionTypeCompleted).Status)
+	})
+
+	t.Run("Completed_CompletedTrueFrozenFalse", func(t *testing.T) {
+		t.Parallel()
+		dfz := &freezerv1alpha1.DeploymentFreezer{}
+		dfz.Status.Phase = freezerv1alpha1.PhaseCompleted
+		syncWaitConditions(dfz)
+
+		assert.Equal(t, freezerv1alpha1.ConditionStatusFalse, find(dfz, freezerv1alpha1.ConditionTypeFrozen).Status)
+		assert.Equal(t, freezerv1alpha1.ConditionStatusTrue, find(dfz, freezerv1alpha1.ConditionTypeCompleted).Status)
+	})
+
+	t.Run("Unchanged_KeepsTransitionTime", func(t *testing.T) {
+		t.Parallel()
+		dfz := &freezerv1alpha1.DeploymentFreezer{}
+		dfz.Status.Phase = freezerv1alpha1.PhaseFrozen
+		syncWaitConditions(dfz)
+		past := metav1.NewTime(time.Unix(1_600_000_000, 0).UTC())
+		for i := range dfz.Status.Conditions {
+			dfz.Status.Conditions[i].LastTransitionTime = past
+		}
+
+		syncWaitConditions(dfz)
+		assert.Equal(t, past, find(dfz, freezerv1alpha1.ConditionTypeFrozen).LastTransitionTime)
+		assert.Equal(t, past, find(dfz, freezerv1alpha1.ConditionTypeCompleted).LastTransitionTime)
+	})
+}
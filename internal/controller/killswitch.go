@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// KillSwitchKey is the kill switch ConfigMap key that, set to "true", stops all new scale-downs.
+const KillSwitchKey = "scaleDownPaused"
+
+// killSwitchEngaged reports whether the kill switch ConfigMap currently pauses scale-downs.
+// A missing ConfigMap means the switch is off.
+func (r *DeploymentFreezerReconciler) killSwitchEngaged(ctx context.Context) (bool, error) {
+	if r.KillSwitch.Name == "" {
+		return false, nil
+	}
+	var cm corev1.ConfigMap
+	if err := r.Get(ctx, r.KillSwitch, &cm); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	engaged, _ := strconv.ParseBool(cm.Data[KillSwitchKey])
+	return engaged, nil
+}
+
+// checkKillSwitch reports whether the DFZ may scale its Deployment down. While the kill switch
+// is engaged (or cannot be read) it reports the block in the Health condition and clears it afterwards.
+func (r *DeploymentFreezerReconciler) checkKillSwitch(ctx context.Context, dfz *freezerv1alpha1.DeploymentFreezer) bool {
+	engaged, err := r.killSwitchEngaged(ctx)
+	blocked := hasCondition(
+		dfz,
+		freezerv1alpha1.ConditionTypeHealth,
+		freezerv1alpha1.ConditionStatusFalse,
+		freezerv1alpha1.ConditionReasonKillSwitch,
+	)
+	switch {
+	case err != nil:
+		// Fail closed: an unreadable kill switch must not let scale-downs through.
+		setCondition(
+			dfz,
+			freezerv1alpha1.ConditionTypeHealth,
+			freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonKillSwitch,
+			fmt.Sprintf(msgKillSwitchReadFailedFmt, err),
+		)
+		return false
+	case engaged:
+		if !blocked {
+			r.Recorder.Eventf(dfz, corev1.EventTypeWarning, ReasonKillSwitchEngaged, msgKillSwitchEngagedEvent, r.KillSwitch)
+		}
+		setStableCondition(
+			dfz,
+			freezerv1alpha1.ConditionTypeHealth,
+			freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonKillSwitch,
+			fmt.Sprintf(msgKillSwitchEngagedFmt, r.KillSwitch, KillSwitchKey),
+		)
+		return false
+	case blocked:
+		setCondition(
+			dfz,
+			freezerv1alpha1.ConditionTypeHealth,
+			freezerv1alpha1.ConditionStatusTrue,
+			freezerv1alpha1.ConditionReasonNormal,
+			msgKillSwitchReleased,
+		)
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestKillSwitch(t *testing.T) {
+	ref := types.NamespacedName{Namespace: "system", Name: "kill-switch"}
+	newReconciler := func(objs ...client.Object) *DeploymentFreezerReconciler {
+		return &DeploymentFreezerReconciler{
+			Client:     fake.NewClientBuilder().WithObjects(objs...).Build(),
+			Recorder:   record.NewFakeRecorder(10),
+			KillSwitch: ref,
+		}
+	}
+	newSwitch := func(value string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ref.Namespace, Name: ref.Name},
+			Data:       map[string]string{KillSwitchKey: value},
+		}
+	}
+	health := func(dfz *freezerv1alpha1.DeploymentFreezer) *freezerv1alpha1.Condition {
+		for i := range dfz.Status.Conditions {
+			if dfz.Status.Conditions[i].Type == freezerv1alpha1.ConditionTypeHealth {
+				return &dfz.Status.Conditions[i]
+			}
+		}
+		return nil
+	}
+
+	t.Run("Disabled_Allows", func(t *testing.T) {
+		t.Parallel()
+		r := newReconciler(newSwitch("true"))
+		r.KillSwitch = types.NamespacedName{}
+		assert.True(t, r.checkKillSwitch(context.Background(), &freezerv1alpha1.DeploymentFreezer{}))
+	})
+
+	t.Run("MissingConfigMap_Allows", func(t *testing.T) {
+		t.Parallel()
+		dfz := &freezerv1alpha1.DeploymentFreezer{}
+		assert.True(t, newReconciler().checkKillSwitch(context.Background(), dfz))
+		assert.Nil(t, health(dfz))
+	})
+
+	t.Run("Engaged_BlocksWithHealthCondition", func(t *testing.T) {
+		t.Parallel()
+		dfz := &freezerv1alpha1.DeploymentFreezer{}
+		assert.False(t, newReconciler(newSwitch("true")).checkKillSwitch(context.Background(), dfz))
+		c := health(dfz)
+		if assert.NotNil(t, c) {
+			assert.Equal(t, freezerv1alpha1.ConditionReasonKillSwitch, c.Reason)
+		}
+	})
+
+	t.Run("Released_ClearsHealthCondition", func(t *testing.T) {
+		t.Parallel()
+		dfz := &freezerv1alpha1.DeploymentFreezer{}
+		assert.False(t, newReconciler(newSwitch("true")).checkKillSwitch(context.Background(), dfz))
+		assert.True(t, newReconciler(newSwitch("false")).checkKillSwitch(context.Background(), dfz))
+		assert.Equal(t, freezerv1alpha1.ConditionReasonNormal, health(dfz).Reason)
+	})
+}
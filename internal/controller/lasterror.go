@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"context"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Operations recorded in status.lastError.
+const (
+	opRead               = "Read"
+	opPatchMetadata      = "PatchMetadata"
+	opEvaluatePolicy     = "EvaluatePolicy"
+	opSnapshot           = "Snapshot"
+	opPauseAutoscaling   = "PauseAutoscaling"
+	opFreezeTarget       = "FreezeTarget"
+	opAcquireOwnership   = "AcquireOwnership"
+	opDivertTraffic      = "DivertTraffic"
+	opRestoreReplicas    = "RestoreReplicas"
+	opRestoreAutoscaling = "RestoreAutoscaling"
+	opRestoreTraffic     = "RestoreTraffic"
+	opReleaseOwnership   = "ReleaseOwnership"
+)
+
+// operationFailed reports a failed API call in the Health condition and records it in status.lastError.
+func (r *DeploymentFreezerReconciler) operationFailed(dfz *freezerv1alpha1.DeploymentFreezer, op, msg string) {
+	setCondition(
+		dfz,
+		freezerv1alpha1.ConditionTypeHealth,
+		freezerv1alpha1.ConditionStatusFalse,
+		freezerv1alpha1.ConditionReasonAPIConflict,
+		msg,
+	)
+	r.recordError(dfz, op, msg)
+}
+
+// recordError stores a failure of op in status.lastError, counting attempts while the same
+// operation keeps failing, and counts it in status.retryCount.
+func (r *DeploymentFreezerReconciler) recordError(dfz *freezerv1alpha1.DeploymentFreezer, op, msg string) {
+	countRetry(dfz, op)
+
+	now := metav1.NewTime(r.now())
+	last := dfz.Status.LastError
+	if last == nil || last.Operation != op {
+		dfz.Status.LastError = &freezerv1alpha1.OperationError{Operation: op, FirstFailureTime: now}
+		last = dfz.Status.LastError
+	}
+	last.Message = msg
+	last.Attempts++
+	last.LastFailureTime = now
+}
+
+// countRetry adds a failure of op to its class in status.retryCount. Reads and policy checks
+// count towards the class of the current phase, and are not counted while Frozen.
+func countRetry(dfz *freezerv1alpha1.DeploymentFreezer, op string) {
+	if dfz.Status.RetryCount == nil {
+		dfz.Status.RetryCount = &freezerv1alpha1.RetryCount{}
+	}
+	rc := dfz.Status.RetryCount
+	switch op {
+	case opAcquireOwnership, opReleaseOwnership:
+		rc.Ownership++
+	case opRestoreReplicas, opRestoreAutoscaling, opRestoreTraffic:
+		rc.Unfreeze++
+	case opSnapshot, opPauseAutoscaling, opFreezeTarget, opDivertTraffic:
+		rc.Freeze++
+	default:
+		switch dfz.Status.Phase {
+		case "", freezerv1alpha1.PhasePending, freezerv1alpha1.PhaseFreezing:
+			rc.Freeze++
+		case freezerv1alpha1.PhaseUnfreezing:
+			rc.Unfreeze++
+		}
+	}
+	if *rc == (freezerv1alpha1.RetryCount{}) {
+		dfz.Status.RetryCount = nil
+	}
+}
+
+// resetRetryCount is a pre transition hook clearing the retry counts of the operations a
+// transition shows to have succeeded: the claim on Freezing or Frozen, the freeze on Frozen, and
+// the restore and release on Completed.
+func resetRetryCount(_ context.Context, dfz *freezerv1alpha1.DeploymentFreezer, t Transition) {
+	rc := dfz.Status.RetryCount
+	if rc == nil {
+		return
+	}
+	switch t.To {
+	case freezerv1alpha1.PhaseFreezing:
+		rc.Ownership = 0
+	case freezerv1alpha1.PhaseFrozen:
+		rc.Ownership, rc.Freeze = 0, 0
+	case freezerv1alpha1.PhaseCompleted:
+		rc.Ownership, rc.Unfreeze = 0, 0
+	}
+	if *rc == (freezerv1alpha1.RetryCount{}) {
+		dfz.Status.RetryCount = nil
+	}
+}
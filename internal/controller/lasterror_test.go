@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+func TestLastError(t *testing.T) {
+	start := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("SameOperation_CountsAttempts", func(t *testing.T) {
+		t.Parallel()
+		clk := testingclock.NewFakeClock(start)
+		r := &DeploymentFreezerReconciler{Clock: clk}
+		dfz := &freezerv1alpha1.DeploymentFreezer{}
+
+		r.operationFailed(dfz, opRestoreAutoscaling, "first")
+		clk.Step(time.Minute)
+		r.operationFailed(dfz, opRestoreAutoscaling, "second")
+
+		last := dfz.Status.LastError
+		require.NotNil(t, last)
+		assert.Equal(t, opRestoreAutoscaling, last.Operation)
+		assert.Equal(t, "second", last.Message)
+		assert.Equal(t, int32(2), last.Attempts)
+		assert.True(t, last.FirstFailureTime.Time.Equal(start))
+		assert.True(t, last.LastFailureTime.Time.Equal(start.Add(time.Minute)))
+		assert.True(t, hasCondition(dfz, freezerv1alpha1.ConditionTypeHealth,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonAPIConflict))
+	})
+
+	t.Run("OtherOperation_StartsOver", func(t *testing.T) {
+		t.Parallel()
+		clk := testingclock.NewFakeClock(start)
+		r := &DeploymentFreezerReconciler{Clock: clk}
+		dfz := &freezerv1alpha1.DeploymentFreezer{}
+
+		r.recordError(dfz, opRestoreReplicas, "quota")
+		r.recordError(dfz, opRestoreReplicas, "quota")
+		clk.Step(time.Minute)
+		r.recordError(dfz, opReleaseOwnership, "conflict")
+
+		last := dfz.Status.LastError
+		assert.Equal(t, opReleaseOwnership, last.Operation)
+		assert.Equal(t, int32(1), last.Attempts)
+		assert.True(t, last.FirstFailureTime.Time.Equal(start.Add(time.Minute)))
+	})
+
+	t.Run("RetryCount_CountsPerClass", func(t *testing.T) {
+		t.Parallel()
+		r := &DeploymentFreezerReconciler{Clock: testingclock.NewFakeClock(start)}
+		dfz := &freezerv1alpha1.DeploymentFreezer{}
+		dfz.Status.Phase = freezerv1alpha1.PhaseFreezing
+
+		r.operationFailed(dfz, opAcquireOwnership, "conflict")
+		r.operationFailed(dfz, opFreezeTarget, "pdb")
+		r.operationFailed(dfz, opRead, "timeout")
+		assert.Equal(t, freezerv1alpha1.RetryCount{Freeze: 2, Ownership: 1}, *dfz.Status.RetryCount)
+
+		// Reads while Frozen belong to no class.
+		dfz.Status.Phase = freezerv1alpha1.PhaseFrozen
+		r.operationFailed(dfz, opRead, "timeout")
+		dfz.Status.Phase = freezerv1alpha1.PhaseUnfreezing
+		r.operationFailed(dfz, opRestoreReplicas, "quota")
+		assert.Equal(t, freezerv1alpha1.RetryCount{Freeze: 2, Unfreeze: 1, Ownership: 1}, *dfz.Status.RetryCount)
+	})
+
+	t.Run("RetryCount_ResetOnSuccess", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		dfz := &freezerv1alpha1.DeploymentFreezer{}
+		dfz.Status.RetryCount = &freezerv1alpha1.RetryCount{Freeze: 3, Ownership: 2}
+
+		resetRetryCount(ctx, dfz, Transition{From: freezerv1alpha1.PhasePending, To: freezerv1alpha1.PhaseFreezing})
+		assert.Equal(t, freezerv1alpha1.RetryCount{Freeze: 3}, *dfz.Status.RetryCount)
+
+		resetRetryCount(ctx, dfz, Transition{From: freezerv1alpha1.PhaseFreezing, To: freezerv1alpha1.PhaseFrozen})
+		assert.Nil(t, dfz.Status.RetryCount)
+
+		dfz.Status.RetryCount = &freezerv1alpha1.RetryCount{Unfreeze: 40}
+		resetRetryCount(ctx, dfz, Transition{From: freezerv1alpha1.PhaseFrozen, To: freezerv1alpha1.PhaseUnfreezing})
+		assert.Equal(t, int32(40), dfz.Status.RetryCount.Unfreeze)
+		resetRetryCount(ctx, dfz, Transition{From: freezerv1alpha1.PhaseUnfreezing, To: freezerv1alpha1.PhaseCompleted})
+		assert.Nil(t, dfz.Status.RetryCount)
+	})
+}
@@ -0,0 +1,222 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// annoMaintenancePage is set on an Ingress switched to the maintenance page. It records the
+// owning DFZ and the replaced backends, and is written in the same patch as the switch.
+const annoMaintenancePage = "apps.boolfixer.dev/maintenance-page"
+
+// maintenanceRecord is the value of annoMaintenancePage.
+type maintenanceRecord struct {
+	Owner       string                             `json:"owner"`
+	Maintenance networkingv1.IngressServiceBackend `json:"maintenance"`
+	Routes      []maintenanceRoute                 `json:"routes"`
+}
+
+// maintenanceRoute is an Ingress path, or the default backend, and the backend it had.
+type maintenanceRoute struct {
+	Host    string                             `json:"host,omitempty"`
+	Path    string                             `json:"path,omitempty"`
+	Default bool                               `json:"default,omitempty"`
+	Backend networkingv1.IngressServiceBackend `json:"backend"`
+}
+
+// switchIngresses switches spec.maintenancePage.ingressName, or every Ingress of the namespace
+// routing to a Service that selects the Deployment's Pods, and returns the switched names.
+// Ingresses and Services are read from the API server, so the controller does not cache them.
+func (r *DeploymentFreezerReconciler) switchIngresses(
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	deploy *appsv1.Deployment,
+) ([]string, error) {
+	page := dfz.Spec.MaintenancePage
+	var ingresses []networkingv1.Ingress
+	var services map[string]bool
+	if page.IngressName != "" {
+		var ing networkingv1.Ingress
+		err := r.APIReader.Get(ctx, types.NamespacedName{Namespace: dfz.Namespace, Name: page.IngressName}, &ing)
+		switch {
+		case apierrors.IsNotFound(err):
+			return nil, nil
+		case err != nil:
+			return nil, err
+		}
+		ingresses = append(ingresses, ing)
+	} else {
+		var list networkingv1.IngressList
+		if err := r.APIReader.List(ctx, &list, client.InNamespace(dfz.Namespace)); err != nil {
+			return nil, err
+		}
+		ingresses = list.Items
+		var err error
+		if services, err = r.servicesSelecting(ctx, deploy); err != nil {
+			return nil, err
+		}
+	}
+
+	owner := frozenByValue(dfz)
+	var switched []string
+	for i := range ingresses {
+		ing := &ingresses[i]
+		if raw, ok := ing.Annotations[annoMaintenancePage]; ok {
+			// Another DFZ's maintenance page stays; its owner restores it.
+			var rec maintenanceRecord
+			if json.Unmarshal([]byte(raw), &rec) == nil && rec.Owner == owner {
+				switched = append(switched, ing.Name)
+			}
+			continue
+		}
+		orig := ing.DeepCopy()
+		routes := divertIngress(ing, services, page.Backend)
+		if len(routes) == 0 {
+			continue
+		}
+		raw, err := json.Marshal(maintenanceRecord{Owner: owner, Maintenance: page.Backend, Routes: routes})
+		if err != nil {
+			return nil, err
+		}
+		if ing.Annotations == nil {
+			ing.Annotations = map[string]string{}
+		}
+		ing.Annotations[annoMaintenancePage] = string(raw)
+		if err := r.Patch(ctx, ing, client.MergeFromWithOptions(orig, client.MergeFromWithOptimisticLock{}),
+			r.patchOpts(dfz)...); err != nil {
+			return nil, fmt.Errorf("ingress %s: %w", ing.Name, err)
+		}
+		switched = append(switched, ing.Name)
+	}
+	return switched, nil
+}
+
+// servicesSelecting returns the names of the Services of the namespace selecting the Deployment's Pods.
+func (r *DeploymentFreezerReconciler) servicesSelecting(ctx context.Context, deploy *appsv1.Deployment) (map[string]bool, error) {
+	var list corev1.ServiceList
+	if err := r.APIReader.List(ctx, &list, client.InNamespace(deploy.Namespace)); err != nil {
+		return nil, err
+	}
+	podLabels := labels.Set(deploy.Spec.Template.Labels)
+	out := map[string]bool{}
+	for _, svc := range list.Items {
+		if len(svc.Spec.Selector) > 0 && labels.SelectorFromSet(svc.Spec.Selector).Matches(podLabels) {
+			out[svc.Name] = true
+		}
+	}
+	return out, nil
+}
+
+// divertIngress points the Ingress' Service backends at backend and returns the replaced routes.
+// With services set, only backends of those Services are replaced.
+func divertIngress(
+	ing *networkingv1.Ingress,
+	services map[string]bool,
+	backend networkingv1.IngressServiceBackend,
+) []maintenanceRoute {
+	divert := func(b *networkingv1.IngressBackend) bool {
+		return b != nil && b.Service != nil && *b.Service != backend && (services == nil || services[b.Service.Name])
+	}
+	var routes []maintenanceRoute
+	if b := ing.Spec.DefaultBackend; divert(b) {
+		routes = append(routes, maintenanceRoute{Default: true, Backend: *b.Service})
+		b.Service = backend.DeepCopy()
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for i := range rule.HTTP.Paths {
+			b := &rule.HTTP.Paths[i].Backend
+			if divert(b) {
+				routes = append(routes, maintenanceRoute{Host: rule.Host, Path: rule.HTTP.Paths[i].Path, Backend: *b.Service})
+				b.Service = backend.DeepCopy()
+			}
+		}
+	}
+	return routes
+}
+
+// restoreIngress gives the recorded routes their backends back, as long as they still point at
+// the maintenance backend; routes changed in the meantime keep the change.
+func restoreIngress(ing *networkingv1.Ingress, rec maintenanceRecord) {
+	diverted := func(b *networkingv1.IngressBackend) bool {
+		return b != nil && b.Service != nil && *b.Service == rec.Maintenance
+	}
+	for _, route := range rec.Routes {
+		if route.Default {
+			if b := ing.Spec.DefaultBackend; diverted(b) {
+				b.Service = route.Backend.DeepCopy()
+			}
+			continue
+		}
+		for _, rule := range ing.Spec.Rules {
+			if rule.Host != route.Host || rule.HTTP == nil {
+				continue
+			}
+			for i := range rule.HTTP.Paths {
+				b := &rule.HTTP.Paths[i].Backend
+				if rule.HTTP.Paths[i].Path == route.Path && diverted(b) {
+					b.Service = route.Backend.DeepCopy()
+				}
+			}
+		}
+	}
+	delete(ing.Annotations, annoMaintenancePage)
+}
+
+// restoreIngresses restores every Ingress of the namespace carrying this DFZ's maintenance record
+// and returns their names. All Ingresses are checked, not only status.maintenanceIngresses, so
+// nothing is left switched if that status was never written.
+func (r *DeploymentFreezerReconciler) restoreIngresses(
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+) ([]string, error) {
+	if dfz.Spec.MaintenancePage == nil && len(dfz.Status.MaintenanceIngresses) == 0 {
+		return nil, nil
+	}
+	var list networkingv1.IngressList
+	if err := r.APIReader.List(ctx, &list, client.InNamespace(dfz.Namespace)); err != nil {
+		return nil, err
+	}
+	owner := frozenByValue(dfz)
+	var restored []string
+	for i := range list.Items {
+		ing := &list.Items[i]
+		raw, ok := ing.Annotations[annoMaintenancePage]
+		if !ok {
+			continue
+		}
+		var rec maintenanceRecord
+		if err := json.Unmarshal([]byte(raw), &rec); err != nil || rec.Owner != owner {
+			continue
+		}
+		orig := ing.DeepCopy()
+		restoreIngress(ing, rec)
+		if err := r.Patch(ctx, ing, client.MergeFromWithOptions(orig, client.MergeFromWithOptimisticLock{}),
+			r.patchOpts(dfz)...); err != nil {
+			return restored, fmt.Errorf("ingress %s: %w", ing.Name, err)
+		}
+		restored = append(restored, ing.Name)
+	}
+	return restored, nil
+}
+
+// backendString formats a Service backend as name:port.
+func backendString(b networkingv1.IngressServiceBackend) string {
+	if b.Port.Name != "" {
+		return b.Name + ":" + b.Port.Name
+	}
+	return b.Name + ":" + strconv.Itoa(int(b.Port.Number))
+}
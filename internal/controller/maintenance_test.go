@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMaintenancePage(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	page := networkingv1.IngressServiceBackend{Name: "maintenance", Port: networkingv1.ServiceBackendPort{Number: 80}}
+	svcBackend := func(name string) networkingv1.IngressBackend {
+		return networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+			Name: name, Port: networkingv1.ServiceBackendPort{Name: "http"},
+		}}
+	}
+	newIngress := func() *networkingv1.Ingress {
+		return &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "storefront"},
+			Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{
+				Host: "shop.example.com",
+				IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{
+						{Path: "/", Backend: svcBackend("web")},
+						{Path: "/api", Backend: svcBackend("api")},
+					},
+				}},
+			}}},
+		}
+	}
+	services := []client.Object{
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web"},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "web"}},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "api"},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "api"}},
+		},
+	}
+	newReconciler := func(objs ...client.Object) *DeploymentFreezerReconciler {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+		return &DeploymentFreezerReconciler{
+			Client:    c,
+			APIReader: c,
+			Recorder:  record.NewFakeRecorder(10),
+			Clock:     testingclock.NewFakeClock(now),
+		}
+	}
+	newDFZ := func() (*freezerv1alpha1.DeploymentFreezer, *appsv1.Deployment) {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "dfz", UID: "dfz-uid"}}
+		dfz.Spec.MaintenancePage = &freezerv1alpha1.MaintenancePage{Backend: page}
+		deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web"}}
+		deploy.Spec.Template.Labels = map[string]string{"app": "web", "tier": "frontend"}
+		return dfz, deploy
+	}
+
+	t.Run("DivertAndRestore_RoundTrip", func(t *testing.T) {
+		t.Parallel()
+		ing := newIngress()
+		ing.Spec.DefaultBackend = &networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "web"}}
+		routes := divertIngress(ing, map[string]bool{"web": true}, page)
+		require.Len(t, routes, 2)
+		assert.Equal(t, page, *ing.Spec.DefaultBackend.Service)
+		assert.Equal(t, page, *ing.Spec.Rules[0].HTTP.Paths[0].Backend.Service)
+		assert.Equal(t, "api", ing.Spec.Rules[0].HTTP.Paths[1].Backend.Service.Name)
+
+		restoreIngress(ing, maintenanceRecord{Maintenance: page, Routes: routes})
+		assert.Equal(t, newIngress().Spec.Rules, ing.Spec.Rules)
+		assert.Equal(t, "web", ing.Spec.DefaultBackend.Service.Name)
+	})
+
+	t.Run("Restore_KeepsRoutesChangedMeanwhile", func(t *testing.T) {
+		t.Parallel()
+		ing := newIngress()
+		routes := divertIngress(ing, nil, page)
+		require.Len(t, routes, 2)
+		ing.Spec.Rules[0].HTTP.Paths[1].Backend = svcBackend("api-v2")
+
+		restoreIngress(ing, maintenanceRecord{Maintenance: page, Routes: routes})
+		assert.Equal(t, "web", ing.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name)
+		assert.Equal(t, "api-v2", ing.Spec.Rules[0].HTTP.Paths[1].Backend.Service.Name)
+	})
+
+	t.Run("Freeze_SwitchesAndRestoresIngress", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		r := newReconciler(append([]client.Object{newIngress()}, services...)...)
+		dfz, deploy := newDFZ()
+
+		_, wait := r.divertTraffic(ctx, dfz, deploy)
+		require.False(t, wait)
+		assert.True(t, hasCondition(dfz, freezerv1alpha1.ConditionTypeTraffic,
+			freezerv1alpha1.ConditionStatusTrue, freezerv1alpha1.ConditionReasonMaintenance))
+		assert.Equal(t, []string{"storefront"}, dfz.Status.MaintenanceIngresses)
+
+		var ing networkingv1.Ingress
+		require.NoError(t, r.Get(ctx, client.ObjectKey{Namespace: "shop", Name: "storefront"}, &ing))
+		assert.Equal(t, page, *ing.Spec.Rules[0].HTTP.Paths[0].Backend.Service)
+		assert.Equal(t, "api", ing.Spec.Rules[0].HTTP.Paths[1].Backend.Service.Name)
+		assert.Contains(t, ing.Annotations, annoMaintenancePage)
+
+		// The page stays until the restored Deployment can serve.
+		dfz.Status.PhaseTransitionTimes = map[freezerv1alpha1.Phase]metav1.Time{
+			freezerv1alpha1.PhaseUnfreezing: metav1.NewTime(now),
+		}
+		res, wait := r.restoreTraffic(ctx, dfz, deploy, 3)
+		require.True(t, wait)
+		assert.Equal(t, requeueShort, res.RequeueAfter)
+		assert.True(t, hasCondition(dfz, freezerv1alpha1.ConditionTypeTraffic,
+			freezerv1alpha1.ConditionStatusTrue, freezerv1alpha1.ConditionReasonAwaitingBackend))
+
+		deploy.Status.AvailableReplicas = 1
+		_, wait = r.restoreTraffic(ctx, dfz, deploy, 3)
+		require.False(t, wait)
+		assert.True(t, hasCondition(dfz, freezerv1alpha1.ConditionTypeTraffic,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonTrafficRestored))
+		assert.Empty(t, dfz.Status.MaintenanceIngresses)
+
+		require.NoError(t, r.Get(ctx, client.ObjectKey{Namespace: "shop", Name: "storefront"}, &ing))
+		assert.Equal(t, newIngress().Spec.Rules, ing.Spec.Rules)
+		assert.NotContains(t, ing.Annotations, annoMaintenancePage)
+	})
+
+	t.Run("Restore_GivesUpWaitingAfterTimeout", func(t *testing.T) {
+		t.Parallel()
+		r := newReconciler(append([]client.Object{newIngress()}, services...)...)
+		dfz, deploy := newDFZ()
+		_, _ = r.divertTraffic(context.Background(), dfz, deploy)
+		dfz.Status.PhaseTransitionTimes = map[freezerv1alpha1.Phase]metav1.Time{
+			freezerv1alpha1.PhaseUnfreezing: metav1.NewTime(now.Add(-trafficRestoreTimeout)),
+		}
+		_, wait := r.restoreTraffic(context.Background(), dfz, deploy, 3)
+		assert.False(t, wait)
+	})
+
+	t.Run("NoRoute_FreezesAnyway", func(t *testing.T) {
+		t.Parallel()
+		r := newReconciler(services...)
+		dfz, deploy := newDFZ()
+		_, wait := r.divertTraffic(context.Background(), dfz, deploy)
+		assert.False(t, wait)
+		assert.True(t, hasCondition(dfz, freezerv1alpha1.ConditionTypeTraffic,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonNoRoute))
+		assert.Len(t, r.Recorder.(*record.FakeRecorder).Events, 1)
+	})
+
+	t.Run("OtherOwner_IngressLeftAlone", func(t *testing.T) {
+		t.Parallel()
+		ing := newIngress()
+		ing.Annotations = map[string]string{annoMaintenancePage: `{"owner":"shop/other/uid"}`}
+		r := newReconciler(append([]client.Object{ing}, services...)...)
+		dfz, deploy := newDFZ()
+		_, _ = r.divertTraffic(context.Background(), dfz, deploy)
+		assert.True(t, hasCondition(dfz, freezerv1alpha1.ConditionTypeTraffic,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonNoRoute))
+
+		restored, err := r.restoreIngresses(context.Background(), dfz)
+		require.NoError(t, err)
+		assert.Empty(t, restored)
+	})
+}
@@ -4,11 +4,26 @@ package controller
 
 const (
 	// General/validation/controller errors
-	msgSpecTargetEmpty            = "spec.targetRef.name is empty"
-	msgTargetDeploymentNotExist   = "Target Deployment does not exist"
-	msgReadErrorFmt               = "read error: %v"
-	msgUIDRecreated               = "Deployment was recreated with a different UID during the freeze lifecycle"
-	msgTemplateHashPatchFailedFmt = "template hash patch failed: %v"
+	msgSpecTargetEmpty         = "spec.targetRef.name is empty"
+	msgTargetNotExistFmt       = "Target %s does not exist"
+	msgTargetAwaitedFmt        = "Target %s does not exist; waiting for it until %s"
+	msgTargetCreated           = "Target was created"
+	msgSelectorInvalidFmt      = "spec.targetRef.selector is invalid: %v"
+	msgSelectorNoMatchFmt      = "No %s matches spec.targetRef.selector %q"
+	msgSelectorAmbiguousFmt    = "spec.targetRef.selector %q matches %d %s objects (%s); it must match exactly one"
+	msgDeploymentOnlyFieldsFmt = "A %s target cannot use Deployment-only settings: %s"
+	msgTargetControlledFmt     = "cannot freeze the target: %v"
+	msgKindNotServedFmt        = "The API server does not serve %s targets; is its CRD installed?"
+	msgTargetNotSelectedFmt    = "Target Deployment is not cached: add labels matching %q to it"
+	msgTargetSelected          = "Target Deployment is cached again"
+	msgReadErrorFmt            = "read error: %v"
+	msgUIDRecreated            = "Deployment was recreated with a different UID during the freeze lifecycle"
+	msgMetadataPatchFailedFmt  = "finalizer/template hash patch failed: %v"
+
+	// Kill switch
+	msgKillSwitchEngagedFmt    = "Kill switch engaged (ConfigMap %s, %s=true): no new scale-downs"
+	msgKillSwitchReadFailedFmt = "cannot read the kill switch, holding scale-downs: %v"
+	msgKillSwitchReleased      = "Kill switch released"
 
 	// Ownership related
 	msgDeploymentAlreadyOwnedFmt      = "Deployment is already owned by %s"
@@ -16,18 +31,141 @@ const (
 	msgOwnershipAlreadyHeld           = "Ownership already held"
 	msgOwnershipAnnotationLost        = "Ownership annotation disappeared or was overwritten"
 	msgOwnershipReleasedAfterUnfreeze = "Ownership released after unfreeze"
+	msgWaitingForOwnershipFmt         = "Deployment is frozen by %s; waiting for it to be released"
+	msgOwnershipFreed                 = "The previous owner released the Deployment"
+	msgAcquireTimeoutFmt              = "Gave up waiting for %s after %s"
+	msgOwnershipPreemptedFromFmt      = "DFZ %s owns Deployment %s/%s, preempted from %s (priority %d)"
+	msgPreemptionFailedFmt            = "cannot take the Deployment over from %s: %v"
+	msgClaimRaceLostFmt               = "the Deployment changed hands before the write: %v"
+	msgPreemptedByFmt                 = "Preempted by %s (priority %d)"
 
 	// Freeze progress related
 	msgCannotScaleDownYetFmt       = "cannot scale down yet: %v"
+	msgBlockedByPDBsFmt            = "scale-down blocked by PodDisruptionBudgets %s: %v"
+	msgPDBFmt                      = "%s (disruptionsAllowed %d)"
 	msgScalingDeploymentToZero     = "Scaling Deployment to 0"
 	msgDeploymentFullyScaledToZero = "Deployment is fully scaled to zero"
 	msgWaitingDeploymentReachZero  = "Waiting for Deployment to reach zero replicas"
+	msgPodsRemainingFmt            = "%d Pod(s) still running at zero replicas: %s"
+	msgPodsOutlivedTimeoutFmt      = "Pods still running %s after scale-down; not declaring the target frozen"
+	msgPodsGone                    = "The remaining Pods are gone"
+	msgListPodsFailedFmt           = "cannot list the target's Pods: %v"
+	msgCannotPauseRolloutFmt       = "cannot pause rollouts: %v"
+	msgSnapshotFailedFmt           = "cannot snapshot autoscaling state: %v"
+	msgCannotPauseAutoscalingFmt   = "cannot pause autoscalers: %v"
+	msgCannotLabelFrozenFmt        = "cannot add the frozen label: %v"
+	msgPauseRolloutNeedsSpecAccess = "spec.pauseRollout is ignored: the controller runs in lean RBAC mode without Deployment spec access"
 
 	// Unfreeze related
-	msgFailedRestoreReplicasFmt      = "failed to restore replicas to %d: %v"
-	msgFailedClearOwnershipFmt       = "failed to clear ownership: %v"
-	msgDeploymentRestoredReplicasFmt = "Deployment restored to %d replicas"
+	msgFailedRestoreReplicasFmt       = "failed to restore replicas to %d: %v"
+	msgFailedRestoreReplicasPausedFmt = "failed to restore replicas to %d and spec.paused to %t: %v"
+	msgFailedClearOwnershipFmt        = "failed to clear ownership: %v"
+	msgFailedRestoreAutoscalingFmt    = "failed to restore autoscalers: %v"
+	msgDeploymentRestoredReplicasFmt  = "Deployment restored to %d replicas"
+
+	msgUnfreezeThrottled      = "Waiting for the unfreeze rate limit (--unfreeze-rate)"
+	msgUnfreezeConcurrencyFmt = "Waiting for one of the %d unfreezes in progress to finish (--max-concurrent-unfreezes)"
+	msgUnfreezeScalingUp      = "Scaling back up; waiting for the target to become available"
+	msgListFreezersFailedFmt  = "cannot count the unfreezes in progress: %v"
+
+	// Unfreeze window
+	msgOutsideUnfreezeWindowFmt = "Freeze window elapsed outside the unfreeze window %s; unfreezing at %s"
+	msgInvalidUnfreezeWindowFmt = "Freeze window elapsed; spec.unfreezeWindow is invalid and the unfreeze is held: %v"
+
+	// Blackout windows
+	msgInBlackoutFmt         = "Blackout %s of FreezerPolicy %s: no scaling until %s"
+	msgBlackoutReadFailedFmt = "cannot read blackout windows, holding: %v"
+	msgBlackoutEnded         = "Blackout ended"
+
+	// Canary unfreeze
+	msgCanaryStarted             = "Canary: restoring a single replica"
+	msgCanaryWaitingReady        = "Canary: waiting for the replica to become Ready"
+	msgCanaryWaitingStableFmt    = "Canary: replica is Ready; restoring all replicas at %s if it stays Ready"
+	msgCanaryPaused              = "Canary failed; unfreeze paused at one replica. Set spec.unfreezeStrategy.type=Immediate to continue"
+	msgCanaryNotStable           = "canary replica stopped being Ready before it was stable"
+	msgCanaryReadyTimeoutFmt     = "canary replica did not become Ready within %s"
+	msgCanaryProgressDeadlineFmt = "canary rollout exceeded its progress deadline: %s"
+
+	// GitOps mode
+	msgAwaitingGitOpsFmt = "Freeze window elapsed; GitOps mode: unsuspend the GitOps sync to restore %d replicas"
+	msgGitOpsSyncedFmt   = "Deployment restored to %d replicas by the GitOps pipeline"
+	msgGitOpsDeletedFmt  = "DeploymentFreezer %s deleted; GitOps mode: unsuspend the GitOps sync to restore %d replicas"
+
+	// Post-unfreeze observation
+	msgPostUnfreezeObservingFmt    = "Observing the Deployment until %s"
+	msgPostUnfreezeHealthy         = "Deployment stayed healthy after unfreeze"
+	msgPostUnfreezeCrashLoopingFmt = "%d container restarts observed after unfreeze"
+	msgPostUnfreezeUnavailableFmt  = "only %d of %d replicas available after unfreeze"
 
 	// Spec change detection
 	msgSpecChangedDuringFreeze = "Target Deployment's pod template changed during the lifecycle"
+
+	// Dry-run (plan mode)
+	msgPlanned                 = "Dry-run: nothing was changed; see status.plannedChanges"
+	msgPlanRejectedFmt         = "dry-run patch rejected: %v"
+	msgPlanSetAnnotationFmt    = "set annotation %s=%s"
+	msgPlanRemoveAnnotationFmt = "remove annotation %s"
+	msgPlanSetLabelFmt         = "set label %s=%s"
+	msgPlanRemoveLabelFmt      = "remove label %s"
+	msgPlanScaleFmt            = "scale replicas from %d to %d"
+	msgPlanPausedFmt           = "set spec.paused to %t"
+	msgPlanRestoreFmt          = "restore replicas to %d at %s"
+
+	// Drift detection while frozen
+	msgNoDrift            = "Deployment is still frozen by this DFZ"
+	msgAnnotationDriftFmt = "annotation %s no longer set to %q"
+	msgReplicasDriftFmt   = "Deployment scaled to %d replicas while frozen"
+	msgDriftActorFmt      = "%s by %s"
+
+	// Drift actor, from the target's managedFields
+	msgDriftManagerFmt     = "field manager %q"
+	msgDriftSubresourceFmt = " through the %s subresource"
+	msgDriftTimeFmt        = " at %s"
+	msgDriftUserFmt        = "user %q"
+
+	// Rollout restarts while frozen
+	msgRestartRequestedFmt = "rollout restart requested at %s; deferred until replicas are restored"
+
+	// FreezerPolicy
+	msgPolicyEvaluationFailedFmt = "cannot evaluate FreezerPolicies: %v"
+
+	// AutoFreezePolicy triggers
+	msgCrashLoopingFmt         = "container %s of Pod %s is in CrashLoopBackOff after %d restarts"
+	msgMetricAboveThresholdFmt = "metric query returned %g, above the threshold of %s"
+
+	// NodeFreeze children
+	msgNodeFreezeChildRefusedFmt = "DeploymentFreezer refused: %v"
+	msgNodeFreezeChildGone       = "DeploymentFreezer no longer exists"
+
+	// Propagated condition
+	msgPropagatedFmt        = "copies applied on %d cluster(s)"
+	msgNotAppliedFmt        = "copies not applied on %s"
+	msgPropagationDisabled  = "spec.propagation needs a controller started with --propagation"
+	msgPropagationFailedFmt = "cannot propagate to cluster %s: %v"
+	msgWithdrawing          = "removed from spec.propagation; deleting the copy"
+
+	// ReconciliationPaused
+	msgPausedFmt = "%s is \"true\": no phase transitions or Deployment patches until it is removed"
+	msgResumed   = "Paused annotation removed"
+
+	// Traffic diversion
+	msgTrafficDivertedFmt      = "traffic diverted: %s"
+	msgDivertedIngressesFmt    = "Ingresses %s to %s"
+	msgDivertedServiceFmt      = "Service %s to Deployment %s"
+	msgTrafficNoRoute          = "no Ingress or Service to divert"
+	msgTrafficAwaitingBackend  = "keeping traffic diverted until the Deployment has an available replica"
+	msgTrafficRestoredCond     = "traffic restored"
+	msgTrafficDivertFailedFmt  = "cannot divert traffic: %v"
+	msgTrafficRestoreFailedFmt = "cannot restore traffic: %v"
+
+	// kubectl wait conditions
+	msgWaitFrozen          = "Deployment is frozen"
+	msgWaitNotFrozenFmt    = "Deployment is not frozen (phase %s)"
+	msgWaitCompleted       = "Freeze/unfreeze cycle finished; replicas restored"
+	msgWaitNotCompletedFmt = "Freeze/unfreeze cycle has not completed (phase %s)"
+
+	// Progressing condition
+	msgGenerationPendingFmt = "generation %d not applied yet"
+	msgProgressingFmt       = "generation %d applied; Deployment is %s"
+	msgSettledFmt           = "generation %d applied (phase %s)"
 )
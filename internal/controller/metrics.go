@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"sync"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/internal/version"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// maxNamespaceLabels bounds the namespace label of the queue and reconcile metrics; namespaces
+// seen after the first maxNamespaceLabels are reported as otherNamespaces.
+const (
+	maxNamespaceLabels = 100
+	otherNamespaces    = "_other"
+)
+
+var (
+	// driftDetectedTotal counts frozen Deployments found out of their frozen state.
+	driftDetectedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "deploymentfreezer_drift_detected_total",
+		Help: "Number of times a frozen Deployment was found to have drifted (annotation or replicas).",
+	}, []string{"namespace", "reason"})
+
+	// phaseTransitionsTotal counts the phase changes written to DFZ status.
+	phaseTransitionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "deploymentfreezer_phase_transitions_total",
+		Help: "Number of DeploymentFreezer phase transitions, by the phase left and the phase entered.",
+	}, []string{"from", "to"})
+
+	// queueDepth counts the DFZs waiting in the work queue, including delayed requeues.
+	queueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "deploymentfreezer_queue_depth",
+		Help: "Number of DeploymentFreezers waiting in the work queue, including delayed requeues, by namespace.",
+	}, []string{"namespace"})
+
+	// queueAddsTotal counts every add to the work queue, including adds of queued DFZs.
+	queueAddsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "deploymentfreezer_queue_adds_total",
+		Help: "Number of adds to the DeploymentFreezer work queue, by namespace.",
+	}, []string{"namespace"})
+
+	// reconcileDuration times DFZ reconciles, status write included.
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "deploymentfreezer_reconcile_duration_seconds",
+		Help:    "Duration of DeploymentFreezer reconciles, by namespace.",
+		Buckets: prometheus.ExponentialBuckets(0.005, 2, 12),
+	}, []string{"namespace"})
+
+	// ownershipConflictsTotal counts the conflicts over a target's frozen-by annotation.
+	ownershipConflictsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "deploymentfreezer_ownership_conflicts_total",
+		Help: "Number of ownership conflicts over a target, by namespace and outcome " +
+			"(denied, lost, taken_over, stale_takeover or preempted).",
+	}, []string{"namespace", "outcome"})
+
+	// deadlineLag measures how late a DFZ past its freezeUntil is taken off the work queue.
+	deadlineLag = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "deploymentfreezer_unfreeze_deadline_lag_seconds",
+		Help:    "Time between a DeploymentFreezer's freezeUntil and its reconcile being picked from the work queue.",
+		Buckets: []float64{0.1, 0.5, 1, 2, 5, 10, 30, 60, 120, 300},
+	})
+
+	// unfreezeLatency measures how late a DFZ's replicas were restored relative to its freezeUntil.
+	unfreezeLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "deploymentfreezer_unfreeze_latency_seconds",
+		Help:    "Time between a DeploymentFreezer's freezeUntil and its replicas being restored, by namespace.",
+		Buckets: []float64{1, 5, 10, 30, 60, 120, 300, 600, 1800, 3600, 4 * 3600},
+	}, []string{"namespace"})
+
+	// timeToFrozen measures how long a DFZ took from creation to its target being drained.
+	timeToFrozen = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "deploymentfreezer_time_to_frozen_seconds",
+		Help:    "Time between a DeploymentFreezer's creation and its target reaching zero replicas, by namespace.",
+		Buckets: []float64{1, 5, 10, 30, 60, 120, 300, 600, 1800, 3600},
+	}, []string{"namespace"})
+
+	// cpuCoreSecondsSaved accumulates the CPU requests freezes kept off the cluster.
+	cpuCoreSecondsSaved = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "deploymentfreezer_cpu_core_seconds_saved_total",
+		Help: "CPU requests of frozen Pods times the time they were frozen, in core-seconds, by namespace.",
+	}, []string{"namespace"})
+
+	// memoryByteSecondsSaved accumulates the memory requests freezes kept off the cluster.
+	memoryByteSecondsSaved = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "deploymentfreezer_memory_byte_seconds_saved_total",
+		Help: "Memory requests of frozen Pods times the time they were frozen, in byte-seconds, by namespace.",
+	}, []string{"namespace"})
+
+	// buildInfo is always 1; its labels identify the running build.
+	buildInfo = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "deploymentfreezer_build_info",
+		Help: "Build of the running controller, by version, git commit and Go version. Always 1.",
+	}, []string{"version", "git_sha", "go_version"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(driftDetectedTotal, phaseTransitionsTotal,
+		queueDepth, queueAddsTotal, reconcileDuration, deadlineLag, ownershipConflictsTotal, unfreezeLatency, timeToFrozen,
+		cpuCoreSecondsSaved, memoryByteSecondsSaved, buildInfo)
+	build := version.Get()
+	buildInfo.WithLabelValues(build.Version, build.GitCommit, build.GoVersion).Set(1)
+}
+
+// namespaceLabels hands out namespace label values, keeping the number of series bounded.
+type namespaceLabels struct {
+	mu   sync.Mutex
+	seen map[string]bool
+	max  int
+}
+
+var metricNamespaces = &namespaceLabels{seen: map[string]bool{}, max: maxNamespaceLabels}
+
+// label returns ns while fewer than max namespaces have been labeled, or otherNamespaces.
+func (l *namespaceLabels) label(ns string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.seen[ns] {
+		return ns
+	}
+	if len(l.seen) >= l.max {
+		return otherNamespaces
+	}
+	l.seen[ns] = true
+	return ns
+}
+
+// observeReconcile records the duration of a reconcile that started at start. It uses the wall
+// clock, not the reconciler clock, which may be simulated.
+func observeReconcile(namespace string, start time.Time) {
+	reconcileDuration.WithLabelValues(metricNamespaces.label(namespace)).Observe(time.Since(start).Seconds())
+}
+
+// observeUnfreezeLatency records how long after its freezeUntil the DFZ's replicas were restored
+// at now. Unfreezes that ran ahead of the deadline, such as on a wake request, are not observed.
+func observeUnfreezeLatency(dfz *freezerv1alpha1.DeploymentFreezer, now time.Time) {
+	if dfz.Status.FreezeUntil == nil {
+		return
+	}
+	if late := now.Sub(dfz.Status.FreezeUntil.Time); late >= 0 {
+		unfreezeLatency.WithLabelValues(metricNamespaces.label(dfz.Namespace)).Observe(late.Seconds())
+	}
+}
+
+// observeTimeToFrozen records how long the DFZ took from its creation to being Frozen at now.
+func observeTimeToFrozen(dfz *freezerv1alpha1.DeploymentFreezer, now time.Time) {
+	if created := dfz.CreationTimestamp; !created.IsZero() {
+		timeToFrozen.WithLabelValues(metricNamespaces.label(dfz.Namespace)).Observe(now.Sub(created.Time).Seconds())
+	}
+}
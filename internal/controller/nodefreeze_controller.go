@@ -0,0 +1,268 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/internal/policy"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// podNodeNameField is the pod field selector supported by the API server for listing a node's pods.
+const podNodeNameField = "spec.nodeName"
+
+// NodeFreezeReconciler freezes the Deployments running on a node through child DeploymentFreezers.
+type NodeFreezeReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// Clock is the time source for status timestamps; defaults to the real clock.
+	Clock clock.Clock
+	// Shard selects the NodeFreezes handled by this replica; children inherit the shard label.
+	Shard Shard
+	// APIReader lists the node's pods and their ReplicaSets, which are not cached;
+	// defaults to the manager's API reader.
+	APIReader client.Reader
+}
+
+// +kubebuilder:rbac:groups=apps.boolfixer.dev,resources=nodefreezes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.boolfixer.dev,resources=nodefreezes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.boolfixer.dev,resources=nodefreezes/finalizers,verbs=update
+// +kubebuilder:rbac:groups=apps.boolfixer.dev,resources=deploymentfreezers,verbs=create
+// +kubebuilder:rbac:groups=apps.boolfixer.dev,resources=freezerpolicies,verbs=list
+// +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get
+
+func (r *NodeFreezeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var nf freezerv1alpha1.NodeFreeze
+	if err := r.Get(ctx, req.NamespacedName, &nf); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !nf.DeletionTimestamp.IsZero() {
+		// Children are garbage collected and restore their Deployments through their finalizers.
+		return ctrl.Result{}, nil
+	}
+
+	orig := nf.DeepCopy()
+	if nf.Status.DiscoveredAt == nil {
+		if err := r.freezeNode(ctx, &nf); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if err := r.refreshChildren(ctx, &nf); err != nil {
+		return ctrl.Result{}, err
+	}
+	nf.Status.Phase = nodeFreezePhase(&nf.Status)
+
+	if equality.Semantic.DeepEqual(orig.Status, nf.Status) {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{}, r.Status().Patch(ctx, &nf, client.MergeFrom(orig))
+}
+
+// freezeNode discovers the Deployments with pods on the node and creates a DFZ for each of them.
+// Child names are deterministic, so a discovery interrupted by an error is simply repeated.
+// Children are created on behalf of the NodeFreeze creator: FreezerPolicies are evaluated
+// against them first, and each child records them as its requester.
+func (r *NodeFreezeReconciler) freezeNode(ctx context.Context, nf *freezerv1alpha1.NodeFreeze) error {
+	deployments, err := r.deploymentsOnNode(ctx, nf.Spec.NodeName)
+	if err != nil {
+		return err
+	}
+
+	requester := policy.RequesterFromAnnotations(nf)
+	children := make([]freezerv1alpha1.ChildFreeze, 0, len(deployments))
+	for _, nn := range deployments {
+		child := freezerv1alpha1.ChildFreeze{
+			Namespace:  nn.Namespace,
+			Name:       childFreezeName(nf.Name, nn.Name),
+			Deployment: nn.Name,
+			Phase:      freezerv1alpha1.PhasePending,
+		}
+		decision, err := policy.Evaluate(ctx, r.Client, policy.Request{
+			Namespace: nn.Namespace,
+			Requester: requester,
+			Duration:  time.Duration(nf.Spec.DurationSeconds) * time.Second,
+			Now:       r.Clock.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		if !decision.Allowed {
+			child.Phase = ""
+			child.Message = fmt.Sprintf(msgNodeFreezeChildRefusedFmt, decision.Message)
+			children = append(children, child)
+			continue
+		}
+		dfz := r.newChildFreeze(nf, child)
+		requester.Annotate(dfz)
+		if err := controllerutil.SetControllerReference(nf, dfz, r.Scheme); err != nil {
+			return err
+		}
+		err = r.Create(ctx, dfz)
+		switch {
+		case err == nil, apierrors.IsAlreadyExists(err):
+		case apierrors.IsForbidden(err), apierrors.IsInvalid(err):
+			// Refused by admission (e.g. a protected namespace); retrying will not help.
+			child.Phase = ""
+			child.Message = fmt.Sprintf(msgNodeFreezeChildRefusedFmt, err)
+		default:
+			return err
+		}
+		children = append(children, child)
+	}
+
+	now := metav1.NewTime(r.Clock.Now().UTC())
+	nf.Status.DiscoveredAt = &now
+	nf.Status.Freezes = children
+	r.Recorder.Eventf(nf, corev1.EventTypeNormal, ReasonNodeFreezeDiscovered, msgNodeFreezeDiscovered,
+		len(children), nf.Spec.NodeName)
+	return nil
+}
+
+func (r *NodeFreezeReconciler) newChildFreeze(
+	nf *freezerv1alpha1.NodeFreeze,
+	child freezerv1alpha1.ChildFreeze,
+) *freezerv1alpha1.DeploymentFreezer {
+	labels := map[string]string{freezerv1alpha1.LabelNodeFreeze: nf.Name}
+	if v, ok := nf.Labels[LabelShard]; ok {
+		labels[LabelShard] = v
+	}
+	return &freezerv1alpha1.DeploymentFreezer{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: child.Namespace,
+			Name:      child.Name,
+			Labels:    labels,
+		},
+		Spec: freezerv1alpha1.DeploymentFreezerSpec{
+			TargetRef:       freezerv1alpha1.DeploymentTargetRef{Name: child.Deployment},
+			DurationSeconds: nf.Spec.DurationSeconds,
+		},
+	}
+}
+
+// deploymentsOnNode returns the Deployments owning running pods on the node, in pod order.
+func (r *NodeFreezeReconciler) deploymentsOnNode(ctx context.Context, node string) ([]types.NamespacedName, error) {
+	var pods corev1.PodList
+	if err := r.APIReader.List(ctx, &pods, client.MatchingFields{podNodeNameField: node}); err != nil {
+		return nil, err
+	}
+
+	var out []types.NamespacedName
+	seen := map[types.NamespacedName]bool{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		nn, ok, err := r.owningDeployment(ctx, pod)
+		if err != nil {
+			return nil, err
+		}
+		if ok && !seen[nn] {
+			seen[nn] = true
+			out = append(out, nn)
+		}
+	}
+	return out, nil
+}
+
+// owningDeployment follows pod -> ReplicaSet -> Deployment controller references.
+func (r *NodeFreezeReconciler) owningDeployment(ctx context.Context, pod *corev1.Pod) (types.NamespacedName, bool, error) {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil || ref.Kind != "ReplicaSet" || ref.APIVersion != appsv1.SchemeGroupVersion.String() {
+		return types.NamespacedName{}, false, nil
+	}
+	var rs appsv1.ReplicaSet
+	if err := r.APIReader.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: ref.Name}, &rs); err != nil {
+		if apierrors.IsNotFound(err) {
+			return types.NamespacedName{}, false, nil
+		}
+		return types.NamespacedName{}, false, err
+	}
+	ref = metav1.GetControllerOf(&rs)
+	if ref == nil || ref.Kind != "Deployment" || ref.APIVersion != appsv1.SchemeGroupVersion.String() {
+		return types.NamespacedName{}, false, nil
+	}
+	return types.NamespacedName{Namespace: pod.Namespace, Name: ref.Name}, true, nil
+}
+
+// refreshChildren copies the current phase of every child DFZ into the NodeFreeze status.
+func (r *NodeFreezeReconciler) refreshChildren(ctx context.Context, nf *freezerv1alpha1.NodeFreeze) error {
+	for i := range nf.Status.Freezes {
+		child := &nf.Status.Freezes[i]
+		if child.Phase == "" {
+			// The DFZ was refused on creation or is gone.
+			continue
+		}
+		var dfz freezerv1alpha1.DeploymentFreezer
+		err := r.Get(ctx, types.NamespacedName{Namespace: child.Namespace, Name: child.Name}, &dfz)
+		switch {
+		case apierrors.IsNotFound(err):
+			child.Phase = ""
+			child.Message = msgNodeFreezeChildGone
+		case err != nil:
+			return err
+		default:
+			child.Phase = dfz.Status.Phase
+			if child.Phase == "" {
+				child.Phase = freezerv1alpha1.PhasePending
+			}
+			child.Message = ""
+		}
+	}
+	return nil
+}
+
+// nodeFreezePhase aggregates the child phases, reporting the least advanced one still in progress.
+func nodeFreezePhase(status *freezerv1alpha1.NodeFreezeStatus) freezerv1alpha1.Phase {
+	if status.DiscoveredAt == nil {
+		return freezerv1alpha1.PhasePending
+	}
+	phase := freezerv1alpha1.PhaseCompleted
+	for _, child := range status.Freezes {
+		switch child.Phase {
+		case freezerv1alpha1.PhasePending, freezerv1alpha1.PhaseFreezing:
+			return freezerv1alpha1.PhaseFreezing
+		case freezerv1alpha1.PhaseFrozen:
+			phase = freezerv1alpha1.PhaseFrozen
+		case freezerv1alpha1.PhaseUnfreezing:
+			if phase != freezerv1alpha1.PhaseFrozen {
+				phase = freezerv1alpha1.PhaseUnfreezing
+			}
+		}
+	}
+	return phase
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NodeFreezeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Clock == nil {
+		r.Clock = clock.RealClock{}
+	}
+	if r.APIReader == nil {
+		r.APIReader = mgr.GetAPIReader()
+	}
+	r.Recorder = mgr.GetEventRecorderFor("nodefreeze")
+	return ctrl.NewControllerManagedBy(mgr).
+		For(
+			&freezerv1alpha1.NodeFreeze{},
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}, r.Shard.Predicate()),
+		).
+		Owns(&freezerv1alpha1.DeploymentFreezer{}).
+		Named("nodefreeze").
+		Complete(r)
+}
@@ -0,0 +1,206 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/internal/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNodeFreeze(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	controlledBy := func(kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{
+			APIVersion: appsv1.SchemeGroupVersion.String(), Kind: kind, Name: name, Controller: ptr.To(true),
+		}}
+	}
+	newPod := func(name, node string, owners []metav1.OwnerReference) *corev1.Pod {
+		p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, OwnerReferences: owners}}
+		p.Spec.NodeName = node
+		return p
+	}
+	newReconciler := func(objs ...client.Object) *NodeFreezeReconciler {
+		c := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objs...).
+			WithStatusSubresource(&freezerv1alpha1.NodeFreeze{}, &freezerv1alpha1.DeploymentFreezer{}).
+			WithIndex(&corev1.Pod{}, podNodeNameField, func(o client.Object) []string {
+				return []string{o.(*corev1.Pod).Spec.NodeName}
+			}).
+			Build()
+		return &NodeFreezeReconciler{
+			Client:    c,
+			Scheme:    scheme,
+			Recorder:  record.NewFakeRecorder(10),
+			Clock:     testingclock.NewFakeClock(now),
+			APIReader: c,
+		}
+	}
+
+	t.Run("Reconcile_CreatesChildFreezes", func(t *testing.T) {
+		t.Parallel()
+		nf := &freezerv1alpha1.NodeFreeze{
+			ObjectMeta: metav1.ObjectMeta{Name: "maint", Labels: map[string]string{LabelShard: "1"}},
+			Spec:       freezerv1alpha1.NodeFreezeSpec{NodeName: "node-a", DurationSeconds: 600},
+		}
+		policy.Requester{Username: "alice", Groups: []string{"sre"}}.Annotate(nf)
+		r := newReconciler(
+			nf,
+			&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns", Name: "web-abc", OwnerReferences: controlledBy("Deployment", "web"),
+			}},
+			newPod("web-abc-1", "node-a", controlledBy("ReplicaSet", "web-abc")),
+			newPod("web-abc-2", "node-a", controlledBy("ReplicaSet", "web-abc")),
+			newPod("web-abc-3", "node-b", controlledBy("ReplicaSet", "web-abc")),
+			newPod("agent-x", "node-a", controlledBy("DaemonSet", "agent")),
+			newPod("bare", "node-a", nil),
+		)
+		ctx := context.Background()
+
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "maint"}})
+		require.NoError(t, err)
+
+		var dfz freezerv1alpha1.DeploymentFreezer
+		require.NoError(t, r.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "maint-web"}, &dfz))
+		assert.Equal(t, "web", dfz.Spec.TargetRef.Name)
+		assert.Equal(t, int64(600), dfz.Spec.DurationSeconds)
+		assert.Equal(t, "maint", dfz.Labels[freezerv1alpha1.LabelNodeFreeze])
+		assert.Equal(t, "1", dfz.Labels[LabelShard])
+		assert.Equal(t, policy.Requester{Username: "alice", Groups: []string{"sre"}}, policy.RequesterFromAnnotations(&dfz))
+		require.NotNil(t, metav1.GetControllerOf(&dfz))
+		assert.Equal(t, "maint", metav1.GetControllerOf(&dfz).Name)
+
+		var got freezerv1alpha1.NodeFreeze
+		require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "maint"}, &got))
+		require.NotNil(t, got.Status.DiscoveredAt)
+		assert.Equal(t, freezerv1alpha1.PhaseFreezing, got.Status.Phase)
+		assert.Equal(t, []freezerv1alpha1.ChildFreeze{{
+			Namespace: "ns", Name: "maint-web", Deployment: "web", Phase: freezerv1alpha1.PhasePending,
+		}}, got.Status.Freezes)
+
+		dfz.Status.Phase = freezerv1alpha1.PhaseFrozen
+		require.NoError(t, r.Status().Update(ctx, &dfz))
+		_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "maint"}})
+		require.NoError(t, err)
+		require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "maint"}, &got))
+		assert.Equal(t, freezerv1alpha1.PhaseFrozen, got.Status.Phase)
+		assert.Equal(t, freezerv1alpha1.PhaseFrozen, got.Status.Freezes[0].Phase)
+	})
+
+	t.Run("Reconcile_PolicyEvaluatedForCreator", func(t *testing.T) {
+		t.Parallel()
+		nf := &freezerv1alpha1.NodeFreeze{
+			ObjectMeta: metav1.ObjectMeta{Name: "maint"},
+			Spec:       freezerv1alpha1.NodeFreezeSpec{NodeName: "node-a"},
+		}
+		policy.Requester{Username: "mallory"}.Annotate(nf)
+		allowAlice := &freezerv1alpha1.FreezerPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "sre"},
+			Spec: freezerv1alpha1.FreezerPolicySpec{Rules: []freezerv1alpha1.FreezerPolicyRule{{
+				Action:     freezerv1alpha1.PolicyActionAllow,
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+				Namespaces: []string{"*"},
+			}}},
+		}
+		r := newReconciler(
+			nf, allowAlice,
+			&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns", Name: "web-abc", OwnerReferences: controlledBy("Deployment", "web"),
+			}},
+			newPod("web-abc-1", "node-a", controlledBy("ReplicaSet", "web-abc")),
+		)
+		ctx := context.Background()
+
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "maint"}})
+		require.NoError(t, err)
+
+		var dfz freezerv1alpha1.DeploymentFreezer
+		assert.True(t, apierrors.IsNotFound(r.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "maint-web"}, &dfz)))
+		var got freezerv1alpha1.NodeFreeze
+		require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "maint"}, &got))
+		require.Len(t, got.Status.Freezes, 1)
+		assert.Empty(t, got.Status.Freezes[0].Phase)
+		assert.Contains(t, got.Status.Freezes[0].Message, "no FreezerPolicy allows mallory")
+	})
+
+	t.Run("Reconcile_ChildGone", func(t *testing.T) {
+		t.Parallel()
+		discovered := metav1.NewTime(now)
+		nf := &freezerv1alpha1.NodeFreeze{
+			ObjectMeta: metav1.ObjectMeta{Name: "maint"},
+			Spec:       freezerv1alpha1.NodeFreezeSpec{NodeName: "node-a"},
+			Status: freezerv1alpha1.NodeFreezeStatus{
+				DiscoveredAt: &discovered,
+				Freezes: []freezerv1alpha1.ChildFreeze{{
+					Namespace: "ns", Name: "maint-web", Deployment: "web", Phase: freezerv1alpha1.PhaseFrozen,
+				}},
+			},
+		}
+		r := newReconciler(nf)
+		ctx := context.Background()
+
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "maint"}})
+		require.NoError(t, err)
+
+		var got freezerv1alpha1.NodeFreeze
+		require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "maint"}, &got))
+		assert.Equal(t, freezerv1alpha1.PhaseCompleted, got.Status.Phase)
+		assert.Empty(t, got.Status.Freezes[0].Phase)
+		assert.Equal(t, msgNodeFreezeChildGone, got.Status.Freezes[0].Message)
+	})
+
+	t.Run("Phase_LeastAdvancedChildWins", func(t *testing.T) {
+		t.Parallel()
+		discovered := metav1.NewTime(now)
+		status := func(phases ...freezerv1alpha1.Phase) *freezerv1alpha1.NodeFreezeStatus {
+			s := &freezerv1alpha1.NodeFreezeStatus{DiscoveredAt: &discovered}
+			for _, p := range phases {
+				s.Freezes = append(s.Freezes, freezerv1alpha1.ChildFreeze{Phase: p})
+			}
+			return s
+		}
+		assert.Equal(t, freezerv1alpha1.PhasePending, nodeFreezePhase(&freezerv1alpha1.NodeFreezeStatus{}))
+		assert.Equal(t, freezerv1alpha1.PhaseCompleted, nodeFreezePhase(status()))
+		assert.Equal(t, freezerv1alpha1.PhaseFreezing,
+			nodeFreezePhase(status(freezerv1alpha1.PhaseFrozen, freezerv1alpha1.PhasePending)))
+		assert.Equal(t, freezerv1alpha1.PhaseFrozen,
+			nodeFreezePhase(status(freezerv1alpha1.PhaseUnfreezing, freezerv1alpha1.PhaseFrozen)))
+		assert.Equal(t, freezerv1alpha1.PhaseUnfreezing,
+			nodeFreezePhase(status(freezerv1alpha1.PhaseUnfreezing, freezerv1alpha1.PhaseCompleted, "")))
+		assert.Equal(t, freezerv1alpha1.PhaseCompleted,
+			nodeFreezePhase(status(freezerv1alpha1.PhaseDenied, freezerv1alpha1.PhaseCompleted)))
+	})
+
+	t.Run("ChildName_LongNamesHashed", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, "maint-web", childFreezeName("maint", "web"))
+		long := childFreezeName(strings.Repeat("n", 200), strings.Repeat("d", 200))
+		assert.Len(t, long, validation.DNS1123SubdomainMaxLength)
+		assert.NotEqual(t, long, childFreezeName(strings.Repeat("n", 200), strings.Repeat("d", 201)))
+	})
+}
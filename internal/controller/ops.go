@@ -1,81 +1,101 @@
 package controller
 
 import (
+	"cmp"
 	"context"
-	"fmt"
 	"slices"
+	"strings"
 
 	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
-	appsv1 "k8s.io/api/apps/v1"
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// patchDeploymentReplicas sets .spec.replicas using a MergeFrom patch with retry on conflict.
-func (r *DeploymentFreezerReconciler) patchDeploymentReplicas(
+// freezeTarget claims the target for the DFZ, pauses its rollouts and scales it to zero, as far
+// as asked, in as few writes as the target allows: a single one for Deployments.
+func (r *DeploymentFreezerReconciler) freezeTarget(
 	ctx context.Context,
-	d *appsv1.Deployment,
-	replicas int32,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	target freeze.Freezable,
+	claim, pause, scale bool,
 ) error {
-	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		var latest appsv1.Deployment
-		if err := r.Get(ctx, types.NamespacedName{Namespace: d.Namespace, Name: d.Name}, &latest); err != nil {
-			return err
-		}
-		orig := latest.DeepCopy()
-		latest.Spec.Replicas = ptr.To(replicas)
-		return r.Patch(ctx, &latest, client.MergeFrom(orig))
-	})
+	var c freeze.Change
+	if claim {
+		c.FrozenBy = ptr.To(frozenByValue(dfz))
+	}
+	if pause {
+		c.Paused = ptr.To(true)
+	}
+	if scale {
+		c.Replicas = ptr.To(int32(0))
+	}
+	return freeze.Apply(ctx, target, c, r.patchOpts(dfz)...)
 }
 
-// patchDeploymentAnno sets or clears a single annotation on Deployment using a MergeFrom patch with retry.
-func (r *DeploymentFreezerReconciler) patchDeploymentAnno(
+// restoreTarget scales the target back to replicas and gives a Pausable target its recorded
+// spec.paused, in a single write for Deployments, so a crash cannot leave the target scaled up
+// with its rollouts still paused. It returns the paused value it wrote, if any. In lean RBAC mode
+// the pause is restored first with its own patch, and the replicas then through the scale subresource.
+func (r *DeploymentFreezerReconciler) restoreTarget(
 	ctx context.Context,
-	d *appsv1.Deployment,
-	key, val string,
-) error {
-	nn := types.NamespacedName{Namespace: d.Namespace, Name: d.Name}
-	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		var latest appsv1.Deployment
-		if err := r.Get(ctx, nn, &latest); err != nil {
-			return err
-		}
-		orig := latest.DeepCopy()
-		if latest.Annotations == nil {
-			latest.Annotations = map[string]string{}
-		}
-		if val != "" {
-			latest.Annotations[key] = val
-		} else {
-			delete(latest.Annotations, key)
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	target freeze.Freezable,
+	replicas int32,
+) (*bool, error) {
+	c := freeze.Change{Replicas: ptr.To(replicas)}
+	if p, ok := target.(freeze.Pausable); ok {
+		if paused := r.pausedToRestore(dfz); paused != nil && *paused != p.Paused() {
+			c.Paused = paused
 		}
-		return r.Patch(ctx, &latest, client.MergeFrom(orig))
-	})
+	}
+	return c.Paused, freeze.Apply(ctx, target, c, r.patchOpts(dfz)...)
+}
+
+// setAnnotation sets the annotation, or removes it when val is empty.
+func setAnnotation(meta *metav1.ObjectMeta, key, val string) {
+	if val == "" {
+		delete(meta.Annotations, key)
+		return
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[key] = val
 }
 
-// ensureFinalizer adds the controller finalizer via Patch with retry to minimize conflicts.
-func (r *DeploymentFreezerReconciler) ensureFinalizer(
+// patchDFZMetadata sends the changes mutate makes to the DFZ metadata as a merge patch guarded by
+// resourceVersion, since merge patches replace lists such as finalizers whole. Only a conflict
+// re-reads the DFZ. The in-memory status, written later by commitStatus, is left untouched.
+func (r *DeploymentFreezerReconciler) patchDFZMetadata(
 	ctx context.Context,
 	dfz *freezerv1alpha1.DeploymentFreezer,
+	mutate func(*metav1.ObjectMeta),
 ) error {
-	if slices.Contains(dfz.Finalizers, finalizerName) {
-		return nil
-	}
+	latest := dfz.DeepCopy()
 	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		var latest freezerv1alpha1.DeploymentFreezer
-		if err := r.Get(ctx, types.NamespacedName{Namespace: dfz.Namespace, Name: dfz.Name}, &latest); err != nil {
+		orig := latest.DeepCopy()
+		mutate(&latest.ObjectMeta)
+		err := r.Patch(ctx, latest, client.MergeFromWithOptions(orig, client.MergeFromWithOptimisticLock{}))
+		if apierrors.IsConflict(err) {
+			latest = &freezerv1alpha1.DeploymentFreezer{}
+			if getErr := r.APIReader.Get(ctx, client.ObjectKeyFromObject(dfz), latest); getErr != nil {
+				return getErr
+			}
+		}
+		if err != nil {
 			return err
 		}
-		orig := latest.DeepCopy()
-		latest.Finalizers = append(latest.Finalizers, finalizerName)
-		return r.Patch(ctx, &latest, client.MergeFrom(orig))
+		dfz.ObjectMeta = latest.ObjectMeta
+		return nil
 	})
 }
 
-// removeFinalizer removes the controller finalizer via Patch with retry.
+// removeFinalizer removes the controller finalizer.
 func (r *DeploymentFreezerReconciler) removeFinalizer(
 	ctx context.Context,
 	dfz *freezerv1alpha1.DeploymentFreezer,
@@ -83,48 +103,48 @@ func (r *DeploymentFreezerReconciler) removeFinalizer(
 	if !slices.Contains(dfz.Finalizers, finalizerName) {
 		return nil
 	}
-	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		var latest freezerv1alpha1.DeploymentFreezer
-		if err := r.Get(ctx, types.NamespacedName{Namespace: dfz.Namespace, Name: dfz.Name}, &latest); err != nil {
-			return err
-		}
-		orig := latest.DeepCopy()
-		latest.Finalizers = removeString(latest.Finalizers, finalizerName)
-		return r.Patch(ctx, &latest, client.MergeFrom(orig))
+	return r.patchDFZMetadata(ctx, dfz, func(meta *metav1.ObjectMeta) {
+		meta.Finalizers = removeString(meta.Finalizers, finalizerName)
 	})
 }
 
-// ensureTemplateHashAnno initializes template-hash annotation, and flags spec change condition.
-// Uses retry-on-conflict when first setting the annotation.
-func (r *DeploymentFreezerReconciler) ensureTemplateHashAnno(
+// ensureMetadata adds the controller finalizer and the restarted-at annotation, records the
+// template hash in status, and flags a spec change once the recorded hash no longer matches the
+// template. The hash is written with the status; DFZs created by earlier versions keep it in
+// the template-hash annotation, which is adopted.
+func (r *DeploymentFreezerReconciler) ensureMetadata(
 	ctx context.Context,
 	dfz *freezerv1alpha1.DeploymentFreezer,
-	deploy *appsv1.Deployment,
+	obj client.Object,
 ) error {
-	tplHash := hashTemplate(deploy)
-	prevHash := ""
-	if dfz.Annotations != nil {
-		prevHash = dfz.Annotations[annoTemplateHash]
-	}
-	if prevHash == "" {
-		return retry.RetryOnConflict(retry.DefaultRetry, func() error {
-			var latest freezerv1alpha1.DeploymentFreezer
-			if err := r.Get(ctx, types.NamespacedName{Namespace: dfz.Namespace, Name: dfz.Name}, &latest); err != nil {
-				return err
-			}
-			if latest.Annotations == nil {
-				latest.Annotations = map[string]string{}
+	tplHash := hashTemplate(obj)
+	prevHash := cmp.Or(dfz.Status.TemplateHash, dfz.Annotations[annoTemplateHash])
+	_, hasBaseline := dfz.Annotations[annoRestartedAt]
+	if !slices.Contains(dfz.Finalizers, finalizerName) || (prevHash == "" && !hasBaseline) {
+		if err := r.patchDFZMetadata(ctx, dfz, func(meta *metav1.ObjectMeta) {
+			if !slices.Contains(meta.Finalizers, finalizerName) {
+				meta.Finalizers = append(meta.Finalizers, finalizerName)
 			}
-			if _, exists := latest.Annotations[annoTemplateHash]; exists {
-				return nil
+			if _, exists := meta.Annotations[annoRestartedAt]; !exists {
+				// Kept even when empty: a missing annotation means no baseline was recorded.
+				if meta.Annotations == nil {
+					meta.Annotations = map[string]string{}
+				}
+				meta.Annotations[annoRestartedAt] = restartedAt(obj)
 			}
-			orig := latest.DeepCopy()
-			latest.Annotations[annoTemplateHash] = tplHash
-			return r.Patch(ctx, &latest, client.MergeFrom(orig))
-		})
+		}); err != nil {
+			return err
+		}
+	}
+	// A hash from an earlier version of hashTemplate cannot be compared; the template as it is
+	// now becomes the baseline.
+	if !strings.HasPrefix(prevHash, templateHashPrefix) {
+		dfz.Status.TemplateHash = tplHash
+		return nil
 	}
+	dfz.Status.TemplateHash = prevHash
 
-	// If the stored hash differs from current template, raise a condition.
+	// If the recorded hash differs from current template, raise a condition.
 	if prevHash != tplHash {
 		setCondition(
 			dfz,
@@ -137,33 +157,83 @@ func (r *DeploymentFreezerReconciler) ensureTemplateHashAnno(
 	return nil
 }
 
+// reconcileDelete restores the target the DFZ holds and releases it. It returns an error if
+// the traffic, the target or its ownership could not be restored: the finalizer is then kept,
+// so the target is not left frozen with nothing to restore it.
 func (r *DeploymentFreezerReconciler) reconcileDelete(
 	ctx context.Context,
-	deployment *appsv1.Deployment,
+	target freeze.Freezable,
 	dfz *freezerv1alpha1.DeploymentFreezer,
-) {
-	owner := fmt.Sprintf("%s/%s", dfz.Namespace, dfz.Name)
-	if deployment.Annotations[annoFrozenBy] != owner {
+) error {
+	// Diverted traffic is recorded per DFZ, so it is restored even without ownership.
+	restored, err := r.restoreAllTraffic(ctx, dfz)
+	if err != nil {
+		r.Recorder.Eventf(dfz, corev1.EventTypeWarning, ReasonRestoreFailed, msgTrafficRestoreFailed, err)
+		return err
+	}
+	if len(restored) > 0 {
+		r.Recorder.Eventf(dfz, corev1.EventTypeNormal, ReasonTrafficRestored, msgTrafficRestored, strings.Join(restored, "; "))
+	}
+
+	owner := frozenByValue(dfz)
+	if !isFrozenBy(target.Owner(), dfz) {
 		// We are not the owner anymore; nothing to do.
 		r.Recorder.Eventf(dfz, corev1.EventTypeWarning, ReasonSkippedNotOwner, msgSkippedNotOwner, owner)
-		return
+		return nil
+	}
+
+	if dfz.Annotations[freezerv1alpha1.AnnoSkipRestore] == "true" {
+		obj := target.Object()
+		r.Recorder.Eventf(dfz, corev1.EventTypeNormal, ReasonRestoreSkipped, msgRestoreSkipped,
+			obj.GetNamespace(), obj.GetName(), target.GetReplicas())
+	} else if dfz.Spec.GitOpsMode {
+		if err := r.gitOpsRestoreOnDelete(ctx, target, dfz); err != nil {
+			return err
+		}
+	} else if err := r.restoreOnDelete(ctx, target, dfz); err != nil {
+		return err
+	}
+
+	// Clear ownership annotation
+	if err := target.AcquireOwnership(ctx, "", r.patchOpts(dfz)...); err != nil {
+		r.Recorder.Eventf(dfz, corev1.EventTypeWarning, ReasonClearOwnershipFailed, msgClearOwnershipFailed, err)
+		return err
 	}
+	obj := target.Object()
+	r.Recorder.Eventf(dfz, corev1.EventTypeNormal, ReasonOwnershipCleared, msgOwnershipCleared, obj.GetNamespace(), obj.GetName())
+	return nil
+}
 
-	// Restore replicas
+// restoreOnDelete restores replicas and the paused flag, then autoscalers from the snapshot.
+// Failures are reported as events and returned.
+func (r *DeploymentFreezerReconciler) restoreOnDelete(
+	ctx context.Context,
+	target freeze.Freezable,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+) error {
 	replicas := defaultReplicasCount
 	if dfz.Status.OriginalReplicas != nil {
 		replicas = *dfz.Status.OriginalReplicas
 	}
-	if err := r.patchDeploymentReplicas(ctx, deployment, replicas); err != nil {
+	paused, err := r.restoreTarget(ctx, dfz, target, replicas)
+	switch {
+	case err != nil && paused != nil:
+		r.Recorder.Eventf(dfz, corev1.EventTypeWarning, ReasonRestoreFailed, msgReplicasPausedFailed, replicas, *paused, err)
+	case err != nil:
 		r.Recorder.Eventf(dfz, corev1.EventTypeWarning, ReasonRestoreFailed, msgReplicasRestoreFailed, replicas, err)
-	} else {
+	case paused != nil:
+		r.Recorder.Eventf(dfz, corev1.EventTypeNormal, ReasonRestored, msgReplicasPausedRestored, replicas, *paused)
+	default:
 		r.Recorder.Eventf(dfz, corev1.EventTypeNormal, ReasonRestored, msgReplicasRestored, replicas)
 	}
-
-	// Clear ownership annotation
-	if err := r.patchDeploymentAnno(ctx, deployment, annoFrozenBy, ""); err != nil {
-		r.Recorder.Eventf(dfz, corev1.EventTypeWarning, ReasonClearOwnershipFailed, msgClearOwnershipFailed, err)
-	} else {
-		r.Recorder.Eventf(dfz, corev1.EventTypeNormal, ReasonOwnershipCleared, msgOwnershipCleared, deployment.Namespace, deployment.Name)
+	if err != nil {
+		return err
 	}
+	// Deleting a DFZ ends its freeze as well; a freeze still in progress saved nothing.
+	observeSavings(dfz, target.Object(), r.Clock.Now())
+	if err := target.Restore(ctx, dfz.Status.Snapshot, r.patchOpts(dfz)...); err != nil {
+		r.Recorder.Eventf(dfz, corev1.EventTypeWarning, ReasonRestoreFailed, msgAutoscalingRestoreFailed, err)
+		return err
+	}
+	return nil
 }
@@ -0,0 +1,320 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestPatchHelpers(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	newObjects := func() (*freezerv1alpha1.DeploymentFreezer, *appsv1.Deployment) {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "freeze"}}
+		dfz.Spec.TargetRef.Name = "web"
+		deploy := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web"},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(3))},
+		}
+		return dfz, deploy
+	}
+	type counts struct{ reads, writes int }
+	// newReconciler counts reads through both the client and the API reader, and patches.
+	newReconciler := func(objs ...client.Object) (*DeploymentFreezerReconciler, *counts) {
+		n := &counts{}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+			WithStatusSubresource(&freezerv1alpha1.DeploymentFreezer{}).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					n.reads++
+					return c.Get(ctx, key, obj, opts...)
+				},
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					n.writes++
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+				SubResourcePatch: func(ctx context.Context, c client.Client, sub string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+					n.writes++
+					return c.SubResource(sub).Patch(ctx, obj, patch, opts...)
+				},
+			}).Build()
+		return &DeploymentFreezerReconciler{Client: c, APIReader: c}, n
+	}
+	fetch := func(t *testing.T, r *DeploymentFreezerReconciler, obj client.Object) {
+		require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(obj), obj))
+	}
+
+	t.Run("Freeze_OneWritePerObjectNoReads", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		dfz, deploy := newObjects()
+		r, n := newReconciler(dfz, deploy)
+		fetch(t, r, dfz)
+		fetch(t, r, deploy)
+		*n = counts{}
+
+		st := newStatusTracker(dfz)
+		require.NoError(t, r.ensureMetadata(ctx, dfz, deploy))
+		target, err := r.freezer().Target(deploy)
+		require.NoError(t, err)
+		require.NoError(t, r.freezeTarget(ctx, dfz, target, true, false, true))
+		r.setPhase(dfz, freezerv1alpha1.PhaseFreezing)
+		r.commitStatus(ctx, dfz, st)
+		assert.Equal(t, counts{writes: 3}, *n)
+
+		// The held objects reflect the writes.
+		assert.Equal(t, int32(0), *deploy.Spec.Replicas)
+		assert.Equal(t, frozenByValue(dfz), deploy.Annotations[annoFrozenBy])
+		assert.Contains(t, dfz.Finalizers, finalizerName)
+		assert.NotEmpty(t, dfz.Status.TemplateHash)
+		assert.NotContains(t, dfz.Annotations, annoTemplateHash)
+		assert.Contains(t, dfz.Annotations, annoRestartedAt)
+
+		got := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: dfz.ObjectMeta}
+		fetch(t, r, got)
+		assert.Equal(t, []string{finalizerName}, got.Finalizers)
+		assert.Equal(t, freezerv1alpha1.PhaseFreezing, got.Status.Phase)
+		assert.Contains(t, got.Status.PhaseTransitionTimes, freezerv1alpha1.PhaseFreezing)
+		gotDeploy := &appsv1.Deployment{ObjectMeta: deploy.ObjectMeta}
+		fetch(t, r, gotDeploy)
+		assert.Equal(t, int32(0), *gotDeploy.Spec.Replicas)
+		assert.Equal(t, frozenByValue(dfz), gotDeploy.Annotations[annoFrozenBy])
+	})
+
+	t.Run("StaleFinalizers_RereadOnConflict", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		dfz, _ := newObjects()
+		r, n := newReconciler(dfz)
+		fetch(t, r, dfz)
+		stale := dfz.DeepCopy()
+
+		// Someone else adds a finalizer after our read.
+		dfz.Finalizers = []string{"other.io/finalizer"}
+		require.NoError(t, r.Update(ctx, dfz))
+		*n = counts{}
+
+		require.NoError(t, r.ensureMetadata(ctx, stale, &appsv1.Deployment{}))
+		assert.Equal(t, counts{reads: 1, writes: 2}, *n)
+		fetch(t, r, dfz)
+		assert.ElementsMatch(t, []string{"other.io/finalizer", finalizerName}, dfz.Finalizers)
+	})
+
+	t.Run("TemplateChanged_FlaggedWithoutWrites", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		dfz, deploy := newObjects()
+		r, n := newReconciler(dfz)
+		fetch(t, r, dfz)
+		require.NoError(t, r.ensureMetadata(ctx, dfz, deploy))
+		recorded := dfz.Status.TemplateHash
+		*n = counts{}
+
+		deploy.Spec.Template.Spec.Containers = []corev1.Container{{Name: "app", Image: "app:2"}}
+		require.NoError(t, r.ensureMetadata(ctx, dfz, deploy))
+		assert.Equal(t, counts{}, *n)
+		assert.Equal(t, recorded, dfz.Status.TemplateHash)
+		assert.True(t, hasCondition(dfz, freezerv1alpha1.ConditionTypeSpecChangedDuringFreeze,
+			freezerv1alpha1.ConditionStatusTrue, freezerv1alpha1.ConditionReasonObserved))
+	})
+
+	t.Run("LegacyAnnotationHash_Adopted", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		dfz, deploy := newObjects()
+		dfz.Finalizers = []string{finalizerName}
+		dfz.Annotations = map[string]string{annoTemplateHash: hashTemplate(deploy), annoRestartedAt: ""}
+		r, n := newReconciler(dfz)
+		fetch(t, r, dfz)
+		*n = counts{}
+
+		require.NoError(t, r.ensureMetadata(ctx, dfz, deploy))
+		assert.Equal(t, counts{}, *n)
+		assert.Equal(t, hashTemplate(deploy), dfz.Status.TemplateHash)
+		assert.Empty(t, dfz.Status.Conditions)
+	})
+
+	t.Run("EarlierHashScheme_Rebaselined", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		dfz, deploy := newObjects()
+		dfz.Finalizers = []string{finalizerName}
+		dfz.Status.TemplateHash = "0123abcd"
+		r, _ := newReconciler(dfz)
+		fetch(t, r, dfz)
+
+		require.NoError(t, r.ensureMetadata(ctx, dfz, deploy))
+		assert.Equal(t, hashTemplate(deploy), dfz.Status.TemplateHash)
+		assert.Empty(t, dfz.Status.Conditions)
+	})
+
+	t.Run("KeepsStatusInMemory", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		dfz, _ := newObjects()
+		r, _ := newReconciler(dfz)
+		fetch(t, r, dfz)
+
+		r.setPhase(dfz, freezerv1alpha1.PhasePending)
+		require.NoError(t, r.ensureMetadata(ctx, dfz, &appsv1.Deployment{}))
+		assert.Equal(t, freezerv1alpha1.PhasePending, dfz.Status.Phase)
+	})
+
+	t.Run("Restore_ReplicasAndPausedInOneWrite", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		dfz, deploy := newObjects()
+		dfz.Status.OriginalReplicas = ptr.To(int32(3))
+		dfz.Status.OriginalPaused = ptr.To(false)
+		deploy.Spec.Replicas = ptr.To(int32(0))
+		deploy.Spec.Paused = true
+		r, n := newReconciler(dfz, deploy)
+		fetch(t, r, deploy)
+		*n = counts{}
+
+		target, err := r.freezer().Target(deploy)
+		require.NoError(t, err)
+		paused, err := r.restoreTarget(ctx, dfz, target, 3)
+		require.NoError(t, err)
+		assert.Equal(t, ptr.To(false), paused)
+		assert.Equal(t, counts{writes: 1}, *n)
+
+		gotDeploy := &appsv1.Deployment{ObjectMeta: deploy.ObjectMeta}
+		fetch(t, r, gotDeploy)
+		assert.Equal(t, int32(3), *gotDeploy.Spec.Replicas)
+		assert.False(t, gotDeploy.Spec.Paused)
+	})
+}
+
+func TestReconcileDelete(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	// run deletes a DFZ that holds a frozen Deployment and returns the Deployment afterwards.
+	run := func(
+		t *testing.T, annotations map[string]string, dryRun bool, funcs interceptor.Funcs,
+		spec ...func(*freezerv1alpha1.DeploymentFreezerSpec),
+	) (*appsv1.Deployment, []string, error) {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns", Name: "freeze", UID: "dfz-uid", Annotations: annotations,
+		}}
+		for _, fn := range spec {
+			fn(&dfz.Spec)
+		}
+		dfz.Status.OriginalReplicas = ptr.To(int32(3))
+		deploy := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "ns",
+				Name:        "web",
+				Annotations: map[string]string{annoFrozenBy: frozenByValue(dfz)},
+			},
+			Spec: appsv1.DeploymentSpec{Replicas: ptr.To(int32(0))},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(dfz, deploy).WithInterceptorFuncs(funcs).Build()
+		rec := record.NewFakeRecorder(10)
+		r := &DeploymentFreezerReconciler{
+			Client: c, APIReader: c, Recorder: rec, Clock: testingclock.NewFakeClock(time.Now()), DryRun: dryRun,
+		}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(deploy), deploy))
+		target, err := r.freezer().Target(deploy)
+		require.NoError(t, err)
+
+		err = r.reconcileDelete(context.Background(), target, dfz)
+		got := &appsv1.Deployment{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(deploy), got))
+		return got, drainEvents(rec), err
+	}
+
+	t.Run("Default_Restored", func(t *testing.T) {
+		t.Parallel()
+		got, events, err := run(t, nil, false, interceptor.Funcs{})
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), *got.Spec.Replicas)
+		assert.NotContains(t, got.Annotations, annoFrozenBy)
+		assert.Contains(t, events, "Normal ReplicasRestored Restored replicas to 3")
+	})
+
+	t.Run("GlobalDryRun_RestoredForReal", func(t *testing.T) {
+		t.Parallel()
+		got, _, err := run(t, nil, true, interceptor.Funcs{})
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), *got.Spec.Replicas)
+		assert.NotContains(t, got.Annotations, annoFrozenBy)
+	})
+
+	t.Run("RestoreFails_ErrorAndOwnershipKept", func(t *testing.T) {
+		t.Parallel()
+		got, _, err := run(t, nil, false, interceptor.Funcs{
+			Patch: func(context.Context, client.WithWatch, client.Object, client.Patch, ...client.PatchOption) error {
+				return errors.New("boom")
+			},
+		})
+		require.Error(t, err)
+		assert.Equal(t, int32(0), *got.Spec.Replicas)
+		assert.Contains(t, got.Annotations, annoFrozenBy)
+	})
+
+	t.Run("SkipRestore_OwnershipReleasedOnly", func(t *testing.T) {
+		t.Parallel()
+		got, events, err := run(t, map[string]string{freezerv1alpha1.AnnoSkipRestore: "true"}, false, interceptor.Funcs{})
+		require.NoError(t, err)
+		assert.Equal(t, int32(0), *got.Spec.Replicas)
+		assert.NotContains(t, got.Annotations, annoFrozenBy)
+		assert.Equal(t, []string{
+			"Normal RestoreSkipped Restore skipped as requested by the skip-restore annotation; ns/web left at 0 replicas",
+			"Normal OwnershipCleared Cleared ownership annotation on Deployment ns/web",
+		}, events)
+	})
+
+	t.Run("GitOpsMode_LeftToThePipeline", func(t *testing.T) {
+		t.Parallel()
+		got, events, err := run(t, nil, false, interceptor.Funcs{}, func(spec *freezerv1alpha1.DeploymentFreezerSpec) {
+			spec.GitOpsMode = true
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int32(0), *got.Spec.Replicas)
+		assert.NotContains(t, got.Annotations, annoFrozenBy)
+		awaiting := "Normal AwaitingGitOps DeploymentFreezer freeze deleted; GitOps mode: unsuspend the GitOps sync to restore 3 replicas"
+		assert.Equal(t, []string{
+			awaiting,
+			awaiting,
+			"Normal OwnershipCleared Cleared ownership annotation on Deployment ns/web",
+		}, events)
+	})
+}
+
+func TestDryRun(t *testing.T) {
+	r := &DeploymentFreezerReconciler{DryRun: true}
+	newDFZ := func(phase freezerv1alpha1.Phase, original *int32) *freezerv1alpha1.DeploymentFreezer {
+		dfz := &freezerv1alpha1.DeploymentFreezer{}
+		dfz.Status.Phase = phase
+		dfz.Status.OriginalReplicas = original
+		return dfz
+	}
+
+	assert.True(t, r.dryRun(newDFZ("", nil)))
+	assert.True(t, r.dryRun(newDFZ(freezerv1alpha1.PhasePending, nil)))
+	assert.False(t, r.dryRun(newDFZ(freezerv1alpha1.PhasePending, ptr.To(int32(3)))), "freeze already started")
+	assert.False(t, r.dryRun(newDFZ(freezerv1alpha1.PhaseFrozen, ptr.To(int32(3)))))
+	assert.False(t, r.dryRun(newDFZ(freezerv1alpha1.PhaseUnfreezing, ptr.To(int32(3)))))
+	assert.False(t, (&DeploymentFreezerReconciler{}).dryRun(newDFZ("", nil)))
+}
@@ -0,0 +1,243 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Outcomes of an ownership conflict, as counted by ownershipConflictsTotal.
+const (
+	// conflictDenied: another owner held the target when the DFZ tried to acquire it.
+	conflictDenied = "denied"
+	// conflictLost: the frozen-by annotation was removed from a target the DFZ held.
+	conflictLost = "lost"
+	// conflictTakenOver: another owner replaced the DFZ's frozen-by value.
+	conflictTakenOver = "taken_over"
+	// conflictStaleTakeover: the DFZ took over the annotation of an owner that had finished.
+	conflictStaleTakeover = "stale_takeover"
+	// conflictPreempted: a DFZ of higher priority took the target over from the DFZ.
+	conflictPreempted = "preempted"
+)
+
+// ownershipRecheckInterval bounds how long a waiting DFZ goes without a reconcile, in case the
+// event releasing its target was missed, e.g. during a restart of the manager.
+const ownershipRecheckInterval = 5 * time.Minute
+
+// countOwnershipConflict counts an ownership conflict of the DFZ's target.
+func countOwnershipConflict(dfz *freezerv1alpha1.DeploymentFreezer, outcome string) {
+	ownershipConflictsTotal.WithLabelValues(metricNamespaces.label(dfz.Namespace), outcome).Inc()
+}
+
+// ownershipLost reports that the frozen-by annotation of a target the DFZ held was removed, or
+// replaced by frozenBy.
+func (r *DeploymentFreezerReconciler) ownershipLost(
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	obj client.Object,
+	frozenBy string,
+) {
+	msg := fmt.Sprintf(msgOwnershipLost, obj.GetNamespace(), obj.GetName())
+	outcome := conflictLost
+	if frozenBy != "" {
+		msg += fmt.Sprintf(msgOwnershipLostToSuffix, frozenBy)
+		outcome = conflictTakenOver
+	}
+	countOwnershipConflict(dfz, outcome)
+	r.Recorder.Event(dfz, corev1.EventTypeWarning, ReasonOwnershipLost, msg)
+}
+
+// hasAcquired reports whether the DFZ got past Pending, i.e. it held its Deployment at some point.
+func hasAcquired(dfz *freezerv1alpha1.DeploymentFreezer) bool {
+	return dfz.Status.Phase != "" && dfz.Status.Phase != freezerv1alpha1.PhasePending
+}
+
+// ownershipHeld reports whether the DFZ named by a frozen-by value still holds the Deployment.
+// An owner that is gone, was recreated, or reached a terminal phase will never release it, so
+// its annotation is stale. Values that do not name a DFZ (set by hand) always hold.
+// The owner is read from the API server because it may belong to another shard.
+func (r *DeploymentFreezerReconciler) ownershipHeld(ctx context.Context, namespace, frozenBy string) (bool, error) {
+	if _, _, ok := parseFrozenBy(namespace, frozenBy); !ok {
+		return true, nil
+	}
+	owner, err := r.frozenByOwner(ctx, namespace, frozenBy)
+	if err != nil || owner == nil {
+		return false, err
+	}
+	return !isTerminalPhase(owner.Status.Phase), nil
+}
+
+// parseFrozenBy splits a frozen-by value naming a DFZ of namespace into its name and UID. The
+// UID is empty in values written before it was added.
+func parseFrozenBy(namespace, frozenBy string) (string, types.UID, bool) {
+	parts := strings.SplitN(frozenBy, "/", 3)
+	if len(parts) < 2 || parts[0] != namespace || parts[1] == "" {
+		return "", "", false
+	}
+	if len(parts) == 3 {
+		return parts[1], types.UID(parts[2]), true
+	}
+	return parts[1], "", true
+}
+
+// waitForOwnership keeps the DFZ Pending while another owner holds the target, and denies
+// it once spec.acquireTimeoutSeconds have passed since it became Pending. Waiters are woken by
+// the release of the target and rechecked every ownershipRecheckInterval.
+func (r *DeploymentFreezerReconciler) waitForOwnership(
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	obj client.Object,
+	frozenBy string,
+) ctrl.Result {
+	if dfz.Status.Phase == "" {
+		r.setPhase(dfz, freezerv1alpha1.PhasePending)
+	}
+
+	res := ctrl.Result{RequeueAfter: ownershipRecheckInterval}
+	if timeout := time.Duration(dfz.Spec.AcquireTimeoutSeconds) * time.Second; timeout > 0 {
+		since := dfz.CreationTimestamp.Time
+		if t, ok := dfz.Status.PhaseTransitionTimes[freezerv1alpha1.PhasePending]; ok {
+			since = t.Time
+		}
+		remaining := since.Add(timeout).Sub(r.Clock.Now())
+		if remaining <= 0 {
+			r.setPhase(dfz, freezerv1alpha1.PhaseDenied)
+			setCondition(
+				dfz,
+				freezerv1alpha1.ConditionTypeOwnership,
+				freezerv1alpha1.ConditionStatusFalse,
+				freezerv1alpha1.ConditionReasonDeniedAlreadyFrozen,
+				fmt.Sprintf(msgDeploymentAlreadyOwnedFmt, frozenBy),
+			)
+			setCondition(
+				dfz,
+				freezerv1alpha1.ConditionTypeWaitingForOwnership,
+				freezerv1alpha1.ConditionStatusFalse,
+				freezerv1alpha1.ConditionReasonAcquireTimeout,
+				fmt.Sprintf(msgAcquireTimeoutFmt, frozenBy, timeout),
+			)
+			r.Recorder.Eventf(dfz, corev1.EventTypeWarning, ReasonAcquireTimeout, msgAcquireTimeout,
+				obj.GetNamespace(), obj.GetName(), frozenBy, timeout)
+			return ctrl.Result{}
+		}
+		res.RequeueAfter = min(res.RequeueAfter, remaining)
+	}
+
+	r.markWaitingForOwnership(dfz, obj, frozenBy)
+	return res
+}
+
+// markWaitingForOwnership records the wait in the Ownership and WaitingForOwnership conditions
+// and reports it once with an event.
+func (r *DeploymentFreezerReconciler) markWaitingForOwnership(
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	obj client.Object,
+	frozenBy string,
+) {
+	if !hasCondition(
+		dfz,
+		freezerv1alpha1.ConditionTypeWaitingForOwnership,
+		freezerv1alpha1.ConditionStatusTrue,
+		freezerv1alpha1.ConditionReasonHeldByOtherOwner,
+	) {
+		countOwnershipConflict(dfz, conflictDenied)
+		r.Recorder.Eventf(dfz, corev1.EventTypeWarning, ReasonOwnershipDenied, msgOwnershipDenied,
+			obj.GetNamespace(), obj.GetName(), frozenBy)
+	}
+	setStableCondition(
+		dfz,
+		freezerv1alpha1.ConditionTypeOwnership,
+		freezerv1alpha1.ConditionStatusFalse,
+		freezerv1alpha1.ConditionReasonDeniedAlreadyFrozen,
+		fmt.Sprintf(msgDeploymentAlreadyOwnedFmt, frozenBy),
+	)
+	setStableCondition(
+		dfz,
+		freezerv1alpha1.ConditionTypeWaitingForOwnership,
+		freezerv1alpha1.ConditionStatusTrue,
+		freezerv1alpha1.ConditionReasonHeldByOtherOwner,
+		fmt.Sprintf(msgWaitingForOwnershipFmt, frozenBy),
+	)
+}
+
+// stopWaitingForOwnership flips WaitingForOwnership to False once the Deployment is free.
+func stopWaitingForOwnership(dfz *freezerv1alpha1.DeploymentFreezer) {
+	if hasCondition(
+		dfz,
+		freezerv1alpha1.ConditionTypeWaitingForOwnership,
+		freezerv1alpha1.ConditionStatusTrue,
+		freezerv1alpha1.ConditionReasonHeldByOtherOwner,
+	) {
+		setStableCondition(
+			dfz,
+			freezerv1alpha1.ConditionTypeWaitingForOwnership,
+			freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonOwnerReleased,
+			msgOwnershipFreed,
+		)
+	}
+}
+
+// ownershipReleased passes Deployment updates that remove the frozen-by annotation.
+var ownershipReleased = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		_, had := e.ObjectOld.GetAnnotations()[annoFrozenBy]
+		_, has := e.ObjectNew.GetAnnotations()[annoFrozenBy]
+		return had && !has
+	},
+}
+
+// ownerFinished passes DFZs that reach a terminal phase or are deleted.
+var ownerFinished = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return true },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldDFZ, okOld := e.ObjectOld.(*freezerv1alpha1.DeploymentFreezer)
+		newDFZ, okNew := e.ObjectNew.(*freezerv1alpha1.DeploymentFreezer)
+		return okOld && okNew && !isTerminalPhase(oldDFZ.Status.Phase) && isTerminalPhase(newDFZ.Status.Phase)
+	},
+}
+
+// ownershipWaiters maps a finished DFZ to the other DFZs waiting for the same Deployment.
+func (r *DeploymentFreezerReconciler) ownershipWaiters(ctx context.Context, obj client.Object) []reconcile.Request {
+	owner, ok := obj.(*freezerv1alpha1.DeploymentFreezer)
+	if !ok || owner.TargetName() == "" {
+		return nil
+	}
+	var list freezerv1alpha1.DeploymentFreezerList
+	if err := r.List(
+		ctx,
+		&list,
+		client.InNamespace(owner.Namespace),
+		client.MatchingFields{TargetNameIndex: owner.TargetName()},
+	); err != nil {
+		return nil
+	}
+
+	var reqs []reconcile.Request
+	for i := range list.Items {
+		waiter := &list.Items[i]
+		if waiter.UID == owner.UID || !r.Shard.Owns(waiter) || !hasCondition(
+			waiter,
+			freezerv1alpha1.ConditionTypeWaitingForOwnership,
+			freezerv1alpha1.ConditionStatusTrue,
+			freezerv1alpha1.ConditionReasonHeldByOtherOwner,
+		) {
+			continue
+		}
+		reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(waiter)})
+	}
+	return reqs
+}
@@ -0,0 +1,222 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestOwnershipWait(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	newDFZ := func(name, uid string, phase freezerv1alpha1.Phase) *freezerv1alpha1.DeploymentFreezer {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns", Name: name, UID: "uid-" + types.UID(uid),
+		}}
+		dfz.Spec.TargetRef.Name = "web"
+		dfz.Status.Phase = phase
+		return dfz
+	}
+	newReconciler := func(objs ...client.Object) (*DeploymentFreezerReconciler, *record.FakeRecorder) {
+		rec := record.NewFakeRecorder(10)
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+			WithStatusSubresource(&freezerv1alpha1.DeploymentFreezer{}).
+			WithIndex(&freezerv1alpha1.DeploymentFreezer{}, ".spec.targetRef.name", func(o client.Object) []string {
+				return []string{o.(*freezerv1alpha1.DeploymentFreezer).Spec.TargetRef.Name}
+			}).Build()
+		return &DeploymentFreezerReconciler{Client: c, APIReader: c, Recorder: rec}, rec
+	}
+	waiting := func(dfz *freezerv1alpha1.DeploymentFreezer) bool {
+		return hasCondition(dfz, freezerv1alpha1.ConditionTypeWaitingForOwnership,
+			freezerv1alpha1.ConditionStatusTrue, freezerv1alpha1.ConditionReasonHeldByOtherOwner)
+	}
+
+	t.Run("OwnershipHeld", func(t *testing.T) {
+		t.Parallel()
+		holder := newDFZ("holder", "1", freezerv1alpha1.PhaseFrozen)
+		done := newDFZ("done", "2", freezerv1alpha1.PhaseCompleted)
+		r, _ := newReconciler(holder, done)
+		ctx := context.Background()
+
+		for value, want := range map[string]bool{
+			frozenByValue(holder): true,
+			"ns/holder":           true,  // written before UIDs were included
+			"ns/holder/uid-old":   false, // holder was recreated
+			"ns/gone/uid-3":       false,
+			frozenByValue(done):   false,
+			"change-freeze":       true, // set by hand
+			"other/holder":        true,
+		} {
+			held, err := r.ownershipHeld(ctx, "ns", value)
+			require.NoError(t, err)
+			assert.Equal(t, want, held, value)
+		}
+	})
+
+	t.Run("HeldByActiveOwner_WaitsWithBoundedRequeue", func(t *testing.T) {
+		t.Parallel()
+		holder := newDFZ("holder", "1", freezerv1alpha1.PhaseFrozen)
+		waiter := newDFZ("waiter", "2", "")
+		deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns", Name: "web", Annotations: map[string]string{annoFrozenBy: frozenByValue(holder)},
+		}}
+		r, rec := newReconciler(holder, waiter, deploy)
+
+		res, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(waiter)})
+		require.NoError(t, err)
+		assert.Equal(t, ownershipRecheckInterval, res.RequeueAfter)
+
+		var got freezerv1alpha1.DeploymentFreezer
+		require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(waiter), &got))
+		assert.Equal(t, freezerv1alpha1.PhasePending, got.Status.Phase)
+		assert.True(t, waiting(&got))
+		assert.True(t, hasCondition(&got, freezerv1alpha1.ConditionTypeOwnership,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonDeniedAlreadyFrozen))
+		assert.Contains(t, <-rec.Events, ReasonOwnershipDenied)
+
+		// Waking up while still held records nothing new.
+		_, err = r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(waiter)})
+		require.NoError(t, err)
+		assert.Empty(t, rec.Events)
+	})
+
+	t.Run("Conflicts_CountedByOutcome", func(t *testing.T) {
+		t.Parallel()
+		ns := "ownership-conflicts"
+		conflicts := func(outcome string) float64 {
+			return testutil.ToFloat64(ownershipConflictsTotal.WithLabelValues(ns, outcome))
+		}
+		dfzIn := func(name, uid string, phase freezerv1alpha1.Phase) *freezerv1alpha1.DeploymentFreezer {
+			dfz := newDFZ(name, uid, phase)
+			dfz.Namespace = ns
+			return dfz
+		}
+		holder, waiter := dfzIn("holder", "1", freezerv1alpha1.PhaseFrozen), dfzIn("waiter", "2", "")
+		deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns, Name: "web", Annotations: map[string]string{annoFrozenBy: "rogue-automation"},
+		}}
+		r, rec := newReconciler(holder, waiter, deploy)
+		r.Clock = testingclock.NewFakeClock(time.Now())
+
+		for _, dfz := range []*freezerv1alpha1.DeploymentFreezer{holder, waiter, waiter} {
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(dfz)})
+			require.NoError(t, err)
+		}
+		assert.Equal(t, float64(1), conflicts(conflictTakenOver))
+		assert.Equal(t, float64(1), conflicts(conflictDenied), "a wait is counted once")
+		assert.Equal(t, []string{
+			"Warning OwnershipLost Ownership annotation lost or overwritten on Deployment ownership-conflicts/web; " +
+				"now owned by rogue-automation",
+			"Warning OwnershipDenied Deployment ownership-conflicts/web is already owned by rogue-automation",
+		}, drainEvents(rec))
+
+		var got freezerv1alpha1.DeploymentFreezer
+		require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(holder), &got))
+		assert.Equal(t, freezerv1alpha1.PhaseDenied, got.Status.Phase)
+		assert.True(t, hasCondition(&got, freezerv1alpha1.ConditionTypeOwnership,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonLost))
+	})
+
+	t.Run("AcquireTimeout", func(t *testing.T) {
+		t.Parallel()
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		holder := newDFZ("holder", "1", freezerv1alpha1.PhaseFrozen)
+		deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns", Name: "web", Annotations: map[string]string{annoFrozenBy: frozenByValue(holder)},
+		}}
+		newWaiter := func(pendingFor time.Duration) *freezerv1alpha1.DeploymentFreezer {
+			waiter := newDFZ("waiter", "2", freezerv1alpha1.PhasePending)
+			waiter.Spec.AcquireTimeoutSeconds = 600
+			waiter.Status.PhaseTransitionTimes = map[freezerv1alpha1.Phase]metav1.Time{
+				freezerv1alpha1.PhasePending: metav1.NewTime(now.Add(-pendingFor)),
+			}
+			return waiter
+		}
+
+		r, _ := newReconciler()
+		r.Clock = testingclock.NewFakeClock(now)
+		waiter := newWaiter(4 * time.Minute)
+		res := r.waitForOwnership(waiter, deploy, frozenByValue(holder))
+		assert.Equal(t, ownershipRecheckInterval, res.RequeueAfter)
+
+		waiter = newWaiter(8 * time.Minute)
+		res = r.waitForOwnership(waiter, deploy, frozenByValue(holder))
+		assert.Equal(t, 2*time.Minute, res.RequeueAfter)
+		assert.Equal(t, freezerv1alpha1.PhasePending, waiter.Status.Phase)
+		assert.True(t, waiting(waiter))
+
+		waiter = newWaiter(0)
+		waiter.Spec.AcquireTimeoutSeconds = 0
+		res = r.waitForOwnership(waiter, deploy, frozenByValue(holder))
+		assert.Equal(t, ownershipRecheckInterval, res.RequeueAfter, "waiting forever is still rechecked")
+
+		r, rec := newReconciler()
+		r.Clock = testingclock.NewFakeClock(now)
+		waiter = newWaiter(10 * time.Minute)
+		res = r.waitForOwnership(waiter, deploy, frozenByValue(holder))
+		assert.Zero(t, res)
+		assert.Equal(t, freezerv1alpha1.PhaseDenied, waiter.Status.Phase)
+		assert.True(t, hasCondition(waiter, freezerv1alpha1.ConditionTypeWaitingForOwnership,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonAcquireTimeout))
+		assert.Contains(t, <-rec.Events, ReasonAcquireTimeout)
+	})
+
+	t.Run("StopWaiting", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ("waiter", "1", freezerv1alpha1.PhasePending)
+		stopWaitingForOwnership(dfz)
+		assert.Empty(t, dfz.Status.Conditions, "never waited: no condition")
+
+		setCondition(dfz, freezerv1alpha1.ConditionTypeWaitingForOwnership, freezerv1alpha1.ConditionStatusTrue,
+			freezerv1alpha1.ConditionReasonHeldByOtherOwner, "waiting")
+		stopWaitingForOwnership(dfz)
+		assert.True(t, hasCondition(dfz, freezerv1alpha1.ConditionTypeWaitingForOwnership,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonOwnerReleased))
+	})
+
+	t.Run("OwnershipWaiters_OnlyWaitingSiblings", func(t *testing.T) {
+		t.Parallel()
+		holder := newDFZ("holder", "1", freezerv1alpha1.PhaseCompleted)
+		waiter := newDFZ("waiter", "2", freezerv1alpha1.PhasePending)
+		setCondition(waiter, freezerv1alpha1.ConditionTypeWaitingForOwnership, freezerv1alpha1.ConditionStatusTrue,
+			freezerv1alpha1.ConditionReasonHeldByOtherOwner, "waiting")
+		idle := newDFZ("idle", "3", freezerv1alpha1.PhaseCompleted)
+		r, _ := newReconciler(holder, waiter, idle)
+
+		reqs := r.ownershipWaiters(context.Background(), holder)
+		require.Len(t, reqs, 1)
+		assert.Equal(t, "waiter", reqs[0].Name)
+	})
+
+	t.Run("Predicates", func(t *testing.T) {
+		t.Parallel()
+		owned := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{annoFrozenBy: "ns/a"}}}
+		free := &appsv1.Deployment{}
+		assert.True(t, ownershipReleased.Update(event.UpdateEvent{ObjectOld: owned, ObjectNew: free}))
+		assert.False(t, ownershipReleased.Update(event.UpdateEvent{ObjectOld: free, ObjectNew: owned}))
+
+		frozen := newDFZ("a", "1", freezerv1alpha1.PhaseUnfreezing)
+		completed := newDFZ("a", "1", freezerv1alpha1.PhaseCompleted)
+		assert.True(t, ownerFinished.Update(event.UpdateEvent{ObjectOld: frozen, ObjectNew: completed}))
+		assert.False(t, ownerFinished.Update(event.UpdateEvent{ObjectOld: completed, ObjectNew: completed}))
+		assert.True(t, ownerFinished.Delete(event.DeleteEvent{Object: frozen}))
+	})
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// isPaused reports whether the DFZ carries the paused annotation.
+func isPaused(obj client.Object) bool {
+	return obj.GetAnnotations()[annoPaused] == "true"
+}
+
+// pauseToggled passes updates that add or remove the paused annotation; annotations do not
+// bump the generation, so the DFZ watch would otherwise miss them.
+var pauseToggled = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return isPaused(e.ObjectOld) != isPaused(e.ObjectNew)
+	},
+}
+
+// pause surfaces the paused annotation in the ReconciliationPaused condition. Nothing else is
+// touched: no phase transition, no Deployment patch, no finalizer change, no requeue.
+func (r *DeploymentFreezerReconciler) pause(ctx context.Context, dfz *freezerv1alpha1.DeploymentFreezer) error {
+	if hasCondition(
+		dfz,
+		freezerv1alpha1.ConditionTypeReconciliationPaused,
+		freezerv1alpha1.ConditionStatusTrue,
+		freezerv1alpha1.ConditionReasonPaused,
+	) {
+		return nil
+	}
+	st := newStatusTracker(dfz)
+	setStableCondition(
+		dfz,
+		freezerv1alpha1.ConditionTypeReconciliationPaused,
+		freezerv1alpha1.ConditionStatusTrue,
+		freezerv1alpha1.ConditionReasonPaused,
+		fmt.Sprintf(msgPausedFmt, annoPaused),
+	)
+	if err := r.commitStatus(ctx, dfz, st); err != nil {
+		return err
+	}
+	r.Recorder.Eventf(dfz, corev1.EventTypeNormal, ReasonReconciliationPaused, msgReconciliationPaused, annoPaused)
+	return nil
+}
+
+// resume flips ReconciliationPaused to False once the annotation is gone; DFZs that were never
+// paused do not get the condition.
+func (r *DeploymentFreezerReconciler) resume(dfz *freezerv1alpha1.DeploymentFreezer) {
+	if !hasCondition(
+		dfz,
+		freezerv1alpha1.ConditionTypeReconciliationPaused,
+		freezerv1alpha1.ConditionStatusTrue,
+		freezerv1alpha1.ConditionReasonPaused,
+	) {
+		return
+	}
+	setStableCondition(
+		dfz,
+		freezerv1alpha1.ConditionTypeReconciliationPaused,
+		freezerv1alpha1.ConditionStatusFalse,
+		freezerv1alpha1.ConditionReasonResumed,
+		msgResumed,
+	)
+	r.Recorder.Event(dfz, corev1.EventTypeNormal, ReasonReconciliationResumed, msgReconciliationResumed)
+}
@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestPause(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	newDFZ := func(paused bool) *freezerv1alpha1.DeploymentFreezer {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "freeze"}}
+		if paused {
+			dfz.Annotations = map[string]string{annoPaused: "true"}
+		}
+		dfz.Spec.TargetRef.Name = "web"
+		return dfz
+	}
+	pausedCondition := func(dfz *freezerv1alpha1.DeploymentFreezer) *freezerv1alpha1.Condition {
+		for i := range dfz.Status.Conditions {
+			if dfz.Status.Conditions[i].Type == freezerv1alpha1.ConditionTypeReconciliationPaused {
+				return &dfz.Status.Conditions[i]
+			}
+		}
+		return nil
+	}
+
+	t.Run("Paused_SkipsReconciliation", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ(true)
+		dfz.Status.Phase = freezerv1alpha1.PhasePending
+		deploy := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web"},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(3))},
+		}
+		rec := record.NewFakeRecorder(10)
+		r := &DeploymentFreezerReconciler{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(dfz, deploy).
+				WithStatusSubresource(&freezerv1alpha1.DeploymentFreezer{}).Build(),
+			Recorder: rec,
+		}
+
+		res, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(dfz)})
+		require.NoError(t, err)
+		assert.Zero(t, res)
+
+		var got freezerv1alpha1.DeploymentFreezer
+		require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(dfz), &got))
+		assert.Equal(t, freezerv1alpha1.PhasePending, got.Status.Phase)
+		assert.Empty(t, got.Finalizers)
+		c := pausedCondition(&got)
+		if assert.NotNil(t, c) {
+			assert.Equal(t, freezerv1alpha1.ConditionStatusTrue, c.Status)
+			assert.Equal(t, freezerv1alpha1.ConditionReasonPaused, c.Reason)
+		}
+		assert.Contains(t, <-rec.Events, ReasonReconciliationPaused)
+
+		var gotDeploy appsv1.Deployment
+		require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(deploy), &gotDeploy))
+		assert.Equal(t, int32(3), *gotDeploy.Spec.Replicas)
+		assert.Empty(t, gotDeploy.Annotations)
+
+		// A second pass while still paused writes and records nothing.
+		_, err = r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(dfz)})
+		require.NoError(t, err)
+		assert.Empty(t, rec.Events)
+	})
+
+	t.Run("Resume_FlipsCondition", func(t *testing.T) {
+		t.Parallel()
+		rec := record.NewFakeRecorder(10)
+		r := &DeploymentFreezerReconciler{Recorder: rec}
+
+		dfz := newDFZ(false)
+		r.resume(dfz)
+		assert.Nil(t, pausedCondition(dfz), "never paused: no condition")
+
+		setCondition(dfz, freezerv1alpha1.ConditionTypeReconciliationPaused, freezerv1alpha1.ConditionStatusTrue,
+			freezerv1alpha1.ConditionReasonPaused, "paused")
+		r.resume(dfz)
+		c := pausedCondition(dfz)
+		if assert.NotNil(t, c) {
+			assert.Equal(t, freezerv1alpha1.ConditionStatusFalse, c.Status)
+			assert.Equal(t, freezerv1alpha1.ConditionReasonResumed, c.Reason)
+		}
+		assert.Contains(t, <-rec.Events, ReasonReconciliationResumed)
+	})
+
+	t.Run("PauseToggled", func(t *testing.T) {
+		t.Parallel()
+		assert.True(t, pauseToggled.Update(event.UpdateEvent{ObjectOld: newDFZ(false), ObjectNew: newDFZ(true)}))
+		assert.True(t, pauseToggled.Update(event.UpdateEvent{ObjectOld: newDFZ(true), ObjectNew: newDFZ(false)}))
+		assert.False(t, pauseToggled.Update(event.UpdateEvent{ObjectOld: newDFZ(true), ObjectNew: newDFZ(true)}))
+	})
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=list
+
+// blockingPDBs describes the PodDisruptionBudgets selecting the target's Pods, with their
+// disruptionsAllowed, when at least one of them allows no disruption; otherwise it returns "".
+// A scale-down is not an eviction, so PDBs only block it through an admission policy enforcing
+// them; without such a budget the failure has another cause.
+func (r *DeploymentFreezerReconciler) blockingPDBs(ctx context.Context, obj client.Object) (string, error) {
+	tpl := podTemplate(obj)
+	if tpl == nil || len(tpl.Labels) == 0 {
+		return "", nil
+	}
+	podLabels := labels.Set(tpl.Labels)
+	var pdbs policyv1.PodDisruptionBudgetList
+	if err := r.APIReader.List(ctx, &pdbs, client.InNamespace(obj.GetNamespace())); err != nil {
+		return "", err
+	}
+
+	var matching []string
+	blocking := false
+	for i := range pdbs.Items {
+		pdb := &pdbs.Items[i]
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || selector.Empty() || !selector.Matches(podLabels) {
+			continue
+		}
+		matching = append(matching, fmt.Sprintf(msgPDBFmt, pdb.Name, pdb.Status.DisruptionsAllowed))
+		blocking = blocking || pdb.Status.DisruptionsAllowed == 0
+	}
+	if !blocking {
+		return "", nil
+	}
+	return strings.Join(matching, ", "), nil
+}
+
+// podTemplate returns the template of the Pods a target workload creates, or nil if it has none.
+// The template of an unstructured target is a copy.
+func podTemplate(obj client.Object) *corev1.PodTemplateSpec {
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		return &o.Spec.Template
+	case *appsv1.ReplicaSet:
+		return &o.Spec.Template
+	case *corev1.ReplicationController:
+		return o.Spec.Template
+	case *appsv1.DaemonSet:
+		return &o.Spec.Template
+	case *unstructured.Unstructured:
+		var tpl corev1.PodTemplateSpec
+		if fromNested(o, &tpl, "spec", "template") {
+			return &tpl
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestScaleDownBlocked(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	appLabels := map[string]string{"app": "web"}
+	newDeployment := func() *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To(int32(3)),
+				Selector: &metav1.LabelSelector{MatchLabels: appLabels},
+				Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: appLabels}},
+			},
+		}
+	}
+	pdb := func(name string, selector map[string]string, allowed int32) *policyv1.PodDisruptionBudget {
+		return &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+			Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: selector}},
+			Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: allowed},
+		}
+	}
+	// run reconciles a DFZ whose scale-down is refused by the API server.
+	run := func(t *testing.T, objs ...client.Object) (*freezerv1alpha1.DeploymentFreezer, *record.FakeRecorder) {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "freeze", UID: "dfz-uid"}}
+		dfz.Spec.TargetRef = freezerv1alpha1.DeploymentTargetRef{Name: "web"}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objs, dfz, newDeployment())...).
+			WithStatusSubresource(&freezerv1alpha1.DeploymentFreezer{}).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if _, ok := obj.(*appsv1.Deployment); ok {
+						return errors.New("admission webhook denied the request")
+					}
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			}).Build()
+		rec := record.NewFakeRecorder(20)
+		r := &DeploymentFreezerReconciler{
+			Client:    c,
+			APIReader: c,
+			Recorder:  rec,
+			Clock:     testingclock.NewFakeClock(time.Now()),
+		}
+		for range 2 {
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(dfz)})
+			require.NoError(t, err)
+		}
+		var got freezerv1alpha1.DeploymentFreezer
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(dfz), &got))
+		return &got, rec
+	}
+	progress := func(t *testing.T, dfz *freezerv1alpha1.DeploymentFreezer) freezerv1alpha1.Condition {
+		for _, c := range dfz.Status.Conditions {
+			if c.Type == freezerv1alpha1.ConditionTypeFreezeProgress {
+				return c
+			}
+		}
+		require.Fail(t, "no FreezeProgress condition")
+		return freezerv1alpha1.Condition{}
+	}
+
+	t.Run("ExhaustedPDB_NamedInConditionAndEvent", func(t *testing.T) {
+		t.Parallel()
+		got, rec := run(t,
+			pdb("web-pdb", appLabels, 0),
+			pdb("web-extra", map[string]string{"app": "web"}, 1),
+			pdb("other", map[string]string{"app": "other"}, 0),
+		)
+
+		c := progress(t, got)
+		assert.Equal(t, freezerv1alpha1.ConditionReasonAwaitingPDB, c.Reason)
+		assert.Equal(t, "scale-down blocked by PodDisruptionBudgets web-extra (disruptionsAllowed 1), "+
+			"web-pdb (disruptionsAllowed 0): admission webhook denied the request", c.Message)
+		events := drainEvents(rec)
+		assert.Contains(t, events, "Warning AwaitingPDB Scale-down blocked by PodDisruptionBudgets: "+
+			"web-extra (disruptionsAllowed 1), web-pdb (disruptionsAllowed 0)")
+		var n int
+		for _, e := range events {
+			if strings.HasPrefix(e, "Warning AwaitingPDB") {
+				n++
+			}
+		}
+		assert.Equal(t, 1, n, "one event while the scale-down stays blocked")
+	})
+
+	t.Run("NoExhaustedPDB_ScaleDownFailed", func(t *testing.T) {
+		t.Parallel()
+		got, _ := run(t, pdb("web-pdb", appLabels, 2))
+
+		c := progress(t, got)
+		assert.Equal(t, freezerv1alpha1.ConditionReasonScaleDownFailed, c.Reason)
+		assert.Equal(t, "cannot scale down yet: admission webhook denied the request", c.Message)
+	})
+}
+
+// drainEvents returns the events recorded so far.
+func drainEvents(rec *record.FakeRecorder) []string {
+	var out []string
+	for {
+		select {
+		case e := <-rec.Events:
+			out = append(out, e)
+		default:
+			return out
+		}
+	}
+}
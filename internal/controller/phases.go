@@ -2,14 +2,20 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/internal/policy"
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 // handlePendingOrFreezing acquires ownership and scales down to zero.
@@ -19,50 +25,130 @@ func (r *DeploymentFreezerReconciler) handlePendingOrFreezing(
 	ctx context.Context,
 	dfz *freezerv1alpha1.DeploymentFreezer,
 	deploy *appsv1.Deployment,
+	target freeze.Freezable,
 ) (ctrl.Result, error) {
-	owner := fmt.Sprintf("%s/%s", dfz.Namespace, dfz.Name)
-	if _, ok := deploy.Annotations[annoFrozenBy]; !ok {
-		if err := r.patchDeploymentAnno(ctx, deploy, annoFrozenBy, owner); err != nil {
-			setCondition(
-				dfz,
-				freezerv1alpha1.ConditionTypeHealth,
-				freezerv1alpha1.ConditionStatusFalse,
-				freezerv1alpha1.ConditionReasonAPIConflict,
-				fmt.Sprintf(msgCannotScaleDownYetFmt, err),
-			)
+	policyMax, allowed, err := r.checkPolicy(ctx, dfz)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: requeueShort}, nil
+	}
+	if !allowed {
+		return ctrl.Result{}, nil
+	}
+	if !r.checkKillSwitch(ctx, dfz) {
+		return ctrl.Result{RequeueAfter: requeueMedium}, nil
+	}
+	if res, held := r.holdForBlackout(ctx, dfz); held {
+		return res, nil
+	}
+
+	// The ownership claim, the rollout pause and the scale-down are sent as one patch below.
+	claim := !isFrozenBy(target.Owner(), dfz)
+
+	// Record original replicas (prefer positive values; fall back to default)
+	if dfz.Status.OriginalReplicas == nil {
+		dfz.Status.OriginalReplicas = ptr.To(freeze.RestoreReplicas(target))
+	}
+
+	// Snapshot the autoscaling context once, then keep KEDA and the VPA from acting on the target.
+	if dfz.Status.Snapshot == nil {
+		snap, err := target.Snapshot(ctx)
+		if err != nil {
+			r.operationFailed(dfz, opSnapshot, fmt.Sprintf(msgSnapshotFailedFmt, err))
 			return ctrl.Result{RequeueAfter: requeueShort}, nil
 		}
+		dfz.Status.Snapshot = snap
+	}
+	if err := r.freezer().PauseAutoscaling(ctx, dfz.Namespace, dfz.Status.Snapshot, r.patchOpts(dfz)...); err != nil {
+		r.operationFailed(dfz, opPauseAutoscaling, fmt.Sprintf(msgCannotPauseAutoscalingFmt, err))
+		return ctrl.Result{RequeueAfter: requeueShort}, nil
+	}
+
+	// Pause rollouts so nothing queued during the freeze ships on restore.
+	// Lean RBAC mode has no rights on the Deployment spec, so this is reported instead.
+	pause := false
+	pausable, canPause := target.(freeze.Pausable)
+	if dfz.Spec.PauseRollout && r.LeanRBAC {
 		setCondition(
 			dfz,
-			freezerv1alpha1.ConditionTypeOwnership,
-			freezerv1alpha1.ConditionStatusTrue,
-			freezerv1alpha1.ConditionReasonAcquired,
-			fmt.Sprintf(msgOwnershipAcquiredFmt, dfz.Name, deploy.Namespace, deploy.Name),
+			freezerv1alpha1.ConditionTypeHealth,
+			freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonRBACDenied,
+			msgPauseRolloutNeedsSpecAccess,
 		)
+	} else if dfz.Spec.PauseRollout && canPause {
+		pause = !pausable.Paused()
+		// Only a pause set by the freeze is undone on unfreeze.
+		if pause && dfz.Status.OriginalPaused == nil {
+			dfz.Status.OriginalPaused = ptr.To(false)
+		}
 	}
 
-	// Record original replicas (prefer positive values; fall back to default)
-	if dfz.Status.OriginalReplicas == nil {
-		replicas := defaultReplicasCount
-		if deploy.Spec.Replicas != nil && *deploy.Spec.Replicas > 0 {
-			replicas = *deploy.Spec.Replicas
-		}
-		dfz.Status.OriginalReplicas = &replicas
+	// Traffic is diverted before the Pods go away.
+	if res, wait := r.divertTraffic(ctx, dfz, deploy); wait {
+		return res, nil
 	}
 
 	// Scale to zero
-	if deploy.Spec.Replicas == nil || *deploy.Spec.Replicas != 0 {
-		if err := r.patchDeploymentReplicas(ctx, deploy, 0); err != nil {
+	scale := target.GetReplicas() != 0
+	if claim || pause || scale {
+		if err := r.freezeTarget(ctx, dfz, target, claim, pause, scale); err != nil {
+			if errors.Is(err, freeze.ErrFrozenByOther) || errors.Is(err, freeze.ErrClaimReleased) {
+				// The target was read before another DFZ claimed it; the next read shows the holder.
+				op := opFreezeTarget
+				if claim {
+					op = opAcquireOwnership
+				}
+				r.operationFailed(dfz, op, fmt.Sprintf(msgClaimRaceLostFmt, err))
+				return ctrl.Result{RequeueAfter: requeueShort}, nil
+			}
+			if !claim {
+				r.setPhase(dfz, freezerv1alpha1.PhaseFreezing)
+			}
+			if !scale {
+				msg := fmt.Sprintf(msgCannotScaleDownYetFmt, err)
+				if pause {
+					msg = fmt.Sprintf(msgCannotPauseRolloutFmt, err)
+				}
+				op := opFreezeTarget
+				if claim {
+					op = opAcquireOwnership
+				}
+				r.operationFailed(dfz, op, msg)
+				return ctrl.Result{RequeueAfter: requeueShort}, nil
+			}
+			msg := fmt.Sprintf(msgCannotScaleDownYetFmt, err)
+			reason := freezerv1alpha1.ConditionReasonScaleDownFailed
+			if pdbs, pdbErr := r.blockingPDBs(ctx, target.Object()); pdbErr != nil {
+				log.FromContext(ctx).Error(pdbErr, "failed to list PodDisruptionBudgets", "namespace", dfz.Namespace)
+			} else if pdbs != "" {
+				reason = freezerv1alpha1.ConditionReasonAwaitingPDB
+				msg = fmt.Sprintf(msgBlockedByPDBsFmt, pdbs, err)
+				if !hasCondition(dfz, freezerv1alpha1.ConditionTypeFreezeProgress,
+					freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonAwaitingPDB) {
+					r.Recorder.Eventf(dfz, corev1.EventTypeWarning, ReasonAwaitingPDB, msgAwaitingPDB, pdbs)
+				}
+			}
 			setCondition(
 				dfz,
 				freezerv1alpha1.ConditionTypeFreezeProgress,
 				freezerv1alpha1.ConditionStatusFalse,
-				freezerv1alpha1.ConditionReasonAwaitingPDB,
-				fmt.Sprintf(msgCannotScaleDownYetFmt, err),
+				reason,
+				msg,
 			)
-			setPhase(dfz, freezerv1alpha1.PhaseFreezing)
+			r.recordError(dfz, opFreezeTarget, msg)
 			return ctrl.Result{RequeueAfter: requeueMedium}, nil
 		}
+		if claim {
+			setCondition(
+				dfz,
+				freezerv1alpha1.ConditionTypeOwnership,
+				freezerv1alpha1.ConditionStatusTrue,
+				freezerv1alpha1.ConditionReasonAcquired,
+				fmt.Sprintf(msgOwnershipAcquiredFmt, dfz.Name, target.Object().GetNamespace(), target.Object().GetName()),
+			)
+		}
+	}
+	if scale {
 		setCondition(
 			dfz,
 			freezerv1alpha1.ConditionTypeFreezeProgress,
@@ -70,15 +156,58 @@ func (r *DeploymentFreezerReconciler) handlePendingOrFreezing(
 			freezerv1alpha1.ConditionReasonScalingDown,
 			msgScalingDeploymentToZero,
 		)
-		setPhase(dfz, freezerv1alpha1.PhaseFreezing)
+		r.setPhase(dfz, freezerv1alpha1.PhaseFreezing)
 		return ctrl.Result{RequeueAfter: requeueShort}, nil
 	}
 
 	// Spec is 0; verify the Deployment is effectively at zero (no replicas running/ready/available/updated).
-	if deploy.Status.Replicas == 0 &&
-		deploy.Status.ReadyReplicas == 0 &&
-		deploy.Status.AvailableReplicas == 0 &&
-		deploy.Status.UpdatedReplicas == 0 {
+	if target.Drained() {
+		// The counters lag behind the kubelet and miss orphaned Pods, so look for Pods too.
+		remaining, err := r.remainingPods(ctx, target.Object())
+		if err != nil {
+			r.operationFailed(dfz, opRead, fmt.Sprintf(msgListPodsFailedFmt, err))
+			return ctrl.Result{RequeueAfter: requeueShort}, nil
+		}
+		if remaining != "" {
+			requeue := requeueShort
+			since, ok := dfz.Status.PhaseTransitionTimes[freezerv1alpha1.PhaseFreezing]
+			if ok && !r.Clock.Now().Before(since.Add(podsRemainingTimeout)) {
+				// Never Frozen while a Pod may still serve traffic; report it and keep polling slower.
+				if !hasCondition(dfz, freezerv1alpha1.ConditionTypeHealth,
+					freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonDegraded) {
+					r.Recorder.Eventf(dfz, corev1.EventTypeWarning, ReasonPodsRemaining, msgPodsRemainingTimeout,
+						podsRemainingTimeout, remaining)
+				}
+				setCondition(
+					dfz,
+					freezerv1alpha1.ConditionTypeHealth,
+					freezerv1alpha1.ConditionStatusFalse,
+					freezerv1alpha1.ConditionReasonDegraded,
+					fmt.Sprintf(msgPodsOutlivedTimeoutFmt, podsRemainingTimeout),
+				)
+				requeue = requeueMedium
+			}
+			setCondition(
+				dfz,
+				freezerv1alpha1.ConditionTypeFreezeProgress,
+				freezerv1alpha1.ConditionStatusFalse,
+				freezerv1alpha1.ConditionReasonPodsRemaining,
+				remaining,
+			)
+			r.setPhase(dfz, freezerv1alpha1.PhaseFreezing)
+			return ctrl.Result{RequeueAfter: requeue}, nil
+		}
+		if hasCondition(dfz, freezerv1alpha1.ConditionTypeHealth,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonDegraded) {
+			setCondition(
+				dfz,
+				freezerv1alpha1.ConditionTypeHealth,
+				freezerv1alpha1.ConditionStatusTrue,
+				freezerv1alpha1.ConditionReasonNormal,
+				msgPodsGone,
+			)
+		}
+
 		setCondition(
 			dfz,
 			freezerv1alpha1.ConditionTypeFreezeProgress,
@@ -86,13 +215,24 @@ func (r *DeploymentFreezerReconciler) handlePendingOrFreezing(
 			freezerv1alpha1.ConditionReasonScaledToZero,
 			msgDeploymentFullyScaledToZero,
 		)
-		setPhase(dfz, freezerv1alpha1.PhaseFrozen)
-		until := r.now().Add(time.Duration(dfz.Spec.DurationSeconds) * time.Second)
-		t := metav1.NewTime(until)
+		r.setPhase(dfz, freezerv1alpha1.PhaseFrozen)
+		observeTimeToFrozen(dfz, r.Clock.Now())
+		if dfz.Status.OriginalReplicas != nil {
+			dfz.Status.ResourcesFreed = resourcesFreed(target.Object(), *dfz.Status.OriginalReplicas)
+		}
+		duration, clamped := freezeDuration(dfz.Spec.RequestedDuration(), r.DefaultDuration, policy.Strictest(r.maxDuration(dfz), policyMax))
+		if clamped {
+			r.Recorder.Eventf(dfz, corev1.EventTypeWarning, ReasonDurationClamped, msgDurationClamped,
+				dfz.Spec.RequestedDuration(), duration)
+		}
+		t := metav1.NewTime(r.Clock.Now().UTC().Add(duration))
 		dfz.Status.FreezeUntil = &t
+		r.syncFreezeGroup(ctx, dfz)
+		until := dfz.Status.FreezeUntil.Time
+		r.heartbeat(dfz)
 
 		r.Recorder.Eventf(dfz, corev1.EventTypeNormal, ReasonFrozen, msgFrozenUntil, until.UTC().Format(time.RFC3339))
-		return ctrl.Result{RequeueAfter: time.Until(until)}, nil
+		return ctrl.Result{RequeueAfter: r.untilTime(until)}, nil
 	}
 
 	// Still draining/terminating: stay in Freezing until status catches up.
@@ -103,54 +243,308 @@ func (r *DeploymentFreezerReconciler) handlePendingOrFreezing(
 		freezerv1alpha1.ConditionReasonScalingDown,
 		msgWaitingDeploymentReachZero,
 	)
-	setPhase(dfz, freezerv1alpha1.PhaseFreezing)
+	r.setPhase(dfz, freezerv1alpha1.PhaseFreezing)
 	return ctrl.Result{RequeueAfter: requeueShort}, nil
 }
 
 // handleFrozen waits until unfreeze time; keeps the resource in Frozen phase until time elapses.
-func (r *DeploymentFreezerReconciler) handleFrozen(dfz *freezerv1alpha1.DeploymentFreezer) ctrl.Result {
+// Meanwhile the Deployment is re-checked at least every driftCheckInterval for drift, and the
+// heartbeat is refreshed.
+//
+//nolint:unparam // error result is currently always nil; keep signature for symmetry
+func (r *DeploymentFreezerReconciler) handleFrozen(
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	_ *appsv1.Deployment,
+	target freeze.Freezable,
+) (ctrl.Result, error) {
+	r.heartbeat(dfz)
+	r.labelFrozen(ctx, dfz, target)
+	r.checkRestart(dfz, target)
+	r.resizeFreezeWindow(ctx, dfz)
+	r.syncFreezeGroup(ctx, dfz)
+	woken := wakeRequested(dfz)
 	// Be defensive: FreezeUntil should be set once the Deployment is fully scaled to zero.
-	if dfz.Status.FreezeUntil != nil && r.now().Before(dfz.Status.FreezeUntil.Time) {
-		return ctrl.Result{RequeueAfter: time.Until(dfz.Status.FreezeUntil.Time)}
+	if !woken && dfz.Status.FreezeUntil != nil && r.Clock.Now().Before(dfz.Status.FreezeUntil.Time) {
+		r.checkDrift(ctx, dfz, target)
+		return ctrl.Result{RequeueAfter: min(r.untilTime(dfz.Status.FreezeUntil.Time), driftCheckInterval)}, nil
+	}
+	if res, held := r.holdForBlackout(ctx, dfz); held {
+		r.checkDrift(ctx, dfz, target)
+		return res, nil
+	}
+	if res, deferred := r.deferUnfreeze(ctx, dfz, target); deferred {
+		return res, nil
 	}
 
-	setPhase(dfz, freezerv1alpha1.PhaseUnfreezing)
-	r.Recorder.Eventf(dfz, corev1.EventTypeNormal, ReasonUnfreezingStarted, msgUnfreezingStarted)
-	return ctrl.Result{RequeueAfter: requeueShort}
+	r.setPhase(dfz, freezerv1alpha1.PhaseUnfreezing)
+	if woken {
+		r.Recorder.Eventf(dfz, corev1.EventTypeNormal, ReasonUnfreezingStarted, msgWokenByRequest,
+			dfz.Annotations[freezerv1alpha1.AnnoWakeRequested])
+	} else {
+		r.Recorder.Eventf(dfz, corev1.EventTypeNormal, ReasonUnfreezingStarted, msgUnfreezingStarted)
+	}
+	return ctrl.Result{RequeueAfter: requeueShort}, nil
+}
+
+// heartbeat refreshes status.lastHeartbeatTime once it is heartbeatInterval old, so a Frozen DFZ
+// shows that a controller is still watching it without a status write on every drift check.
+func (r *DeploymentFreezerReconciler) heartbeat(dfz *freezerv1alpha1.DeploymentFreezer) {
+	now := r.Clock.Now()
+	if last := dfz.Status.LastHeartbeatTime; last != nil && now.Sub(last.Time) < heartbeatInterval {
+		return
+	}
+	dfz.Status.LastHeartbeatTime = &metav1.Time{Time: now}
+}
+
+// resizeFreezeWindow moves FreezeUntil when the spec duration was changed while frozen, so a
+// freeze can be extended or shortened in place. The window keeps starting when the DFZ became Frozen.
+// A freeze group member resizes its own deadline; the group deadline follows in syncFreezeGroup.
+func (r *DeploymentFreezerReconciler) resizeFreezeWindow(ctx context.Context, dfz *freezerv1alpha1.DeploymentFreezer) {
+	frozenAt, ok := dfz.Status.PhaseTransitionTimes[freezerv1alpha1.PhaseFrozen]
+	own := memberFreezeUntil(dfz)
+	if !ok || own == nil {
+		return
+	}
+	// Status times are stored with second precision.
+	current := own.Sub(frozenAt.Time)
+	unchanged := func(d time.Duration) bool { return (d - current).Abs() < time.Second }
+
+	if d, _ := freezeDuration(dfz.Spec.RequestedDuration(), r.DefaultDuration, r.maxDuration(dfz)); unchanged(d) {
+		return
+	}
+	policyMax, _, err := r.checkPolicy(ctx, dfz)
+	if err != nil {
+		return
+	}
+	duration, clamped := freezeDuration(dfz.Spec.RequestedDuration(), r.DefaultDuration, policy.Strictest(r.maxDuration(dfz), policyMax))
+	if unchanged(duration) {
+		return
+	}
+	if clamped {
+		r.Recorder.Eventf(dfz, corev1.EventTypeWarning, ReasonDurationClamped, msgDurationClamped,
+			dfz.Spec.RequestedDuration(), duration)
+	}
+	until := metav1.NewTime(frozenAt.Add(duration))
+	if dfz.Status.MemberFreezeUntil != nil {
+		dfz.Status.MemberFreezeUntil = &until
+	} else {
+		dfz.Status.FreezeUntil = &until
+	}
+	r.Recorder.Eventf(dfz, corev1.EventTypeNormal, ReasonFreezeWindowChanged, msgFreezeWindowChanged,
+		until.UTC().Format(time.RFC3339))
 }
 
-// handleUnfreezing restores replicas and releases ownership.
+// labelFrozen adds the frozen label to a target claimed by a version that did not set it yet.
+// The claim is rewritten with the owner it already has; the label follows it on release.
+func (r *DeploymentFreezerReconciler) labelFrozen(
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	target freeze.Freezable,
+) {
+	if r.dryRun(dfz) || !isFrozenBy(target.Owner(), dfz) || target.Object().GetLabels()[freeze.LabelFrozen] == "true" {
+		return
+	}
+	if err := target.AcquireOwnership(ctx, target.Owner()); err != nil {
+		r.operationFailed(dfz, opAcquireOwnership, fmt.Sprintf(msgCannotLabelFrozenFmt, err))
+	}
+}
+
+// checkDrift verifies the frozen target still carries our ownership annotation and zero replicas.
+// Drift is only reported (DriftDetected condition and metric); a new occurrence is counted once.
+// Replica drift names the field manager that last set the replicas, when it is known.
+func (r *DeploymentFreezerReconciler) checkDrift(
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	target freeze.Freezable,
+) {
+	var reason freezerv1alpha1.ConditionReason
+	var msg string
+	switch replicas := target.GetReplicas(); {
+	case !isFrozenBy(target.Owner(), dfz):
+		reason, msg = freezerv1alpha1.ConditionReasonAnnotationDrift,
+			fmt.Sprintf(msgAnnotationDriftFmt, annoFrozenBy, frozenByValue(dfz))
+	case replicas != 0:
+		reason, msg = freezerv1alpha1.ConditionReasonReplicasDrift, fmt.Sprintf(msgReplicasDriftFmt, replicas)
+		if actor := r.driftActor(ctx, target.Object()); actor != "" {
+			msg = fmt.Sprintf(msgDriftActorFmt, msg, actor)
+		}
+	default:
+		setStableCondition(
+			dfz,
+			freezerv1alpha1.ConditionTypeDriftDetected,
+			freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonNoDrift,
+			msgNoDrift,
+		)
+		return
+	}
+
+	if !hasCondition(dfz, freezerv1alpha1.ConditionTypeDriftDetected, freezerv1alpha1.ConditionStatusTrue, reason) {
+		driftDetectedTotal.WithLabelValues(dfz.Namespace, string(reason)).Inc()
+		r.Recorder.Event(dfz, corev1.EventTypeWarning, ReasonDriftDetected, msg)
+		if reason == freezerv1alpha1.ConditionReasonAnnotationDrift {
+			r.ownershipLost(dfz, target.Object(), target.Owner())
+		}
+	}
+	setStableCondition(dfz, freezerv1alpha1.ConditionTypeDriftDetected, freezerv1alpha1.ConditionStatusTrue, reason, msg)
+}
+
+// checkRestart reports a `kubectl rollout restart` of the frozen target: it only changes the pod
+// template, which rolls out nothing at zero replicas. Each new restart is announced once.
+// DFZs created before the restarted-at annotation was recorded have no baseline and are skipped.
+func (r *DeploymentFreezerReconciler) checkRestart(dfz *freezerv1alpha1.DeploymentFreezer, target freeze.Freezable) {
+	baseline, ok := dfz.Annotations[annoRestartedAt]
+	current := restartedAt(target.Object())
+	if !ok || current == "" || current == baseline {
+		return
+	}
+	msg := fmt.Sprintf(msgRestartRequestedFmt, current)
+	for _, c := range dfz.Status.Conditions {
+		if c.Type == freezerv1alpha1.ConditionTypeRestartRequested && c.Message == msg {
+			return
+		}
+	}
+	r.Recorder.Eventf(dfz, corev1.EventTypeWarning, ReasonRestartDeferred, msgRestartDeferred, current)
+	setCondition(
+		dfz,
+		freezerv1alpha1.ConditionTypeRestartRequested,
+		freezerv1alpha1.ConditionStatusTrue,
+		freezerv1alpha1.ConditionReasonRolloutRestart,
+		msg,
+	)
+}
+
+// handleUnfreezing restores replicas and releases ownership. The canary, GitOps and
+// post-unfreeze steps are Deployment-specific; the restore itself goes through target.
 //
 //nolint:unparam // error result is currently always nil; keep signature for symmetry
 func (r *DeploymentFreezerReconciler) handleUnfreezing(
 	ctx context.Context,
 	dfz *freezerv1alpha1.DeploymentFreezer,
 	deploy *appsv1.Deployment,
+	target freeze.Freezable,
 ) (ctrl.Result, error) {
+	ready, available := readyReplicas(target.Object())
+	dfz.Status.RestoreProgress = &freezerv1alpha1.RestoreProgress{
+		Replicas:          ptr.Deref(dfz.Status.OriginalReplicas, 0),
+		ReadyReplicas:     ready,
+		AvailableReplicas: available,
+	}
+	if dfz.Status.PostUnfreeze != nil && dfz.Status.PostUnfreeze.RestoredAt != nil {
+		return r.observePostUnfreeze(ctx, dfz, deploy), nil
+	}
+	if res, held := r.holdForBlackout(ctx, dfz); held {
+		return res, nil
+	}
+
+	if dfz.Spec.GitOpsMode {
+		return r.handleGitOpsUnfreeze(ctx, dfz, deploy), nil
+	}
+
 	// Restore from the recorded original replicas; the current spec is 0 while frozen.
 	targetReplicas := *dfz.Status.OriginalReplicas
-	if err := r.patchDeploymentReplicas(ctx, deploy, targetReplicas); err != nil {
+	if target.GetReplicas() == 0 {
+		if wait := r.unfreezeDelay(); wait > 0 {
+			setCondition(
+				dfz,
+				freezerv1alpha1.ConditionTypeUnfreezeProgress,
+				freezerv1alpha1.ConditionStatusFalse,
+				freezerv1alpha1.ConditionReasonThrottled,
+				msgUnfreezeThrottled,
+			)
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
+		admitted, err := r.acquireUnfreezeSlot(ctx, dfz)
+		if err != nil {
+			r.operationFailed(dfz, opRead, fmt.Sprintf(msgListFreezersFailedFmt, err))
+			return ctrl.Result{RequeueAfter: requeueShort}, nil
+		}
+		if !admitted {
+			setCondition(
+				dfz,
+				freezerv1alpha1.ConditionTypeUnfreezeProgress,
+				freezerv1alpha1.ConditionStatusFalse,
+				freezerv1alpha1.ConditionReasonThrottled,
+				fmt.Sprintf(msgUnfreezeConcurrencyFmt, r.MaxConcurrentUnfreezes),
+			)
+			return ctrl.Result{RequeueAfter: requeueMedium}, nil
+		}
+		if r.MaxConcurrentUnfreezes > 0 {
+			setStableCondition(
+				dfz,
+				freezerv1alpha1.ConditionTypeUnfreezeProgress,
+				freezerv1alpha1.ConditionStatusFalse,
+				freezerv1alpha1.ConditionReasonScalingUp,
+				msgUnfreezeScalingUp,
+			)
+		}
+	}
+	if canaryUnfreeze(dfz) && targetReplicas > canaryReplicas && ptr.Deref(deploy.Spec.Replicas, 1) < targetReplicas {
+		if res, done := r.runCanary(ctx, dfz, deploy); !done {
+			return res, nil
+		}
+	}
+	if paused, err := r.restoreTarget(ctx, dfz, target, targetReplicas); err != nil {
+		msg := fmt.Sprintf(msgFailedRestoreReplicasFmt, targetReplicas, err)
+		if paused != nil {
+			msg = fmt.Sprintf(msgFailedRestoreReplicasPausedFmt, targetReplicas, *paused, err)
+		}
 		setCondition(
 			dfz,
 			freezerv1alpha1.ConditionTypeUnfreezeProgress,
 			freezerv1alpha1.ConditionStatusFalse,
 			freezerv1alpha1.ConditionReasonQuotaExceeded,
-			fmt.Sprintf(msgFailedRestoreReplicasFmt, targetReplicas, err),
+			msg,
 		)
+		r.recordError(dfz, opRestoreReplicas, msg)
 		return ctrl.Result{RequeueAfter: requeueMedium}, nil
 	}
 
-	if err := r.patchDeploymentAnno(ctx, deploy, annoFrozenBy, ""); err != nil {
-		setCondition(
-			dfz,
-			freezerv1alpha1.ConditionTypeHealth,
-			freezerv1alpha1.ConditionStatusFalse,
-			freezerv1alpha1.ConditionReasonAPIConflict,
-			fmt.Sprintf(msgFailedClearOwnershipFmt, err),
-		)
+	// Ownership is released only once the whole snapshot is back in place.
+	if err := target.Restore(ctx, dfz.Status.Snapshot, r.patchOpts(dfz)...); err != nil {
+		r.operationFailed(dfz, opRestoreAutoscaling, fmt.Sprintf(msgFailedRestoreAutoscalingFmt, err))
 		return ctrl.Result{RequeueAfter: requeueShort}, nil
 	}
+	if res, wait := r.restoreTraffic(ctx, dfz, deploy, targetReplicas); wait {
+		return res, nil
+	}
+	if r.awaitAvailable(dfz, target.Object(), targetReplicas) {
+		return ctrl.Result{RequeueAfter: requeueShort}, nil
+	}
+
+	if err := target.AcquireOwnership(ctx, "", r.patchOpts(dfz)...); err != nil {
+		r.operationFailed(dfz, opReleaseOwnership, fmt.Sprintf(msgFailedClearOwnershipFmt, err))
+		return ctrl.Result{RequeueAfter: requeueShort}, nil
+	}
+
+	return r.releaseAfterUnfreeze(dfz, target.Object(), targetReplicas), nil
+}
+
+// unfreezeDelay takes a token from the shared unfreeze limiter. It returns 0 if the
+// scale-up may start now, or how long to wait before trying again.
+func (r *DeploymentFreezerReconciler) unfreezeDelay() time.Duration {
+	if r.UnfreezeLimiter == nil {
+		return 0
+	}
+	res := r.UnfreezeLimiter.Reserve()
+	if d := res.Delay(); d > 0 {
+		// Give the token back; the DFZ competes again when it is requeued.
+		res.Cancel()
+		return d
+	}
+	return 0
+}
 
+// releaseAfterUnfreeze records that replicas are back and ownership was released, then either
+// starts the post-unfreeze observation or completes the DFZ.
+func (r *DeploymentFreezerReconciler) releaseAfterUnfreeze(
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	obj client.Object,
+	targetReplicas int32,
+) ctrl.Result {
+	observeUnfreezeLatency(dfz, r.Clock.Now())
+	observeSavings(dfz, obj, r.Clock.Now())
 	setCondition(
 		dfz, freezerv1alpha1.ConditionTypeUnfreezeProgress,
 		freezerv1alpha1.ConditionStatusTrue,
@@ -164,8 +558,26 @@ func (r *DeploymentFreezerReconciler) handleUnfreezing(
 		freezerv1alpha1.ConditionReasonReleased,
 		msgOwnershipReleasedAfterUnfreeze,
 	)
-	setPhase(dfz, freezerv1alpha1.PhaseCompleted)
-	r.Recorder.Eventf(dfz, corev1.EventTypeNormal, ReasonUnfreezeCompleted, msgUnfreezeCompleted, targetReplicas)
 
-	return ctrl.Result{}, nil
+	if observation := time.Duration(dfz.Spec.PostUnfreezeObservationSeconds) * time.Second; observation > 0 {
+		now := metav1.NewTime(r.Clock.Now().UTC())
+		dfz.Status.PostUnfreeze = &freezerv1alpha1.PostUnfreezeStatus{RestoredAt: &now}
+		setCondition(
+			dfz,
+			freezerv1alpha1.ConditionTypePostUnfreezeHealthy,
+			freezerv1alpha1.ConditionStatusUnknown,
+			freezerv1alpha1.ConditionReasonObserving,
+			fmt.Sprintf(msgPostUnfreezeObservingFmt, now.Add(observation).Format(time.RFC3339)),
+		)
+		return ctrl.Result{RequeueAfter: requeueShort}
+	}
+
+	r.completeUnfreeze(dfz)
+	return ctrl.Result{}
+}
+
+// completeUnfreeze moves the DFZ to Completed once replicas are restored (and observed, if requested).
+func (r *DeploymentFreezerReconciler) completeUnfreeze(dfz *freezerv1alpha1.DeploymentFreezer) {
+	r.setPhase(dfz, freezerv1alpha1.PhaseCompleted)
+	r.Recorder.Eventf(dfz, corev1.EventTypeNormal, ReasonUnfreezeCompleted, msgUnfreezeCompleted, *dfz.Status.OriginalReplicas)
 }
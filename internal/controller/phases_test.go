@@ -0,0 +1,406 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// freezeTarget wraps obj in its freeze plugin, for handlers that take the target.
+func freezeTarget(t *testing.T, obj client.Object) freeze.Freezable {
+	t.Helper()
+	target, err := (&freeze.Freezer{Client: fake.NewClientBuilder().Build()}).Target(obj)
+	require.NoError(t, err)
+	return target
+}
+
+func TestCheckDrift(t *testing.T) {
+	newObjects := func(ns string) (*freezerv1alpha1.DeploymentFreezer, *appsv1.Deployment) {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "dfz", UID: "dfz-uid"}}
+		dfz.Status.Phase = freezerv1alpha1.PhaseFrozen
+		deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   ns,
+			Name:        "web",
+			Annotations: map[string]string{annoFrozenBy: frozenByValue(dfz)},
+		}}
+		deploy.Spec.Replicas = ptr.To(int32(0))
+		return dfz, deploy
+	}
+	find := func(dfz *freezerv1alpha1.DeploymentFreezer) freezerv1alpha1.Condition {
+		for _, c := range dfz.Status.Conditions {
+			if c.Type == freezerv1alpha1.ConditionTypeDriftDetected {
+				return c
+			}
+		}
+		t.Fatalf("condition %s not set", freezerv1alpha1.ConditionTypeDriftDetected)
+		return freezerv1alpha1.Condition{}
+	}
+	count := func(ns string, reason freezerv1alpha1.ConditionReason) float64 {
+		return testutil.ToFloat64(driftDetectedTotal.WithLabelValues(ns, string(reason)))
+	}
+
+	t.Run("StillFrozen_NoDrift", func(t *testing.T) {
+		t.Parallel()
+		r := &DeploymentFreezerReconciler{Recorder: record.NewFakeRecorder(10)}
+		dfz, deploy := newObjects("drift-none")
+		r.checkDrift(context.Background(), dfz, freezeTarget(t, deploy))
+
+		c := find(dfz)
+		assert.Equal(t, freezerv1alpha1.ConditionStatusFalse, c.Status)
+		assert.Equal(t, freezerv1alpha1.ConditionReasonNoDrift, c.Reason)
+	})
+
+	t.Run("AnnotationRemoved_CountedOnce", func(t *testing.T) {
+		t.Parallel()
+		rec := record.NewFakeRecorder(10)
+		r := &DeploymentFreezerReconciler{Recorder: rec}
+		dfz, deploy := newObjects("drift-anno")
+		delete(deploy.Annotations, annoFrozenBy)
+
+		r.checkDrift(context.Background(), dfz, freezeTarget(t, deploy))
+		r.checkDrift(context.Background(), dfz, freezeTarget(t, deploy))
+
+		c := find(dfz)
+		assert.Equal(t, freezerv1alpha1.ConditionStatusTrue, c.Status)
+		assert.Equal(t, freezerv1alpha1.ConditionReasonAnnotationDrift, c.Reason)
+		assert.Equal(t, float64(1), count("drift-anno", freezerv1alpha1.ConditionReasonAnnotationDrift))
+		assert.Equal(t, float64(1), testutil.ToFloat64(ownershipConflictsTotal.WithLabelValues("drift-anno", conflictLost)))
+		assert.Equal(t, []string{
+			"Warning DriftDetected annotation apps.boolfixer.dev/frozen-by no longer set to \"drift-anno/dfz/dfz-uid\"",
+			"Warning OwnershipLost Ownership annotation lost or overwritten on Deployment drift-anno/web",
+		}, drainEvents(rec))
+	})
+
+	t.Run("RecreatedOwner_AnnotationDrift", func(t *testing.T) {
+		t.Parallel()
+		r := &DeploymentFreezerReconciler{Recorder: record.NewFakeRecorder(10)}
+		dfz, deploy := newObjects("drift-recreated")
+		dfz.UID = "new-uid"
+
+		r.checkDrift(context.Background(), dfz, freezeTarget(t, deploy))
+		assert.Equal(t, freezerv1alpha1.ConditionReasonAnnotationDrift, find(dfz).Reason)
+	})
+
+	t.Run("ScaledUp_ReplicasDrift", func(t *testing.T) {
+		t.Parallel()
+		dfz, deploy := newObjects("drift-replicas")
+		deploy.Spec.Replicas = ptr.To(int32(2))
+		c := fake.NewClientBuilder().WithObjects(deploy).Build()
+		r := &DeploymentFreezerReconciler{Client: c, APIReader: c, Recorder: record.NewFakeRecorder(10)}
+
+		r.checkDrift(context.Background(), dfz, freezeTarget(t, deploy))
+		assert.Equal(t, freezerv1alpha1.ConditionReasonReplicasDrift, find(dfz).Reason)
+		assert.Equal(t, "Deployment scaled to 2 replicas while frozen", find(dfz).Message)
+		assert.Equal(t, float64(1), count("drift-replicas", freezerv1alpha1.ConditionReasonReplicasDrift))
+
+		// Scaled back down: drift clears.
+		deploy.Spec.Replicas = ptr.To(int32(0))
+		r.checkDrift(context.Background(), dfz, freezeTarget(t, deploy))
+		assert.Equal(t, freezerv1alpha1.ConditionStatusFalse, find(dfz).Status)
+	})
+
+	t.Run("ScaledUp_ActorReported", func(t *testing.T) {
+		t.Parallel()
+		dfz, deploy := newObjects("drift-actor")
+		deploy.Spec.Replicas = ptr.To(int32(3))
+		deploy.Annotations["audit.example.com/last-modified-by"] = "alice@example.com"
+		at := metav1.NewTime(time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC))
+		deploy.ManagedFields = []metav1.ManagedFieldsEntry{
+			{Manager: "helm", Operation: metav1.ManagedFieldsOperationUpdate, Time: ptr.To(metav1.NewTime(at.Add(-time.Hour))),
+				FieldsType: "FieldsV1", FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:template":{}}}`)}},
+			{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationUpdate, Subresource: "scale", Time: &at,
+				FieldsType: "FieldsV1", FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)}},
+		}
+		c := fake.NewClientBuilder().WithObjects(deploy).Build()
+		rec := record.NewFakeRecorder(10)
+		r := &DeploymentFreezerReconciler{
+			Client:               c,
+			APIReader:            c,
+			Recorder:             rec,
+			DriftActorAnnotation: "audit.example.com/last-modified-by",
+		}
+
+		r.checkDrift(context.Background(), dfz, freezeTarget(t, deploy))
+		want := `Deployment scaled to 3 replicas while frozen by field manager "kubectl" through the scale subresource ` +
+			`at 2026-10-16T09:30:00Z, user "alice@example.com"`
+		assert.Equal(t, want, find(dfz).Message)
+		assert.Equal(t, []string{"Warning DriftDetected " + want}, drainEvents(rec))
+	})
+}
+
+func TestCheckRestart(t *testing.T) {
+	newObjects := func(baseline *string, restartedAt string) (*freezerv1alpha1.DeploymentFreezer, *appsv1.Deployment) {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "dfz"}}
+		if baseline != nil {
+			dfz.Annotations = map[string]string{annoRestartedAt: *baseline}
+		}
+		deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web"}}
+		if restartedAt != "" {
+			deploy.Spec.Template.Annotations = map[string]string{annoKubectlRestart: restartedAt}
+		}
+		return dfz, deploy
+	}
+
+	t.Run("Unchanged_NoCondition", func(t *testing.T) {
+		t.Parallel()
+		rec := record.NewFakeRecorder(10)
+		r := &DeploymentFreezerReconciler{Recorder: rec}
+		dfz, deploy := newObjects(ptr.To("2025-01-01T00:00:00Z"), "2025-01-01T00:00:00Z")
+		r.checkRestart(dfz, freezeTarget(t, deploy))
+		assert.Empty(t, dfz.Status.Conditions)
+		assert.Empty(t, rec.Events)
+	})
+
+	t.Run("NoBaseline_Skipped", func(t *testing.T) {
+		t.Parallel()
+		rec := record.NewFakeRecorder(10)
+		r := &DeploymentFreezerReconciler{Recorder: rec}
+		dfz, deploy := newObjects(nil, "2025-01-01T00:00:00Z")
+		r.checkRestart(dfz, freezeTarget(t, deploy))
+		assert.Empty(t, dfz.Status.Conditions)
+	})
+
+	t.Run("Restarted_ReportedOncePerRestart", func(t *testing.T) {
+		t.Parallel()
+		rec := record.NewFakeRecorder(10)
+		r := &DeploymentFreezerReconciler{Recorder: rec}
+		dfz, deploy := newObjects(ptr.To(""), "2025-01-01T01:00:00Z")
+		r.checkRestart(dfz, freezeTarget(t, deploy))
+		r.checkRestart(dfz, freezeTarget(t, deploy))
+
+		assert.True(t, hasCondition(dfz, freezerv1alpha1.ConditionTypeRestartRequested,
+			freezerv1alpha1.ConditionStatusTrue, freezerv1alpha1.ConditionReasonRolloutRestart))
+		assert.Equal(t, "Warning RestartDeferred Rollout restart requested at 2025-01-01T01:00:00Z has no effect "+
+			"while frozen; it rolls out once replicas are restored", <-rec.Events)
+		assert.Empty(t, rec.Events)
+
+		deploy.Spec.Template.Annotations[annoKubectlRestart] = "2025-01-01T02:00:00Z"
+		r.checkRestart(dfz, freezeTarget(t, deploy))
+		assert.Len(t, rec.Events, 1)
+		assert.Equal(t, "rollout restart requested at 2025-01-01T02:00:00Z; deferred until replicas are restored",
+			dfz.Status.Conditions[0].Message)
+	})
+}
+
+func TestHeartbeat(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("FirstBeat_Set", func(t *testing.T) {
+		t.Parallel()
+		r := &DeploymentFreezerReconciler{Clock: testingclock.NewFakeClock(start)}
+		dfz := &freezerv1alpha1.DeploymentFreezer{}
+		r.heartbeat(dfz)
+		require.NotNil(t, dfz.Status.LastHeartbeatTime)
+		assert.Equal(t, start, dfz.Status.LastHeartbeatTime.Time)
+	})
+
+	t.Run("WithinInterval_Unchanged", func(t *testing.T) {
+		t.Parallel()
+		clk := testingclock.NewFakeClock(start)
+		r := &DeploymentFreezerReconciler{Clock: clk}
+		dfz := &freezerv1alpha1.DeploymentFreezer{}
+		r.heartbeat(dfz)
+
+		clk.Step(heartbeatInterval - time.Second)
+		r.heartbeat(dfz)
+		assert.Equal(t, start, dfz.Status.LastHeartbeatTime.Time)
+
+		clk.Step(time.Second)
+		r.heartbeat(dfz)
+		assert.Equal(t, start.Add(heartbeatInterval), dfz.Status.LastHeartbeatTime.Time)
+	})
+}
+
+func TestLabelFrozen(t *testing.T) {
+	dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "dfz", UID: "dfz-uid"}}
+	newReconciler := func(labels map[string]string) (*DeploymentFreezerReconciler, *appsv1.Deployment) {
+		deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "ns",
+			Name:        "web",
+			Labels:      labels,
+			Annotations: map[string]string{annoFrozenBy: frozenByValue(dfz)},
+		}}
+		c := fake.NewClientBuilder().WithObjects(deploy).Build()
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(deploy), deploy))
+		return &DeploymentFreezerReconciler{Client: c, APIReader: c, Recorder: record.NewFakeRecorder(10)}, deploy
+	}
+
+	t.Run("ClaimedWithoutLabel_Added", func(t *testing.T) {
+		t.Parallel()
+		r, deploy := newReconciler(map[string]string{"app": "web"})
+		target, err := r.freezer().Target(deploy)
+		require.NoError(t, err)
+		r.labelFrozen(context.Background(), dfz.DeepCopy(), target)
+
+		got := &appsv1.Deployment{}
+		require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(deploy), got))
+		assert.Equal(t, map[string]string{"app": "web", freeze.LabelFrozen: "true"}, got.Labels)
+		assert.Equal(t, frozenByValue(dfz), got.Annotations[annoFrozenBy])
+	})
+
+	t.Run("Labeled_NoWrite", func(t *testing.T) {
+		t.Parallel()
+		r, deploy := newReconciler(map[string]string{freeze.LabelFrozen: "true"})
+		rv := deploy.ResourceVersion
+		target, err := r.freezer().Target(deploy)
+		require.NoError(t, err)
+		r.labelFrozen(context.Background(), dfz.DeepCopy(), target)
+
+		got := &appsv1.Deployment{}
+		require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(deploy), got))
+		assert.Equal(t, rv, got.ResourceVersion)
+	})
+}
+
+func TestUnfreezeDelay(t *testing.T) {
+	t.Run("NoLimiter_NeverWaits", func(t *testing.T) {
+		t.Parallel()
+		r := &DeploymentFreezerReconciler{}
+		assert.Zero(t, r.unfreezeDelay())
+	})
+
+	t.Run("BurstExhausted_Waits", func(t *testing.T) {
+		t.Parallel()
+		r := &DeploymentFreezerReconciler{UnfreezeLimiter: rate.NewLimiter(rate.Every(time.Hour), 1)}
+		assert.Zero(t, r.unfreezeDelay())
+		assert.Positive(t, r.unfreezeDelay())
+		// A refused reservation does not consume a token.
+		assert.LessOrEqual(t, r.unfreezeDelay(), time.Hour)
+	})
+}
+
+func TestRestoreProgress(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	newUnfreezing := func() (*freezerv1alpha1.DeploymentFreezer, *appsv1.Deployment) {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "dfz"}}
+		dfz.Status.Phase = freezerv1alpha1.PhaseUnfreezing
+		dfz.Status.OriginalReplicas = ptr.To(int32(3))
+		deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web"}}
+		return dfz, deploy
+	}
+	newReconciler := func(objs ...client.Object) *DeploymentFreezerReconciler {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+		return &DeploymentFreezerReconciler{
+			Client:    c,
+			APIReader: c,
+			Recorder:  record.NewFakeRecorder(10),
+			Clock:     testingclock.NewFakeClock(now),
+		}
+	}
+
+	t.Run("Throttled_CountsCopied", func(t *testing.T) {
+		t.Parallel()
+		dfz, deploy := newUnfreezing()
+		deploy.Spec.Replicas = ptr.To(int32(0))
+		r := newReconciler(deploy)
+		r.UnfreezeLimiter = rate.NewLimiter(rate.Every(time.Hour), 0)
+
+		_, err := r.handleUnfreezing(context.Background(), dfz, deploy, freezeTarget(t, deploy))
+		require.NoError(t, err)
+		assert.Equal(t, &freezerv1alpha1.RestoreProgress{Replicas: 3}, dfz.Status.RestoreProgress)
+	})
+
+	t.Run("Observing_FollowsTarget", func(t *testing.T) {
+		t.Parallel()
+		dfz, deploy := newUnfreezing()
+		dfz.Spec.PostUnfreezeObservationSeconds = 300
+		dfz.Status.PostUnfreeze = &freezerv1alpha1.PostUnfreezeStatus{RestoredAt: ptr.To(metav1.NewTime(now))}
+		deploy.Spec.Replicas = ptr.To(int32(3))
+		r := newReconciler(deploy)
+
+		for _, ready := range []int32{1, 3} {
+			deploy.Status.ReadyReplicas, deploy.Status.AvailableReplicas = ready, ready-1
+			_, err := r.handleUnfreezing(context.Background(), dfz, deploy, freezeTarget(t, deploy))
+			require.NoError(t, err)
+			assert.Equal(t, &freezerv1alpha1.RestoreProgress{
+				Replicas: 3, ReadyReplicas: ready, AvailableReplicas: ready - 1,
+			}, dfz.Status.RestoreProgress)
+		}
+		assert.Equal(t, freezerv1alpha1.PhaseUnfreezing, dfz.Status.Phase)
+	})
+}
+
+func TestResizeFreezeWindow(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	frozenAt := now.Add(-10 * time.Minute)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	newReconciler := func(maxDuration time.Duration) (*DeploymentFreezerReconciler, *record.FakeRecorder) {
+		rec := record.NewFakeRecorder(10)
+		return &DeploymentFreezerReconciler{
+			Client:          fake.NewClientBuilder().WithScheme(scheme).Build(),
+			Recorder:        rec,
+			Clock:           testingclock.NewFakeClock(now),
+			DefaultDuration: time.Hour,
+			MaxDuration:     maxDuration,
+		}, rec
+	}
+	newDFZ := func(durationSeconds int64) *freezerv1alpha1.DeploymentFreezer {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "dfz"}}
+		dfz.Spec.DurationSeconds = durationSeconds
+		dfz.Status.Phase = freezerv1alpha1.PhaseFrozen
+		dfz.Status.PhaseTransitionTimes = map[freezerv1alpha1.Phase]metav1.Time{
+			freezerv1alpha1.PhaseFrozen: metav1.NewTime(frozenAt),
+		}
+		until := metav1.NewTime(frozenAt.Add(time.Hour))
+		dfz.Status.FreezeUntil = &until
+		return dfz
+	}
+
+	t.Run("SpecUnchanged_NoOp", func(t *testing.T) {
+		t.Parallel()
+		r, rec := newReconciler(0)
+		dfz := newDFZ(3600)
+		r.resizeFreezeWindow(t.Context(), dfz)
+		assert.Equal(t, frozenAt.Add(time.Hour), dfz.Status.FreezeUntil.Time)
+		assert.Empty(t, rec.Events)
+	})
+
+	t.Run("Extended_MovesFreezeUntil", func(t *testing.T) {
+		t.Parallel()
+		r, rec := newReconciler(0)
+		dfz := newDFZ(7200)
+		r.resizeFreezeWindow(t.Context(), dfz)
+		assert.Equal(t, frozenAt.Add(2*time.Hour), dfz.Status.FreezeUntil.Time)
+		assert.Contains(t, <-rec.Events, ReasonFreezeWindowChanged)
+	})
+
+	t.Run("Shortened_MovesFreezeUntil", func(t *testing.T) {
+		t.Parallel()
+		r, _ := newReconciler(0)
+		dfz := newDFZ(300)
+		r.resizeFreezeWindow(t.Context(), dfz)
+		assert.Equal(t, frozenAt.Add(5*time.Minute), dfz.Status.FreezeUntil.Time)
+	})
+
+	t.Run("ExtendedBeyondMax_Clamped", func(t *testing.T) {
+		t.Parallel()
+		r, rec := newReconciler(90 * time.Minute)
+		dfz := newDFZ(7200)
+		r.resizeFreezeWindow(t.Context(), dfz)
+		assert.Equal(t, frozenAt.Add(90*time.Minute), dfz.Status.FreezeUntil.Time)
+		assert.Contains(t, <-rec.Events, ReasonDurationClamped)
+	})
+}
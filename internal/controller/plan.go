@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/internal/policy"
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// dryRun reports whether mutations for this DFZ must only be planned. Plan mode only covers
+// freezes that have not started: a DFZ that already recorded the replicas to restore, for
+// example one created before the controller was restarted with --dry-run, is unfrozen,
+// restored and released for real.
+func (r *DeploymentFreezerReconciler) dryRun(dfz *freezerv1alpha1.DeploymentFreezer) bool {
+	if !r.DryRun && !dfz.Spec.DryRun {
+		return false
+	}
+	started := dfz.Status.OriginalReplicas != nil
+	return !started && (dfz.Status.Phase == "" || dfz.Status.Phase == freezerv1alpha1.PhasePending)
+}
+
+// patchOpts returns the options every Deployment patch for this DFZ must carry.
+func (r *DeploymentFreezerReconciler) patchOpts(dfz *freezerv1alpha1.DeploymentFreezer) []client.PatchOption {
+	if r.dryRun(dfz) {
+		return []client.PatchOption{client.DryRunAll}
+	}
+	return nil
+}
+
+// handlePlan sends the next step of the lifecycle to the API server as a dry run, using the
+// same requests as the real path (so admission webhooks and defaulting are applied), and
+// records what would change.
+//
+//nolint:unparam // error result is currently always nil; keep signature for symmetry
+func (r *DeploymentFreezerReconciler) handlePlan(
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	target freeze.Freezable,
+) (ctrl.Result, error) {
+	owner := frozenByValue(dfz)
+	current := target.GetReplicas()
+	pausable, canPause := target.(freeze.Pausable)
+
+	// Only freezes that have not started are planned; see dryRun.
+	policyMax, allowed, err := r.checkPolicy(ctx, dfz)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: requeueShort}, nil
+	}
+	if !allowed {
+		return ctrl.Result{}, nil
+	}
+	change := freeze.Change{FrozenBy: &owner, Replicas: ptr.To(int32(0))}
+	// Lean RBAC mode cannot pause rollouts.
+	if dfz.Spec.PauseRollout && canPause && !r.LeanRBAC {
+		change.Paused = ptr.To(true)
+	}
+
+	planned, err := r.planChange(ctx, dfz, target, change)
+	if err != nil {
+		setCondition(
+			dfz,
+			freezerv1alpha1.ConditionTypeDryRun,
+			freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonPlanRejected,
+			fmt.Sprintf(msgPlanRejectedFmt, err),
+		)
+		return ctrl.Result{RequeueAfter: requeueMedium}, nil
+	}
+
+	// Report the server's view of the result, which includes admission effects.
+	var changes []string
+	if target.Owner() != planned.Owner() {
+		if v := planned.Owner(); v != "" {
+			changes = append(changes, fmt.Sprintf(msgPlanSetAnnotationFmt, annoFrozenBy, v))
+		} else {
+			changes = append(changes, fmt.Sprintf(msgPlanRemoveAnnotationFmt, annoFrozenBy))
+		}
+	}
+	if label := planned.Object().GetLabels()[freeze.LabelFrozen]; label != target.Object().GetLabels()[freeze.LabelFrozen] {
+		if label != "" {
+			changes = append(changes, fmt.Sprintf(msgPlanSetLabelFmt, freeze.LabelFrozen, label))
+		} else {
+			changes = append(changes, fmt.Sprintf(msgPlanRemoveLabelFmt, freeze.LabelFrozen))
+		}
+	}
+	if plannedPausable, ok := planned.(freeze.Pausable); canPause && ok && pausable.Paused() != plannedPausable.Paused() {
+		changes = append(changes, fmt.Sprintf(msgPlanPausedFmt, plannedPausable.Paused()))
+	}
+	after := planned.GetReplicas()
+	if after != current {
+		changes = append(changes, fmt.Sprintf(msgPlanScaleFmt, current, after))
+	}
+	if after == 0 {
+		restore := current
+		if restore <= 0 {
+			restore = defaultReplicasCount
+		}
+		duration, _ := freezeDuration(dfz.Spec.RequestedDuration(), r.DefaultDuration, policy.Strictest(r.maxDuration(dfz), policyMax))
+		until := r.Clock.Now().UTC().Add(duration)
+		changes = append(changes, fmt.Sprintf(msgPlanRestoreFmt, restore, until.Format(time.RFC3339)))
+	}
+
+	dfz.Status.PlannedChanges = changes
+	setCondition(
+		dfz,
+		freezerv1alpha1.ConditionTypeDryRun,
+		freezerv1alpha1.ConditionStatusTrue,
+		freezerv1alpha1.ConditionReasonPlanned,
+		msgPlanned,
+	)
+	return ctrl.Result{}, nil
+}
+
+// planChange dry-runs the change on the target and wraps the server's result, so it can be
+// compared with the target.
+func (r *DeploymentFreezerReconciler) planChange(
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	target freeze.Freezable,
+	change freeze.Change,
+) (freeze.Freezable, error) {
+	planner, ok := target.(freeze.Planner)
+	if !ok {
+		return nil, fmt.Errorf("%T cannot preview changes", target)
+	}
+	planned, err := planner.Plan(ctx, change, r.patchOpts(dfz)...)
+	if err != nil {
+		return nil, err
+	}
+	return r.freezer().Target(planned)
+}
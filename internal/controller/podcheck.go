@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maxListedPods caps the Pods named in the FreezeProgress condition.
+const maxListedPods = 5
+
+// podsRemainingTimeout is how long a drained target waits for its remaining Pods before the DFZ
+// reports Health=Degraded, so a Pod stuck terminating, or an orphan nobody deletes, gets noticed.
+// The DFZ stays Freezing until the Pods are gone.
+const podsRemainingTimeout = 10 * time.Minute
+
+// remainingPods describes the Pods of the target still present once its status counters read
+// zero, or returns "". Counters lag behind the kubelet, and an orphaned Pod matching the selector
+// is in no counter at all. A Pod counts when it matches the target's selector and is controlled
+// by the target, by one of its ReplicaSets, or by nothing; finished Pods do not count.
+func (r *DeploymentFreezerReconciler) remainingPods(ctx context.Context, obj client.Object) (string, error) {
+	selector, err := targetSelector(obj)
+	if err != nil || selector == nil || selector.Empty() {
+		// No selector to go by (the API server refuses an empty one); never match every Pod of
+		// the namespace.
+		return "", err
+	}
+	if _, selectable := selector.Requirements(); !selectable {
+		return "", nil
+	}
+	var pods corev1.PodList
+	if err := r.APIReader.List(
+		ctx,
+		&pods,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingLabelsSelector{Selector: selector},
+	); err != nil {
+		return "", err
+	}
+
+	_, isDeployment := obj.(*appsv1.Deployment)
+	// ReplicaSet UID -> whether the Deployment controls it
+	replicaSets := map[types.UID]bool{}
+	var names []string
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		ref := metav1.GetControllerOf(pod)
+		switch {
+		case ref == nil:
+			names = append(names, pod.Name+" (orphaned)")
+			continue
+		case ref.UID == obj.GetUID():
+		case isDeployment && ref.Kind == "ReplicaSet" && ref.APIVersion == appsv1.SchemeGroupVersion.String():
+			owned, seen := replicaSets[ref.UID]
+			if !seen {
+				if owned, err = r.controlsReplicaSet(ctx, obj, ref.Name, ref.UID); err != nil {
+					return "", err
+				}
+				replicaSets[ref.UID] = owned
+			}
+			if !owned {
+				continue
+			}
+		default:
+			continue
+		}
+		names = append(names, pod.Name)
+	}
+
+	if len(names) == 0 {
+		return "", nil
+	}
+	listed := names[:min(len(names), maxListedPods)]
+	msg := fmt.Sprintf(msgPodsRemainingFmt, len(names), strings.Join(listed, ", "))
+	if len(names) > maxListedPods {
+		msg += ", ..."
+	}
+	return msg, nil
+}
+
+// controlsReplicaSet reports whether deploy controls the ReplicaSet. A ReplicaSet that is gone
+// counts as controlled: its Pods are still running until they are garbage collected.
+func (r *DeploymentFreezerReconciler) controlsReplicaSet(
+	ctx context.Context,
+	deploy client.Object,
+	name string,
+	uid types.UID,
+) (bool, error) {
+	var rs appsv1.ReplicaSet
+	err := r.APIReader.Get(ctx, types.NamespacedName{Namespace: deploy.GetNamespace(), Name: name}, &rs)
+	switch {
+	case apierrors.IsNotFound(err):
+		return true, nil
+	case err != nil:
+		return false, err
+	case rs.UID != uid:
+		// Recreated under the same name; the Pod's ReplicaSet is gone.
+		return true, nil
+	}
+	ref := metav1.GetControllerOf(&rs)
+	return ref != nil && ref.UID == deploy.GetUID(), nil
+}
+
+// targetSelector returns the Pod selector of a target workload, or nil when it has none.
+func targetSelector(obj client.Object) (labels.Selector, error) {
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		return metav1.LabelSelectorAsSelector(o.Spec.Selector)
+	case *appsv1.ReplicaSet:
+		return metav1.LabelSelectorAsSelector(o.Spec.Selector)
+	case *corev1.ReplicationController:
+		return labels.SelectorFromSet(o.Spec.Selector), nil
+	case *appsv1.DaemonSet:
+		return metav1.LabelSelectorAsSelector(o.Spec.Selector)
+	case *unstructured.Unstructured:
+		var selector metav1.LabelSelector
+		if !fromNested(o, &selector, "spec", "selector") {
+			return nil, nil
+		}
+		return metav1.LabelSelectorAsSelector(&selector)
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,203 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRemainingPods(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	appLabels := map[string]string{"app": "web"}
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web", UID: "web-uid"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(0)),
+			Selector: &metav1.LabelSelector{MatchLabels: appLabels},
+		},
+	}
+	controlledBy := func(kind, name string, uid types.UID) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: kind, Name: name, UID: uid, Controller: ptr.To(true)}}
+	}
+	replicaSet := func(name string, uid, owner types.UID) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns", Name: name, UID: uid, OwnerReferences: controlledBy("Deployment", "x", owner),
+		}}
+	}
+	pod := func(name string, owners []metav1.OwnerReference, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, Labels: appLabels, OwnerReferences: owners},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+	newReconciler := func(objs ...client.Object) *DeploymentFreezerReconciler {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+		return &DeploymentFreezerReconciler{Client: c, APIReader: c}
+	}
+
+	t.Run("NoPods_Empty", func(t *testing.T) {
+		t.Parallel()
+		msg, err := newReconciler().remainingPods(context.Background(), deploy)
+		require.NoError(t, err)
+		assert.Empty(t, msg)
+	})
+
+	t.Run("OwnAndOrphanedPods_Listed", func(t *testing.T) {
+		t.Parallel()
+		r := newReconciler(
+			replicaSet("web-1", "rs-1", "web-uid"),
+			replicaSet("other-1", "rs-2", "other-uid"),
+			pod("web-1-a", controlledBy("ReplicaSet", "web-1", "rs-1"), corev1.PodRunning),
+			pod("web-1-done", controlledBy("ReplicaSet", "web-1", "rs-1"), corev1.PodSucceeded),
+			pod("other-1-a", controlledBy("ReplicaSet", "other-1", "rs-2"), corev1.PodRunning),
+			pod("stray", nil, corev1.PodRunning),
+		)
+		msg, err := r.remainingPods(context.Background(), deploy)
+		require.NoError(t, err)
+		assert.Equal(t, "2 Pod(s) still running at zero replicas: stray (orphaned), web-1-a", msg)
+	})
+
+	t.Run("ReplicaSetGone_Counted", func(t *testing.T) {
+		t.Parallel()
+		r := newReconciler(pod("web-0-a", controlledBy("ReplicaSet", "web-0", "rs-0"), corev1.PodPending))
+		msg, err := r.remainingPods(context.Background(), deploy)
+		require.NoError(t, err)
+		assert.Equal(t, "1 Pod(s) still running at zero replicas: web-0-a", msg)
+	})
+
+	t.Run("ReplicationController_OwnPods", func(t *testing.T) {
+		t.Parallel()
+		rc := &corev1.ReplicationController{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "legacy", UID: "rc-uid"},
+			Spec:       corev1.ReplicationControllerSpec{Selector: appLabels},
+		}
+		r := newReconciler(
+			pod("legacy-a", []metav1.OwnerReference{{
+				APIVersion: "v1", Kind: "ReplicationController", Name: "legacy", UID: "rc-uid", Controller: ptr.To(true),
+			}}, corev1.PodRunning),
+			pod("web-1-a", controlledBy("ReplicaSet", "web-1", "rs-1"), corev1.PodRunning),
+		)
+		msg, err := r.remainingPods(context.Background(), rc)
+		require.NoError(t, err)
+		assert.Equal(t, "1 Pod(s) still running at zero replicas: legacy-a", msg)
+	})
+
+	t.Run("UnstructuredWithoutSelector_Skipped", func(t *testing.T) {
+		t.Parallel()
+		target := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "example.com/v1",
+			"kind":       "Worker",
+			"metadata":   map[string]any{"namespace": "ns", "name": "worker", "uid": "worker-uid"},
+			"spec":       map[string]any{"replicas": int64(0)},
+		}}
+		r := newReconciler(pod("stray", nil, corev1.PodRunning), pod("web-1-a", nil, corev1.PodRunning))
+		msg, err := r.remainingPods(context.Background(), target)
+		require.NoError(t, err)
+		assert.Empty(t, msg)
+	})
+
+	t.Run("Reconcile_FrozenOnlyOnceGone", func(t *testing.T) {
+		t.Parallel()
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "freeze", UID: "dfz-uid"}}
+		dfz.Spec.TargetRef = freezerv1alpha1.DeploymentTargetRef{Name: "web"}
+		dfz.Spec.DurationSeconds = 3600
+		stray := pod("stray", nil, corev1.PodRunning)
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(dfz, deploy.DeepCopy(), stray).
+			WithStatusSubresource(&freezerv1alpha1.DeploymentFreezer{}).Build()
+		r := &DeploymentFreezerReconciler{
+			Client:    c,
+			APIReader: c,
+			Recorder:  record.NewFakeRecorder(20),
+			Clock:     testingclock.NewFakeClock(time.Now()),
+		}
+		reconcile := func() *freezerv1alpha1.DeploymentFreezer {
+			var got freezerv1alpha1.DeploymentFreezer
+			for range 3 {
+				_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(dfz)})
+				require.NoError(t, err)
+			}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(dfz), &got))
+			return &got
+		}
+
+		got := reconcile()
+		assert.Equal(t, freezerv1alpha1.PhaseFreezing, got.Status.Phase)
+		assert.True(t, hasCondition(got, freezerv1alpha1.ConditionTypeFreezeProgress,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonPodsRemaining))
+		assert.Nil(t, got.Status.FreezeUntil)
+
+		require.NoError(t, c.Delete(context.Background(), stray))
+		got = reconcile()
+		assert.Equal(t, freezerv1alpha1.PhaseFrozen, got.Status.Phase)
+		assert.True(t, hasCondition(got, freezerv1alpha1.ConditionTypeFreezeProgress,
+			freezerv1alpha1.ConditionStatusTrue, freezerv1alpha1.ConditionReasonScaledToZero))
+		require.NotNil(t, got.Status.ResourcesFreed)
+		assert.Equal(t, *got.Status.OriginalReplicas, got.Status.ResourcesFreed.Replicas)
+	})
+
+	t.Run("Reconcile_NeverFrozenWhilePodsOutliveTimeout", func(t *testing.T) {
+		t.Parallel()
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "freeze", UID: "dfz-uid"}}
+		dfz.Spec.TargetRef = freezerv1alpha1.DeploymentTargetRef{Name: "web"}
+		dfz.Spec.DurationSeconds = 3600
+		stray := pod("stray", nil, corev1.PodRunning)
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(dfz, deploy.DeepCopy(), stray).
+			WithStatusSubresource(&freezerv1alpha1.DeploymentFreezer{}).Build()
+		clock := testingclock.NewFakeClock(time.Now())
+		recorder := record.NewFakeRecorder(20)
+		r := &DeploymentFreezerReconciler{Client: c, APIReader: c, Recorder: recorder, Clock: clock}
+		reconcile := func() *freezerv1alpha1.DeploymentFreezer {
+			var got freezerv1alpha1.DeploymentFreezer
+			for range 3 {
+				_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(dfz)})
+				require.NoError(t, err)
+			}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(dfz), &got))
+			return &got
+		}
+
+		got := reconcile()
+		assert.Equal(t, freezerv1alpha1.PhaseFreezing, got.Status.Phase)
+		drainEvents(recorder)
+
+		for range 3 {
+			clock.Step(podsRemainingTimeout)
+			got = reconcile()
+			assert.Equal(t, freezerv1alpha1.PhaseFreezing, got.Status.Phase)
+			assert.True(t, hasCondition(got, freezerv1alpha1.ConditionTypeFreezeProgress,
+				freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonPodsRemaining))
+			assert.True(t, hasCondition(got, freezerv1alpha1.ConditionTypeHealth,
+				freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonDegraded))
+			assert.Nil(t, got.Status.FreezeUntil)
+		}
+		events := drainEvents(recorder)
+		require.Len(t, events, 1, "the warning is emitted once")
+		assert.Contains(t, events[0], "Warning "+ReasonPodsRemaining)
+
+		require.NoError(t, c.Delete(context.Background(), stray))
+		got = reconcile()
+		assert.Equal(t, freezerv1alpha1.PhaseFrozen, got.Status.Phase)
+		assert.True(t, hasCondition(got, freezerv1alpha1.ConditionTypeHealth,
+			freezerv1alpha1.ConditionStatusTrue, freezerv1alpha1.ConditionReasonNormal))
+	})
+}
@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/internal/policy"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// checkPolicy evaluates FreezerPolicies for the DFZ and returns the policy's maximum duration.
+// DFZs in protected namespaces are refused before any policy is consulted, unless they declare
+// the ProtectedNamespace exemption, which the policies must then grant.
+// A DFZ that has not touched its target yet is Denied when no policy allows it; once freezing
+// has started the denial is only reported, so the Deployment is never left half-frozen.
+func (r *DeploymentFreezerReconciler) checkPolicy(
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+) (time.Duration, bool, error) {
+	if policy.IsProtected(dfz.Namespace, r.ProtectedNamespaces) &&
+		!slices.Contains(dfz.Spec.Exemptions, freezerv1alpha1.ExemptionProtectedNamespace) {
+		return 0, r.deny(dfz, freezerv1alpha1.ConditionReasonProtectedNamespace, ReasonProtectedNamespace,
+			policy.ProtectedMessage(dfz.Namespace)), nil
+	}
+
+	maxDuration := r.MaxDuration
+	if slices.Contains(dfz.Spec.Exemptions, freezerv1alpha1.ExemptionMaxDuration) {
+		maxDuration = 0
+	}
+	requested, _ := freezeDuration(dfz.Spec.RequestedDuration(), r.DefaultDuration, maxDuration)
+	decision, err := policy.Evaluate(ctx, r.Client, policy.Request{
+		Namespace:  dfz.Namespace,
+		Requester:  policy.RequesterFromAnnotations(dfz),
+		Duration:   requested,
+		Self:       dfz.UID,
+		Now:        r.Clock.Now(),
+		Exemptions: dfz.Spec.Exemptions,
+	})
+	if err != nil {
+		r.operationFailed(dfz, opEvaluatePolicy, fmt.Sprintf(msgPolicyEvaluationFailedFmt, err))
+		return 0, false, err
+	}
+
+	if decision.Allowed {
+		r.recordExemptions(dfz, decision.Exemptions)
+		setCondition(
+			dfz,
+			freezerv1alpha1.ConditionTypePolicy,
+			freezerv1alpha1.ConditionStatusTrue,
+			freezerv1alpha1.ConditionReasonAllowed,
+			decision.Message,
+		)
+		return decision.MaxDuration, true, nil
+	}
+
+	allowed := r.deny(dfz, freezerv1alpha1.ConditionReasonPolicyDenied, ReasonPolicyDenied, decision.Message)
+	if !allowed {
+		return 0, false, nil
+	}
+	return decision.MaxDuration, true, nil
+}
+
+// recordExemptions keeps the granted exemptions in status for audit and announces new grants.
+func (r *DeploymentFreezerReconciler) recordExemptions(
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	grants []freezerv1alpha1.ExemptionGrant,
+) {
+	for _, g := range grants {
+		if !slices.Contains(dfz.Status.Exemptions, g) {
+			r.Recorder.Eventf(dfz, corev1.EventTypeNormal, ReasonExemptionGranted, msgExemptionGranted, g.Exemption, g.Policy)
+		}
+	}
+	dfz.Status.Exemptions = grants
+}
+
+// maxDuration is the controller-wide cap on the DFZ's freeze window; 0 when it was exempted from it.
+func (r *DeploymentFreezerReconciler) maxDuration(dfz *freezerv1alpha1.DeploymentFreezer) time.Duration {
+	if policy.Exempted(dfz.Status.Exemptions, freezerv1alpha1.ExemptionMaxDuration) {
+		return 0
+	}
+	return r.MaxDuration
+}
+
+// deny records a refusal in the Policy condition. Only a Pending DFZ moves to Denied; the
+// result reports whether the DFZ may still proceed.
+func (r *DeploymentFreezerReconciler) deny(
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	reason freezerv1alpha1.ConditionReason,
+	eventReason, msg string,
+) bool {
+	setCondition(dfz, freezerv1alpha1.ConditionTypePolicy, freezerv1alpha1.ConditionStatusFalse, reason, msg)
+	if dfz.Status.Phase != freezerv1alpha1.PhasePending {
+		return true
+	}
+	r.setPhase(dfz, freezerv1alpha1.PhaseDenied)
+	r.Recorder.Event(dfz, corev1.EventTypeWarning, eventReason, msg)
+	return false
+}
+
+// recordFreezeUsage charges the time the DFZ kept its Deployment frozen to the quotas of its namespace.
+func (r *DeploymentFreezerReconciler) recordFreezeUsage(ctx context.Context, dfz *freezerv1alpha1.DeploymentFreezer) error {
+	period, ok := policy.ActivePeriod(dfz, r.Clock.Now())
+	if !ok {
+		return nil
+	}
+	if err := policy.RecordUsage(ctx, r.Client, dfz.Namespace, period); err != nil {
+		return fmt.Errorf("record freeze usage of namespace %s: %w", dfz.Namespace, err)
+	}
+	return nil
+}
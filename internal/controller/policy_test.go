@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/internal/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestExemptions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	sre := &freezerv1alpha1.FreezerPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "sre"},
+		Spec: freezerv1alpha1.FreezerPolicySpec{Rules: []freezerv1alpha1.FreezerPolicyRule{{
+			Action:     freezerv1alpha1.PolicyActionAllow,
+			Namespaces: []string{"*"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "sre"}},
+			Exemptions: []freezerv1alpha1.Exemption{
+				freezerv1alpha1.ExemptionProtectedNamespace,
+				freezerv1alpha1.ExemptionMaxDuration,
+			},
+		}, {
+			Action:     freezerv1alpha1.PolicyActionAllow,
+			Namespaces: []string{"*"},
+		}}},
+	}
+	newReconciler := func(objs ...client.Object) (*DeploymentFreezerReconciler, *record.FakeRecorder) {
+		rec := record.NewFakeRecorder(10)
+		return &DeploymentFreezerReconciler{
+			Client:              fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+			Recorder:            rec,
+			Clock:               testingclock.NewFakeClock(time.Now()),
+			MaxDuration:         time.Hour,
+			ProtectedNamespaces: []string{"payments"},
+		}, rec
+	}
+	newDFZ := func(groups []string, exemptions ...freezerv1alpha1.Exemption) *freezerv1alpha1.DeploymentFreezer {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "payments", Name: "dfz"}}
+		dfz.Spec.Exemptions = exemptions
+		dfz.Status.Phase = freezerv1alpha1.PhasePending
+		policy.Requester{Username: "alice", Groups: groups}.Annotate(dfz)
+		return dfz
+	}
+
+	t.Run("Granted_RecordedInStatus", func(t *testing.T) {
+		t.Parallel()
+		r, rec := newReconciler(sre)
+		dfz := newDFZ([]string{"sre"}, freezerv1alpha1.ExemptionProtectedNamespace, freezerv1alpha1.ExemptionMaxDuration)
+
+		for range 2 {
+			_, allowed, err := r.checkPolicy(context.Background(), dfz)
+			require.NoError(t, err)
+			assert.True(t, allowed)
+		}
+		assert.Equal(t, []freezerv1alpha1.ExemptionGrant{
+			{Exemption: freezerv1alpha1.ExemptionProtectedNamespace, Policy: "sre"},
+			{Exemption: freezerv1alpha1.ExemptionMaxDuration, Policy: "sre"},
+		}, dfz.Status.Exemptions)
+		assert.Zero(t, r.maxDuration(dfz))
+		assert.Equal(t, []string{
+			"Normal ExemptionGranted Exempted from ProtectedNamespace by FreezerPolicy sre",
+			"Normal ExemptionGranted Exempted from MaxDuration by FreezerPolicy sre",
+		}, drainEvents(rec), "each grant is announced once")
+	})
+
+	t.Run("NotGranted_Denied", func(t *testing.T) {
+		t.Parallel()
+		r, _ := newReconciler(sre)
+		dfz := newDFZ([]string{"dev"}, freezerv1alpha1.ExemptionProtectedNamespace)
+
+		_, allowed, err := r.checkPolicy(context.Background(), dfz)
+		require.NoError(t, err)
+		assert.False(t, allowed)
+		assert.Equal(t, freezerv1alpha1.PhaseDenied, dfz.Status.Phase)
+		assert.True(t, hasCondition(dfz, freezerv1alpha1.ConditionTypePolicy,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonPolicyDenied))
+		assert.Empty(t, dfz.Status.Exemptions)
+	})
+
+	t.Run("NotRequested_ProtectedNamespaceRefused", func(t *testing.T) {
+		t.Parallel()
+		r, _ := newReconciler(sre)
+		dfz := newDFZ([]string{"sre"})
+
+		_, allowed, err := r.checkPolicy(context.Background(), dfz)
+		require.NoError(t, err)
+		assert.False(t, allowed)
+		assert.True(t, hasCondition(dfz, freezerv1alpha1.ConditionTypePolicy,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonProtectedNamespace))
+		assert.Equal(t, time.Hour, r.maxDuration(dfz))
+	})
+}
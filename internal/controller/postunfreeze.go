@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// observePostUnfreeze watches the restored Deployment for spec.postUnfreezeObservationSeconds,
+// then records the verdict in the PostUnfreezeHealthy condition and completes the DFZ.
+func (r *DeploymentFreezerReconciler) observePostUnfreeze(
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	deploy *appsv1.Deployment,
+) ctrl.Result {
+	obs := dfz.Status.PostUnfreeze
+	restarts, err := r.podRestarts(ctx, deploy)
+	if err != nil {
+		r.operationFailed(dfz, opRead, fmt.Sprintf(msgReadErrorFmt, err))
+		return ctrl.Result{RequeueAfter: requeueMedium}
+	}
+	// Restarted Pods may be replaced between polls, so keep the highest count seen.
+	obs.Restarts = max(obs.Restarts, restarts)
+
+	end := obs.RestoredAt.Add(time.Duration(dfz.Spec.PostUnfreezeObservationSeconds) * time.Second)
+	if r.Clock.Now().Before(end) {
+		return ctrl.Result{RequeueAfter: min(r.untilTime(end), requeueMedium)}
+	}
+
+	status, reason, msg := postUnfreezeVerdict(deploy, obs.Restarts)
+	setCondition(dfz, freezerv1alpha1.ConditionTypePostUnfreezeHealthy, status, reason, msg)
+	if status != freezerv1alpha1.ConditionStatusTrue {
+		r.Recorder.Event(dfz, corev1.EventTypeWarning, ReasonPostUnfreezeUnhealthy, msg)
+	}
+	r.completeUnfreeze(dfz)
+	return ctrl.Result{}
+}
+
+// podRestarts sums the container restart counts of the Deployment's Pods. The Deployment was
+// at zero replicas, so all of its Pods were created by the unfreeze.
+func (r *DeploymentFreezerReconciler) podRestarts(ctx context.Context, deploy *appsv1.Deployment) (int32, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deploy.Spec.Selector)
+	if err != nil {
+		return 0, err
+	}
+	var pods corev1.PodList
+	if err := r.APIReader.List(
+		ctx,
+		&pods,
+		client.InNamespace(deploy.Namespace),
+		client.MatchingLabelsSelector{Selector: selector},
+	); err != nil {
+		return 0, err
+	}
+
+	var restarts int32
+	for _, pod := range pods.Items {
+		for _, cs := range pod.Status.ContainerStatuses {
+			restarts += cs.RestartCount
+		}
+	}
+	return restarts, nil
+}
+
+// postUnfreezeVerdict decides the PostUnfreezeHealthy condition at the end of the observation window.
+func postUnfreezeVerdict(
+	deploy *appsv1.Deployment,
+	restarts int32,
+) (freezerv1alpha1.ConditionStatus, freezerv1alpha1.ConditionReason, string) {
+	desired := ptr.Deref(deploy.Spec.Replicas, 1)
+	switch {
+	case restarts > 0:
+		return freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonCrashLooping,
+			fmt.Sprintf(msgPostUnfreezeCrashLoopingFmt, restarts)
+	case deploy.Status.AvailableReplicas < desired:
+		return freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonUnavailable,
+			fmt.Sprintf(msgPostUnfreezeUnavailableFmt, deploy.Status.AvailableReplicas, desired)
+	default:
+		return freezerv1alpha1.ConditionStatusTrue, freezerv1alpha1.ConditionReasonHealthy, msgPostUnfreezeHealthy
+	}
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPostUnfreeze(t *testing.T) {
+	newDeploy := func(desired, available int32) *appsv1.Deployment {
+		d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web"}}
+		d.Spec.Replicas = ptr.To(desired)
+		d.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+		d.Status.AvailableReplicas = available
+		return d
+	}
+	newPod := func(name string, labels map[string]string, restarts ...int32) *corev1.Pod {
+		p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, Labels: labels}}
+		for _, n := range restarts {
+			p.Status.ContainerStatuses = append(p.Status.ContainerStatuses, corev1.ContainerStatus{RestartCount: n})
+		}
+		return p
+	}
+
+	t.Run("Verdict_Healthy", func(t *testing.T) {
+		t.Parallel()
+		status, reason, _ := postUnfreezeVerdict(newDeploy(3, 3), 0)
+		assert.Equal(t, freezerv1alpha1.ConditionStatusTrue, status)
+		assert.Equal(t, freezerv1alpha1.ConditionReasonHealthy, reason)
+	})
+
+	t.Run("Verdict_RestartsAreCrashLooping", func(t *testing.T) {
+		t.Parallel()
+		status, reason, _ := postUnfreezeVerdict(newDeploy(3, 3), 2)
+		assert.Equal(t, freezerv1alpha1.ConditionStatusFalse, status)
+		assert.Equal(t, freezerv1alpha1.ConditionReasonCrashLooping, reason)
+	})
+
+	t.Run("Verdict_Unavailable", func(t *testing.T) {
+		t.Parallel()
+		status, reason, _ := postUnfreezeVerdict(newDeploy(3, 1), 0)
+		assert.Equal(t, freezerv1alpha1.ConditionStatusFalse, status)
+		assert.Equal(t, freezerv1alpha1.ConditionReasonUnavailable, reason)
+	})
+
+	t.Run("PodRestarts_SumsSelectedPods", func(t *testing.T) {
+		t.Parallel()
+		c := fake.NewClientBuilder().WithObjects(
+			newPod("web-1", map[string]string{"app": "web"}, 1, 2),
+			newPod("web-2", map[string]string{"app": "web"}, 0),
+			newPod("api-1", map[string]string{"app": "api"}, 5),
+		).Build()
+		r := &DeploymentFreezerReconciler{Client: c, APIReader: c}
+
+		restarts, err := r.podRestarts(context.Background(), newDeploy(2, 2))
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), restarts)
+	})
+}
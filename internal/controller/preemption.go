@@ -0,0 +1,171 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// frozenByOwner reads the DFZ a frozen-by value names, or returns nil if the value does not name
+// a DFZ of namespace that exists under that UID.
+func (r *DeploymentFreezerReconciler) frozenByOwner(
+	ctx context.Context,
+	namespace, frozenBy string,
+) (*freezerv1alpha1.DeploymentFreezer, error) {
+	name, uid, ok := parseFrozenBy(namespace, frozenBy)
+	if !ok {
+		return nil, nil
+	}
+	var owner freezerv1alpha1.DeploymentFreezer
+	if err := r.APIReader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &owner); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	if uid != "" && uid != owner.UID {
+		return nil, nil
+	}
+	return &owner, nil
+}
+
+// preemptible reports whether dfz may take the target over from owner. The owner must have a
+// lower priority and hold a target that is frozen or being frozen, with its original replicas
+// recorded. Diverted traffic is restored from the owner's spec, so such owners are waited for,
+// as are owners that are paused or being deleted, which would not notice.
+func preemptible(dfz, owner *freezerv1alpha1.DeploymentFreezer) bool {
+	return owner != nil &&
+		owner.Spec.Priority < dfz.Spec.Priority &&
+		(owner.Status.Phase == freezerv1alpha1.PhaseFreezing || owner.Status.Phase == freezerv1alpha1.PhaseFrozen) &&
+		owner.Status.OriginalReplicas != nil &&
+		owner.Spec.MaintenancePage == nil && owner.Spec.Standby == nil &&
+		len(owner.Status.MaintenanceIngresses) == 0 && owner.Status.Standby == nil &&
+		!isPaused(owner) && owner.DeletionTimestamp.IsZero()
+}
+
+// preempt takes the target over from a lower-priority owner, in two reconciles. The first copies
+// the owner's freeze state into the DFZ status and lets it be written; the second moves the
+// frozen-by annotation. The owner then finds itself preempted rather than overwritten, and the
+// target is never restored in between. It reports false when the owner is to be waited for.
+func (r *DeploymentFreezerReconciler) preempt(
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	target freeze.Freezable,
+	frozenBy string,
+) (ctrl.Result, bool) {
+	if dfz.Spec.Priority == 0 || r.dryRun(dfz) {
+		abandonPreemption(dfz)
+		return ctrl.Result{}, false
+	}
+	owner, err := r.frozenByOwner(ctx, dfz.Namespace, frozenBy)
+	if err != nil {
+		r.operationFailed(dfz, opRead, fmt.Sprintf(msgReadErrorFmt, err))
+		return ctrl.Result{RequeueAfter: requeueShort}, true
+	}
+	if !preemptible(dfz, owner) {
+		abandonPreemption(dfz)
+		return ctrl.Result{}, false
+	}
+	if _, allowed, err := r.checkPolicy(ctx, dfz); err != nil {
+		return ctrl.Result{RequeueAfter: requeueShort}, true
+	} else if !allowed {
+		return ctrl.Result{}, true
+	}
+
+	prepared := dfz.Status.Preempted != nil && dfz.Status.Preempted.UID == owner.UID
+	// Copied again before the takeover: the owner may have recorded more since.
+	dfz.Status.OriginalReplicas = owner.Status.OriginalReplicas
+	dfz.Status.OriginalPaused = owner.Status.OriginalPaused
+	dfz.Status.Snapshot = owner.Status.Snapshot.DeepCopy()
+	dfz.Status.Preempted = &freezerv1alpha1.PreemptedFreeze{
+		Name:     owner.Name,
+		UID:      owner.UID,
+		Priority: owner.Spec.Priority,
+	}
+	if dfz.Status.Phase == "" {
+		r.setPhase(dfz, freezerv1alpha1.PhasePending)
+	}
+	if !prepared {
+		return ctrl.Result{RequeueAfter: requeueShort}, true
+	}
+
+	if err := target.AcquireOwnership(ctx, frozenByValue(dfz), r.patchOpts(dfz)...); err != nil {
+		r.operationFailed(dfz, opAcquireOwnership, fmt.Sprintf(msgPreemptionFailedFmt, owner.Name, err))
+		return ctrl.Result{RequeueAfter: requeueShort}, true
+	}
+	now := metav1.NewTime(r.Clock.Now().UTC())
+	dfz.Status.Preempted.Time = &now
+	setCondition(
+		dfz,
+		freezerv1alpha1.ConditionTypeOwnership,
+		freezerv1alpha1.ConditionStatusTrue,
+		freezerv1alpha1.ConditionReasonAcquired,
+		fmt.Sprintf(msgOwnershipPreemptedFromFmt, dfz.Name, target.Object().GetNamespace(),
+			target.Object().GetName(), owner.Name, owner.Spec.Priority),
+	)
+	r.Recorder.Eventf(dfz, corev1.EventTypeNormal, ReasonPreempting, msgPreempting,
+		target.Object().GetNamespace(), target.Object().GetName(), owner.Name, owner.Spec.Priority)
+	return ctrl.Result{RequeueAfter: requeueShort}, true
+}
+
+// settlePreemption completes the record of a takeover whose status write was lost, or drops a
+// prepared one once the target is free: the copied state is stale, and the freeze starts afresh.
+func (r *DeploymentFreezerReconciler) settlePreemption(dfz *freezerv1alpha1.DeploymentFreezer, frozenBy string) {
+	p := dfz.Status.Preempted
+	switch {
+	case p == nil || p.Time != nil:
+	case isFrozenBy(frozenBy, dfz):
+		now := metav1.NewTime(r.Clock.Now().UTC())
+		p.Time = &now
+	case frozenBy == "":
+		abandonPreemption(dfz)
+	}
+}
+
+// abandonPreemption drops a prepared takeover and the freeze state copied for it.
+func abandonPreemption(dfz *freezerv1alpha1.DeploymentFreezer) {
+	if dfz.Status.Preempted == nil || dfz.Status.Preempted.Time != nil {
+		return
+	}
+	dfz.Status.Preempted = nil
+	dfz.Status.OriginalReplicas = nil
+	dfz.Status.OriginalPaused = nil
+	dfz.Status.Snapshot = nil
+}
+
+// preemptedBy returns the DFZ named by frozenBy if it took the target over from dfz by preemption.
+func (r *DeploymentFreezerReconciler) preemptedBy(
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	frozenBy string,
+) (*freezerv1alpha1.DeploymentFreezer, error) {
+	owner, err := r.frozenByOwner(ctx, dfz.Namespace, frozenBy)
+	if err != nil || owner == nil || owner.Status.Preempted == nil || owner.Status.Preempted.UID != dfz.UID {
+		return nil, err
+	}
+	return owner, nil
+}
+
+// markPreempted aborts a DFZ whose target was taken over by a higher-priority one. The target
+// stays frozen under the new owner, which restores it in the end.
+func (r *DeploymentFreezerReconciler) markPreempted(
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	obj client.Object,
+	by *freezerv1alpha1.DeploymentFreezer,
+) {
+	r.setPhase(dfz, freezerv1alpha1.PhaseAborted)
+	setCondition(
+		dfz,
+		freezerv1alpha1.ConditionTypeOwnership,
+		freezerv1alpha1.ConditionStatusFalse,
+		freezerv1alpha1.ConditionReasonPreempted,
+		fmt.Sprintf(msgPreemptedByFmt, by.Name, by.Spec.Priority),
+	)
+	countOwnershipConflict(dfz, conflictPreempted)
+	r.Recorder.Eventf(dfz, corev1.EventTypeNormal, ReasonPreempted, msgPreempted,
+		obj.GetNamespace(), obj.GetName(), by.Name, by.Spec.Priority)
+}
@@ -0,0 +1,179 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPreemption(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// newObjects returns a Frozen holder of priority 1, its Deployment at zero, and a Pending
+	// DFZ of the given priority for the same Deployment.
+	newObjects := func(
+		ns string,
+		priority int32,
+	) (holder, preemptor *freezerv1alpha1.DeploymentFreezer, deploy *appsv1.Deployment) {
+		newDFZ := func(name string, priority int32) *freezerv1alpha1.DeploymentFreezer {
+			dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns, Name: name, UID: types.UID(name + "-uid"),
+			}}
+			dfz.Spec.TargetRef.Name = "web"
+			dfz.Spec.Priority = priority
+			return dfz
+		}
+		holder = newDFZ("holder", 1)
+		holder.Finalizers = []string{finalizerName}
+		holder.Status.Phase = freezerv1alpha1.PhaseFrozen
+		holder.Status.OriginalReplicas = ptr.To(int32(3))
+		holder.Status.Snapshot = &freezerv1alpha1.AutoscalingSnapshot{Paused: true}
+		holder.Status.FreezeUntil = &metav1.Time{Time: now.Add(time.Hour)}
+		deploy = &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns, Name: "web", UID: "web-uid",
+			Annotations: map[string]string{annoFrozenBy: frozenByValue(holder)},
+		}}
+		deploy.Spec.Replicas = ptr.To(int32(0))
+		return holder, newDFZ("preemptor", priority), deploy
+	}
+	newReconciler := func(objs ...client.Object) (*DeploymentFreezerReconciler, *record.FakeRecorder) {
+		rec := record.NewFakeRecorder(20)
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+			WithStatusSubresource(&freezerv1alpha1.DeploymentFreezer{}).Build()
+		return &DeploymentFreezerReconciler{
+			Client: c, APIReader: c, Recorder: rec, Clock: testingclock.NewFakeClock(now),
+		}, rec
+	}
+	reconcile := func(t *testing.T, r *DeploymentFreezerReconciler, obj client.Object) {
+		t.Helper()
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(obj)})
+		require.NoError(t, err)
+	}
+	get := func(t *testing.T, r *DeploymentFreezerReconciler, obj client.Object) {
+		t.Helper()
+		require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(obj), obj))
+	}
+
+	t.Run("HigherPriority_TakesOverWithoutRestore", func(t *testing.T) {
+		t.Parallel()
+		holder, preemptor, deploy := newObjects("preemption", 5)
+		r, rec := newReconciler(holder, preemptor, deploy)
+
+		// The freeze state is written before the annotation moves.
+		reconcile(t, r, preemptor)
+		get(t, r, preemptor)
+		get(t, r, deploy)
+		assert.Equal(t, frozenByValue(holder), deploy.Annotations[annoFrozenBy])
+		require.NotNil(t, preemptor.Status.Preempted)
+		assert.Equal(t, freezerv1alpha1.PreemptedFreeze{Name: "holder", UID: "holder-uid", Priority: 1},
+			*preemptor.Status.Preempted)
+		assert.Equal(t, ptr.To(int32(3)), preemptor.Status.OriginalReplicas)
+
+		reconcile(t, r, preemptor)
+		get(t, r, preemptor)
+		get(t, r, deploy)
+		assert.Equal(t, frozenByValue(preemptor), deploy.Annotations[annoFrozenBy])
+		assert.NotNil(t, preemptor.Status.Preempted.Time)
+
+		reconcile(t, r, holder)
+		get(t, r, holder)
+		assert.Equal(t, freezerv1alpha1.PhaseAborted, holder.Status.Phase)
+		assert.True(t, hasCondition(holder, freezerv1alpha1.ConditionTypeOwnership,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonPreempted))
+		assert.Equal(t, 1.0, testutil.ToFloat64(ownershipConflictsTotal.WithLabelValues("preemption", conflictPreempted)))
+
+		reconcile(t, r, preemptor)
+		get(t, r, preemptor)
+		get(t, r, deploy)
+		assert.Equal(t, freezerv1alpha1.PhaseFrozen, preemptor.Status.Phase)
+		assert.Equal(t, ptr.To(int32(3)), preemptor.Status.OriginalReplicas)
+		assert.Equal(t, &freezerv1alpha1.AutoscalingSnapshot{Paused: true}, preemptor.Status.Snapshot)
+		assert.Equal(t, int32(0), *deploy.Spec.Replicas, "the target was never restored")
+
+		events := drainEvents(rec)
+		assert.Contains(t, events,
+			"Normal Preempting Took Deployment preemption/web over from holder (priority 1) with its recorded replicas")
+		assert.Contains(t, events,
+			"Normal Preempted Deployment preemption/web was taken over by preemptor (priority 5); it stays frozen")
+	})
+
+	t.Run("SamePriority_Waits", func(t *testing.T) {
+		t.Parallel()
+		holder, preemptor, deploy := newObjects("preemption-equal", 1)
+		r, _ := newReconciler(holder, preemptor, deploy)
+
+		reconcile(t, r, preemptor)
+		get(t, r, preemptor)
+		assert.Nil(t, preemptor.Status.Preempted)
+		assert.True(t, hasCondition(preemptor, freezerv1alpha1.ConditionTypeWaitingForOwnership,
+			freezerv1alpha1.ConditionStatusTrue, freezerv1alpha1.ConditionReasonHeldByOtherOwner))
+	})
+
+	t.Run("ReleasedBeforeTakeover_FreezesAfresh", func(t *testing.T) {
+		t.Parallel()
+		holder, preemptor, deploy := newObjects("preemption-released", 5)
+		r, _ := newReconciler(holder, preemptor, deploy)
+		reconcile(t, r, preemptor)
+
+		// The holder unfreezes to 2 replicas before the takeover.
+		get(t, r, deploy)
+		delete(deploy.Annotations, annoFrozenBy)
+		deploy.Spec.Replicas = ptr.To(int32(2))
+		require.NoError(t, r.Update(context.Background(), deploy))
+
+		reconcile(t, r, preemptor)
+		get(t, r, preemptor)
+		assert.Nil(t, preemptor.Status.Preempted)
+		assert.Equal(t, ptr.To(int32(2)), preemptor.Status.OriginalReplicas)
+		assert.Equal(t, freezerv1alpha1.PhaseFreezing, preemptor.Status.Phase)
+	})
+}
+
+func TestPreemptible(t *testing.T) {
+	dfz := &freezerv1alpha1.DeploymentFreezer{}
+	dfz.Spec.Priority = 5
+	newOwner := func() *freezerv1alpha1.DeploymentFreezer {
+		owner := &freezerv1alpha1.DeploymentFreezer{}
+		owner.Spec.Priority = 1
+		owner.Status.Phase = freezerv1alpha1.PhaseFrozen
+		owner.Status.OriginalReplicas = ptr.To(int32(3))
+		return owner
+	}
+
+	assert.True(t, preemptible(dfz, newOwner()))
+	assert.False(t, preemptible(dfz, nil), "not a DeploymentFreezer")
+	for name, mutate := range map[string]func(*freezerv1alpha1.DeploymentFreezer){
+		"SamePriority": func(o *freezerv1alpha1.DeploymentFreezer) { o.Spec.Priority = 5 },
+		"Unfreezing":   func(o *freezerv1alpha1.DeploymentFreezer) { o.Status.Phase = freezerv1alpha1.PhaseUnfreezing },
+		"NoReplicas":   func(o *freezerv1alpha1.DeploymentFreezer) { o.Status.OriginalReplicas = nil },
+		"Standby": func(o *freezerv1alpha1.DeploymentFreezer) {
+			o.Status.Standby = &freezerv1alpha1.StandbyStatus{ServiceName: "web"}
+		},
+		"Maintenance": func(o *freezerv1alpha1.DeploymentFreezer) { o.Status.MaintenanceIngresses = []string{"web"} },
+		"Paused":      func(o *freezerv1alpha1.DeploymentFreezer) { o.Annotations = map[string]string{annoPaused: "true"} },
+		"Deleting":    func(o *freezerv1alpha1.DeploymentFreezer) { o.DeletionTimestamp = &metav1.Time{Time: time.Now()} },
+	} {
+		owner := newOwner()
+		mutate(owner)
+		assert.False(t, preemptible(dfz, owner), name)
+	}
+}
@@ -0,0 +1,288 @@
+package controller
+
+import (
+	"math/bits"
+	"sync"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// priorityDeadline is assigned to DFZs whose unfreeze is imminent or overdue so
+	// they are processed before bulk work (new freezes, resyncs).
+	priorityDeadline = 100
+	// deadlineImminentWindow is how close to FreezeUntil a DFZ must be to jump the queue.
+	deadlineImminentWindow = 10 * time.Second
+	// maxFairnessTier bounds how far fairness lowers a priority. It stays below the gap between
+	// the priorities in use (LowPriority, 0, priorityDeadline), so fairness only orders items
+	// of the same priority.
+	maxFairnessTier = 16
+)
+
+// deadlineTracker remembers the unfreeze deadline of every DFZ that has one.
+// It is fed by the reconciler and read by the work queue when items are added.
+type deadlineTracker struct {
+	mu        sync.RWMutex
+	deadlines map[types.NamespacedName]time.Time
+}
+
+func newDeadlineTracker() *deadlineTracker {
+	return &deadlineTracker{deadlines: map[types.NamespacedName]time.Time{}}
+}
+
+// observe records or forgets the DFZ's deadline depending on its phase.
+func (t *deadlineTracker) observe(dfz *freezerv1alpha1.DeploymentFreezer) {
+	if t == nil {
+		return
+	}
+	nn := types.NamespacedName{Namespace: dfz.Namespace, Name: dfz.Name}
+	switch {
+	case dfz.Status.Phase == freezerv1alpha1.PhaseUnfreezing && dfz.Status.PostUnfreeze != nil:
+		// Replicas are already restored; only the post-unfreeze observation is left.
+		t.forget(nn)
+	case dfz.Status.Phase == freezerv1alpha1.PhaseUnfreezing:
+		// Already overdue: the zero time sorts before any "now".
+		t.set(nn, time.Time{})
+	case dfz.Status.Phase == freezerv1alpha1.PhaseFrozen && dfz.Status.FreezeUntil != nil:
+		t.set(nn, dfz.Status.FreezeUntil.Time)
+	default:
+		t.forget(nn)
+	}
+}
+
+func (t *deadlineTracker) set(nn types.NamespacedName, deadline time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.deadlines[nn] = deadline
+}
+
+func (t *deadlineTracker) forget(nn types.NamespacedName) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.deadlines, nn)
+}
+
+// priority returns the queue priority for an item that becomes ready at readyAt.
+func (t *deadlineTracker) priority(nn types.NamespacedName, readyAt time.Time) int {
+	t.mu.RLock()
+	deadline, ok := t.deadlines[nn]
+	t.mu.RUnlock()
+	if ok && !readyAt.Before(deadline.Add(-deadlineImminentWindow)) {
+		return priorityDeadline
+	}
+	return 0
+}
+
+// lateBy returns how far past its deadline the DFZ is at now, or 0 if it has none or is not late.
+// Unfreezing DFZs, tracked with the zero deadline, are not measured.
+func (t *deadlineTracker) lateBy(nn types.NamespacedName, now time.Time) time.Duration {
+	t.mu.RLock()
+	deadline, ok := t.deadlines[nn]
+	t.mu.RUnlock()
+	if !ok || deadline.IsZero() || !now.After(deadline) {
+		return 0
+	}
+	return now.Sub(deadline)
+}
+
+// deadlineQueue is a priority queue that raises the priority of DFZs with an
+// imminent or overdue unfreeze, regardless of who enqueued them, and shares the
+// workers fairly between namespaces. It also feeds the per-namespace queue metrics
+// and the deadline lag.
+//
+// Delays are held here rather than in the priority queue, which hands out items added
+// without a delay before delayed ones that became ready: an unfreeze requeued for its
+// deadline would otherwise wait behind every DFZ created in the meantime.
+type deadlineQueue struct {
+	priorityqueue.PriorityQueue[reconcile.Request]
+	tracker     *deadlineTracker
+	clock       clock.PassiveClock
+	rateLimiter workqueue.TypedRateLimiter[reconcile.Request]
+	// timers runs the delays. They are wall-clock durations even under a simulated reconciler clock.
+	timers clock.WithDelayedExecution
+
+	mu       sync.Mutex
+	queued   map[reconcile.Request]*queuedItem
+	ready    map[string]int // ready items by namespace
+	shutdown bool
+}
+
+// queuedItem is what the queue knows of an item between its first add and its hand-out.
+type queuedItem struct {
+	label    string // namespace label of the queue metrics
+	priority int    // highest priority it was added with, before fairness
+	tier     int    // fairness tier, set once ready
+	ready    bool   // in the priority queue; otherwise waiting for readyAt
+	readyAt  time.Time
+	timer    clock.Timer
+}
+
+func (r *DeploymentFreezerReconciler) newDeadlineQueue(
+	name string,
+	rateLimiter workqueue.TypedRateLimiter[reconcile.Request],
+) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+	if rateLimiter == nil {
+		rateLimiter = workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](
+			5*time.Millisecond, 1000*time.Second)
+	}
+	return &deadlineQueue{
+		PriorityQueue: priorityqueue.New(name, func(o *priorityqueue.Opts[reconcile.Request]) {
+			o.RateLimiter = rateLimiter
+		}),
+		tracker:     r.deadlines,
+		clock:       r.Clock,
+		rateLimiter: rateLimiter,
+		timers:      clock.RealClock{},
+		queued:      map[reconcile.Request]*queuedItem{},
+		ready:       map[string]int{},
+	}
+}
+
+// fairnessTier is how far an item is lowered within its priority when rank ready items of its
+// namespace are ahead of it. Tiers grow with the logarithm of the rank: a namespace with one
+// item queued is served before the bulk of a namespace with thousands, while the number of
+// distinct priorities, and so of queue metric series, stays bounded.
+func fairnessTier(rank int) int {
+	return min(bits.Len(uint(rank)), maxFairnessTier)
+}
+
+func (q *deadlineQueue) AddWithOpts(o priorityqueue.AddOpts, items ...reconcile.Request) {
+	for _, item := range items {
+		after := o.After
+		if o.RateLimited {
+			if backoff := q.rateLimiter.When(item); after == 0 || backoff < after {
+				after = backoff
+			}
+		}
+		q.mu.Lock()
+		q.add(item, o.Priority, after)
+		q.mu.Unlock()
+	}
+}
+
+// add counts an add and queues the item, now or after the delay. The queue de-duplicates, so
+// the depth only grows for new items, and the earliest delay wins.
+func (q *deadlineQueue) add(item reconcile.Request, priority int, after time.Duration) {
+	qi, ok := q.queued[item]
+	if !ok {
+		qi = &queuedItem{label: metricNamespaces.label(item.Namespace), priority: priority}
+		q.queued[item] = qi
+		queueDepth.WithLabelValues(qi.label).Inc()
+	}
+	queueAddsTotal.WithLabelValues(qi.label).Inc()
+	qi.priority = max(qi.priority, priority)
+	if q.shutdown {
+		return
+	}
+	switch {
+	case qi.ready:
+		// Already ready: a delay cannot hold it back, but a higher priority applies.
+		q.push(item, qi)
+	case after <= 0:
+		q.release(item, qi)
+	default:
+		at := q.timers.Now().Add(after)
+		if qi.timer != nil && !at.Before(qi.readyAt) {
+			return
+		}
+		if qi.timer != nil {
+			qi.timer.Stop()
+		}
+		qi.readyAt = at
+		qi.timer = q.timers.AfterFunc(after, func() {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			// A stale timer, stopped too late, finds the item rescheduled or handed out.
+			if !q.shutdown && q.queued[item] == qi && !qi.ready && qi.readyAt.Equal(at) {
+				qi.timer = nil // fired
+				q.release(item, qi)
+			}
+		})
+	}
+}
+
+// release moves a waiting item into the priority queue, behind the items of its namespace
+// that are already there.
+func (q *deadlineQueue) release(item reconcile.Request, qi *queuedItem) {
+	if qi.timer != nil {
+		qi.timer.Stop()
+		qi.timer = nil
+	}
+	qi.ready = true
+	qi.tier = fairnessTier(q.ready[item.Namespace])
+	q.ready[item.Namespace]++
+	q.push(item, qi)
+}
+
+// push adds a ready item to the priority queue, raised for an imminent deadline and lowered
+// by its fairness tier.
+func (q *deadlineQueue) push(item reconcile.Request, qi *queuedItem) {
+	qi.priority = max(qi.priority, q.tracker.priority(item.NamespacedName, q.clock.Now()))
+	q.PriorityQueue.AddWithOpts(priorityqueue.AddOpts{Priority: qi.priority - qi.tier}, item)
+}
+
+// GetWithPriority takes the next item off the queue. The controller only calls this method.
+// The priority returned leaves out fairness, so a requeue is not penalized twice.
+func (q *deadlineQueue) GetWithPriority() (reconcile.Request, int, bool) {
+	item, priority, shutdown := q.PriorityQueue.GetWithPriority()
+	if shutdown {
+		return item, priority, shutdown
+	}
+	q.mu.Lock()
+	if qi, ok := q.queued[item]; ok {
+		delete(q.queued, item)
+		if q.ready[item.Namespace]--; q.ready[item.Namespace] <= 0 {
+			delete(q.ready, item.Namespace)
+		}
+		queueDepth.WithLabelValues(qi.label).Dec()
+		priority = qi.priority
+	}
+	q.mu.Unlock()
+	if late := q.tracker.lateBy(item.NamespacedName, q.clock.Now()); late > 0 {
+		deadlineLag.Observe(late.Seconds())
+	}
+	return item, priority, shutdown
+}
+
+func (q *deadlineQueue) Add(item reconcile.Request) {
+	q.AddWithOpts(priorityqueue.AddOpts{}, item)
+}
+
+func (q *deadlineQueue) AddAfter(item reconcile.Request, after time.Duration) {
+	q.AddWithOpts(priorityqueue.AddOpts{After: after}, item)
+}
+
+func (q *deadlineQueue) AddRateLimited(item reconcile.Request) {
+	q.AddWithOpts(priorityqueue.AddOpts{RateLimited: true}, item)
+}
+
+func (q *deadlineQueue) ShutDown() {
+	q.stopTimers()
+	q.PriorityQueue.ShutDown()
+}
+
+func (q *deadlineQueue) ShutDownWithDrain() {
+	q.stopTimers()
+	q.PriorityQueue.ShutDownWithDrain()
+}
+
+// stopTimers drops the items still waiting for their delay.
+func (q *deadlineQueue) stopTimers() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.shutdown = true
+	for _, qi := range q.queued {
+		if qi.timer != nil {
+			qi.timer.Stop()
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	testingclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestDeadlineTrackerPriority(t *testing.T) {
+	nn := types.NamespacedName{Namespace: "ns", Name: "dfz"}
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newDFZ := func(phase freezerv1alpha1.Phase, until *time.Time) *freezerv1alpha1.DeploymentFreezer {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: nn.Namespace, Name: nn.Name}}
+		dfz.Status.Phase = phase
+		if until != nil {
+			ts := metav1.NewTime(*until)
+			dfz.Status.FreezeUntil = &ts
+		}
+		return dfz
+	}
+
+	t.Run("Unknown_DefaultPriority", func(t *testing.T) {
+		t.Parallel()
+		tr := newDeadlineTracker()
+		assert.Equal(t, 0, tr.priority(nn, now))
+	})
+
+	t.Run("FrozenFarFromDeadline_DefaultPriority", func(t *testing.T) {
+		t.Parallel()
+		tr := newDeadlineTracker()
+		until := now.Add(time.Hour)
+		tr.observe(newDFZ(freezerv1alpha1.PhaseFrozen, &until))
+		assert.Equal(t, 0, tr.priority(nn, now))
+	})
+
+	t.Run("FrozenReadyAtDeadline_HighPriority", func(t *testing.T) {
+		t.Parallel()
+		tr := newDeadlineTracker()
+		until := now.Add(time.Hour)
+		tr.observe(newDFZ(freezerv1alpha1.PhaseFrozen, &until))
+		assert.Equal(t, priorityDeadline, tr.priority(nn, until))
+		assert.Equal(t, priorityDeadline, tr.priority(nn, until.Add(-deadlineImminentWindow)))
+	})
+
+	t.Run("Unfreezing_HighPriority", func(t *testing.T) {
+		t.Parallel()
+		tr := newDeadlineTracker()
+		tr.observe(newDFZ(freezerv1alpha1.PhaseUnfreezing, nil))
+		assert.Equal(t, priorityDeadline, tr.priority(nn, now))
+	})
+
+	t.Run("Completed_Forgotten", func(t *testing.T) {
+		t.Parallel()
+		tr := newDeadlineTracker()
+		tr.observe(newDFZ(freezerv1alpha1.PhaseUnfreezing, nil))
+		tr.observe(newDFZ(freezerv1alpha1.PhaseCompleted, nil))
+		assert.Equal(t, 0, tr.priority(nn, now))
+	})
+}
+
+func TestDeadlineQueueMetrics(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	r := &DeploymentFreezerReconciler{Clock: testingclock.NewFakeClock(now), deadlines: newDeadlineTracker()}
+	q := r.newDeadlineQueue("metrics-test", nil)
+	defer q.ShutDown()
+	pq := q.(*deadlineQueue)
+	// Namespaces unique to this test, so parallel tests do not move the series.
+	item := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "queue-metrics", Name: "dfz"}}
+	depth := func() float64 { return testutil.ToFloat64(queueDepth.WithLabelValues("queue-metrics")) }
+
+	until := metav1.NewTime(now.Add(-3 * time.Second))
+	dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "queue-metrics", Name: "dfz"}}
+	dfz.Status.Phase = freezerv1alpha1.PhaseFrozen
+	dfz.Status.FreezeUntil = &until
+	r.deadlines.observe(dfz)
+
+	q.Add(item)
+	q.Add(item)
+	assert.Equal(t, 2.0, testutil.ToFloat64(queueAddsTotal.WithLabelValues("queue-metrics")))
+	assert.Equal(t, 1.0, depth())
+
+	got, _, shutdown := pq.GetWithPriority()
+	require.False(t, shutdown)
+	assert.Equal(t, item, got)
+	assert.Equal(t, 0.0, depth())
+	// The lag observed for the deadline 3s ago.
+	assert.Equal(t, 3*time.Second, r.deadlines.lateBy(item.NamespacedName, now))
+	q.Done(got)
+}
+
+func TestDeadlineQueueFairness(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	newQueue := func(t *testing.T) (*deadlineQueue, *DeploymentFreezerReconciler, *testingclock.FakeClock) {
+		r := &DeploymentFreezerReconciler{Clock: testingclock.NewFakeClock(now), deadlines: newDeadlineTracker()}
+		q := r.newDeadlineQueue("fairness-test", nil).(*deadlineQueue)
+		timers := testingclock.NewFakeClock(now)
+		q.timers = timers
+		t.Cleanup(q.ShutDown)
+		return q, r, timers
+	}
+	// Namespaces unique to this test, so parallel tests do not move the queue metrics.
+	request := func(namespace, name string) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}
+	}
+	addNoisy := func(q *deadlineQueue, n int) {
+		for i := range n {
+			q.Add(request("fairness-noisy", fmt.Sprintf("dfz-%d", i)))
+		}
+	}
+	get := func(t *testing.T, q *deadlineQueue) (reconcile.Request, int) {
+		item, priority, shutdown := q.GetWithPriority()
+		require.False(t, shutdown)
+		q.Done(item)
+		return item, priority
+	}
+
+	t.Run("QuietNamespace_ServedBeforeNoisyBacklog", func(t *testing.T) {
+		t.Parallel()
+		q, _, _ := newQueue(t)
+		addNoisy(q, 100)
+		quiet := request("fairness-quiet", "dfz")
+		q.Add(quiet)
+
+		first, _ := get(t, q)
+		assert.Equal(t, "dfz-0", first.Name)
+		second, priority := get(t, q)
+		assert.Equal(t, quiet, second)
+		assert.Equal(t, 0, priority, "fairness is not handed back to the controller for requeues")
+		third, priority := get(t, q)
+		assert.Equal(t, "dfz-1", third.Name)
+		assert.Equal(t, 0, priority)
+	})
+
+	t.Run("DelayedDeadline_ServedBeforeReadyBacklog", func(t *testing.T) {
+		t.Parallel()
+		q, r, timers := newQueue(t)
+		unfreeze := request("fairness-deadline", "dfz")
+		q.AddAfter(unfreeze, time.Minute)
+		addNoisy(q, 10)
+		assert.Equal(t, 10, q.Len(), "delayed items are not ready")
+
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "fairness-deadline", Name: "dfz"}}
+		dfz.Status.Phase = freezerv1alpha1.PhaseUnfreezing
+		r.deadlines.observe(dfz)
+		timers.Step(time.Minute)
+		require.Eventually(t, func() bool { return q.Len() == 11 }, time.Second, time.Millisecond)
+
+		item, priority := get(t, q)
+		assert.Equal(t, unfreeze, item)
+		assert.Equal(t, priorityDeadline, priority)
+	})
+
+	t.Run("EarliestDelay_Wins", func(t *testing.T) {
+		t.Parallel()
+		q, _, timers := newQueue(t)
+		item := request("fairness-delay", "dfz")
+		q.AddAfter(item, time.Minute)
+		q.AddAfter(item, time.Hour)
+		q.AddAfter(item, 10*time.Second)
+		timers.Step(10 * time.Second)
+		require.Eventually(t, func() bool { return q.Len() == 1 }, time.Second, time.Millisecond)
+		got, _ := get(t, q)
+		assert.Equal(t, item, got)
+
+		timers.Step(time.Hour)
+		assert.False(t, timers.HasWaiters(), "the replaced delays were stopped")
+		assert.Equal(t, 0, q.Len())
+	})
+}
+
+func TestFairnessTier(t *testing.T) {
+	for rank, tier := range map[int]int{0: 0, 1: 1, 2: 2, 3: 2, 4: 3, 1000: 10, 1 << 30: maxFairnessTier} {
+		assert.Equal(t, tier, fairnessTier(rank), "rank %d", rank)
+	}
+}
+
+func TestNamespaceLabels(t *testing.T) {
+	l := &namespaceLabels{seen: map[string]bool{}, max: 2}
+	assert.Equal(t, "a", l.label("a"))
+	assert.Equal(t, "b", l.label("b"))
+	assert.Equal(t, otherNamespaces, l.label("c"))
+	assert.Equal(t, "a", l.label("a"))
+}
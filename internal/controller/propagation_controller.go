@@ -0,0 +1,245 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	// propagationFinalizer keeps a propagated DFZ until its copies are deleted from every cluster.
+	propagationFinalizer = "apps.boolfixer.dev/propagation"
+
+	// propagationInterval is how often the copies of an unfinished propagated DFZ are checked.
+	// Managed clusters report the state of a copy periodically, so nothing can be watched.
+	propagationInterval = 30 * time.Second
+)
+
+// Propagator copies DeploymentFreezers to managed clusters and reads back the state of the copies.
+type Propagator interface {
+	// Propagate creates or updates the copy of dfz on cluster and returns its last reported state.
+	Propagate(ctx context.Context, cluster string, dfz *freezerv1alpha1.DeploymentFreezer) (freezerv1alpha1.ClusterFreeze, error)
+	// Withdraw deletes the copy of dfz from cluster, which unfreezes the target there, and
+	// reports whether it is gone.
+	Withdraw(ctx context.Context, cluster string, dfz *freezerv1alpha1.DeploymentFreezer) (bool, error)
+}
+
+// PropagationReconciler freezes the targets of DFZs with spec.propagation on managed clusters, and
+// aggregates the phases of the copies into the DFZ status. DeploymentFreezerReconciler ignores them.
+type PropagationReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Clock is the time source for phase transition times; defaults to the real clock.
+	Clock clock.Clock
+	// Shard selects the DFZs handled by this replica.
+	Shard Shard
+	// Propagator copies the DFZs to the clusters; nil denies every propagated DFZ.
+	Propagator Propagator
+}
+
+// +kubebuilder:rbac:groups=apps.boolfixer.dev,resources=deploymentfreezers,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=apps.boolfixer.dev,resources=deploymentfreezers/status,verbs=get;update;patch
+
+func (r *PropagationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var dfz freezerv1alpha1.DeploymentFreezer
+	if err := r.Get(ctx, req.NamespacedName, &dfz); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if dfz.Spec.Propagation == nil || !r.Shard.Owns(&dfz) {
+		return ctrl.Result{}, nil
+	}
+
+	orig := dfz.DeepCopy()
+	if r.Propagator == nil {
+		// A finalizer left by an earlier run stays: the copies are only deleted through it.
+		if dfz.DeletionTimestamp.IsZero() {
+			recordPhase(&dfz, freezerv1alpha1.PhaseDenied, r.Clock.Now())
+			setStableCondition(&dfz, freezerv1alpha1.ConditionTypePropagated, freezerv1alpha1.ConditionStatusFalse,
+				freezerv1alpha1.ConditionReasonPropagationDisabled, msgPropagationDisabled)
+		}
+		return ctrl.Result{}, r.patchStatus(ctx, orig, &dfz)
+	}
+
+	if !dfz.DeletionTimestamp.IsZero() {
+		return r.withdraw(ctx, &dfz)
+	}
+	if controllerutil.AddFinalizer(&dfz, propagationFinalizer) {
+		if err := r.Update(ctx, &dfz); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	clusters := r.propagate(ctx, &dfz)
+	recordPhase(&dfz, propagatedPhase(clusters), r.Clock.Now())
+	r.setPropagatedCondition(&dfz, clusters)
+
+	// Clusters removed from the spec keep their entry until their copy is gone.
+	for _, c := range dfz.Status.Clusters {
+		if slices.Contains(dfz.Spec.Propagation.Clusters, c.Cluster) {
+			continue
+		}
+		gone, err := r.Propagator.Withdraw(ctx, c.Cluster, &dfz)
+		switch {
+		case err != nil:
+			c.Message = fmt.Sprintf(msgPropagationFailedFmt, c.Cluster, err)
+		case !gone:
+			c.Message = msgWithdrawing
+		}
+		if err != nil || !gone {
+			clusters = append(clusters, c)
+		}
+	}
+	dfz.Status.Clusters = clusters
+	dfz.Status.ObservedGeneration = dfz.Generation
+	syncWaitConditions(&dfz)
+
+	if err := r.patchStatus(ctx, orig, &dfz); err != nil {
+		return ctrl.Result{}, err
+	}
+	if isTerminalPhase(dfz.Status.Phase) && len(clusters) == len(dfz.Spec.Propagation.Clusters) {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: propagationInterval}, nil
+}
+
+// propagate applies the copy of dfz on every cluster of its spec. A cluster that cannot be
+// reached keeps its last reported phase.
+func (r *PropagationReconciler) propagate(
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+) []freezerv1alpha1.ClusterFreeze {
+	clusters := make([]freezerv1alpha1.ClusterFreeze, 0, len(dfz.Spec.Propagation.Clusters))
+	for _, name := range dfz.Spec.Propagation.Clusters {
+		state, err := r.Propagator.Propagate(ctx, name, dfz)
+		if err != nil {
+			state = freezerv1alpha1.ClusterFreeze{Cluster: name}
+			if i := slices.IndexFunc(dfz.Status.Clusters, func(c freezerv1alpha1.ClusterFreeze) bool {
+				return c.Cluster == name
+			}); i >= 0 {
+				state.Phase = dfz.Status.Clusters[i].Phase
+			}
+			state.Message = fmt.Sprintf(msgPropagationFailedFmt, name, err)
+		}
+		clusters = append(clusters, state)
+	}
+	return clusters
+}
+
+func (r *PropagationReconciler) setPropagatedCondition(
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	clusters []freezerv1alpha1.ClusterFreeze,
+) {
+	var failed []string
+	for _, c := range clusters {
+		if c.Message != "" {
+			failed = append(failed, c.Cluster)
+		}
+	}
+	if len(failed) > 0 {
+		setStableCondition(dfz, freezerv1alpha1.ConditionTypePropagated, freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonNotApplied, fmt.Sprintf(msgNotAppliedFmt, strings.Join(failed, ", ")))
+		return
+	}
+	setStableCondition(dfz, freezerv1alpha1.ConditionTypePropagated, freezerv1alpha1.ConditionStatusTrue,
+		freezerv1alpha1.ConditionReasonApplied, fmt.Sprintf(msgPropagatedFmt, len(clusters)))
+}
+
+// withdraw deletes the copies from every cluster, in the spec or still in the status, and
+// removes the finalizer once they are all gone.
+func (r *PropagationReconciler) withdraw(ctx context.Context, dfz *freezerv1alpha1.DeploymentFreezer) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(dfz, propagationFinalizer) {
+		return ctrl.Result{}, nil
+	}
+	names := slices.Clone(dfz.Spec.Propagation.Clusters)
+	for _, c := range dfz.Status.Clusters {
+		names = append(names, c.Cluster)
+	}
+	slices.Sort(names)
+
+	pending := false
+	for _, name := range slices.Compact(names) {
+		gone, err := r.Propagator.Withdraw(ctx, name, dfz)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		pending = pending || !gone
+	}
+	if pending {
+		// The copies restore their targets before they are gone.
+		return ctrl.Result{RequeueAfter: propagationInterval}, nil
+	}
+	controllerutil.RemoveFinalizer(dfz, propagationFinalizer)
+	return ctrl.Result{}, r.Update(ctx, dfz)
+}
+
+func (r *PropagationReconciler) patchStatus(
+	ctx context.Context,
+	orig, dfz *freezerv1alpha1.DeploymentFreezer,
+) error {
+	if equality.Semantic.DeepEqual(orig.Status, dfz.Status) {
+		return nil
+	}
+	return r.Status().Patch(ctx, dfz, client.MergeFrom(orig))
+}
+
+// propagatedPhase aggregates the phases reported by the clusters: the least advanced phase while
+// any cluster is in progress, then the phase the clusters finished in, or Completed if they differ.
+func propagatedPhase(clusters []freezerv1alpha1.ClusterFreeze) freezerv1alpha1.Phase {
+	progress := []freezerv1alpha1.Phase{
+		freezerv1alpha1.PhasePending,
+		freezerv1alpha1.PhaseFreezing,
+		freezerv1alpha1.PhaseFrozen,
+		freezerv1alpha1.PhaseUnfreezing,
+	}
+	if len(clusters) == 0 {
+		return freezerv1alpha1.PhasePending
+	}
+	least := len(progress)
+	for _, c := range clusters {
+		if i := slices.Index(progress, cmp.Or(c.Phase, freezerv1alpha1.PhasePending)); i >= 0 {
+			least = min(least, i)
+		}
+	}
+	if least < len(progress) {
+		return progress[least]
+	}
+	for _, c := range clusters[1:] {
+		if c.Phase != clusters[0].Phase {
+			return freezerv1alpha1.PhaseCompleted
+		}
+	}
+	return clusters[0].Phase
+}
+
+// isPropagated selects the DFZs with spec.propagation.
+var isPropagated = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	dfz, ok := obj.(*freezerv1alpha1.DeploymentFreezer)
+	return ok && dfz.Spec.Propagation != nil
+})
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PropagationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Clock == nil {
+		r.Clock = clock.RealClock{}
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(
+			&freezerv1alpha1.DeploymentFreezer{},
+			builder.WithPredicates(isPropagated, predicate.GenerationChangedPredicate{}, r.Shard.Predicate()),
+		).
+		Named("propagation").
+		Complete(r)
+}
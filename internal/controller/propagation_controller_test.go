@@ -0,0 +1,229 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakePropagator reports fixed states and records withdrawals; clusters in pending are not gone yet.
+type fakePropagator struct {
+	states    map[string]freezerv1alpha1.ClusterFreeze
+	errs      map[string]error
+	pending   map[string]bool
+	withdrawn []string
+}
+
+func (p *fakePropagator) Propagate(
+	_ context.Context,
+	cluster string,
+	_ *freezerv1alpha1.DeploymentFreezer,
+) (freezerv1alpha1.ClusterFreeze, error) {
+	if err := p.errs[cluster]; err != nil {
+		return freezerv1alpha1.ClusterFreeze{}, err
+	}
+	state := p.states[cluster]
+	state.Cluster = cluster
+	return state, nil
+}
+
+func (p *fakePropagator) Withdraw(_ context.Context, cluster string, _ *freezerv1alpha1.DeploymentFreezer) (bool, error) {
+	p.withdrawn = append(p.withdrawn, cluster)
+	return !p.pending[cluster], nil
+}
+
+func TestPropagation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	newDFZ := func(clusters ...string) *freezerv1alpha1.DeploymentFreezer {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "release", Generation: 1}}
+		dfz.Spec.TargetRef = freezerv1alpha1.DeploymentTargetRef{Name: "web"}
+		dfz.Spec.Propagation = &freezerv1alpha1.Propagation{Clusters: clusters}
+		return dfz
+	}
+	newReconciler := func(dfz *freezerv1alpha1.DeploymentFreezer, p Propagator) (*PropagationReconciler, client.Client) {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(dfz).
+			WithStatusSubresource(&freezerv1alpha1.DeploymentFreezer{}).Build()
+		return &PropagationReconciler{Client: c, Clock: testingclock.NewFakeClock(time.Now()), Propagator: p}, c
+	}
+	reconcile := func(t *testing.T, r *PropagationReconciler, dfz *freezerv1alpha1.DeploymentFreezer) ctrl.Result {
+		res, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(dfz)})
+		require.NoError(t, err)
+		return res
+	}
+	get := func(t *testing.T, c client.Client, dfz *freezerv1alpha1.DeploymentFreezer) *freezerv1alpha1.DeploymentFreezer {
+		var got freezerv1alpha1.DeploymentFreezer
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(dfz), &got))
+		return &got
+	}
+
+	t.Run("Propagated_PhasesAggregated", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ("east", "west")
+		r, c := newReconciler(dfz, &fakePropagator{states: map[string]freezerv1alpha1.ClusterFreeze{
+			"east": {Phase: freezerv1alpha1.PhaseFrozen},
+			"west": {Phase: freezerv1alpha1.PhaseFreezing},
+		}})
+		res := reconcile(t, r, dfz)
+
+		got := get(t, c, dfz)
+		assert.Equal(t, propagationInterval, res.RequeueAfter)
+		assert.Contains(t, got.Finalizers, propagationFinalizer)
+		assert.Equal(t, freezerv1alpha1.PhaseFreezing, got.Status.Phase)
+		assert.Equal(t, []freezerv1alpha1.ClusterFreeze{
+			{Cluster: "east", Phase: freezerv1alpha1.PhaseFrozen},
+			{Cluster: "west", Phase: freezerv1alpha1.PhaseFreezing},
+		}, got.Status.Clusters)
+		assert.Equal(t, int64(1), got.Status.ObservedGeneration)
+		assert.True(t, hasCondition(got, freezerv1alpha1.ConditionTypePropagated,
+			freezerv1alpha1.ConditionStatusTrue, freezerv1alpha1.ConditionReasonApplied))
+	})
+
+	t.Run("AllCompleted_StopsPolling", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ("east")
+		r, c := newReconciler(dfz, &fakePropagator{states: map[string]freezerv1alpha1.ClusterFreeze{
+			"east": {Phase: freezerv1alpha1.PhaseCompleted},
+		}})
+		res := reconcile(t, r, dfz)
+
+		assert.True(t, res.IsZero())
+		got := get(t, c, dfz)
+		assert.Equal(t, freezerv1alpha1.PhaseCompleted, got.Status.Phase)
+		assert.True(t, hasCondition(got, freezerv1alpha1.ConditionTypeCompleted,
+			freezerv1alpha1.ConditionStatusTrue, freezerv1alpha1.ConditionReason(freezerv1alpha1.PhaseCompleted)))
+	})
+
+	t.Run("ClusterFailure_KeepsLastPhase", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ("east", "west")
+		dfz.Status.Clusters = []freezerv1alpha1.ClusterFreeze{{Cluster: "west", Phase: freezerv1alpha1.PhaseFrozen}}
+		r, c := newReconciler(dfz, &fakePropagator{
+			states: map[string]freezerv1alpha1.ClusterFreeze{"east": {Phase: freezerv1alpha1.PhaseFrozen}},
+			errs:   map[string]error{"west": errors.New("hub unreachable")},
+		})
+		reconcile(t, r, dfz)
+
+		got := get(t, c, dfz)
+		assert.Equal(t, freezerv1alpha1.PhaseFrozen, got.Status.Phase)
+		assert.Equal(t, freezerv1alpha1.ClusterFreeze{
+			Cluster: "west",
+			Phase:   freezerv1alpha1.PhaseFrozen,
+			Message: "cannot propagate to cluster west: hub unreachable",
+		}, got.Status.Clusters[1])
+		assert.True(t, hasCondition(got, freezerv1alpha1.ConditionTypePropagated,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonNotApplied))
+	})
+
+	t.Run("ClusterRemoved_WithdrawnBeforeDropped", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ("east")
+		dfz.Status.Clusters = []freezerv1alpha1.ClusterFreeze{{Cluster: "west", Phase: freezerv1alpha1.PhaseFrozen}}
+		p := &fakePropagator{pending: map[string]bool{"west": true}}
+		r, c := newReconciler(dfz, p)
+		reconcile(t, r, dfz)
+
+		got := get(t, c, dfz)
+		require.Len(t, got.Status.Clusters, 2)
+		assert.Equal(t, freezerv1alpha1.ClusterFreeze{
+			Cluster: "west", Phase: freezerv1alpha1.PhaseFrozen, Message: msgWithdrawing,
+		}, got.Status.Clusters[1])
+		assert.Equal(t, freezerv1alpha1.PhasePending, got.Status.Phase)
+
+		p.pending = nil
+		reconcile(t, r, dfz)
+		got = get(t, c, dfz)
+		assert.Equal(t, []freezerv1alpha1.ClusterFreeze{{Cluster: "east"}}, got.Status.Clusters)
+		assert.Equal(t, []string{"west", "west"}, p.withdrawn)
+	})
+
+	t.Run("Deleted_FinalizerKeptUntilWithdrawn", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ("east")
+		dfz.Finalizers = []string{propagationFinalizer}
+		dfz.Status.Clusters = []freezerv1alpha1.ClusterFreeze{{Cluster: "west", Message: msgWithdrawing}}
+		p := &fakePropagator{pending: map[string]bool{"east": true}}
+		r, c := newReconciler(dfz, p)
+		require.NoError(t, c.Delete(context.Background(), dfz))
+
+		res := reconcile(t, r, dfz)
+		assert.Equal(t, propagationInterval, res.RequeueAfter)
+		assert.Contains(t, get(t, c, dfz).Finalizers, propagationFinalizer)
+		assert.ElementsMatch(t, []string{"east", "west"}, p.withdrawn)
+
+		p.pending = nil
+		reconcile(t, r, dfz)
+		err := c.Get(context.Background(), client.ObjectKeyFromObject(dfz), &freezerv1alpha1.DeploymentFreezer{})
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("NoPropagator_Denied", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ("east")
+		r, c := newReconciler(dfz, nil)
+		reconcile(t, r, dfz)
+
+		got := get(t, c, dfz)
+		assert.Equal(t, freezerv1alpha1.PhaseDenied, got.Status.Phase)
+		assert.Empty(t, got.Finalizers)
+		assert.True(t, hasCondition(got, freezerv1alpha1.ConditionTypePropagated,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonPropagationDisabled))
+	})
+
+	t.Run("DeploymentFreezerReconciler_Ignores", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ("east")
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(dfz).
+			WithStatusSubresource(&freezerv1alpha1.DeploymentFreezer{}).Build()
+		r := &DeploymentFreezerReconciler{Client: c, APIReader: c, Recorder: record.NewFakeRecorder(10)}
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(dfz)})
+		require.NoError(t, err)
+
+		got := get(t, c, dfz)
+		assert.Empty(t, got.Status.Phase)
+		assert.Empty(t, got.Finalizers)
+	})
+}
+
+func TestPropagatedPhase(t *testing.T) {
+	phases := func(ps ...freezerv1alpha1.Phase) []freezerv1alpha1.ClusterFreeze {
+		out := make([]freezerv1alpha1.ClusterFreeze, len(ps))
+		for i, p := range ps {
+			out[i] = freezerv1alpha1.ClusterFreeze{Cluster: string(rune('a' + i)), Phase: p}
+		}
+		return out
+	}
+	for _, tc := range []struct {
+		name     string
+		clusters []freezerv1alpha1.ClusterFreeze
+		want     freezerv1alpha1.Phase
+	}{
+		{"None_Pending", nil, freezerv1alpha1.PhasePending},
+		{"NotReported_Pending", phases("", freezerv1alpha1.PhaseFrozen), freezerv1alpha1.PhasePending},
+		{"LeastAdvanced", phases(freezerv1alpha1.PhaseUnfreezing, freezerv1alpha1.PhaseFrozen), freezerv1alpha1.PhaseFrozen},
+		{"InProgressBeatsFinished", phases(freezerv1alpha1.PhaseDenied, freezerv1alpha1.PhaseFreezing), freezerv1alpha1.PhaseFreezing},
+		{"AllDenied", phases(freezerv1alpha1.PhaseDenied, freezerv1alpha1.PhaseDenied), freezerv1alpha1.PhaseDenied},
+		{"MixedFinished_Completed", phases(freezerv1alpha1.PhaseAborted, freezerv1alpha1.PhaseCompleted), freezerv1alpha1.PhaseCompleted},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, propagatedPhase(tc.clusters))
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// registerResyncRunnable enqueues every DFZ still in progress once the cache is synced, then
+// every ResyncInterval. Watch events filtered out, or lost while no replica was leader, would
+// otherwise leave a DFZ waiting until its next requeue, if it has one. It only runs on the leader.
+func (r *DeploymentFreezerReconciler) registerResyncRunnable(mgr ctrl.Manager, resyncCh chan<- event.GenericEvent) error {
+	return mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		if ok := mgr.GetCache().WaitForCacheSync(ctx); !ok {
+			return ctx.Err()
+		}
+		if err := r.resync(ctx, resyncCh); err != nil {
+			return err
+		}
+		if r.ResyncInterval <= 0 {
+			return nil
+		}
+		// The wall clock, not the reconciler clock: the interval is operational, not a freeze window.
+		ticker := time.NewTicker(r.ResyncInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				if err := r.resync(ctx, resyncCh); err != nil {
+					// A failed scan is retried on the next tick rather than stopping the manager.
+					log.FromContext(ctx).Error(err, "failed to resync DeploymentFreezers")
+				}
+			}
+		}
+	}))
+}
+
+// resync sends an event for every DFZ of this shard that is not in a terminal phase, or is
+// being deleted. Deadlines are recorded first, so overdue unfreezes are taken off the queue first.
+func (r *DeploymentFreezerReconciler) resync(ctx context.Context, resyncCh chan<- event.GenericEvent) error {
+	var list freezerv1alpha1.DeploymentFreezerList
+	if err := r.List(ctx, &list); err != nil {
+		return err
+	}
+	for i := range list.Items {
+		dfz := &list.Items[i]
+		if !r.Shard.Owns(dfz) || (isTerminalPhase(dfz.Status.Phase) && dfz.DeletionTimestamp.IsZero()) {
+			continue
+		}
+		r.deadlines.observe(dfz)
+		select {
+		case resyncCh <- event.GenericEvent{Object: dfz}:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestResync(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	now := time.Now().Truncate(time.Second)
+	newDFZ := func(name string, phase freezerv1alpha1.Phase) *freezerv1alpha1.DeploymentFreezer {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name}}
+		dfz.Status.Phase = phase
+		return dfz
+	}
+	frozen := newDFZ("frozen", freezerv1alpha1.PhaseFrozen)
+	frozen.Status.FreezeUntil = &metav1.Time{Time: now.Add(-time.Minute)}
+	deleting := newDFZ("deleting", freezerv1alpha1.PhaseCompleted)
+	deleting.Finalizers = []string{finalizerName}
+	deleting.DeletionTimestamp = &metav1.Time{Time: now}
+	objs := []client.Object{
+		newDFZ("new", ""),
+		newDFZ("pending", freezerv1alpha1.PhasePending),
+		frozen,
+		newDFZ("unfreezing", freezerv1alpha1.PhaseUnfreezing),
+		newDFZ("completed", freezerv1alpha1.PhaseCompleted),
+		newDFZ("aborted", freezerv1alpha1.PhaseAborted),
+		deleting,
+	}
+	newReconciler := func() *DeploymentFreezerReconciler {
+		return &DeploymentFreezerReconciler{
+			Client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+			deadlines: newDeadlineTracker(),
+		}
+	}
+	names := func(ch chan event.GenericEvent) []string {
+		close(ch)
+		var out []string
+		for e := range ch {
+			out = append(out, e.Object.GetName())
+		}
+		return out
+	}
+
+	t.Run("InProgressOrDeleting_Enqueued", func(t *testing.T) {
+		t.Parallel()
+		r := newReconciler()
+		ch := make(chan event.GenericEvent, len(objs))
+		require.NoError(t, r.resync(context.Background(), ch))
+
+		assert.ElementsMatch(t, []string{"new", "pending", "frozen", "unfreezing", "deleting"}, names(ch))
+		deadline, ok := r.deadlines.deadlines[types.NamespacedName{Namespace: "ns", Name: "frozen"}]
+		assert.True(t, ok, "deadlines are known before the events are queued")
+		assert.True(t, deadline.Equal(frozen.Status.FreezeUntil.Time))
+	})
+
+	t.Run("OtherShard_Skipped", func(t *testing.T) {
+		t.Parallel()
+		r := newReconciler()
+		r.Shard = Shard{Count: 2, ID: 0, Mode: ShardModeNamespace}
+		if r.Shard.Owns(frozen) {
+			r.Shard.ID = 1
+		}
+		ch := make(chan event.GenericEvent, len(objs))
+		require.NoError(t, r.resync(context.Background(), ch))
+		assert.Empty(t, names(ch))
+	})
+
+	t.Run("Cancelled_StopsSending", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		// Nothing reads the channel: a blocked send must give way to the cancellation.
+		assert.NoError(t, newReconciler().resync(ctx, make(chan event.GenericEvent)))
+	})
+}
@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// savedResources are the requests the savings counters report.
+var savedResources = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+
+// observeSavings adds the CPU and memory requests the DFZ's freeze kept off the cluster to the
+// savings counters: status.resourcesFreed times the time from reaching Frozen until now. DFZs
+// frozen before resourcesFreed was recorded fall back to the current template of obj. A DFZ that
+// never reached Frozen saved nothing.
+func observeSavings(dfz *freezerv1alpha1.DeploymentFreezer, obj client.Object, now time.Time) {
+	frozenAt, ok := dfz.Status.PhaseTransitionTimes[freezerv1alpha1.PhaseFrozen]
+	if !ok || dfz.Status.OriginalReplicas == nil {
+		return
+	}
+	freed := dfz.Status.ResourcesFreed
+	if freed == nil {
+		if freed = resourcesFreed(obj, *dfz.Status.OriginalReplicas); freed == nil {
+			return
+		}
+	}
+	seconds := now.Sub(frozenAt.Time).Seconds()
+	if seconds <= 0 {
+		return
+	}
+	ns := metricNamespaces.label(dfz.Namespace)
+	cpuCoreSecondsSaved.WithLabelValues(ns).Add(freed.CPU.AsApproximateFloat64() * seconds)
+	memoryByteSecondsSaved.WithLabelValues(ns).Add(freed.Memory.AsApproximateFloat64() * seconds)
+}
+
+// resourcesFreed returns the requests of replicas Pods of obj, or nil if obj has no pod template.
+func resourcesFreed(obj client.Object, replicas int32) *freezerv1alpha1.ResourcesFreed {
+	tpl := podTemplate(obj)
+	if tpl == nil {
+		return nil
+	}
+	reqs := podRequests(&tpl.Spec)
+	freed := &freezerv1alpha1.ResourcesFreed{
+		Replicas: replicas,
+		CPU:      reqs.Cpu().DeepCopy(),
+		Memory:   reqs.Memory().DeepCopy(),
+	}
+	freed.CPU.Mul(int64(replicas))
+	freed.Memory.Mul(int64(replicas))
+	return freed
+}
+
+// podRequests returns the CPU and memory requests of a Pod of spec the way the scheduler counts
+// them: the pod-level requests if set, otherwise the app containers and sidecars together, or
+// the largest init container if it needs more while it runs, plus the pod overhead.
+func podRequests(spec *corev1.PodSpec) corev1.ResourceList {
+	reqs := corev1.ResourceList{}
+	sidecars := corev1.ResourceList{}
+	initReqs := corev1.ResourceList{}
+	for _, c := range spec.Containers {
+		addRequests(reqs, c.Resources.Requests)
+	}
+	for _, c := range spec.InitContainers {
+		if c.RestartPolicy != nil && *c.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			// A sidecar keeps running next to the app containers and the init containers after it.
+			addRequests(reqs, c.Resources.Requests)
+			addRequests(sidecars, c.Resources.Requests)
+			maxRequests(initReqs, sidecars)
+			continue
+		}
+		running := sidecars.DeepCopy()
+		addRequests(running, c.Resources.Requests)
+		maxRequests(initReqs, running)
+	}
+	maxRequests(reqs, initReqs)
+	if spec.Resources != nil {
+		for _, name := range savedResources {
+			if q, ok := spec.Resources.Requests[name]; ok {
+				reqs[name] = q.DeepCopy()
+			}
+		}
+	}
+	addRequests(reqs, spec.Overhead)
+	return reqs
+}
+
+// addRequests adds the CPU and memory of add to reqs.
+func addRequests(reqs, add corev1.ResourceList) {
+	for _, name := range savedResources {
+		q, ok := add[name]
+		if !ok {
+			continue
+		}
+		sum := reqs[name].DeepCopy()
+		sum.Add(q)
+		reqs[name] = sum
+	}
+}
+
+// maxRequests raises the CPU and memory of reqs to those of other where other is larger.
+func maxRequests(reqs, other corev1.ResourceList) {
+	for _, name := range savedResources {
+		if q, ok := other[name]; ok {
+			if cur := reqs[name]; q.Cmp(cur) > 0 {
+				reqs[name] = q.DeepCopy()
+			}
+		}
+	}
+}
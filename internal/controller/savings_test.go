@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestPodRequests(t *testing.T) {
+	requests := func(cpu, memory string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(memory),
+		}}
+	}
+	sidecar := ptr.To(corev1.ContainerRestartPolicyAlways)
+
+	tests := []struct {
+		name      string
+		spec      corev1.PodSpec
+		cpu       float64
+		memoryMiB float64
+	}{
+		{
+			name: "ContainersAdded",
+			spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Resources: requests("250m", "128Mi")},
+				{Resources: requests("500m", "256Mi")},
+			}},
+			cpu: 0.75, memoryMiB: 384,
+		},
+		{
+			name: "LargerInitContainer_Wins",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Resources: requests("2", "64Mi")}},
+				Containers:     []corev1.Container{{Resources: requests("500m", "256Mi")}},
+			},
+			cpu: 2, memoryMiB: 256,
+		},
+		{
+			name: "SidecarAndOverhead_Added",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Resources: requests("100m", "32Mi"), RestartPolicy: sidecar}},
+				Containers:     []corev1.Container{{Resources: requests("500m", "256Mi")}},
+				Overhead:       requests("50m", "16Mi").Requests,
+			},
+			cpu: 0.65, memoryMiB: 304,
+		},
+		{
+			name: "PodLevelRequests_Override",
+			spec: corev1.PodSpec{
+				Resources:  &corev1.ResourceRequirements{Requests: requests("1", "1Gi").Requests},
+				Containers: []corev1.Container{{Resources: requests("500m", "256Mi")}},
+			},
+			cpu: 1, memoryMiB: 1024,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			reqs := podRequests(&tt.spec)
+			assert.InDelta(t, tt.cpu, reqs.Cpu().AsApproximateFloat64(), 1e-9)
+			assert.InDelta(t, tt.memoryMiB, reqs.Memory().AsApproximateFloat64()/(1<<20), 1e-9)
+		})
+	}
+}
+
+func TestObserveSavings(t *testing.T) {
+	frozenAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	deploy := &appsv1.Deployment{}
+	deploy.Spec.Template.Spec.Containers = []corev1.Container{{Resources: corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("1Ki"),
+		},
+	}}}
+	newDFZ := func(ns string) *freezerv1alpha1.DeploymentFreezer {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: ns}}
+		dfz.Status.OriginalReplicas = ptr.To(int32(3))
+		dfz.Status.PhaseTransitionTimes = map[freezerv1alpha1.Phase]metav1.Time{
+			freezerv1alpha1.PhaseFrozen: metav1.NewTime(frozenAt),
+		}
+		return dfz
+	}
+
+	t.Run("Frozen_RequestsTimesReplicasTimesDuration", func(t *testing.T) {
+		t.Parallel()
+		observeSavings(newDFZ("savings-frozen"), deploy, frozenAt.Add(time.Hour))
+		assert.InDelta(t, 0.5*3*3600, testutil.ToFloat64(cpuCoreSecondsSaved.WithLabelValues("savings-frozen")), 1e-6)
+		assert.InDelta(t, 1024*3*3600, testutil.ToFloat64(memoryByteSecondsSaved.WithLabelValues("savings-frozen")), 1e-6)
+	})
+
+	t.Run("ResourcesFreed_PreferredOverTemplate", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ("savings-recorded")
+		dfz.Status.ResourcesFreed = &freezerv1alpha1.ResourcesFreed{
+			Replicas: 3, CPU: resource.MustParse("6"), Memory: resource.MustParse("0"),
+		}
+		observeSavings(dfz, deploy, frozenAt.Add(time.Minute))
+		assert.InDelta(t, 6*60, testutil.ToFloat64(cpuCoreSecondsSaved.WithLabelValues("savings-recorded")), 1e-6)
+	})
+
+	t.Run("NeverFrozen_NothingSaved", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ("savings-never-frozen")
+		dfz.Status.PhaseTransitionTimes = nil
+		observeSavings(dfz, deploy, frozenAt.Add(time.Hour))
+		assert.Zero(t, testutil.ToFloat64(cpuCoreSecondsSaved.WithLabelValues("savings-never-frozen")))
+	})
+}
+
+func TestResourcesFreed(t *testing.T) {
+	deploy := &appsv1.Deployment{}
+	deploy.Spec.Template.Spec.Containers = []corev1.Container{{Resources: corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("250m"),
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		},
+	}}}
+
+	freed := resourcesFreed(deploy, 4)
+	assert.Equal(t, int32(4), freed.Replicas)
+	assert.Equal(t, "1", freed.CPU.String())
+	assert.Equal(t, "2Gi", freed.Memory.String())
+	assert.Nil(t, resourcesFreed(&corev1.ConfigMap{}, 4))
+}
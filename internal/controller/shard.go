@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"hash/fnv"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	// LabelShard pins a DFZ to an explicit shard when the label shard mode is used.
+	LabelShard = "apps.boolfixer.dev/shard"
+
+	ShardModeNamespace = "namespace"
+	ShardModeLabel     = "label"
+)
+
+// Shard describes which slice of DFZs this controller replica is responsible for.
+// The zero value (Count <= 1) owns everything.
+type Shard struct {
+	// ID of this replica, in [0, Count).
+	ID int
+	// Count is the total number of shards.
+	Count int
+	// Mode selects how DFZs are assigned: by namespace hash or by the shard label.
+	Mode string
+}
+
+// Enabled reports whether sharding is active.
+func (s Shard) Enabled() bool {
+	return s.Count > 1
+}
+
+// LabelValue is the shard label value selecting this replica's DFZs.
+func (s Shard) LabelValue() string {
+	return strconv.Itoa(s.ID)
+}
+
+// Owns reports whether obj belongs to this shard. In label mode an object without a valid
+// shard label falls back to the namespace hash, so exactly one replica still owns it.
+func (s Shard) Owns(obj client.Object) bool {
+	if !s.Enabled() {
+		return true
+	}
+	if s.Mode == ShardModeLabel {
+		if id, ok := s.labeledShard(obj); ok {
+			return id == s.ID
+		}
+	}
+	return s.OwnsNamespace(obj.GetNamespace())
+}
+
+// labeledShard returns the shard of the object's shard label, if it names one in [0, Count).
+func (s Shard) labeledShard(obj client.Object) (int, bool) {
+	v, ok := obj.GetLabels()[LabelShard]
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.Atoi(v)
+	if err != nil || id < 0 || id >= s.Count {
+		return 0, false
+	}
+	return id, true
+}
+
+// LabelSelector selects the objects a label mode shard may own: those labeled for it and those
+// without a valid shard label, whose owner is picked by Owns. It excludes the other shards'.
+func (s Shard) LabelSelector() (labels.Selector, error) {
+	others := make([]string, 0, s.Count-1)
+	for id := range s.Count {
+		if id != s.ID {
+			others = append(others, strconv.Itoa(id))
+		}
+	}
+	req, err := labels.NewRequirement(LabelShard, selection.NotIn, others)
+	if err != nil {
+		return nil, err
+	}
+	return labels.NewSelector().Add(*req), nil
+}
+
+// OwnedNamespaces narrows the watched namespaces to those hashing to this shard, or returns nil
+// when it owns none of them or everything is watched.
+func (s Shard) OwnedNamespaces(watched map[string]cache.Config) map[string]cache.Config {
+	owned := map[string]cache.Config{}
+	for ns, cfg := range watched {
+		if s.OwnsNamespace(ns) {
+			owned[ns] = cfg
+		}
+	}
+	if len(owned) == 0 {
+		return nil
+	}
+	return owned
+}
+
+// OwnsNamespace reports whether the namespace hashes to this shard.
+func (s Shard) OwnsNamespace(ns string) bool {
+	if !s.Enabled() {
+		return true
+	}
+	return shardForNamespace(ns, s.Count) == s.ID
+}
+
+// Predicate filters out events for objects that belong to other shards.
+func (s Shard) Predicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(s.Owns)
+}
+
+// DeploymentPredicate filters Deployment events by namespace hash. In label mode
+// Deployments are not labeled, so filtering happens when mapping to this shard's DFZs.
+func (s Shard) DeploymentPredicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return s.Mode == ShardModeLabel || s.OwnsNamespace(obj.GetNamespace())
+	})
+}
+
+func shardForNamespace(ns string, count int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(ns))
+	return int(h.Sum32() % uint32(count))
+}
@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+func TestShardOwns(t *testing.T) {
+	newDFZ := func(ns string, labels map[string]string) *freezerv1alpha1.DeploymentFreezer {
+		return &freezerv1alpha1.DeploymentFreezer{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "dfz", Labels: labels},
+		}
+	}
+
+	t.Run("Disabled_OwnsEverything", func(t *testing.T) {
+		t.Parallel()
+		s := Shard{}
+		assert.True(t, s.Owns(newDFZ("a", nil)))
+		assert.True(t, s.Owns(newDFZ("b", nil)))
+	})
+
+	t.Run("NamespaceMode_ExactlyOneShardOwnsEachNamespace", func(t *testing.T) {
+		t.Parallel()
+		const count = 4
+		for i := 0; i < 50; i++ {
+			ns := fmt.Sprintf("team-%d", i)
+			owners := 0
+			for id := 0; id < count; id++ {
+				if (Shard{ID: id, Count: count, Mode: ShardModeNamespace}).Owns(newDFZ(ns, nil)) {
+					owners++
+				}
+			}
+			assert.Equal(t, 1, owners, "namespace %s", ns)
+		}
+	})
+
+	t.Run("LabelMode_UsesShardLabel", func(t *testing.T) {
+		t.Parallel()
+		s := Shard{ID: 1, Count: 3, Mode: ShardModeLabel}
+		assert.True(t, s.Owns(newDFZ("a", map[string]string{LabelShard: "1"})))
+		assert.False(t, s.Owns(newDFZ("a", map[string]string{LabelShard: "2"})))
+	})
+
+	t.Run("LabelMode_UnlabeledFallsBackToNamespaceHash", func(t *testing.T) {
+		t.Parallel()
+		const count = 3
+		for _, lbls := range []map[string]string{nil, {LabelShard: "7"}, {LabelShard: "x"}} {
+			owners := 0
+			for id := 0; id < count; id++ {
+				s := Shard{ID: id, Count: count, Mode: ShardModeLabel}
+				if s.Owns(newDFZ("team-a", lbls)) {
+					owners++
+					assert.Equal(t, shardForNamespace("team-a", count), id)
+				}
+			}
+			assert.Equal(t, 1, owners, "labels %v", lbls)
+		}
+	})
+
+	t.Run("LabelMode_SelectorExcludesOtherShards", func(t *testing.T) {
+		t.Parallel()
+		selector, err := (Shard{ID: 1, Count: 3, Mode: ShardModeLabel}).LabelSelector()
+		require.NoError(t, err)
+		assert.True(t, selector.Matches(labels.Set{LabelShard: "1"}))
+		assert.True(t, selector.Matches(labels.Set{}))
+		assert.False(t, selector.Matches(labels.Set{LabelShard: "0"}))
+		assert.False(t, selector.Matches(labels.Set{LabelShard: "2"}))
+	})
+}
+
+func TestShardOwnedNamespaces(t *testing.T) {
+	watched := map[string]cache.Config{}
+	for i := range 20 {
+		watched[fmt.Sprintf("team-%d", i)] = cache.Config{}
+	}
+	const count = 3
+	seen := map[string]int{}
+	for id := 0; id < count; id++ {
+		for ns := range (Shard{ID: id, Count: count, Mode: ShardModeNamespace}).OwnedNamespaces(watched) {
+			assert.Equal(t, id, shardForNamespace(ns, count))
+			seen[ns]++
+		}
+	}
+	assert.Len(t, seen, len(watched))
+
+	assert.Nil(t, (Shard{ID: 1, Count: count}).OwnedNamespaces(nil))
+}
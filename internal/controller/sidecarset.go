@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// envSidecarInjected is set to "true" on every container an OpenKruise SidecarSet injects.
+const envSidecarInjected = "IS_INJECTED"
+
+// withoutInjectedSidecars returns spec without the containers SidecarSets injected and the
+// volumes only they mount. SidecarSets inject into Pods, but templates copied from a live Pod or
+// written back by tools that sync live state carry the sidecars too, and a SidecarSet rolling
+// out a new sidecar image must not read as a change of the workload.
+func withoutInjectedSidecars(spec corev1.PodSpec) corev1.PodSpec {
+	if !slices.ContainsFunc(spec.Containers, injectedSidecar) &&
+		!slices.ContainsFunc(spec.InitContainers, injectedSidecar) {
+		return spec
+	}
+	spec = *spec.DeepCopy()
+	sidecarMounts, appMounts := map[string]bool{}, map[string]bool{}
+	for _, c := range slices.Concat(spec.Containers, spec.InitContainers) {
+		mounts := appMounts
+		if injectedSidecar(c) {
+			mounts = sidecarMounts
+		}
+		for _, m := range c.VolumeMounts {
+			mounts[m.Name] = true
+		}
+	}
+	spec.Containers = slices.DeleteFunc(spec.Containers, injectedSidecar)
+	spec.InitContainers = slices.DeleteFunc(spec.InitContainers, injectedSidecar)
+	spec.Volumes = slices.DeleteFunc(spec.Volumes, func(v corev1.Volume) bool {
+		return sidecarMounts[v.Name] && !appMounts[v.Name]
+	})
+	return spec
+}
+
+func injectedSidecar(c corev1.Container) bool {
+	return slices.ContainsFunc(c.Env, func(e corev1.EnvVar) bool {
+		return e.Name == envSidecarInjected && e.Value == "true"
+	})
+}
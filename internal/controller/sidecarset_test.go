@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestHashTemplate_InjectedSidecars(t *testing.T) {
+	injected := []corev1.EnvVar{{Name: envSidecarInjected, Value: "true"}}
+	newDeployment := func(sidecarImage string) *appsv1.Deployment {
+		d := &appsv1.Deployment{}
+		d.Spec.Template.Spec = corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app", Image: "app:1", VolumeMounts: []corev1.VolumeMount{{Name: "shared"}}},
+				{
+					Name:         "log-agent",
+					Image:        sidecarImage,
+					Env:          injected,
+					VolumeMounts: []corev1.VolumeMount{{Name: "shared"}, {Name: "agent-config"}},
+				},
+			},
+			Volumes: []corev1.Volume{{Name: "shared"}, {Name: "agent-config"}},
+		}
+		return d
+	}
+
+	t.Run("SidecarChange_SameHash", func(t *testing.T) {
+		t.Parallel()
+		d := newDeployment("agent:1")
+		other := newDeployment("agent:2")
+		other.Spec.Template.Spec.Volumes = other.Spec.Template.Spec.Volumes[:1]
+		assert.Equal(t, hashTemplate(d), hashTemplate(other))
+	})
+
+	t.Run("AppChange_ChangesHash", func(t *testing.T) {
+		t.Parallel()
+		d := newDeployment("agent:1")
+		other := newDeployment("agent:1")
+		other.Spec.Template.Spec.Containers[0].Image = "app:2"
+		assert.NotEqual(t, hashTemplate(d), hashTemplate(other))
+	})
+
+	t.Run("SharedVolume_Kept", func(t *testing.T) {
+		t.Parallel()
+		spec := withoutInjectedSidecars(newDeployment("agent:1").Spec.Template.Spec)
+		assert.Len(t, spec.Containers, 1)
+		assert.Equal(t, []corev1.Volume{{Name: "shared"}}, spec.Volumes)
+	})
+}
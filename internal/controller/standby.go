@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// annoStandby is set on a Service swapped to a standby Deployment. It records the owning DFZ and
+// both selectors, and is written in the same patch as the swap.
+const annoStandby = "apps.boolfixer.dev/standby"
+
+// standbyRecord is the value of annoStandby.
+type standbyRecord struct {
+	Owner    string            `json:"owner"`
+	Selector map[string]string `json:"selector"`
+	Standby  map[string]string `json:"standby"`
+}
+
+// swapService points the spec.standby Service at the standby Deployment's Pods with a single
+// patch and records the original selector in status. It reports false when the Service or the
+// standby Deployment does not exist, the standby selector cannot be expressed as a Service
+// selector, or the Service is already swapped by another DFZ.
+func (r *DeploymentFreezerReconciler) swapService(ctx context.Context, dfz *freezerv1alpha1.DeploymentFreezer) (bool, error) {
+	sb := dfz.Spec.Standby
+	var svc corev1.Service
+	err := r.APIReader.Get(ctx, types.NamespacedName{Namespace: dfz.Namespace, Name: sb.ServiceName}, &svc)
+	switch {
+	case apierrors.IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	owner := frozenByValue(dfz)
+	if raw, ok := svc.Annotations[annoStandby]; ok {
+		var rec standbyRecord
+		if json.Unmarshal([]byte(raw), &rec) != nil || rec.Owner != owner {
+			return false, nil
+		}
+		dfz.Status.Standby = &freezerv1alpha1.StandbyStatus{ServiceName: svc.Name, OriginalSelector: rec.Selector}
+		return true, nil
+	}
+
+	// The standby Deployment is read from the API server: it may be outside the Deployment cache.
+	var standby appsv1.Deployment
+	err = r.APIReader.Get(ctx, types.NamespacedName{Namespace: dfz.Namespace, Name: sb.DeploymentName}, &standby)
+	switch {
+	case apierrors.IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	// A Service selector is a plain label set, so matchExpressions cannot be carried over.
+	sel := standby.Spec.Selector
+	if sel == nil || len(sel.MatchLabels) == 0 || len(sel.MatchExpressions) > 0 {
+		return false, nil
+	}
+
+	rec := standbyRecord{Owner: owner, Selector: svc.Spec.Selector, Standby: sel.MatchLabels}
+	raw, err := json.Marshal(rec)
+	if err != nil {
+		return false, err
+	}
+	orig := svc.DeepCopy()
+	svc.Spec.Selector = maps.Clone(sel.MatchLabels)
+	if svc.Annotations == nil {
+		svc.Annotations = map[string]string{}
+	}
+	svc.Annotations[annoStandby] = string(raw)
+	if err := r.Patch(ctx, &svc, client.MergeFromWithOptions(orig, client.MergeFromWithOptimisticLock{}),
+		r.patchOpts(dfz)...); err != nil {
+		return false, fmt.Errorf("service %s: %w", svc.Name, err)
+	}
+	dfz.Status.Standby = &freezerv1alpha1.StandbyStatus{ServiceName: svc.Name, OriginalSelector: rec.Selector}
+	return true, nil
+}
+
+// restoreService gives the swapped Service its original selector back and returns its name, or
+// "" if there was nothing to restore. A selector changed since the swap is kept.
+func (r *DeploymentFreezerReconciler) restoreService(ctx context.Context, dfz *freezerv1alpha1.DeploymentFreezer) (string, error) {
+	var name string
+	switch {
+	case dfz.Status.Standby != nil:
+		name = dfz.Status.Standby.ServiceName
+	case dfz.Spec.Standby != nil:
+		name = dfz.Spec.Standby.ServiceName
+	default:
+		return "", nil
+	}
+	var svc corev1.Service
+	if err := r.APIReader.Get(ctx, types.NamespacedName{Namespace: dfz.Namespace, Name: name}, &svc); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+	raw, ok := svc.Annotations[annoStandby]
+	if !ok {
+		return "", nil
+	}
+	var rec standbyRecord
+	if err := json.Unmarshal([]byte(raw), &rec); err != nil || rec.Owner != frozenByValue(dfz) {
+		return "", nil
+	}
+
+	orig := svc.DeepCopy()
+	if maps.Equal(svc.Spec.Selector, rec.Standby) {
+		svc.Spec.Selector = rec.Selector
+	}
+	delete(svc.Annotations, annoStandby)
+	if err := r.Patch(ctx, &svc, client.MergeFromWithOptions(orig, client.MergeFromWithOptimisticLock{}),
+		r.patchOpts(dfz)...); err != nil {
+		return "", fmt.Errorf("service %s: %w", svc.Name, err)
+	}
+	return svc.Name, nil
+}
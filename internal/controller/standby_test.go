@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestStandbyService(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	webSelector := map[string]string{"app": "web"}
+	standbySelector := map[string]string{"app": "web-standby"}
+	newService := func() *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web"},
+			Spec:       corev1.ServiceSpec{Selector: webSelector},
+		}
+	}
+	newStandby := func() *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web-standby"},
+			Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: standbySelector}},
+		}
+	}
+	newReconciler := func(objs ...client.Object) *DeploymentFreezerReconciler {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+		return &DeploymentFreezerReconciler{
+			Client:    c,
+			APIReader: c,
+			Recorder:  record.NewFakeRecorder(10),
+			Clock:     testingclock.NewFakeClock(now),
+		}
+	}
+	newDFZ := func() (*freezerv1alpha1.DeploymentFreezer, *appsv1.Deployment) {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "dfz", UID: "dfz-uid"}}
+		dfz.Spec.Standby = &freezerv1alpha1.Standby{ServiceName: "web", DeploymentName: "web-standby"}
+		dfz.Status.PhaseTransitionTimes = map[freezerv1alpha1.Phase]metav1.Time{
+			freezerv1alpha1.PhaseUnfreezing: metav1.NewTime(now),
+		}
+		deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web"}}
+		return dfz, deploy
+	}
+	getService := func(t *testing.T, r *DeploymentFreezerReconciler) corev1.Service {
+		var svc corev1.Service
+		require.NoError(t, r.Get(context.Background(), client.ObjectKey{Namespace: "shop", Name: "web"}, &svc))
+		return svc
+	}
+
+	t.Run("Freeze_SwapsAndRestoresSelector", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		r := newReconciler(newService(), newStandby())
+		dfz, deploy := newDFZ()
+
+		_, wait := r.divertTraffic(ctx, dfz, deploy)
+		require.False(t, wait)
+		assert.True(t, hasCondition(dfz, freezerv1alpha1.ConditionTypeTraffic,
+			freezerv1alpha1.ConditionStatusTrue, freezerv1alpha1.ConditionReasonMaintenance))
+		require.NotNil(t, dfz.Status.Standby)
+		assert.Equal(t, "web", dfz.Status.Standby.ServiceName)
+		assert.Equal(t, webSelector, dfz.Status.Standby.OriginalSelector)
+
+		svc := getService(t, r)
+		assert.Equal(t, standbySelector, svc.Spec.Selector)
+		assert.Contains(t, svc.Annotations, annoStandby)
+
+		deploy.Status.AvailableReplicas = 1
+		_, wait = r.restoreTraffic(ctx, dfz, deploy, 3)
+		require.False(t, wait)
+		assert.Nil(t, dfz.Status.Standby)
+		svc = getService(t, r)
+		assert.Equal(t, webSelector, svc.Spec.Selector)
+		assert.NotContains(t, svc.Annotations, annoStandby)
+	})
+
+	t.Run("Restore_KeepsSelectorChangedMeanwhile", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		r := newReconciler(newService(), newStandby())
+		dfz, _ := newDFZ()
+		swapped, err := r.swapService(ctx, dfz)
+		require.NoError(t, err)
+		require.True(t, swapped)
+
+		svc := getService(t, r)
+		svc.Spec.Selector = map[string]string{"app": "web-v2"}
+		require.NoError(t, r.Update(ctx, &svc))
+
+		name, err := r.restoreService(ctx, dfz)
+		require.NoError(t, err)
+		assert.Equal(t, "web", name)
+		svc = getService(t, r)
+		assert.Equal(t, map[string]string{"app": "web-v2"}, svc.Spec.Selector)
+		assert.NotContains(t, svc.Annotations, annoStandby)
+	})
+
+	t.Run("Swap_Repeated_KeepsOriginalSelector", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		r := newReconciler(newService(), newStandby())
+		dfz, _ := newDFZ()
+		_, err := r.swapService(ctx, dfz)
+		require.NoError(t, err)
+
+		// A lost status write makes the swap run again against the swapped Service.
+		dfz.Status.Standby = nil
+		swapped, err := r.swapService(ctx, dfz)
+		require.NoError(t, err)
+		assert.True(t, swapped)
+		assert.Equal(t, webSelector, dfz.Status.Standby.OriginalSelector)
+	})
+
+	t.Run("MatchExpressions_NoRoute", func(t *testing.T) {
+		t.Parallel()
+		standby := newStandby()
+		standby.Spec.Selector.MatchExpressions = []metav1.LabelSelectorRequirement{
+			{Key: "track", Operator: metav1.LabelSelectorOpIn, Values: []string{"standby"}},
+		}
+		r := newReconciler(newService(), standby)
+		dfz, deploy := newDFZ()
+		_, wait := r.divertTraffic(context.Background(), dfz, deploy)
+		assert.False(t, wait)
+		assert.True(t, hasCondition(dfz, freezerv1alpha1.ConditionTypeTraffic,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonNoRoute))
+		assert.Equal(t, webSelector, getService(t, r).Spec.Selector)
+	})
+
+	t.Run("OtherOwner_ServiceLeftAlone", func(t *testing.T) {
+		t.Parallel()
+		svc := newService()
+		svc.Annotations = map[string]string{annoStandby: `{"owner":"shop/other/uid"}`}
+		r := newReconciler(svc, newStandby())
+		dfz, _ := newDFZ()
+		swapped, err := r.swapService(context.Background(), dfz)
+		require.NoError(t, err)
+		assert.False(t, swapped)
+
+		name, err := r.restoreService(context.Background(), dfz)
+		require.NoError(t, err)
+		assert.Empty(t, name)
+		assert.Contains(t, getService(t, r).Annotations, annoStandby)
+	})
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"context"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
+	appsv1 "k8s.io/api/apps/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// phaseHandler runs one reconcile of a DFZ in its current phase.
+type phaseHandler func(
+	r *DeploymentFreezerReconciler,
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	deploy *appsv1.Deployment,
+	target freeze.Freezable,
+) (ctrl.Result, error)
+
+// phaseState is one state of the DFZ lifecycle.
+type phaseState struct {
+	// handle is nil for terminal phases: a finished DFZ only waits for its deletion.
+	handle phaseHandler
+	// next lists the phases a DFZ may move to from this one.
+	next []freezerv1alpha1.Phase
+}
+
+// lifecycle is the DFZ state machine. Any phase that is not terminal can end in Denied, when the
+// Deployment is lost to another owner, or in Aborted, when it is deleted or recreated.
+var lifecycle = map[freezerv1alpha1.Phase]phaseState{
+	"": {
+		next: []freezerv1alpha1.Phase{freezerv1alpha1.PhasePending, freezerv1alpha1.PhaseDenied, freezerv1alpha1.PhaseAborted},
+	},
+	freezerv1alpha1.PhasePending: {
+		handle: (*DeploymentFreezerReconciler).handlePendingOrFreezing,
+		// An already drained Deployment is Frozen without passing through Freezing.
+		next: []freezerv1alpha1.Phase{
+			freezerv1alpha1.PhaseFreezing, freezerv1alpha1.PhaseFrozen,
+			freezerv1alpha1.PhaseDenied, freezerv1alpha1.PhaseAborted,
+		},
+	},
+	freezerv1alpha1.PhaseFreezing: {
+		handle: (*DeploymentFreezerReconciler).handlePendingOrFreezing,
+		next:   []freezerv1alpha1.Phase{freezerv1alpha1.PhaseFrozen, freezerv1alpha1.PhaseDenied, freezerv1alpha1.PhaseAborted},
+	},
+	freezerv1alpha1.PhaseFrozen: {
+		handle: (*DeploymentFreezerReconciler).handleFrozen,
+		next:   []freezerv1alpha1.Phase{freezerv1alpha1.PhaseUnfreezing, freezerv1alpha1.PhaseDenied, freezerv1alpha1.PhaseAborted},
+	},
+	freezerv1alpha1.PhaseUnfreezing: {
+		handle: (*DeploymentFreezerReconciler).handleUnfreezing,
+		next:   []freezerv1alpha1.Phase{freezerv1alpha1.PhaseCompleted, freezerv1alpha1.PhaseDenied, freezerv1alpha1.PhaseAborted},
+	},
+	freezerv1alpha1.PhaseCompleted: {},
+	freezerv1alpha1.PhaseDenied:    {},
+	freezerv1alpha1.PhaseAborted:   {},
+}
+
+// canTransition reports whether a single reconcile may move a DFZ from one phase to another,
+// possibly through intermediate phases, as from "" through Pending to Denied.
+func canTransition(from, to freezerv1alpha1.Phase) bool {
+	seen := map[freezerv1alpha1.Phase]bool{from: true}
+	queue := []freezerv1alpha1.Phase{from}
+	for len(queue) > 0 {
+		phase := queue[0]
+		queue = queue[1:]
+		for _, next := range lifecycle[phase].next {
+			if next == to {
+				return true
+			}
+			if !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return false
+}
+
+// Transition is the phase change of a DFZ during one reconcile: from the phase it was read in to
+// the phase its status is written with. Phases passed through in between are not reported.
+type Transition struct {
+	From freezerv1alpha1.Phase
+	To   freezerv1alpha1.Phase
+}
+
+// TransitionHook reacts to a phase change of dfz.
+type TransitionHook func(ctx context.Context, dfz *freezerv1alpha1.DeploymentFreezer, t Transition)
+
+// TransitionHooks are run on every phase change, in order. Pre hooks run before the status is
+// written and may still change it, for example to add a condition; post hooks run only once the
+// status was written, so they see each transition once and never one that was lost to a
+// failed write.
+type TransitionHooks struct {
+	Pre  []TransitionHook
+	Post []TransitionHook
+}
+
+// preTransitionHooks are the built-in pre hooks, run before the configured ones.
+func (r *DeploymentFreezerReconciler) preTransitionHooks() []TransitionHook {
+	return append([]TransitionHook{resetRetryCount}, r.Hooks.Pre...)
+}
+
+// postTransitionHooks are the built-in post hooks, run before the configured ones.
+func (r *DeploymentFreezerReconciler) postTransitionHooks() []TransitionHook {
+	return append([]TransitionHook{countTransition}, r.Hooks.Post...)
+}
+
+// runTransitionHooks runs hooks if the status moves dfz to another phase.
+func runTransitionHooks(ctx context.Context, dfz *freezerv1alpha1.DeploymentFreezer, t Transition, hooks []TransitionHook) {
+	if t.From == t.To {
+		return
+	}
+	for _, hook := range hooks {
+		hook(ctx, dfz, t)
+	}
+}
+
+// checkTransition logs a transition the lifecycle does not allow. Such a transition is a bug in a
+// handler; it is still written and reported to the hooks.
+func checkTransition(ctx context.Context, t Transition) {
+	if t.From != t.To && !canTransition(t.From, t.To) {
+		log.FromContext(ctx).Error(nil, "phase transition not allowed by the lifecycle", "from", t.From, "to", t.To)
+	}
+}
+
+// chargeUsageOnFinish charges the freeze to the namespace quotas once the DFZ reaches a
+// terminal phase. If the charge fails the DFZ is held in the phase it was read in, so the next
+// reconcile makes the transition, and charges it, again: a quota never misses a finished freeze.
+func (r *DeploymentFreezerReconciler) chargeUsageOnFinish(
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	from freezerv1alpha1.Phase,
+) error {
+	if isTerminalPhase(from) || !isTerminalPhase(dfz.Status.Phase) {
+		return nil
+	}
+	if err := r.recordFreezeUsage(ctx, dfz); err != nil {
+		delete(dfz.Status.PhaseTransitionTimes, dfz.Status.Phase)
+		dfz.Status.Phase = from
+		return err
+	}
+	return nil
+}
+
+// countTransition feeds the phase transition metric.
+func countTransition(_ context.Context, _ *freezerv1alpha1.DeploymentFreezer, t Transition) {
+	from := string(t.From)
+	if from == "" {
+		from = "None"
+	}
+	phaseTransitionsTotal.WithLabelValues(from, string(t.To)).Inc()
+}
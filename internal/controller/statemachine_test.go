@@ -0,0 +1,232 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/internal/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestLifecycle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	newDFZ := func(phase freezerv1alpha1.Phase) *freezerv1alpha1.DeploymentFreezer {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "freeze"}}
+		dfz.Status.Phase = phase
+		return dfz
+	}
+	newReconciler := func(objs ...client.Object) *DeploymentFreezerReconciler {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+			WithStatusSubresource(&freezerv1alpha1.DeploymentFreezer{}).Build()
+		return &DeploymentFreezerReconciler{Client: c, APIReader: c}
+	}
+
+	t.Run("Phases_TerminalOnesHaveNoHandler", func(t *testing.T) {
+		t.Parallel()
+		for _, phase := range []freezerv1alpha1.Phase{
+			freezerv1alpha1.PhasePending, freezerv1alpha1.PhaseFreezing, freezerv1alpha1.PhaseFrozen,
+			freezerv1alpha1.PhaseUnfreezing, freezerv1alpha1.PhaseCompleted, freezerv1alpha1.PhaseDenied,
+			freezerv1alpha1.PhaseAborted,
+		} {
+			state, ok := lifecycle[phase]
+			require.True(t, ok, phase)
+			assert.Equal(t, isTerminalPhase(phase), state.handle == nil, phase)
+			assert.Equal(t, isTerminalPhase(phase), len(state.next) == 0, phase)
+		}
+	})
+
+	t.Run("CanTransition", func(t *testing.T) {
+		t.Parallel()
+		for _, tc := range []struct {
+			from, to freezerv1alpha1.Phase
+			want     bool
+		}{
+			{"", freezerv1alpha1.PhaseDenied, true},
+			{freezerv1alpha1.PhasePending, freezerv1alpha1.PhaseFrozen, true},
+			{freezerv1alpha1.PhaseFrozen, freezerv1alpha1.PhaseCompleted, true},
+			{freezerv1alpha1.PhaseFrozen, freezerv1alpha1.PhaseFreezing, false},
+			{freezerv1alpha1.PhaseCompleted, freezerv1alpha1.PhasePending, false},
+			{freezerv1alpha1.PhaseAborted, freezerv1alpha1.PhaseDenied, false},
+		} {
+			assert.Equal(t, tc.want, canTransition(tc.from, tc.to), "%q -> %q", tc.from, tc.to)
+		}
+	})
+
+	t.Run("Hooks_RunAroundStatusWrite", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		dfz := newDFZ(freezerv1alpha1.PhasePending)
+		r := newReconciler(dfz)
+		stored := func() freezerv1alpha1.Phase {
+			got := &freezerv1alpha1.DeploymentFreezer{}
+			require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(dfz), got))
+			return got.Status.Phase
+		}
+		require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(dfz), dfz))
+
+		var calls []string
+		r.Hooks = TransitionHooks{
+			Pre: []TransitionHook{func(_ context.Context, d *freezerv1alpha1.DeploymentFreezer, tr Transition) {
+				calls = append(calls, "pre:"+string(tr.From)+"->"+string(tr.To)+":"+string(stored()))
+				d.Status.PlannedChanges = []string{"written with the transition"}
+			}},
+			Post: []TransitionHook{func(_ context.Context, _ *freezerv1alpha1.DeploymentFreezer, tr Transition) {
+				calls = append(calls, "post:"+string(tr.From)+"->"+string(tr.To)+":"+string(stored()))
+			}},
+		}
+
+		st := newStatusTracker(dfz)
+		r.setPhase(dfz, freezerv1alpha1.PhaseFreezing)
+		r.commitStatus(ctx, dfz, st)
+		assert.Equal(t, []string{"pre:Pending->Freezing:Pending", "post:Pending->Freezing:Freezing"}, calls)
+
+		got := &freezerv1alpha1.DeploymentFreezer{}
+		require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(dfz), got))
+		assert.Equal(t, []string{"written with the transition"}, got.Status.PlannedChanges)
+	})
+
+	t.Run("Hooks_NotRunWithoutPhaseChange", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		dfz := newDFZ(freezerv1alpha1.PhaseFrozen)
+		r := newReconciler(dfz)
+		require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(dfz), dfz))
+		calls := 0
+		hook := func(context.Context, *freezerv1alpha1.DeploymentFreezer, Transition) { calls++ }
+		r.Hooks = TransitionHooks{Pre: []TransitionHook{hook}, Post: []TransitionHook{hook}}
+
+		st := newStatusTracker(dfz)
+		dfz.Status.PlannedChanges = []string{"status changed, phase did not"}
+		r.commitStatus(ctx, dfz, st)
+		assert.Zero(t, calls)
+	})
+
+	t.Run("Hooks_PostSkippedWhenWriteFails", func(t *testing.T) {
+		t.Parallel()
+		// The DFZ is gone, so the status write fails.
+		dfz := newDFZ(freezerv1alpha1.PhasePending)
+		r := newReconciler()
+		var pre, post int
+		r.Hooks = TransitionHooks{
+			Pre:  []TransitionHook{func(context.Context, *freezerv1alpha1.DeploymentFreezer, Transition) { pre++ }},
+			Post: []TransitionHook{func(context.Context, *freezerv1alpha1.DeploymentFreezer, Transition) { post++ }},
+		}
+
+		st := newStatusTracker(dfz)
+		r.setPhase(dfz, freezerv1alpha1.PhaseFreezing)
+		r.commitStatus(context.Background(), dfz, st)
+		assert.Equal(t, 1, pre)
+		assert.Zero(t, post)
+	})
+
+	t.Run("Commit_SurvivesCancelledContext", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ(freezerv1alpha1.PhaseFreezing)
+		// Unlike the API server client, the fake client ignores the context; check it here.
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(dfz).
+			WithStatusSubresource(&freezerv1alpha1.DeploymentFreezer{}).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourcePatch: func(ctx context.Context, c client.Client, sub string, obj client.Object,
+					patch client.Patch, opts ...client.SubResourcePatchOption) error {
+					if err := ctx.Err(); err != nil {
+						return err
+					}
+					return c.SubResource(sub).Patch(ctx, obj, patch, opts...)
+				},
+			}).Build()
+		r := &DeploymentFreezerReconciler{Client: c}
+		require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(dfz), dfz))
+
+		// Shutdown cancels the reconcile after the Deployment was scaled down.
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		st := newStatusTracker(dfz)
+		r.setPhase(dfz, freezerv1alpha1.PhaseFrozen)
+		r.commitStatus(ctx, dfz, st)
+
+		got := &freezerv1alpha1.DeploymentFreezer{}
+		require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(dfz), got))
+		assert.Equal(t, freezerv1alpha1.PhaseFrozen, got.Status.Phase)
+	})
+
+	t.Run("Commit_ConflictReappliedOnLatest", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		dfz := newDFZ(freezerv1alpha1.PhaseFreezing)
+		r := newReconciler(dfz)
+		require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(dfz), dfz))
+
+		// Written elsewhere after this reconcile read the DFZ.
+		newer := dfz.DeepCopy()
+		newer.Status.PlannedChanges = []string{"elsewhere"}
+		require.NoError(t, r.Status().Update(ctx, newer))
+
+		st := newStatusTracker(dfz)
+		dfz.Status.OriginalReplicas = ptr.To[int32](3)
+		require.NoError(t, r.commitStatus(ctx, dfz, st))
+
+		got := &freezerv1alpha1.DeploymentFreezer{}
+		require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(dfz), got))
+		assert.Equal(t, ptr.To[int32](3), got.Status.OriginalReplicas, "the recorded scale-down is not lost")
+		assert.Equal(t, []string{"elsewhere"}, got.Status.PlannedChanges, "fields not changed here are kept")
+	})
+
+	t.Run("Commit_StampsControllerVersion", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		dfz := newDFZ(freezerv1alpha1.PhaseFrozen)
+		r := newReconciler(dfz)
+		require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(dfz), dfz))
+		stored := func() string {
+			got := &freezerv1alpha1.DeploymentFreezer{}
+			require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(dfz), got))
+			return got.Status.ControllerVersion
+		}
+
+		r.commitStatus(ctx, dfz, newStatusTracker(dfz))
+		assert.Empty(t, stored(), "an unchanged status is not written")
+
+		st := newStatusTracker(dfz)
+		dfz.Status.PlannedChanges = []string{"changed"}
+		r.commitStatus(ctx, dfz, st)
+		assert.Equal(t, version.Get().String(), stored())
+	})
+
+	t.Run("ChargeUsage_FailureHoldsPhase", func(t *testing.T) {
+		t.Parallel()
+		c := fake.NewClientBuilder().WithScheme(scheme).
+			WithInterceptorFuncs(interceptor.Funcs{
+				List: func(context.Context, client.WithWatch, client.ObjectList, ...client.ListOption) error {
+					return errors.New("apiserver unavailable")
+				},
+			}).Build()
+		r := &DeploymentFreezerReconciler{Client: c, Clock: testingclock.NewFakeClock(time.Now())}
+		dfz := newDFZ(freezerv1alpha1.PhaseUnfreezing)
+		recordPhase(dfz, freezerv1alpha1.PhaseFrozen, time.Now().Add(-time.Hour))
+		recordPhase(dfz, freezerv1alpha1.PhaseUnfreezing, time.Now())
+		r.setPhase(dfz, freezerv1alpha1.PhaseCompleted)
+
+		err := r.chargeUsageOnFinish(context.Background(), dfz, freezerv1alpha1.PhaseUnfreezing)
+		require.ErrorContains(t, err, "apiserver unavailable")
+		assert.Equal(t, freezerv1alpha1.PhaseUnfreezing, dfz.Status.Phase)
+		assert.NotContains(t, dfz.Status.PhaseTransitionTimes, freezerv1alpha1.PhaseCompleted)
+
+		// Nothing is charged, and nothing can fail, without a finished freeze.
+		require.NoError(t, r.chargeUsageOnFinish(context.Background(), dfz, freezerv1alpha1.PhaseFrozen))
+	})
+}
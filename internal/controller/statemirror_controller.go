@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// labelManagedBy marks the state ConfigMaps the controller created, and may update and delete.
+	labelManagedBy = "app.kubernetes.io/managed-by"
+	managedByValue = "deployment-freezer"
+)
+
+// StateMirrorReconciler mirrors the active freezes of each namespace into a ConfigMap, for
+// dashboards and scripts that cannot read DeploymentFreezers. The ConfigMap has one key per frozen
+// Deployment, holding a JSON FreezeStateEntry, and is deleted once the namespace has no active freeze.
+type StateMirrorReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// APIReader reads the ConfigMaps, which are not cached.
+	APIReader client.Reader
+	// Name of the ConfigMap maintained in every namespace with active freezes.
+	Name string
+	// Shard restricts this replica to the namespaces of its shard; it must be in namespace mode.
+	Shard Shard
+}
+
+// FreezeStateEntry is the value of a state ConfigMap key, named after the Deployment.
+type FreezeStateEntry struct {
+	// DeploymentFreezer holding the Deployment.
+	Freezer string                `json:"freezer"`
+	Phase   freezerv1alpha1.Phase `json:"phase"`
+	// Until is the end of the freeze window, once the Deployment is Frozen.
+	Until  *time.Time `json:"until,omitempty"`
+	Reason string     `json:"reason,omitempty"`
+	// State is the freeze-state annotation of the Deployment, for humans.
+	State string `json:"state"`
+}
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;delete
+
+func (r *StateMirrorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if !r.Shard.OwnsNamespace(req.Namespace) {
+		return ctrl.Result{}, nil
+	}
+	var list freezerv1alpha1.DeploymentFreezerList
+	if err := r.List(ctx, &list, client.InNamespace(req.Namespace)); err != nil {
+		return ctrl.Result{}, err
+	}
+	data, err := mirrorData(list.Items)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var cm corev1.ConfigMap
+	err = r.APIReader.Get(ctx, req.NamespacedName, &cm)
+	switch {
+	case apierrors.IsNotFound(err):
+		if len(data) == 0 {
+			return ctrl.Result{}, nil
+		}
+		cm = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: req.Namespace,
+				Name:      req.Name,
+				Labels:    map[string]string{labelManagedBy: managedByValue},
+			},
+			Data: data,
+		}
+		return ctrl.Result{}, client.IgnoreAlreadyExists(r.Create(ctx, &cm))
+	case err != nil:
+		return ctrl.Result{}, err
+	case cm.Labels[labelManagedBy] != managedByValue:
+		log.FromContext(ctx).Info("not mirroring freeze state into a ConfigMap the controller did not create",
+			"configmap", req.NamespacedName)
+		return ctrl.Result{}, nil
+	case len(data) == 0:
+		return ctrl.Result{}, client.IgnoreNotFound(r.Delete(ctx, &cm, client.Preconditions{UID: &cm.UID}))
+	case maps.Equal(cm.Data, data):
+		return ctrl.Result{}, nil
+	}
+	cm.Data = data
+	// The resourceVersion just read makes a concurrent change fail the update and retry.
+	return ctrl.Result{}, r.Update(ctx, &cm)
+}
+
+// mirrorData builds the state ConfigMap data from the DFZs of a namespace. A Deployment claimed by
+// several active DFZs is reported with the one furthest into its freeze.
+func mirrorData(dfzs []freezerv1alpha1.DeploymentFreezer) (map[string]string, error) {
+	rank := map[freezerv1alpha1.Phase]int{
+		freezerv1alpha1.PhaseFreezing:   1,
+		freezerv1alpha1.PhaseUnfreezing: 2,
+		freezerv1alpha1.PhaseFrozen:     3,
+	}
+	holders := map[string]*freezerv1alpha1.DeploymentFreezer{}
+	for i := range dfzs {
+		dfz := &dfzs[i]
+		target := dfz.TargetName()
+		if rank[dfz.Status.Phase] == 0 || target == "" {
+			continue
+		}
+		if cur, ok := holders[target]; ok && cmp.Or(
+			cmp.Compare(rank[cur.Status.Phase], rank[dfz.Status.Phase]),
+			cmp.Compare(dfz.Name, cur.Name),
+		) > 0 {
+			continue
+		}
+		holders[target] = dfz
+	}
+
+	data := map[string]string{}
+	for _, target := range slices.Sorted(maps.Keys(holders)) {
+		dfz := holders[target]
+		entry := FreezeStateEntry{
+			Freezer: dfz.Name,
+			Phase:   dfz.Status.Phase,
+			Reason:  dfz.Annotations[AnnoAutoFreezeReason],
+			State:   describeFreeze(dfz),
+		}
+		if dfz.Status.Phase == freezerv1alpha1.PhaseFrozen && dfz.Status.FreezeUntil != nil {
+			until := dfz.Status.FreezeUntil.UTC()
+			entry.Until = &until
+		}
+		value, err := json.Marshal(entry)
+		if err != nil {
+			return nil, fmt.Errorf("encode the freeze state of %s: %w", target, err)
+		}
+		data[target] = string(value)
+	}
+	return data, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *StateMirrorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.APIReader == nil {
+		r.APIReader = mgr.GetAPIReader()
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("statemirror").
+		Watches(&freezerv1alpha1.DeploymentFreezer{}, handler.EnqueueRequestsFromMapFunc(r.namespaceConfigMap)).
+		Complete(r)
+}
+
+// namespaceConfigMap enqueues the state ConfigMap of the DFZ's namespace.
+func (r *StateMirrorReconciler) namespaceConfigMap(_ context.Context, obj client.Object) []reconcile.Request {
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: r.Name}}}
+}
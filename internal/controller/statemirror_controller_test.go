@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestStateMirror(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	until := time.Date(2025, 1, 1, 2, 0, 0, 0, time.UTC)
+	newDFZ := func(name, target string, phase freezerv1alpha1.Phase) *freezerv1alpha1.DeploymentFreezer {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: name}}
+		dfz.Spec.TargetRef.Name = target
+		dfz.Status.Phase = phase
+		dfz.Status.FreezeUntil = &metav1.Time{Time: until}
+		return dfz
+	}
+	key := types.NamespacedName{Namespace: "shop", Name: "freeze-state"}
+	reconcileMirror := func(t *testing.T, objs ...client.Object) (*corev1.ConfigMap, error) {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+		r := &StateMirrorReconciler{Client: c, Scheme: scheme, APIReader: c, Name: key.Name}
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+		require.NoError(t, err)
+		cm := &corev1.ConfigMap{}
+		return cm, c.Get(context.Background(), key, cm)
+	}
+
+	t.Run("ActiveFreezes_Created", func(t *testing.T) {
+		t.Parallel()
+		frozen := newDFZ("web-freeze", "web", freezerv1alpha1.PhaseFrozen)
+		frozen.Annotations = map[string]string{AnnoAutoFreezeReason: "error rate above 5%"}
+		cm, err := reconcileMirror(t,
+			frozen,
+			newDFZ("web-later", "web", freezerv1alpha1.PhaseFreezing),
+			newDFZ("cart-freeze", "cart", freezerv1alpha1.PhaseFreezing),
+			newDFZ("done", "api", freezerv1alpha1.PhaseCompleted),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, managedByValue, cm.Labels[labelManagedBy])
+		assert.Equal(t, map[string]string{
+			"web": `{"freezer":"web-freeze","phase":"Frozen","until":"2025-01-01T02:00:00Z",` +
+				`"reason":"error rate above 5%","state":"frozen until 2025-01-01T02:00Z by shop/web-freeze (error rate above 5%)"}`,
+			"cart": `{"freezer":"cart-freeze","phase":"Freezing","state":"freezing by shop/cart-freeze"}`,
+		}, cm.Data)
+	})
+
+	t.Run("Changed_Updated", func(t *testing.T) {
+		t.Parallel()
+		cm, err := reconcileMirror(t,
+			newDFZ("web-freeze", "web", freezerv1alpha1.PhaseUnfreezing),
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: key.Name, Labels: map[string]string{labelManagedBy: managedByValue}},
+				Data:       map[string]string{"cart": "{}"},
+			},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"web": `{"freezer":"web-freeze","phase":"Unfreezing","state":"unfreezing by shop/web-freeze"}`,
+		}, cm.Data)
+	})
+
+	t.Run("NoActiveFreeze_Deleted", func(t *testing.T) {
+		t.Parallel()
+		_, err := reconcileMirror(t,
+			newDFZ("web-freeze", "web", freezerv1alpha1.PhaseCompleted),
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: key.Name, Labels: map[string]string{labelManagedBy: managedByValue}},
+				Data:       map[string]string{"web": "{}"},
+			},
+		)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("ForeignConfigMap_LeftAlone", func(t *testing.T) {
+		t.Parallel()
+		cm, err := reconcileMirror(t,
+			newDFZ("web-freeze", "web", freezerv1alpha1.PhaseFrozen),
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: key.Name},
+				Data:       map[string]string{"owner": "someone else"},
+			},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"owner": "someone else"}, cm.Data)
+	})
+}
@@ -3,41 +3,72 @@ package controller
 import (
 	"context"
 	"reflect"
+	"time"
 
 	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
-	"k8s.io/apimachinery/pkg/types"
+	"github.com/boolfixer/deployment-freezer/internal/version"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+// statusFlushTimeout bounds the status write of a reconcile that outlives its context.
+const statusFlushTimeout = 10 * time.Second
+
 type statusTracker struct {
 	orig freezerv1alpha1.DeploymentFreezerStatus
 }
 
 func newStatusTracker(dfz *freezerv1alpha1.DeploymentFreezer) statusTracker {
-	return statusTracker{orig: dfz.Status}
+	// A deep copy, since the reconcile mutates conditions and transition times in place.
+	return statusTracker{orig: *dfz.Status.DeepCopy()}
 }
 
-// commitStatus writes status once if it changed; uses retry on conflict with a fresh GET.
+// commitStatus writes status once if it changed, as a merge patch of the changes made during this
+// reconcile, stamped with the build that wrote it. It needs no fresh GET: the patch only carries
+// fields this controller owns.
+// The write survives the cancellation of ctx on shutdown or lost leadership: the Deployment may
+// already have been changed, and the transition must be recorded for the next leader. The patch
+// carries the resourceVersion it was computed from. On a conflict the DFZ is read again from the
+// API server and the same changes are patched onto it: they may record a scale-down already made,
+// such as originalReplicas, which a later reconcile could no longer work out.
 func (r *DeploymentFreezerReconciler) commitStatus(
 	ctx context.Context,
 	dfz *freezerv1alpha1.DeploymentFreezer,
 	st statusTracker,
-) {
+) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), statusFlushTimeout)
+	defer cancel()
+	t := Transition{From: st.orig.Phase, To: dfz.Status.Phase}
+	checkTransition(ctx, t)
+	runTransitionHooks(ctx, dfz, t, r.preTransitionHooks())
 	if reflect.DeepEqual(st.orig, dfz.Status) {
-		return
+		return nil
 	}
-	err := retry.OnError(retry.DefaultRetry, func(err error) bool { return true }, func() error {
-		var latest freezerv1alpha1.DeploymentFreezer
-		if err := r.Get(ctx, types.NamespacedName{Namespace: dfz.Namespace, Name: dfz.Name}, &latest); err != nil {
+	dfz.Status.ControllerVersion = version.Get().String()
+	// The patch is the diff from base to desired: the changes made to status during this reconcile.
+	base, desired := dfz.DeepCopy(), dfz.DeepCopy()
+	base.Status = st.orig
+	retriable := func(err error) bool { return !apierrors.IsNotFound(err) }
+	err := retry.OnError(retry.DefaultRetry, retriable, func() error {
+		err := r.Status().Patch(ctx, desired.DeepCopy(), client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{}))
+		if !apierrors.IsConflict(err) {
 			return err
 		}
-		orig := latest.DeepCopy()
-		latest.Status = dfz.Status
-		return r.Status().Patch(ctx, &latest, client.MergeFrom(orig))
+		var latest freezerv1alpha1.DeploymentFreezer
+		if getErr := r.APIReader.Get(ctx, client.ObjectKeyFromObject(dfz), &latest); getErr != nil {
+			return getErr
+		}
+		base, desired = latest.DeepCopy(), latest.DeepCopy()
+		base.Status = st.orig
+		desired.Status = *dfz.Status.DeepCopy()
+		return err
 	})
 	if err != nil {
 		log.FromContext(ctx).Error(err, "failed to update status")
+		return client.IgnoreNotFound(err)
 	}
+	runTransitionHooks(ctx, dfz, t, r.postTransitionHooks())
+	return nil
 }
@@ -0,0 +1,218 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;patch
+// +kubebuilder:rbac:groups=apps,resources=replicasets/scale,verbs=get;update
+// +kubebuilder:rbac:groups="",resources=replicationcontrollers,verbs=get;list;patch
+// +kubebuilder:rbac:groups="",resources=replicationcontrollers/scale,verbs=get;update
+// +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;patch
+// +kubebuilder:rbac:groups=apps.kruise.io,resources=statefulsets,verbs=get;list;patch
+// +kubebuilder:rbac:groups=apps.kruise.io,resources=statefulsets/scale,verbs=get;update
+
+// targetKind returns the kind of the DFZ's target; an unset kind is a Deployment.
+func targetKind(dfz *freezerv1alpha1.DeploymentFreezer) freezerv1alpha1.TargetKind {
+	if dfz.Spec.TargetRef.Kind == "" {
+		return freezerv1alpha1.TargetKindDeployment
+	}
+	return dfz.Spec.TargetRef.Kind
+}
+
+// awaitTarget keeps a DFZ whose target does not exist Pending for up to TargetNotFoundPatience,
+// so a DFZ applied just before its target is not Aborted. It returns how long is left, or 0 once
+// the patience is spent and for a DFZ past Pending or being deleted.
+func (r *DeploymentFreezerReconciler) awaitTarget(
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	kind freezerv1alpha1.TargetKind,
+) time.Duration {
+	if r.TargetNotFoundPatience <= 0 || !dfz.DeletionTimestamp.IsZero() {
+		return 0
+	}
+	switch dfz.Status.Phase {
+	case "":
+		r.setPhase(dfz, freezerv1alpha1.PhasePending)
+	case freezerv1alpha1.PhasePending:
+	default:
+		return 0
+	}
+	deadline := dfz.Status.PhaseTransitionTimes[freezerv1alpha1.PhasePending].Add(r.TargetNotFoundPatience)
+	wait := deadline.Sub(r.now())
+	if wait > 0 {
+		setCondition(
+			dfz,
+			freezerv1alpha1.ConditionTypeTargetFound,
+			freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonNotFound,
+			fmt.Sprintf(msgTargetAwaitedFmt, kind, deadline.UTC().Format(time.RFC3339)),
+		)
+	}
+	return wait
+}
+
+// markFound clears the NotFound condition left by awaitTarget once the target was created. An
+// Aborted DFZ keeps the condition that ended it.
+func markFound(dfz *freezerv1alpha1.DeploymentFreezer) {
+	if !isTerminalPhase(dfz.Status.Phase) && hasCondition(
+		dfz,
+		freezerv1alpha1.ConditionTypeTargetFound,
+		freezerv1alpha1.ConditionStatusFalse,
+		freezerv1alpha1.ConditionReasonNotFound,
+	) {
+		setCondition(
+			dfz,
+			freezerv1alpha1.ConditionTypeTargetFound,
+			freezerv1alpha1.ConditionStatusTrue,
+			freezerv1alpha1.ConditionReasonFound,
+			msgTargetCreated,
+		)
+	}
+}
+
+// readyReplicas returns the ready and available replica counts from the status of a target.
+func readyReplicas(obj client.Object) (ready, available int32) {
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		return o.Status.ReadyReplicas, o.Status.AvailableReplicas
+	case *appsv1.ReplicaSet:
+		return o.Status.ReadyReplicas, o.Status.AvailableReplicas
+	case *corev1.ReplicationController:
+		return o.Status.ReadyReplicas, o.Status.AvailableReplicas
+	case *appsv1.DaemonSet:
+		return o.Status.NumberReady, o.Status.NumberAvailable
+	case *unstructured.Unstructured:
+		ready, _, _ := unstructured.NestedInt64(o.Object, "status", "readyReplicas")
+		available, _, _ := unstructured.NestedInt64(o.Object, "status", "availableReplicas")
+		return int32(ready), int32(available)
+	}
+	return 0, 0
+}
+
+// newTargetObject returns an empty object of the target kind to read the target into. Only
+// Deployments are cached and watched: a cluster has a ReplicaSet for every Deployment revision,
+// so standalone ReplicaSets and ReplicationControllers are read from the API server instead, as
+// are DaemonSets, which are rarely frozen, and Advanced StatefulSets, whose CRD may be missing.
+func newTargetObject(kind freezerv1alpha1.TargetKind) (obj client.Object, cached bool) {
+	switch kind {
+	case freezerv1alpha1.TargetKindReplicaSet:
+		return &appsv1.ReplicaSet{}, false
+	case freezerv1alpha1.TargetKindReplicationController:
+		return &corev1.ReplicationController{}, false
+	case freezerv1alpha1.TargetKindDaemonSet:
+		return &appsv1.DaemonSet{}, false
+	case freezerv1alpha1.TargetKindAdvancedStatefulSet:
+		return freeze.NewAdvancedStatefulSet(), false
+	default:
+		return &appsv1.Deployment{}, true
+	}
+}
+
+// newTargetList returns an empty list of the target kind.
+func newTargetList(kind freezerv1alpha1.TargetKind) client.ObjectList {
+	switch kind {
+	case freezerv1alpha1.TargetKindReplicaSet:
+		return &appsv1.ReplicaSetList{}
+	case freezerv1alpha1.TargetKindReplicationController:
+		return &corev1.ReplicationControllerList{}
+	case freezerv1alpha1.TargetKindDaemonSet:
+		return &appsv1.DaemonSetList{}
+	case freezerv1alpha1.TargetKindAdvancedStatefulSet:
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(freeze.AdvancedStatefulSetGVK.GroupVersion().WithKind("StatefulSetList"))
+		return list
+	default:
+		return &appsv1.DeploymentList{}
+	}
+}
+
+// selectTarget resolves spec.targetRef.selector to the name of the only matching workload. When
+// it does not match exactly one, it returns "" and the reason and message to deny the DFZ with.
+// The API server is asked, so workloads outside the Deployment cache are counted too.
+func (r *DeploymentFreezerReconciler) selectTarget(
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	kind freezerv1alpha1.TargetKind,
+) (string, freezerv1alpha1.ConditionReason, string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(dfz.Spec.TargetRef.Selector)
+	if err != nil {
+		return "", freezerv1alpha1.ConditionReasonNotFound, fmt.Sprintf(msgSelectorInvalidFmt, err), nil
+	}
+	list := newTargetList(kind)
+	if err := r.APIReader.List(
+		ctx,
+		list,
+		client.InNamespace(dfz.Namespace),
+		client.MatchingLabelsSelector{Selector: selector},
+	); err != nil {
+		return "", "", "", err
+	}
+	var names []string
+	if err := apimeta.EachListItem(list, func(o runtime.Object) error {
+		names = append(names, o.(client.Object).GetName())
+		return nil
+	}); err != nil {
+		return "", "", "", err
+	}
+	slices.Sort(names)
+
+	switch len(names) {
+	case 1:
+		return names[0], "", "", nil
+	case 0:
+		return "", freezerv1alpha1.ConditionReasonNotFound, fmt.Sprintf(msgSelectorNoMatchFmt, kind, selector), nil
+	default:
+		return "", freezerv1alpha1.ConditionReasonAmbiguousTarget,
+			fmt.Sprintf(msgSelectorAmbiguousFmt, selector, len(names), kind, strings.Join(names, ", ")), nil
+	}
+}
+
+// deploymentOnlyFields names the spec fields set on the DFZ that only work for a Deployment
+// target, or returns "". The CRD rejects them for other kinds; this catches DFZs admitted
+// without that rule.
+func deploymentOnlyFields(spec *freezerv1alpha1.DeploymentFreezerSpec) string {
+	var fields []string
+	if spec.PauseRollout {
+		fields = append(fields, "pauseRollout")
+	}
+	if spec.GitOpsMode {
+		fields = append(fields, "gitopsMode")
+	}
+	if s := spec.UnfreezeStrategy; s != nil && s.Type == freezerv1alpha1.UnfreezeStrategyCanary {
+		fields = append(fields, "unfreezeStrategy")
+	}
+	if spec.MaintenancePage != nil {
+		fields = append(fields, "maintenancePage")
+	}
+	if spec.Standby != nil {
+		fields = append(fields, "standby")
+	}
+	if spec.PostUnfreezeObservationSeconds > 0 {
+		fields = append(fields, "postUnfreezeObservationSeconds")
+	}
+	return strings.Join(fields, ", ")
+}
+
+// fromNested converts the field of u at fields into out, and reports whether it was set and
+// converted.
+func fromNested(u *unstructured.Unstructured, out any, fields ...string) bool {
+	m, ok, err := unstructured.NestedMap(u.Object, fields...)
+	if !ok || err != nil {
+		return false
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(m, out) == nil
+}
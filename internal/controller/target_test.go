@@ -0,0 +1,309 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReplicatedTargetKinds(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	newDFZ := func(kind freezerv1alpha1.TargetKind) *freezerv1alpha1.DeploymentFreezer {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "freeze", UID: "dfz-uid"}}
+		dfz.Spec.TargetRef = freezerv1alpha1.DeploymentTargetRef{Kind: kind, Name: "legacy"}
+		return dfz
+	}
+	// run reconciles until the DFZ stops requeueing or reaches want. The target is only on the
+	// API server, as it would be outside the Deployment cache.
+	run := func(t *testing.T, dfz *freezerv1alpha1.DeploymentFreezer, want freezerv1alpha1.Phase, target client.Object) (
+		*freezerv1alpha1.DeploymentFreezer, client.Client,
+	) {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(dfz, target).
+			WithStatusSubresource(&freezerv1alpha1.DeploymentFreezer{}).Build()
+		r := &DeploymentFreezerReconciler{
+			Client:    c,
+			APIReader: c,
+			Recorder:  record.NewFakeRecorder(20),
+			Clock:     testingclock.NewFakeClock(time.Now()),
+		}
+		var got freezerv1alpha1.DeploymentFreezer
+		for range 5 {
+			res, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(dfz)})
+			require.NoError(t, err)
+			require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(dfz), &got))
+			if got.Status.Phase == want || res.IsZero() {
+				break
+			}
+		}
+		return &got, c
+	}
+
+	t.Run("ReplicaSet_Frozen", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ(freezerv1alpha1.TargetKindReplicaSet)
+		rs := &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "legacy", UID: "rs-uid"},
+			Spec:       appsv1.ReplicaSetSpec{Replicas: ptr.To(int32(3))},
+		}
+		got, c := run(t, dfz, freezerv1alpha1.PhaseFrozen, rs)
+
+		assert.Equal(t, freezerv1alpha1.PhaseFrozen, got.Status.Phase)
+		assert.Equal(t, ptr.To(int32(3)), got.Status.OriginalReplicas)
+		assert.Equal(t, types.UID("rs-uid"), got.Status.TargetRef.UID)
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(rs), rs))
+		assert.Equal(t, int32(0), *rs.Spec.Replicas)
+		assert.True(t, isFrozenBy(rs.Annotations[annoFrozenBy], got))
+	})
+
+	t.Run("DaemonSet_FrozenWithNodeSelector", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ(freezerv1alpha1.TargetKindDaemonSet)
+		ds := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "legacy", UID: "ds-uid"}}
+		ds.Spec.Template.Spec.NodeSelector = map[string]string{"kubernetes.io/os": "linux"}
+		ds.Status.DesiredNumberScheduled = 4
+		got, c := run(t, dfz, freezerv1alpha1.PhaseFrozen, ds)
+
+		assert.Equal(t, freezerv1alpha1.PhaseFrozen, got.Status.Phase)
+		assert.Equal(t, ptr.To(int32(4)), got.Status.OriginalReplicas)
+		assert.False(t, hasCondition(got, freezerv1alpha1.ConditionTypeSpecChangedDuringFreeze,
+			freezerv1alpha1.ConditionStatusTrue, freezerv1alpha1.ConditionReasonObserved))
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(ds), ds))
+		assert.Equal(t, "true", ds.Spec.Template.Spec.NodeSelector[freeze.NodeSelectorFrozen])
+		assert.Equal(t, "linux", ds.Spec.Template.Spec.NodeSelector["kubernetes.io/os"])
+		assert.True(t, isFrozenBy(ds.Annotations[annoFrozenBy], got))
+	})
+
+	t.Run("AdvancedStatefulSet_Frozen", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ(freezerv1alpha1.TargetKindAdvancedStatefulSet)
+		sts := freeze.NewAdvancedStatefulSet()
+		sts.SetNamespace("ns")
+		sts.SetName("legacy")
+		sts.SetUID("sts-uid")
+		require.NoError(t, unstructured.SetNestedField(sts.Object, int64(3), "spec", "replicas"))
+		got, c := run(t, dfz, freezerv1alpha1.PhaseFrozen, sts)
+
+		assert.Equal(t, freezerv1alpha1.PhaseFrozen, got.Status.Phase)
+		assert.Equal(t, ptr.To(int32(3)), got.Status.OriginalReplicas)
+		assert.Equal(t, types.UID("sts-uid"), got.Status.TargetRef.UID)
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(sts), sts))
+		replicas, _, _ := unstructured.NestedInt64(sts.Object, "spec", "replicas")
+		assert.Equal(t, int64(0), replicas)
+		assert.True(t, isFrozenBy(sts.GetAnnotations()[annoFrozenBy], got))
+	})
+
+	t.Run("ControlledReplicaSet_Denied", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ(freezerv1alpha1.TargetKindReplicaSet)
+		rs := &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "legacy",
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "apps/v1", Kind: "Deployment", Name: "web", UID: "web-uid", Controller: ptr.To(true),
+				}},
+			},
+			Spec: appsv1.ReplicaSetSpec{Replicas: ptr.To(int32(3))},
+		}
+		got, c := run(t, dfz, freezerv1alpha1.PhaseDenied, rs)
+
+		assert.Equal(t, freezerv1alpha1.PhaseDenied, got.Status.Phase)
+		assert.True(t, hasCondition(got, freezerv1alpha1.ConditionTypeTargetFound,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonUnsupportedTarget))
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(rs), rs))
+		assert.Equal(t, int32(3), *rs.Spec.Replicas)
+	})
+
+	t.Run("DeploymentOnlyField_Denied", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ(freezerv1alpha1.TargetKindReplicationController)
+		dfz.Spec.GitOpsMode = true
+		rc := &corev1.ReplicationController{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "legacy"},
+			Spec:       corev1.ReplicationControllerSpec{Replicas: ptr.To(int32(2))},
+		}
+		got, _ := run(t, dfz, freezerv1alpha1.PhaseDenied, rc)
+
+		assert.Equal(t, freezerv1alpha1.PhaseDenied, got.Status.Phase)
+		c := got.Status.Conditions[0]
+		assert.Equal(t, freezerv1alpha1.ConditionReasonUnsupportedTarget, c.Reason)
+		assert.Equal(t, "A ReplicationController target cannot use Deployment-only settings: gitopsMode", c.Message)
+	})
+
+	t.Run("MissingTarget_AbortedWithKind", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ(freezerv1alpha1.TargetKindReplicationController)
+		other := &corev1.ReplicationController{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "other"}}
+		got, _ := run(t, dfz, freezerv1alpha1.PhaseAborted, other)
+
+		assert.Equal(t, freezerv1alpha1.PhaseAborted, got.Status.Phase)
+		assert.Equal(t, "Target ReplicationController does not exist", got.Status.Conditions[0].Message)
+	})
+}
+
+func TestTargetSelector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	deployment := func(name, app string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, UID: types.UID(name + "-uid"), Labels: map[string]string{"app": app}},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(2))},
+		}
+	}
+	run := func(t *testing.T, objs ...client.Object) (*freezerv1alpha1.DeploymentFreezer, client.Client) {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "freeze", UID: "dfz-uid"}}
+		dfz.Spec.TargetRef.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+		dfz.Spec.DurationSeconds = 3600
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objs, dfz)...).
+			WithStatusSubresource(&freezerv1alpha1.DeploymentFreezer{}).Build()
+		r := &DeploymentFreezerReconciler{
+			Client:    c,
+			APIReader: c,
+			Recorder:  record.NewFakeRecorder(20),
+			Clock:     testingclock.NewFakeClock(time.Now()),
+		}
+		for range 3 {
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(dfz)})
+			require.NoError(t, err)
+		}
+		var got freezerv1alpha1.DeploymentFreezer
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(dfz), &got))
+		return &got, c
+	}
+
+	t.Run("OneMatch_FrozenAndPinned", func(t *testing.T) {
+		t.Parallel()
+		got, c := run(t, deployment("web", "web"), deployment("api", "api"))
+
+		assert.Equal(t, freezerv1alpha1.PhaseFrozen, got.Status.Phase)
+		assert.Equal(t, "web", got.Status.TargetRef.Name)
+		assert.Equal(t, "web", got.TargetName())
+		var web appsv1.Deployment
+		require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "ns", Name: "web"}, &web))
+		assert.Equal(t, int32(0), *web.Spec.Replicas)
+	})
+
+	t.Run("NoMatch_Denied", func(t *testing.T) {
+		t.Parallel()
+		got, _ := run(t, deployment("api", "api"))
+
+		assert.Equal(t, freezerv1alpha1.PhaseDenied, got.Status.Phase)
+		assert.True(t, hasCondition(got, freezerv1alpha1.ConditionTypeTargetFound,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonNotFound))
+	})
+
+	t.Run("SeveralMatches_DeniedAsAmbiguous", func(t *testing.T) {
+		t.Parallel()
+		got, c := run(t, deployment("web", "web"), deployment("web-canary", "web"))
+
+		assert.Equal(t, freezerv1alpha1.PhaseDenied, got.Status.Phase)
+		assert.True(t, hasCondition(got, freezerv1alpha1.ConditionTypeTargetFound,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonAmbiguousTarget))
+		for _, c := range got.Status.Conditions {
+			if c.Type == freezerv1alpha1.ConditionTypeTargetFound {
+				assert.Equal(t, `spec.targetRef.selector "app=web" matches 2 Deployment objects (web, web-canary); `+
+					"it must match exactly one", c.Message)
+			}
+		}
+		var web appsv1.Deployment
+		require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "ns", Name: "web"}, &web))
+		assert.Equal(t, int32(2), *web.Spec.Replicas, "an ambiguous selector scales nothing")
+	})
+}
+
+func TestTargetNotFoundPatience(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	setup := func(patience time.Duration) (*DeploymentFreezerReconciler, *testingclock.FakeClock, client.Client) {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "freeze", UID: "dfz-uid"}}
+		dfz.Spec.TargetRef = freezerv1alpha1.DeploymentTargetRef{Name: "web"}
+		dfz.Spec.DurationSeconds = 3600
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(dfz).
+			WithStatusSubresource(&freezerv1alpha1.DeploymentFreezer{}).Build()
+		clk := testingclock.NewFakeClock(now)
+		return &DeploymentFreezerReconciler{
+			Client:                 c,
+			APIReader:              c,
+			Recorder:               record.NewFakeRecorder(20),
+			Clock:                  clk,
+			TargetNotFoundPatience: patience,
+		}, clk, c
+	}
+	reconcile := func(t *testing.T, r *DeploymentFreezerReconciler, c client.Client) (
+		ctrl.Result, *freezerv1alpha1.DeploymentFreezer,
+	) {
+		key := types.NamespacedName{Namespace: "ns", Name: "freeze"}
+		res, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+		require.NoError(t, err)
+		var got freezerv1alpha1.DeploymentFreezer
+		require.NoError(t, c.Get(context.Background(), key, &got))
+		return res, &got
+	}
+
+	t.Run("NoPatience_AbortedAtOnce", func(t *testing.T) {
+		t.Parallel()
+		r, _, c := setup(0)
+		_, got := reconcile(t, r, c)
+		assert.Equal(t, freezerv1alpha1.PhaseAborted, got.Status.Phase)
+	})
+
+	t.Run("PatienceSpent_Aborted", func(t *testing.T) {
+		t.Parallel()
+		r, clk, c := setup(10 * time.Minute)
+		res, got := reconcile(t, r, c)
+		assert.Equal(t, freezerv1alpha1.PhasePending, got.Status.Phase)
+		assert.Equal(t, 10*time.Minute, res.RequeueAfter)
+		assert.True(t, hasCondition(got, freezerv1alpha1.ConditionTypeTargetFound,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonNotFound))
+
+		clk.Step(10 * time.Minute)
+		res, got = reconcile(t, r, c)
+		assert.Equal(t, freezerv1alpha1.PhaseAborted, got.Status.Phase)
+		assert.Zero(t, res.RequeueAfter)
+	})
+
+	t.Run("CreatedWithinPatience_Freezes", func(t *testing.T) {
+		t.Parallel()
+		r, clk, c := setup(10 * time.Minute)
+		_, got := reconcile(t, r, c)
+		require.Equal(t, freezerv1alpha1.PhasePending, got.Status.Phase)
+
+		clk.Step(5 * time.Minute)
+		require.NoError(t, c.Create(context.Background(), &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web"},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(2))},
+		}))
+		for range 3 {
+			_, got = reconcile(t, r, c)
+		}
+		assert.Equal(t, freezerv1alpha1.PhaseFrozen, got.Status.Phase)
+		assert.True(t, hasCondition(got, freezerv1alpha1.ConditionTypeTargetFound,
+			freezerv1alpha1.ConditionStatusTrue, freezerv1alpha1.ConditionReasonFound))
+	})
+}
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"sync"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// targetLocks serializes the reconciles of DFZs that share a target. The work queue only keeps
+// one DFZ from being reconciled twice at once; two DFZs naming the same Deployment could still
+// interleave their ownership and replica patches across workers. The zero value is ready to use.
+//
+// The locks are an optimization, saving the conflicts of such reconciles: they are local to the
+// process, so shards and replicas do not share them, and the target is read from the cache, which
+// may not show a claim made just before. A second claim is refused by the writes themselves, which
+// carry the resourceVersion of the target read and check its holder again on a conflict.
+type targetLocks struct {
+	mu    sync.Mutex
+	locks map[targetLockKey]*targetLock
+}
+
+type targetLockKey struct {
+	kind freezerv1alpha1.TargetKind
+	nn   types.NamespacedName
+}
+
+// targetLock is held by the reconcile working on a target; refs counts it and its waiters, so
+// the entry is dropped once nobody needs it.
+type targetLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// lock blocks until no other reconcile holds the target, and returns the function releasing it.
+func (l *targetLocks) lock(kind freezerv1alpha1.TargetKind, nn types.NamespacedName) func() {
+	key := targetLockKey{kind: kind, nn: nn}
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = map[targetLockKey]*targetLock{}
+	}
+	tl, ok := l.locks[key]
+	if !ok {
+		tl = &targetLock{}
+		l.locks[key] = tl
+	}
+	tl.refs++
+	l.mu.Unlock()
+
+	tl.mu.Lock()
+	return func() {
+		tl.mu.Unlock()
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if tl.refs--; tl.refs == 0 {
+			delete(l.locks, key)
+		}
+	}
+}
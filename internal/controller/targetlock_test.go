@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestTargetLocks(t *testing.T) {
+	web := types.NamespacedName{Namespace: "ns", Name: "web"}
+
+	t.Run("SameTarget_Serialized", func(t *testing.T) {
+		t.Parallel()
+		var l targetLocks
+		unlock := l.lock(freezerv1alpha1.TargetKindDeployment, web)
+
+		acquired := make(chan struct{})
+		go func() {
+			defer l.lock(freezerv1alpha1.TargetKindDeployment, web)()
+			close(acquired)
+		}()
+		select {
+		case <-acquired:
+			t.Fatal("second reconcile of the same target ran concurrently")
+		case <-time.After(50 * time.Millisecond):
+		}
+		unlock()
+		select {
+		case <-acquired:
+		case <-time.After(5 * time.Second):
+			t.Fatal("second reconcile never got the target")
+		}
+		assert.Eventually(t, func() bool {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			return len(l.locks) == 0
+		}, 5*time.Second, 10*time.Millisecond, "released locks are dropped")
+	})
+
+	t.Run("OtherTargets_Independent", func(t *testing.T) {
+		t.Parallel()
+		var l targetLocks
+		defer l.lock(freezerv1alpha1.TargetKindDeployment, web)()
+
+		done := make(chan struct{})
+		go func() {
+			l.lock(freezerv1alpha1.TargetKindDeployment, types.NamespacedName{Namespace: "ns", Name: "api"})()
+			l.lock(freezerv1alpha1.TargetKindReplicaSet, web)()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("a lock on another target blocked")
+		}
+	})
+
+	t.Run("StaleCache_SecondClaimRefused", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		require.NoError(t, clientgoscheme.AddToScheme(scheme))
+		require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+		deploy := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web", UID: "web-uid"},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(3))},
+		}
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "second", UID: "second-uid"}}
+		dfz.Spec.TargetRef = freezerv1alpha1.DeploymentTargetRef{Name: "web"}
+		dfz.Spec.DurationSeconds = 3600
+		api := fake.NewClientBuilder().WithScheme(scheme).WithObjects(dfz, deploy).
+			WithStatusSubresource(&freezerv1alpha1.DeploymentFreezer{}).Build()
+
+		// The cache still shows the Deployment as it was before another replica claimed it.
+		stale := &appsv1.Deployment{}
+		require.NoError(t, api.Get(ctx, client.ObjectKeyFromObject(deploy), stale))
+		claimed := stale.DeepCopy()
+		claimed.Annotations = map[string]string{freeze.AnnotationFrozenBy: "ns/first/first-uid"}
+		require.NoError(t, api.Update(ctx, claimed))
+		cached := interceptor.NewClient(api, interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if d, ok := obj.(*appsv1.Deployment); ok {
+					stale.DeepCopyInto(d)
+					return nil
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+		})
+		r := &DeploymentFreezerReconciler{
+			Client:    cached,
+			APIReader: api,
+			Recorder:  record.NewFakeRecorder(20),
+			Clock:     testingclock.NewFakeClock(time.Now()),
+		}
+
+		for range 3 {
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(dfz)})
+			require.NoError(t, err)
+		}
+		got := &appsv1.Deployment{}
+		require.NoError(t, api.Get(ctx, client.ObjectKeyFromObject(deploy), got))
+		assert.Equal(t, "ns/first/first-uid", freeze.Holder(got))
+		assert.Equal(t, int32(3), *got.Spec.Replicas)
+	})
+}
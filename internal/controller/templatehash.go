@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// templateHashPrefix marks hashes of the normalized template as canonical JSON. Hashes recorded
+// by earlier versions lack it and cannot be compared with the current ones.
+const templateHashPrefix = "v2:"
+
+// Defaults the API server fills into fields left unset that corev1 has no constant for.
+const (
+	defaultProbeTimeoutSeconds     = 1
+	defaultProbePeriodSeconds      = 10
+	defaultProbeSuccessThreshold   = 1
+	defaultProbeFailureThreshold   = 3
+	defaultFieldRefAPIVersion      = "v1"
+	defaultRollingUpdateFraction   = "25%"
+	defaultDaemonSetMaxUnavailable = 1
+)
+
+// normalizedPodSpec returns a copy of spec with every field the API server defaults cleared when
+// it holds its default, so a template as written and as stored compare equal, and a re-apply
+// that only leaves out defaulted fields is not taken for a change.
+func normalizedPodSpec(spec corev1.PodSpec) corev1.PodSpec {
+	spec = *spec.DeepCopy()
+	clearIf(&spec.RestartPolicy, corev1.RestartPolicyAlways)
+	clearIf(&spec.DNSPolicy, corev1.DNSClusterFirst)
+	clearIf(&spec.SchedulerName, corev1.DefaultSchedulerName)
+	clearIf(&spec.DeprecatedServiceAccount, spec.ServiceAccountName)
+	clearPtrIf(&spec.TerminationGracePeriodSeconds, corev1.DefaultTerminationGracePeriodSeconds)
+	clearPtrIf(&spec.EnableServiceLinks, true)
+	if spec.SecurityContext != nil && equality.Semantic.DeepEqual(*spec.SecurityContext, corev1.PodSecurityContext{}) {
+		spec.SecurityContext = nil
+	}
+	for i := range spec.InitContainers {
+		normalizeContainer(&spec.InitContainers[i])
+	}
+	for i := range spec.Containers {
+		normalizeContainer(&spec.Containers[i])
+	}
+	for i := range spec.Volumes {
+		normalizeVolume(&spec.Volumes[i].VolumeSource)
+	}
+	return spec
+}
+
+func normalizeContainer(c *corev1.Container) {
+	clearIf(&c.TerminationMessagePath, corev1.TerminationMessagePathDefault)
+	clearIf(&c.TerminationMessagePolicy, corev1.TerminationMessageReadFile)
+	clearIf(&c.ImagePullPolicy, defaultPullPolicy(c.Image))
+	for i := range c.Ports {
+		clearIf(&c.Ports[i].Protocol, corev1.ProtocolTCP)
+	}
+	for i := range c.Env {
+		if from := c.Env[i].ValueFrom; from != nil && from.FieldRef != nil {
+			clearIf(&from.FieldRef.APIVersion, defaultFieldRefAPIVersion)
+		}
+	}
+	for _, p := range []*corev1.Probe{c.LivenessProbe, c.ReadinessProbe, c.StartupProbe} {
+		normalizeProbe(p)
+	}
+	if c.Lifecycle != nil {
+		for _, h := range []*corev1.LifecycleHandler{c.Lifecycle.PostStart, c.Lifecycle.PreStop} {
+			if h != nil {
+				normalizeHTTPGet(h.HTTPGet)
+			}
+		}
+	}
+	// Requests left out are copied from the limits.
+	for name, limit := range c.Resources.Limits {
+		if req, ok := c.Resources.Requests[name]; ok && req.Cmp(limit) == 0 {
+			delete(c.Resources.Requests, name)
+		}
+	}
+	if len(c.Resources.Requests) == 0 {
+		c.Resources.Requests = nil
+	}
+}
+
+func normalizeProbe(p *corev1.Probe) {
+	if p == nil {
+		return
+	}
+	clearIf(&p.TimeoutSeconds, defaultProbeTimeoutSeconds)
+	clearIf(&p.PeriodSeconds, defaultProbePeriodSeconds)
+	clearIf(&p.SuccessThreshold, defaultProbeSuccessThreshold)
+	clearIf(&p.FailureThreshold, defaultProbeFailureThreshold)
+	normalizeHTTPGet(p.HTTPGet)
+}
+
+func normalizeHTTPGet(g *corev1.HTTPGetAction) {
+	if g != nil {
+		clearIf(&g.Scheme, corev1.URISchemeHTTP)
+	}
+}
+
+func normalizeVolume(v *corev1.VolumeSource) {
+	switch {
+	case v.ConfigMap != nil:
+		clearPtrIf(&v.ConfigMap.DefaultMode, corev1.ConfigMapVolumeSourceDefaultMode)
+	case v.Secret != nil:
+		clearPtrIf(&v.Secret.DefaultMode, corev1.SecretVolumeSourceDefaultMode)
+	case v.Projected != nil:
+		clearPtrIf(&v.Projected.DefaultMode, corev1.ProjectedVolumeSourceDefaultMode)
+	case v.DownwardAPI != nil:
+		clearPtrIf(&v.DownwardAPI.DefaultMode, corev1.DownwardAPIVolumeSourceDefaultMode)
+	case v.HostPath != nil:
+		clearPtrIf(&v.HostPath.Type, corev1.HostPathUnset)
+	}
+}
+
+// defaultPullPolicy is the pull policy the API server sets for image: Always for an untagged or
+// latest image, IfNotPresent otherwise.
+func defaultPullPolicy(image string) corev1.PullPolicy {
+	if strings.Contains(image, "@") {
+		return corev1.PullIfNotPresent
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	if i := strings.LastIndex(name, ":"); i < 0 || name[i+1:] == "latest" {
+		return corev1.PullAlways
+	}
+	return corev1.PullIfNotPresent
+}
+
+// defaultedDeploymentStrategy returns s with the API server defaults filled in, so a strategy left
+// out and the rolling update it defaults to compare equal.
+func defaultedDeploymentStrategy(s appsv1.DeploymentStrategy) appsv1.DeploymentStrategy {
+	s = *s.DeepCopy()
+	if s.Type == "" {
+		s.Type = appsv1.RollingUpdateDeploymentStrategyType
+	}
+	if s.Type != appsv1.RollingUpdateDeploymentStrategyType {
+		return s
+	}
+	if s.RollingUpdate == nil {
+		s.RollingUpdate = &appsv1.RollingUpdateDeployment{}
+	}
+	fraction := intstr.FromString(defaultRollingUpdateFraction)
+	setIfNil(&s.RollingUpdate.MaxUnavailable, fraction)
+	setIfNil(&s.RollingUpdate.MaxSurge, fraction)
+	return s
+}
+
+// defaultedDaemonSetStrategy is defaultedDeploymentStrategy for a DaemonSet.
+func defaultedDaemonSetStrategy(s appsv1.DaemonSetUpdateStrategy) appsv1.DaemonSetUpdateStrategy {
+	s = *s.DeepCopy()
+	if s.Type == "" {
+		s.Type = appsv1.RollingUpdateDaemonSetStrategyType
+	}
+	if s.Type != appsv1.RollingUpdateDaemonSetStrategyType {
+		return s
+	}
+	if s.RollingUpdate == nil {
+		s.RollingUpdate = &appsv1.RollingUpdateDaemonSet{}
+	}
+	setIfNil(&s.RollingUpdate.MaxUnavailable, intstr.FromInt32(defaultDaemonSetMaxUnavailable))
+	setIfNil(&s.RollingUpdate.MaxSurge, intstr.FromInt32(0))
+	return s
+}
+
+func clearIf[T comparable](v *T, def T) {
+	if *v == def {
+		var zero T
+		*v = zero
+	}
+}
+
+func clearPtrIf[T comparable](p **T, def T) {
+	if *p != nil && **p == def {
+		*p = nil
+	}
+}
+
+func setIfNil[T any](p **T, def T) {
+	if *p == nil {
+		*p = &def
+	}
+}
@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func TestHashTemplate_ServerDefaults(t *testing.T) {
+	// newApplied returns a Deployment as written in a manifest.
+	newApplied := func() *appsv1.Deployment {
+		d := &appsv1.Deployment{}
+		d.Spec.Template.Labels = map[string]string{"app": "web"}
+		d.Spec.Template.Spec = corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  "app",
+				Image: "registry.local:5000/web:1.2",
+				Ports: []corev1.ContainerPort{{ContainerPort: 8080}},
+				ReadinessProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+					HTTPGet: &corev1.HTTPGetAction{Path: "/ready", Port: intstr.FromInt32(8080)},
+				}},
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+				},
+			}},
+			Volumes: []corev1.Volume{{
+				Name:         "config",
+				VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}},
+			}},
+		}
+		return d
+	}
+	// newStored returns the same Deployment as the API server stores it.
+	newStored := func() *appsv1.Deployment {
+		d := newApplied()
+		spec := &d.Spec.Template.Spec
+		spec.RestartPolicy = corev1.RestartPolicyAlways
+		spec.DNSPolicy = corev1.DNSClusterFirst
+		spec.SchedulerName = corev1.DefaultSchedulerName
+		spec.TerminationGracePeriodSeconds = ptr.To(int64(corev1.DefaultTerminationGracePeriodSeconds))
+		spec.SecurityContext = &corev1.PodSecurityContext{}
+		spec.Volumes[0].ConfigMap.DefaultMode = ptr.To(corev1.ConfigMapVolumeSourceDefaultMode)
+		c := &spec.Containers[0]
+		c.ImagePullPolicy = corev1.PullIfNotPresent
+		c.TerminationMessagePath = corev1.TerminationMessagePathDefault
+		c.TerminationMessagePolicy = corev1.TerminationMessageReadFile
+		c.Ports[0].Protocol = corev1.ProtocolTCP
+		c.ReadinessProbe.TimeoutSeconds = 1
+		c.ReadinessProbe.PeriodSeconds = 10
+		c.ReadinessProbe.SuccessThreshold = 1
+		c.ReadinessProbe.FailureThreshold = 3
+		c.ReadinessProbe.HTTPGet.Scheme = corev1.URISchemeHTTP
+		c.Resources.Requests = corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")}
+		quarter := intstr.FromString("25%")
+		d.Spec.Strategy = appsv1.DeploymentStrategy{
+			Type:          appsv1.RollingUpdateDeploymentStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateDeployment{MaxUnavailable: &quarter, MaxSurge: &quarter},
+		}
+		return d
+	}
+
+	t.Run("Defaulted_SameHash", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, hashTemplate(newApplied()), hashTemplate(newStored()))
+	})
+
+	t.Run("NonDefaultValue_ChangesHash", func(t *testing.T) {
+		t.Parallel()
+		d := newStored()
+		d.Spec.Template.Spec.Containers[0].ReadinessProbe.PeriodSeconds = 30
+		assert.NotEqual(t, hashTemplate(newStored()), hashTemplate(d))
+	})
+
+	t.Run("LatestImage_PullAlwaysIsDefault", func(t *testing.T) {
+		t.Parallel()
+		d, other := newApplied(), newApplied()
+		d.Spec.Template.Spec.Containers[0].Image = "web"
+		other.Spec.Template.Spec.Containers[0].Image = "web"
+		other.Spec.Template.Spec.Containers[0].ImagePullPolicy = corev1.PullAlways
+		assert.Equal(t, hashTemplate(d), hashTemplate(other))
+		other.Spec.Template.Spec.Containers[0].ImagePullPolicy = corev1.PullIfNotPresent
+		assert.NotEqual(t, hashTemplate(d), hashTemplate(other))
+	})
+
+	t.Run("DaemonSetStrategy_Defaulted", func(t *testing.T) {
+		t.Parallel()
+		ds := &appsv1.DaemonSet{}
+		stored := ds.DeepCopy()
+		one, zero := intstr.FromInt32(1), intstr.FromInt32(0)
+		stored.Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{
+			Type:          appsv1.RollingUpdateDaemonSetStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateDaemonSet{MaxUnavailable: &one, MaxSurge: &zero},
+		}
+		assert.Equal(t, hashTemplate(ds), hashTemplate(stored))
+	})
+}
+
+func TestDefaultPullPolicy(t *testing.T) {
+	for image, want := range map[string]corev1.PullPolicy{
+		"nginx":                          corev1.PullAlways,
+		"nginx:latest":                   corev1.PullAlways,
+		"registry.local:5000/nginx":      corev1.PullAlways,
+		"registry.local:5000/nginx:1.27": corev1.PullIfNotPresent,
+		"nginx@sha256:0123":              corev1.PullIfNotPresent,
+	} {
+		assert.Equal(t, want, defaultPullPolicy(image), image)
+	}
+}
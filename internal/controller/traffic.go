@@ -0,0 +1,165 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// trafficRestoreTimeout bounds how long diverted traffic waits for the restored Deployment to
+// become available, so a broken rollout does not keep the DFZ Unfreezing forever.
+const trafficRestoreTimeout = 5 * time.Minute
+
+// divertTraffic sends the Deployment's traffic elsewhere before it is scaled down: Ingresses to
+// spec.maintenancePage and the Service of spec.standby to the standby Deployment. It runs once
+// per DFZ and reports whether the freeze must wait. Every step records what it changed on the
+// changed object itself, so repeating it after a lost status write does no harm.
+func (r *DeploymentFreezerReconciler) divertTraffic(
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	deploy *appsv1.Deployment,
+) (ctrl.Result, bool) {
+	if (dfz.Spec.MaintenancePage == nil && dfz.Spec.Standby == nil) || trafficCondition(dfz) != nil {
+		return ctrl.Result{}, false
+	}
+
+	var diverted []string
+	if page := dfz.Spec.MaintenancePage; page != nil {
+		switched, err := r.switchIngresses(ctx, dfz, deploy)
+		if err != nil {
+			return r.trafficFailed(dfz, opDivertTraffic, msgTrafficDivertFailedFmt, err), true
+		}
+		if len(switched) > 0 {
+			dfz.Status.MaintenanceIngresses = switched
+			diverted = append(diverted,
+				fmt.Sprintf(msgDivertedIngressesFmt, strings.Join(switched, ", "), backendString(page.Backend)))
+		}
+	}
+	if sb := dfz.Spec.Standby; sb != nil {
+		swapped, err := r.swapService(ctx, dfz)
+		if err != nil {
+			return r.trafficFailed(dfz, opDivertTraffic, msgTrafficDivertFailedFmt, err), true
+		}
+		if swapped {
+			diverted = append(diverted, fmt.Sprintf(msgDivertedServiceFmt, sb.ServiceName, sb.DeploymentName))
+		}
+	}
+
+	if len(diverted) == 0 {
+		// The freeze goes ahead: a missing route is no reason to keep the Deployment up.
+		r.Recorder.Event(dfz, corev1.EventTypeWarning, ReasonTrafficDiverted, msgTrafficNoRouteEvent)
+		setCondition(
+			dfz,
+			freezerv1alpha1.ConditionTypeTraffic,
+			freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonNoRoute,
+			msgTrafficNoRoute,
+		)
+		return ctrl.Result{}, false
+	}
+	summary := strings.Join(diverted, "; ")
+	r.Recorder.Eventf(dfz, corev1.EventTypeNormal, ReasonTrafficDiverted, msgTrafficDiverted, summary)
+	setCondition(
+		dfz,
+		freezerv1alpha1.ConditionTypeTraffic,
+		freezerv1alpha1.ConditionStatusTrue,
+		freezerv1alpha1.ConditionReasonMaintenance,
+		fmt.Sprintf(msgTrafficDivertedFmt, summary),
+	)
+	return ctrl.Result{}, false
+}
+
+// restoreTraffic undoes divertTraffic once the restored Deployment has an available replica, or
+// after trafficRestoreTimeout, and reports whether the unfreeze must wait.
+func (r *DeploymentFreezerReconciler) restoreTraffic(
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	deploy *appsv1.Deployment,
+	replicas int32,
+) (ctrl.Result, bool) {
+	if c := trafficCondition(dfz); c == nil || c.Status != freezerv1alpha1.ConditionStatusTrue {
+		return ctrl.Result{}, false
+	}
+
+	since, ok := dfz.Status.PhaseTransitionTimes[freezerv1alpha1.PhaseUnfreezing]
+	timedOut := ok && !r.Clock.Now().Before(since.Add(trafficRestoreTimeout))
+	if replicas > 0 && deploy.Status.AvailableReplicas == 0 && !timedOut {
+		setStableCondition(
+			dfz,
+			freezerv1alpha1.ConditionTypeTraffic,
+			freezerv1alpha1.ConditionStatusTrue,
+			freezerv1alpha1.ConditionReasonAwaitingBackend,
+			msgTrafficAwaitingBackend,
+		)
+		return ctrl.Result{RequeueAfter: requeueShort}, true
+	}
+
+	restored, err := r.restoreAllTraffic(ctx, dfz)
+	if err != nil {
+		return r.trafficFailed(dfz, opRestoreTraffic, msgTrafficRestoreFailedFmt, err), true
+	}
+	if len(restored) > 0 {
+		r.Recorder.Eventf(dfz, corev1.EventTypeNormal, ReasonTrafficRestored, msgTrafficRestored, strings.Join(restored, "; "))
+	}
+	setCondition(
+		dfz,
+		freezerv1alpha1.ConditionTypeTraffic,
+		freezerv1alpha1.ConditionStatusFalse,
+		freezerv1alpha1.ConditionReasonTrafficRestored,
+		msgTrafficRestoredCond,
+	)
+	return ctrl.Result{}, false
+}
+
+// restoreAllTraffic restores the Ingresses and the Service diverted by this DFZ and describes
+// what it restored. It does not wait for the Deployment, for use when the DFZ is deleted.
+func (r *DeploymentFreezerReconciler) restoreAllTraffic(
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+) ([]string, error) {
+	var restored []string
+	ingresses, err := r.restoreIngresses(ctx, dfz)
+	if len(ingresses) > 0 {
+		restored = append(restored, "Ingresses "+strings.Join(ingresses, ", "))
+	}
+	if err != nil {
+		return restored, err
+	}
+	dfz.Status.MaintenanceIngresses = nil
+
+	svc, err := r.restoreService(ctx, dfz)
+	if err != nil {
+		return restored, err
+	}
+	if svc != "" {
+		restored = append(restored, "Service "+svc)
+	}
+	dfz.Status.Standby = nil
+	return restored, nil
+}
+
+// trafficFailed reports a failed Ingress or Service write and retries shortly.
+func (r *DeploymentFreezerReconciler) trafficFailed(
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	op, format string,
+	err error,
+) ctrl.Result {
+	r.operationFailed(dfz, op, fmt.Sprintf(format, err))
+	return ctrl.Result{RequeueAfter: requeueShort}
+}
+
+// trafficCondition returns the Traffic condition, which is set once divertTraffic has run.
+func trafficCondition(dfz *freezerv1alpha1.DeploymentFreezer) *freezerv1alpha1.Condition {
+	for i := range dfz.Status.Conditions {
+		if dfz.Status.Conditions[i].Type == freezerv1alpha1.ConditionTypeTraffic {
+			return &dfz.Status.Conditions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// unfreezeSlotTimeout bounds how long an admitted unfreeze holds its slot waiting for the
+	// target to become available, so a broken rollout does not block every other unfreeze.
+	unfreezeSlotTimeout = 5 * time.Minute
+	// unfreezeSlotGrace is how long a slot taken by this replica counts on its own, until the
+	// status write recording it is back in the cache.
+	unfreezeSlotGrace = 10 * time.Second
+)
+
+// unfreezeSlots remembers the DFZs this replica admitted to scale up recently.
+type unfreezeSlots struct {
+	mu       sync.Mutex
+	admitted map[types.NamespacedName]time.Time
+}
+
+// acquireUnfreezeSlot reports whether dfz may start scaling its target up: fewer than
+// MaxConcurrentUnfreezes other DFZs of the cluster are restoring. A DFZ restores from its
+// admission until its target is available, or for unfreezeSlotTimeout.
+func (r *DeploymentFreezerReconciler) acquireUnfreezeSlot(
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+) (bool, error) {
+	if r.MaxConcurrentUnfreezes <= 0 {
+		return true, nil
+	}
+	s := &r.unfreezeSlots
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// A shard only caches its own DFZs; the limit spans every shard.
+	var reader client.Reader = r.Client
+	if r.Shard.Enabled() {
+		reader = r.APIReader
+	}
+	var list freezerv1alpha1.DeploymentFreezerList
+	if err := reader.List(ctx, &list); err != nil {
+		return false, err
+	}
+
+	self := client.ObjectKeyFromObject(dfz)
+	now := r.Clock.Now()
+	busy := map[types.NamespacedName]bool{}
+	for i := range list.Items {
+		if key := client.ObjectKeyFromObject(&list.Items[i]); key != self && restoring(&list.Items[i]) {
+			busy[key] = true
+		}
+	}
+	for key, at := range s.admitted {
+		switch {
+		case now.Sub(at) >= unfreezeSlotGrace:
+			delete(s.admitted, key)
+		case key != self:
+			busy[key] = true
+		}
+	}
+	if len(busy) >= r.MaxConcurrentUnfreezes {
+		return false, nil
+	}
+
+	if s.admitted == nil {
+		s.admitted = map[types.NamespacedName]time.Time{}
+	}
+	s.admitted[self] = now
+	return true, nil
+}
+
+// restoring reports whether dfz holds an unfreeze slot: it is Unfreezing, was admitted and its
+// target is not available yet. Conditions carry the wall clock time, so the timeout does too.
+func restoring(dfz *freezerv1alpha1.DeploymentFreezer) bool {
+	if dfz.Status.Phase != freezerv1alpha1.PhaseUnfreezing {
+		return false
+	}
+	for _, c := range dfz.Status.Conditions {
+		if c.Type != freezerv1alpha1.ConditionTypeUnfreezeProgress {
+			continue
+		}
+		switch c.Reason {
+		case freezerv1alpha1.ConditionReasonScalingUp:
+			return time.Since(c.LastTransitionTime.Time) < unfreezeSlotTimeout
+		case freezerv1alpha1.ConditionReasonCanary, freezerv1alpha1.ConditionReasonQuotaExceeded:
+			return true
+		}
+		return false
+	}
+	return false
+}
+
+// awaitAvailable keeps an admitted DFZ Unfreezing, holding its slot, until the target has
+// targetReplicas available replicas or unfreezeSlotTimeout passed, and reports whether it must
+// wait.
+func (r *DeploymentFreezerReconciler) awaitAvailable(
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	obj client.Object,
+	targetReplicas int32,
+) bool {
+	if r.MaxConcurrentUnfreezes <= 0 {
+		return false
+	}
+	if _, available := readyReplicas(obj); available >= targetReplicas {
+		return false
+	}
+	return restoring(dfz)
+}
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAcquireUnfreezeSlot(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	newDFZ := func(ns, name string, reason freezerv1alpha1.ConditionReason, since time.Time) *freezerv1alpha1.DeploymentFreezer {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name}}
+		dfz.Status.Phase = freezerv1alpha1.PhaseUnfreezing
+		if reason != "" {
+			dfz.Status.Conditions = []freezerv1alpha1.Condition{{
+				Type:               freezerv1alpha1.ConditionTypeUnfreezeProgress,
+				Status:             freezerv1alpha1.ConditionStatusFalse,
+				Reason:             reason,
+				LastTransitionTime: metav1.NewTime(since),
+			}}
+		}
+		return dfz
+	}
+	newReconciler := func(limit int, objs ...client.Object) *DeploymentFreezerReconciler {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+		return &DeploymentFreezerReconciler{
+			Client:                 c,
+			APIReader:              c,
+			Clock:                  testingclock.NewFakeClock(now),
+			MaxConcurrentUnfreezes: limit,
+		}
+	}
+
+	t.Run("NoLimit_Admitted", func(t *testing.T) {
+		t.Parallel()
+		ok, err := newReconciler(0).acquireUnfreezeSlot(context.Background(), newDFZ("a", "x", "", now))
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("RestoringElsewhere_Refused", func(t *testing.T) {
+		t.Parallel()
+		r := newReconciler(1, newDFZ("b", "other", freezerv1alpha1.ConditionReasonScalingUp, time.Now()))
+		ok, err := r.acquireUnfreezeSlot(context.Background(), newDFZ("a", "x", "", now))
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("ThrottledAndTimedOut_NotCounted", func(t *testing.T) {
+		t.Parallel()
+		r := newReconciler(1,
+			newDFZ("b", "waiting", freezerv1alpha1.ConditionReasonThrottled, time.Now()),
+			newDFZ("c", "stuck", freezerv1alpha1.ConditionReasonScalingUp, time.Now().Add(-unfreezeSlotTimeout)),
+		)
+		ok, err := r.acquireUnfreezeSlot(context.Background(), newDFZ("a", "x", "", now))
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("AdmittedLocally_CountsUntilCached", func(t *testing.T) {
+		t.Parallel()
+		r := newReconciler(1)
+		first := newDFZ("a", "x", "", now)
+		ok, err := r.acquireUnfreezeSlot(context.Background(), first)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = r.acquireUnfreezeSlot(context.Background(), newDFZ("b", "y", "", now))
+		require.NoError(t, err)
+		assert.False(t, ok)
+		// The same DFZ asking again does not count itself.
+		ok, err = r.acquireUnfreezeSlot(context.Background(), first)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("Unfreezing_HoldsUntilAvailable", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ("ns", "dfz", "", now)
+		dfz.Status.OriginalReplicas = ptr.To(int32(3))
+		deploy := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web"},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(0))},
+		}
+		r := newReconciler(1, deploy)
+		r.Recorder = record.NewFakeRecorder(10)
+		target := func() freeze.Freezable {
+			got, err := (&freeze.Freezer{Client: r.Client}).Target(deploy)
+			require.NoError(t, err)
+			return got
+		}
+
+		res, err := r.handleUnfreezing(context.Background(), dfz, deploy, target())
+		require.NoError(t, err)
+		assert.Positive(t, res.RequeueAfter)
+		assert.Equal(t, freezerv1alpha1.PhaseUnfreezing, dfz.Status.Phase)
+		assert.True(t, hasCondition(dfz, freezerv1alpha1.ConditionTypeUnfreezeProgress,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonScalingUp))
+
+		require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(deploy), deploy))
+		assert.Equal(t, int32(3), *deploy.Spec.Replicas)
+		deploy.Status.AvailableReplicas = 3
+		require.NoError(t, r.Status().Update(context.Background(), deploy))
+		_, err = r.handleUnfreezing(context.Background(), dfz, deploy, target())
+		require.NoError(t, err)
+		assert.Equal(t, freezerv1alpha1.PhaseCompleted, dfz.Status.Phase)
+	})
+}
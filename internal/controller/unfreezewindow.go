@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/internal/schedule"
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// deferUnfreeze keeps an elapsed freeze Frozen while spec.unfreezeWindow is closed, and reports
+// whether it did. The target is still checked for drift while it waits. An invalid window,
+// which the webhook normally rejects, holds the freeze too: it never ends at an unintended time.
+func (r *DeploymentFreezerReconciler) deferUnfreeze(
+	ctx context.Context,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+	target freeze.Freezable,
+) (ctrl.Result, bool) {
+	if dfz.Spec.UnfreezeWindow == nil {
+		return ctrl.Result{}, false
+	}
+	window, err := schedule.FromUnfreezeWindow(dfz.Spec.UnfreezeWindow)
+	if err != nil {
+		setCondition(
+			dfz,
+			freezerv1alpha1.ConditionTypeUnfreezeProgress,
+			freezerv1alpha1.ConditionStatusFalse,
+			freezerv1alpha1.ConditionReasonInvalidWindow,
+			fmt.Sprintf(msgInvalidUnfreezeWindowFmt, err),
+		)
+		r.checkDrift(ctx, dfz, target)
+		return ctrl.Result{RequeueAfter: driftCheckInterval}, true
+	}
+
+	now := r.Clock.Now()
+	next := window.Next(now)
+	if !next.After(now) {
+		return ctrl.Result{}, false
+	}
+	opensAt := next.In(window.Location).Format(time.RFC3339)
+	if !hasCondition(dfz, freezerv1alpha1.ConditionTypeUnfreezeProgress,
+		freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonOutsideWindow) {
+		r.Recorder.Eventf(dfz, corev1.EventTypeNormal, ReasonUnfreezeDeferred, msgUnfreezeDeferred, opensAt)
+	}
+	setCondition(
+		dfz,
+		freezerv1alpha1.ConditionTypeUnfreezeProgress,
+		freezerv1alpha1.ConditionStatusFalse,
+		freezerv1alpha1.ConditionReasonOutsideWindow,
+		fmt.Sprintf(msgOutsideUnfreezeWindowFmt, window, opensAt),
+	)
+	r.checkDrift(ctx, dfz, target)
+	return ctrl.Result{RequeueAfter: min(r.untilTime(next), driftCheckInterval)}, true
+}
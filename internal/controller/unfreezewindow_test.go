@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+)
+
+func TestDeferUnfreeze(t *testing.T) {
+	// Saturday, 7 March 2026, 03:00 UTC.
+	saturdayNight := time.Date(2026, 3, 7, 3, 0, 0, 0, time.UTC)
+	newObjects := func(window *freezerv1alpha1.UnfreezeWindow) (*freezerv1alpha1.DeploymentFreezer, *appsv1.Deployment) {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "window", Name: "dfz", UID: "dfz-uid"}}
+		dfz.Spec.UnfreezeWindow = window
+		dfz.Status.Phase = freezerv1alpha1.PhaseFrozen
+		deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "window",
+			Name:        "web",
+			Annotations: map[string]string{annoFrozenBy: frozenByValue(dfz)},
+		}}
+		deploy.Spec.Replicas = ptr.To(int32(0))
+		return dfz, deploy
+	}
+	newReconciler := func(now time.Time) (*DeploymentFreezerReconciler, *record.FakeRecorder) {
+		rec := record.NewFakeRecorder(10)
+		return &DeploymentFreezerReconciler{Recorder: rec, Clock: testingclock.NewFakeClock(now)}, rec
+	}
+	weekdays := &freezerv1alpha1.UnfreezeWindow{
+		Days:  []freezerv1alpha1.Weekday{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
+		Start: "09:00",
+		End:   "17:00",
+	}
+
+	t.Run("NoWindow_NotDeferred", func(t *testing.T) {
+		t.Parallel()
+		r, _ := newReconciler(saturdayNight)
+		dfz, deploy := newObjects(nil)
+		_, deferred := r.deferUnfreeze(context.Background(), dfz, freezeTarget(t, deploy))
+		assert.False(t, deferred)
+	})
+
+	t.Run("OutsideWindow_HeldUntilItOpens", func(t *testing.T) {
+		t.Parallel()
+		r, rec := newReconciler(saturdayNight)
+		dfz, deploy := newObjects(weekdays)
+
+		res, deferred := r.deferUnfreeze(context.Background(), dfz, freezeTarget(t, deploy))
+		require.True(t, deferred)
+		assert.Equal(t, driftCheckInterval, res.RequeueAfter)
+		assert.True(t, hasCondition(dfz, freezerv1alpha1.ConditionTypeUnfreezeProgress,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonOutsideWindow))
+		assert.True(t, hasCondition(dfz, freezerv1alpha1.ConditionTypeDriftDetected,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonNoDrift))
+		assert.Contains(t, dfz.Status.Conditions[0].Message, "2026-03-09T09:00:00Z")
+
+		// The deferral is announced once.
+		_, deferred = r.deferUnfreeze(context.Background(), dfz, freezeTarget(t, deploy))
+		assert.True(t, deferred)
+		assert.Len(t, rec.Events, 1)
+	})
+
+	t.Run("ShortlyBeforeOpening_RequeuesAtOpening", func(t *testing.T) {
+		t.Parallel()
+		r, _ := newReconciler(time.Date(2026, 3, 9, 8, 59, 30, 0, time.UTC))
+		dfz, deploy := newObjects(weekdays)
+		res, deferred := r.deferUnfreeze(context.Background(), dfz, freezeTarget(t, deploy))
+		require.True(t, deferred)
+		assert.Equal(t, 30*time.Second, res.RequeueAfter)
+	})
+
+	t.Run("InsideWindow_NotDeferred", func(t *testing.T) {
+		t.Parallel()
+		r, _ := newReconciler(time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC))
+		dfz, deploy := newObjects(weekdays)
+		_, deferred := r.deferUnfreeze(context.Background(), dfz, freezeTarget(t, deploy))
+		assert.False(t, deferred)
+	})
+
+	t.Run("InvalidWindow_Held", func(t *testing.T) {
+		t.Parallel()
+		r, _ := newReconciler(saturdayNight)
+		dfz, deploy := newObjects(&freezerv1alpha1.UnfreezeWindow{Start: "09:00", End: "17:00", TimeZone: "Nowhere/Town"})
+		_, deferred := r.deferUnfreeze(context.Background(), dfz, freezeTarget(t, deploy))
+		assert.True(t, deferred)
+		assert.True(t, hasCondition(dfz, freezerv1alpha1.ConditionTypeUnfreezeProgress,
+			freezerv1alpha1.ConditionStatusFalse, freezerv1alpha1.ConditionReasonInvalidWindow))
+	})
+}
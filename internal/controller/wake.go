@@ -0,0 +1,26 @@
+package controller
+
+import (
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// wakeRequested reports whether the activator asked to end the freeze early. The annotation
+// only counts while spec.wakeOnRequest is set, so removing the field disarms a stale one.
+func wakeRequested(dfz *freezerv1alpha1.DeploymentFreezer) bool {
+	return dfz.Spec.WakeOnRequest != nil && dfz.Annotations[freezerv1alpha1.AnnoWakeRequested] != ""
+}
+
+// wakeRequestedAdded passes updates that add the wake-requested annotation, so the freeze ends
+// right away rather than at the next drift check.
+var wakeRequestedAdded = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return !hasWakeAnnotation(e.ObjectOld) && hasWakeAnnotation(e.ObjectNew)
+	},
+}
+
+func hasWakeAnnotation(obj client.Object) bool {
+	return obj.GetAnnotations()[freezerv1alpha1.AnnoWakeRequested] != ""
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestWakeOnRequest(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	newReconciler := func() *DeploymentFreezerReconciler {
+		return &DeploymentFreezerReconciler{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).Build(),
+			Recorder: record.NewFakeRecorder(10),
+			Clock:    testingclock.NewFakeClock(now),
+		}
+	}
+	newFrozen := func() (*freezerv1alpha1.DeploymentFreezer, *appsv1.Deployment) {
+		dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "dev",
+			Name:        "dfz",
+			UID:         "dfz-uid",
+			Annotations: map[string]string{freezerv1alpha1.AnnoWakeRequested: now.Format(time.RFC3339)},
+		}}
+		dfz.Spec.WakeOnRequest = &freezerv1alpha1.WakeOnRequest{Hosts: []string{"web.dev.example.com"}}
+		dfz.Status.Phase = freezerv1alpha1.PhaseFrozen
+		dfz.Status.FreezeUntil = ptr.To(metav1.NewTime(now.Add(time.Hour)))
+		deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "dev",
+			Name:        "web",
+			Annotations: map[string]string{annoFrozenBy: frozenByValue(dfz)},
+		}}
+		deploy.Spec.Replicas = ptr.To(int32(0))
+		return dfz, deploy
+	}
+
+	t.Run("WakeRequested_UnfreezesEarly", func(t *testing.T) {
+		t.Parallel()
+		r := newReconciler()
+		dfz, deploy := newFrozen()
+		_, err := r.handleFrozen(context.Background(), dfz, deploy, freezeTarget(t, deploy))
+		require.NoError(t, err)
+		assert.Equal(t, freezerv1alpha1.PhaseUnfreezing, dfz.Status.Phase)
+		assert.Contains(t, <-r.Recorder.(*record.FakeRecorder).Events, "Woken by a request")
+	})
+
+	t.Run("WithoutWakeOnRequest_AnnotationIgnored", func(t *testing.T) {
+		t.Parallel()
+		r := newReconciler()
+		dfz, deploy := newFrozen()
+		dfz.Spec.WakeOnRequest = nil
+		_, err := r.handleFrozen(context.Background(), dfz, deploy, freezeTarget(t, deploy))
+		require.NoError(t, err)
+		assert.Equal(t, freezerv1alpha1.PhaseFrozen, dfz.Status.Phase)
+	})
+
+	t.Run("Predicate_PassesAddedAnnotationOnly", func(t *testing.T) {
+		t.Parallel()
+		woken, _ := newFrozen()
+		plain := woken.DeepCopy()
+		plain.Annotations = nil
+		assert.True(t, wakeRequestedAdded.Update(event.UpdateEvent{ObjectOld: plain, ObjectNew: woken}))
+		assert.False(t, wakeRequestedAdded.Update(event.UpdateEvent{ObjectOld: woken, ObjectNew: woken}))
+		assert.False(t, wakeRequestedAdded.Update(event.UpdateEvent{ObjectOld: woken, ObjectNew: plain}))
+	})
+}
@@ -0,0 +1,257 @@
+// Package datadog mutes the Datadog monitors of a Deployment while it is frozen, so that a
+// Deployment scaled to zero on purpose does not page anyone. Monitors are selected by their tags,
+// which are templated from the DeploymentFreezer; they are muted without an end and unmuted as
+// soon as the Deployment leaves Frozen, so a window extended while Frozen stays muted.
+package datadog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// DefaultSite is the Datadog site of the US1 region.
+	DefaultSite = "datadoghq.com"
+	// callTimeout bounds the Datadog calls made for one transition, retries included.
+	callTimeout = time.Minute
+)
+
+var log = logf.Log.WithName("datadog")
+
+var defaultHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// backoff spaces the retries of a failed mute or unmute.
+var backoff = wait.Backoff{Steps: 5, Duration: time.Second, Factor: 2, Jitter: 0.1}
+
+// Muter mutes and unmutes the Datadog monitors matching a DeploymentFreezer.
+type Muter struct {
+	// Site is the Datadog site, e.g. datadoghq.eu; defaults to DefaultSite.
+	Site string
+	// BaseURL overrides the API URL derived from Site, e.g. for a proxy.
+	BaseURL string
+	// APIKey and AppKey authenticate the requests; muting needs an application key.
+	APIKey, AppKey string
+	// MonitorTags select the monitors of a Deployment: a monitor must carry all of them. The
+	// placeholders {namespace}, {deployment} and {name} (of the DeploymentFreezer) are replaced.
+	MonitorTags []string
+	// Scope restricts the mute to the monitor groups matching it, e.g.
+	// "kube_namespace:{namespace},kube_deployment:{deployment}", with the same placeholders.
+	// Empty mutes the whole monitor.
+	Scope string
+	// HTTP is the client used for requests; defaults to one with a 10s timeout.
+	HTTP *http.Client
+
+	mu sync.Mutex
+	// pending holds the calls queued per DeploymentFreezer; a key is present while a goroutine
+	// drains its queue.
+	pending map[string][]func()
+}
+
+// Validate checks the configuration.
+func (m *Muter) Validate() error {
+	switch {
+	case m.APIKey == "" || m.AppKey == "":
+		return fmt.Errorf("an API key and an application key are required")
+	case len(m.MonitorTags) == 0:
+		return fmt.Errorf("at least one monitor tag is required, or every monitor would be muted")
+	}
+	return nil
+}
+
+// OnTransition mutes the monitors of a DeploymentFreezer entering Frozen and unmutes them when it
+// leaves Frozen. The calls are made in the background, one at a time and in order for a given
+// DeploymentFreezer, so an unmute never overtakes the mute it undoes; failures are retried, then
+// logged.
+func (m *Muter) OnTransition(dfz *freezerv1alpha1.DeploymentFreezer, from, to freezerv1alpha1.Phase) {
+	call := m.action(dfz, from, to)
+	if call == nil {
+		return
+	}
+	key := dfz.Namespace + "/" + dfz.Name
+	lg := log.WithValues("dfz", key, "to", to)
+	m.enqueue(key, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+		defer cancel()
+		err := retry.OnError(backoff, func(error) bool { return ctx.Err() == nil }, func() error {
+			return call(ctx)
+		})
+		if err != nil {
+			lg.Error(err, "failed to update Datadog monitors")
+		}
+	})
+}
+
+// enqueue runs fn after the calls already queued for key, starting a goroutine to drain the
+// queue unless one is running.
+func (m *Muter) enqueue(key string, fn func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.pending == nil {
+		m.pending = map[string][]func(){}
+	}
+	queue, running := m.pending[key]
+	m.pending[key] = append(queue, fn)
+	if !running {
+		go m.drain(key)
+	}
+}
+
+// drain runs the calls queued for key until the queue is empty.
+func (m *Muter) drain(key string) {
+	for {
+		m.mu.Lock()
+		queue := m.pending[key]
+		if len(queue) == 0 {
+			delete(m.pending, key)
+			m.mu.Unlock()
+			return
+		}
+		m.pending[key] = queue[1:]
+		m.mu.Unlock()
+		queue[0]()
+	}
+}
+
+// action returns the call a transition needs, or nil.
+func (m *Muter) action(dfz *freezerv1alpha1.DeploymentFreezer, from, to freezerv1alpha1.Phase) func(context.Context) error {
+	// Only what the calls need is kept: dfz belongs to the reconcile.
+	expand := strings.NewReplacer(
+		"{namespace}", dfz.Namespace,
+		"{deployment}", dfz.TargetName(),
+		"{name}", dfz.Name,
+	).Replace
+	tags := make([]string, 0, len(m.MonitorTags))
+	for _, tag := range m.MonitorTags {
+		tags = append(tags, expand(tag))
+	}
+	scope := expand(m.Scope)
+
+	switch {
+	case to == freezerv1alpha1.PhaseFrozen && from != to:
+		// No end: status.freezeUntil may still move, and the unmute follows the window.
+		return func(ctx context.Context) error { return m.Mute(ctx, tags, scope, time.Time{}) }
+	case from == freezerv1alpha1.PhaseFrozen && to != from:
+		return func(ctx context.Context) error { return m.Unmute(ctx, tags, scope) }
+	}
+	return nil
+}
+
+// Mute mutes the monitors carrying all tags, within scope, until end; a zero end mutes them
+// until they are unmuted.
+func (m *Muter) Mute(ctx context.Context, tags []string, scope string, end time.Time) error {
+	ids, err := m.monitors(ctx, tags)
+	if err != nil {
+		return err
+	}
+	body := map[string]any{}
+	if scope != "" {
+		body["scope"] = scope
+	}
+	if !end.IsZero() {
+		body["end"] = end.Unix()
+	}
+	for _, id := range ids {
+		if err := m.do(ctx, http.MethodPost, fmt.Sprintf("/api/v1/monitor/%d/mute", id), body, nil); err != nil {
+			return fmt.Errorf("mute monitor %d: %w", id, err)
+		}
+	}
+	log.V(1).Info("muted Datadog monitors", "monitors", ids, "scope", scope, "end", end)
+	return nil
+}
+
+// Unmute unmutes the monitors carrying all tags, within scope.
+func (m *Muter) Unmute(ctx context.Context, tags []string, scope string) error {
+	ids, err := m.monitors(ctx, tags)
+	if err != nil {
+		return err
+	}
+	body := map[string]any{}
+	if scope != "" {
+		body["scope"] = scope
+	}
+	for _, id := range ids {
+		if err := m.do(ctx, http.MethodPost, fmt.Sprintf("/api/v1/monitor/%d/unmute", id), body, nil); err != nil {
+			return fmt.Errorf("unmute monitor %d: %w", id, err)
+		}
+	}
+	log.V(1).Info("unmuted Datadog monitors", "monitors", ids, "scope", scope)
+	return nil
+}
+
+// monitors returns the IDs of the monitors carrying all tags.
+func (m *Muter) monitors(ctx context.Context, tags []string) ([]int64, error) {
+	var monitors []struct {
+		ID int64 `json:"id"`
+	}
+	path := "/api/v1/monitor?" + url.Values{"monitor_tags": {strings.Join(tags, ",")}}.Encode()
+	if err := m.do(ctx, http.MethodGet, path, nil, &monitors); err != nil {
+		return nil, fmt.Errorf("list monitors tagged %s: %w", strings.Join(tags, ","), err)
+	}
+	ids := make([]int64, 0, len(monitors))
+	for _, mon := range monitors {
+		ids = append(ids, mon.ID)
+	}
+	return ids, nil
+}
+
+// do sends one request and decodes the response into out, if set.
+func (m *Muter) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, m.baseURL()+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("DD-API-KEY", m.APIKey)
+	req.Header.Set("DD-APPLICATION-KEY", m.AppKey)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	httpClient := m.HTTP
+	if httpClient == nil {
+		httpClient = defaultHTTPClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (m *Muter) baseURL() string {
+	if m.BaseURL != "" {
+		return strings.TrimSuffix(m.BaseURL, "/")
+	}
+	site := m.Site
+	if site == "" {
+		site = DefaultSite
+	}
+	return "https://api." + site
+}
@@ -0,0 +1,132 @@
+package datadog
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+)
+
+func TestMuter(t *testing.T) {
+	until := time.Date(2025, 1, 1, 2, 0, 0, 0, time.UTC)
+	dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web-freeze"}}
+	dfz.Spec.TargetRef.Name = "web"
+	dfz.Status.FreezeUntil = &metav1.Time{Time: until}
+
+	// newAPI serves two monitors for any tag query and records every other call with its body.
+	newAPI := func(t *testing.T) (*Muter, func() []string) {
+		var mu sync.Mutex
+		var calls []string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "api-key", req.Header.Get("DD-API-KEY"))
+			assert.Equal(t, "app-key", req.Header.Get("DD-APPLICATION-KEY"))
+			mu.Lock()
+			defer mu.Unlock()
+			if req.Method == http.MethodGet {
+				calls = append(calls, "GET "+req.URL.Query().Get("monitor_tags"))
+				_, _ = io.WriteString(w, `[{"id":11},{"id":12}]`)
+				return
+			}
+			body, _ := io.ReadAll(req.Body)
+			calls = append(calls, req.Method+" "+req.URL.Path+" "+string(body))
+		}))
+		t.Cleanup(srv.Close)
+		m := &Muter{
+			BaseURL: srv.URL, APIKey: "api-key", AppKey: "app-key",
+			MonitorTags: []string{"team:shop", "service:{deployment}"},
+			Scope:       "kube_namespace:{namespace}",
+		}
+		return m, func() []string {
+			mu.Lock()
+			defer mu.Unlock()
+			return calls
+		}
+	}
+
+	t.Run("EnterFrozen_MutesWithoutEnd", func(t *testing.T) {
+		t.Parallel()
+		m, calls := newAPI(t)
+		call := m.action(dfz, freezerv1alpha1.PhaseFreezing, freezerv1alpha1.PhaseFrozen)
+		require.NotNil(t, call)
+		require.NoError(t, call(context.Background()))
+
+		mute, _ := json.Marshal(map[string]any{"scope": "kube_namespace:shop"})
+		assert.Equal(t, []string{
+			"GET team:shop,service:web",
+			"POST /api/v1/monitor/11/mute " + string(mute),
+			"POST /api/v1/monitor/12/mute " + string(mute),
+		}, calls())
+	})
+
+	t.Run("Mute_EndSent", func(t *testing.T) {
+		t.Parallel()
+		m, calls := newAPI(t)
+		require.NoError(t, m.Mute(context.Background(), []string{"team:shop"}, "", until))
+
+		mute, _ := json.Marshal(map[string]any{"end": until.Unix()})
+		assert.Equal(t, "POST /api/v1/monitor/11/mute "+string(mute), calls()[1])
+	})
+
+	t.Run("OnTransition_SerializedPerFreezer", func(t *testing.T) {
+		t.Parallel()
+		m, calls := newAPI(t)
+		m.OnTransition(dfz, freezerv1alpha1.PhaseFreezing, freezerv1alpha1.PhaseFrozen)
+		m.OnTransition(dfz, freezerv1alpha1.PhaseFrozen, freezerv1alpha1.PhaseUnfreezing)
+
+		require.Eventually(t, func() bool { return len(calls()) == 6 }, 5*time.Second, 10*time.Millisecond)
+		got := calls()
+		assert.Contains(t, got[1], "/mute")
+		assert.Contains(t, got[2], "/mute")
+		assert.Contains(t, got[4], "/unmute")
+		assert.Contains(t, got[5], "/unmute")
+	})
+
+	t.Run("LeaveFrozen_Unmutes", func(t *testing.T) {
+		t.Parallel()
+		m, calls := newAPI(t)
+		call := m.action(dfz, freezerv1alpha1.PhaseFrozen, freezerv1alpha1.PhaseUnfreezing)
+		require.NotNil(t, call)
+		require.NoError(t, call(context.Background()))
+
+		assert.Equal(t, []string{
+			"GET team:shop,service:web",
+			`POST /api/v1/monitor/11/unmute {"scope":"kube_namespace:shop"}`,
+			`POST /api/v1/monitor/12/unmute {"scope":"kube_namespace:shop"}`,
+		}, calls())
+	})
+
+	t.Run("OtherTransitions_Ignored", func(t *testing.T) {
+		t.Parallel()
+		m, _ := newAPI(t)
+		assert.Nil(t, m.action(dfz, freezerv1alpha1.PhasePending, freezerv1alpha1.PhaseFreezing))
+		assert.Nil(t, m.action(dfz, freezerv1alpha1.PhaseUnfreezing, freezerv1alpha1.PhaseCompleted))
+		assert.Nil(t, m.action(dfz, freezerv1alpha1.PhaseFrozen, freezerv1alpha1.PhaseFrozen))
+	})
+
+	t.Run("APIError_Returned", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, `{"errors":["Forbidden"]}`, http.StatusForbidden)
+		}))
+		t.Cleanup(srv.Close)
+		m := &Muter{BaseURL: srv.URL, APIKey: "a", AppKey: "b", MonitorTags: []string{"team:shop"}}
+		assert.ErrorContains(t, m.Unmute(context.Background(), []string{"team:shop"}, ""), "HTTP 403")
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		t.Parallel()
+		assert.ErrorContains(t, (&Muter{APIKey: "a", MonitorTags: []string{"x"}}).Validate(), "application key")
+		assert.ErrorContains(t, (&Muter{APIKey: "a", AppKey: "b"}).Validate(), "monitor tag")
+		assert.NoError(t, (&Muter{APIKey: "a", AppKey: "b", MonitorTags: []string{"x"}}).Validate())
+	})
+}
@@ -0,0 +1,130 @@
+// Package health provides the readiness checks of the manager: beyond the process being up, a
+// ready replica has synced caches, the field indexes the controller lists by, a valid webhook
+// serving certificate and a live leader to reconcile.
+package health
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// checkTimeout bounds each check, well below the kubelet's default probe timeout of 1s.
+const checkTimeout = 500 * time.Millisecond
+
+// CacheSyncer is the part of the manager's cache that reports whether its informers synced.
+type CacheSyncer interface {
+	WaitForCacheSync(ctx context.Context) bool
+}
+
+// CacheSynced fails until every informer of c has synced.
+func CacheSynced(c CacheSyncer) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), checkTimeout)
+		defer cancel()
+		if !c.WaitForCacheSync(ctx) {
+			return errors.New("informer caches not synced")
+		}
+		return nil
+	}
+}
+
+// FieldIndexed fails unless field is indexed for the objects of list in reader, the manager's
+// cache: a List by an unregistered field fails, and the controller would miss the objects it
+// looks up by that field.
+func FieldIndexed(reader client.Reader, list client.ObjectList, field string) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), checkTimeout)
+		defer cancel()
+		if err := reader.List(ctx, list, client.MatchingFields{field: ""}); err != nil {
+			return fmt.Errorf("field index %s: %w", field, err)
+		}
+		return nil
+	}
+}
+
+// CertificateValid fails when the certificate returned by get cannot be loaded, or is not valid
+// at the time of the check. get is called on every check, so a rotated certificate is seen.
+func CertificateValid(get func() (*tls.Certificate, error), clk clock.PassiveClock) healthz.Checker {
+	return func(_ *http.Request) error {
+		cert, err := get()
+		if err != nil {
+			return fmt.Errorf("loading the serving certificate: %w", err)
+		}
+		if cert == nil || len(cert.Certificate) == 0 {
+			return errors.New("no serving certificate")
+		}
+		leaf := cert.Leaf
+		if leaf == nil {
+			if leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+				return fmt.Errorf("parsing the serving certificate: %w", err)
+			}
+		}
+		now := clk.Now()
+		if now.Before(leaf.NotBefore) {
+			return fmt.Errorf("serving certificate not valid before %s", leaf.NotBefore.UTC().Format(time.RFC3339))
+		}
+		if now.After(leaf.NotAfter) {
+			return fmt.Errorf("serving certificate expired at %s", leaf.NotAfter.UTC().Format(time.RFC3339))
+		}
+		return nil
+	}
+}
+
+// CertificateFiles returns a loader of the key pair in certFile and keyFile, for CertificateValid.
+func CertificateFiles(certFile, keyFile string) func() (*tls.Certificate, error) {
+	return func() (*tls.Certificate, error) {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		return &cert, nil
+	}
+}
+
+// LeaderElected passes on the leader, once elected is closed, and on the other replicas while
+// the leader election Lease at lease is held and renewed: it fails while no replica reconciles.
+// The Lease is read from reader on every check, so reader should not be cached.
+func LeaderElected(
+	elected <-chan struct{},
+	reader client.Reader,
+	lease types.NamespacedName,
+	clk clock.PassiveClock,
+) healthz.Checker {
+	return func(req *http.Request) error {
+		select {
+		case <-elected:
+			return nil
+		default:
+		}
+		ctx, cancel := context.WithTimeout(req.Context(), checkTimeout)
+		defer cancel()
+		var l coordinationv1.Lease
+		if err := reader.Get(ctx, lease, &l); err != nil {
+			return fmt.Errorf("reading the leader election Lease %s: %w", lease, err)
+		}
+		holder := ""
+		if l.Spec.HolderIdentity != nil {
+			holder = *l.Spec.HolderIdentity
+		}
+		if holder == "" || l.Spec.RenewTime == nil || l.Spec.LeaseDurationSeconds == nil {
+			return fmt.Errorf("no leader holds the Lease %s", lease)
+		}
+		expiry := l.Spec.RenewTime.Add(time.Duration(*l.Spec.LeaseDurationSeconds) * time.Second)
+		if clk.Now().After(expiry) {
+			return fmt.Errorf("leader %s stopped renewing the Lease %s at %s",
+				holder, lease, l.Spec.RenewTime.UTC().Format(time.RFC3339))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,107 @@
+package health
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type syncer bool
+
+func (s syncer) WaitForCacheSync(context.Context) bool { return bool(s) }
+
+func TestChecks(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	clk := testingclock.NewFakePassiveClock(now)
+	req := httptest.NewRequest("GET", "/readyz", nil)
+
+	t.Run("CacheSynced", func(t *testing.T) {
+		t.Parallel()
+		assert.NoError(t, CacheSynced(syncer(true))(req))
+		assert.EqualError(t, CacheSynced(syncer(false))(req), "informer caches not synced")
+	})
+
+	t.Run("FieldIndexed", func(t *testing.T) {
+		t.Parallel()
+		indexed := fake.NewClientBuilder().WithScheme(scheme).
+			WithIndex(&freezerv1alpha1.DeploymentFreezer{}, "spec.x", func(client.Object) []string { return nil }).Build()
+		assert.NoError(t, FieldIndexed(indexed, &freezerv1alpha1.DeploymentFreezerList{}, "spec.x")(req))
+
+		missing := fake.NewClientBuilder().WithScheme(scheme).Build()
+		assert.ErrorContains(t, FieldIndexed(missing, &freezerv1alpha1.DeploymentFreezerList{}, "spec.x")(req),
+			"field index spec.x")
+	})
+
+	t.Run("CertificateValid", func(t *testing.T) {
+		t.Parallel()
+		cert := func(notBefore, notAfter time.Time) func() (*tls.Certificate, error) {
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			require.NoError(t, err)
+			tpl := &x509.Certificate{SerialNumber: big.NewInt(1), NotBefore: notBefore, NotAfter: notAfter}
+			der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+			require.NoError(t, err)
+			return func() (*tls.Certificate, error) { return &tls.Certificate{Certificate: [][]byte{der}}, nil }
+		}
+
+		assert.NoError(t, CertificateValid(cert(now.Add(-time.Hour), now.Add(time.Hour)), clk)(req))
+		assert.EqualError(t, CertificateValid(cert(now.Add(-2*time.Hour), now.Add(-time.Hour)), clk)(req),
+			"serving certificate expired at 2025-01-01T11:00:00Z")
+		assert.EqualError(t, CertificateValid(cert(now.Add(time.Hour), now.Add(2*time.Hour)), clk)(req),
+			"serving certificate not valid before 2025-01-01T13:00:00Z")
+		assert.ErrorContains(t, CertificateValid(CertificateFiles("/nonexistent/tls.crt", "/nonexistent/tls.key"), clk)(req),
+			"loading the serving certificate")
+	})
+
+	t.Run("LeaderElected", func(t *testing.T) {
+		t.Parallel()
+		key := types.NamespacedName{Namespace: "system", Name: "lock"}
+		lease := func(renewed time.Time) *coordinationv1.Lease {
+			return &coordinationv1.Lease{
+				ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+				Spec: coordinationv1.LeaseSpec{
+					HolderIdentity:       ptr.To("manager-0"),
+					LeaseDurationSeconds: ptr.To(int32(15)),
+					RenewTime:            ptr.To(metav1.NewMicroTime(renewed)),
+				},
+			}
+		}
+		check := func(elected chan struct{}, objs ...client.Object) error {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+			return LeaderElected(elected, c, key, clk)(req)
+		}
+		elected := make(chan struct{})
+		close(elected)
+
+		assert.NoError(t, check(elected), "the leader does not read the Lease")
+		assert.NoError(t, check(make(chan struct{}), lease(now.Add(-5*time.Second))))
+		assert.EqualError(t, check(make(chan struct{}), lease(now.Add(-time.Minute))),
+			"leader manager-0 stopped renewing the Lease system/lock at 2025-01-01T11:59:00Z")
+		released := lease(now)
+		released.Spec.HolderIdentity = ptr.To("")
+		assert.EqualError(t, check(make(chan struct{}), released), "no leader holds the Lease system/lock")
+		assert.ErrorContains(t, check(make(chan struct{})), "reading the leader election Lease system/lock")
+	})
+}
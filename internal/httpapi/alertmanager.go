@@ -0,0 +1,333 @@
+package httpapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// LabelAlertMapping marks DeploymentFreezers created by the Alertmanager receiver; value: the mapping name.
+	LabelAlertMapping = "apps.boolfixer.dev/alert-mapping"
+	// AnnoFiringAlerts lists the fingerprints of the firing alerts holding a DeploymentFreezer
+	// created by the Alertmanager receiver, comma-separated.
+	AnnoFiringAlerts = "apps.boolfixer.dev/firing-alerts"
+
+	defaultNamespaceLabel  = "namespace"
+	defaultDeploymentLabel = "deployment"
+	alertStatusFiring      = "firing"
+)
+
+// AlertConfig maps Alertmanager alerts to the Deployments they freeze.
+type AlertConfig struct {
+	Mappings []AlertMapping `json:"mappings"`
+}
+
+// AlertMapping freezes Deployments while a matching alert fires.
+type AlertMapping struct {
+	// Name identifies the mapping on the DeploymentFreezers it creates.
+	Name string `json:"name"`
+	// MatchLabels must all be present on the alert with these values.
+	MatchLabels map[string]string `json:"matchLabels"`
+	// Namespace of the Deployments; taken from the alert label NamespaceLabel when empty.
+	Namespace string `json:"namespace,omitempty"`
+	// Namespaces the alert label NamespaceLabel may name; alerts naming another namespace are
+	// rejected. Required when Namespace is empty, since alert labels are set by whoever writes
+	// the alerting rules.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// NamespaceLabel is the alert label holding the namespace; defaults to "namespace".
+	NamespaceLabel string `json:"namespaceLabel,omitempty"`
+	// Deployments to freeze; taken from the alert label DeploymentLabel when empty.
+	Deployments []string `json:"deployments,omitempty"`
+	// DeploymentLabel is the alert label holding the Deployment name; defaults to "deployment".
+	DeploymentLabel string `json:"deploymentLabel,omitempty"`
+	// DurationSeconds the Deployments stay frozen at most, counted from the last notification of a
+	// firing alert; the controller default applies when 0.
+	DurationSeconds int64 `json:"durationSeconds,omitempty"`
+}
+
+// AlertNotification is the body of an Alertmanager webhook (version 4). Fields the receiver does
+// not use are left out.
+type AlertNotification struct {
+	Alerts []Alert `json:"alerts"`
+}
+
+// Alert is one alert of a notification.
+type Alert struct {
+	// Status is "firing" or "resolved".
+	Status      string            `json:"status"`
+	Labels      map[string]string `json:"labels"`
+	Fingerprint string            `json:"fingerprint,omitempty"`
+}
+
+// AlertResult is the response of the Alertmanager receiver.
+type AlertResult struct {
+	// Frozen are the freezes held by a firing alert.
+	Frozen []freezerv1alpha1.FreezeSummary `json:"frozen"`
+	// Released are the freezes deleted because their last alert resolved.
+	Released []freezerv1alpha1.FreezeSummary `json:"released"`
+	// Refused lists the freezes the admission webhook denied, e.g. by a FreezerPolicy.
+	Refused []string `json:"refused,omitempty"`
+}
+
+// alertTarget is a Deployment an alert maps to.
+type alertTarget struct {
+	mapping    *AlertMapping
+	namespace  string
+	deployment string
+}
+
+// LoadAlertConfig reads and validates a mapping file.
+func LoadAlertConfig(data []byte) (*AlertConfig, error) {
+	var cfg AlertConfig
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	for i := range cfg.Mappings {
+		m := &cfg.Mappings[i]
+		if errs := validation.IsValidLabelValue(m.Name); m.Name == "" || len(errs) > 0 {
+			return nil, fmt.Errorf("mappings[%d]: name must be a non-empty label value", i)
+		}
+		if names[m.Name] {
+			return nil, fmt.Errorf("mappings[%d]: duplicate name %q", i, m.Name)
+		}
+		names[m.Name] = true
+		if len(m.MatchLabels) == 0 {
+			return nil, fmt.Errorf("mappings[%d]: matchLabels is required", i)
+		}
+		if (m.Namespace == "") == (len(m.Namespaces) == 0) {
+			return nil, fmt.Errorf("mappings[%d]: exactly one of namespace and namespaces is required", i)
+		}
+		if m.DurationSeconds < 0 {
+			return nil, fmt.Errorf("mappings[%d]: durationSeconds must not be negative", i)
+		}
+		if m.NamespaceLabel == "" {
+			m.NamespaceLabel = defaultNamespaceLabel
+		}
+		if m.DeploymentLabel == "" {
+			m.DeploymentLabel = defaultDeploymentLabel
+		}
+	}
+	return &cfg, nil
+}
+
+// targets returns the Deployments the alert maps to. Alerts lacking the labels that name
+// their Deployment, or naming a namespace the mapping does not allow, are skipped.
+func (c *AlertConfig) targets(a Alert) []alertTarget {
+	var out []alertTarget
+	for i := range c.Mappings {
+		m := &c.Mappings[i]
+		if !matchesLabels(a.Labels, m.MatchLabels) {
+			continue
+		}
+		namespace := m.Namespace
+		if namespace == "" {
+			namespace = a.Labels[m.NamespaceLabel]
+		}
+		deployments := m.Deployments
+		if len(deployments) == 0 && a.Labels[m.DeploymentLabel] != "" {
+			deployments = []string{a.Labels[m.DeploymentLabel]}
+		}
+		if namespace == "" || len(deployments) == 0 {
+			log.Info("alert does not name a Deployment; ignoring it", "mapping", m.Name, "labels", a.Labels)
+			continue
+		}
+		if m.Namespace == "" && !slices.Contains(m.Namespaces, namespace) {
+			log.Info("alert names a namespace the mapping does not allow; ignoring it",
+				"mapping", m.Name, "namespace", namespace)
+			continue
+		}
+		for _, deployment := range deployments {
+			out = append(out, alertTarget{mapping: m, namespace: namespace, deployment: deployment})
+		}
+	}
+	return out
+}
+
+func matchesLabels(labels, want map[string]string) bool {
+	for k, v := range want {
+		if got, ok := labels[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
+
+// fingerprint identifies the alert across notifications. Alertmanager sends one; for other
+// senders it is derived from the labels, which identify an alert in Alertmanager too.
+func fingerprint(a Alert) string {
+	if a.Fingerprint != "" {
+		return a.Fingerprint
+	}
+	keys := make([]string, 0, len(a.Labels))
+	for k := range a.Labels {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	h := sha256.New()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s\xff%s\xff", k, a.Labels[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// receiveAlerts handles an Alertmanager notification. A firing alert keeps every Deployment it
+// maps to frozen; once the last alert holding a freeze resolves, the DeploymentFreezer is
+// deleted and the Deployment restored. Alerts without a mapping are ignored, since a receiver
+// usually gets whole alert groups. Denied freezes are reported without failing the others; other
+// errors fail the request so Alertmanager retries the notification, which is safe to handle twice.
+func (s *Server) receiveAlerts(w http.ResponseWriter, req *http.Request) {
+	var n AlertNotification
+	dec := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxBodyBytes))
+	if err := dec.Decode(&n); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	out := AlertResult{Frozen: []freezerv1alpha1.FreezeSummary{}, Released: []freezerv1alpha1.FreezeSummary{}}
+	for _, a := range n.Alerts {
+		fp := fingerprint(a)
+		for _, t := range s.Alerts.targets(a) {
+			if a.Status == alertStatusFiring {
+				dfz, err := s.holdFor(req.Context(), t, fp)
+				if apierrors.IsForbidden(err) || apierrors.IsInvalid(err) {
+					out.Refused = append(out.Refused, fmt.Sprintf("%s/%s: %v", t.namespace, t.deployment, err))
+					continue
+				}
+				if err != nil {
+					writeAPIError(w, err)
+					return
+				}
+				out.Frozen = append(out.Frozen, summarize(dfz))
+				continue
+			}
+			dfz, released, err := s.releaseFor(req.Context(), t, fp)
+			if err != nil {
+				writeAPIError(w, err)
+				return
+			}
+			if released {
+				out.Released = append(out.Released, summarize(dfz))
+			}
+		}
+	}
+	log.Info("handled Alertmanager notification", "alerts", len(n.Alerts),
+		"frozen", len(out.Frozen), "released", len(out.Released), "refused", len(out.Refused))
+	writeJSON(w, http.StatusOK, out)
+}
+
+// alertFreezer returns the mapping's unfinished DeploymentFreezer for the target, or nil.
+func (s *Server) alertFreezer(ctx context.Context, t alertTarget) (*freezerv1alpha1.DeploymentFreezer, error) {
+	var list freezerv1alpha1.DeploymentFreezerList
+	if err := s.Reader.List(ctx, &list, client.InNamespace(t.namespace),
+		client.MatchingLabels{LabelAlertMapping: t.mapping.Name}); err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		item := &list.Items[i]
+		if item.Spec.TargetRef.Name == t.deployment && !finished(item) && item.DeletionTimestamp.IsZero() {
+			return item, nil
+		}
+	}
+	return nil, nil
+}
+
+// holdFor records the firing alert on the target's DeploymentFreezer and extends it to last the
+// mapped duration from now, or creates it.
+func (s *Server) holdFor(ctx context.Context, t alertTarget, fp string) (*freezerv1alpha1.DeploymentFreezer, error) {
+	var dfz *freezerv1alpha1.DeploymentFreezer
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var err error
+		if dfz, err = s.alertFreezer(ctx, t); err != nil {
+			return err
+		}
+		if dfz == nil {
+			dfz = &freezerv1alpha1.DeploymentFreezer{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:    t.namespace,
+					GenerateName: t.deployment + "-",
+					Labels:       map[string]string{LabelAlertMapping: t.mapping.Name},
+					Annotations:  map[string]string{AnnoFiringAlerts: fp},
+				},
+				Spec: freezerv1alpha1.DeploymentFreezerSpec{
+					TargetRef:       freezerv1alpha1.DeploymentTargetRef{Name: t.deployment},
+					DurationSeconds: t.mapping.DurationSeconds,
+				},
+			}
+			return s.Client.Create(ctx, dfz)
+		}
+
+		alerts := firingAlerts(dfz)
+		added := !slices.Contains(alerts, fp)
+		if added {
+			alerts = append(alerts, fp)
+			slices.Sort(alerts)
+			if dfz.Annotations == nil {
+				dfz.Annotations = map[string]string{}
+			}
+			dfz.Annotations[AnnoFiringAlerts] = strings.Join(alerts, ",")
+		}
+		if !s.extendTo(dfz, t.mapping.DurationSeconds) && !added {
+			return nil
+		}
+		return s.Client.Update(ctx, dfz)
+	})
+	return dfz, err
+}
+
+// releaseFor drops the resolved alert from the target's DeploymentFreezer and deletes the
+// DeploymentFreezer once no alert holds it any more. It reports whether it was deleted.
+func (s *Server) releaseFor(ctx context.Context, t alertTarget, fp string) (*freezerv1alpha1.DeploymentFreezer, bool, error) {
+	var dfz *freezerv1alpha1.DeploymentFreezer
+	var released bool
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var err error
+		released = false
+		if dfz, err = s.alertFreezer(ctx, t); err != nil || dfz == nil {
+			return err
+		}
+		alerts := firingAlerts(dfz)
+		i := slices.Index(alerts, fp)
+		if i < 0 {
+			return nil
+		}
+		alerts = slices.Delete(alerts, i, i+1)
+		if len(alerts) > 0 {
+			dfz.Annotations[AnnoFiringAlerts] = strings.Join(alerts, ",")
+			return s.Client.Update(ctx, dfz)
+		}
+		// The precondition turns a concurrent change into a conflict, which is retried.
+		err = s.Client.Delete(ctx, dfz, client.Preconditions{UID: &dfz.UID, ResourceVersion: &dfz.ResourceVersion})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		released = err == nil
+		return err
+	})
+	if released {
+		log.Info("released DeploymentFreezer after its alerts resolved", "namespace", dfz.Namespace, "name", dfz.Name)
+	}
+	return dfz, released, err
+}
+
+func firingAlerts(dfz *freezerv1alpha1.DeploymentFreezer) []string {
+	v := dfz.Annotations[AnnoFiringAlerts]
+	if v == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
@@ -0,0 +1,179 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const alertToken = "alert-token"
+
+func notify(h http.Handler, body, bearer string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/hooks/v1/alertmanager", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+bearer)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func alertBody(alerts ...string) string {
+	return `{"version":"4","status":"firing","receiver":"freezer","alerts":[` + strings.Join(alerts, ",") + `]}`
+}
+
+func TestAlertmanagerReceiver(t *testing.T) {
+	cfg, err := LoadAlertConfig([]byte(`
+mappings:
+- name: error-budget
+  matchLabels: {alertname: ErrorBudgetBurn, severity: critical}
+  namespaces: [shop]
+  durationSeconds: 7200
+- name: checkout-db
+  matchLabels: {alertname: DatabaseDown}
+  namespace: shop
+  deployments: [checkout, checkout-worker]
+`))
+	require.NoError(t, err)
+
+	newAlertServer := func(t *testing.T, objs ...client.Object) (*Server, http.Handler) {
+		s, _ := newServer(t, objs...)
+		s.Alerts = cfg
+		s.AlertToken = alertToken
+		return s, s.Handler()
+	}
+	listShop := func(t *testing.T, s *Server) []freezerv1alpha1.DeploymentFreezer {
+		var list freezerv1alpha1.DeploymentFreezerList
+		require.NoError(t, s.Reader.List(t.Context(), &list, client.InNamespace("shop")))
+		return list.Items
+	}
+	burn := func(status, fp, deployment string) string {
+		return `{"status":"` + status + `","fingerprint":"` + fp + `","labels":{"alertname":"ErrorBudgetBurn",` +
+			`"severity":"critical","namespace":"shop","deployment":"` + deployment + `"}}`
+	}
+
+	t.Run("LoadAlertConfig_Invalid", func(t *testing.T) {
+		t.Parallel()
+		for _, data := range []string{
+			"mappings:\n- name: x\n",
+			"mappings:\n- matchLabels: {a: b}\n",
+			"mappings:\n- name: not a label\n  matchLabels: {a: b}\n",
+			"mappings:\n- name: x\n  matchLabels: {a: b}\n  namespace: n\n- name: x\n  matchLabels: {a: c}\n  namespace: n\n",
+			"mappings:\n- name: x\n  matchLabels: {a: b}\n",
+			"mappings:\n- name: x\n  matchLabels: {a: b}\n  namespace: n\n  namespaces: [n]\n",
+		} {
+			_, err := LoadAlertConfig([]byte(data))
+			assert.Error(t, err, data)
+		}
+	})
+
+	t.Run("WrongToken_Unauthorized", func(t *testing.T) {
+		t.Parallel()
+		_, h := newAlertServer(t)
+		assert.Equal(t, http.StatusUnauthorized, notify(h, alertBody(), token).Code)
+	})
+
+	t.Run("Disabled_NotFound", func(t *testing.T) {
+		t.Parallel()
+		_, h := newServer(t)
+		assert.Equal(t, http.StatusNotFound, notify(h, alertBody(), alertToken).Code)
+	})
+
+	t.Run("UnmappedAlert_Ignored", func(t *testing.T) {
+		t.Parallel()
+		s, h := newAlertServer(t)
+		body := alertBody(`{"status":"firing","labels":{"alertname":"ErrorBudgetBurn","severity":"warning",`+
+			`"namespace":"shop","deployment":"checkout"}}`,
+			`{"status":"firing","labels":{"alertname":"ErrorBudgetBurn","severity":"critical"}}`)
+		rec := notify(h, body, alertToken)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Empty(t, listShop(t, s))
+	})
+
+	t.Run("NamespaceNotAllowed_Ignored", func(t *testing.T) {
+		t.Parallel()
+		s, h := newAlertServer(t)
+		body := alertBody(`{"status":"firing","labels":{"alertname":"ErrorBudgetBurn","severity":"critical",` +
+			`"namespace":"kube-system","deployment":"coredns"}}`)
+		require.Equal(t, http.StatusOK, notify(h, body, alertToken).Code)
+		var list freezerv1alpha1.DeploymentFreezerList
+		require.NoError(t, s.Reader.List(t.Context(), &list))
+		assert.Empty(t, list.Items)
+	})
+
+	t.Run("Firing_FreezesLabeledDeployment", func(t *testing.T) {
+		t.Parallel()
+		s, h := newAlertServer(t)
+		rec := notify(h, alertBody(burn("firing", "aaa", "checkout")), alertToken)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var res AlertResult
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		require.Len(t, res.Frozen, 1)
+		items := listShop(t, s)
+		require.Len(t, items, 1)
+		assert.Equal(t, "checkout", items[0].Spec.TargetRef.Name)
+		assert.Equal(t, "error-budget", items[0].Labels[LabelAlertMapping])
+		assert.Equal(t, "aaa", items[0].Annotations[AnnoFiringAlerts])
+		assert.Equal(t, int64(7200), items[0].Spec.DurationSeconds)
+
+		// Repeated notifications reuse the freeze.
+		require.Equal(t, http.StatusOK, notify(h, alertBody(burn("firing", "aaa", "checkout")), alertToken).Code)
+		assert.Len(t, listShop(t, s), 1)
+	})
+
+	t.Run("FixedTargets_FreezeEveryDeployment", func(t *testing.T) {
+		t.Parallel()
+		s, h := newAlertServer(t)
+		body := alertBody(`{"status":"firing","labels":{"alertname":"DatabaseDown","instance":"db-0"}}`)
+		require.Equal(t, http.StatusOK, notify(h, body, alertToken).Code)
+
+		items := listShop(t, s)
+		require.Len(t, items, 2)
+		for _, dfz := range items {
+			assert.Equal(t, "checkout-db", dfz.Labels[LabelAlertMapping])
+			// Alerts without a fingerprint are identified by their labels.
+			assert.Len(t, dfz.Annotations[AnnoFiringAlerts], 16)
+		}
+	})
+
+	t.Run("Resolved_UnfreezesAfterLastAlert", func(t *testing.T) {
+		t.Parallel()
+		s, h := newAlertServer(t)
+		require.Equal(t, http.StatusOK, notify(h, alertBody(burn("firing", "aaa", "checkout"),
+			burn("firing", "bbb", "checkout")), alertToken).Code)
+		items := listShop(t, s)
+		require.Len(t, items, 1)
+		assert.Equal(t, "aaa,bbb", items[0].Annotations[AnnoFiringAlerts])
+
+		// One alert resolving keeps the Deployment frozen for the other.
+		require.Equal(t, http.StatusOK, notify(h, alertBody(burn("resolved", "aaa", "checkout")), alertToken).Code)
+		items = listShop(t, s)
+		require.Len(t, items, 1)
+		assert.Equal(t, "bbb", items[0].Annotations[AnnoFiringAlerts])
+
+		rec := notify(h, alertBody(burn("resolved", "bbb", "checkout")), alertToken)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var res AlertResult
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Len(t, res.Released, 1)
+		assert.Empty(t, listShop(t, s))
+
+		// Resolving again is a no-op.
+		require.Equal(t, http.StatusOK, notify(h, alertBody(burn("resolved", "bbb", "checkout")), alertToken).Code)
+	})
+
+	t.Run("Resolved_LeavesOtherFreezesAlone", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ("shop", "checkout-manual", freezerv1alpha1.PhaseFrozen)
+		dfz.Spec.TargetRef.Name = "checkout"
+		s, h := newAlertServer(t, dfz)
+		require.Equal(t, http.StatusOK, notify(h, alertBody(burn("resolved", "aaa", "checkout")), alertToken).Code)
+		assert.Len(t, listShop(t, s), 1)
+	})
+}
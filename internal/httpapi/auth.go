@@ -0,0 +1,107 @@
+package httpapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+)
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// userKey is the context key of the authenticated Kubernetes user of an API request.
+type userKey struct{}
+
+// userFrom returns the user authenticateUser resolved for the request.
+func userFrom(ctx context.Context) authenticationv1.UserInfo {
+	user, _ := ctx.Value(userKey{}).(authenticationv1.UserInfo)
+	return user
+}
+
+// authenticateUser resolves the Kubernetes bearer token of the request to its user with a
+// TokenReview, so API calls are made on behalf of the caller and not of the manager.
+func (s *Server) authenticateUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			unauthorized(w)
+			return
+		}
+		review := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+		if err := s.Client.Create(req.Context(), review); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		if !review.Status.Authenticated {
+			unauthorized(w)
+			return
+		}
+		ctx := context.WithValue(req.Context(), userKey{}, review.Status.User)
+		next.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
+
+// authorize checks with a SubjectAccessReview that the caller may perform verb on the
+// DeploymentFreezer name in namespace, the same check the API server makes for kubectl. An
+// empty namespace stands for all namespaces. It writes the error response when not allowed.
+func (s *Server) authorize(w http.ResponseWriter, req *http.Request, verb, namespace, name string) bool {
+	user := userFrom(req.Context())
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	review := &authorizationv1.SubjectAccessReview{Spec: authorizationv1.SubjectAccessReviewSpec{
+		User:   user.Username,
+		UID:    user.UID,
+		Groups: user.Groups,
+		Extra:  extra,
+		ResourceAttributes: &authorizationv1.ResourceAttributes{
+			Namespace: namespace,
+			Verb:      verb,
+			Group:     freezerv1alpha1.GroupVersion.Group,
+			Version:   freezerv1alpha1.GroupVersion.Version,
+			Resource:  "deploymentfreezers",
+			Name:      name,
+		},
+	}}
+	if err := s.Client.Create(req.Context(), review); err != nil {
+		writeAPIError(w, err)
+		return false
+	}
+	if !review.Status.Allowed {
+		writeError(w, http.StatusForbidden, errors.New(forbiddenMessage(user.Username, verb, namespace)))
+		return false
+	}
+	return true
+}
+
+func forbiddenMessage(username, verb, namespace string) string {
+	if namespace == "" {
+		return username + " cannot " + verb + " deploymentfreezers in all namespaces"
+	}
+	return username + " cannot " + verb + " deploymentfreezers in namespace " + namespace
+}
+
+func unauthorized(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", "Bearer")
+	writeError(w, http.StatusUnauthorized, errors.New("missing or invalid bearer token"))
+}
+
+// Loopback reports whether addr only listens on the loopback interface.
+func Loopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
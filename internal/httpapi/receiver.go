@@ -0,0 +1,293 @@
+package httpapi
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/internal/policy"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// SignatureHeader carries the HMAC-SHA256 of the signed payload as "sha256=<hex>"; see signedPayload.
+	SignatureHeader = "X-Hub-Signature-256"
+	// TimestampHeader carries the time the event was sent, in Unix seconds.
+	TimestampHeader = "X-Freezer-Timestamp"
+	// DeliveryHeader carries an ID unique to each event sent.
+	DeliveryHeader = "X-Freezer-Delivery"
+	// LabelCIApp marks DeploymentFreezers created by the CI webhook receiver; value: the app.
+	LabelCIApp = "apps.boolfixer.dev/ci-app"
+	// AnnoCIDelivery records the delivery that last created or extended a DeploymentFreezer.
+	AnnoCIDelivery = "apps.boolfixer.dev/ci-delivery"
+	// HookRequester is the requester recorded on DeploymentFreezers created for CI events: the
+	// identity the hook secret authenticates. FreezerPolicy subjects match it as a User.
+	HookRequester = "deployment-freezer:ci-webhook"
+
+	// hookMaxAge is how far the timestamp of a CI event may be from the receiver's clock.
+	hookMaxAge = 5 * time.Minute
+)
+
+// HookConfig maps CI events to the Deployments they freeze.
+type HookConfig struct {
+	Mappings []HookMapping `json:"mappings"`
+}
+
+// HookMapping freezes Deployments when a matching event arrives.
+type HookMapping struct {
+	// App is matched against the "app" field of the event.
+	App string `json:"app"`
+	// Event is matched against the "event" field; empty matches any event.
+	Event string `json:"event,omitempty"`
+	// Namespace of the Deployments.
+	Namespace string `json:"namespace"`
+	// Deployments to freeze.
+	Deployments []string `json:"deployments"`
+	// DurationSeconds the Deployments stay frozen after the event; the controller default applies when 0.
+	DurationSeconds int64 `json:"durationSeconds,omitempty"`
+}
+
+// HookEvent is the body sent by CI systems.
+type HookEvent struct {
+	Event string `json:"event"`
+	App   string `json:"app"`
+}
+
+// LoadHookConfig reads and validates a mapping file.
+func LoadHookConfig(data []byte) (*HookConfig, error) {
+	var cfg HookConfig
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return nil, err
+	}
+	for i, m := range cfg.Mappings {
+		if m.App == "" || m.Namespace == "" || len(m.Deployments) == 0 {
+			return nil, fmt.Errorf("mappings[%d]: app, namespace and deployments are required", i)
+		}
+		if m.DurationSeconds < 0 {
+			return nil, fmt.Errorf("mappings[%d]: durationSeconds must not be negative", i)
+		}
+	}
+	return &cfg, nil
+}
+
+// match returns the mappings that apply to the event.
+func (c *HookConfig) match(ev HookEvent) []HookMapping {
+	var out []HookMapping
+	for _, m := range c.Mappings {
+		if m.App == ev.App && (m.Event == "" || m.Event == ev.Event) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// receive handles a signed CI event: every mapped Deployment gets a DeploymentFreezer that
+// lasts at least the mapped duration from now, created or extended as needed.
+func (s *Server) receive(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxBodyBytes))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	timestamp, delivery := req.Header.Get(TimestampHeader), req.Header.Get(DeliveryHeader)
+	if delivery == "" ||
+		!validSignature(s.HookSecret, signedPayload(timestamp, delivery, body), req.Header.Get(SignatureHeader)) {
+		writeError(w, http.StatusUnauthorized,
+			fmt.Errorf("missing or invalid %s, %s or %s", SignatureHeader, TimestampHeader, DeliveryHeader))
+		return
+	}
+	now := time.Now()
+	sent, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || now.Sub(time.Unix(sent, 0)).Abs() > hookMaxAge {
+		writeError(w, http.StatusUnauthorized, fmt.Errorf("%s is more than %s away from now", TimestampHeader, hookMaxAge))
+		return
+	}
+	if !s.hookDeliveries.first(delivery, now) {
+		writeError(w, http.StatusConflict, fmt.Errorf("delivery %q was already received", delivery))
+		return
+	}
+
+	var ev HookEvent
+	if err := json.Unmarshal(body, &ev); err != nil || ev.App == "" {
+		writeError(w, http.StatusBadRequest, errors.New(`body must be JSON with an "app" field`))
+		return
+	}
+	mappings := s.Hooks.match(ev)
+	if len(mappings) == 0 {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no mapping for app %q and event %q", ev.App, ev.Event))
+		return
+	}
+
+	out := []freezerv1alpha1.FreezeSummary{}
+	for _, m := range mappings {
+		for _, deployment := range m.Deployments {
+			dfz, err := s.freezeFor(req.Context(), m, deployment, delivery)
+			if err != nil {
+				writeAPIError(w, err)
+				return
+			}
+			out = append(out, summarize(dfz))
+		}
+	}
+	log.Info("handled CI event", "app", ev.App, "event", ev.Event, "freezes", len(out))
+	writeJSON(w, http.StatusOK, out)
+}
+
+// freezeFor extends the app's unfinished DeploymentFreezer for the Deployment, or creates one.
+// A delivery received again, by another replica or after a restart, changes nothing: the
+// DeploymentFreezer it created is named after it, and one it extended records it.
+func (s *Server) freezeFor(
+	ctx context.Context,
+	m HookMapping,
+	deployment, delivery string,
+) (*freezerv1alpha1.DeploymentFreezer, error) {
+	var dfz *freezerv1alpha1.DeploymentFreezer
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var list freezerv1alpha1.DeploymentFreezerList
+		if err := s.Reader.List(ctx, &list, client.InNamespace(m.Namespace),
+			client.MatchingLabels{LabelCIApp: m.App}); err != nil {
+			return err
+		}
+		dfz = nil
+		for i := range list.Items {
+			item := &list.Items[i]
+			if item.Spec.TargetRef.Name == deployment && !finished(item) && item.DeletionTimestamp.IsZero() {
+				dfz = item
+				break
+			}
+		}
+		if dfz == nil {
+			dfz = &freezerv1alpha1.DeploymentFreezer{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   m.Namespace,
+					Name:        deliveryName(deployment, delivery),
+					Labels:      map[string]string{LabelCIApp: m.App},
+					Annotations: map[string]string{AnnoCIDelivery: delivery},
+				},
+				Spec: freezerv1alpha1.DeploymentFreezerSpec{
+					TargetRef:       freezerv1alpha1.DeploymentTargetRef{Name: deployment},
+					DurationSeconds: m.DurationSeconds,
+				},
+			}
+			policy.Requester{Username: HookRequester}.Annotate(dfz)
+			err := s.Client.Create(ctx, dfz)
+			if apierrors.IsAlreadyExists(err) {
+				// Created for this delivery before, possibly finished since.
+				return s.Reader.Get(ctx, client.ObjectKeyFromObject(dfz), dfz)
+			}
+			return err
+		}
+
+		if dfz.Annotations[AnnoCIDelivery] == delivery || !s.extendTo(dfz, m.DurationSeconds) {
+			return nil
+		}
+		metav1.SetMetaDataAnnotation(&dfz.ObjectMeta, AnnoCIDelivery, delivery)
+		return s.Client.Update(ctx, dfz)
+	})
+	return dfz, err
+}
+
+// deliveryName names the DeploymentFreezer a delivery creates for deployment, so the API server
+// refuses a second one for the same delivery.
+func deliveryName(deployment, delivery string) string {
+	sum := sha256.Sum256([]byte(delivery))
+	suffix := "-" + hex.EncodeToString(sum[:])[:10]
+	prefix := deployment[:min(len(deployment), validation.DNS1123SubdomainMaxLength-len(suffix))]
+	return strings.TrimRight(prefix, "-.") + suffix
+}
+
+// extendTo raises the spec duration so the DFZ stays frozen for at least seconds from now,
+// with 0 meaning the default duration. It reports whether the spec changed.
+func (s *Server) extendTo(dfz *freezerv1alpha1.DeploymentFreezer, seconds int64) bool {
+	if seconds <= 0 {
+		seconds = int64(s.DefaultDuration / time.Second)
+	}
+	current := int64(dfz.Spec.RequestedDuration() / time.Second)
+	if current <= 0 {
+		current = int64(s.DefaultDuration / time.Second)
+	}
+	wanted := durationUntil(dfz, time.Now(), time.Duration(seconds)*time.Second)
+	if wanted <= current {
+		return false
+	}
+	dfz.Spec.SetRequestedDuration(time.Duration(wanted) * time.Second)
+	return true
+}
+
+// durationUntil returns the window, in seconds, that keeps the DFZ frozen for at least d after now.
+// The window of a frozen DFZ starts when it became Frozen; otherwise it has not started yet.
+func durationUntil(dfz *freezerv1alpha1.DeploymentFreezer, now time.Time, d time.Duration) int64 {
+	start := now
+	if frozenAt, ok := dfz.Status.PhaseTransitionTimes[freezerv1alpha1.PhaseFrozen]; ok {
+		start = frozenAt.Time
+	}
+	return int64(now.Add(d).Sub(start).Round(time.Second) / time.Second)
+}
+
+// signedPayload is what the signature of a CI event covers: "<timestamp>.<delivery>.<body>", so a
+// captured request can neither be sent again later nor under another delivery ID.
+func signedPayload(timestamp, delivery string, body []byte) []byte {
+	return slices.Concat([]byte(timestamp+"."+delivery+"."), body)
+}
+
+// deliveryLog remembers the delivery IDs received within hookMaxAge, beyond which a request is
+// refused for its timestamp anyway. It is local to the replica and lost on restart; it only
+// saves the API requests of a replay, which freezeFor makes harmless. The zero value is ready to
+// use.
+type deliveryLog struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// first records the delivery and reports whether it was not received before.
+func (l *deliveryLog) first(delivery string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.seen == nil {
+		l.seen = map[string]time.Time{}
+	}
+	// A timestamp is accepted from hookMaxAge before to hookMaxAge after it, so that is how
+	// long the same delivery can arrive again.
+	for id, at := range l.seen {
+		if now.Sub(at) > 2*hookMaxAge {
+			delete(l.seen, id)
+		}
+	}
+	if _, ok := l.seen[delivery]; ok {
+		return false
+	}
+	l.seen[delivery] = now
+	return true
+}
+
+// validSignature checks a "sha256=<hex>" HMAC of body made with secret.
+func validSignature(secret, body []byte, header string) bool {
+	sig, ok := strings.CutPrefix(header, "sha256=")
+	if !ok || len(secret) == 0 {
+		return false
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
@@ -0,0 +1,196 @@
+package httpapi
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/internal/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const hookSecret = "hook-secret"
+
+// delivery is a CI event as sent: the body and the headers it is signed with.
+type delivery struct {
+	body, id, timestamp string
+}
+
+func newDelivery(body string) delivery {
+	return delivery{body: body, id: rand.Text(), timestamp: strconv.FormatInt(time.Now().Unix(), 10)}
+}
+
+func (d delivery) sign() string {
+	mac := hmac.New(sha256.New, []byte(hookSecret))
+	mac.Write([]byte(d.timestamp + "." + d.id + "." + d.body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func deliver(h http.Handler, d delivery, signature string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/hooks/v1/ci", strings.NewReader(d.body))
+	req.Header.Set(SignatureHeader, signature)
+	req.Header.Set(TimestampHeader, d.timestamp)
+	req.Header.Set(DeliveryHeader, d.id)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+// send signs and delivers a new event.
+func send(h http.Handler, body string) *httptest.ResponseRecorder {
+	d := newDelivery(body)
+	return deliver(h, d, d.sign())
+}
+
+func TestReceiver(t *testing.T) {
+	cfg, err := LoadHookConfig([]byte(`
+mappings:
+- app: checkout
+  event: deployment-window-opening
+  namespace: shop
+  deployments: [checkout, checkout-worker]
+  durationSeconds: 1800
+`))
+	require.NoError(t, err)
+
+	newHookServer := func(t *testing.T, objs ...client.Object) (*Server, http.Handler) {
+		s, _ := newServer(t, objs...)
+		s.Hooks = cfg
+		s.HookSecret = []byte(hookSecret)
+		return s, s.Handler()
+	}
+	const event = `{"event":"deployment-window-opening","app":"checkout"}`
+
+	t.Run("LoadHookConfig_Invalid", func(t *testing.T) {
+		t.Parallel()
+		_, err := LoadHookConfig([]byte("mappings:\n- app: checkout\n  namespace: shop\n"))
+		assert.Error(t, err)
+		_, err = LoadHookConfig([]byte("mapping: []\n"))
+		assert.Error(t, err)
+	})
+
+	t.Run("BadSignature_Unauthorized", func(t *testing.T) {
+		t.Parallel()
+		_, h := newHookServer(t)
+		d := newDelivery(event)
+		assert.Equal(t, http.StatusUnauthorized, deliver(h, d, "").Code)
+		tampered := d
+		tampered.body += " "
+		assert.Equal(t, http.StatusUnauthorized, deliver(h, tampered, d.sign()).Code)
+		tampered = d
+		tampered.id = "other"
+		assert.Equal(t, http.StatusUnauthorized, deliver(h, tampered, d.sign()).Code)
+	})
+
+	t.Run("StaleTimestamp_Unauthorized", func(t *testing.T) {
+		t.Parallel()
+		_, h := newHookServer(t)
+		d := newDelivery(event)
+		d.timestamp = strconv.FormatInt(time.Now().Add(-hookMaxAge-time.Minute).Unix(), 10)
+		assert.Equal(t, http.StatusUnauthorized, deliver(h, d, d.sign()).Code)
+	})
+
+	t.Run("Replay_Conflict", func(t *testing.T) {
+		t.Parallel()
+		_, h := newHookServer(t)
+		d := newDelivery(event)
+		require.Equal(t, http.StatusOK, deliver(h, d, d.sign()).Code)
+		assert.Equal(t, http.StatusConflict, deliver(h, d, d.sign()).Code)
+	})
+
+	t.Run("Replay_OtherReplicaCreatesNothing", func(t *testing.T) {
+		t.Parallel()
+		s, h := newHookServer(t)
+		d := newDelivery(event)
+		require.Equal(t, http.StatusOK, deliver(h, d, d.sign()).Code)
+		var list freezerv1alpha1.DeploymentFreezerList
+		require.NoError(t, s.Reader.List(t.Context(), &list, client.InNamespace("shop")))
+		require.Len(t, list.Items, 2)
+		// Even once finished, the freeze created for the delivery is not created again.
+		done := list.Items[0].DeepCopy()
+		done.Status.Phase = freezerv1alpha1.PhaseCompleted
+		require.NoError(t, s.Client.Status().Update(t.Context(), done))
+
+		// Another replica, or this one restarted, has not seen the delivery.
+		other := &Server{Client: s.Client, Reader: s.Reader, DefaultDuration: s.DefaultDuration, Hooks: cfg, HookSecret: s.HookSecret}
+		rec := deliver(other.Handler(), d, d.sign())
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.NoError(t, s.Reader.List(t.Context(), &list, client.InNamespace("shop")))
+		assert.Len(t, list.Items, 2)
+		for _, dfz := range list.Items {
+			assert.Equal(t, int64(1800), dfz.Spec.DurationSeconds)
+		}
+	})
+
+	t.Run("Disabled_NotFound", func(t *testing.T) {
+		t.Parallel()
+		_, h := newServer(t)
+		assert.Equal(t, http.StatusNotFound, send(h, event).Code)
+	})
+
+	t.Run("UnknownEvent_NotFound", func(t *testing.T) {
+		t.Parallel()
+		_, h := newHookServer(t)
+		body := `{"event":"deployment-window-closing","app":"checkout"}`
+		assert.Equal(t, http.StatusNotFound, send(h, body).Code)
+	})
+
+	t.Run("Event_CreatesDeploymentFreezers", func(t *testing.T) {
+		t.Parallel()
+		s, h := newHookServer(t)
+		rec := send(h, event)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var list freezerv1alpha1.DeploymentFreezerList
+		require.NoError(t, s.Reader.List(t.Context(), &list, client.InNamespace("shop")))
+		require.Len(t, list.Items, 2)
+		for _, dfz := range list.Items {
+			assert.Equal(t, "checkout", dfz.Labels[LabelCIApp])
+			assert.Equal(t, int64(1800), dfz.Spec.DurationSeconds)
+			assert.Equal(t, HookRequester, policy.RequesterFromAnnotations(&dfz).Username)
+		}
+
+		// A repeated event reuses the unfinished freezes.
+		require.Equal(t, http.StatusOK, send(h, event).Code)
+		require.NoError(t, s.Reader.List(t.Context(), &list, client.InNamespace("shop")))
+		assert.Len(t, list.Items, 2)
+	})
+
+	t.Run("Event_ExtendsFrozenDeploymentFreezer", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ("shop", "checkout-abc", freezerv1alpha1.PhaseFrozen)
+		dfz.Labels = map[string]string{LabelCIApp: "checkout"}
+		dfz.Spec.TargetRef.Name = "checkout"
+		dfz.Spec.DurationSeconds = 1800
+		dfz.Status.PhaseTransitionTimes = map[freezerv1alpha1.Phase]metav1.Time{
+			freezerv1alpha1.PhaseFrozen: metav1.NewTime(time.Now().Add(-time.Hour)),
+		}
+		s, h := newHookServer(t, dfz)
+		require.Equal(t, http.StatusOK, send(h, event).Code)
+
+		require.NoError(t, s.Reader.Get(t.Context(), client.ObjectKeyFromObject(dfz), dfz))
+		assert.InDelta(t, 5400, dfz.Spec.DurationSeconds, 5)
+	})
+}
+
+func TestDurationUntil(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	dfz := &freezerv1alpha1.DeploymentFreezer{}
+	assert.Equal(t, int64(600), durationUntil(dfz, now, 10*time.Minute))
+
+	dfz.Status.PhaseTransitionTimes = map[freezerv1alpha1.Phase]metav1.Time{
+		freezerv1alpha1.PhaseFrozen: metav1.NewTime(now.Add(-time.Hour)),
+	}
+	assert.Equal(t, int64(4200), durationUntil(dfz, now, 10*time.Minute))
+}
@@ -0,0 +1,353 @@
+// Package httpapi serves a small authenticated HTTP/JSON API for driving DeploymentFreezers
+// from tooling that does not speak Kubernetes, such as change-management portals, a receiver
+// for signed CI/CD webhooks and a receiver for Alertmanager notifications.
+package httpapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/internal/policy"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	readHeaderTimeout = 10 * time.Second
+	shutdownTimeout   = 10 * time.Second
+	// maxBodyBytes bounds request bodies; they only carry a few fields.
+	maxBodyBytes = 1 << 20
+)
+
+var log = logf.Log.WithName("httpapi")
+
+// Server serves the management API. It implements manager.Runnable and runs on every replica.
+type Server struct {
+	// Client creates, updates and deletes DeploymentFreezers.
+	Client client.Client
+	// Reader reads DeploymentFreezers straight from the API server, so results do not depend
+	// on what this replica caches.
+	Reader client.Reader
+	// BindAddress is the address the API listens on. Without TLSConfig it must be a loopback address.
+	BindAddress string
+	// Hooks maps CI events to Deployments; the webhook receiver is disabled when nil.
+	Hooks *HookConfig
+	// HookSecret signs CI webhooks (HMAC-SHA256).
+	HookSecret []byte
+	// hookDeliveries refuses CI webhooks received before.
+	hookDeliveries deliveryLog
+	// Alerts maps Alertmanager alerts to Deployments; the Alertmanager receiver is disabled when nil.
+	Alerts *AlertConfig
+	// AlertToken is the bearer token Alertmanager must send.
+	AlertToken string
+	// TLSConfig enables HTTPS. Bearer tokens are only accepted in plain text on a loopback address,
+	// for a proxy terminating TLS next to the manager.
+	TLSConfig *tls.Config
+	// DefaultDuration is the freeze window assumed when a DeploymentFreezer leaves it unset.
+	DefaultDuration time.Duration
+}
+
+// CreateRequest is the body of a create call.
+type CreateRequest struct {
+	// Name of the DeploymentFreezer; generated from the Deployment name when empty.
+	Name string `json:"name,omitempty"`
+	// Deployment to freeze, in the namespace of the URL.
+	Deployment string `json:"deployment"`
+	// DurationSeconds of the freeze window; the controller default applies when 0.
+	DurationSeconds int64 `json:"durationSeconds,omitempty"`
+}
+
+// ExtendRequest is the body of an extend call.
+type ExtendRequest struct {
+	// Seconds added to the freeze window.
+	Seconds int64 `json:"seconds"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Start serves the API until ctx is done. It refuses to serve plain HTTP beyond the loopback interface.
+func (s *Server) Start(ctx context.Context) error {
+	if s.TLSConfig == nil && !Loopback(s.BindAddress) {
+		return fmt.Errorf("management API on %s needs TLS; bind it to a loopback address to serve plain HTTP",
+			s.BindAddress)
+	}
+	srv := &http.Server{
+		Addr:              s.BindAddress,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+		TLSConfig:         s.TLSConfig,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		log.Info("serving management API", "address", s.BindAddress, "tls", s.TLSConfig != nil)
+		if s.TLSConfig != nil {
+			errCh <- srv.ListenAndServeTLS("", "")
+		} else {
+			errCh <- srv.ListenAndServe()
+		}
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+// NeedLeaderElection lets every replica serve the API. It implements manager.LeaderElectionRunnable.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Handler returns the API routes, authenticated by Kubernetes bearer token and authorized by
+// Kubernetes RBAC on deploymentfreezers, the CI webhook receiver, authenticated by request
+// signature, and the Alertmanager receiver, authenticated by its own bearer token.
+func (s *Server) Handler() http.Handler {
+	api := http.NewServeMux()
+	api.HandleFunc("GET /api/v1/freezes", s.list)
+	api.HandleFunc("POST /api/v1/namespaces/{namespace}/freezes", s.create)
+	api.HandleFunc("GET /api/v1/namespaces/{namespace}/freezes/{name}", s.get)
+	api.HandleFunc("POST /api/v1/namespaces/{namespace}/freezes/{name}/extend", s.extend)
+	api.HandleFunc("DELETE /api/v1/namespaces/{namespace}/freezes/{name}", s.cancel)
+
+	mux := http.NewServeMux()
+	mux.Handle("/api/", s.authenticateUser(api))
+	if s.Hooks != nil {
+		mux.HandleFunc("POST /hooks/v1/ci", s.receive)
+	}
+	if s.Alerts != nil {
+		mux.Handle("POST /hooks/v1/alertmanager", authenticate(s.AlertToken, http.HandlerFunc(s.receiveAlerts)))
+	}
+	return mux
+}
+
+// authenticate refuses requests that do not carry the bearer token; all are refused when it is empty.
+func authenticate(want string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if !ok || want == "" || subtle.ConstantTimeCompare([]byte(token), []byte(want)) != 1 {
+			unauthorized(w)
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// list returns the DeploymentFreezers that have not finished, optionally limited to ?namespace=.
+func (s *Server) list(w http.ResponseWriter, req *http.Request) {
+	namespace := req.URL.Query().Get("namespace")
+	if !s.authorize(w, req, "list", namespace, "") {
+		return
+	}
+	var list freezerv1alpha1.DeploymentFreezerList
+	if err := s.Reader.List(req.Context(), &list, client.InNamespace(namespace)); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	out := []freezerv1alpha1.FreezeSummary{}
+	for i := range list.Items {
+		if !finished(&list.Items[i]) {
+			out = append(out, summarize(&list.Items[i]))
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) get(w http.ResponseWriter, req *http.Request) {
+	key := objectKey(req)
+	if !s.authorize(w, req, "get", key.Namespace, key.Name) {
+		return
+	}
+	var dfz freezerv1alpha1.DeploymentFreezer
+	if err := s.Reader.Get(req.Context(), key, &dfz); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, summarize(&dfz))
+}
+
+// create creates the DeploymentFreezer on behalf of the caller, who is recorded as its requester
+// for FreezerPolicies.
+func (s *Server) create(w http.ResponseWriter, req *http.Request) {
+	if !s.authorize(w, req, "create", req.PathValue("namespace"), "") {
+		return
+	}
+	var body CreateRequest
+	if !decode(w, req, &body) {
+		return
+	}
+	if body.Deployment == "" {
+		writeError(w, http.StatusBadRequest, errors.New("deployment is required"))
+		return
+	}
+	if body.DurationSeconds < 0 {
+		writeError(w, http.StatusBadRequest, errors.New("durationSeconds must not be negative"))
+		return
+	}
+
+	dfz := &freezerv1alpha1.DeploymentFreezer{
+		ObjectMeta: metav1.ObjectMeta{Namespace: req.PathValue("namespace"), Name: body.Name},
+		Spec: freezerv1alpha1.DeploymentFreezerSpec{
+			TargetRef:       freezerv1alpha1.DeploymentTargetRef{Name: body.Deployment},
+			DurationSeconds: body.DurationSeconds,
+		},
+	}
+	if dfz.Name == "" {
+		dfz.GenerateName = body.Deployment + "-"
+	}
+	user := userFrom(req.Context())
+	policy.Requester{Username: user.Username, Groups: user.Groups}.Annotate(dfz)
+	if err := s.Client.Create(req.Context(), dfz); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	log.Info("created DeploymentFreezer", "namespace", dfz.Namespace, "name", dfz.Name)
+	writeJSON(w, http.StatusCreated, summarize(dfz))
+}
+
+// extend lengthens the freeze window by raising spec.durationSeconds; the controller moves
+// status.freezeUntil accordingly.
+func (s *Server) extend(w http.ResponseWriter, req *http.Request) {
+	key := objectKey(req)
+	if !s.authorize(w, req, "update", key.Namespace, key.Name) {
+		return
+	}
+	var body ExtendRequest
+	if !decode(w, req, &body) {
+		return
+	}
+	if body.Seconds <= 0 {
+		writeError(w, http.StatusBadRequest, errors.New("seconds must be positive"))
+		return
+	}
+
+	var dfz freezerv1alpha1.DeploymentFreezer
+	errFinished := errors.New("the freeze has already finished")
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := s.Reader.Get(req.Context(), key, &dfz); err != nil {
+			return err
+		}
+		if finished(&dfz) || !dfz.DeletionTimestamp.IsZero() {
+			return errFinished
+		}
+		current := dfz.Spec.RequestedDuration()
+		if current <= 0 {
+			current = s.DefaultDuration
+		}
+		dfz.Spec.SetRequestedDuration(current + time.Duration(body.Seconds)*time.Second)
+		return s.Client.Update(req.Context(), &dfz)
+	})
+	switch {
+	case errors.Is(err, errFinished):
+		writeError(w, http.StatusConflict, err)
+		return
+	case err != nil:
+		writeAPIError(w, err)
+		return
+	}
+	log.Info("extended DeploymentFreezer", "namespace", dfz.Namespace, "name", dfz.Name,
+		"duration", dfz.Spec.RequestedDuration())
+	writeJSON(w, http.StatusOK, summarize(&dfz))
+}
+
+// cancel deletes the DeploymentFreezer; its finalizer restores the Deployment.
+func (s *Server) cancel(w http.ResponseWriter, req *http.Request) {
+	key := objectKey(req)
+	if !s.authorize(w, req, "delete", key.Namespace, key.Name) {
+		return
+	}
+	var dfz freezerv1alpha1.DeploymentFreezer
+	if err := s.Reader.Get(req.Context(), key, &dfz); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if err := s.Client.Delete(req.Context(), &dfz, client.Preconditions{UID: &dfz.UID}); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	log.Info("cancelled DeploymentFreezer", "namespace", dfz.Namespace, "name", dfz.Name)
+	writeJSON(w, http.StatusAccepted, summarize(&dfz))
+}
+
+func objectKey(req *http.Request) types.NamespacedName {
+	return types.NamespacedName{Namespace: req.PathValue("namespace"), Name: req.PathValue("name")}
+}
+
+func finished(dfz *freezerv1alpha1.DeploymentFreezer) bool {
+	switch dfz.Status.Phase {
+	case freezerv1alpha1.PhaseCompleted, freezerv1alpha1.PhaseDenied, freezerv1alpha1.PhaseAborted:
+		return true
+	}
+	return false
+}
+
+func summarize(dfz *freezerv1alpha1.DeploymentFreezer) freezerv1alpha1.FreezeSummary {
+	phase := dfz.Status.Phase
+	if phase == "" {
+		phase = freezerv1alpha1.PhasePending
+	}
+	s := freezerv1alpha1.FreezeSummary{
+		Namespace:      dfz.Namespace,
+		Name:           dfz.Name,
+		Target:         dfz.TargetName(),
+		Phase:          phase,
+		FreezeUntil:    dfz.Status.FreezeUntil,
+		ResourcesFreed: dfz.Status.ResourcesFreed,
+	}
+	if t, ok := dfz.Status.PhaseTransitionTimes[phase]; ok {
+		s.Since = &t
+	}
+	return s
+}
+
+func decode(w http.ResponseWriter, req *http.Request, v any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return false
+	}
+	return true
+}
+
+// writeAPIError maps a Kubernetes API error to its HTTP status, so admission denials
+// (FreezerPolicies, protected namespaces, maximum duration) reach the caller unchanged.
+func writeAPIError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	var status apierrors.APIStatus
+	if errors.As(err, &status) && status.Status().Code != 0 {
+		code = int(status.Status().Code)
+	}
+	writeError(w, code, err)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Error(err, "failed to write response")
+	}
+}
@@ -0,0 +1,202 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/internal/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// Bearer tokens the fake API server knows: token authenticates alice, who may do anything,
+// viewerToken authenticates viewer, who may only get and list.
+const (
+	token       = "s3cret"
+	viewerToken = "viewer-token"
+)
+
+// reviewFuncs answers TokenReviews and SubjectAccessReviews like the API server would.
+var reviewFuncs = interceptor.Funcs{
+	Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+		switch review := obj.(type) {
+		case *authenticationv1.TokenReview:
+			switch review.Spec.Token {
+			case token:
+				review.Status = authenticationv1.TokenReviewStatus{Authenticated: true,
+					User: authenticationv1.UserInfo{Username: "alice", Groups: []string{"sre"}}}
+			case viewerToken:
+				review.Status = authenticationv1.TokenReviewStatus{Authenticated: true,
+					User: authenticationv1.UserInfo{Username: "viewer"}}
+			}
+			return nil
+		case *authorizationv1.SubjectAccessReview:
+			verb := review.Spec.ResourceAttributes.Verb
+			review.Status.Allowed = review.Spec.User == "alice" || verb == "get" || verb == "list"
+			return nil
+		}
+		return c.Create(ctx, obj, opts...)
+	},
+}
+
+func newServer(t *testing.T, objs ...client.Object) (*Server, http.Handler) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+		WithStatusSubresource(&freezerv1alpha1.DeploymentFreezer{}).WithInterceptorFuncs(reviewFuncs).Build()
+	s := &Server{Client: c, Reader: c, DefaultDuration: time.Hour}
+	return s, s.Handler()
+}
+
+func newDFZ(ns, name string, phase freezerv1alpha1.Phase) *freezerv1alpha1.DeploymentFreezer {
+	dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name}}
+	dfz.Spec.TargetRef.Name = "web"
+	dfz.Status.Phase = phase
+	return dfz
+}
+
+func do(h http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	return doAs(h, token, method, path, body)
+}
+
+func doAs(h http.Handler, bearer, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+bearer)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer(t *testing.T) {
+	t.Run("MissingToken_Unauthorized", func(t *testing.T) {
+		t.Parallel()
+		_, h := newServer(t)
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/freezes", nil)
+		req.Header.Set("Authorization", "Bearer wrong")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("NotAllowedByRBAC_Forbidden", func(t *testing.T) {
+		t.Parallel()
+		_, h := newServer(t, newDFZ("ns", "release", freezerv1alpha1.PhaseFrozen))
+		assert.Equal(t, http.StatusOK, doAs(h, viewerToken, http.MethodGet, "/api/v1/namespaces/ns/freezes/release", "").Code)
+		rec := doAs(h, viewerToken, http.MethodDelete, "/api/v1/namespaces/ns/freezes/release", "")
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Contains(t, rec.Body.String(), "viewer cannot delete deploymentfreezers in namespace ns")
+	})
+
+	t.Run("List_OnlyUnfinished", func(t *testing.T) {
+		t.Parallel()
+		_, h := newServer(t,
+			newDFZ("a", "frozen", freezerv1alpha1.PhaseFrozen),
+			newDFZ("a", "done", freezerv1alpha1.PhaseCompleted),
+			newDFZ("b", "new", ""),
+		)
+		rec := do(h, http.MethodGet, "/api/v1/freezes", "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		var got []freezerv1alpha1.FreezeSummary
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+		require.Len(t, got, 2)
+
+		rec = do(h, http.MethodGet, "/api/v1/freezes?namespace=b", "")
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+		require.Len(t, got, 1)
+		assert.Equal(t, freezerv1alpha1.PhasePending, got[0].Phase)
+	})
+
+	t.Run("Create_DeploymentFreezer", func(t *testing.T) {
+		t.Parallel()
+		s, h := newServer(t)
+		rec := do(h, http.MethodPost, "/api/v1/namespaces/ns/freezes",
+			`{"name":"release","deployment":"web","durationSeconds":600}`)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+		var dfz freezerv1alpha1.DeploymentFreezer
+		require.NoError(t, s.Reader.Get(t.Context(), types.NamespacedName{Namespace: "ns", Name: "release"}, &dfz))
+		assert.Equal(t, "web", dfz.Spec.TargetRef.Name)
+		assert.Equal(t, int64(600), dfz.Spec.DurationSeconds)
+		assert.Equal(t, policy.Requester{Username: "alice", Groups: []string{"sre"}}, policy.RequesterFromAnnotations(&dfz))
+	})
+
+	t.Run("Create_InvalidBody", func(t *testing.T) {
+		t.Parallel()
+		_, h := newServer(t)
+		assert.Equal(t, http.StatusBadRequest, do(h, http.MethodPost, "/api/v1/namespaces/ns/freezes", `{}`).Code)
+		assert.Equal(t, http.StatusBadRequest,
+			do(h, http.MethodPost, "/api/v1/namespaces/ns/freezes", `{"deployment":"web","extra":1}`).Code)
+	})
+
+	t.Run("Create_AlreadyExists_Conflict", func(t *testing.T) {
+		t.Parallel()
+		_, h := newServer(t, newDFZ("ns", "release", freezerv1alpha1.PhaseFrozen))
+		rec := do(h, http.MethodPost, "/api/v1/namespaces/ns/freezes", `{"name":"release","deployment":"web"}`)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("Extend_AddsToDuration", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ("ns", "release", freezerv1alpha1.PhaseFrozen)
+		s, h := newServer(t, dfz)
+		rec := do(h, http.MethodPost, "/api/v1/namespaces/ns/freezes/release/extend", `{"seconds":1800}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		require.NoError(t, s.Reader.Get(t.Context(), client.ObjectKeyFromObject(dfz), dfz))
+		assert.Equal(t, int64(5400), dfz.Spec.DurationSeconds)
+	})
+
+	t.Run("Extend_FinishedFreeze_Conflict", func(t *testing.T) {
+		t.Parallel()
+		_, h := newServer(t, newDFZ("ns", "release", freezerv1alpha1.PhaseCompleted))
+		rec := do(h, http.MethodPost, "/api/v1/namespaces/ns/freezes/release/extend", `{"seconds":60}`)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("Cancel_DeletesDeploymentFreezer", func(t *testing.T) {
+		t.Parallel()
+		s, h := newServer(t, newDFZ("ns", "release", freezerv1alpha1.PhaseFrozen))
+		rec := do(h, http.MethodDelete, "/api/v1/namespaces/ns/freezes/release", "")
+		require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+
+		var list freezerv1alpha1.DeploymentFreezerList
+		require.NoError(t, s.Reader.List(t.Context(), &list))
+		assert.Empty(t, list.Items)
+	})
+
+	t.Run("Get_NotFound", func(t *testing.T) {
+		t.Parallel()
+		_, h := newServer(t)
+		assert.Equal(t, http.StatusNotFound, do(h, http.MethodGet, "/api/v1/namespaces/ns/freezes/missing", "").Code)
+	})
+}
+
+func TestLoopback(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"127.0.0.1:8080": true,
+		"[::1]:8080":     true,
+		"localhost:8080": true,
+		":8080":          false,
+		"0.0.0.0:8080":   false,
+		"10.0.0.1:8080":  false,
+	} {
+		assert.Equal(t, want, Loopback(addr), addr)
+	}
+}
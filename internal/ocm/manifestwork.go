@@ -0,0 +1,224 @@
+// Package ocm propagates DeploymentFreezers from an Open Cluster Management hub to its managed
+// clusters. Each copy is wrapped in a ManifestWork in the namespace OCM keeps for the cluster, and
+// the work agent on the cluster reports the copy's phase back through status feedback.
+package ocm
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups=work.open-cluster-management.io,resources=manifestworks,verbs=get;create;update;delete
+
+// manifestWorkGVK is the kind wrapping the copies. It is handled as unstructured, so the hub
+// needs no OCM client.
+var manifestWorkGVK = schema.GroupVersionKind{
+	Group:   "work.open-cluster-management.io",
+	Version: "v1",
+	Kind:    "ManifestWork",
+}
+
+const (
+	// annotationSpecHash on a ManifestWork is the hash of the spec the controller last wrote,
+	// so fields defaulted by the API server do not look like changes.
+	annotationSpecHash = "apps.boolfixer.dev/spec-hash"
+
+	// feedbackPhase names the status feedback value carrying the copy's status.phase.
+	feedbackPhase = "phase"
+	// conditionApplied is the ManifestWork condition reporting whether the copy was applied.
+	conditionApplied = "Applied"
+	// lastApplied is the kubectl annotation, meaningless on the copy.
+	lastApplied = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// ManifestWorks propagates DeploymentFreezers as ManifestWorks.
+type ManifestWorks struct {
+	Client client.Client
+}
+
+// workName returns the name of the ManifestWork carrying the copy of the DFZ. Namespaces have no
+// dots, so names of different DFZs cannot collide.
+func workName(dfz *freezerv1alpha1.DeploymentFreezer) string {
+	name := fmt.Sprintf("dfz.%s.%s", dfz.Namespace, dfz.Name)
+	if len(name) <= validation.DNS1123SubdomainMaxLength {
+		return name
+	}
+	sum := sha256.Sum256([]byte(name))
+	suffix := hex.EncodeToString(sum[:])[:10]
+	return fmt.Sprintf("%s-%s", name[:validation.DNS1123SubdomainMaxLength-len(suffix)-1], suffix)
+}
+
+// Propagate creates the ManifestWork of the DFZ in the cluster namespace, or updates it when the
+// DFZ changed, and returns the state reported in its status.
+func (m *ManifestWorks) Propagate(
+	ctx context.Context,
+	cluster string,
+	dfz *freezerv1alpha1.DeploymentFreezer,
+) (freezerv1alpha1.ClusterFreeze, error) {
+	state := freezerv1alpha1.ClusterFreeze{Cluster: cluster}
+	desired, err := newManifestWork(cluster, dfz)
+	if err != nil {
+		return state, err
+	}
+
+	work := &unstructured.Unstructured{}
+	work.SetGroupVersionKind(manifestWorkGVK)
+	err = m.Client.Get(ctx, client.ObjectKeyFromObject(desired), work)
+	switch {
+	case apierrors.IsNotFound(err):
+		if err := m.Client.Create(ctx, desired); err != nil {
+			if apierrors.IsNotFound(err) {
+				return state, fmt.Errorf("namespace %s not found; is %s a managed cluster?", cluster, cluster)
+			}
+			return state, err
+		}
+		return state, nil
+	case err != nil:
+		return state, err
+	}
+
+	hash := desired.GetAnnotations()[annotationSpecHash]
+	if work.GetAnnotations()[annotationSpecHash] != hash {
+		work.Object["spec"] = desired.Object["spec"]
+		annotations := work.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[annotationSpecHash] = hash
+		work.SetAnnotations(annotations)
+		if err := m.Client.Update(ctx, work); err != nil {
+			return state, err
+		}
+	}
+	observe(work, &state)
+	return state, nil
+}
+
+// Withdraw deletes the ManifestWork of the DFZ. The work agent deletes the copy, whose finalizer
+// restores the target, before the ManifestWork is gone.
+func (m *ManifestWorks) Withdraw(ctx context.Context, cluster string, dfz *freezerv1alpha1.DeploymentFreezer) (bool, error) {
+	work := &unstructured.Unstructured{}
+	work.SetGroupVersionKind(manifestWorkGVK)
+	work.SetNamespace(cluster)
+	work.SetName(workName(dfz))
+	err := m.Client.Delete(ctx, work)
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	return false, err
+}
+
+// newManifestWork wraps the copy of dfz, without spec.propagation, in a ManifestWork that feeds
+// its phase back.
+func newManifestWork(cluster string, dfz *freezerv1alpha1.DeploymentFreezer) (*unstructured.Unstructured, error) {
+	manifest, err := newCopy(dfz)
+	if err != nil {
+		return nil, err
+	}
+	spec := map[string]any{
+		"workload": map[string]any{
+			"manifests": []any{manifest},
+		},
+		"manifestConfigs": []any{map[string]any{
+			"resourceIdentifier": map[string]any{
+				"group":     freezerv1alpha1.GroupVersion.Group,
+				"resource":  "deploymentfreezers",
+				"namespace": dfz.Namespace,
+				"name":      dfz.Name,
+			},
+			"feedbackRules": []any{map[string]any{
+				"type":      "JSONPaths",
+				"jsonPaths": []any{map[string]any{"name": feedbackPhase, "path": ".status.phase"}},
+			}},
+		}},
+	}
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(raw)
+
+	work := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	work.SetGroupVersionKind(manifestWorkGVK)
+	work.SetNamespace(cluster)
+	work.SetName(workName(dfz))
+	work.SetLabels(map[string]string{"app.kubernetes.io/managed-by": "deployment-freezer"})
+	work.SetAnnotations(map[string]string{annotationSpecHash: hex.EncodeToString(sum[:])})
+	return work, nil
+}
+
+// newCopy returns the DFZ as applied on a managed cluster: its labels, annotations and spec, but
+// no propagation, so the copy freezes the target there.
+func newCopy(dfz *freezerv1alpha1.DeploymentFreezer) (map[string]any, error) {
+	cp := &freezerv1alpha1.DeploymentFreezer{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   dfz.Namespace,
+			Name:        dfz.Name,
+			Labels:      dfz.Labels,
+			Annotations: dfz.Annotations,
+		},
+		Spec: *dfz.Spec.DeepCopy(),
+	}
+	cp.SetGroupVersionKind(freezerv1alpha1.GroupVersion.WithKind("DeploymentFreezer"))
+	cp.Spec.Propagation = nil
+	if _, ok := cp.Annotations[lastApplied]; ok {
+		cp.Annotations = make(map[string]string, len(dfz.Annotations))
+		for k, v := range dfz.Annotations {
+			if k != lastApplied {
+				cp.Annotations[k] = v
+			}
+		}
+	}
+
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cp)
+	if err != nil {
+		return nil, err
+	}
+	delete(obj, "status")
+	unstructured.RemoveNestedField(obj, "metadata", "creationTimestamp")
+	return obj, nil
+}
+
+// observe reads the phase fed back by the cluster, and why the copy was not applied, from the
+// status of the ManifestWork.
+func observe(work *unstructured.Unstructured, state *freezerv1alpha1.ClusterFreeze) {
+	conditions, _, _ := unstructured.NestedSlice(work.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]any)
+		if ok && cond["type"] == conditionApplied && cond["status"] == string(metav1.ConditionFalse) {
+			message, _ := cond["message"].(string)
+			reason, _ := cond["reason"].(string)
+			state.Message = cmp.Or(message, reason, "not applied")
+		}
+	}
+
+	manifests, _, _ := unstructured.NestedSlice(work.Object, "status", "resourceStatus", "manifests")
+	for _, m := range manifests {
+		manifest, ok := m.(map[string]any)
+		if !ok {
+			continue
+		}
+		values, _, _ := unstructured.NestedSlice(manifest, "statusFeedback", "values")
+		for _, v := range values {
+			value, ok := v.(map[string]any)
+			if !ok || value["name"] != feedbackPhase {
+				continue
+			}
+			phase, _, _ := unstructured.NestedString(value, "fieldValue", "string")
+			state.Phase = freezerv1alpha1.Phase(phase)
+		}
+	}
+}
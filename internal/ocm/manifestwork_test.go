@@ -0,0 +1,166 @@
+package ocm
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestManifestWorks(t *testing.T) {
+	newDFZ := func() *freezerv1alpha1.DeploymentFreezer {
+		return &freezerv1alpha1.DeploymentFreezer{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "shop",
+				Name:        "release",
+				Labels:      map[string]string{"team": "payments"},
+				Annotations: map[string]string{lastApplied: "{}", "note": "quarter close"},
+			},
+			Spec: freezerv1alpha1.DeploymentFreezerSpec{
+				TargetRef:       freezerv1alpha1.DeploymentTargetRef{Name: "web"},
+				DurationSeconds: 3600,
+				Propagation:     &freezerv1alpha1.Propagation{Clusters: []string{"east", "west"}},
+			},
+		}
+	}
+	getWork := func(t *testing.T, c client.Client, cluster string) *unstructured.Unstructured {
+		work := &unstructured.Unstructured{}
+		work.SetGroupVersionKind(manifestWorkGVK)
+		require.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: cluster, Name: "dfz.shop.release"}, work))
+		return work
+	}
+
+	t.Run("Propagate_CreatesWorkWithCopy", func(t *testing.T) {
+		t.Parallel()
+		c := fake.NewClientBuilder().Build()
+		m := &ManifestWorks{Client: c}
+
+		state, err := m.Propagate(context.Background(), "east", newDFZ())
+		require.NoError(t, err)
+		assert.Equal(t, freezerv1alpha1.ClusterFreeze{Cluster: "east"}, state)
+
+		work := getWork(t, c, "east")
+		manifests, _, _ := unstructured.NestedSlice(work.Object, "spec", "workload", "manifests")
+		require.Len(t, manifests, 1)
+		cp := &freezerv1alpha1.DeploymentFreezer{}
+		require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(manifests[0].(map[string]any), cp))
+		assert.Equal(t, "DeploymentFreezer", cp.Kind)
+		assert.Equal(t, "shop", cp.Namespace)
+		assert.Nil(t, cp.Spec.Propagation)
+		assert.Equal(t, int64(3600), cp.Spec.DurationSeconds)
+		assert.Equal(t, map[string]string{"team": "payments"}, cp.Labels)
+		assert.Equal(t, map[string]string{"note": "quarter close"}, cp.Annotations)
+
+		rules, _, _ := unstructured.NestedSlice(work.Object, "spec", "manifestConfigs")
+		require.Len(t, rules, 1)
+		name, _, _ := unstructured.NestedString(rules[0].(map[string]any), "resourceIdentifier", "name")
+		assert.Equal(t, "release", name)
+	})
+
+	t.Run("Propagate_ReadsFeedback", func(t *testing.T) {
+		t.Parallel()
+		c := fake.NewClientBuilder().Build()
+		m := &ManifestWorks{Client: c}
+		_, err := m.Propagate(context.Background(), "east", newDFZ())
+		require.NoError(t, err)
+
+		work := getWork(t, c, "east")
+		work.Object["status"] = map[string]any{
+			"conditions": []any{map[string]any{"type": "Applied", "status": "True"}},
+			"resourceStatus": map[string]any{"manifests": []any{map[string]any{
+				"statusFeedback": map[string]any{"values": []any{map[string]any{
+					"name":       "phase",
+					"fieldValue": map[string]any{"type": "String", "string": "Frozen"},
+				}}},
+			}}},
+		}
+		require.NoError(t, c.Update(context.Background(), work))
+
+		state, err := m.Propagate(context.Background(), "east", newDFZ())
+		require.NoError(t, err)
+		assert.Equal(t, freezerv1alpha1.ClusterFreeze{Cluster: "east", Phase: freezerv1alpha1.PhaseFrozen}, state)
+	})
+
+	t.Run("Propagate_NotApplied", func(t *testing.T) {
+		t.Parallel()
+		c := fake.NewClientBuilder().Build()
+		m := &ManifestWorks{Client: c}
+		_, err := m.Propagate(context.Background(), "east", newDFZ())
+		require.NoError(t, err)
+
+		work := getWork(t, c, "east")
+		work.Object["status"] = map[string]any{"conditions": []any{map[string]any{
+			"type": "Applied", "status": "False", "reason": "AppliedManifestWorkFailed", "message": "CRD missing",
+		}}}
+		require.NoError(t, c.Update(context.Background(), work))
+
+		state, err := m.Propagate(context.Background(), "east", newDFZ())
+		require.NoError(t, err)
+		assert.Equal(t, "CRD missing", state.Message)
+		assert.Empty(t, state.Phase)
+	})
+
+	t.Run("Propagate_UpdatesChangedSpecOnly", func(t *testing.T) {
+		t.Parallel()
+		c := fake.NewClientBuilder().Build()
+		m := &ManifestWorks{Client: c}
+		dfz := newDFZ()
+		_, err := m.Propagate(context.Background(), "east", dfz)
+		require.NoError(t, err)
+		before := getWork(t, c, "east").GetResourceVersion()
+
+		_, err = m.Propagate(context.Background(), "east", dfz)
+		require.NoError(t, err)
+		assert.Equal(t, before, getWork(t, c, "east").GetResourceVersion())
+
+		dfz.Spec.DurationSeconds = 7200
+		_, err = m.Propagate(context.Background(), "east", dfz)
+		require.NoError(t, err)
+		work := getWork(t, c, "east")
+		assert.NotEqual(t, before, work.GetResourceVersion())
+		manifests, _, _ := unstructured.NestedSlice(work.Object, "spec", "workload", "manifests")
+		seconds, _, _ := unstructured.NestedInt64(manifests[0].(map[string]any), "spec", "durationSeconds")
+		assert.Equal(t, int64(7200), seconds)
+	})
+
+	t.Run("Withdraw_DeletesWork", func(t *testing.T) {
+		t.Parallel()
+		c := fake.NewClientBuilder().Build()
+		m := &ManifestWorks{Client: c}
+		_, err := m.Propagate(context.Background(), "west", newDFZ())
+		require.NoError(t, err)
+
+		gone, err := m.Withdraw(context.Background(), "west", newDFZ())
+		require.NoError(t, err)
+		assert.False(t, gone)
+		work := &unstructured.Unstructured{}
+		work.SetGroupVersionKind(manifestWorkGVK)
+		err = c.Get(context.Background(), types.NamespacedName{Namespace: "west", Name: "dfz.shop.release"}, work)
+		assert.True(t, apierrors.IsNotFound(err))
+
+		gone, err = m.Withdraw(context.Background(), "west", newDFZ())
+		require.NoError(t, err)
+		assert.True(t, gone)
+	})
+
+	t.Run("WorkName_LongNamesHashed", func(t *testing.T) {
+		t.Parallel()
+		dfz := newDFZ()
+		dfz.Name = strings.Repeat("a", 250)
+		name := workName(dfz)
+		assert.Len(t, name, 253)
+		assert.True(t, strings.HasPrefix(name, "dfz.shop.aaa"))
+		dfz.Name += "b"
+		assert.NotEqual(t, name, workName(dfz))
+	})
+}
@@ -0,0 +1,56 @@
+package policy
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Blackout is a blackout window of a FreezerPolicy in effect for a namespace.
+type Blackout struct {
+	// Policy is the FreezerPolicy declaring the blackout.
+	Policy string
+	// Name of the blackout within the policy.
+	Name string
+	// End is when the blackout ends.
+	End time.Time
+}
+
+// ActiveBlackout returns the blackout covering namespace at now. Of overlapping blackouts it
+// returns the one ending last, since nothing may be scaled until all of them have ended.
+func ActiveBlackout(ctx context.Context, c client.Reader, namespace string, now time.Time) (Blackout, bool, error) {
+	var policies freezerv1alpha1.FreezerPolicyList
+	if err := c.List(ctx, &policies); err != nil {
+		return Blackout{}, false, err
+	}
+	slices.SortFunc(policies.Items, func(a, b freezerv1alpha1.FreezerPolicy) int { return strings.Compare(a.Name, b.Name) })
+
+	m := &matcher{reader: c, namespace: namespace}
+	var active Blackout
+	var found bool
+	for _, p := range policies.Items {
+		for _, b := range p.Spec.Blackouts {
+			if now.Before(b.Start.Time) || !now.Before(b.End.Time) {
+				continue
+			}
+			if found && !b.End.After(active.End) {
+				continue
+			}
+			if len(b.Namespaces) > 0 || b.NamespaceSelector != nil {
+				ok, err := m.matchesNamespace(ctx, b.Namespaces, b.NamespaceSelector)
+				if err != nil {
+					return Blackout{}, false, err
+				}
+				if !ok {
+					continue
+				}
+			}
+			active, found = Blackout{Policy: p.Name, Name: b.Name, End: b.End.Time}, true
+		}
+	}
+	return active, found, nil
+}
@@ -0,0 +1,261 @@
+// Package policy evaluates FreezerPolicies for DeploymentFreezers. It is shared by the
+// admission webhook and the reconciler so both enforce the same decision.
+package policy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Annotations recording who created a DeploymentFreezer. They are set by the defaulting
+// webhook and cannot be changed afterwards.
+const (
+	AnnoRequestedBy       = "apps.boolfixer.dev/requested-by"
+	AnnoRequestedByGroups = "apps.boolfixer.dev/requested-by-groups"
+)
+
+const (
+	msgDeniedByRuleFmt  = "denied by FreezerPolicy %s (rule %d)"
+	msgQuotaExceededFmt = "namespace %s would be frozen for %s within %s, exceeding the quota of %s " +
+		"set by FreezerPolicy %s (rule %d)"
+	msgNotAllowedFmt = "no FreezerPolicy allows %s to freeze Deployments in namespace %s"
+	msgAllowedFmt    = "allowed by FreezerPolicy %s"
+	msgNoPolicies    = "no FreezerPolicy rules defined; all freezes are allowed"
+	msgNotExemptFmt  = "no FreezerPolicy grants %s the %s exemption in namespace %s"
+	msgAnonymousFmt  = "the requester is unknown, so the subjects of FreezerPolicy %s (rule %d) cannot be checked"
+)
+
+// Requester is the identity that created a DeploymentFreezer.
+type Requester struct {
+	Username string
+	Groups   []string
+}
+
+// RequesterFromAnnotations reads the requester recorded on obj.
+func RequesterFromAnnotations(obj metav1.Object) Requester {
+	a := obj.GetAnnotations()
+	r := Requester{Username: a[AnnoRequestedBy]}
+	if g := a[AnnoRequestedByGroups]; g != "" {
+		r.Groups = strings.Split(g, ",")
+	}
+	return r
+}
+
+// Annotate records the requester on obj.
+func (r Requester) Annotate(obj metav1.Object) {
+	a := obj.GetAnnotations()
+	if a == nil {
+		a = map[string]string{}
+	}
+	a[AnnoRequestedBy] = r.Username
+	if len(r.Groups) > 0 {
+		a[AnnoRequestedByGroups] = strings.Join(r.Groups, ",")
+	} else {
+		delete(a, AnnoRequestedByGroups)
+	}
+	obj.SetAnnotations(a)
+}
+
+func (r Requester) String() string {
+	if r.Username == "" {
+		return "an unknown requester"
+	}
+	return r.Username
+}
+
+func (r Requester) matches(s rbacv1.Subject) bool {
+	switch s.Kind {
+	case rbacv1.UserKind:
+		return r.Username == s.Name
+	case rbacv1.GroupKind:
+		return slices.Contains(r.Groups, s.Name)
+	case rbacv1.ServiceAccountKind:
+		return r.Username == fmt.Sprintf("system:serviceaccount:%s:%s", s.Namespace, s.Name)
+	default:
+		return false
+	}
+}
+
+// Decision is the outcome of evaluating FreezerPolicies.
+type Decision struct {
+	Allowed bool
+	// Message explains the decision.
+	Message string
+	// MaxDuration is the strictest limit of the matching Allow rules; 0 means unlimited.
+	MaxDuration time.Duration
+	// Exemptions are the requested exemptions with the policy granting each, in request order.
+	Exemptions []freezerv1alpha1.ExemptionGrant
+}
+
+// Request describes the freeze being evaluated.
+type Request struct {
+	Namespace string
+	Requester Requester
+	// Duration is the requested freeze duration, counted against quotas.
+	Duration time.Duration
+	// Self is the UID of the DeploymentFreezer being evaluated, if it exists already;
+	// its own frozen time is not counted against quotas.
+	Self types.UID
+	// Now is the current time on the caller's clock.
+	Now time.Time
+	// Exemptions are the controller-wide protections the DeploymentFreezer asks to be exempted
+	// from; each must be granted by a matching Allow rule.
+	Exemptions []freezerv1alpha1.Exemption
+}
+
+// Evaluate decides whether the request may freeze a Deployment.
+// Without any FreezerPolicy rule everything is allowed, except exemptions, which nothing grants.
+// Otherwise a matching Deny rule or an exhausted quota wins, at least one Allow rule must match,
+// and every requested exemption must be granted by a matching Allow rule.
+// It fails closed: a request without a recorded requester is denied as soon as a rule with
+// subjects applies to its namespace, since it cannot be told whether that rule matches.
+func Evaluate(ctx context.Context, c client.Reader, req Request) (Decision, error) {
+	var policies freezerv1alpha1.FreezerPolicyList
+	if err := c.List(ctx, &policies); err != nil {
+		return Decision{}, err
+	}
+	if !slices.ContainsFunc(policies.Items, func(p freezerv1alpha1.FreezerPolicy) bool { return len(p.Spec.Rules) > 0 }) {
+		if len(req.Exemptions) > 0 {
+			return Decision{Message: fmt.Sprintf(msgNotExemptFmt, req.Requester, req.Exemptions[0], req.Namespace)}, nil
+		}
+		return Decision{Allowed: true, Message: msgNoPolicies}, nil
+	}
+	slices.SortFunc(policies.Items, func(a, b freezerv1alpha1.FreezerPolicy) int { return strings.Compare(a.Name, b.Name) })
+
+	m := &matcher{reader: c, namespace: req.Namespace, requester: req.Requester}
+	var usage *quotaUsage
+	var allowedBy []string
+	var maxDuration time.Duration
+	grantedBy := map[freezerv1alpha1.Exemption]string{}
+	for _, p := range policies.Items {
+		for i, rule := range p.Spec.Rules {
+			if len(rule.Subjects) > 0 && req.Requester.Username == "" {
+				ok, err := m.matchesNamespace(ctx, rule.Namespaces, rule.NamespaceSelector)
+				if err != nil {
+					return Decision{}, err
+				}
+				if ok {
+					return Decision{Message: fmt.Sprintf(msgAnonymousFmt, p.Name, i)}, nil
+				}
+				continue
+			}
+			ok, err := m.matches(ctx, rule)
+			if err != nil {
+				return Decision{}, err
+			}
+			if !ok {
+				continue
+			}
+			if rule.Action == freezerv1alpha1.PolicyActionDeny {
+				return Decision{Message: fmt.Sprintf(msgDeniedByRuleFmt, p.Name, i)}, nil
+			}
+			if rule.Quota != nil {
+				if usage == nil {
+					if usage, err = loadActiveUsage(ctx, c, req); err != nil {
+						return Decision{}, err
+					}
+				}
+				window := quotaWindow(rule.Quota)
+				used := usage.frozenWithin(&p, window) + req.Duration
+				if limit := time.Duration(rule.Quota.MaxFrozenSeconds) * time.Second; used > limit {
+					return Decision{Message: fmt.Sprintf(msgQuotaExceededFmt, req.Namespace, used, window, limit, p.Name, i)}, nil
+				}
+			}
+			if !slices.Contains(allowedBy, p.Name) {
+				allowedBy = append(allowedBy, p.Name)
+			}
+			for _, e := range rule.Exemptions {
+				if _, ok := grantedBy[e]; !ok {
+					grantedBy[e] = p.Name
+				}
+			}
+			if d := time.Duration(rule.MaxDurationSeconds) * time.Second; d > 0 && (maxDuration == 0 || d < maxDuration) {
+				maxDuration = d
+			}
+		}
+	}
+	if len(allowedBy) == 0 {
+		return Decision{Message: fmt.Sprintf(msgNotAllowedFmt, req.Requester, req.Namespace)}, nil
+	}
+	var grants []freezerv1alpha1.ExemptionGrant
+	for _, e := range req.Exemptions {
+		p, ok := grantedBy[e]
+		if !ok {
+			return Decision{Message: fmt.Sprintf(msgNotExemptFmt, req.Requester, e, req.Namespace)}, nil
+		}
+		grants = append(grants, freezerv1alpha1.ExemptionGrant{Exemption: e, Policy: p})
+	}
+	return Decision{
+		Allowed:     true,
+		Message:     fmt.Sprintf(msgAllowedFmt, strings.Join(allowedBy, ", ")),
+		MaxDuration: maxDuration,
+		Exemptions:  grants,
+	}, nil
+}
+
+// matcher matches rules against one namespace and requester, loading the namespace labels lazily.
+type matcher struct {
+	reader    client.Reader
+	namespace string
+	requester Requester
+	nsLabels  labels.Set
+}
+
+func (m *matcher) matches(ctx context.Context, rule freezerv1alpha1.FreezerPolicyRule) (bool, error) {
+	if len(rule.Subjects) > 0 && !slices.ContainsFunc(rule.Subjects, m.requester.matches) {
+		return false, nil
+	}
+	return m.matchesNamespace(ctx, rule.Namespaces, rule.NamespaceSelector)
+}
+
+func (m *matcher) matchesNamespace(ctx context.Context, names []string, sel *metav1.LabelSelector) (bool, error) {
+	if slices.Contains(names, "*") || slices.Contains(names, m.namespace) {
+		return true, nil
+	}
+	if sel == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(sel)
+	if err != nil {
+		return false, err
+	}
+	if m.nsLabels == nil {
+		var ns corev1.Namespace
+		if err := m.reader.Get(ctx, types.NamespacedName{Name: m.namespace}, &ns); err != nil {
+			return false, err
+		}
+		m.nsLabels = labels.Set(ns.Labels)
+		if m.nsLabels == nil {
+			m.nsLabels = labels.Set{}
+		}
+	}
+	return selector.Matches(m.nsLabels), nil
+}
+
+// Strictest returns the smaller of two duration limits, where 0 means unlimited.
+func Strictest(a, b time.Duration) time.Duration {
+	switch {
+	case a == 0:
+		return b
+	case b == 0:
+		return a
+	default:
+		return min(a, b)
+	}
+}
+
+// Exempted reports whether the grants include the exemption.
+func Exempted(grants []freezerv1alpha1.ExemptionGrant, e freezerv1alpha1.Exemption) bool {
+	return slices.ContainsFunc(grants, func(g freezerv1alpha1.ExemptionGrant) bool { return g.Exemption == e })
+}
@@ -0,0 +1,391 @@
+package policy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newReader(t *testing.T, objs ...client.Object) client.Reader {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, freezerv1alpha1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+		WithStatusSubresource(&freezerv1alpha1.FreezerPolicy{}).Build()
+}
+
+func newPolicy(name string, rules ...freezerv1alpha1.FreezerPolicyRule) *freezerv1alpha1.FreezerPolicy {
+	return &freezerv1alpha1.FreezerPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       freezerv1alpha1.FreezerPolicySpec{Rules: rules},
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	ctx := context.Background()
+	alice := Requester{Username: "alice", Groups: []string{"team-a"}}
+	shop := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop", Labels: map[string]string{"tier": "prod"}}}
+
+	t.Run("NoPolicies_Allowed", func(t *testing.T) {
+		t.Parallel()
+		d, err := Evaluate(ctx, newReader(t), Request{Namespace: "shop", Requester: alice})
+		require.NoError(t, err)
+		assert.True(t, d.Allowed)
+		assert.Zero(t, d.MaxDuration)
+	})
+
+	t.Run("NoMatchingAllowRule_Denied", func(t *testing.T) {
+		t.Parallel()
+		p := newPolicy("p", freezerv1alpha1.FreezerPolicyRule{
+			Action: freezerv1alpha1.PolicyActionAllow, Namespaces: []string{"other"},
+		})
+		d, err := Evaluate(ctx, newReader(t, p), Request{Namespace: "shop", Requester: alice})
+		require.NoError(t, err)
+		assert.False(t, d.Allowed)
+	})
+
+	t.Run("SelectorAllow_StrictestMaxDuration", func(t *testing.T) {
+		t.Parallel()
+		p1 := newPolicy("p1", freezerv1alpha1.FreezerPolicyRule{
+			Action:             freezerv1alpha1.PolicyActionAllow,
+			NamespaceSelector:  &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "prod"}},
+			MaxDurationSeconds: 3600,
+		})
+		p2 := newPolicy("p2", freezerv1alpha1.FreezerPolicyRule{
+			Action: freezerv1alpha1.PolicyActionAllow, Namespaces: []string{"*"}, MaxDurationSeconds: 600,
+		})
+		d, err := Evaluate(ctx, newReader(t, shop, p1, p2), Request{Namespace: "shop", Requester: alice})
+		require.NoError(t, err)
+		assert.True(t, d.Allowed)
+		assert.Equal(t, 10*time.Minute, d.MaxDuration)
+	})
+
+	t.Run("DenyRule_Wins", func(t *testing.T) {
+		t.Parallel()
+		allow := newPolicy("a", freezerv1alpha1.FreezerPolicyRule{
+			Action: freezerv1alpha1.PolicyActionAllow, Namespaces: []string{"*"},
+		})
+		deny := newPolicy("b", freezerv1alpha1.FreezerPolicyRule{
+			Action: freezerv1alpha1.PolicyActionDeny, Namespaces: []string{"shop"},
+		})
+		d, err := Evaluate(ctx, newReader(t, allow, deny), Request{Namespace: "shop", Requester: alice})
+		require.NoError(t, err)
+		assert.False(t, d.Allowed)
+		assert.Contains(t, d.Message, "FreezerPolicy b")
+	})
+
+	t.Run("Subjects_MatchUserGroupAndServiceAccount", func(t *testing.T) {
+		t.Parallel()
+		rule := func(s rbacv1.Subject) *freezerv1alpha1.FreezerPolicy {
+			return newPolicy("p", freezerv1alpha1.FreezerPolicyRule{
+				Action: freezerv1alpha1.PolicyActionAllow, Namespaces: []string{"shop"}, Subjects: []rbacv1.Subject{s},
+			})
+		}
+		sa := Requester{Username: "system:serviceaccount:ci:deployer"}
+
+		for _, tc := range []struct {
+			subject   rbacv1.Subject
+			requester Requester
+			allowed   bool
+		}{
+			{rbacv1.Subject{Kind: rbacv1.UserKind, Name: "alice"}, alice, true},
+			{rbacv1.Subject{Kind: rbacv1.UserKind, Name: "bob"}, alice, false},
+			{rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "team-a"}, alice, true},
+			{rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Namespace: "ci", Name: "deployer"}, sa, true},
+			{rbacv1.Subject{Kind: rbacv1.UserKind, Name: "alice"}, Requester{}, false},
+		} {
+			d, err := Evaluate(ctx, newReader(t, rule(tc.subject)), Request{Namespace: "shop", Requester: tc.requester})
+			require.NoError(t, err)
+			assert.Equal(t, tc.allowed, d.Allowed, "subject %v requester %v", tc.subject, tc.requester)
+		}
+	})
+
+	t.Run("UnknownRequester_FailsClosed", func(t *testing.T) {
+		t.Parallel()
+		allow := newPolicy("a", freezerv1alpha1.FreezerPolicyRule{
+			Action: freezerv1alpha1.PolicyActionAllow, Namespaces: []string{"*"},
+		})
+		deny := newPolicy("b", freezerv1alpha1.FreezerPolicyRule{
+			Action:     freezerv1alpha1.PolicyActionDeny,
+			Namespaces: []string{"shop"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "mallory"}},
+		})
+		d, err := Evaluate(ctx, newReader(t, allow, deny), Request{Namespace: "shop"})
+		require.NoError(t, err)
+		assert.False(t, d.Allowed)
+		assert.Contains(t, d.Message, "requester is unknown")
+
+		// A subject rule for another namespace does not apply.
+		d, err = Evaluate(ctx, newReader(t, allow, deny), Request{Namespace: "dev"})
+		require.NoError(t, err)
+		assert.True(t, d.Allowed)
+	})
+
+	t.Run("Exemptions_GrantedByMatchingAllowRule", func(t *testing.T) {
+		t.Parallel()
+		sre := newPolicy("sre", freezerv1alpha1.FreezerPolicyRule{
+			Action:     freezerv1alpha1.PolicyActionAllow,
+			Namespaces: []string{"*"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "team-a"}},
+			Exemptions: []freezerv1alpha1.Exemption{freezerv1alpha1.ExemptionProtectedNamespace},
+		})
+		everyone := newPolicy("everyone", freezerv1alpha1.FreezerPolicyRule{
+			Action: freezerv1alpha1.PolicyActionAllow, Namespaces: []string{"*"},
+		})
+		r := newReader(t, sre, everyone)
+
+		d, err := Evaluate(ctx, r, Request{
+			Namespace:  "shop",
+			Requester:  alice,
+			Exemptions: []freezerv1alpha1.Exemption{freezerv1alpha1.ExemptionProtectedNamespace},
+		})
+		require.NoError(t, err)
+		assert.True(t, d.Allowed)
+		assert.Equal(t, []freezerv1alpha1.ExemptionGrant{
+			{Exemption: freezerv1alpha1.ExemptionProtectedNamespace, Policy: "sre"},
+		}, d.Exemptions)
+
+		d, err = Evaluate(ctx, r, Request{
+			Namespace:  "shop",
+			Requester:  Requester{Username: "bob"},
+			Exemptions: []freezerv1alpha1.Exemption{freezerv1alpha1.ExemptionProtectedNamespace},
+		})
+		require.NoError(t, err)
+		assert.False(t, d.Allowed)
+		assert.Equal(t, "no FreezerPolicy grants bob the ProtectedNamespace exemption in namespace shop", d.Message)
+	})
+
+	t.Run("Exemptions_NoPolicies_Denied", func(t *testing.T) {
+		t.Parallel()
+		d, err := Evaluate(ctx, newReader(t), Request{
+			Namespace:  "shop",
+			Requester:  alice,
+			Exemptions: []freezerv1alpha1.Exemption{freezerv1alpha1.ExemptionMaxDuration},
+		})
+		require.NoError(t, err)
+		assert.False(t, d.Allowed)
+	})
+}
+
+func TestRequesterAnnotations(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		t.Parallel()
+		obj := &metav1.ObjectMeta{}
+		in := Requester{Username: "alice", Groups: []string{"team-a", "system:authenticated"}}
+		in.Annotate(obj)
+		assert.Equal(t, in, RequesterFromAnnotations(obj))
+	})
+
+	t.Run("NoGroups_OmitsAnnotation", func(t *testing.T) {
+		t.Parallel()
+		obj := &metav1.ObjectMeta{Annotations: map[string]string{AnnoRequestedByGroups: "stale"}}
+		Requester{Username: "alice"}.Annotate(obj)
+		assert.NotContains(t, obj.Annotations, AnnoRequestedByGroups)
+	})
+}
+
+func TestStrictest(t *testing.T) {
+	t.Run("ZeroIsUnlimited", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, time.Hour, Strictest(0, time.Hour))
+		assert.Equal(t, time.Hour, Strictest(time.Hour, 0))
+		assert.Zero(t, Strictest(0, 0))
+	})
+
+	t.Run("BothSet_Smaller", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, time.Minute, Strictest(time.Hour, time.Minute))
+	})
+}
+
+func TestQuota(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	alice := Requester{Username: "alice"}
+	quotaPolicy := func(usage ...freezerv1alpha1.FrozenPeriod) *freezerv1alpha1.FreezerPolicy {
+		p := newPolicy("quota", freezerv1alpha1.FreezerPolicyRule{
+			Action:     freezerv1alpha1.PolicyActionAllow,
+			Namespaces: []string{"shop"},
+			Quota:      &freezerv1alpha1.FreezeQuota{MaxFrozenSeconds: 3600, WindowSeconds: 24 * 3600},
+		})
+		if len(usage) > 0 {
+			p.Status.Usage = []freezerv1alpha1.NamespaceUsage{{Namespace: "shop", Periods: usage}}
+		}
+		return p
+	}
+	period := func(uid string, start time.Time, d time.Duration) freezerv1alpha1.FrozenPeriod {
+		return freezerv1alpha1.FrozenPeriod{
+			Freezer: uid, UID: types.UID(uid), Start: metav1.NewTime(start), End: metav1.NewTime(start.Add(d)),
+		}
+	}
+
+	t.Run("WithinQuota_Allowed", func(t *testing.T) {
+		t.Parallel()
+		p := quotaPolicy(period("a", now.Add(-2*time.Hour), 30*time.Minute))
+		d, err := Evaluate(ctx, newReader(t, p), Request{Namespace: "shop", Requester: alice, Duration: 30 * time.Minute, Now: now})
+		require.NoError(t, err)
+		assert.True(t, d.Allowed)
+	})
+
+	t.Run("RecordedAndRequested_ExceedQuota", func(t *testing.T) {
+		t.Parallel()
+		p := quotaPolicy(period("a", now.Add(-2*time.Hour), 45*time.Minute))
+		d, err := Evaluate(ctx, newReader(t, p), Request{Namespace: "shop", Requester: alice, Duration: 30 * time.Minute, Now: now})
+		require.NoError(t, err)
+		assert.False(t, d.Allowed)
+		assert.Contains(t, d.Message, "quota")
+	})
+
+	t.Run("PeriodsOutsideWindow_Ignored", func(t *testing.T) {
+		t.Parallel()
+		p := quotaPolicy(period("a", now.Add(-48*time.Hour), 2*time.Hour))
+		d, err := Evaluate(ctx, newReader(t, p), Request{Namespace: "shop", Requester: alice, Duration: 30 * time.Minute, Now: now})
+		require.NoError(t, err)
+		assert.True(t, d.Allowed)
+	})
+
+	t.Run("ActiveFreezes_Counted", func(t *testing.T) {
+		t.Parallel()
+		active := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "active", UID: "active"}}
+		active.Status.Phase = freezerv1alpha1.PhaseFrozen
+		active.Status.PhaseTransitionTimes = map[freezerv1alpha1.Phase]metav1.Time{
+			freezerv1alpha1.PhaseFreezing: metav1.NewTime(now.Add(-50 * time.Minute)),
+		}
+		req := Request{Namespace: "shop", Requester: alice, Duration: 30 * time.Minute, Now: now}
+
+		d, err := Evaluate(ctx, newReader(t, quotaPolicy(), active), req)
+		require.NoError(t, err)
+		assert.False(t, d.Allowed)
+
+		req.Self = "active"
+		d, err = Evaluate(ctx, newReader(t, quotaPolicy(), active), req)
+		require.NoError(t, err)
+		assert.True(t, d.Allowed, "a DFZ's own frozen time is not counted")
+	})
+
+	t.Run("RecordUsage_AppendsOncePrunesOld", func(t *testing.T) {
+		t.Parallel()
+		old := period("old", now.Add(-72*time.Hour), time.Hour)
+		c := newReader(t, quotaPolicy(old)).(client.Client)
+		p := period("new", now.Add(-time.Hour), 30*time.Minute)
+
+		require.NoError(t, RecordUsage(ctx, c, "shop", p))
+		require.NoError(t, RecordUsage(ctx, c, "shop", p))
+
+		var got freezerv1alpha1.FreezerPolicy
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "quota"}, &got))
+		require.Len(t, got.Status.Usage, 1)
+		require.Len(t, got.Status.Usage[0].Periods, 1)
+		assert.Equal(t, types.UID("new"), got.Status.Usage[0].Periods[0].UID)
+	})
+
+	t.Run("RecordUsage_SkipsPoliciesWithoutQuota", func(t *testing.T) {
+		t.Parallel()
+		plain := newPolicy("plain", freezerv1alpha1.FreezerPolicyRule{
+			Action: freezerv1alpha1.PolicyActionAllow, Namespaces: []string{"*"},
+		})
+		c := newReader(t, plain).(client.Client)
+		require.NoError(t, RecordUsage(ctx, c, "shop", period("x", now, time.Minute)))
+
+		var got freezerv1alpha1.FreezerPolicy
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "plain"}, &got))
+		assert.Empty(t, got.Status.Usage)
+	})
+}
+
+func TestIsProtected(t *testing.T) {
+	t.Run("KubeSystem_AlwaysProtected", func(t *testing.T) {
+		t.Parallel()
+		assert.True(t, IsProtected("kube-system", nil))
+	})
+
+	t.Run("Configured_Protected", func(t *testing.T) {
+		t.Parallel()
+		assert.True(t, IsProtected("payments", []string{"payments"}))
+		assert.False(t, IsProtected("shop", []string{"payments"}))
+	})
+}
+
+func TestActiveBlackout(t *testing.T) {
+	ctx := context.Background()
+	shop := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop", Labels: map[string]string{"tier": "prod"}}}
+	start := time.Date(2026, 11, 20, 0, 0, 0, 0, time.UTC)
+	blackout := func(name string, from, to time.Time) freezerv1alpha1.BlackoutWindow {
+		return freezerv1alpha1.BlackoutWindow{Name: name, Start: metav1.NewTime(from), End: metav1.NewTime(to)}
+	}
+	withBlackouts := func(name string, blackouts ...freezerv1alpha1.BlackoutWindow) *freezerv1alpha1.FreezerPolicy {
+		p := newPolicy(name)
+		p.Spec.Blackouts = blackouts
+		return p
+	}
+
+	t.Run("ClusterWide_ActiveBetweenStartAndEnd", func(t *testing.T) {
+		t.Parallel()
+		c := newReader(t, withBlackouts("company", blackout("release", start, start.Add(time.Hour))))
+		for _, tc := range []struct {
+			at   time.Time
+			want bool
+		}{
+			{start.Add(-time.Second), false},
+			{start, true},
+			{start.Add(time.Hour - time.Second), true},
+			{start.Add(time.Hour), false},
+		} {
+			b, ok, err := ActiveBlackout(ctx, c, "shop", tc.at)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, ok, tc.at)
+			if ok {
+				assert.Equal(t, "company", b.Policy)
+				assert.Equal(t, "release", b.Name)
+				assert.True(t, b.End.Equal(start.Add(time.Hour)), b.End)
+			}
+		}
+	})
+
+	t.Run("Overlapping_LatestEndWins", func(t *testing.T) {
+		t.Parallel()
+		c := newReader(t,
+			withBlackouts("a", blackout("short", start, start.Add(time.Hour))),
+			withBlackouts("b", blackout("long", start, start.Add(3*time.Hour)), blackout("later", start.Add(5*time.Hour), start.Add(6*time.Hour))),
+		)
+		b, ok, err := ActiveBlackout(ctx, c, "shop", start.Add(30*time.Minute))
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, "long", b.Name)
+	})
+
+	t.Run("NamespaceScoped", func(t *testing.T) {
+		t.Parallel()
+		byName := blackout("payments-only", start, start.Add(time.Hour))
+		byName.Namespaces = []string{"payments"}
+		byLabel := blackout("prod", start, start.Add(time.Hour))
+		byLabel.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "prod"}}
+		c := newReader(t, shop, withBlackouts("p", byName, byLabel))
+
+		b, ok, err := ActiveBlackout(ctx, c, "shop", start)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, "prod", b.Name)
+	})
+
+	t.Run("BlackoutOnlyPolicy_AllowsFreezes", func(t *testing.T) {
+		t.Parallel()
+		c := newReader(t, withBlackouts("company", blackout("release", start, start.Add(time.Hour))))
+		d, err := Evaluate(ctx, c, Request{Namespace: "shop", Now: start})
+		require.NoError(t, err)
+		assert.True(t, d.Allowed)
+	})
+}
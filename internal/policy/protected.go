@@ -0,0 +1,21 @@
+package policy
+
+import (
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const msgProtectedNamespaceFmt = "namespace %s is protected; its Deployments cannot be frozen"
+
+// IsProtected reports whether Deployments in ns must never be frozen. kube-system is always
+// protected; protected lists the namespaces configured on top of it.
+func IsProtected(ns string, protected []string) bool {
+	return ns == metav1.NamespaceSystem || slices.Contains(protected, ns)
+}
+
+// ProtectedMessage explains why a DeploymentFreezer in the protected namespace ns is refused.
+func ProtectedMessage(ns string) string {
+	return fmt.Sprintf(msgProtectedNamespaceFmt, ns)
+}
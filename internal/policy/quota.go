@@ -0,0 +1,167 @@
+package policy
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func quotaWindow(q *freezerv1alpha1.FreezeQuota) time.Duration {
+	if q.WindowSeconds > 0 {
+		return time.Duration(q.WindowSeconds) * time.Second
+	}
+	return freezerv1alpha1.DefaultQuotaWindowSeconds * time.Second
+}
+
+// quotaUsage is the frozen time of one namespace: the freezes still in flight plus,
+// per policy, the finished ones recorded in its status.
+type quotaUsage struct {
+	namespace string
+	now       time.Time
+	active    []freezerv1alpha1.FrozenPeriod
+}
+
+// loadActiveUsage collects the freezes in flight in the request's namespace, up to now.
+func loadActiveUsage(ctx context.Context, c client.Reader, req Request) (*quotaUsage, error) {
+	var dfzs freezerv1alpha1.DeploymentFreezerList
+	if err := c.List(ctx, &dfzs, client.InNamespace(req.Namespace)); err != nil {
+		return nil, err
+	}
+	u := &quotaUsage{namespace: req.Namespace, now: req.Now}
+	for i := range dfzs.Items {
+		dfz := &dfzs.Items[i]
+		if dfz.UID == req.Self {
+			continue
+		}
+		if p, ok := ActivePeriod(dfz, req.Now); ok {
+			u.active = append(u.active, p)
+		}
+	}
+	return u, nil
+}
+
+// frozenWithin sums the frozen time overlapping the window ending now, recorded in p or in flight.
+func (u *quotaUsage) frozenWithin(p *freezerv1alpha1.FreezerPolicy, window time.Duration) time.Duration {
+	from := u.now.Add(-window)
+	var total time.Duration
+	seen := map[types.UID]bool{}
+	for _, ns := range p.Status.Usage {
+		if ns.Namespace != u.namespace {
+			continue
+		}
+		for _, period := range ns.Periods {
+			seen[period.UID] = true
+			total += overlap(period, from, u.now)
+		}
+	}
+	for _, period := range u.active {
+		if !seen[period.UID] {
+			total += overlap(period, from, u.now)
+		}
+	}
+	return total
+}
+
+func overlap(p freezerv1alpha1.FrozenPeriod, from, to time.Time) time.Duration {
+	start, end := p.Start.Time, p.End.Time
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// ActivePeriod returns the period a DeploymentFreezer has kept its Deployment frozen so far,
+// from the start of scaling down until the restore (or now if it is still frozen).
+func ActivePeriod(dfz *freezerv1alpha1.DeploymentFreezer, now time.Time) (freezerv1alpha1.FrozenPeriod, bool) {
+	times := dfz.Status.PhaseTransitionTimes
+	start, ok := times[freezerv1alpha1.PhaseFreezing]
+	if !ok {
+		if start, ok = times[freezerv1alpha1.PhaseFrozen]; !ok {
+			return freezerv1alpha1.FrozenPeriod{}, false
+		}
+	}
+	end := metav1.NewTime(now)
+	if t, ok := times[freezerv1alpha1.PhaseUnfreezing]; ok {
+		end = t
+	}
+	return freezerv1alpha1.FrozenPeriod{Freezer: dfz.Name, UID: dfz.UID, Start: start, End: end}, true
+}
+
+// RecordUsage adds a finished freeze of namespace to every FreezerPolicy with a quota rule
+// covering that namespace, and prunes periods older than the policy's longest quota window.
+// Recording the same DeploymentFreezer twice is a no-op.
+func RecordUsage(ctx context.Context, c client.Client, namespace string, period freezerv1alpha1.FrozenPeriod) error {
+	var policies freezerv1alpha1.FreezerPolicyList
+	if err := c.List(ctx, &policies); err != nil {
+		return err
+	}
+	m := &matcher{reader: c, namespace: namespace}
+	for _, p := range policies.Items {
+		var window time.Duration
+		for _, rule := range p.Spec.Rules {
+			if rule.Quota == nil {
+				continue
+			}
+			ok, err := m.matchesNamespace(ctx, rule.Namespaces, rule.NamespaceSelector)
+			if err != nil {
+				return err
+			}
+			if ok {
+				window = max(window, quotaWindow(rule.Quota))
+			}
+		}
+		if window == 0 {
+			continue
+		}
+		if err := recordPeriod(ctx, c, p.Name, namespace, period, window); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func recordPeriod(
+	ctx context.Context,
+	c client.Client,
+	policyName, namespace string,
+	period freezerv1alpha1.FrozenPeriod,
+	window time.Duration,
+) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var latest freezerv1alpha1.FreezerPolicy
+		if err := c.Get(ctx, types.NamespacedName{Name: policyName}, &latest); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		orig := latest.DeepCopy()
+
+		i := slices.IndexFunc(latest.Status.Usage, func(u freezerv1alpha1.NamespaceUsage) bool {
+			return u.Namespace == namespace
+		})
+		if i < 0 {
+			latest.Status.Usage = append(latest.Status.Usage, freezerv1alpha1.NamespaceUsage{Namespace: namespace})
+			i = len(latest.Status.Usage) - 1
+		}
+		usage := &latest.Status.Usage[i]
+		if slices.ContainsFunc(usage.Periods, func(p freezerv1alpha1.FrozenPeriod) bool { return p.UID == period.UID }) {
+			return nil
+		}
+		cutoff := period.End.Add(-window)
+		usage.Periods = slices.DeleteFunc(usage.Periods, func(p freezerv1alpha1.FrozenPeriod) bool {
+			return p.End.Time.Before(cutoff)
+		})
+		usage.Periods = append(usage.Periods, period)
+		return c.Status().Patch(ctx, &latest, client.MergeFromWithOptions(orig, client.MergeFromWithOptimisticLock{}))
+	})
+}
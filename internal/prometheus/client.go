@@ -0,0 +1,110 @@
+// Package prometheus evaluates instant PromQL queries against the Prometheus HTTP API. It only
+// reads the highest sample of a vector or scalar result, which is all the AutoFreezePolicy
+// metric trigger compares with its threshold.
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client queries one Prometheus server.
+type Client struct {
+	// URL of the server, e.g. http://prometheus.monitoring:9090.
+	URL string
+	// HTTP is the client used for requests; defaults to one with a 10s timeout.
+	HTTP *http.Client
+}
+
+var defaultHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// response is the part of a /api/v1/query response the client reads.
+type response struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// Query evaluates query at the current time and returns the highest sample. It reports false
+// when the result is empty; NaN samples are ignored.
+func (c *Client) Query(ctx context.Context, query string) (float64, bool, error) {
+	u, err := url.Parse(strings.TrimSuffix(c.URL, "/") + "/api/v1/query")
+	if err != nil {
+		return 0, false, err
+	}
+	u.RawQuery = url.Values{"query": {query}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return 0, false, err
+	}
+	httpClient := c.HTTP
+	if httpClient == nil {
+		httpClient = defaultHTTPClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, false, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var body response
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return 0, false, fmt.Errorf("decode response (HTTP %d): %w", resp.StatusCode, err)
+	}
+	if body.Status != "success" {
+		return 0, false, fmt.Errorf("query failed (HTTP %d): %s", resp.StatusCode, body.Error)
+	}
+	return maxSample(body.Data.ResultType, body.Data.Result)
+}
+
+// maxSample returns the highest value of a vector or scalar result.
+func maxSample(resultType string, result json.RawMessage) (float64, bool, error) {
+	var samples [][2]any
+	switch resultType {
+	case "vector":
+		var vector []struct {
+			Value [2]any `json:"value"`
+		}
+		if err := json.Unmarshal(result, &vector); err != nil {
+			return 0, false, err
+		}
+		for _, s := range vector {
+			samples = append(samples, s.Value)
+		}
+	case "scalar":
+		var scalar [2]any
+		if err := json.Unmarshal(result, &scalar); err != nil {
+			return 0, false, err
+		}
+		samples = append(samples, scalar)
+	default:
+		return 0, false, fmt.Errorf("unsupported result type %q; the query must return an instant vector or scalar", resultType)
+	}
+
+	highest, found := math.Inf(-1), false
+	for _, s := range samples {
+		raw, ok := s[1].(string)
+		if !ok {
+			return 0, false, fmt.Errorf("unexpected sample value %v", s[1])
+		}
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return 0, false, err
+		}
+		if math.IsNaN(v) {
+			continue
+		}
+		highest, found = max(highest, v), true
+	}
+	return highest, found, nil
+}
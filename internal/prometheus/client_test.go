@@ -0,0 +1,69 @@
+package prometheus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery(t *testing.T) {
+	serve := func(t *testing.T, status int, body string) *Client {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v1/query", r.URL.Path)
+			assert.Equal(t, `up{job="web"}`, r.URL.Query().Get("query"))
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		}))
+		t.Cleanup(srv.Close)
+		return &Client{URL: srv.URL + "/"}
+	}
+
+	t.Run("Vector_HighestSample", func(t *testing.T) {
+		t.Parallel()
+		c := serve(t, http.StatusOK, `{"status":"success","data":{"resultType":"vector","result":[
+			{"metric":{"pod":"a"},"value":[1700000000,"0.5"]},
+			{"metric":{"pod":"b"},"value":[1700000000,"NaN"]},
+			{"metric":{"pod":"c"},"value":[1700000000,"2.25"]}]}}`)
+		v, ok, err := c.Query(context.Background(), `up{job="web"}`)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.InDelta(t, 2.25, v, 1e-9)
+	})
+
+	t.Run("Scalar", func(t *testing.T) {
+		t.Parallel()
+		c := serve(t, http.StatusOK, `{"status":"success","data":{"resultType":"scalar","result":[1700000000,"-1"]}}`)
+		v, ok, err := c.Query(context.Background(), `up{job="web"}`)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.InDelta(t, -1.0, v, 1e-9)
+	})
+
+	t.Run("EmptyVector_NoResult", func(t *testing.T) {
+		t.Parallel()
+		c := serve(t, http.StatusOK, `{"status":"success","data":{"resultType":"vector","result":[]}}`)
+		_, ok, err := c.Query(context.Background(), `up{job="web"}`)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+		for _, tc := range []struct {
+			status int
+			body   string
+			want   string
+		}{
+			{http.StatusBadRequest, `{"status":"error","error":"parse error"}`, "parse error"},
+			{http.StatusOK, `{"status":"success","data":{"resultType":"matrix","result":[]}}`, "instant vector or scalar"},
+			{http.StatusBadGateway, `<html>`, "HTTP 502"},
+		} {
+			_, _, err := serve(t, tc.status, tc.body).Query(context.Background(), `up{job="web"}`)
+			assert.ErrorContains(t, err, tc.want)
+		}
+	})
+}
@@ -0,0 +1,133 @@
+// Package schedule evaluates recurring weekly time windows, such as the unfreeze window of a
+// DeploymentFreezer. Windows are defined in wall-clock time of an IANA time zone, so a window
+// from 09:00 to 17:00 keeps those local hours across daylight saving changes.
+package schedule
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+)
+
+// Window is a window that opens at Start on each of Days and closes at End. A window whose End
+// is not after its Start closes on the following day.
+type Window struct {
+	// Days the window opens on; empty means every day.
+	Days []time.Weekday
+	// Start and End are times of day, as offsets from midnight.
+	Start, End time.Duration
+	// Location the days and times are read in.
+	Location *time.Location
+}
+
+// weekdays maps the API day names to time.Weekday.
+var weekdays = map[freezerv1alpha1.Weekday]time.Weekday{
+	"Sunday": time.Sunday, "Monday": time.Monday, "Tuesday": time.Tuesday, "Wednesday": time.Wednesday,
+	"Thursday": time.Thursday, "Friday": time.Friday, "Saturday": time.Saturday,
+}
+
+// LoadLocation returns the IANA time zone name, UTC when name is empty.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	return loc, nil
+}
+
+// FromUnfreezeWindow converts the API window.
+func FromUnfreezeWindow(w *freezerv1alpha1.UnfreezeWindow) (Window, error) {
+	loc, err := LoadLocation(w.TimeZone)
+	if err != nil {
+		return Window{}, err
+	}
+	start, err := parseClock(w.Start)
+	if err != nil {
+		return Window{}, fmt.Errorf("start: %w", err)
+	}
+	end, err := parseClock(w.End)
+	if err != nil {
+		return Window{}, fmt.Errorf("end: %w", err)
+	}
+	if start == end {
+		return Window{}, fmt.Errorf("start and end are both %s", w.Start)
+	}
+	days := make([]time.Weekday, 0, len(w.Days))
+	for _, d := range w.Days {
+		day, ok := weekdays[d]
+		if !ok {
+			return Window{}, fmt.Errorf("unknown day %q", d)
+		}
+		days = append(days, day)
+	}
+	return Window{Days: days, Start: start, End: end, Location: loc}, nil
+}
+
+// parseClock parses HH:MM into an offset from midnight.
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a time of day as HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Contains reports whether the window is open at t.
+func (w Window) Contains(t time.Time) bool {
+	local := t.In(w.Location)
+	h, m, s := local.Clock()
+	clock := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+	if w.Start < w.End {
+		return w.opensOn(local.Weekday()) && clock >= w.Start && clock < w.End
+	}
+	// Overnight: open from Start until midnight, and from midnight until End when it opened the day before.
+	return (w.opensOn(local.Weekday()) && clock >= w.Start) ||
+		(w.opensOn(local.AddDate(0, 0, -1).Weekday()) && clock < w.End)
+}
+
+// Next returns t if the window is open at t, and otherwise the time it next opens.
+func (w Window) Next(t time.Time) time.Time {
+	if w.Contains(t) {
+		return t
+	}
+	local := t.In(w.Location)
+	for day := range 8 {
+		d := local.AddDate(0, 0, day)
+		// Built from the wall clock, so a daylight saving change on that day does not shift it.
+		open := time.Date(d.Year(), d.Month(), d.Day(), int(w.Start.Hours()), int(w.Start.Minutes())%60, 0, 0, w.Location)
+		if w.opensOn(d.Weekday()) && open.After(t) {
+			return open
+		}
+	}
+	// Unreachable for a valid window: every day of the week is checked.
+	return t
+}
+
+// String describes the window, such as "Mon,Tue,Wed,Thu,Fri 09:00-17:00 Europe/Berlin".
+func (w Window) String() string {
+	days := "daily"
+	if len(w.Days) > 0 {
+		names := make([]string, 0, len(w.Days))
+		sorted := slices.Clone(w.Days)
+		slices.Sort(sorted)
+		for _, d := range sorted {
+			names = append(names, d.String()[:3])
+		}
+		days = strings.Join(names, ",")
+	}
+	return fmt.Sprintf("%s %s-%s %s", days, formatClock(w.Start), formatClock(w.End), w.Location)
+}
+
+func (w Window) opensOn(day time.Weekday) bool {
+	return len(w.Days) == 0 || slices.Contains(w.Days, day)
+}
+
+func formatClock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
@@ -0,0 +1,80 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindow(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	businessHours := func(t *testing.T) Window {
+		w, err := FromUnfreezeWindow(&freezerv1alpha1.UnfreezeWindow{
+			Days:     []freezerv1alpha1.Weekday{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
+			Start:    "09:00",
+			End:      "17:00",
+			TimeZone: "Europe/Berlin",
+		})
+		require.NoError(t, err)
+		return w
+	}
+
+	t.Run("Contains_LocalHoursAndDays", func(t *testing.T) {
+		t.Parallel()
+		w := businessHours(t)
+		assert.True(t, w.Contains(time.Date(2026, 3, 3, 9, 0, 0, 0, berlin)))
+		assert.True(t, w.Contains(time.Date(2026, 3, 3, 16, 59, 59, 0, berlin)))
+		assert.False(t, w.Contains(time.Date(2026, 3, 3, 17, 0, 0, 0, berlin)))
+		// 09:30 UTC is 10:30 in Berlin.
+		assert.True(t, w.Contains(time.Date(2026, 3, 3, 9, 30, 0, 0, time.UTC)))
+		// A Saturday.
+		assert.False(t, w.Contains(time.Date(2026, 3, 7, 12, 0, 0, 0, berlin)))
+	})
+
+	t.Run("Next_SkipsWeekendAcrossDSTChange", func(t *testing.T) {
+		t.Parallel()
+		w := businessHours(t)
+		// Friday evening before clocks go forward on Sunday, 29 March 2026.
+		next := w.Next(time.Date(2026, 3, 27, 18, 0, 0, 0, berlin))
+		assert.Equal(t, time.Date(2026, 3, 30, 9, 0, 0, 0, berlin), next)
+		assert.Equal(t, time.Date(2026, 3, 30, 7, 0, 0, 0, time.UTC), next.UTC())
+	})
+
+	t.Run("Next_OpenReturnsNow", func(t *testing.T) {
+		t.Parallel()
+		w := businessHours(t)
+		now := time.Date(2026, 3, 3, 10, 0, 0, 0, berlin)
+		assert.Equal(t, now, w.Next(now))
+	})
+
+	t.Run("Overnight_ClosesNextDay", func(t *testing.T) {
+		t.Parallel()
+		w, err := FromUnfreezeWindow(&freezerv1alpha1.UnfreezeWindow{
+			Days: []freezerv1alpha1.Weekday{"Friday"}, Start: "22:00", End: "02:00",
+		})
+		require.NoError(t, err)
+		assert.True(t, w.Contains(time.Date(2026, 3, 6, 23, 0, 0, 0, time.UTC)))
+		assert.True(t, w.Contains(time.Date(2026, 3, 7, 1, 0, 0, 0, time.UTC)))
+		assert.False(t, w.Contains(time.Date(2026, 3, 7, 3, 0, 0, 0, time.UTC)))
+		assert.False(t, w.Contains(time.Date(2026, 3, 6, 1, 0, 0, 0, time.UTC)))
+		assert.Equal(t, time.Date(2026, 3, 13, 22, 0, 0, 0, time.UTC), w.Next(time.Date(2026, 3, 7, 3, 0, 0, 0, time.UTC)))
+		assert.Equal(t, "Fri 22:00-02:00 UTC", w.String())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+		for _, w := range []freezerv1alpha1.UnfreezeWindow{
+			{Start: "09:00", End: "17:00", TimeZone: "Mars/Olympus_Mons"},
+			{Start: "09:00", End: "09:00"},
+			{Start: "9am", End: "17:00"},
+			{Start: "09:00", End: "17:00", Days: []freezerv1alpha1.Weekday{"Someday"}},
+		} {
+			_, err := FromUnfreezeWindow(&w)
+			assert.Error(t, err, w)
+		}
+	})
+}
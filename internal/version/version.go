@@ -0,0 +1,55 @@
+// Package version identifies the build of the controller, so that its metrics, the status it
+// writes and its audit records can be matched to a rollout of the operator.
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Set at build time with -ldflags "-X <package>.Version=v1.2.3 -X <package>.GitCommit=<sha>";
+// the Makefile and the Dockerfile do so from git.
+var (
+	Version   = "dev"
+	GitCommit = ""
+)
+
+// shortCommit is the length of the commit hash in String.
+const shortCommit = 7
+
+// Info describes a build.
+type Info struct {
+	Version   string
+	GitCommit string
+	GoVersion string
+}
+
+// Get returns the build of the running binary. Without a commit set at link time, it falls
+// back to the VCS revision the Go toolchain stamped, and to "unknown" outside a checkout.
+func Get() Info {
+	info := Info{Version: Version, GitCommit: GitCommit, GoVersion: runtime.Version()}
+	if info.GitCommit == "" {
+		info.GitCommit = vcsRevision()
+	}
+	return info
+}
+
+// String formats the build as "<version> (<short commit>)", the form stamped into status.
+func (i Info) String() string {
+	commit := i.GitCommit
+	if len(commit) > shortCommit {
+		commit = commit[:shortCommit]
+	}
+	return i.Version + " (" + commit + ")"
+}
+
+func vcsRevision() string {
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range bi.Settings {
+			if s.Key == "vcs.revision" {
+				return s.Value
+			}
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,29 @@
+package version
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersion(t *testing.T) {
+	t.Run("String_ShortCommit", func(t *testing.T) {
+		assert.Equal(t, "v1.2.3 (3f2a9c1)", Info{Version: "v1.2.3", GitCommit: "3f2a9c1d0e5b"}.String())
+		assert.Equal(t, "dev (unknown)", Info{Version: "dev", GitCommit: "unknown"}.String())
+	})
+
+	t.Run("Get_LinkedCommitWins", func(t *testing.T) {
+		defer func(v, c string) { Version, GitCommit = v, c }(Version, GitCommit)
+		Version, GitCommit = "v1.2.3", "3f2a9c1d0e5b"
+
+		assert.Equal(t, Info{Version: "v1.2.3", GitCommit: "3f2a9c1d0e5b", GoVersion: runtime.Version()}, Get())
+	})
+
+	t.Run("Get_CommitFallsBack", func(t *testing.T) {
+		defer func(c string) { GitCommit = c }(GitCommit)
+		GitCommit = ""
+
+		assert.NotEmpty(t, Get().GitCommit)
+	})
+}
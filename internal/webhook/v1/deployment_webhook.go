@@ -0,0 +1,98 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
+)
+
+// annoRestartedAt is set on the pod template by `kubectl rollout restart`.
+const annoRestartedAt = "kubectl.kubernetes.io/restartedAt"
+
+// errRestartFrozenFmt is returned for a rollout restart of a frozen Deployment.
+const errRestartFrozenFmt = "Deployment is frozen by %s; rollout restart has no effect at zero replicas " +
+	"and is refused until it is unfrozen"
+
+var deploymentlog = logf.Log.WithName("deployment-resource")
+
+// SetupDeploymentWebhookWithManager registers the webhook refusing rollout restarts of frozen
+// Deployments in the manager.
+func SetupDeploymentWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&appsv1.Deployment{}).
+		WithValidator(&DeploymentCustomValidator{}).
+		Complete()
+}
+
+// The webhook fails open: it only runs with --block-rollout-restart, and otherwise the API server
+// finds no handler and admits the request.
+// +kubebuilder:webhook:path=/validate-apps-v1-deployment,mutating=false,failurePolicy=ignore,sideEffects=None,groups=apps,resources=deployments,verbs=update,versions=v1,name=vdeployment-v1.kb.io,admissionReviewVersions=v1
+
+// DeploymentCustomValidator refuses `kubectl rollout restart` of a Deployment carrying the
+// frozen-by annotation. Other changes are left to the controller's drift detection.
+type DeploymentCustomValidator struct{}
+
+var _ webhook.CustomValidator = &DeploymentCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator; creations are never refused.
+func (v *DeploymentCustomValidator) ValidateCreate(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateUpdate refuses a change of the restartedAt template annotation while the Deployment is
+// frozen. An update that also releases the Deployment is let through.
+func (v *DeploymentCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	deploy, ok := newObj.(*appsv1.Deployment)
+	if !ok {
+		return nil, fmt.Errorf("expected a Deployment object for the newObj but got %T", newObj)
+	}
+	old, ok := oldObj.(*appsv1.Deployment)
+	if !ok {
+		return nil, fmt.Errorf("expected a Deployment object for the oldObj but got %T", oldObj)
+	}
+
+	owner := old.Annotations[freeze.AnnotationFrozenBy]
+	if owner == "" || deploy.Annotations[freeze.AnnotationFrozenBy] != owner {
+		return nil, nil
+	}
+	restartedAt := deploy.Spec.Template.Annotations[annoRestartedAt]
+	if restartedAt == "" || restartedAt == old.Spec.Template.Annotations[annoRestartedAt] {
+		return nil, nil
+	}
+	deploymentlog.Info("Refusing rollout restart of a frozen Deployment",
+		"namespace", deploy.Namespace, "name", deploy.Name, "frozenBy", owner)
+	return nil, apierrors.NewForbidden(
+		appsv1.Resource("deployments"),
+		deploy.Name,
+		fmt.Errorf(errRestartFrozenFmt, owner),
+	)
+}
+
+// ValidateDelete implements webhook.CustomValidator; deletions are never refused.
+func (v *DeploymentCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
+)
+
+var _ = Describe("Deployment Webhook", func() {
+	var (
+		ctx       context.Context
+		validator *DeploymentCustomValidator
+		old       *appsv1.Deployment
+	)
+
+	restarted := func(d *appsv1.Deployment, at string) *appsv1.Deployment {
+		d = d.DeepCopy()
+		d.Spec.Template.Annotations = map[string]string{annoRestartedAt: at}
+		return d
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		validator = &DeploymentCustomValidator{}
+		old = &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "shop",
+			Name:        "web",
+			Annotations: map[string]string{freeze.AnnotationFrozenBy: "shop/web-freeze/uid-1"},
+		}}
+	})
+
+	It("Should refuse a rollout restart of a frozen Deployment", func() {
+		_, err := validator.ValidateUpdate(ctx, old, restarted(old, "2025-01-01T00:00:00Z"))
+		Expect(apierrors.IsForbidden(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("frozen by shop/web-freeze/uid-1"))
+	})
+
+	It("Should admit other changes of a frozen Deployment", func() {
+		withRestart := restarted(old, "2025-01-01T00:00:00Z")
+		changed := withRestart.DeepCopy()
+		changed.Labels = map[string]string{"team": "shop"}
+		_, err := validator.ValidateUpdate(ctx, withRestart, changed)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Should admit a rollout restart of a Deployment that is not frozen", func() {
+		old.Annotations = nil
+		_, err := validator.ValidateUpdate(ctx, old, restarted(old, "2025-01-01T00:00:00Z"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Should admit a restart sent together with the release", func() {
+		released := restarted(old, "2025-01-01T00:00:00Z")
+		released.Annotations = nil
+		_, err := validator.ValidateUpdate(ctx, old, released)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,407 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	appsv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/internal/activator"
+	"github.com/boolfixer/deployment-freezer/internal/policy"
+	"github.com/boolfixer/deployment-freezer/internal/schedule"
+)
+
+// Metadata set on objects managed by GitOps tools.
+const (
+	annoArgoCDTrackingID = "argocd.argoproj.io/tracking-id"
+	labelArgoCDInstance  = "argocd.argoproj.io/instance"
+	labelFluxKustomize   = "kustomize.toolkit.fluxcd.io/name"
+	labelFluxHelmRelease = "helm.toolkit.fluxcd.io/name"
+)
+
+// Admission warnings returned for specs that are valid but likely to surprise the user.
+const (
+	warnTargetNotFoundFmt = "target Deployment %q does not exist; the freeze will be Aborted unless it is created first"
+	warnHPAFmt            = "target Deployment %q is scaled by HorizontalPodAutoscaler %q, which may scale it back up while frozen"
+	warnGitOpsFmt         = "target Deployment %q is managed by %s, which may revert the scale-down while frozen"
+	warnNotSelectedFmt    = "target Deployment %q does not match the controller's Deployment selector %q; the freeze waits until it is labeled"
+	warnSelectorFmt       = "spec.targetRef.selector %q matches %d Deployments instead of one; the freeze will be Denied"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var deploymentfreezerlog = logf.Log.WithName("deploymentfreezer-resource")
+
+// SetupDeploymentFreezerWebhookWithManager registers the webhook for DeploymentFreezer in the manager.
+// defaultDuration fills an unset spec.durationSeconds; maxDuration (0 means unlimited) caps it.
+// deploymentSelector is the controller's Deployment cache selector, nil when it caches all of them.
+// controllerUsername is the controller's own user, empty if it is not known.
+func SetupDeploymentFreezerWebhookWithManager(
+	mgr ctrl.Manager,
+	defaultDuration, maxDuration time.Duration,
+	protectedNamespaces []string,
+	deploymentSelector labels.Selector,
+	controllerUsername string,
+) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&appsv1alpha1.DeploymentFreezer{}).
+		WithValidator(&DeploymentFreezerCustomValidator{
+			Reader:              mgr.GetAPIReader(),
+			MaxDuration:         maxDuration,
+			ProtectedNamespaces: protectedNamespaces,
+			DeploymentSelector:  deploymentSelector,
+		}).
+		WithDefaulter(&DeploymentFreezerCustomDefaulter{
+			DefaultDuration:    defaultDuration,
+			ControllerUsername: controllerUsername,
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-apps-boolfixer-dev-v1alpha1-deploymentfreezer,mutating=true,failurePolicy=fail,sideEffects=None,groups=apps.boolfixer.dev,resources=deploymentfreezers,verbs=create;update,versions=v1alpha1,name=mdeploymentfreezer-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// DeploymentFreezerCustomDefaulter struct is responsible for setting default values on the custom resource of the
+// Kind DeploymentFreezer when those are created or updated.
+type DeploymentFreezerCustomDefaulter struct {
+	// DefaultDuration is written to spec.duration and spec.durationSeconds when neither is set.
+	DefaultDuration time.Duration
+	// ControllerUsername is the controller's own user. DeploymentFreezers it creates keep the
+	// requester they carry, such as the creator of the NodeFreeze they belong to.
+	ControllerUsername string
+}
+
+var _ webhook.CustomDefaulter = &DeploymentFreezerCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind DeploymentFreezer.
+func (d *DeploymentFreezerCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	deploymentfreezer, ok := obj.(*appsv1alpha1.DeploymentFreezer)
+	if !ok {
+		return fmt.Errorf("expected a DeploymentFreezer object but got %T", obj)
+	}
+	deploymentfreezerlog.Info("Defaulting for DeploymentFreezer", "name", deploymentfreezer.GetName())
+
+	req, reqErr := admission.RequestFromContext(ctx)
+	var old *appsv1alpha1.DeploymentFreezerSpec
+	if reqErr == nil && req.Operation == admissionv1.Update && len(req.OldObject.Raw) > 0 {
+		var stored appsv1alpha1.DeploymentFreezer
+		if err := json.Unmarshal(req.OldObject.Raw, &stored); err != nil {
+			return fmt.Errorf("decoding the stored DeploymentFreezer: %w", err)
+		}
+		old = &stored.Spec
+	}
+	deploymentfreezer.Spec.SyncDurations(old)
+	if deploymentfreezer.Spec.RequestedDuration() == 0 && d.DefaultDuration > 0 {
+		deploymentfreezer.Spec.DurationSeconds = int64(d.DefaultDuration / time.Second)
+		deploymentfreezer.Spec.SyncDurations(nil)
+	}
+
+	// Record the creator for FreezerPolicy subject rules; any user-supplied value is overwritten,
+	// except on DeploymentFreezers the controller creates on behalf of someone else.
+	if reqErr == nil && req.Operation == admissionv1.Create {
+		recordRequester(req, deploymentfreezer, d.ControllerUsername)
+	}
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-apps-boolfixer-dev-v1alpha1-deploymentfreezer,mutating=false,failurePolicy=fail,sideEffects=None,groups=apps.boolfixer.dev,resources=deploymentfreezers,verbs=create;update,versions=v1alpha1,name=vdeploymentfreezer-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=list
+// +kubebuilder:rbac:groups=apps.boolfixer.dev,resources=freezerpolicies,verbs=list
+// +kubebuilder:rbac:groups=apps.boolfixer.dev,resources=deploymentfreezers,verbs=list
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
+
+// DeploymentFreezerCustomValidator struct is responsible for validating the DeploymentFreezer resource
+// when it is created, updated, or deleted.
+//
+// It enforces FreezerPolicies and the maximum duration when the spec is created or changed,
+// and returns warnings about the target so `kubectl apply` surfaces the caveats immediately.
+type DeploymentFreezerCustomValidator struct {
+	// Reader is used to look up FreezerPolicies, the target Deployment and its HPAs.
+	// It reads straight from the API server so no extra informers are started.
+	Reader client.Reader
+	// MaxDuration rejects longer freeze windows; 0 means unlimited.
+	MaxDuration time.Duration
+	// ProtectedNamespaces are refused in addition to kube-system.
+	ProtectedNamespaces []string
+	// DeploymentSelector is the controller's Deployment cache selector; targets outside it get a warning.
+	DeploymentSelector labels.Selector
+}
+
+var _ webhook.CustomValidator = &DeploymentFreezerCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type DeploymentFreezer.
+func (v *DeploymentFreezerCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	deploymentfreezer, ok := obj.(*appsv1alpha1.DeploymentFreezer)
+	if !ok {
+		return nil, fmt.Errorf("expected a DeploymentFreezer object but got %T", obj)
+	}
+	deploymentfreezerlog.Info("Validation for DeploymentFreezer upon creation", "name", deploymentfreezer.GetName())
+
+	if err := v.validate(ctx, nil, deploymentfreezer); err != nil {
+		return nil, err
+	}
+	return v.warnings(ctx, deploymentfreezer)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type DeploymentFreezer.
+func (v *DeploymentFreezerCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	deploymentfreezer, ok := newObj.(*appsv1alpha1.DeploymentFreezer)
+	if !ok {
+		return nil, fmt.Errorf("expected a DeploymentFreezer object for the newObj but got %T", newObj)
+	}
+	old, ok := oldObj.(*appsv1alpha1.DeploymentFreezer)
+	if !ok {
+		return nil, fmt.Errorf("expected a DeploymentFreezer object for the oldObj but got %T", oldObj)
+	}
+	deploymentfreezerlog.Info("Validation for DeploymentFreezer upon update", "name", deploymentfreezer.GetName())
+
+	if err := v.validate(ctx, old, deploymentfreezer); err != nil {
+		return nil, err
+	}
+	return v.warnings(ctx, deploymentfreezer)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type DeploymentFreezer.
+func (v *DeploymentFreezerCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	if _, ok := obj.(*appsv1alpha1.DeploymentFreezer); !ok {
+		return nil, fmt.Errorf("expected a DeploymentFreezer object but got %T", obj)
+	}
+	return nil, nil
+}
+
+// validate enforces FreezerPolicies and the controller-wide guardrails. old is nil on create.
+// Updates that leave the spec alone (e.g. the controller's finalizer changes) are not re-checked.
+func (v *DeploymentFreezerCustomValidator) validate(ctx context.Context, old, dfz *appsv1alpha1.DeploymentFreezer) error {
+	var allErrs field.ErrorList
+	if old != nil {
+		allErrs = append(allErrs, requesterChanges(old, dfz)...)
+		if equality.Semantic.DeepEqual(old.Spec, dfz.Spec) {
+			return v.invalid(dfz, allErrs)
+		}
+	}
+
+	// A requested exemption is checked by the policies below.
+	if policy.IsProtected(dfz.Namespace, v.ProtectedNamespaces) &&
+		!slices.Contains(dfz.Spec.Exemptions, appsv1alpha1.ExemptionProtectedNamespace) {
+		return apierrors.NewForbidden(
+			schema.GroupResource{Group: appsv1alpha1.GroupVersion.Group, Resource: "deploymentfreezers"},
+			dfz.Name,
+			errors.New(policy.ProtectedMessage(dfz.Namespace)),
+		)
+	}
+
+	requested := dfz.Spec.RequestedDuration()
+	decision, err := policy.Evaluate(ctx, v.Reader, policy.Request{
+		Namespace:  dfz.Namespace,
+		Requester:  policy.RequesterFromAnnotations(dfz),
+		Duration:   requested,
+		Self:       dfz.UID,
+		Now:        time.Now(),
+		Exemptions: dfz.Spec.Exemptions,
+	})
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	if !decision.Allowed {
+		return apierrors.NewForbidden(
+			schema.GroupResource{Group: appsv1alpha1.GroupVersion.Group, Resource: "deploymentfreezers"},
+			dfz.Name,
+			errors.New(decision.Message),
+		)
+	}
+
+	maxDuration := policy.Strictest(v.MaxDuration, decision.MaxDuration)
+	if policy.Exempted(decision.Exemptions, appsv1alpha1.ExemptionMaxDuration) {
+		maxDuration = decision.MaxDuration
+	}
+	if maxDuration > 0 && requested > maxDuration {
+		path, value := field.NewPath("spec", "durationSeconds"), any(dfz.Spec.DurationSeconds)
+		if dfz.Spec.Duration != nil {
+			path, value = field.NewPath("spec", "duration"), dfz.Spec.Duration.Duration.String()
+		}
+		allErrs = append(allErrs, field.Invalid(
+			path,
+			value,
+			fmt.Sprintf("must not exceed the maximum duration of %s", maxDuration),
+		))
+	}
+	if sel := dfz.Spec.TargetRef.Selector; sel != nil {
+		if _, err := metav1.LabelSelectorAsSelector(sel); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "targetRef", "selector"), sel, err.Error()))
+		}
+	}
+	if w := dfz.Spec.UnfreezeWindow; w != nil {
+		if _, err := schedule.FromUnfreezeWindow(w); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "unfreezeWindow"), *w, err.Error()))
+		}
+	}
+	hostErrs, err := v.claimedHosts(ctx, dfz)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	allErrs = append(allErrs, hostErrs...)
+	return v.invalid(dfz, allErrs)
+}
+
+// claimedHosts refuses wake-on-request hosts another unfinished DeploymentFreezer already wakes
+// on, so a request only ever wakes the Deployment of the namespace that claimed its host.
+func (v *DeploymentFreezerCustomValidator) claimedHosts(
+	ctx context.Context,
+	dfz *appsv1alpha1.DeploymentFreezer,
+) (field.ErrorList, error) {
+	if dfz.Spec.WakeOnRequest == nil || len(dfz.Spec.WakeOnRequest.Hosts) == 0 {
+		return nil, nil
+	}
+	var list appsv1alpha1.DeploymentFreezerList
+	if err := v.Reader.List(ctx, &list); err != nil {
+		return nil, err
+	}
+	var allErrs field.ErrorList
+	for i, host := range dfz.Spec.WakeOnRequest.Hosts {
+		for j := range list.Items {
+			other := &list.Items[j]
+			if other.Namespace == dfz.Namespace && other.Name == dfz.Name {
+				continue
+			}
+			switch other.Status.Phase {
+			case appsv1alpha1.PhaseCompleted, appsv1alpha1.PhaseDenied, appsv1alpha1.PhaseAborted:
+				continue
+			}
+			if activator.WakesOn(other, host) {
+				allErrs = append(allErrs, field.Forbidden(
+					field.NewPath("spec", "wakeOnRequest", "hosts").Index(i),
+					fmt.Sprintf("%s is already woken by DeploymentFreezer %s/%s", host, other.Namespace, other.Name),
+				))
+				break
+			}
+		}
+	}
+	return allErrs, nil
+}
+
+// invalid wraps field errors into an Invalid API error, or returns nil when there are none.
+func (v *DeploymentFreezerCustomValidator) invalid(dfz *appsv1alpha1.DeploymentFreezer, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(appsv1alpha1.GroupVersion.WithKind("DeploymentFreezer").GroupKind(), dfz.Name, allErrs)
+}
+
+// warnings inspects the target Deployment. Lookup failures are logged and never block admission.
+// Other target kinds are not inspected.
+func (v *DeploymentFreezerCustomValidator) warnings(
+	ctx context.Context,
+	dfz *appsv1alpha1.DeploymentFreezer,
+) (admission.Warnings, error) {
+	if kind := dfz.Spec.TargetRef.Kind; kind != "" && kind != appsv1alpha1.TargetKindDeployment {
+		return nil, nil
+	}
+	if dfz.Spec.Propagation != nil {
+		// The target lives on the managed clusters, whose webhooks warn about it.
+		return nil, nil
+	}
+	name := dfz.Spec.TargetRef.Name
+	if sel := dfz.Spec.TargetRef.Selector; sel != nil && name == "" {
+		selector, err := metav1.LabelSelectorAsSelector(sel)
+		if err != nil {
+			return nil, nil
+		}
+		var list appsv1.DeploymentList
+		if err := v.Reader.List(
+			ctx,
+			&list,
+			client.InNamespace(dfz.Namespace),
+			client.MatchingLabelsSelector{Selector: selector},
+		); err != nil {
+			deploymentfreezerlog.Error(err, "unable to list Deployments", "namespace", dfz.Namespace)
+			return nil, nil
+		}
+		if len(list.Items) != 1 {
+			return admission.Warnings{fmt.Sprintf(warnSelectorFmt, selector, len(list.Items))}, nil
+		}
+		name = list.Items[0].Name
+	}
+	var deploy appsv1.Deployment
+	if err := v.Reader.Get(ctx, types.NamespacedName{Namespace: dfz.Namespace, Name: name}, &deploy); err != nil {
+		if apierrors.IsNotFound(err) {
+			return admission.Warnings{fmt.Sprintf(warnTargetNotFoundFmt, name)}, nil
+		}
+		deploymentfreezerlog.Error(err, "unable to get target Deployment", "namespace", dfz.Namespace, "name", name)
+		return nil, nil
+	}
+
+	var warnings admission.Warnings
+	if sel := v.DeploymentSelector; sel != nil && !sel.Empty() && !sel.Matches(labels.Set(deploy.Labels)) {
+		warnings = append(warnings, fmt.Sprintf(warnNotSelectedFmt, name, sel.String()))
+	}
+	if hpa, err := v.findHPA(ctx, &deploy); err != nil {
+		deploymentfreezerlog.Error(err, "unable to list HorizontalPodAutoscalers", "namespace", dfz.Namespace)
+	} else if hpa != "" {
+		warnings = append(warnings, fmt.Sprintf(warnHPAFmt, name, hpa))
+	}
+	if tool := gitOpsManager(&deploy); tool != "" {
+		warnings = append(warnings, fmt.Sprintf(warnGitOpsFmt, name, tool))
+	}
+	return warnings, nil
+}
+
+// findHPA returns the name of an HPA scaling the Deployment, or "" if there is none.
+func (v *DeploymentFreezerCustomValidator) findHPA(ctx context.Context, deploy *appsv1.Deployment) (string, error) {
+	var hpas autoscalingv2.HorizontalPodAutoscalerList
+	if err := v.Reader.List(ctx, &hpas, client.InNamespace(deploy.Namespace)); err != nil {
+		return "", err
+	}
+	for _, hpa := range hpas.Items {
+		ref := hpa.Spec.ScaleTargetRef
+		if ref.Kind == "Deployment" && ref.Name == deploy.Name {
+			return hpa.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// gitOpsManager returns the GitOps tool managing the Deployment, or "" if none is detected.
+func gitOpsManager(deploy *appsv1.Deployment) string {
+	switch {
+	case deploy.Annotations[annoArgoCDTrackingID] != "" || deploy.Labels[labelArgoCDInstance] != "":
+		return "Argo CD"
+	case deploy.Labels[labelFluxKustomize] != "" || deploy.Labels[labelFluxHelmRelease] != "":
+		return "Flux"
+	default:
+		return ""
+	}
+}
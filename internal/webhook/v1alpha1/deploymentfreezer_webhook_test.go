@@ -0,0 +1,384 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	appsv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/internal/policy"
+)
+
+var _ = Describe("DeploymentFreezer Webhook", func() {
+	const (
+		ns     = "default"
+		target = "web"
+	)
+
+	var (
+		ctx context.Context
+		obj *appsv1alpha1.DeploymentFreezer
+	)
+
+	newValidator := func(objs ...client.Object) *DeploymentFreezerCustomValidator {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(appsv1alpha1.AddToScheme(scheme)).To(Succeed())
+		return &DeploymentFreezerCustomValidator{
+			Reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+		}
+	}
+
+	makeDeployment := func() *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: target}}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		obj = &appsv1alpha1.DeploymentFreezer{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "dfz"},
+			Spec: appsv1alpha1.DeploymentFreezerSpec{
+				TargetRef:       appsv1alpha1.DeploymentTargetRef{Name: target},
+				DurationSeconds: 60,
+			},
+		}
+	})
+
+	Context("When creating DeploymentFreezer under Defaulting Webhook", func() {
+		It("Should apply the default duration when durationSeconds is unset", func() {
+			obj.Spec.DurationSeconds = 0
+			defaulter := &DeploymentFreezerCustomDefaulter{DefaultDuration: 2 * time.Hour}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.DurationSeconds).To(Equal(int64(7200)))
+			Expect(obj.Spec.Duration).To(Equal(&metav1.Duration{Duration: 2 * time.Hour}))
+		})
+
+		It("Should record the creator from the admission request", func() {
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				UserInfo:  authenticationv1.UserInfo{Username: "alice", Groups: []string{"team-a"}},
+			}}
+			obj.Annotations = map[string]string{policy.AnnoRequestedBy: "mallory"}
+			defaulter := &DeploymentFreezerCustomDefaulter{}
+			Expect(defaulter.Default(admission.NewContextWithRequest(ctx, req), obj)).To(Succeed())
+			Expect(obj.Annotations).To(HaveKeyWithValue(policy.AnnoRequestedBy, "alice"))
+			Expect(obj.Annotations).To(HaveKeyWithValue(policy.AnnoRequestedByGroups, "team-a"))
+		})
+
+		It("Should keep the requester recorded by the controller on its own DeploymentFreezers", func() {
+			controllerReq := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				UserInfo:  authenticationv1.UserInfo{Username: "system:serviceaccount:freezer:manager"},
+			}}
+			policy.Requester{Username: "alice"}.Annotate(obj)
+			defaulter := &DeploymentFreezerCustomDefaulter{ControllerUsername: "system:serviceaccount:freezer:manager"}
+			Expect(defaulter.Default(admission.NewContextWithRequest(ctx, controllerReq), obj)).To(Succeed())
+			Expect(obj.Annotations).To(HaveKeyWithValue(policy.AnnoRequestedBy, "alice"))
+
+			By("overwriting it for anyone else")
+			userReq := controllerReq
+			userReq.UserInfo = authenticationv1.UserInfo{Username: "mallory"}
+			Expect(defaulter.Default(admission.NewContextWithRequest(ctx, userReq), obj)).To(Succeed())
+			Expect(obj.Annotations).To(HaveKeyWithValue(policy.AnnoRequestedBy, "mallory"))
+		})
+
+		It("Should fill durationSeconds from spec.duration", func() {
+			obj.Spec.DurationSeconds = 0
+			obj.Spec.Duration = &metav1.Duration{Duration: 90 * time.Minute}
+			defaulter := &DeploymentFreezerCustomDefaulter{DefaultDuration: 2 * time.Hour}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.DurationSeconds).To(Equal(int64(5400)))
+		})
+
+		It("Should keep an explicit duration", func() {
+			defaulter := &DeploymentFreezerCustomDefaulter{DefaultDuration: 2 * time.Hour}
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.DurationSeconds).To(Equal(int64(60)))
+			Expect(obj.Spec.Duration).To(Equal(&metav1.Duration{Duration: time.Minute}))
+		})
+
+		It("Should carry a durationSeconds change over to spec.duration on update", func() {
+			stored := obj.DeepCopy()
+			stored.Spec.Duration = &metav1.Duration{Duration: time.Minute}
+			raw, err := json.Marshal(stored)
+			Expect(err).NotTo(HaveOccurred())
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				OldObject: runtime.RawExtension{Raw: raw},
+			}}
+			updateCtx := admission.NewContextWithRequest(ctx, req)
+			defaulter := &DeploymentFreezerCustomDefaulter{}
+
+			obj.Spec.Duration = &metav1.Duration{Duration: time.Minute}
+			obj.Spec.DurationSeconds = 120
+			Expect(defaulter.Default(updateCtx, obj)).To(Succeed())
+			Expect(obj.Spec.Duration).To(Equal(&metav1.Duration{Duration: 2 * time.Minute}))
+
+			obj.Spec.Duration = &metav1.Duration{Duration: 5 * time.Minute}
+			obj.Spec.DurationSeconds = 60
+			Expect(defaulter.Default(updateCtx, obj)).To(Succeed())
+			Expect(obj.Spec.DurationSeconds).To(Equal(int64(300)))
+
+			By("refilling a field removed on its own")
+			obj.Spec.Duration = &metav1.Duration{Duration: time.Minute}
+			obj.Spec.DurationSeconds = 0
+			Expect(defaulter.Default(updateCtx, obj)).To(Succeed())
+			Expect(obj.Spec.DurationSeconds).To(Equal(int64(60)))
+		})
+	})
+
+	Context("When creating or updating DeploymentFreezer under Validating Webhook", func() {
+		It("Should admit a plain target without warnings", func() {
+			warnings, err := newValidator(makeDeployment()).ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should deny a duration above the maximum", func() {
+			validator := newValidator(makeDeployment())
+			validator.MaxDuration = 30 * time.Second
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.durationSeconds"))
+
+			validator.MaxDuration = time.Minute
+			_, err = validator.ValidateUpdate(ctx, obj, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny a spec.duration above the maximum", func() {
+			obj.Spec.DurationSeconds = 0
+			obj.Spec.Duration = &metav1.Duration{Duration: 2*time.Hour + 30*time.Minute}
+			validator := newValidator(makeDeployment())
+			validator.MaxDuration = 2 * time.Hour
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring(`spec.duration: Invalid value: "2h30m0s"`))
+		})
+
+		It("Should deny a DeploymentFreezer no FreezerPolicy allows", func() {
+			fzp := &appsv1alpha1.FreezerPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod-only"},
+				Spec: appsv1alpha1.FreezerPolicySpec{Rules: []appsv1alpha1.FreezerPolicyRule{{
+					Action: appsv1alpha1.PolicyActionAllow, Namespaces: []string{"prod"},
+				}}},
+			}
+			_, err := newValidator(makeDeployment(), fzp).ValidateCreate(ctx, obj)
+			Expect(apierrors.IsForbidden(err)).To(BeTrue())
+		})
+
+		It("Should deny DeploymentFreezers in protected namespaces", func() {
+			obj.Namespace = metav1.NamespaceSystem
+			_, err := newValidator().ValidateCreate(ctx, obj)
+			Expect(apierrors.IsForbidden(err)).To(BeTrue())
+
+			obj.Namespace = "payments"
+			validator := newValidator()
+			validator.ProtectedNamespaces = []string{"payments"}
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(apierrors.IsForbidden(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("protected"))
+		})
+
+		It("Should admit exemptions only when a FreezerPolicy grants them", func() {
+			obj.Namespace = "payments"
+			obj.Spec.DurationSeconds = 7200
+			obj.Spec.Exemptions = []appsv1alpha1.Exemption{
+				appsv1alpha1.ExemptionProtectedNamespace, appsv1alpha1.ExemptionMaxDuration,
+			}
+			fzp := &appsv1alpha1.FreezerPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "sre"},
+				Spec: appsv1alpha1.FreezerPolicySpec{Rules: []appsv1alpha1.FreezerPolicyRule{{
+					Action:     appsv1alpha1.PolicyActionAllow,
+					Namespaces: []string{"*"},
+					Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+					Exemptions: obj.Spec.Exemptions,
+				}, {
+					Action: appsv1alpha1.PolicyActionAllow, Namespaces: []string{"*"},
+				}}},
+			}
+			validator := newValidator(fzp)
+			validator.ProtectedNamespaces = []string{"payments"}
+			validator.MaxDuration = time.Hour
+
+			policy.Requester{Username: "bob"}.Annotate(obj)
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(apierrors.IsForbidden(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("ProtectedNamespace exemption"))
+
+			policy.Requester{Username: "alice"}.Annotate(obj)
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should apply the FreezerPolicy maximum duration", func() {
+			fzp := &appsv1alpha1.FreezerPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "short"},
+				Spec: appsv1alpha1.FreezerPolicySpec{Rules: []appsv1alpha1.FreezerPolicyRule{{
+					Action: appsv1alpha1.PolicyActionAllow, Namespaces: []string{"*"}, MaxDurationSeconds: 30,
+				}}},
+			}
+			_, err := newValidator(makeDeployment(), fzp).ValidateCreate(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		})
+
+		It("Should not re-check policies on updates that leave the spec alone", func() {
+			deny := &appsv1alpha1.FreezerPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "deny-all"},
+				Spec: appsv1alpha1.FreezerPolicySpec{Rules: []appsv1alpha1.FreezerPolicyRule{{
+					Action: appsv1alpha1.PolicyActionDeny, Namespaces: []string{"*"},
+				}}},
+			}
+			updated := obj.DeepCopy()
+			updated.Finalizers = nil
+			_, err := newValidator(makeDeployment(), deny).ValidateUpdate(ctx, obj, updated)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should deny changing the recorded creator", func() {
+			obj.Annotations = map[string]string{policy.AnnoRequestedBy: "alice"}
+			updated := obj.DeepCopy()
+			updated.Annotations[policy.AnnoRequestedBy] = "bob"
+			_, err := newValidator(makeDeployment()).ValidateUpdate(ctx, obj, updated)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		})
+
+		It("Should warn when the target Deployment does not exist", func() {
+			warnings, err := newValidator().ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(fmt.Sprintf(warnTargetNotFoundFmt, target)))
+		})
+
+		It("Should warn when spec.targetRef.selector does not match exactly one Deployment", func() {
+			labelled := func(name string) *appsv1.Deployment {
+				return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+					Namespace: ns, Name: name, Labels: map[string]string{"app": "web"},
+				}}
+			}
+			obj.Spec.TargetRef = appsv1alpha1.DeploymentTargetRef{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			}
+			warnings, err := newValidator(labelled(target)).ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+
+			warnings, err = newValidator(labelled(target), labelled("web-canary")).ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(fmt.Sprintf(warnSelectorFmt, "app=web", 2)))
+		})
+
+		It("Should deny an invalid spec.targetRef.selector", func() {
+			obj.Spec.TargetRef = appsv1alpha1.DeploymentTargetRef{
+				Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key: "app", Operator: "Near",
+				}}},
+			}
+			_, err := newValidator().ValidateCreate(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.targetRef.selector"))
+		})
+
+		It("Should deny a wake-on-request host another unfinished DeploymentFreezer claims", func() {
+			claimed := &appsv1alpha1.DeploymentFreezer{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "web"},
+				Spec: appsv1alpha1.DeploymentFreezerSpec{
+					WakeOnRequest: &appsv1alpha1.WakeOnRequest{Hosts: []string{"web.example.com"}},
+				},
+				Status: appsv1alpha1.DeploymentFreezerStatus{Phase: appsv1alpha1.PhaseFrozen},
+			}
+			finished := claimed.DeepCopy()
+			finished.Name = "old"
+			finished.Spec.WakeOnRequest.Hosts = []string{"old.example.com"}
+			finished.Status.Phase = appsv1alpha1.PhaseCompleted
+			v := newValidator(makeDeployment(), claimed, finished)
+
+			obj.Spec.WakeOnRequest = &appsv1alpha1.WakeOnRequest{Hosts: []string{"old.example.com", "web.example.com"}}
+			_, err := v.ValidateCreate(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.wakeOnRequest.hosts[1]"))
+			Expect(err.Error()).NotTo(ContainSubstring("hosts[0]"))
+
+			obj.Spec.WakeOnRequest.Hosts = []string{"old.example.com"}
+			_, err = v.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should warn when the target Deployment is scaled by an HPA", func() {
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "web-hpa"},
+				Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+						APIVersion: "apps/v1", Kind: "Deployment", Name: target,
+					},
+					MaxReplicas: 3,
+				},
+			}
+			warnings, err := newValidator(makeDeployment(), hpa).ValidateUpdate(ctx, obj, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(fmt.Sprintf(warnHPAFmt, target, "web-hpa")))
+		})
+
+		It("Should warn when the target Deployment is managed by Argo CD or Flux", func() {
+			argo := makeDeployment()
+			argo.Annotations = map[string]string{annoArgoCDTrackingID: "app:apps/Deployment:default/web"}
+			warnings, err := newValidator(argo).ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(fmt.Sprintf(warnGitOpsFmt, target, "Argo CD")))
+
+			flux := makeDeployment()
+			flux.Labels = map[string]string{labelFluxKustomize: "apps"}
+			warnings, err = newValidator(flux).ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(fmt.Sprintf(warnGitOpsFmt, target, "Flux")))
+		})
+
+		It("Should warn when the target Deployment is outside the controller's Deployment selector", func() {
+			v := newValidator(makeDeployment())
+			v.DeploymentSelector = labels.SelectorFromSet(labels.Set{"freezable": "true"})
+			warnings, err := v.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(fmt.Sprintf(warnNotSelectedFmt, target, "freezable=true")))
+
+			labeled := makeDeployment()
+			labeled.Labels = map[string]string{"freezable": "true"}
+			v = newValidator(labeled)
+			v.DeploymentSelector = labels.SelectorFromSet(labels.Set{"freezable": "true"})
+			warnings, err = v.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+	})
+})
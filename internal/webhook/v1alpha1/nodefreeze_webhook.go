@@ -0,0 +1,100 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	appsv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+)
+
+var nodefreezelog = logf.Log.WithName("nodefreeze-resource")
+
+// SetupNodeFreezeWebhookWithManager registers the webhook recording the creator of a NodeFreeze
+// in the manager. The controller creates the NodeFreeze's DeploymentFreezers on their behalf.
+func SetupNodeFreezeWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&appsv1alpha1.NodeFreeze{}).
+		WithValidator(&NodeFreezeCustomValidator{}).
+		WithDefaulter(&NodeFreezeCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-apps-boolfixer-dev-v1alpha1-nodefreeze,mutating=true,failurePolicy=fail,sideEffects=None,groups=apps.boolfixer.dev,resources=nodefreezes,verbs=create,versions=v1alpha1,name=mnodefreeze-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// NodeFreezeCustomDefaulter records the creator of a NodeFreeze, which its DeploymentFreezers
+// are created and evaluated against FreezerPolicies for.
+type NodeFreezeCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &NodeFreezeCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter; any user-supplied requester is overwritten.
+func (d *NodeFreezeCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	nf, ok := obj.(*appsv1alpha1.NodeFreeze)
+	if !ok {
+		return fmt.Errorf("expected a NodeFreeze object but got %T", obj)
+	}
+	nodefreezelog.Info("Defaulting for NodeFreeze", "name", nf.GetName())
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil || req.Operation != admissionv1.Create {
+		return nil
+	}
+	recordRequester(req, nf, "")
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-apps-boolfixer-dev-v1alpha1-nodefreeze,mutating=false,failurePolicy=fail,sideEffects=None,groups=apps.boolfixer.dev,resources=nodefreezes,verbs=update,versions=v1alpha1,name=vnodefreeze-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// NodeFreezeCustomValidator keeps the recorded creator of a NodeFreeze from being changed.
+type NodeFreezeCustomValidator struct{}
+
+var _ webhook.CustomValidator = &NodeFreezeCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator; creations are never refused.
+func (v *NodeFreezeCustomValidator) ValidateCreate(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateUpdate refuses changes of the requester annotations.
+func (v *NodeFreezeCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	nf, ok := newObj.(*appsv1alpha1.NodeFreeze)
+	if !ok {
+		return nil, fmt.Errorf("expected a NodeFreeze object for the newObj but got %T", newObj)
+	}
+	old, ok := oldObj.(*appsv1alpha1.NodeFreeze)
+	if !ok {
+		return nil, fmt.Errorf("expected a NodeFreeze object for the oldObj but got %T", oldObj)
+	}
+	if allErrs := requesterChanges(old, nf); len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(appsv1alpha1.GroupVersion.WithKind("NodeFreeze").GroupKind(), nf.Name, allErrs)
+	}
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator; deletions are never refused.
+func (v *NodeFreezeCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	appsv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/internal/policy"
+)
+
+var _ = Describe("NodeFreeze Webhook", func() {
+	var obj *appsv1alpha1.NodeFreeze
+
+	BeforeEach(func() {
+		obj = &appsv1alpha1.NodeFreeze{
+			ObjectMeta: metav1.ObjectMeta{Name: "maint"},
+			Spec:       appsv1alpha1.NodeFreezeSpec{NodeName: "node-a"},
+		}
+	})
+
+	It("Should record the creator from the admission request", func() {
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			UserInfo:  authenticationv1.UserInfo{Username: "alice", Groups: []string{"sre"}},
+		}}
+		obj.Annotations = map[string]string{policy.AnnoRequestedBy: "mallory"}
+		ctx := admission.NewContextWithRequest(context.Background(), req)
+		Expect((&NodeFreezeCustomDefaulter{}).Default(ctx, obj)).To(Succeed())
+		Expect(policy.RequesterFromAnnotations(obj)).To(Equal(policy.Requester{Username: "alice", Groups: []string{"sre"}}))
+	})
+
+	It("Should refuse changing the recorded creator", func() {
+		policy.Requester{Username: "alice"}.Annotate(obj)
+		changed := obj.DeepCopy()
+		policy.Requester{Username: "mallory"}.Annotate(changed)
+		_, err := (&NodeFreezeCustomValidator{}).ValidateUpdate(context.Background(), obj, changed)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+
+		_, err = (&NodeFreezeCustomValidator{}).ValidateUpdate(context.Background(), obj, obj.DeepCopy())
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
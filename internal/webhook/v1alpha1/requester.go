@@ -0,0 +1,49 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/boolfixer/deployment-freezer/internal/policy"
+)
+
+// recordRequester records the user sending req as the requester of obj. A request from delegate,
+// the controller's own user, keeps the requester already recorded on obj: the controller creates
+// objects on behalf of the requester of their parent, which it copies over.
+func recordRequester(req admission.Request, obj metav1.Object, delegate string) {
+	if delegate != "" && req.UserInfo.Username == delegate && obj.GetAnnotations()[policy.AnnoRequestedBy] != "" {
+		return
+	}
+	policy.Requester{Username: req.UserInfo.Username, Groups: req.UserInfo.Groups}.Annotate(obj)
+}
+
+// requesterChanges forbids changing the requester recorded on creation.
+func requesterChanges(old, obj metav1.Object) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, key := range []string{policy.AnnoRequestedBy, policy.AnnoRequestedByGroups} {
+		if old.GetAnnotations()[key] != obj.GetAnnotations()[key] {
+			allErrs = append(allErrs, field.Forbidden(
+				field.NewPath("metadata", "annotations").Key(key),
+				"is set on creation and cannot be changed",
+			))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// AutoFreezePolicySpecApplyConfiguration represents a declarative configuration of the AutoFreezePolicySpec type for use
+// with apply.
+type AutoFreezePolicySpecApplyConfiguration struct {
+	Selector        *v1.LabelSelectorApplyConfiguration `json:"selector,omitempty"`
+	CrashLoop       *CrashLoopTriggerApplyConfiguration `json:"crashLoop,omitempty"`
+	Metric          *MetricTriggerApplyConfiguration    `json:"metric,omitempty"`
+	ForSeconds      *int64                              `json:"forSeconds,omitempty"`
+	DurationSeconds *int64                              `json:"durationSeconds,omitempty"`
+	CooldownSeconds *int64                              `json:"cooldownSeconds,omitempty"`
+}
+
+// AutoFreezePolicySpecApplyConfiguration constructs a declarative configuration of the AutoFreezePolicySpec type for use with
+// apply.
+func AutoFreezePolicySpec() *AutoFreezePolicySpecApplyConfiguration {
+	return &AutoFreezePolicySpecApplyConfiguration{}
+}
+
+// WithSelector sets the Selector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Selector field is set to the value of the last call.
+func (b *AutoFreezePolicySpecApplyConfiguration) WithSelector(value *v1.LabelSelectorApplyConfiguration) *AutoFreezePolicySpecApplyConfiguration {
+	b.Selector = value
+	return b
+}
+
+// WithCrashLoop sets the CrashLoop field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CrashLoop field is set to the value of the last call.
+func (b *AutoFreezePolicySpecApplyConfiguration) WithCrashLoop(value *CrashLoopTriggerApplyConfiguration) *AutoFreezePolicySpecApplyConfiguration {
+	b.CrashLoop = value
+	return b
+}
+
+// WithMetric sets the Metric field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Metric field is set to the value of the last call.
+func (b *AutoFreezePolicySpecApplyConfiguration) WithMetric(value *MetricTriggerApplyConfiguration) *AutoFreezePolicySpecApplyConfiguration {
+	b.Metric = value
+	return b
+}
+
+// WithForSeconds sets the ForSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ForSeconds field is set to the value of the last call.
+func (b *AutoFreezePolicySpecApplyConfiguration) WithForSeconds(value int64) *AutoFreezePolicySpecApplyConfiguration {
+	b.ForSeconds = &value
+	return b
+}
+
+// WithDurationSeconds sets the DurationSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DurationSeconds field is set to the value of the last call.
+func (b *AutoFreezePolicySpecApplyConfiguration) WithDurationSeconds(value int64) *AutoFreezePolicySpecApplyConfiguration {
+	b.DurationSeconds = &value
+	return b
+}
+
+// WithCooldownSeconds sets the CooldownSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CooldownSeconds field is set to the value of the last call.
+func (b *AutoFreezePolicySpecApplyConfiguration) WithCooldownSeconds(value int64) *AutoFreezePolicySpecApplyConfiguration {
+	b.CooldownSeconds = &value
+	return b
+}
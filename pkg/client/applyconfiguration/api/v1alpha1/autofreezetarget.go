@@ -0,0 +1,78 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AutoFreezeTargetApplyConfiguration represents a declarative configuration of the AutoFreezeTarget type for use
+// with apply.
+type AutoFreezeTargetApplyConfiguration struct {
+	Deployment     *string  `json:"deployment,omitempty"`
+	UnhealthySince *v1.Time `json:"unhealthySince,omitempty"`
+	Message        *string  `json:"message,omitempty"`
+	Freezer        *string  `json:"freezer,omitempty"`
+	FrozenAt       *v1.Time `json:"frozenAt,omitempty"`
+}
+
+// AutoFreezeTargetApplyConfiguration constructs a declarative configuration of the AutoFreezeTarget type for use with
+// apply.
+func AutoFreezeTarget() *AutoFreezeTargetApplyConfiguration {
+	return &AutoFreezeTargetApplyConfiguration{}
+}
+
+// WithDeployment sets the Deployment field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Deployment field is set to the value of the last call.
+func (b *AutoFreezeTargetApplyConfiguration) WithDeployment(value string) *AutoFreezeTargetApplyConfiguration {
+	b.Deployment = &value
+	return b
+}
+
+// WithUnhealthySince sets the UnhealthySince field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UnhealthySince field is set to the value of the last call.
+func (b *AutoFreezeTargetApplyConfiguration) WithUnhealthySince(value v1.Time) *AutoFreezeTargetApplyConfiguration {
+	b.UnhealthySince = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *AutoFreezeTargetApplyConfiguration) WithMessage(value string) *AutoFreezeTargetApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// WithFreezer sets the Freezer field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Freezer field is set to the value of the last call.
+func (b *AutoFreezeTargetApplyConfiguration) WithFreezer(value string) *AutoFreezeTargetApplyConfiguration {
+	b.Freezer = &value
+	return b
+}
+
+// WithFrozenAt sets the FrozenAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FrozenAt field is set to the value of the last call.
+func (b *AutoFreezeTargetApplyConfiguration) WithFrozenAt(value v1.Time) *AutoFreezeTargetApplyConfiguration {
+	b.FrozenAt = &value
+	return b
+}
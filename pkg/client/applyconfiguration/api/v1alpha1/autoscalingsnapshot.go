@@ -0,0 +1,65 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// AutoscalingSnapshotApplyConfiguration represents a declarative configuration of the AutoscalingSnapshot type for use
+// with apply.
+type AutoscalingSnapshotApplyConfiguration struct {
+	Paused       *bool                                   `json:"paused,omitempty"`
+	HPA          *HPASnapshotApplyConfiguration          `json:"hpa,omitempty"`
+	ScaledObject *ScaledObjectSnapshotApplyConfiguration `json:"scaledObject,omitempty"`
+	VPA          *VPASnapshotApplyConfiguration          `json:"vpa,omitempty"`
+}
+
+// AutoscalingSnapshotApplyConfiguration constructs a declarative configuration of the AutoscalingSnapshot type for use with
+// apply.
+func AutoscalingSnapshot() *AutoscalingSnapshotApplyConfiguration {
+	return &AutoscalingSnapshotApplyConfiguration{}
+}
+
+// WithPaused sets the Paused field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Paused field is set to the value of the last call.
+func (b *AutoscalingSnapshotApplyConfiguration) WithPaused(value bool) *AutoscalingSnapshotApplyConfiguration {
+	b.Paused = &value
+	return b
+}
+
+// WithHPA sets the HPA field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HPA field is set to the value of the last call.
+func (b *AutoscalingSnapshotApplyConfiguration) WithHPA(value *HPASnapshotApplyConfiguration) *AutoscalingSnapshotApplyConfiguration {
+	b.HPA = value
+	return b
+}
+
+// WithScaledObject sets the ScaledObject field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ScaledObject field is set to the value of the last call.
+func (b *AutoscalingSnapshotApplyConfiguration) WithScaledObject(value *ScaledObjectSnapshotApplyConfiguration) *AutoscalingSnapshotApplyConfiguration {
+	b.ScaledObject = value
+	return b
+}
+
+// WithVPA sets the VPA field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the VPA field is set to the value of the last call.
+func (b *AutoscalingSnapshotApplyConfiguration) WithVPA(value *VPASnapshotApplyConfiguration) *AutoscalingSnapshotApplyConfiguration {
+	b.VPA = value
+	return b
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// BlackoutWindowApplyConfiguration represents a declarative configuration of the BlackoutWindow type for use
+// with apply.
+type BlackoutWindowApplyConfiguration struct {
+	Name              *string                                 `json:"name,omitempty"`
+	Start             *v1.Time                                `json:"start,omitempty"`
+	End               *v1.Time                                `json:"end,omitempty"`
+	Namespaces        []string                                `json:"namespaces,omitempty"`
+	NamespaceSelector *metav1.LabelSelectorApplyConfiguration `json:"namespaceSelector,omitempty"`
+}
+
+// BlackoutWindowApplyConfiguration constructs a declarative configuration of the BlackoutWindow type for use with
+// apply.
+func BlackoutWindow() *BlackoutWindowApplyConfiguration {
+	return &BlackoutWindowApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *BlackoutWindowApplyConfiguration) WithName(value string) *BlackoutWindowApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithStart sets the Start field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Start field is set to the value of the last call.
+func (b *BlackoutWindowApplyConfiguration) WithStart(value v1.Time) *BlackoutWindowApplyConfiguration {
+	b.Start = &value
+	return b
+}
+
+// WithEnd sets the End field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the End field is set to the value of the last call.
+func (b *BlackoutWindowApplyConfiguration) WithEnd(value v1.Time) *BlackoutWindowApplyConfiguration {
+	b.End = &value
+	return b
+}
+
+// WithNamespaces adds the given value to the Namespaces field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Namespaces field.
+func (b *BlackoutWindowApplyConfiguration) WithNamespaces(values ...string) *BlackoutWindowApplyConfiguration {
+	for i := range values {
+		b.Namespaces = append(b.Namespaces, values[i])
+	}
+	return b
+}
+
+// WithNamespaceSelector sets the NamespaceSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamespaceSelector field is set to the value of the last call.
+func (b *BlackoutWindowApplyConfiguration) WithNamespaceSelector(value *metav1.LabelSelectorApplyConfiguration) *BlackoutWindowApplyConfiguration {
+	b.NamespaceSelector = value
+	return b
+}
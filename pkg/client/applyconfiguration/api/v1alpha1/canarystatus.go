@@ -0,0 +1,60 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CanaryStatusApplyConfiguration represents a declarative configuration of the CanaryStatus type for use
+// with apply.
+type CanaryStatusApplyConfiguration struct {
+	StartedAt  *v1.Time `json:"startedAt,omitempty"`
+	ReadySince *v1.Time `json:"readySince,omitempty"`
+	Failed     *bool    `json:"failed,omitempty"`
+}
+
+// CanaryStatusApplyConfiguration constructs a declarative configuration of the CanaryStatus type for use with
+// apply.
+func CanaryStatus() *CanaryStatusApplyConfiguration {
+	return &CanaryStatusApplyConfiguration{}
+}
+
+// WithStartedAt sets the StartedAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StartedAt field is set to the value of the last call.
+func (b *CanaryStatusApplyConfiguration) WithStartedAt(value v1.Time) *CanaryStatusApplyConfiguration {
+	b.StartedAt = &value
+	return b
+}
+
+// WithReadySince sets the ReadySince field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReadySince field is set to the value of the last call.
+func (b *CanaryStatusApplyConfiguration) WithReadySince(value v1.Time) *CanaryStatusApplyConfiguration {
+	b.ReadySince = &value
+	return b
+}
+
+// WithFailed sets the Failed field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Failed field is set to the value of the last call.
+func (b *CanaryStatusApplyConfiguration) WithFailed(value bool) *CanaryStatusApplyConfiguration {
+	b.Failed = &value
+	return b
+}
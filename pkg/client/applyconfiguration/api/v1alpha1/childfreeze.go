@@ -0,0 +1,78 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+)
+
+// ChildFreezeApplyConfiguration represents a declarative configuration of the ChildFreeze type for use
+// with apply.
+type ChildFreezeApplyConfiguration struct {
+	Namespace  *string            `json:"namespace,omitempty"`
+	Name       *string            `json:"name,omitempty"`
+	Deployment *string            `json:"deployment,omitempty"`
+	Phase      *apiv1alpha1.Phase `json:"phase,omitempty"`
+	Message    *string            `json:"message,omitempty"`
+}
+
+// ChildFreezeApplyConfiguration constructs a declarative configuration of the ChildFreeze type for use with
+// apply.
+func ChildFreeze() *ChildFreezeApplyConfiguration {
+	return &ChildFreezeApplyConfiguration{}
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *ChildFreezeApplyConfiguration) WithNamespace(value string) *ChildFreezeApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ChildFreezeApplyConfiguration) WithName(value string) *ChildFreezeApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithDeployment sets the Deployment field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Deployment field is set to the value of the last call.
+func (b *ChildFreezeApplyConfiguration) WithDeployment(value string) *ChildFreezeApplyConfiguration {
+	b.Deployment = &value
+	return b
+}
+
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *ChildFreezeApplyConfiguration) WithPhase(value apiv1alpha1.Phase) *ChildFreezeApplyConfiguration {
+	b.Phase = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *ChildFreezeApplyConfiguration) WithMessage(value string) *ChildFreezeApplyConfiguration {
+	b.Message = &value
+	return b
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+)
+
+// ClusterFreezeApplyConfiguration represents a declarative configuration of the ClusterFreeze type for use
+// with apply.
+type ClusterFreezeApplyConfiguration struct {
+	Cluster *string            `json:"cluster,omitempty"`
+	Phase   *apiv1alpha1.Phase `json:"phase,omitempty"`
+	Message *string            `json:"message,omitempty"`
+}
+
+// ClusterFreezeApplyConfiguration constructs a declarative configuration of the ClusterFreeze type for use with
+// apply.
+func ClusterFreeze() *ClusterFreezeApplyConfiguration {
+	return &ClusterFreezeApplyConfiguration{}
+}
+
+// WithCluster sets the Cluster field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cluster field is set to the value of the last call.
+func (b *ClusterFreezeApplyConfiguration) WithCluster(value string) *ClusterFreezeApplyConfiguration {
+	b.Cluster = &value
+	return b
+}
+
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *ClusterFreezeApplyConfiguration) WithPhase(value apiv1alpha1.Phase) *ClusterFreezeApplyConfiguration {
+	b.Phase = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *ClusterFreezeApplyConfiguration) WithMessage(value string) *ClusterFreezeApplyConfiguration {
+	b.Message = &value
+	return b
+}
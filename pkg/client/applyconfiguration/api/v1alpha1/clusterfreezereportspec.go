@@ -0,0 +1,47 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ClusterFreezeReportSpecApplyConfiguration represents a declarative configuration of the ClusterFreezeReportSpec type for use
+// with apply.
+type ClusterFreezeReportSpecApplyConfiguration struct {
+	UpcomingWindowSeconds *int64 `json:"upcomingWindowSeconds,omitempty"`
+	RecentWindowSeconds   *int64 `json:"recentWindowSeconds,omitempty"`
+}
+
+// ClusterFreezeReportSpecApplyConfiguration constructs a declarative configuration of the ClusterFreezeReportSpec type for use with
+// apply.
+func ClusterFreezeReportSpec() *ClusterFreezeReportSpecApplyConfiguration {
+	return &ClusterFreezeReportSpecApplyConfiguration{}
+}
+
+// WithUpcomingWindowSeconds sets the UpcomingWindowSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpcomingWindowSeconds field is set to the value of the last call.
+func (b *ClusterFreezeReportSpecApplyConfiguration) WithUpcomingWindowSeconds(value int64) *ClusterFreezeReportSpecApplyConfiguration {
+	b.UpcomingWindowSeconds = &value
+	return b
+}
+
+// WithRecentWindowSeconds sets the RecentWindowSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RecentWindowSeconds field is set to the value of the last call.
+func (b *ClusterFreezeReportSpecApplyConfiguration) WithRecentWindowSeconds(value int64) *ClusterFreezeReportSpecApplyConfiguration {
+	b.RecentWindowSeconds = &value
+	return b
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterFreezeReportStatusApplyConfiguration represents a declarative configuration of the ClusterFreezeReportStatus type for use
+// with apply.
+type ClusterFreezeReportStatusApplyConfiguration struct {
+	LastUpdated       *v1.Time                          `json:"lastUpdated,omitempty"`
+	Phases            map[apiv1alpha1.Phase]int32       `json:"phases,omitempty"`
+	Active            []FreezeSummaryApplyConfiguration `json:"active,omitempty"`
+	UpcomingUnfreezes []FreezeSummaryApplyConfiguration `json:"upcomingUnfreezes,omitempty"`
+	RecentAborts      []FreezeSummaryApplyConfiguration `json:"recentAborts,omitempty"`
+	Truncated         *bool                             `json:"truncated,omitempty"`
+}
+
+// ClusterFreezeReportStatusApplyConfiguration constructs a declarative configuration of the ClusterFreezeReportStatus type for use with
+// apply.
+func ClusterFreezeReportStatus() *ClusterFreezeReportStatusApplyConfiguration {
+	return &ClusterFreezeReportStatusApplyConfiguration{}
+}
+
+// WithLastUpdated sets the LastUpdated field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastUpdated field is set to the value of the last call.
+func (b *ClusterFreezeReportStatusApplyConfiguration) WithLastUpdated(value v1.Time) *ClusterFreezeReportStatusApplyConfiguration {
+	b.LastUpdated = &value
+	return b
+}
+
+// WithPhases puts the entries into the Phases field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Phases field,
+// overwriting an existing map entries in Phases field with the same key.
+func (b *ClusterFreezeReportStatusApplyConfiguration) WithPhases(entries map[apiv1alpha1.Phase]int32) *ClusterFreezeReportStatusApplyConfiguration {
+	if b.Phases == nil && len(entries) > 0 {
+		b.Phases = make(map[apiv1alpha1.Phase]int32, len(entries))
+	}
+	for k, v := range entries {
+		b.Phases[k] = v
+	}
+	return b
+}
+
+// WithActive adds the given value to the Active field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Active field.
+func (b *ClusterFreezeReportStatusApplyConfiguration) WithActive(values ...*FreezeSummaryApplyConfiguration) *ClusterFreezeReportStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithActive")
+		}
+		b.Active = append(b.Active, *values[i])
+	}
+	return b
+}
+
+// WithUpcomingUnfreezes adds the given value to the UpcomingUnfreezes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the UpcomingUnfreezes field.
+func (b *ClusterFreezeReportStatusApplyConfiguration) WithUpcomingUnfreezes(values ...*FreezeSummaryApplyConfiguration) *ClusterFreezeReportStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithUpcomingUnfreezes")
+		}
+		b.UpcomingUnfreezes = append(b.UpcomingUnfreezes, *values[i])
+	}
+	return b
+}
+
+// WithRecentAborts adds the given value to the RecentAborts field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the RecentAborts field.
+func (b *ClusterFreezeReportStatusApplyConfiguration) WithRecentAborts(values ...*FreezeSummaryApplyConfiguration) *ClusterFreezeReportStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithRecentAborts")
+		}
+		b.RecentAborts = append(b.RecentAborts, *values[i])
+	}
+	return b
+}
+
+// WithTruncated sets the Truncated field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Truncated field is set to the value of the last call.
+func (b *ClusterFreezeReportStatusApplyConfiguration) WithTruncated(value bool) *ClusterFreezeReportStatusApplyConfiguration {
+	b.Truncated = &value
+	return b
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeploymentFreezerSpecApplyConfiguration represents a declarative configuration of the DeploymentFreezerSpec type for use
+// with apply.
+type DeploymentFreezerSpecApplyConfiguration struct {
+	TargetRef                      *DeploymentTargetRefApplyConfiguration `json:"targetRef,omitempty"`
+	DurationSeconds                *int64                                 `json:"durationSeconds,omitempty"`
+	Duration                       *v1.Duration                           `json:"duration,omitempty"`
+	FreezeGroup                    *string                                `json:"freezeGroup,omitempty"`
+	Notes                          *string                                `json:"notes,omitempty"`
+	Exemptions                     []apiv1alpha1.Exemption                `json:"exemptions,omitempty"`
+	PauseRollout                   *bool                                  `json:"pauseRollout,omitempty"`
+	DryRun                         *bool                                  `json:"dryRun,omitempty"`
+	UnfreezeStrategy               *UnfreezeStrategyApplyConfiguration    `json:"unfreezeStrategy,omitempty"`
+	GitOpsMode                     *bool                                  `json:"gitopsMode,omitempty"`
+	PostUnfreezeObservationSeconds *int64                                 `json:"postUnfreezeObservationSeconds,omitempty"`
+	AcquireTimeoutSeconds          *int64                                 `json:"acquireTimeoutSeconds,omitempty"`
+	Priority                       *int32                                 `json:"priority,omitempty"`
+	UnfreezeWindow                 *UnfreezeWindowApplyConfiguration      `json:"unfreezeWindow,omitempty"`
+	MaintenancePage                *MaintenancePageApplyConfiguration     `json:"maintenancePage,omitempty"`
+	Standby                        *StandbyApplyConfiguration             `json:"standby,omitempty"`
+	WakeOnRequest                  *WakeOnRequestApplyConfiguration       `json:"wakeOnRequest,omitempty"`
+	Propagation                    *PropagationApplyConfiguration         `json:"propagation,omitempty"`
+	EventPolicy                    *apiv1alpha1.EventPolicy               `json:"eventPolicy,omitempty"`
+}
+
+// DeploymentFreezerSpecApplyConfiguration constructs a declarative configuration of the DeploymentFreezerSpec type for use with
+// apply.
+func DeploymentFreezerSpec() *DeploymentFreezerSpecApplyConfiguration {
+	return &DeploymentFreezerSpecApplyConfiguration{}
+}
+
+// WithTargetRef sets the TargetRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TargetRef field is set to the value of the last call.
+func (b *DeploymentFreezerSpecApplyConfiguration) WithTargetRef(value *DeploymentTargetRefApplyConfiguration) *DeploymentFreezerSpecApplyConfiguration {
+	b.TargetRef = value
+	return b
+}
+
+// WithDurationSeconds sets the DurationSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DurationSeconds field is set to the value of the last call.
+func (b *DeploymentFreezerSpecApplyConfiguration) WithDurationSeconds(value int64) *DeploymentFreezerSpecApplyConfiguration {
+	b.DurationSeconds = &value
+	return b
+}
+
+// WithDuration sets the Duration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Duration field is set to the value of the last call.
+func (b *DeploymentFreezerSpecApplyConfiguration) WithDuration(value v1.Duration) *DeploymentFreezerSpecApplyConfiguration {
+	b.Duration = &value
+	return b
+}
+
+// WithFreezeGroup sets the FreezeGroup field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FreezeGroup field is set to the value of the last call.
+func (b *DeploymentFreezerSpecApplyConfiguration) WithFreezeGroup(value string) *DeploymentFreezerSpecApplyConfiguration {
+	b.FreezeGroup = &value
+	return b
+}
+
+// WithNotes sets the Notes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Notes field is set to the value of the last call.
+func (b *DeploymentFreezerSpecApplyConfiguration) WithNotes(value string) *DeploymentFreezerSpecApplyConfiguration {
+	b.Notes = &value
+	return b
+}
+
+// WithExemptions adds the given value to the Exemptions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Exemptions field.
+func (b *DeploymentFreezerSpecApplyConfiguration) WithExemptions(values ...apiv1alpha1.Exemption) *DeploymentFreezerSpecApplyConfiguration {
+	for i := range values {
+		b.Exemptions = append(b.Exemptions, values[i])
+	}
+	return b
+}
+
+// WithPauseRollout sets the PauseRollout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PauseRollout field is set to the value of the last call.
+func (b *DeploymentFreezerSpecApplyConfiguration) WithPauseRollout(value bool) *DeploymentFreezerSpecApplyConfiguration {
+	b.PauseRollout = &value
+	return b
+}
+
+// WithDryRun sets the DryRun field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DryRun field is set to the value of the last call.
+func (b *DeploymentFreezerSpecApplyConfiguration) WithDryRun(value bool) *DeploymentFreezerSpecApplyConfiguration {
+	b.DryRun = &value
+	return b
+}
+
+// WithUnfreezeStrategy sets the UnfreezeStrategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UnfreezeStrategy field is set to the value of the last call.
+func (b *DeploymentFreezerSpecApplyConfiguration) WithUnfreezeStrategy(value *UnfreezeStrategyApplyConfiguration) *DeploymentFreezerSpecApplyConfiguration {
+	b.UnfreezeStrategy = value
+	return b
+}
+
+// WithGitOpsMode sets the GitOpsMode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GitOpsMode field is set to the value of the last call.
+func (b *DeploymentFreezerSpecApplyConfiguration) WithGitOpsMode(value bool) *DeploymentFreezerSpecApplyConfiguration {
+	b.GitOpsMode = &value
+	return b
+}
+
+// WithPostUnfreezeObservationSeconds sets the PostUnfreezeObservationSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PostUnfreezeObservationSeconds field is set to the value of the last call.
+func (b *DeploymentFreezerSpecApplyConfiguration) WithPostUnfreezeObservationSeconds(value int64) *DeploymentFreezerSpecApplyConfiguration {
+	b.PostUnfreezeObservationSeconds = &value
+	return b
+}
+
+// WithAcquireTimeoutSeconds sets the AcquireTimeoutSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AcquireTimeoutSeconds field is set to the value of the last call.
+func (b *DeploymentFreezerSpecApplyConfiguration) WithAcquireTimeoutSeconds(value int64) *DeploymentFreezerSpecApplyConfiguration {
+	b.AcquireTimeoutSeconds = &value
+	return b
+}
+
+// WithPriority sets the Priority field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Priority field is set to the value of the last call.
+func (b *DeploymentFreezerSpecApplyConfiguration) WithPriority(value int32) *DeploymentFreezerSpecApplyConfiguration {
+	b.Priority = &value
+	return b
+}
+
+// WithUnfreezeWindow sets the UnfreezeWindow field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UnfreezeWindow field is set to the value of the last call.
+func (b *DeploymentFreezerSpecApplyConfiguration) WithUnfreezeWindow(value *UnfreezeWindowApplyConfiguration) *DeploymentFreezerSpecApplyConfiguration {
+	b.UnfreezeWindow = value
+	return b
+}
+
+// WithMaintenancePage sets the MaintenancePage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaintenancePage field is set to the value of the last call.
+func (b *DeploymentFreezerSpecApplyConfiguration) WithMaintenancePage(value *MaintenancePageApplyConfiguration) *DeploymentFreezerSpecApplyConfiguration {
+	b.MaintenancePage = value
+	return b
+}
+
+// WithStandby sets the Standby field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Standby field is set to the value of the last call.
+func (b *DeploymentFreezerSpecApplyConfiguration) WithStandby(value *StandbyApplyConfiguration) *DeploymentFreezerSpecApplyConfiguration {
+	b.Standby = value
+	return b
+}
+
+// WithWakeOnRequest sets the WakeOnRequest field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WakeOnRequest field is set to the value of the last call.
+func (b *DeploymentFreezerSpecApplyConfiguration) WithWakeOnRequest(value *WakeOnRequestApplyConfiguration) *DeploymentFreezerSpecApplyConfiguration {
+	b.WakeOnRequest = value
+	return b
+}
+
+// WithPropagation sets the Propagation field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Propagation field is set to the value of the last call.
+func (b *DeploymentFreezerSpecApplyConfiguration) WithPropagation(value *PropagationApplyConfiguration) *DeploymentFreezerSpecApplyConfiguration {
+	b.Propagation = value
+	return b
+}
+
+// WithEventPolicy sets the EventPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EventPolicy field is set to the value of the last call.
+func (b *DeploymentFreezerSpecApplyConfiguration) WithEventPolicy(value apiv1alpha1.EventPolicy) *DeploymentFreezerSpecApplyConfiguration {
+	b.EventPolicy = &value
+	return b
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeploymentFreezerStatusApplyConfiguration represents a declarative configuration of the DeploymentFreezerStatus type for use
+// with apply.
+type DeploymentFreezerStatusApplyConfiguration struct {
+	Phase                *apiv1alpha1.Phase                     `json:"phase,omitempty"`
+	PhaseTransitionTimes map[apiv1alpha1.Phase]v1.Time          `json:"phaseTransitionTimes,omitempty"`
+	ObservedGeneration   *int64                                 `json:"observedGeneration,omitempty"`
+	ControllerVersion    *string                                `json:"controllerVersion,omitempty"`
+	TargetRef            *StatusTargetRefApplyConfiguration     `json:"targetRef,omitempty"`
+	TemplateHash         *string                                `json:"templateHash,omitempty"`
+	OriginalReplicas     *int32                                 `json:"originalReplicas,omitempty"`
+	OriginalPaused       *bool                                  `json:"originalPaused,omitempty"`
+	Snapshot             *AutoscalingSnapshotApplyConfiguration `json:"snapshot,omitempty"`
+	ResourcesFreed       *ResourcesFreedApplyConfiguration      `json:"resourcesFreed,omitempty"`
+	Preempted            *PreemptedFreezeApplyConfiguration     `json:"preempted,omitempty"`
+	FreezeUntil          *v1.Time                               `json:"freezeUntil,omitempty"`
+	MemberFreezeUntil    *v1.Time                               `json:"memberFreezeUntil,omitempty"`
+	Exemptions           []ExemptionGrantApplyConfiguration     `json:"exemptions,omitempty"`
+	LastHeartbeatTime    *v1.Time                               `json:"lastHeartbeatTime,omitempty"`
+	Canary               *CanaryStatusApplyConfiguration        `json:"canary,omitempty"`
+	PostUnfreeze         *PostUnfreezeStatusApplyConfiguration  `json:"postUnfreeze,omitempty"`
+	RestoreProgress      *RestoreProgressApplyConfiguration     `json:"restoreProgress,omitempty"`
+	MaintenanceIngresses []string                               `json:"maintenanceIngresses,omitempty"`
+	Standby              *StandbyStatusApplyConfiguration       `json:"standby,omitempty"`
+	LastError            *OperationErrorApplyConfiguration      `json:"lastError,omitempty"`
+	RetryCount           *RetryCountApplyConfiguration          `json:"retryCount,omitempty"`
+	Conditions           []ConditionApplyConfiguration          `json:"conditions,omitempty"`
+	PlannedChanges       []string                               `json:"plannedChanges,omitempty"`
+	Clusters             []ClusterFreezeApplyConfiguration      `json:"clusters,omitempty"`
+}
+
+// DeploymentFreezerStatusApplyConfiguration constructs a declarative configuration of the DeploymentFreezerStatus type for use with
+// apply.
+func DeploymentFreezerStatus() *DeploymentFreezerStatusApplyConfiguration {
+	return &DeploymentFreezerStatusApplyConfiguration{}
+}
+
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithPhase(value apiv1alpha1.Phase) *DeploymentFreezerStatusApplyConfiguration {
+	b.Phase = &value
+	return b
+}
+
+// WithPhaseTransitionTimes puts the entries into the PhaseTransitionTimes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the PhaseTransitionTimes field,
+// overwriting an existing map entries in PhaseTransitionTimes field with the same key.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithPhaseTransitionTimes(entries map[apiv1alpha1.Phase]v1.Time) *DeploymentFreezerStatusApplyConfiguration {
+	if b.PhaseTransitionTimes == nil && len(entries) > 0 {
+		b.PhaseTransitionTimes = make(map[apiv1alpha1.Phase]v1.Time, len(entries))
+	}
+	for k, v := range entries {
+		b.PhaseTransitionTimes[k] = v
+	}
+	return b
+}
+
+// WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedGeneration field is set to the value of the last call.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithObservedGeneration(value int64) *DeploymentFreezerStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
+// WithControllerVersion sets the ControllerVersion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ControllerVersion field is set to the value of the last call.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithControllerVersion(value string) *DeploymentFreezerStatusApplyConfiguration {
+	b.ControllerVersion = &value
+	return b
+}
+
+// WithTargetRef sets the TargetRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TargetRef field is set to the value of the last call.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithTargetRef(value *StatusTargetRefApplyConfiguration) *DeploymentFreezerStatusApplyConfiguration {
+	b.TargetRef = value
+	return b
+}
+
+// WithTemplateHash sets the TemplateHash field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TemplateHash field is set to the value of the last call.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithTemplateHash(value string) *DeploymentFreezerStatusApplyConfiguration {
+	b.TemplateHash = &value
+	return b
+}
+
+// WithOriginalReplicas sets the OriginalReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OriginalReplicas field is set to the value of the last call.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithOriginalReplicas(value int32) *DeploymentFreezerStatusApplyConfiguration {
+	b.OriginalReplicas = &value
+	return b
+}
+
+// WithOriginalPaused sets the OriginalPaused field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OriginalPaused field is set to the value of the last call.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithOriginalPaused(value bool) *DeploymentFreezerStatusApplyConfiguration {
+	b.OriginalPaused = &value
+	return b
+}
+
+// WithSnapshot sets the Snapshot field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Snapshot field is set to the value of the last call.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithSnapshot(value *AutoscalingSnapshotApplyConfiguration) *DeploymentFreezerStatusApplyConfiguration {
+	b.Snapshot = value
+	return b
+}
+
+// WithResourcesFreed sets the ResourcesFreed field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ResourcesFreed field is set to the value of the last call.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithResourcesFreed(value *ResourcesFreedApplyConfiguration) *DeploymentFreezerStatusApplyConfiguration {
+	b.ResourcesFreed = value
+	return b
+}
+
+// WithPreempted sets the Preempted field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Preempted field is set to the value of the last call.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithPreempted(value *PreemptedFreezeApplyConfiguration) *DeploymentFreezerStatusApplyConfiguration {
+	b.Preempted = value
+	return b
+}
+
+// WithFreezeUntil sets the FreezeUntil field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FreezeUntil field is set to the value of the last call.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithFreezeUntil(value v1.Time) *DeploymentFreezerStatusApplyConfiguration {
+	b.FreezeUntil = &value
+	return b
+}
+
+// WithMemberFreezeUntil sets the MemberFreezeUntil field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MemberFreezeUntil field is set to the value of the last call.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithMemberFreezeUntil(value v1.Time) *DeploymentFreezerStatusApplyConfiguration {
+	b.MemberFreezeUntil = &value
+	return b
+}
+
+// WithExemptions adds the given value to the Exemptions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Exemptions field.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithExemptions(values ...*ExemptionGrantApplyConfiguration) *DeploymentFreezerStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithExemptions")
+		}
+		b.Exemptions = append(b.Exemptions, *values[i])
+	}
+	return b
+}
+
+// WithLastHeartbeatTime sets the LastHeartbeatTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastHeartbeatTime field is set to the value of the last call.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithLastHeartbeatTime(value v1.Time) *DeploymentFreezerStatusApplyConfiguration {
+	b.LastHeartbeatTime = &value
+	return b
+}
+
+// WithCanary sets the Canary field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Canary field is set to the value of the last call.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithCanary(value *CanaryStatusApplyConfiguration) *DeploymentFreezerStatusApplyConfiguration {
+	b.Canary = value
+	return b
+}
+
+// WithPostUnfreeze sets the PostUnfreeze field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PostUnfreeze field is set to the value of the last call.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithPostUnfreeze(value *PostUnfreezeStatusApplyConfiguration) *DeploymentFreezerStatusApplyConfiguration {
+	b.PostUnfreeze = value
+	return b
+}
+
+// WithRestoreProgress sets the RestoreProgress field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RestoreProgress field is set to the value of the last call.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithRestoreProgress(value *RestoreProgressApplyConfiguration) *DeploymentFreezerStatusApplyConfiguration {
+	b.RestoreProgress = value
+	return b
+}
+
+// WithMaintenanceIngresses adds the given value to the MaintenanceIngresses field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the MaintenanceIngresses field.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithMaintenanceIngresses(values ...string) *DeploymentFreezerStatusApplyConfiguration {
+	for i := range values {
+		b.MaintenanceIngresses = append(b.MaintenanceIngresses, values[i])
+	}
+	return b
+}
+
+// WithStandby sets the Standby field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Standby field is set to the value of the last call.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithStandby(value *StandbyStatusApplyConfiguration) *DeploymentFreezerStatusApplyConfiguration {
+	b.Standby = value
+	return b
+}
+
+// WithLastError sets the LastError field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastError field is set to the value of the last call.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithLastError(value *OperationErrorApplyConfiguration) *DeploymentFreezerStatusApplyConfiguration {
+	b.LastError = value
+	return b
+}
+
+// WithRetryCount sets the RetryCount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RetryCount field is set to the value of the last call.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithRetryCount(value *RetryCountApplyConfiguration) *DeploymentFreezerStatusApplyConfiguration {
+	b.RetryCount = value
+	return b
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithConditions(values ...*ConditionApplyConfiguration) *DeploymentFreezerStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
+
+// WithPlannedChanges adds the given value to the PlannedChanges field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PlannedChanges field.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithPlannedChanges(values ...string) *DeploymentFreezerStatusApplyConfiguration {
+	for i := range values {
+		b.PlannedChanges = append(b.PlannedChanges, values[i])
+	}
+	return b
+}
+
+// WithClusters adds the given value to the Clusters field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Clusters field.
+func (b *DeploymentFreezerStatusApplyConfiguration) WithClusters(values ...*ClusterFreezeApplyConfiguration) *DeploymentFreezerStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithClusters")
+		}
+		b.Clusters = append(b.Clusters, *values[i])
+	}
+	return b
+}
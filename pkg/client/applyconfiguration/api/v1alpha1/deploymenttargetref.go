@@ -0,0 +1,61 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// DeploymentTargetRefApplyConfiguration represents a declarative configuration of the DeploymentTargetRef type for use
+// with apply.
+type DeploymentTargetRefApplyConfiguration struct {
+	Kind     *apiv1alpha1.TargetKind             `json:"kind,omitempty"`
+	Name     *string                             `json:"name,omitempty"`
+	Selector *v1.LabelSelectorApplyConfiguration `json:"selector,omitempty"`
+}
+
+// DeploymentTargetRefApplyConfiguration constructs a declarative configuration of the DeploymentTargetRef type for use with
+// apply.
+func DeploymentTargetRef() *DeploymentTargetRefApplyConfiguration {
+	return &DeploymentTargetRefApplyConfiguration{}
+}
+
+// WithKind sets the Kind field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Kind field is set to the value of the last call.
+func (b *DeploymentTargetRefApplyConfiguration) WithKind(value apiv1alpha1.TargetKind) *DeploymentTargetRefApplyConfiguration {
+	b.Kind = &value
+	return b
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *DeploymentTargetRefApplyConfiguration) WithName(value string) *DeploymentTargetRefApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithSelector sets the Selector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Selector field is set to the value of the last call.
+func (b *DeploymentTargetRefApplyConfiguration) WithSelector(value *v1.LabelSelectorApplyConfiguration) *DeploymentTargetRefApplyConfiguration {
+	b.Selector = value
+	return b
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+)
+
+// ExemptionGrantApplyConfiguration represents a declarative configuration of the ExemptionGrant type for use
+// with apply.
+type ExemptionGrantApplyConfiguration struct {
+	Exemption *apiv1alpha1.Exemption `json:"exemption,omitempty"`
+	Policy    *string                `json:"policy,omitempty"`
+}
+
+// ExemptionGrantApplyConfiguration constructs a declarative configuration of the ExemptionGrant type for use with
+// apply.
+func ExemptionGrant() *ExemptionGrantApplyConfiguration {
+	return &ExemptionGrantApplyConfiguration{}
+}
+
+// WithExemption sets the Exemption field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Exemption field is set to the value of the last call.
+func (b *ExemptionGrantApplyConfiguration) WithExemption(value apiv1alpha1.Exemption) *ExemptionGrantApplyConfiguration {
+	b.Exemption = &value
+	return b
+}
+
+// WithPolicy sets the Policy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Policy field is set to the value of the last call.
+func (b *ExemptionGrantApplyConfiguration) WithPolicy(value string) *ExemptionGrantApplyConfiguration {
+	b.Policy = &value
+	return b
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// FreezeQuotaApplyConfiguration represents a declarative configuration of the FreezeQuota type for use
+// with apply.
+type FreezeQuotaApplyConfiguration struct {
+	MaxFrozenSeconds *int64 `json:"maxFrozenSeconds,omitempty"`
+	WindowSeconds    *int64 `json:"windowSeconds,omitempty"`
+}
+
+// FreezeQuotaApplyConfiguration constructs a declarative configuration of the FreezeQuota type for use with
+// apply.
+func FreezeQuota() *FreezeQuotaApplyConfiguration {
+	return &FreezeQuotaApplyConfiguration{}
+}
+
+// WithMaxFrozenSeconds sets the MaxFrozenSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxFrozenSeconds field is set to the value of the last call.
+func (b *FreezeQuotaApplyConfiguration) WithMaxFrozenSeconds(value int64) *FreezeQuotaApplyConfiguration {
+	b.MaxFrozenSeconds = &value
+	return b
+}
+
+// WithWindowSeconds sets the WindowSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WindowSeconds field is set to the value of the last call.
+func (b *FreezeQuotaApplyConfiguration) WithWindowSeconds(value int64) *FreezeQuotaApplyConfiguration {
+	b.WindowSeconds = &value
+	return b
+}
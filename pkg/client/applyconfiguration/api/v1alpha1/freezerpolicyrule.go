@@ -0,0 +1,104 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// FreezerPolicyRuleApplyConfiguration represents a declarative configuration of the FreezerPolicyRule type for use
+// with apply.
+type FreezerPolicyRuleApplyConfiguration struct {
+	Action             *apiv1alpha1.PolicyAction           `json:"action,omitempty"`
+	Namespaces         []string                            `json:"namespaces,omitempty"`
+	NamespaceSelector  *v1.LabelSelectorApplyConfiguration `json:"namespaceSelector,omitempty"`
+	Subjects           []rbacv1.Subject                    `json:"subjects,omitempty"`
+	MaxDurationSeconds *int64                              `json:"maxDurationSeconds,omitempty"`
+	Quota              *FreezeQuotaApplyConfiguration      `json:"quota,omitempty"`
+	Exemptions         []apiv1alpha1.Exemption             `json:"exemptions,omitempty"`
+}
+
+// FreezerPolicyRuleApplyConfiguration constructs a declarative configuration of the FreezerPolicyRule type for use with
+// apply.
+func FreezerPolicyRule() *FreezerPolicyRuleApplyConfiguration {
+	return &FreezerPolicyRuleApplyConfiguration{}
+}
+
+// WithAction sets the Action field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Action field is set to the value of the last call.
+func (b *FreezerPolicyRuleApplyConfiguration) WithAction(value apiv1alpha1.PolicyAction) *FreezerPolicyRuleApplyConfiguration {
+	b.Action = &value
+	return b
+}
+
+// WithNamespaces adds the given value to the Namespaces field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Namespaces field.
+func (b *FreezerPolicyRuleApplyConfiguration) WithNamespaces(values ...string) *FreezerPolicyRuleApplyConfiguration {
+	for i := range values {
+		b.Namespaces = append(b.Namespaces, values[i])
+	}
+	return b
+}
+
+// WithNamespaceSelector sets the NamespaceSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamespaceSelector field is set to the value of the last call.
+func (b *FreezerPolicyRuleApplyConfiguration) WithNamespaceSelector(value *v1.LabelSelectorApplyConfiguration) *FreezerPolicyRuleApplyConfiguration {
+	b.NamespaceSelector = value
+	return b
+}
+
+// WithSubjects adds the given value to the Subjects field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Subjects field.
+func (b *FreezerPolicyRuleApplyConfiguration) WithSubjects(values ...rbacv1.Subject) *FreezerPolicyRuleApplyConfiguration {
+	for i := range values {
+		b.Subjects = append(b.Subjects, values[i])
+	}
+	return b
+}
+
+// WithMaxDurationSeconds sets the MaxDurationSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxDurationSeconds field is set to the value of the last call.
+func (b *FreezerPolicyRuleApplyConfiguration) WithMaxDurationSeconds(value int64) *FreezerPolicyRuleApplyConfiguration {
+	b.MaxDurationSeconds = &value
+	return b
+}
+
+// WithQuota sets the Quota field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Quota field is set to the value of the last call.
+func (b *FreezerPolicyRuleApplyConfiguration) WithQuota(value *FreezeQuotaApplyConfiguration) *FreezerPolicyRuleApplyConfiguration {
+	b.Quota = value
+	return b
+}
+
+// WithExemptions adds the given value to the Exemptions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Exemptions field.
+func (b *FreezerPolicyRuleApplyConfiguration) WithExemptions(values ...apiv1alpha1.Exemption) *FreezerPolicyRuleApplyConfiguration {
+	for i := range values {
+		b.Exemptions = append(b.Exemptions, values[i])
+	}
+	return b
+}
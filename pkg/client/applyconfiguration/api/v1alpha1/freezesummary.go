@@ -0,0 +1,97 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FreezeSummaryApplyConfiguration represents a declarative configuration of the FreezeSummary type for use
+// with apply.
+type FreezeSummaryApplyConfiguration struct {
+	Namespace      *string                           `json:"namespace,omitempty"`
+	Name           *string                           `json:"name,omitempty"`
+	Target         *string                           `json:"target,omitempty"`
+	Phase          *apiv1alpha1.Phase                `json:"phase,omitempty"`
+	Since          *v1.Time                          `json:"since,omitempty"`
+	FreezeUntil    *v1.Time                          `json:"freezeUntil,omitempty"`
+	ResourcesFreed *ResourcesFreedApplyConfiguration `json:"resourcesFreed,omitempty"`
+}
+
+// FreezeSummaryApplyConfiguration constructs a declarative configuration of the FreezeSummary type for use with
+// apply.
+func FreezeSummary() *FreezeSummaryApplyConfiguration {
+	return &FreezeSummaryApplyConfiguration{}
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *FreezeSummaryApplyConfiguration) WithNamespace(value string) *FreezeSummaryApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *FreezeSummaryApplyConfiguration) WithName(value string) *FreezeSummaryApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithTarget sets the Target field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Target field is set to the value of the last call.
+func (b *FreezeSummaryApplyConfiguration) WithTarget(value string) *FreezeSummaryApplyConfiguration {
+	b.Target = &value
+	return b
+}
+
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *FreezeSummaryApplyConfiguration) WithPhase(value apiv1alpha1.Phase) *FreezeSummaryApplyConfiguration {
+	b.Phase = &value
+	return b
+}
+
+// WithSince sets the Since field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Since field is set to the value of the last call.
+func (b *FreezeSummaryApplyConfiguration) WithSince(value v1.Time) *FreezeSummaryApplyConfiguration {
+	b.Since = &value
+	return b
+}
+
+// WithFreezeUntil sets the FreezeUntil field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FreezeUntil field is set to the value of the last call.
+func (b *FreezeSummaryApplyConfiguration) WithFreezeUntil(value v1.Time) *FreezeSummaryApplyConfiguration {
+	b.FreezeUntil = &value
+	return b
+}
+
+// WithResourcesFreed sets the ResourcesFreed field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ResourcesFreed field is set to the value of the last call.
+func (b *FreezeSummaryApplyConfiguration) WithResourcesFreed(value *ResourcesFreedApplyConfiguration) *FreezeSummaryApplyConfiguration {
+	b.ResourcesFreed = value
+	return b
+}
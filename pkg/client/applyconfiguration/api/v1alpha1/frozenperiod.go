@@ -0,0 +1,70 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+)
+
+// FrozenPeriodApplyConfiguration represents a declarative configuration of the FrozenPeriod type for use
+// with apply.
+type FrozenPeriodApplyConfiguration struct {
+	Freezer *string    `json:"freezer,omitempty"`
+	UID     *types.UID `json:"uid,omitempty"`
+	Start   *v1.Time   `json:"start,omitempty"`
+	End     *v1.Time   `json:"end,omitempty"`
+}
+
+// FrozenPeriodApplyConfiguration constructs a declarative configuration of the FrozenPeriod type for use with
+// apply.
+func FrozenPeriod() *FrozenPeriodApplyConfiguration {
+	return &FrozenPeriodApplyConfiguration{}
+}
+
+// WithFreezer sets the Freezer field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Freezer field is set to the value of the last call.
+func (b *FrozenPeriodApplyConfiguration) WithFreezer(value string) *FrozenPeriodApplyConfiguration {
+	b.Freezer = &value
+	return b
+}
+
+// WithUID sets the UID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UID field is set to the value of the last call.
+func (b *FrozenPeriodApplyConfiguration) WithUID(value types.UID) *FrozenPeriodApplyConfiguration {
+	b.UID = &value
+	return b
+}
+
+// WithStart sets the Start field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Start field is set to the value of the last call.
+func (b *FrozenPeriodApplyConfiguration) WithStart(value v1.Time) *FrozenPeriodApplyConfiguration {
+	b.Start = &value
+	return b
+}
+
+// WithEnd sets the End field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the End field is set to the value of the last call.
+func (b *FrozenPeriodApplyConfiguration) WithEnd(value v1.Time) *FrozenPeriodApplyConfiguration {
+	b.End = &value
+	return b
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/networking/v1"
+)
+
+// MaintenancePageApplyConfiguration represents a declarative configuration of the MaintenancePage type for use
+// with apply.
+type MaintenancePageApplyConfiguration struct {
+	IngressName *string                   `json:"ingressName,omitempty"`
+	Backend     *v1.IngressServiceBackend `json:"backend,omitempty"`
+}
+
+// MaintenancePageApplyConfiguration constructs a declarative configuration of the MaintenancePage type for use with
+// apply.
+func MaintenancePage() *MaintenancePageApplyConfiguration {
+	return &MaintenancePageApplyConfiguration{}
+}
+
+// WithIngressName sets the IngressName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IngressName field is set to the value of the last call.
+func (b *MaintenancePageApplyConfiguration) WithIngressName(value string) *MaintenancePageApplyConfiguration {
+	b.IngressName = &value
+	return b
+}
+
+// WithBackend sets the Backend field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Backend field is set to the value of the last call.
+func (b *MaintenancePageApplyConfiguration) WithBackend(value v1.IngressServiceBackend) *MaintenancePageApplyConfiguration {
+	b.Backend = &value
+	return b
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// MetricTriggerApplyConfiguration represents a declarative configuration of the MetricTrigger type for use
+// with apply.
+type MetricTriggerApplyConfiguration struct {
+	Query     *string            `json:"query,omitempty"`
+	Threshold *resource.Quantity `json:"threshold,omitempty"`
+}
+
+// MetricTriggerApplyConfiguration constructs a declarative configuration of the MetricTrigger type for use with
+// apply.
+func MetricTrigger() *MetricTriggerApplyConfiguration {
+	return &MetricTriggerApplyConfiguration{}
+}
+
+// WithQuery sets the Query field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Query field is set to the value of the last call.
+func (b *MetricTriggerApplyConfiguration) WithQuery(value string) *MetricTriggerApplyConfiguration {
+	b.Query = &value
+	return b
+}
+
+// WithThreshold sets the Threshold field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Threshold field is set to the value of the last call.
+func (b *MetricTriggerApplyConfiguration) WithThreshold(value resource.Quantity) *MetricTriggerApplyConfiguration {
+	b.Threshold = &value
+	return b
+}
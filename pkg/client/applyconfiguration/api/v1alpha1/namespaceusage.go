@@ -0,0 +1,52 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// NamespaceUsageApplyConfiguration represents a declarative configuration of the NamespaceUsage type for use
+// with apply.
+type NamespaceUsageApplyConfiguration struct {
+	Namespace *string                          `json:"namespace,omitempty"`
+	Periods   []FrozenPeriodApplyConfiguration `json:"periods,omitempty"`
+}
+
+// NamespaceUsageApplyConfiguration constructs a declarative configuration of the NamespaceUsage type for use with
+// apply.
+func NamespaceUsage() *NamespaceUsageApplyConfiguration {
+	return &NamespaceUsageApplyConfiguration{}
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *NamespaceUsageApplyConfiguration) WithNamespace(value string) *NamespaceUsageApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithPeriods adds the given value to the Periods field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Periods field.
+func (b *NamespaceUsageApplyConfiguration) WithPeriods(values ...*FrozenPeriodApplyConfiguration) *NamespaceUsageApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPeriods")
+		}
+		b.Periods = append(b.Periods, *values[i])
+	}
+	return b
+}
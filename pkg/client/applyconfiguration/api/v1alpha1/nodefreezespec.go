@@ -0,0 +1,47 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// NodeFreezeSpecApplyConfiguration represents a declarative configuration of the NodeFreezeSpec type for use
+// with apply.
+type NodeFreezeSpecApplyConfiguration struct {
+	NodeName        *string `json:"nodeName,omitempty"`
+	DurationSeconds *int64  `json:"durationSeconds,omitempty"`
+}
+
+// NodeFreezeSpecApplyConfiguration constructs a declarative configuration of the NodeFreezeSpec type for use with
+// apply.
+func NodeFreezeSpec() *NodeFreezeSpecApplyConfiguration {
+	return &NodeFreezeSpecApplyConfiguration{}
+}
+
+// WithNodeName sets the NodeName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeName field is set to the value of the last call.
+func (b *NodeFreezeSpecApplyConfiguration) WithNodeName(value string) *NodeFreezeSpecApplyConfiguration {
+	b.NodeName = &value
+	return b
+}
+
+// WithDurationSeconds sets the DurationSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DurationSeconds field is set to the value of the last call.
+func (b *NodeFreezeSpecApplyConfiguration) WithDurationSeconds(value int64) *NodeFreezeSpecApplyConfiguration {
+	b.DurationSeconds = &value
+	return b
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeFreezeStatusApplyConfiguration represents a declarative configuration of the NodeFreezeStatus type for use
+// with apply.
+type NodeFreezeStatusApplyConfiguration struct {
+	Phase        *apiv1alpha1.Phase              `json:"phase,omitempty"`
+	DiscoveredAt *v1.Time                        `json:"discoveredAt,omitempty"`
+	Freezes      []ChildFreezeApplyConfiguration `json:"freezes,omitempty"`
+}
+
+// NodeFreezeStatusApplyConfiguration constructs a declarative configuration of the NodeFreezeStatus type for use with
+// apply.
+func NodeFreezeStatus() *NodeFreezeStatusApplyConfiguration {
+	return &NodeFreezeStatusApplyConfiguration{}
+}
+
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *NodeFreezeStatusApplyConfiguration) WithPhase(value apiv1alpha1.Phase) *NodeFreezeStatusApplyConfiguration {
+	b.Phase = &value
+	return b
+}
+
+// WithDiscoveredAt sets the DiscoveredAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DiscoveredAt field is set to the value of the last call.
+func (b *NodeFreezeStatusApplyConfiguration) WithDiscoveredAt(value v1.Time) *NodeFreezeStatusApplyConfiguration {
+	b.DiscoveredAt = &value
+	return b
+}
+
+// WithFreezes adds the given value to the Freezes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Freezes field.
+func (b *NodeFreezeStatusApplyConfiguration) WithFreezes(values ...*ChildFreezeApplyConfiguration) *NodeFreezeStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithFreezes")
+		}
+		b.Freezes = append(b.Freezes, *values[i])
+	}
+	return b
+}
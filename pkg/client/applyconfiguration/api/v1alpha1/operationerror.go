@@ -0,0 +1,78 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OperationErrorApplyConfiguration represents a declarative configuration of the OperationError type for use
+// with apply.
+type OperationErrorApplyConfiguration struct {
+	Operation        *string  `json:"operation,omitempty"`
+	Message          *string  `json:"message,omitempty"`
+	Attempts         *int32   `json:"attempts,omitempty"`
+	FirstFailureTime *v1.Time `json:"firstFailureTime,omitempty"`
+	LastFailureTime  *v1.Time `json:"lastFailureTime,omitempty"`
+}
+
+// OperationErrorApplyConfiguration constructs a declarative configuration of the OperationError type for use with
+// apply.
+func OperationError() *OperationErrorApplyConfiguration {
+	return &OperationErrorApplyConfiguration{}
+}
+
+// WithOperation sets the Operation field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Operation field is set to the value of the last call.
+func (b *OperationErrorApplyConfiguration) WithOperation(value string) *OperationErrorApplyConfiguration {
+	b.Operation = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *OperationErrorApplyConfiguration) WithMessage(value string) *OperationErrorApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// WithAttempts sets the Attempts field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Attempts field is set to the value of the last call.
+func (b *OperationErrorApplyConfiguration) WithAttempts(value int32) *OperationErrorApplyConfiguration {
+	b.Attempts = &value
+	return b
+}
+
+// WithFirstFailureTime sets the FirstFailureTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FirstFailureTime field is set to the value of the last call.
+func (b *OperationErrorApplyConfiguration) WithFirstFailureTime(value v1.Time) *OperationErrorApplyConfiguration {
+	b.FirstFailureTime = &value
+	return b
+}
+
+// WithLastFailureTime sets the LastFailureTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastFailureTime field is set to the value of the last call.
+func (b *OperationErrorApplyConfiguration) WithLastFailureTime(value v1.Time) *OperationErrorApplyConfiguration {
+	b.LastFailureTime = &value
+	return b
+}
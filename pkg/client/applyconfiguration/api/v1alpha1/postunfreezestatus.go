@@ -0,0 +1,51 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PostUnfreezeStatusApplyConfiguration represents a declarative configuration of the PostUnfreezeStatus type for use
+// with apply.
+type PostUnfreezeStatusApplyConfiguration struct {
+	RestoredAt *v1.Time `json:"restoredAt,omitempty"`
+	Restarts   *int32   `json:"restarts,omitempty"`
+}
+
+// PostUnfreezeStatusApplyConfiguration constructs a declarative configuration of the PostUnfreezeStatus type for use with
+// apply.
+func PostUnfreezeStatus() *PostUnfreezeStatusApplyConfiguration {
+	return &PostUnfreezeStatusApplyConfiguration{}
+}
+
+// WithRestoredAt sets the RestoredAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RestoredAt field is set to the value of the last call.
+func (b *PostUnfreezeStatusApplyConfiguration) WithRestoredAt(value v1.Time) *PostUnfreezeStatusApplyConfiguration {
+	b.RestoredAt = &value
+	return b
+}
+
+// WithRestarts sets the Restarts field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Restarts field is set to the value of the last call.
+func (b *PostUnfreezeStatusApplyConfiguration) WithRestarts(value int32) *PostUnfreezeStatusApplyConfiguration {
+	b.Restarts = &value
+	return b
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+)
+
+// PreemptedFreezeApplyConfiguration represents a declarative configuration of the PreemptedFreeze type for use
+// with apply.
+type PreemptedFreezeApplyConfiguration struct {
+	Name     *string    `json:"name,omitempty"`
+	UID      *types.UID `json:"uid,omitempty"`
+	Priority *int32     `json:"priority,omitempty"`
+	Time     *v1.Time   `json:"time,omitempty"`
+}
+
+// PreemptedFreezeApplyConfiguration constructs a declarative configuration of the PreemptedFreeze type for use with
+// apply.
+func PreemptedFreeze() *PreemptedFreezeApplyConfiguration {
+	return &PreemptedFreezeApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *PreemptedFreezeApplyConfiguration) WithName(value string) *PreemptedFreezeApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithUID sets the UID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UID field is set to the value of the last call.
+func (b *PreemptedFreezeApplyConfiguration) WithUID(value types.UID) *PreemptedFreezeApplyConfiguration {
+	b.UID = &value
+	return b
+}
+
+// WithPriority sets the Priority field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Priority field is set to the value of the last call.
+func (b *PreemptedFreezeApplyConfiguration) WithPriority(value int32) *PreemptedFreezeApplyConfiguration {
+	b.Priority = &value
+	return b
+}
+
+// WithTime sets the Time field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Time field is set to the value of the last call.
+func (b *PreemptedFreezeApplyConfiguration) WithTime(value v1.Time) *PreemptedFreezeApplyConfiguration {
+	b.Time = &value
+	return b
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// PropagationApplyConfiguration represents a declarative configuration of the Propagation type for use
+// with apply.
+type PropagationApplyConfiguration struct {
+	Clusters []string `json:"clusters,omitempty"`
+}
+
+// PropagationApplyConfiguration constructs a declarative configuration of the Propagation type for use with
+// apply.
+func Propagation() *PropagationApplyConfiguration {
+	return &PropagationApplyConfiguration{}
+}
+
+// WithClusters adds the given value to the Clusters field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Clusters field.
+func (b *PropagationApplyConfiguration) WithClusters(values ...string) *PropagationApplyConfiguration {
+	for i := range values {
+		b.Clusters = append(b.Clusters, values[i])
+	}
+	return b
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ResourcesFreedApplyConfiguration represents a declarative configuration of the ResourcesFreed type for use
+// with apply.
+type ResourcesFreedApplyConfiguration struct {
+	Replicas *int32             `json:"replicas,omitempty"`
+	CPU      *resource.Quantity `json:"cpu,omitempty"`
+	Memory   *resource.Quantity `json:"memory,omitempty"`
+}
+
+// ResourcesFreedApplyConfiguration constructs a declarative configuration of the ResourcesFreed type for use with
+// apply.
+func ResourcesFreed() *ResourcesFreedApplyConfiguration {
+	return &ResourcesFreedApplyConfiguration{}
+}
+
+// WithReplicas sets the Replicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Replicas field is set to the value of the last call.
+func (b *ResourcesFreedApplyConfiguration) WithReplicas(value int32) *ResourcesFreedApplyConfiguration {
+	b.Replicas = &value
+	return b
+}
+
+// WithCPU sets the CPU field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CPU field is set to the value of the last call.
+func (b *ResourcesFreedApplyConfiguration) WithCPU(value resource.Quantity) *ResourcesFreedApplyConfiguration {
+	b.CPU = &value
+	return b
+}
+
+// WithMemory sets the Memory field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Memory field is set to the value of the last call.
+func (b *ResourcesFreedApplyConfiguration) WithMemory(value resource.Quantity) *ResourcesFreedApplyConfiguration {
+	b.Memory = &value
+	return b
+}
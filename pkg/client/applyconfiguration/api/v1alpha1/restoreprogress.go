@@ -0,0 +1,56 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// RestoreProgressApplyConfiguration represents a declarative configuration of the RestoreProgress type for use
+// with apply.
+type RestoreProgressApplyConfiguration struct {
+	Replicas          *int32 `json:"replicas,omitempty"`
+	ReadyReplicas     *int32 `json:"readyReplicas,omitempty"`
+	AvailableReplicas *int32 `json:"availableReplicas,omitempty"`
+}
+
+// RestoreProgressApplyConfiguration constructs a declarative configuration of the RestoreProgress type for use with
+// apply.
+func RestoreProgress() *RestoreProgressApplyConfiguration {
+	return &RestoreProgressApplyConfiguration{}
+}
+
+// WithReplicas sets the Replicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Replicas field is set to the value of the last call.
+func (b *RestoreProgressApplyConfiguration) WithReplicas(value int32) *RestoreProgressApplyConfiguration {
+	b.Replicas = &value
+	return b
+}
+
+// WithReadyReplicas sets the ReadyReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReadyReplicas field is set to the value of the last call.
+func (b *RestoreProgressApplyConfiguration) WithReadyReplicas(value int32) *RestoreProgressApplyConfiguration {
+	b.ReadyReplicas = &value
+	return b
+}
+
+// WithAvailableReplicas sets the AvailableReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AvailableReplicas field is set to the value of the last call.
+func (b *RestoreProgressApplyConfiguration) WithAvailableReplicas(value int32) *RestoreProgressApplyConfiguration {
+	b.AvailableReplicas = &value
+	return b
+}
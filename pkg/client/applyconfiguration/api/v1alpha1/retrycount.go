@@ -0,0 +1,56 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// RetryCountApplyConfiguration represents a declarative configuration of the RetryCount type for use
+// with apply.
+type RetryCountApplyConfiguration struct {
+	Freeze    *int32 `json:"freeze,omitempty"`
+	Unfreeze  *int32 `json:"unfreeze,omitempty"`
+	Ownership *int32 `json:"ownership,omitempty"`
+}
+
+// RetryCountApplyConfiguration constructs a declarative configuration of the RetryCount type for use with
+// apply.
+func RetryCount() *RetryCountApplyConfiguration {
+	return &RetryCountApplyConfiguration{}
+}
+
+// WithFreeze sets the Freeze field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Freeze field is set to the value of the last call.
+func (b *RetryCountApplyConfiguration) WithFreeze(value int32) *RetryCountApplyConfiguration {
+	b.Freeze = &value
+	return b
+}
+
+// WithUnfreeze sets the Unfreeze field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Unfreeze field is set to the value of the last call.
+func (b *RetryCountApplyConfiguration) WithUnfreeze(value int32) *RetryCountApplyConfiguration {
+	b.Unfreeze = &value
+	return b
+}
+
+// WithOwnership sets the Ownership field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Ownership field is set to the value of the last call.
+func (b *RetryCountApplyConfiguration) WithOwnership(value int32) *RetryCountApplyConfiguration {
+	b.Ownership = &value
+	return b
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ScaledObjectSnapshotApplyConfiguration represents a declarative configuration of the ScaledObjectSnapshot type for use
+// with apply.
+type ScaledObjectSnapshotApplyConfiguration struct {
+	Name   *string `json:"name,omitempty"`
+	Paused *string `json:"paused,omitempty"`
+}
+
+// ScaledObjectSnapshotApplyConfiguration constructs a declarative configuration of the ScaledObjectSnapshot type for use with
+// apply.
+func ScaledObjectSnapshot() *ScaledObjectSnapshotApplyConfiguration {
+	return &ScaledObjectSnapshotApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ScaledObjectSnapshotApplyConfiguration) WithName(value string) *ScaledObjectSnapshotApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithPaused sets the Paused field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Paused field is set to the value of the last call.
+func (b *ScaledObjectSnapshotApplyConfiguration) WithPaused(value string) *ScaledObjectSnapshotApplyConfiguration {
+	b.Paused = &value
+	return b
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// StandbyStatusApplyConfiguration represents a declarative configuration of the StandbyStatus type for use
+// with apply.
+type StandbyStatusApplyConfiguration struct {
+	ServiceName      *string           `json:"serviceName,omitempty"`
+	OriginalSelector map[string]string `json:"originalSelector,omitempty"`
+}
+
+// StandbyStatusApplyConfiguration constructs a declarative configuration of the StandbyStatus type for use with
+// apply.
+func StandbyStatus() *StandbyStatusApplyConfiguration {
+	return &StandbyStatusApplyConfiguration{}
+}
+
+// WithServiceName sets the ServiceName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceName field is set to the value of the last call.
+func (b *StandbyStatusApplyConfiguration) WithServiceName(value string) *StandbyStatusApplyConfiguration {
+	b.ServiceName = &value
+	return b
+}
+
+// WithOriginalSelector puts the entries into the OriginalSelector field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the OriginalSelector field,
+// overwriting an existing map entries in OriginalSelector field with the same key.
+func (b *StandbyStatusApplyConfiguration) WithOriginalSelector(entries map[string]string) *StandbyStatusApplyConfiguration {
+	if b.OriginalSelector == nil && len(entries) > 0 {
+		b.OriginalSelector = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.OriginalSelector[k] = v
+	}
+	return b
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+)
+
+// UnfreezeStrategyApplyConfiguration represents a declarative configuration of the UnfreezeStrategy type for use
+// with apply.
+type UnfreezeStrategyApplyConfiguration struct {
+	Type                *apiv1alpha1.UnfreezeStrategyType `json:"type,omitempty"`
+	StableSeconds       *int64                            `json:"stableSeconds,omitempty"`
+	ReadyTimeoutSeconds *int64                            `json:"readyTimeoutSeconds,omitempty"`
+}
+
+// UnfreezeStrategyApplyConfiguration constructs a declarative configuration of the UnfreezeStrategy type for use with
+// apply.
+func UnfreezeStrategy() *UnfreezeStrategyApplyConfiguration {
+	return &UnfreezeStrategyApplyConfiguration{}
+}
+
+// WithType sets the Type field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Type field is set to the value of the last call.
+func (b *UnfreezeStrategyApplyConfiguration) WithType(value apiv1alpha1.UnfreezeStrategyType) *UnfreezeStrategyApplyConfiguration {
+	b.Type = &value
+	return b
+}
+
+// WithStableSeconds sets the StableSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StableSeconds field is set to the value of the last call.
+func (b *UnfreezeStrategyApplyConfiguration) WithStableSeconds(value int64) *UnfreezeStrategyApplyConfiguration {
+	b.StableSeconds = &value
+	return b
+}
+
+// WithReadyTimeoutSeconds sets the ReadyTimeoutSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReadyTimeoutSeconds field is set to the value of the last call.
+func (b *UnfreezeStrategyApplyConfiguration) WithReadyTimeoutSeconds(value int64) *UnfreezeStrategyApplyConfiguration {
+	b.ReadyTimeoutSeconds = &value
+	return b
+}
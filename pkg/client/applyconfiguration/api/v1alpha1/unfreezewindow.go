@@ -0,0 +1,71 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+)
+
+// UnfreezeWindowApplyConfiguration represents a declarative configuration of the UnfreezeWindow type for use
+// with apply.
+type UnfreezeWindowApplyConfiguration struct {
+	Days     []apiv1alpha1.Weekday `json:"days,omitempty"`
+	Start    *string               `json:"start,omitempty"`
+	End      *string               `json:"end,omitempty"`
+	TimeZone *string               `json:"timeZone,omitempty"`
+}
+
+// UnfreezeWindowApplyConfiguration constructs a declarative configuration of the UnfreezeWindow type for use with
+// apply.
+func UnfreezeWindow() *UnfreezeWindowApplyConfiguration {
+	return &UnfreezeWindowApplyConfiguration{}
+}
+
+// WithDays adds the given value to the Days field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Days field.
+func (b *UnfreezeWindowApplyConfiguration) WithDays(values ...apiv1alpha1.Weekday) *UnfreezeWindowApplyConfiguration {
+	for i := range values {
+		b.Days = append(b.Days, values[i])
+	}
+	return b
+}
+
+// WithStart sets the Start field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Start field is set to the value of the last call.
+func (b *UnfreezeWindowApplyConfiguration) WithStart(value string) *UnfreezeWindowApplyConfiguration {
+	b.Start = &value
+	return b
+}
+
+// WithEnd sets the End field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the End field is set to the value of the last call.
+func (b *UnfreezeWindowApplyConfiguration) WithEnd(value string) *UnfreezeWindowApplyConfiguration {
+	b.End = &value
+	return b
+}
+
+// WithTimeZone sets the TimeZone field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TimeZone field is set to the value of the last call.
+func (b *UnfreezeWindowApplyConfiguration) WithTimeZone(value string) *UnfreezeWindowApplyConfiguration {
+	b.TimeZone = &value
+	return b
+}
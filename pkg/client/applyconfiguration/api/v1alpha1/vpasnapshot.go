@@ -0,0 +1,47 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// VPASnapshotApplyConfiguration represents a declarative configuration of the VPASnapshot type for use
+// with apply.
+type VPASnapshotApplyConfiguration struct {
+	Name       *string `json:"name,omitempty"`
+	UpdateMode *string `json:"updateMode,omitempty"`
+}
+
+// VPASnapshotApplyConfiguration constructs a declarative configuration of the VPASnapshot type for use with
+// apply.
+func VPASnapshot() *VPASnapshotApplyConfiguration {
+	return &VPASnapshotApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *VPASnapshotApplyConfiguration) WithName(value string) *VPASnapshotApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithUpdateMode sets the UpdateMode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpdateMode field is set to the value of the last call.
+func (b *VPASnapshotApplyConfiguration) WithUpdateMode(value string) *VPASnapshotApplyConfiguration {
+	b.UpdateMode = &value
+	return b
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// WakeOnRequestApplyConfiguration represents a declarative configuration of the WakeOnRequest type for use
+// with apply.
+type WakeOnRequestApplyConfiguration struct {
+	Hosts []string `json:"hosts,omitempty"`
+}
+
+// WakeOnRequestApplyConfiguration constructs a declarative configuration of the WakeOnRequest type for use with
+// apply.
+func WakeOnRequest() *WakeOnRequestApplyConfiguration {
+	return &WakeOnRequestApplyConfiguration{}
+}
+
+// WithHosts adds the given value to the Hosts field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Hosts field.
+func (b *WakeOnRequestApplyConfiguration) WithHosts(values ...string) *WakeOnRequestApplyConfiguration {
+	for i := range values {
+		b.Hosts = append(b.Hosts, values[i])
+	}
+	return b
+}
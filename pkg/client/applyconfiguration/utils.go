@@ -0,0 +1,137 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package applyconfiguration
+
+import (
+	v1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/pkg/client/applyconfiguration/api/v1alpha1"
+	internal "github.com/boolfixer/deployment-freezer/pkg/client/applyconfiguration/internal"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	testing "k8s.io/client-go/testing"
+)
+
+// ForKind returns an apply configuration type for the given GroupVersionKind, or nil if no
+// apply configuration type exists for the given GroupVersionKind.
+func ForKind(kind schema.GroupVersionKind) interface{} {
+	switch kind {
+	// Group=apps.boolfixer.dev, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithKind("AutoFreezePolicy"):
+		return &apiv1alpha1.AutoFreezePolicyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AutoFreezePolicySpec"):
+		return &apiv1alpha1.AutoFreezePolicySpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AutoFreezePolicyStatus"):
+		return &apiv1alpha1.AutoFreezePolicyStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AutoFreezeTarget"):
+		return &apiv1alpha1.AutoFreezeTargetApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AutoscalingSnapshot"):
+		return &apiv1alpha1.AutoscalingSnapshotApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("BlackoutWindow"):
+		return &apiv1alpha1.BlackoutWindowApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("CanaryStatus"):
+		return &apiv1alpha1.CanaryStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ChildFreeze"):
+		return &apiv1alpha1.ChildFreezeApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterFreeze"):
+		return &apiv1alpha1.ClusterFreezeApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterFreezeReport"):
+		return &apiv1alpha1.ClusterFreezeReportApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterFreezeReportSpec"):
+		return &apiv1alpha1.ClusterFreezeReportSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterFreezeReportStatus"):
+		return &apiv1alpha1.ClusterFreezeReportStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Condition"):
+		return &apiv1alpha1.ConditionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("CrashLoopTrigger"):
+		return &apiv1alpha1.CrashLoopTriggerApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DeploymentFreezer"):
+		return &apiv1alpha1.DeploymentFreezerApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DeploymentFreezerSpec"):
+		return &apiv1alpha1.DeploymentFreezerSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DeploymentFreezerStatus"):
+		return &apiv1alpha1.DeploymentFreezerStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DeploymentTargetRef"):
+		return &apiv1alpha1.DeploymentTargetRefApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ExemptionGrant"):
+		return &apiv1alpha1.ExemptionGrantApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FreezeQuota"):
+		return &apiv1alpha1.FreezeQuotaApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FreezerPolicy"):
+		return &apiv1alpha1.FreezerPolicyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FreezerPolicyRule"):
+		return &apiv1alpha1.FreezerPolicyRuleApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FreezerPolicySpec"):
+		return &apiv1alpha1.FreezerPolicySpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FreezerPolicyStatus"):
+		return &apiv1alpha1.FreezerPolicyStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FreezeSummary"):
+		return &apiv1alpha1.FreezeSummaryApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FrozenPeriod"):
+		return &apiv1alpha1.FrozenPeriodApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("HPASnapshot"):
+		return &apiv1alpha1.HPASnapshotApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("MaintenancePage"):
+		return &apiv1alpha1.MaintenancePageApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("MetricTrigger"):
+		return &apiv1alpha1.MetricTriggerApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NamespaceUsage"):
+		return &apiv1alpha1.NamespaceUsageApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NodeFreeze"):
+		return &apiv1alpha1.NodeFreezeApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NodeFreezeSpec"):
+		return &apiv1alpha1.NodeFreezeSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NodeFreezeStatus"):
+		return &apiv1alpha1.NodeFreezeStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("OperationError"):
+		return &apiv1alpha1.OperationErrorApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PostUnfreezeStatus"):
+		return &apiv1alpha1.PostUnfreezeStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PreemptedFreeze"):
+		return &apiv1alpha1.PreemptedFreezeApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Propagation"):
+		return &apiv1alpha1.PropagationApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ResourcesFreed"):
+		return &apiv1alpha1.ResourcesFreedApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RestoreProgress"):
+		return &apiv1alpha1.RestoreProgressApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RetryCount"):
+		return &apiv1alpha1.RetryCountApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ScaledObjectSnapshot"):
+		return &apiv1alpha1.ScaledObjectSnapshotApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Standby"):
+		return &apiv1alpha1.StandbyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("StandbyStatus"):
+		return &apiv1alpha1.StandbyStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("StatusTargetRef"):
+		return &apiv1alpha1.StatusTargetRefApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("UnfreezeStrategy"):
+		return &apiv1alpha1.UnfreezeStrategyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("UnfreezeWindow"):
+		return &apiv1alpha1.UnfreezeWindowApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VPASnapshot"):
+		return &apiv1alpha1.VPASnapshotApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("WakeOnRequest"):
+		return &apiv1alpha1.WakeOnRequestApplyConfiguration{}
+
+	}
+	return nil
+}
+
+func NewTypeConverter(scheme *runtime.Scheme) *testing.TypeConverter {
+	return &testing.TypeConverter{Scheme: scheme, TypeResolver: internal.Parser()}
+}
@@ -0,0 +1,80 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	applyv1alpha1 "github.com/boolfixer/deployment-freezer/pkg/client/applyconfiguration/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/pkg/client/clientset/versioned/fake"
+	"github.com/boolfixer/deployment-freezer/pkg/client/informers/externalversions"
+)
+
+func TestGeneratedClient(t *testing.T) {
+	cs := fake.NewSimpleClientset()
+	factory := externalversions.NewSharedInformerFactory(cs, time.Minute)
+	informer := factory.Freezer().V1alpha1().DeploymentFreezers()
+	lister := informer.Lister()
+	informer.Informer()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	factory.Start(ctx.Done())
+	defer factory.Shutdown()
+	defer cancel()
+	factory.WaitForCacheSync(ctx.Done())
+
+	dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "checkout"}}
+	dfz.Spec.TargetRef.Name = "checkout"
+	_, err := cs.FreezerV1alpha1().DeploymentFreezers("shop").Create(ctx, dfz, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		got, err := lister.DeploymentFreezers("shop").Get("checkout")
+		return err == nil && got.Spec.TargetRef.Name == "checkout"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	dfz.Status.Phase = freezerv1alpha1.PhaseFrozen
+	_, err = cs.FreezerV1alpha1().DeploymentFreezers("shop").UpdateStatus(ctx, dfz, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	policies, err := cs.FreezerV1alpha1().FreezerPolicies().List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, policies.Items)
+
+	all, err := lister.List(labels.Everything())
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+}
+
+func TestApplyConfiguration(t *testing.T) {
+	ac := applyv1alpha1.DeploymentFreezer("checkout", "shop").
+		WithSpec(applyv1alpha1.DeploymentFreezerSpec().
+			WithTargetRef(applyv1alpha1.DeploymentTargetRef().WithName("checkout")).
+			WithDurationSeconds(1800))
+
+	// Only the fields set on the configuration are sent, so the patch claims nothing else.
+	data, err := json.Marshal(ac)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"kind": "DeploymentFreezer",
+		"apiVersion": "apps.boolfixer.dev/v1alpha1",
+		"metadata": {"name": "checkout", "namespace": "shop"},
+		"spec": {"targetRef": {"name": "checkout"}, "durationSeconds": 1800}
+	}`, string(data))
+
+	dfz := &freezerv1alpha1.DeploymentFreezer{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "checkout"}}
+	dfz.Spec.TargetRef.Name = "checkout"
+	dfz.Spec.DurationSeconds = 600
+	cs := fake.NewSimpleClientset(dfz)
+	got, err := cs.FreezerV1alpha1().DeploymentFreezers("shop").
+		Apply(t.Context(), ac, metav1.ApplyOptions{FieldManager: "release-bot"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1800), got.Spec.DurationSeconds)
+}
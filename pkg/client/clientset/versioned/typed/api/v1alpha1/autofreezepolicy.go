@@ -0,0 +1,73 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	applyconfigurationapiv1alpha1 "github.com/boolfixer/deployment-freezer/pkg/client/applyconfiguration/api/v1alpha1"
+	scheme "github.com/boolfixer/deployment-freezer/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// AutoFreezePoliciesGetter has a method to return a AutoFreezePolicyInterface.
+// A group's client should implement this interface.
+type AutoFreezePoliciesGetter interface {
+	AutoFreezePolicies(namespace string) AutoFreezePolicyInterface
+}
+
+// AutoFreezePolicyInterface has methods to work with AutoFreezePolicy resources.
+type AutoFreezePolicyInterface interface {
+	Create(ctx context.Context, autoFreezePolicy *apiv1alpha1.AutoFreezePolicy, opts v1.CreateOptions) (*apiv1alpha1.AutoFreezePolicy, error)
+	Update(ctx context.Context, autoFreezePolicy *apiv1alpha1.AutoFreezePolicy, opts v1.UpdateOptions) (*apiv1alpha1.AutoFreezePolicy, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, autoFreezePolicy *apiv1alpha1.AutoFreezePolicy, opts v1.UpdateOptions) (*apiv1alpha1.AutoFreezePolicy, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*apiv1alpha1.AutoFreezePolicy, error)
+	List(ctx context.Context, opts v1.ListOptions) (*apiv1alpha1.AutoFreezePolicyList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apiv1alpha1.AutoFreezePolicy, err error)
+	Apply(ctx context.Context, autoFreezePolicy *applyconfigurationapiv1alpha1.AutoFreezePolicyApplyConfiguration, opts v1.ApplyOptions) (result *apiv1alpha1.AutoFreezePolicy, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, autoFreezePolicy *applyconfigurationapiv1alpha1.AutoFreezePolicyApplyConfiguration, opts v1.ApplyOptions) (result *apiv1alpha1.AutoFreezePolicy, err error)
+	AutoFreezePolicyExpansion
+}
+
+// autoFreezePolicies implements AutoFreezePolicyInterface
+type autoFreezePolicies struct {
+	*gentype.ClientWithListAndApply[*apiv1alpha1.AutoFreezePolicy, *apiv1alpha1.AutoFreezePolicyList, *applyconfigurationapiv1alpha1.AutoFreezePolicyApplyConfiguration]
+}
+
+// newAutoFreezePolicies returns a AutoFreezePolicies
+func newAutoFreezePolicies(c *FreezerV1alpha1Client, namespace string) *autoFreezePolicies {
+	return &autoFreezePolicies{
+		gentype.NewClientWithListAndApply[*apiv1alpha1.AutoFreezePolicy, *apiv1alpha1.AutoFreezePolicyList, *applyconfigurationapiv1alpha1.AutoFreezePolicyApplyConfiguration](
+			"autofreezepolicies",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *apiv1alpha1.AutoFreezePolicy { return &apiv1alpha1.AutoFreezePolicy{} },
+			func() *apiv1alpha1.AutoFreezePolicyList { return &apiv1alpha1.AutoFreezePolicyList{} },
+		),
+	}
+}
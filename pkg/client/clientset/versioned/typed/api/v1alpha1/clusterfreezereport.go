@@ -0,0 +1,73 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	applyconfigurationapiv1alpha1 "github.com/boolfixer/deployment-freezer/pkg/client/applyconfiguration/api/v1alpha1"
+	scheme "github.com/boolfixer/deployment-freezer/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// ClusterFreezeReportsGetter has a method to return a ClusterFreezeReportInterface.
+// A group's client should implement this interface.
+type ClusterFreezeReportsGetter interface {
+	ClusterFreezeReports() ClusterFreezeReportInterface
+}
+
+// ClusterFreezeReportInterface has methods to work with ClusterFreezeReport resources.
+type ClusterFreezeReportInterface interface {
+	Create(ctx context.Context, clusterFreezeReport *apiv1alpha1.ClusterFreezeReport, opts v1.CreateOptions) (*apiv1alpha1.ClusterFreezeReport, error)
+	Update(ctx context.Context, clusterFreezeReport *apiv1alpha1.ClusterFreezeReport, opts v1.UpdateOptions) (*apiv1alpha1.ClusterFreezeReport, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, clusterFreezeReport *apiv1alpha1.ClusterFreezeReport, opts v1.UpdateOptions) (*apiv1alpha1.ClusterFreezeReport, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*apiv1alpha1.ClusterFreezeReport, error)
+	List(ctx context.Context, opts v1.ListOptions) (*apiv1alpha1.ClusterFreezeReportList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apiv1alpha1.ClusterFreezeReport, err error)
+	Apply(ctx context.Context, clusterFreezeReport *applyconfigurationapiv1alpha1.ClusterFreezeReportApplyConfiguration, opts v1.ApplyOptions) (result *apiv1alpha1.ClusterFreezeReport, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, clusterFreezeReport *applyconfigurationapiv1alpha1.ClusterFreezeReportApplyConfiguration, opts v1.ApplyOptions) (result *apiv1alpha1.ClusterFreezeReport, err error)
+	ClusterFreezeReportExpansion
+}
+
+// clusterFreezeReports implements ClusterFreezeReportInterface
+type clusterFreezeReports struct {
+	*gentype.ClientWithListAndApply[*apiv1alpha1.ClusterFreezeReport, *apiv1alpha1.ClusterFreezeReportList, *applyconfigurationapiv1alpha1.ClusterFreezeReportApplyConfiguration]
+}
+
+// newClusterFreezeReports returns a ClusterFreezeReports
+func newClusterFreezeReports(c *FreezerV1alpha1Client) *clusterFreezeReports {
+	return &clusterFreezeReports{
+		gentype.NewClientWithListAndApply[*apiv1alpha1.ClusterFreezeReport, *apiv1alpha1.ClusterFreezeReportList, *applyconfigurationapiv1alpha1.ClusterFreezeReportApplyConfiguration](
+			"clusterfreezereports",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			"",
+			func() *apiv1alpha1.ClusterFreezeReport { return &apiv1alpha1.ClusterFreezeReport{} },
+			func() *apiv1alpha1.ClusterFreezeReportList { return &apiv1alpha1.ClusterFreezeReportList{} },
+		),
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	applyconfigurationapiv1alpha1 "github.com/boolfixer/deployment-freezer/pkg/client/applyconfiguration/api/v1alpha1"
+	scheme "github.com/boolfixer/deployment-freezer/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// DeploymentFreezersGetter has a method to return a DeploymentFreezerInterface.
+// A group's client should implement this interface.
+type DeploymentFreezersGetter interface {
+	DeploymentFreezers(namespace string) DeploymentFreezerInterface
+}
+
+// DeploymentFreezerInterface has methods to work with DeploymentFreezer resources.
+type DeploymentFreezerInterface interface {
+	Create(ctx context.Context, deploymentFreezer *apiv1alpha1.DeploymentFreezer, opts v1.CreateOptions) (*apiv1alpha1.DeploymentFreezer, error)
+	Update(ctx context.Context, deploymentFreezer *apiv1alpha1.DeploymentFreezer, opts v1.UpdateOptions) (*apiv1alpha1.DeploymentFreezer, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, deploymentFreezer *apiv1alpha1.DeploymentFreezer, opts v1.UpdateOptions) (*apiv1alpha1.DeploymentFreezer, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*apiv1alpha1.DeploymentFreezer, error)
+	List(ctx context.Context, opts v1.ListOptions) (*apiv1alpha1.DeploymentFreezerList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *apiv1alpha1.DeploymentFreezer, err error)
+	Apply(ctx context.Context, deploymentFreezer *applyconfigurationapiv1alpha1.DeploymentFreezerApplyConfiguration, opts v1.ApplyOptions) (result *apiv1alpha1.DeploymentFreezer, err error)
+	// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+	ApplyStatus(ctx context.Context, deploymentFreezer *applyconfigurationapiv1alpha1.DeploymentFreezerApplyConfiguration, opts v1.ApplyOptions) (result *apiv1alpha1.DeploymentFreezer, err error)
+	DeploymentFreezerExpansion
+}
+
+// deploymentFreezers implements DeploymentFreezerInterface
+type deploymentFreezers struct {
+	*gentype.ClientWithListAndApply[*apiv1alpha1.DeploymentFreezer, *apiv1alpha1.DeploymentFreezerList, *applyconfigurationapiv1alpha1.DeploymentFreezerApplyConfiguration]
+}
+
+// newDeploymentFreezers returns a DeploymentFreezers
+func newDeploymentFreezers(c *FreezerV1alpha1Client, namespace string) *deploymentFreezers {
+	return &deploymentFreezers{
+		gentype.NewClientWithListAndApply[*apiv1alpha1.DeploymentFreezer, *apiv1alpha1.DeploymentFreezerList, *applyconfigurationapiv1alpha1.DeploymentFreezerApplyConfiguration](
+			"deploymentfreezers",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *apiv1alpha1.DeploymentFreezer { return &apiv1alpha1.DeploymentFreezer{} },
+			func() *apiv1alpha1.DeploymentFreezerList { return &apiv1alpha1.DeploymentFreezerList{} },
+		),
+	}
+}
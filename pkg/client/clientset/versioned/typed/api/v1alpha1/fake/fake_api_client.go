@@ -0,0 +1,55 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/boolfixer/deployment-freezer/pkg/client/clientset/versioned/typed/api/v1alpha1"
+	rest "k8s.io/client-go/rest"
+	testing "k8s.io/client-go/testing"
+)
+
+type FakeFreezerV1alpha1 struct {
+	*testing.Fake
+}
+
+func (c *FakeFreezerV1alpha1) AutoFreezePolicies(namespace string) v1alpha1.AutoFreezePolicyInterface {
+	return newFakeAutoFreezePolicies(c, namespace)
+}
+
+func (c *FakeFreezerV1alpha1) ClusterFreezeReports() v1alpha1.ClusterFreezeReportInterface {
+	return newFakeClusterFreezeReports(c)
+}
+
+func (c *FakeFreezerV1alpha1) DeploymentFreezers(namespace string) v1alpha1.DeploymentFreezerInterface {
+	return newFakeDeploymentFreezers(c, namespace)
+}
+
+func (c *FakeFreezerV1alpha1) FreezerPolicies() v1alpha1.FreezerPolicyInterface {
+	return newFakeFreezerPolicies(c)
+}
+
+func (c *FakeFreezerV1alpha1) NodeFreezes() v1alpha1.NodeFreezeInterface {
+	return newFakeNodeFreezes(c)
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *FakeFreezerV1alpha1) RESTClient() rest.Interface {
+	var ret *rest.RESTClient
+	return ret
+}
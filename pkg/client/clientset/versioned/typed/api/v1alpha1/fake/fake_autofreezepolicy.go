@@ -0,0 +1,52 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/pkg/client/applyconfiguration/api/v1alpha1"
+	typedapiv1alpha1 "github.com/boolfixer/deployment-freezer/pkg/client/clientset/versioned/typed/api/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeAutoFreezePolicies implements AutoFreezePolicyInterface
+type fakeAutoFreezePolicies struct {
+	*gentype.FakeClientWithListAndApply[*v1alpha1.AutoFreezePolicy, *v1alpha1.AutoFreezePolicyList, *apiv1alpha1.AutoFreezePolicyApplyConfiguration]
+	Fake *FakeFreezerV1alpha1
+}
+
+func newFakeAutoFreezePolicies(fake *FakeFreezerV1alpha1, namespace string) typedapiv1alpha1.AutoFreezePolicyInterface {
+	return &fakeAutoFreezePolicies{
+		gentype.NewFakeClientWithListAndApply[*v1alpha1.AutoFreezePolicy, *v1alpha1.AutoFreezePolicyList, *apiv1alpha1.AutoFreezePolicyApplyConfiguration](
+			fake.Fake,
+			namespace,
+			v1alpha1.SchemeGroupVersion.WithResource("autofreezepolicies"),
+			v1alpha1.SchemeGroupVersion.WithKind("AutoFreezePolicy"),
+			func() *v1alpha1.AutoFreezePolicy { return &v1alpha1.AutoFreezePolicy{} },
+			func() *v1alpha1.AutoFreezePolicyList { return &v1alpha1.AutoFreezePolicyList{} },
+			func(dst, src *v1alpha1.AutoFreezePolicyList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.AutoFreezePolicyList) []*v1alpha1.AutoFreezePolicy {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.AutoFreezePolicyList, items []*v1alpha1.AutoFreezePolicy) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
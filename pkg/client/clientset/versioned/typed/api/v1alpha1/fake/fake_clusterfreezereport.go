@@ -0,0 +1,52 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/pkg/client/applyconfiguration/api/v1alpha1"
+	typedapiv1alpha1 "github.com/boolfixer/deployment-freezer/pkg/client/clientset/versioned/typed/api/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeClusterFreezeReports implements ClusterFreezeReportInterface
+type fakeClusterFreezeReports struct {
+	*gentype.FakeClientWithListAndApply[*v1alpha1.ClusterFreezeReport, *v1alpha1.ClusterFreezeReportList, *apiv1alpha1.ClusterFreezeReportApplyConfiguration]
+	Fake *FakeFreezerV1alpha1
+}
+
+func newFakeClusterFreezeReports(fake *FakeFreezerV1alpha1) typedapiv1alpha1.ClusterFreezeReportInterface {
+	return &fakeClusterFreezeReports{
+		gentype.NewFakeClientWithListAndApply[*v1alpha1.ClusterFreezeReport, *v1alpha1.ClusterFreezeReportList, *apiv1alpha1.ClusterFreezeReportApplyConfiguration](
+			fake.Fake,
+			"",
+			v1alpha1.SchemeGroupVersion.WithResource("clusterfreezereports"),
+			v1alpha1.SchemeGroupVersion.WithKind("ClusterFreezeReport"),
+			func() *v1alpha1.ClusterFreezeReport { return &v1alpha1.ClusterFreezeReport{} },
+			func() *v1alpha1.ClusterFreezeReportList { return &v1alpha1.ClusterFreezeReportList{} },
+			func(dst, src *v1alpha1.ClusterFreezeReportList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.ClusterFreezeReportList) []*v1alpha1.ClusterFreezeReport {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.ClusterFreezeReportList, items []*v1alpha1.ClusterFreezeReport) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/pkg/client/applyconfiguration/api/v1alpha1"
+	typedapiv1alpha1 "github.com/boolfixer/deployment-freezer/pkg/client/clientset/versioned/typed/api/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeFreezerPolicies implements FreezerPolicyInterface
+type fakeFreezerPolicies struct {
+	*gentype.FakeClientWithListAndApply[*v1alpha1.FreezerPolicy, *v1alpha1.FreezerPolicyList, *apiv1alpha1.FreezerPolicyApplyConfiguration]
+	Fake *FakeFreezerV1alpha1
+}
+
+func newFakeFreezerPolicies(fake *FakeFreezerV1alpha1) typedapiv1alpha1.FreezerPolicyInterface {
+	return &fakeFreezerPolicies{
+		gentype.NewFakeClientWithListAndApply[*v1alpha1.FreezerPolicy, *v1alpha1.FreezerPolicyList, *apiv1alpha1.FreezerPolicyApplyConfiguration](
+			fake.Fake,
+			"",
+			v1alpha1.SchemeGroupVersion.WithResource("freezerpolicies"),
+			v1alpha1.SchemeGroupVersion.WithKind("FreezerPolicy"),
+			func() *v1alpha1.FreezerPolicy { return &v1alpha1.FreezerPolicy{} },
+			func() *v1alpha1.FreezerPolicyList { return &v1alpha1.FreezerPolicyList{} },
+			func(dst, src *v1alpha1.FreezerPolicyList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.FreezerPolicyList) []*v1alpha1.FreezerPolicy {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.FreezerPolicyList, items []*v1alpha1.FreezerPolicy) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/pkg/client/applyconfiguration/api/v1alpha1"
+	typedapiv1alpha1 "github.com/boolfixer/deployment-freezer/pkg/client/clientset/versioned/typed/api/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeNodeFreezes implements NodeFreezeInterface
+type fakeNodeFreezes struct {
+	*gentype.FakeClientWithListAndApply[*v1alpha1.NodeFreeze, *v1alpha1.NodeFreezeList, *apiv1alpha1.NodeFreezeApplyConfiguration]
+	Fake *FakeFreezerV1alpha1
+}
+
+func newFakeNodeFreezes(fake *FakeFreezerV1alpha1) typedapiv1alpha1.NodeFreezeInterface {
+	return &fakeNodeFreezes{
+		gentype.NewFakeClientWithListAndApply[*v1alpha1.NodeFreeze, *v1alpha1.NodeFreezeList, *apiv1alpha1.NodeFreezeApplyConfiguration](
+			fake.Fake,
+			"",
+			v1alpha1.SchemeGroupVersion.WithResource("nodefreezes"),
+			v1alpha1.SchemeGroupVersion.WithKind("NodeFreeze"),
+			func() *v1alpha1.NodeFreeze { return &v1alpha1.NodeFreeze{} },
+			func() *v1alpha1.NodeFreezeList { return &v1alpha1.NodeFreezeList{} },
+			func(dst, src *v1alpha1.NodeFreezeList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.NodeFreezeList) []*v1alpha1.NodeFreeze { return gentype.ToPointerSlice(list.Items) },
+			func(list *v1alpha1.NodeFreezeList, items []*v1alpha1.NodeFreeze) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
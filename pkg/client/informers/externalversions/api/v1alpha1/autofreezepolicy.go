@@ -0,0 +1,101 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+	time "time"
+
+	deploymentfreezerapiv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	versioned "github.com/boolfixer/deployment-freezer/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/boolfixer/deployment-freezer/pkg/client/informers/externalversions/internalinterfaces"
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/pkg/client/listers/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// AutoFreezePolicyInformer provides access to a shared informer and lister for
+// AutoFreezePolicies.
+type AutoFreezePolicyInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() apiv1alpha1.AutoFreezePolicyLister
+}
+
+type autoFreezePolicyInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewAutoFreezePolicyInformer constructs a new informer for AutoFreezePolicy type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewAutoFreezePolicyInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredAutoFreezePolicyInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredAutoFreezePolicyInformer constructs a new informer for AutoFreezePolicy type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredAutoFreezePolicyInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.FreezerV1alpha1().AutoFreezePolicies(namespace).List(context.Background(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.FreezerV1alpha1().AutoFreezePolicies(namespace).Watch(context.Background(), options)
+			},
+			ListWithContextFunc: func(ctx context.Context, options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.FreezerV1alpha1().AutoFreezePolicies(namespace).List(ctx, options)
+			},
+			WatchFuncWithContext: func(ctx context.Context, options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.FreezerV1alpha1().AutoFreezePolicies(namespace).Watch(ctx, options)
+			},
+		},
+		&deploymentfreezerapiv1alpha1.AutoFreezePolicy{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *autoFreezePolicyInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredAutoFreezePolicyInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *autoFreezePolicyInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&deploymentfreezerapiv1alpha1.AutoFreezePolicy{}, f.defaultInformer)
+}
+
+func (f *autoFreezePolicyInformer) Lister() apiv1alpha1.AutoFreezePolicyLister {
+	return apiv1alpha1.NewAutoFreezePolicyLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+	time "time"
+
+	deploymentfreezerapiv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	versioned "github.com/boolfixer/deployment-freezer/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/boolfixer/deployment-freezer/pkg/client/informers/externalversions/internalinterfaces"
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/pkg/client/listers/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ClusterFreezeReportInformer provides access to a shared informer and lister for
+// ClusterFreezeReports.
+type ClusterFreezeReportInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() apiv1alpha1.ClusterFreezeReportLister
+}
+
+type clusterFreezeReportInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewClusterFreezeReportInformer constructs a new informer for ClusterFreezeReport type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewClusterFreezeReportInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredClusterFreezeReportInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredClusterFreezeReportInformer constructs a new informer for ClusterFreezeReport type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredClusterFreezeReportInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.FreezerV1alpha1().ClusterFreezeReports().List(context.Background(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.FreezerV1alpha1().ClusterFreezeReports().Watch(context.Background(), options)
+			},
+			ListWithContextFunc: func(ctx context.Context, options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.FreezerV1alpha1().ClusterFreezeReports().List(ctx, options)
+			},
+			WatchFuncWithContext: func(ctx context.Context, options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.FreezerV1alpha1().ClusterFreezeReports().Watch(ctx, options)
+			},
+		},
+		&deploymentfreezerapiv1alpha1.ClusterFreezeReport{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *clusterFreezeReportInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredClusterFreezeReportInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *clusterFreezeReportInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&deploymentfreezerapiv1alpha1.ClusterFreezeReport{}, f.defaultInformer)
+}
+
+func (f *clusterFreezeReportInformer) Lister() apiv1alpha1.ClusterFreezeReportLister {
+	return apiv1alpha1.NewClusterFreezeReportLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+	time "time"
+
+	deploymentfreezerapiv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	versioned "github.com/boolfixer/deployment-freezer/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/boolfixer/deployment-freezer/pkg/client/informers/externalversions/internalinterfaces"
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/pkg/client/listers/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// DeploymentFreezerInformer provides access to a shared informer and lister for
+// DeploymentFreezers.
+type DeploymentFreezerInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() apiv1alpha1.DeploymentFreezerLister
+}
+
+type deploymentFreezerInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewDeploymentFreezerInformer constructs a new informer for DeploymentFreezer type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewDeploymentFreezerInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredDeploymentFreezerInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredDeploymentFreezerInformer constructs a new informer for DeploymentFreezer type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredDeploymentFreezerInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.FreezerV1alpha1().DeploymentFreezers(namespace).List(context.Background(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.FreezerV1alpha1().DeploymentFreezers(namespace).Watch(context.Background(), options)
+			},
+			ListWithContextFunc: func(ctx context.Context, options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.FreezerV1alpha1().DeploymentFreezers(namespace).List(ctx, options)
+			},
+			WatchFuncWithContext: func(ctx context.Context, options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.FreezerV1alpha1().DeploymentFreezers(namespace).Watch(ctx, options)
+			},
+		},
+		&deploymentfreezerapiv1alpha1.DeploymentFreezer{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *deploymentFreezerInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredDeploymentFreezerInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *deploymentFreezerInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&deploymentfreezerapiv1alpha1.DeploymentFreezer{}, f.defaultInformer)
+}
+
+func (f *deploymentFreezerInformer) Lister() apiv1alpha1.DeploymentFreezerLister {
+	return apiv1alpha1.NewDeploymentFreezerLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	context "context"
+	time "time"
+
+	deploymentfreezerapiv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	versioned "github.com/boolfixer/deployment-freezer/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/boolfixer/deployment-freezer/pkg/client/informers/externalversions/internalinterfaces"
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/pkg/client/listers/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// FreezerPolicyInformer provides access to a shared informer and lister for
+// FreezerPolicies.
+type FreezerPolicyInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() apiv1alpha1.FreezerPolicyLister
+}
+
+type freezerPolicyInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewFreezerPolicyInformer constructs a new informer for FreezerPolicy type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFreezerPolicyInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredFreezerPolicyInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredFreezerPolicyInformer constructs a new informer for FreezerPolicy type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredFreezerPolicyInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.FreezerV1alpha1().FreezerPolicies().List(context.Background(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.FreezerV1alpha1().FreezerPolicies().Watch(context.Background(), options)
+			},
+			ListWithContextFunc: func(ctx context.Context, options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.FreezerV1alpha1().FreezerPolicies().List(ctx, options)
+			},
+			WatchFuncWithContext: func(ctx context.Context, options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.FreezerV1alpha1().FreezerPolicies().Watch(ctx, options)
+			},
+		},
+		&deploymentfreezerapiv1alpha1.FreezerPolicy{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *freezerPolicyInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredFreezerPolicyInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *freezerPolicyInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&deploymentfreezerapiv1alpha1.FreezerPolicy{}, f.defaultInformer)
+}
+
+func (f *freezerPolicyInformer) Lister() apiv1alpha1.FreezerPolicyLister {
+	return apiv1alpha1.NewFreezerPolicyLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	internalinterfaces "github.com/boolfixer/deployment-freezer/pkg/client/informers/externalversions/internalinterfaces"
+)
+
+// Interface provides access to all the informers in this group version.
+type Interface interface {
+	// AutoFreezePolicies returns a AutoFreezePolicyInformer.
+	AutoFreezePolicies() AutoFreezePolicyInformer
+	// ClusterFreezeReports returns a ClusterFreezeReportInformer.
+	ClusterFreezeReports() ClusterFreezeReportInformer
+	// DeploymentFreezers returns a DeploymentFreezerInformer.
+	DeploymentFreezers() DeploymentFreezerInformer
+	// FreezerPolicies returns a FreezerPolicyInformer.
+	FreezerPolicies() FreezerPolicyInformer
+	// NodeFreezes returns a NodeFreezeInformer.
+	NodeFreezes() NodeFreezeInformer
+}
+
+type version struct {
+	factory          internalinterfaces.SharedInformerFactory
+	namespace        string
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// New returns a new Interface.
+func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakListOptions internalinterfaces.TweakListOptionsFunc) Interface {
+	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
+}
+
+// AutoFreezePolicies returns a AutoFreezePolicyInformer.
+func (v *version) AutoFreezePolicies() AutoFreezePolicyInformer {
+	return &autoFreezePolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ClusterFreezeReports returns a ClusterFreezeReportInformer.
+func (v *version) ClusterFreezeReports() ClusterFreezeReportInformer {
+	return &clusterFreezeReportInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// DeploymentFreezers returns a DeploymentFreezerInformer.
+func (v *version) DeploymentFreezers() DeploymentFreezerInformer {
+	return &deploymentFreezerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// FreezerPolicies returns a FreezerPolicyInformer.
+func (v *version) FreezerPolicies() FreezerPolicyInformer {
+	return &freezerPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// NodeFreezes returns a NodeFreezeInformer.
+func (v *version) NodeFreezes() NodeFreezeInformer {
+	return &nodeFreezeInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
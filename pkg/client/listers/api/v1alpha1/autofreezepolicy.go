@@ -0,0 +1,69 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// AutoFreezePolicyLister helps list AutoFreezePolicies.
+// All objects returned here must be treated as read-only.
+type AutoFreezePolicyLister interface {
+	// List lists all AutoFreezePolicies in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.AutoFreezePolicy, err error)
+	// AutoFreezePolicies returns an object that can list and get AutoFreezePolicies.
+	AutoFreezePolicies(namespace string) AutoFreezePolicyNamespaceLister
+	AutoFreezePolicyListerExpansion
+}
+
+// autoFreezePolicyLister implements the AutoFreezePolicyLister interface.
+type autoFreezePolicyLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.AutoFreezePolicy]
+}
+
+// NewAutoFreezePolicyLister returns a new AutoFreezePolicyLister.
+func NewAutoFreezePolicyLister(indexer cache.Indexer) AutoFreezePolicyLister {
+	return &autoFreezePolicyLister{listers.New[*apiv1alpha1.AutoFreezePolicy](indexer, apiv1alpha1.Resource("autofreezepolicy"))}
+}
+
+// AutoFreezePolicies returns an object that can list and get AutoFreezePolicies.
+func (s *autoFreezePolicyLister) AutoFreezePolicies(namespace string) AutoFreezePolicyNamespaceLister {
+	return autoFreezePolicyNamespaceLister{listers.NewNamespaced[*apiv1alpha1.AutoFreezePolicy](s.ResourceIndexer, namespace)}
+}
+
+// AutoFreezePolicyNamespaceLister helps list and get AutoFreezePolicies.
+// All objects returned here must be treated as read-only.
+type AutoFreezePolicyNamespaceLister interface {
+	// List lists all AutoFreezePolicies in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.AutoFreezePolicy, err error)
+	// Get retrieves the AutoFreezePolicy from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*apiv1alpha1.AutoFreezePolicy, error)
+	AutoFreezePolicyNamespaceListerExpansion
+}
+
+// autoFreezePolicyNamespaceLister implements the AutoFreezePolicyNamespaceLister
+// interface.
+type autoFreezePolicyNamespaceLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.AutoFreezePolicy]
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ClusterFreezeReportLister helps list ClusterFreezeReports.
+// All objects returned here must be treated as read-only.
+type ClusterFreezeReportLister interface {
+	// List lists all ClusterFreezeReports in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.ClusterFreezeReport, err error)
+	// Get retrieves the ClusterFreezeReport from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*apiv1alpha1.ClusterFreezeReport, error)
+	ClusterFreezeReportListerExpansion
+}
+
+// clusterFreezeReportLister implements the ClusterFreezeReportLister interface.
+type clusterFreezeReportLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.ClusterFreezeReport]
+}
+
+// NewClusterFreezeReportLister returns a new ClusterFreezeReportLister.
+func NewClusterFreezeReportLister(indexer cache.Indexer) ClusterFreezeReportLister {
+	return &clusterFreezeReportLister{listers.New[*apiv1alpha1.ClusterFreezeReport](indexer, apiv1alpha1.Resource("clusterfreezereport"))}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	apiv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// DeploymentFreezerLister helps list DeploymentFreezers.
+// All objects returned here must be treated as read-only.
+type DeploymentFreezerLister interface {
+	// List lists all DeploymentFreezers in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.DeploymentFreezer, err error)
+	// DeploymentFreezers returns an object that can list and get DeploymentFreezers.
+	DeploymentFreezers(namespace string) DeploymentFreezerNamespaceLister
+	DeploymentFreezerListerExpansion
+}
+
+// deploymentFreezerLister implements the DeploymentFreezerLister interface.
+type deploymentFreezerLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.DeploymentFreezer]
+}
+
+// NewDeploymentFreezerLister returns a new DeploymentFreezerLister.
+func NewDeploymentFreezerLister(indexer cache.Indexer) DeploymentFreezerLister {
+	return &deploymentFreezerLister{listers.New[*apiv1alpha1.DeploymentFreezer](indexer, apiv1alpha1.Resource("deploymentfreezer"))}
+}
+
+// DeploymentFreezers returns an object that can list and get DeploymentFreezers.
+func (s *deploymentFreezerLister) DeploymentFreezers(namespace string) DeploymentFreezerNamespaceLister {
+	return deploymentFreezerNamespaceLister{listers.NewNamespaced[*apiv1alpha1.DeploymentFreezer](s.ResourceIndexer, namespace)}
+}
+
+// DeploymentFreezerNamespaceLister helps list and get DeploymentFreezers.
+// All objects returned here must be treated as read-only.
+type DeploymentFreezerNamespaceLister interface {
+	// List lists all DeploymentFreezers in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*apiv1alpha1.DeploymentFreezer, err error)
+	// Get retrieves the DeploymentFreezer from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*apiv1alpha1.DeploymentFreezer, error)
+	DeploymentFreezerNamespaceListerExpansion
+}
+
+// deploymentFreezerNamespaceLister implements the DeploymentFreezerNamespaceLister
+// interface.
+type deploymentFreezerNamespaceLister struct {
+	listers.ResourceIndexer[*apiv1alpha1.DeploymentFreezer]
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2025 boolfixer.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// AutoFreezePolicyListerExpansion allows custom methods to be added to
+// AutoFreezePolicyLister.
+type AutoFreezePolicyListerExpansion interface{}
+
+// AutoFreezePolicyNamespaceListerExpansion allows custom methods to be added to
+// AutoFreezePolicyNamespaceLister.
+type AutoFreezePolicyNamespaceListerExpansion interface{}
+
+// ClusterFreezeReportListerExpansion allows custom methods to be added to
+// ClusterFreezeReportLister.
+type ClusterFreezeReportListerExpansion interface{}
+
+// DeploymentFreezerListerExpansion allows custom methods to be added to
+// DeploymentFreezerLister.
+type DeploymentFreezerListerExpansion interface{}
+
+// DeploymentFreezerNamespaceListerExpansion allows custom methods to be added to
+// DeploymentFreezerNamespaceLister.
+type DeploymentFreezerNamespaceListerExpansion interface{}
+
+// FreezerPolicyListerExpansion allows custom methods to be added to
+// FreezerPolicyLister.
+type FreezerPolicyListerExpansion interface{}
+
+// NodeFreezeListerExpansion allows custom methods to be added to
+// NodeFreezeLister.
+type NodeFreezeListerExpansion interface{}
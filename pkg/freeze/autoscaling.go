@@ -0,0 +1,229 @@
+package freeze
+
+import (
+	"context"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// annoKEDAPaused pauses a KEDA ScaledObject at the current replica count.
+	annoKEDAPaused = "autoscaling.keda.sh/paused"
+	// labelKEDAScaledObject marks the HPAs KEDA creates for its ScaledObjects.
+	labelKEDAScaledObject = "scaledobject.keda.sh/name"
+	// vpaUpdateModeOff keeps a VerticalPodAutoscaler recommending without evicting Pods.
+	vpaUpdateModeOff = "Off"
+)
+
+var (
+	scaledObjectGVK = schema.GroupVersionKind{Group: "keda.sh", Version: "v1alpha1", Kind: "ScaledObject"}
+	vpaGVK          = schema.GroupVersionKind{Group: "autoscaling.k8s.io", Version: "v1", Kind: "VerticalPodAutoscaler"}
+)
+
+// SnapshotAutoscaling records the HPA, KEDA ScaledObject and VerticalPodAutoscaler that scale obj,
+// a workload of the given kind, for Freezable implementations. Autoscalers are read uncached: they are only needed
+// when a freeze starts and ends.
+func (f *Freezer) SnapshotAutoscaling(
+	ctx context.Context,
+	kind string,
+	obj client.Object,
+) (*freezerv1alpha1.AutoscalingSnapshot, error) {
+	snap := &freezerv1alpha1.AutoscalingSnapshot{}
+
+	var hpas autoscalingv2.HorizontalPodAutoscalerList
+	if err := f.reader().List(ctx, &hpas, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil, err
+	}
+	for _, hpa := range hpas.Items {
+		ref := hpa.Spec.ScaleTargetRef
+		// HPAs generated by KEDA are restored by KEDA itself.
+		if ref.Kind != kind || ref.Name != obj.GetName() || hpa.Labels[labelKEDAScaledObject] != "" {
+			continue
+		}
+		snap.HPA = &freezerv1alpha1.HPASnapshot{
+			Name:        hpa.Name,
+			MinReplicas: hpa.Spec.MinReplicas,
+			MaxReplicas: hpa.Spec.MaxReplicas,
+		}
+		break
+	}
+
+	scaledObjects, err := f.listOptional(ctx, scaledObjectGVK, obj.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	for _, so := range scaledObjects {
+		targetKind, _, _ := unstructured.NestedString(so.Object, "spec", "scaleTargetRef", "kind")
+		name, _, _ := unstructured.NestedString(so.Object, "spec", "scaleTargetRef", "name")
+		// KEDA defaults the target kind to Deployment.
+		if targetKind == "" {
+			targetKind = "Deployment"
+		}
+		if targetKind != kind || name != obj.GetName() {
+			continue
+		}
+		snap.ScaledObject = &freezerv1alpha1.ScaledObjectSnapshot{Name: so.GetName()}
+		if v, ok := so.GetAnnotations()[annoKEDAPaused]; ok {
+			snap.ScaledObject.Paused = ptr.To(v)
+		}
+		break
+	}
+
+	vpas, err := f.listOptional(ctx, vpaGVK, obj.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	for _, vpa := range vpas {
+		targetKind, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "kind")
+		name, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "name")
+		if targetKind != kind || name != obj.GetName() {
+			continue
+		}
+		snap.VPA = &freezerv1alpha1.VPASnapshot{Name: vpa.GetName()}
+		if mode, ok, _ := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode"); ok {
+			snap.VPA.UpdateMode = ptr.To(mode)
+		}
+		break
+	}
+	return snap, nil
+}
+
+// listOptional lists the objects of a CRD-backed kind in namespace; nothing is listed if the CRD
+// is not installed.
+func (f *Freezer) listOptional(
+	ctx context.Context,
+	gvk schema.GroupVersionKind,
+	namespace string,
+) ([]unstructured.Unstructured, error) {
+	var list unstructured.UnstructuredList
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := f.reader().List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// PauseAutoscaling pauses the snapshotted ScaledObject so KEDA does not scale the workload
+// back up from zero while it is frozen, and turns the snapshotted VPA's updates off so it does
+// not recreate Pods. HPAs need no pause: they do not scale a workload that is at zero.
+func (f *Freezer) PauseAutoscaling(
+	ctx context.Context,
+	namespace string,
+	snap *freezerv1alpha1.AutoscalingSnapshot,
+	opts ...client.PatchOption,
+) error {
+	if snap == nil {
+		return nil
+	}
+	if snap.ScaledObject != nil {
+		if err := f.setScaledObjectPaused(ctx, namespace, snap.ScaledObject.Name, ptr.To("true"), opts...); err != nil {
+			return err
+		}
+	}
+	if snap.VPA != nil {
+		if err := f.setVPAUpdateMode(ctx, namespace, snap.VPA.Name, ptr.To(vpaUpdateModeOff), opts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestoreAutoscaling puts the snapshotted ScaledObject pause state and VPA update mode back. The
+// HPA is only recorded: the freeze does not change it, so bounds edited during the freeze are
+// kept. Every step is idempotent, so a failure can simply be retried.
+func (f *Freezer) RestoreAutoscaling(
+	ctx context.Context,
+	namespace string,
+	snap *freezerv1alpha1.AutoscalingSnapshot,
+	opts ...client.PatchOption,
+) error {
+	if snap == nil {
+		return nil
+	}
+	if snap.ScaledObject != nil {
+		if err := f.setScaledObjectPaused(ctx, namespace, snap.ScaledObject.Name, snap.ScaledObject.Paused, opts...); err != nil {
+			return err
+		}
+	}
+	if snap.VPA != nil {
+		if err := f.setVPAUpdateMode(ctx, namespace, snap.VPA.Name, snap.VPA.UpdateMode, opts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setScaledObjectPaused sets the ScaledObject's pause annotation to value, or removes it if value is nil.
+func (f *Freezer) setScaledObjectPaused(
+	ctx context.Context,
+	namespace, name string,
+	value *string,
+	opts ...client.PatchOption,
+) error {
+	so := &unstructured.Unstructured{}
+	so.SetGroupVersionKind(scaledObjectGVK)
+	if err := f.reader().Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, so); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return client.IgnoreNotFound(err)
+	}
+
+	annos := so.GetAnnotations()
+	current, ok := annos[annoKEDAPaused]
+	if (value == nil && !ok) || (value != nil && ok && current == *value) {
+		return nil
+	}
+	orig := so.DeepCopy()
+	if annos == nil {
+		annos = map[string]string{}
+	}
+	if value == nil {
+		delete(annos, annoKEDAPaused)
+	} else {
+		annos[annoKEDAPaused] = *value
+	}
+	so.SetAnnotations(annos)
+	return f.Client.Patch(ctx, so, client.MergeFrom(orig), opts...)
+}
+
+// setVPAUpdateMode sets the VerticalPodAutoscaler's spec.updatePolicy.updateMode to value, or
+// removes it if value is nil.
+func (f *Freezer) setVPAUpdateMode(
+	ctx context.Context,
+	namespace, name string,
+	value *string,
+	opts ...client.PatchOption,
+) error {
+	vpa := &unstructured.Unstructured{}
+	vpa.SetGroupVersionKind(vpaGVK)
+	if err := f.reader().Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, vpa); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return client.IgnoreNotFound(err)
+	}
+
+	current, ok, _ := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode")
+	if (value == nil && !ok) || (value != nil && ok && current == *value) {
+		return nil
+	}
+	orig := vpa.DeepCopy()
+	if value == nil {
+		unstructured.RemoveNestedField(vpa.Object, "spec", "updatePolicy", "updateMode")
+	} else if err := unstructured.SetNestedField(vpa.Object, *value, "spec", "updatePolicy", "updateMode"); err != nil {
+		return err
+	}
+	return f.Client.Patch(ctx, vpa, client.MergeFrom(orig), opts...)
+}
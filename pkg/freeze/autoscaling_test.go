@@ -0,0 +1,145 @@
+package freeze
+
+import (
+	"context"
+	"testing"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAutoscalingSnapshot(t *testing.T) {
+	deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web"}}
+	deploy.Spec.Paused = true
+
+	newHPA := func(name, target string, labels map[string]string) *autoscalingv2.HorizontalPodAutoscaler {
+		return &autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, Labels: labels},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: target},
+				MinReplicas:    ptr.To(int32(2)),
+				MaxReplicas:    10,
+			},
+		}
+	}
+	newScaledObject := func(name, target string, annos map[string]string) *unstructured.Unstructured {
+		so := &unstructured.Unstructured{}
+		so.SetGroupVersionKind(scaledObjectGVK)
+		so.SetNamespace("ns")
+		so.SetName(name)
+		so.SetAnnotations(annos)
+		_ = unstructured.SetNestedField(so.Object, target, "spec", "scaleTargetRef", "name")
+		return so
+	}
+	newVPA := func(name, target string, mode *string) *unstructured.Unstructured {
+		vpa := &unstructured.Unstructured{}
+		vpa.SetGroupVersionKind(vpaGVK)
+		vpa.SetNamespace("ns")
+		vpa.SetName(name)
+		_ = unstructured.SetNestedField(vpa.Object, "Deployment", "spec", "targetRef", "kind")
+		_ = unstructured.SetNestedField(vpa.Object, target, "spec", "targetRef", "name")
+		if mode != nil {
+			_ = unstructured.SetNestedField(vpa.Object, *mode, "spec", "updatePolicy", "updateMode")
+		}
+		return vpa
+	}
+	vpaUpdateMode := func(t *testing.T, f *Freezer, name string) (string, bool) {
+		vpa := &unstructured.Unstructured{}
+		vpa.SetGroupVersionKind(vpaGVK)
+		require.NoError(t, f.Client.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: name}, vpa))
+		mode, ok, _ := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode")
+		return mode, ok
+	}
+	newFreezer := func(objs ...client.Object) *Freezer {
+		c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(objs...).Build()
+		return &Freezer{Client: c}
+	}
+
+	t.Run("Take_RecordsTargetAutoscalers", func(t *testing.T) {
+		t.Parallel()
+		f := newFreezer(
+			newHPA("other", "api", nil),
+			newHPA("keda-hpa-web", "web", map[string]string{labelKEDAScaledObject: "web"}),
+			newHPA("web", "web", nil),
+			newScaledObject("web", "web", map[string]string{annoKEDAPaused: "false"}),
+			newVPA("api", "api", nil),
+			newVPA("web", "web", ptr.To("Recreate")),
+		)
+
+		target, err := f.Target(deploy)
+		require.NoError(t, err)
+		snap, err := target.Snapshot(context.Background())
+		require.NoError(t, err)
+		assert.True(t, snap.Paused)
+		require.NotNil(t, snap.HPA)
+		assert.Equal(t, "web", snap.HPA.Name)
+		assert.Equal(t, int32(10), snap.HPA.MaxReplicas)
+		require.NotNil(t, snap.ScaledObject)
+		assert.Equal(t, ptr.To("false"), snap.ScaledObject.Paused)
+		assert.Equal(t, &freezerv1alpha1.VPASnapshot{Name: "web", UpdateMode: ptr.To("Recreate")}, snap.VPA)
+	})
+
+	t.Run("Restore_PutsKEDAPauseBackAndKeepsHPA", func(t *testing.T) {
+		t.Parallel()
+		hpa := newHPA("web", "web", nil)
+		hpa.Spec.MinReplicas = ptr.To(int32(5))
+		f := newFreezer(hpa, newScaledObject("web", "web", map[string]string{annoKEDAPaused: "true"}))
+		snap := &freezerv1alpha1.AutoscalingSnapshot{
+			HPA:          &freezerv1alpha1.HPASnapshot{Name: "web", MinReplicas: ptr.To(int32(2)), MaxReplicas: 10},
+			ScaledObject: &freezerv1alpha1.ScaledObjectSnapshot{Name: "web"},
+		}
+
+		require.NoError(t, f.RestoreAutoscaling(context.Background(), "ns", snap))
+
+		var got autoscalingv2.HorizontalPodAutoscaler
+		require.NoError(t, f.Client.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "web"}, &got))
+		assert.Equal(t, ptr.To(int32(5)), got.Spec.MinReplicas, "changed during the freeze, not by it")
+		so := &unstructured.Unstructured{}
+		so.SetGroupVersionKind(scaledObjectGVK)
+		require.NoError(t, f.Client.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "web"}, so))
+		assert.NotContains(t, so.GetAnnotations(), annoKEDAPaused)
+	})
+
+	t.Run("Pause_PausesScaledObject", func(t *testing.T) {
+		t.Parallel()
+		f := newFreezer(newScaledObject("web", "web", nil))
+		snap := &freezerv1alpha1.AutoscalingSnapshot{ScaledObject: &freezerv1alpha1.ScaledObjectSnapshot{Name: "web"}}
+
+		require.NoError(t, f.PauseAutoscaling(context.Background(), "ns", snap))
+		so := &unstructured.Unstructured{}
+		so.SetGroupVersionKind(scaledObjectGVK)
+		require.NoError(t, f.Client.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "web"}, so))
+		assert.Equal(t, "true", so.GetAnnotations()[annoKEDAPaused])
+	})
+
+	t.Run("Pause_TurnsVPAOffAndRestorePutsItBack", func(t *testing.T) {
+		t.Parallel()
+		f := newFreezer(newVPA("web", "web", ptr.To("Auto")), newVPA("api", "api", nil))
+		snap := &freezerv1alpha1.AutoscalingSnapshot{VPA: &freezerv1alpha1.VPASnapshot{Name: "web", UpdateMode: ptr.To("Auto")}}
+
+		require.NoError(t, f.PauseAutoscaling(context.Background(), "ns", snap))
+		mode, _ := vpaUpdateMode(t, f, "web")
+		assert.Equal(t, "Off", mode)
+
+		require.NoError(t, f.RestoreAutoscaling(context.Background(), "ns", snap))
+		mode, _ = vpaUpdateMode(t, f, "web")
+		assert.Equal(t, "Auto", mode)
+
+		// A VPA without an update mode gets none back.
+		unset := &freezerv1alpha1.AutoscalingSnapshot{VPA: &freezerv1alpha1.VPASnapshot{Name: "api"}}
+		require.NoError(t, f.PauseAutoscaling(context.Background(), "ns", unset))
+		require.NoError(t, f.RestoreAutoscaling(context.Background(), "ns", unset))
+		_, ok := vpaUpdateMode(t, f, "api")
+		assert.False(t, ok)
+	})
+}
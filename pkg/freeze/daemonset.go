@@ -0,0 +1,148 @@
+package freeze
+
+import (
+	"context"
+	"fmt"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NodeSelectorFrozen is the node selector a frozen DaemonSet's pod template carries. No node has
+// the label, so the DaemonSet controller removes every Pod; removing the key restores the
+// original selector.
+const NodeSelectorFrozen = "apps.boolfixer.dev/frozen-daemonset"
+
+var daemonSetGVK = appsv1.SchemeGroupVersion.WithKind("DaemonSet")
+
+func init() {
+	Register(daemonSetGVK.GroupKind(), func(f *Freezer, obj client.Object) (Freezable, error) {
+		ds, ok := obj.(*appsv1.DaemonSet)
+		if !ok {
+			return nil, fmt.Errorf("freeze: want *appsv1.DaemonSet, got %T", obj)
+		}
+		return &daemonSetTarget{f: f, ds: ds}, nil
+	})
+}
+
+// daemonSetTarget is the built-in plugin for DaemonSets. A DaemonSet has no replica count, so
+// scaling to zero adds NodeSelectorFrozen to its pod template and any other count removes it.
+// GetReplicas reports the number of nodes the DaemonSet is scheduled on, or 0 while frozen.
+// The claim and the selector are written with one patch; the scale subresource does not exist,
+// so lean RBAC mode patches the spec too.
+type daemonSetTarget struct {
+	f  *Freezer
+	ds *appsv1.DaemonSet
+}
+
+var _ Batcher = &daemonSetTarget{}
+var _ Planner = &daemonSetTarget{}
+
+func (t *daemonSetTarget) Object() client.Object { return t.ds }
+func (t *daemonSetTarget) Owner() string         { return Holder(t.ds) }
+
+func (t *daemonSetTarget) GetReplicas() int32 {
+	if DaemonSetFrozen(t.ds) {
+		return 0
+	}
+	return max(t.ds.Status.DesiredNumberScheduled, 1)
+}
+
+// Drained reports whether the controller has seen the frozen template and removed every Pod,
+// including those on nodes the template no longer matches.
+func (t *daemonSetTarget) Drained() bool {
+	s := t.ds.Status
+	return DaemonSetFrozen(t.ds) && s.ObservedGeneration >= t.ds.Generation &&
+		s.CurrentNumberScheduled == 0 && s.NumberMisscheduled == 0 && s.NumberReady == 0
+}
+
+func (t *daemonSetTarget) ScaleTo(ctx context.Context, replicas int32, opts ...client.PatchOption) error {
+	return t.Apply(ctx, Change{Replicas: &replicas}, opts...)
+}
+
+func (t *daemonSetTarget) AcquireOwnership(ctx context.Context, owner string, opts ...client.PatchOption) error {
+	return t.Apply(ctx, Change{FrozenBy: &owner}, opts...)
+}
+
+// Apply writes the claim and the node selector of c; Paused is ignored.
+func (t *daemonSetTarget) Apply(ctx context.Context, c Change, opts ...client.PatchOption) error {
+	return t.update(ctx, t.ds, c, !isDryRun(opts), opts...)
+}
+
+func (t *daemonSetTarget) Plan(ctx context.Context, c Change, opts ...client.PatchOption) (client.Object, error) {
+	planned := t.ds.DeepCopy()
+	if err := t.update(ctx, planned, c, true, append(opts, client.DryRunAll)...); err != nil {
+		return nil, err
+	}
+	return planned, nil
+}
+
+// Snapshot records nothing: autoscalers cannot target a DaemonSet.
+func (t *daemonSetTarget) Snapshot(context.Context) (*freezerv1alpha1.AutoscalingSnapshot, error) {
+	return &freezerv1alpha1.AutoscalingSnapshot{}, nil
+}
+
+func (t *daemonSetTarget) Restore(context.Context, *freezerv1alpha1.AutoscalingSnapshot, ...client.PatchOption) error {
+	return nil
+}
+
+func (t *daemonSetTarget) update(
+	ctx context.Context,
+	ds *appsv1.DaemonSet,
+	c Change,
+	adopt bool,
+	opts ...client.PatchOption,
+) error {
+	if c.FrozenBy == nil && c.Replicas == nil {
+		return nil
+	}
+	latest, err := t.f.lockedPatch(ctx, ds, c.FrozenBy, func(obj client.Object) error {
+		latest := obj.(*appsv1.DaemonSet)
+		if c.FrozenBy != nil {
+			SetFrozenBy(latest, *c.FrozenBy)
+		}
+		if c.Replicas != nil {
+			setNodeSelectorFrozen(&latest.Spec.Template, *c.Replicas == 0)
+		}
+		return nil
+	}, opts...)
+	if err != nil {
+		return err
+	}
+	if adopt {
+		*ds = *latest.(*appsv1.DaemonSet)
+	}
+	return nil
+}
+
+// DaemonSetFrozen reports whether the pod template of ds carries NodeSelectorFrozen.
+func DaemonSetFrozen(ds *appsv1.DaemonSet) bool {
+	_, ok := ds.Spec.Template.Spec.NodeSelector[NodeSelectorFrozen]
+	return ok
+}
+
+// UnfrozenTemplate returns the pod template of ds without NodeSelectorFrozen, as it was before
+// the freeze.
+func UnfrozenTemplate(ds *appsv1.DaemonSet) corev1.PodTemplateSpec {
+	tpl := *ds.Spec.Template.DeepCopy()
+	setNodeSelectorFrozen(&tpl, false)
+	return tpl
+}
+
+// setNodeSelectorFrozen adds or removes NodeSelectorFrozen; a selector left empty is dropped, so
+// a template that had none is restored exactly.
+func setNodeSelectorFrozen(tpl *corev1.PodTemplateSpec, frozen bool) {
+	if !frozen {
+		delete(tpl.Spec.NodeSelector, NodeSelectorFrozen)
+		if len(tpl.Spec.NodeSelector) == 0 {
+			tpl.Spec.NodeSelector = nil
+		}
+		return
+	}
+	if tpl.Spec.NodeSelector == nil {
+		tpl.Spec.NodeSelector = map[string]string{}
+	}
+	tpl.Spec.NodeSelector[NodeSelectorFrozen] = "true"
+}
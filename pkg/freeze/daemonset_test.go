@@ -0,0 +1,100 @@
+package freeze
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestDaemonSetTarget(t *testing.T) {
+	const owner = "maintenance:window-7"
+
+	newDaemonSet := func(nodeSelector map[string]string) *appsv1.DaemonSet {
+		ds := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "agent", Generation: 1}}
+		ds.Spec.Template.Spec.NodeSelector = nodeSelector
+		ds.Status.DesiredNumberScheduled = 5
+		return ds
+	}
+	newFreezer := func(lean bool, objs ...client.Object) (*Freezer, *int) {
+		writes := new(int)
+		c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(objs...).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					*writes++
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			}).Build()
+		return &Freezer{Client: c, LeanRBAC: lean}, writes
+	}
+
+	t.Run("FreezeRestoreRoundTrip_KeepsOriginalSelector", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		ds := newDaemonSet(map[string]string{"kubernetes.io/os": "linux"})
+		f, writes := newFreezer(true, ds)
+		require.NoError(t, f.Client.Get(ctx, client.ObjectKeyFromObject(ds), ds))
+
+		var state State
+		require.NoError(t, f.Freeze(ctx, ds, owner, &state, Options{PauseRollout: true}))
+		assert.Equal(t, 1, *writes, "claim and selector in one patch")
+		assert.Equal(t, ptr.To(int32(5)), state.OriginalReplicas)
+		assert.Nil(t, state.OriginalPaused)
+		assert.Equal(t, owner, Holder(ds))
+		assert.Equal(t, map[string]string{"kubernetes.io/os": "linux", NodeSelectorFrozen: "true"},
+			ds.Spec.Template.Spec.NodeSelector)
+
+		target, err := f.Target(ds)
+		require.NoError(t, err)
+		assert.Equal(t, int32(0), target.GetReplicas())
+		assert.False(t, target.Drained(), "the controller has not removed the Pods yet")
+		ds.Status = appsv1.DaemonSetStatus{ObservedGeneration: ds.Generation}
+		assert.True(t, target.Drained())
+
+		require.NoError(t, f.Restore(ctx, ds, owner, &state, Options{}))
+		got := &appsv1.DaemonSet{}
+		require.NoError(t, f.Client.Get(ctx, client.ObjectKeyFromObject(ds), got))
+		assert.Empty(t, Holder(got))
+		assert.Equal(t, map[string]string{"kubernetes.io/os": "linux"}, got.Spec.Template.Spec.NodeSelector)
+	})
+
+	t.Run("NoSelector_RestoredToNone", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		ds := newDaemonSet(nil)
+		f, _ := newFreezer(false, ds)
+		require.NoError(t, f.Client.Get(ctx, client.ObjectKeyFromObject(ds), ds))
+
+		var state State
+		require.NoError(t, f.Freeze(ctx, ds, owner, &state, Options{}))
+		assert.True(t, DaemonSetFrozen(ds))
+		assert.Empty(t, UnfrozenTemplate(ds).Spec.NodeSelector)
+		require.NoError(t, f.Restore(ctx, ds, owner, &state, Options{}))
+		assert.Nil(t, ds.Spec.Template.Spec.NodeSelector)
+	})
+
+	t.Run("Plan_LeavesObjectUntouched", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		ds := newDaemonSet(nil)
+		f, _ := newFreezer(false, ds)
+		require.NoError(t, f.Client.Get(ctx, client.ObjectKeyFromObject(ds), ds))
+		target, err := f.Target(ds)
+		require.NoError(t, err)
+
+		planned, err := target.(Planner).Plan(ctx, Change{FrozenBy: ptr.To(owner), Replicas: ptr.To(int32(0))})
+		require.NoError(t, err)
+		assert.Equal(t, owner, Holder(planned))
+		assert.True(t, DaemonSetFrozen(planned.(*appsv1.DaemonSet)))
+		assert.Empty(t, Holder(ds))
+		assert.False(t, DaemonSetFrozen(ds))
+	})
+}
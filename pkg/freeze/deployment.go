@@ -0,0 +1,365 @@
+package freeze
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var deploymentGVK = appsv1.SchemeGroupVersion.WithKind("Deployment")
+
+func init() {
+	Register(deploymentGVK.GroupKind(), func(f *Freezer, obj client.Object) (Freezable, error) {
+		d, ok := obj.(*appsv1.Deployment)
+		if !ok {
+			return nil, fmt.Errorf("freeze: want *appsv1.Deployment, got %T", obj)
+		}
+		return &deploymentTarget{f: f, d: d}, nil
+	})
+}
+
+// deploymentTarget is the built-in plugin for apps/v1 Deployments. It pauses rollouts and
+// writes several changes in one patch.
+type deploymentTarget struct {
+	f *Freezer
+	d *appsv1.Deployment
+}
+
+var _ Batcher = &deploymentTarget{}
+var _ Pausable = &deploymentTarget{}
+var _ Planner = &deploymentTarget{}
+
+func (t *deploymentTarget) Object() client.Object { return t.d }
+func (t *deploymentTarget) GetReplicas() int32    { return ptr.Deref(t.d.Spec.Replicas, 1) }
+func (t *deploymentTarget) Drained() bool         { return Frozen(t.d) }
+func (t *deploymentTarget) Owner() string         { return Holder(t.d) }
+func (t *deploymentTarget) Paused() bool          { return t.d.Spec.Paused }
+
+func (t *deploymentTarget) ScaleTo(ctx context.Context, replicas int32, opts ...client.PatchOption) error {
+	return t.f.Update(ctx, t.d, Change{Replicas: &replicas}, opts...)
+}
+
+func (t *deploymentTarget) AcquireOwnership(ctx context.Context, owner string, opts ...client.PatchOption) error {
+	return t.f.Update(ctx, t.d, Change{FrozenBy: &owner}, opts...)
+}
+
+func (t *deploymentTarget) SetPaused(ctx context.Context, paused bool, opts ...client.PatchOption) error {
+	return t.f.Update(ctx, t.d, Change{Paused: &paused}, opts...)
+}
+
+func (t *deploymentTarget) Apply(ctx context.Context, c Change, opts ...client.PatchOption) error {
+	return t.f.Update(ctx, t.d, c, opts...)
+}
+
+func (t *deploymentTarget) Plan(ctx context.Context, c Change, opts ...client.PatchOption) (client.Object, error) {
+	return t.f.Plan(ctx, t.d, c, opts...)
+}
+
+// Snapshot records the autoscalers and spec.paused.
+func (t *deploymentTarget) Snapshot(ctx context.Context) (*freezerv1alpha1.AutoscalingSnapshot, error) {
+	snap, err := t.f.SnapshotAutoscaling(ctx, "Deployment", t.d)
+	if err != nil {
+		return nil, err
+	}
+	snap.Paused = t.d.Spec.Paused
+	return snap, nil
+}
+
+// Restore puts the autoscalers back; spec.paused is restored through SetPaused, as only the
+// caller knows whether the freeze changed it.
+func (t *deploymentTarget) Restore(
+	ctx context.Context,
+	snap *freezerv1alpha1.AutoscalingSnapshot,
+	opts ...client.PatchOption,
+) error {
+	return t.f.RestoreAutoscaling(ctx, t.d.Namespace, snap, opts...)
+}
+
+// Frozen reports whether the Deployment d is scaled to zero and no replicas are left running.
+func Frozen(d *appsv1.Deployment) bool {
+	return ptr.Deref(d.Spec.Replicas, 1) == 0 &&
+		d.Status.Replicas == 0 &&
+		d.Status.ReadyReplicas == 0 &&
+		d.Status.AvailableReplicas == 0 &&
+		d.Status.UpdatedReplicas == 0
+}
+
+// Deployment writes are merge patches computed against the Deployment the caller already holds,
+// so they cost one request and no read. Each carries the resourceVersion of the held Deployment:
+// the caller decided on the write from the frozen-by annotation it saw, so the write must not
+// land on a Deployment claimed meanwhile. Only a conflict re-reads the Deployment, from the API
+// server. The response replaces the held Deployment so later steps see the write; dry runs leave
+// it untouched.
+
+// Update writes the change to d in a single merge patch and replaces d with the result, unless
+// opts only dry-run the write. Lean RBAC mode cannot patch the spec, so it sends the annotation
+// as a metadata patch and replicas through the scale subresource; spec.paused still needs a
+// regular patch.
+func (f *Freezer) Update(ctx context.Context, d *appsv1.Deployment, c Change, opts ...client.PatchOption) error {
+	return f.update(ctx, d, c, !isDryRun(opts), opts...)
+}
+
+// Plan sends the change to the API server as a dry run, with the same requests Update uses,
+// and returns the Deployment as the server would store it, admission effects included.
+// d is left untouched.
+func (f *Freezer) Plan(ctx context.Context, d *appsv1.Deployment, c Change, opts ...client.PatchOption) (*appsv1.Deployment, error) {
+	planned := d.DeepCopy()
+	if err := f.update(ctx, planned, c, true, append(opts, client.DryRunAll)...); err != nil {
+		return nil, err
+	}
+	return planned, nil
+}
+
+func (f *Freezer) update(ctx context.Context, d *appsv1.Deployment, c Change, adopt bool, opts ...client.PatchOption) error {
+	if c.empty() {
+		return nil
+	}
+	if !f.LeanRBAC {
+		return f.patch(ctx, d, c.FrozenBy, func(latest *appsv1.Deployment) {
+			c.applyTo(latest)
+		}, adopt, opts...)
+	}
+
+	if c.FrozenBy != nil {
+		if err := f.patchMetadata(ctx, d, deploymentGVK, *c.FrozenBy, adopt, opts...); err != nil {
+			return err
+		}
+	}
+	if c.Paused != nil {
+		if err := f.patch(ctx, d, nil, func(latest *appsv1.Deployment) {
+			latest.Spec.Paused = *c.Paused
+		}, adopt, opts...); err != nil {
+			return err
+		}
+	}
+	if c.Replicas != nil {
+		return f.scale(ctx, d, *c.Replicas, adopt, opts...)
+	}
+	return nil
+}
+
+func (c Change) applyTo(d *appsv1.Deployment) {
+	if c.FrozenBy != nil {
+		SetFrozenBy(d, *c.FrozenBy)
+	}
+	if c.Replicas != nil {
+		d.Spec.Replicas = ptr.To(*c.Replicas)
+	}
+	if c.Paused != nil {
+		d.Spec.Paused = *c.Paused
+	}
+}
+
+// patch sends the changes mutate makes to a copy of d as a merge patch; want is the claim it
+// sets, if any.
+func (f *Freezer) patch(
+	ctx context.Context,
+	d *appsv1.Deployment,
+	want *string,
+	mutate func(*appsv1.Deployment),
+	adopt bool,
+	opts ...client.PatchOption,
+) error {
+	latest, err := f.lockedPatch(ctx, d, want, func(obj client.Object) error {
+		mutate(obj.(*appsv1.Deployment))
+		return nil
+	}, opts...)
+	if err != nil {
+		return err
+	}
+	if adopt {
+		*d = *latest.(*appsv1.Deployment)
+	}
+	return nil
+}
+
+// lockedPatch sends the changes mutate makes to a copy of obj as a merge patch carrying the
+// resourceVersion of obj, and returns the patched copy. On a conflict obj is read again from the
+// API server and the patch computed again, unless the claim on it changed meanwhile to another
+// owner than want.
+func (f *Freezer) lockedPatch(
+	ctx context.Context,
+	obj client.Object,
+	want *string,
+	mutate func(client.Object) error,
+	opts ...client.PatchOption,
+) (client.Object, error) {
+	base := obj
+	var latest client.Object
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest = base.DeepCopyObject().(client.Object)
+		if err := mutate(latest); err != nil {
+			return err
+		}
+		err := f.Client.Patch(ctx, latest, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{}), opts...)
+		if apierrors.IsConflict(err) {
+			fresh, getErr := f.reread(ctx, obj, want)
+			if getErr != nil {
+				return getErr
+			}
+			base = fresh
+		}
+		return err
+	})
+	return latest, err
+}
+
+// reread reads held again from the API server after a conflict. It fails with ErrFrozenByOther,
+// or ErrClaimReleased, when the claim changed since held was read, other than to want.
+func (f *Freezer) reread(ctx context.Context, held client.Object, want *string) (client.Object, error) {
+	// A zero object, since decoding into a copy would keep annotations removed meanwhile.
+	fresh := reflect.New(reflect.TypeOf(held).Elem()).Interface().(client.Object)
+	fresh.GetObjectKind().SetGroupVersionKind(held.GetObjectKind().GroupVersionKind())
+	if err := f.reader().Get(ctx, client.ObjectKeyFromObject(held), fresh); err != nil {
+		return nil, err
+	}
+	holder := Holder(fresh)
+	switch {
+	case holder == Holder(held), want != nil && holder == *want:
+		return fresh, nil
+	case holder == "":
+		return nil, fmt.Errorf("%w: was %s", ErrClaimReleased, Holder(held))
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrFrozenByOther, holder)
+	}
+}
+
+// patchMetadata sets or clears the frozen-by annotation and the frozen label of obj, of kind
+// gvk, with a metadata-only patch.
+func (f *Freezer) patchMetadata(
+	ctx context.Context,
+	obj client.Object,
+	gvk schema.GroupVersionKind,
+	frozenBy string,
+	adopt bool,
+	opts ...client.PatchOption,
+) error {
+	latest, err := f.lockedPatch(ctx, metadata(obj, gvk), &frozenBy, func(meta client.Object) error {
+		SetFrozenBy(meta, frozenBy)
+		return nil
+	}, opts...)
+	if err != nil {
+		return err
+	}
+	meta := latest.(*metav1.PartialObjectMetadata)
+	if adopt {
+		if u, ok := obj.(*unstructured.Unstructured); ok {
+			u.SetAnnotations(meta.Annotations)
+			u.SetLabels(meta.Labels)
+			u.SetResourceVersion(meta.ResourceVersion)
+		} else {
+			*objectMeta(obj) = meta.ObjectMeta
+		}
+	}
+	return nil
+}
+
+// scale sets replicas through the scale subresource, which only needs update rights on
+// deployments/scale. The update carries the resourceVersion of the held Deployment; only a
+// conflict re-reads it, from the API server since a cache may still lag behind, and checks its
+// claim again.
+func (f *Freezer) scale(ctx context.Context, d *appsv1.Deployment, replicas int32, adopt bool, opts ...client.PatchOption) error {
+	latest := d
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		scale, err := f.updateScale(ctx, latest, replicas, opts...)
+		if apierrors.IsConflict(err) {
+			fresh, getErr := f.reread(ctx, d, nil)
+			if getErr != nil {
+				return getErr
+			}
+			latest = fresh.(*appsv1.Deployment)
+		}
+		if err != nil || !adopt {
+			return err
+		}
+		*d = *latest
+		d.ResourceVersion = scale.ResourceVersion
+		d.Spec.Replicas = ptr.To(scale.Spec.Replicas)
+		return nil
+	})
+}
+
+func (f *Freezer) updateScale(
+	ctx context.Context,
+	obj client.Object,
+	replicas int32,
+	opts ...client.PatchOption,
+) (*autoscalingv1.Scale, error) {
+	scale := &autoscalingv1.Scale{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       obj.GetNamespace(),
+			Name:            obj.GetName(),
+			ResourceVersion: obj.GetResourceVersion(),
+		},
+		Spec: autoscalingv1.ScaleSpec{Replicas: replicas},
+	}
+	// The unstructured client, used for kinds without Go types, only sends unstructured bodies.
+	var body client.Object = scale
+	if _, ok := obj.(*unstructured.Unstructured); ok {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(scale)
+		if err != nil {
+			return nil, err
+		}
+		u := &unstructured.Unstructured{Object: content}
+		u.SetGroupVersionKind(autoscalingv1.SchemeGroupVersion.WithKind("Scale"))
+		body = u
+	}
+	updateOpts := []client.SubResourceUpdateOption{client.WithSubResourceBody(body)}
+	for _, o := range opts {
+		if uo, ok := o.(client.SubResourceUpdateOption); ok {
+			updateOpts = append(updateOpts, uo)
+		}
+	}
+	if err := f.Client.SubResource("scale").Update(ctx, obj, updateOpts...); err != nil {
+		return nil, err
+	}
+	if u, ok := body.(*unstructured.Unstructured); ok {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, scale); err != nil {
+			return nil, err
+		}
+	}
+	return scale, nil
+}
+
+// metadata returns the metadata-only view of obj, of kind gvk, used for metadata patches. Of an
+// unstructured object it keeps the fields a metadata patch can change, and its identity.
+func metadata(obj client.Object, gvk schema.GroupVersionKind) *metav1.PartialObjectMetadata {
+	meta := &metav1.PartialObjectMetadata{}
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		u = u.DeepCopy()
+		meta.ObjectMeta = metav1.ObjectMeta{
+			Namespace:       u.GetNamespace(),
+			Name:            u.GetName(),
+			UID:             u.GetUID(),
+			ResourceVersion: u.GetResourceVersion(),
+			Labels:          u.GetLabels(),
+			Annotations:     u.GetAnnotations(),
+		}
+	} else {
+		meta.ObjectMeta = *objectMeta(obj).DeepCopy()
+	}
+	meta.SetGroupVersionKind(gvk)
+	return meta
+}
+
+// objectMeta returns the metadata embedded in obj, which every built-in kind has.
+func objectMeta(obj client.Object) *metav1.ObjectMeta {
+	return obj.(metav1.ObjectMetaAccessor).GetObjectMeta().(*metav1.ObjectMeta)
+}
+
+// isDryRun reports whether opts only dry-run a write.
+func isDryRun(opts []client.PatchOption) bool {
+	return len((&client.PatchOptions{}).ApplyOptions(opts).DryRun) > 0
+}
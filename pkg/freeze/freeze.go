@@ -0,0 +1,210 @@
+// Package freeze freezes and restores workloads: it claims a workload through the frozen-by
+// annotation, records its replicas and autoscaling context, scales it to zero, and later puts
+// all of it back. The DeploymentFreezer controller is built on it; other controllers can embed
+// it to freeze workloads directly, without creating DeploymentFreezers.
+//
+// Workload kinds are plugins: a Plugin wraps an object of its kind in a Freezable, and Register
+// makes the kind known to Freezer.Target. Deployments are built in.
+package freeze
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// AnnotationFrozenBy on a workload names the owner of its freeze. The DeploymentFreezer
+	// controller writes "<namespace>/<name>/<uid>" of the DeploymentFreezer. Embedders choose
+	// their own values; "<namespace>/<name>" values are read as DeploymentFreezers by the controller.
+	AnnotationFrozenBy = "apps.boolfixer.dev/frozen-by"
+	// LabelFrozen is set to "true" together with AnnotationFrozenBy, so label selectors of
+	// policy engines, NetworkPolicies and monitoring can match frozen workloads.
+	LabelFrozen = "apps.boolfixer.dev/frozen"
+	// DefaultReplicas is restored when a workload was already at zero when it was frozen.
+	DefaultReplicas = int32(1)
+)
+
+// ErrFrozenByOther is returned when another owner holds the workload.
+var ErrFrozenByOther = errors.New("deployment is frozen by another owner")
+
+// ErrClaimReleased is returned when the claim a write was decided on is removed before the write
+// lands.
+var ErrClaimReleased = errors.New("claim on the workload was released concurrently")
+
+// Freezer freezes and restores workloads. The zero value is not usable; Client must be set.
+type Freezer struct {
+	// Client writes workloads and their autoscalers.
+	Client client.Client
+	// Reader reads autoscalers, and workloads after a conflict, straight from the API server.
+	// Client is used when nil.
+	Reader client.Reader
+	// LeanRBAC scales through the deployments/scale subresource and only sends metadata patches
+	// to Deployments, so no write access to the Deployment spec is needed except for spec.paused.
+	LeanRBAC bool
+}
+
+// State is what Freeze records so that Restore can put the workload back. Callers keep it
+// between the calls, for example in the status of their own object, and persist it after every
+// call, including failed ones.
+type State struct {
+	// OriginalReplicas is the replica count to restore.
+	OriginalReplicas *int32 `json:"originalReplicas,omitempty"`
+	// OriginalPaused is spec.paused before the freeze; recorded only when Options.PauseRollout
+	// paused the workload itself, and then restored.
+	OriginalPaused *bool `json:"originalPaused,omitempty"`
+	// Snapshot is the autoscaling context before the freeze.
+	Snapshot *freezerv1alpha1.AutoscalingSnapshot `json:"snapshot,omitempty"`
+}
+
+// Options tune Freeze and Restore.
+type Options struct {
+	// PauseRollout also pauses rollouts, so nothing queued during the freeze ships on restore.
+	PauseRollout bool
+	// DryRun only sends dry-run writes.
+	DryRun bool
+}
+
+func (o Options) patchOpts() []client.PatchOption {
+	if o.DryRun {
+		return []client.PatchOption{client.DryRunAll}
+	}
+	return nil
+}
+
+// Holder returns the owner named by the frozen-by annotation of obj, or "" if it is not frozen.
+func Holder(obj client.Object) string {
+	return obj.GetAnnotations()[AnnotationFrozenBy]
+}
+
+// SetFrozenBy records owner in the frozen-by annotation of obj and sets the frozen label, or
+// removes both when owner is empty. Plugins call it from Freezable.AcquireOwnership.
+func SetFrozenBy(obj metav1.Object, owner string) {
+	// Unstructured objects return copies, so the maps are always set back.
+	annotations, labels := obj.GetAnnotations(), obj.GetLabels()
+	if owner == "" {
+		delete(annotations, AnnotationFrozenBy)
+		delete(labels, LabelFrozen)
+		obj.SetAnnotations(annotations)
+		obj.SetLabels(labels)
+		return
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	annotations[AnnotationFrozenBy] = owner
+	labels[LabelFrozen] = "true"
+	obj.SetAnnotations(annotations)
+	obj.SetLabels(labels)
+}
+
+// RestoreReplicas returns the replica count to record for t before it is frozen: its current
+// count, or DefaultReplicas when it is already at zero.
+func RestoreReplicas(t Freezable) int32 {
+	if replicas := t.GetReplicas(); replicas > 0 {
+		return replicas
+	}
+	return DefaultReplicas
+}
+
+// RestorePaused returns the spec.paused value Restore puts back, if any. Only a pause the freeze
+// set itself is undone; a value changed by someone else during the freeze is left alone.
+func (f *Freezer) RestorePaused(s *State) *bool {
+	return s.OriginalPaused
+}
+
+// Freeze claims obj, a workload of a registered kind, for owner and scales it to zero. The first
+// call records the original replicas and autoscaling context in state; every call pauses the KEDA
+// ScaledObject and then sends the claim, the rollout pause and the scale-down, as far as still
+// needed, in as few writes as the target allows.
+//
+// Freeze is idempotent: call it again, with the same state, until the target reports that it has
+// drained. It returns ErrFrozenByOther while another owner holds obj. obj may come from a cache:
+// the writes carry its resourceVersion, and obj is read again and its holder checked again when
+// it changed meanwhile, so two owners racing for the same workload cannot both claim it.
+func (f *Freezer) Freeze(ctx context.Context, obj client.Object, owner string, state *State, opts Options) error {
+	t, err := f.Target(obj)
+	if err != nil {
+		return err
+	}
+	holder := t.Owner()
+	if holder != "" && holder != owner {
+		return fmt.Errorf("%w: %s", ErrFrozenByOther, holder)
+	}
+
+	if state.OriginalReplicas == nil {
+		state.OriginalReplicas = ptr.To(RestoreReplicas(t))
+	}
+	if state.Snapshot == nil {
+		snap, err := t.Snapshot(ctx)
+		if err != nil {
+			return fmt.Errorf("snapshot: %w", err)
+		}
+		state.Snapshot = snap
+	}
+	if err := f.PauseAutoscaling(ctx, obj.GetNamespace(), state.Snapshot, opts.patchOpts()...); err != nil {
+		return fmt.Errorf("pause autoscalers: %w", err)
+	}
+
+	var c Change
+	if holder != owner {
+		c.FrozenBy = &owner
+	}
+	if p, ok := t.(Pausable); ok && opts.PauseRollout && !p.Paused() {
+		if state.OriginalPaused == nil {
+			state.OriginalPaused = ptr.To(false)
+		}
+		c.Paused = ptr.To(true)
+	}
+	if t.GetReplicas() != 0 {
+		c.Replicas = ptr.To(int32(0))
+	}
+	return Apply(ctx, t, c, opts.patchOpts()...)
+}
+
+// Restore puts back what Freeze recorded in state: replicas and the rollout pause first, then
+// the snapshot, and releases owner's claim last, so the workload is not handed over before it is
+// whole again. Restore is idempotent; a workload that is no longer claimed is left alone.
+// It returns ErrFrozenByOther when another owner holds obj.
+func (f *Freezer) Restore(ctx context.Context, obj client.Object, owner string, state *State, opts Options) error {
+	t, err := f.Target(obj)
+	if err != nil {
+		return err
+	}
+	switch holder := t.Owner(); holder {
+	case "":
+		return nil
+	case owner:
+	default:
+		return fmt.Errorf("%w: %s", ErrFrozenByOther, holder)
+	}
+
+	c := Change{Replicas: ptr.To(ptr.Deref(state.OriginalReplicas, DefaultReplicas))}
+	if p, ok := t.(Pausable); ok {
+		if paused := f.RestorePaused(state); paused != nil && *paused != p.Paused() {
+			c.Paused = paused
+		}
+	}
+	if err := Apply(ctx, t, c, opts.patchOpts()...); err != nil {
+		return fmt.Errorf("restore replicas: %w", err)
+	}
+	if err := t.Restore(ctx, state.Snapshot, opts.patchOpts()...); err != nil {
+		return fmt.Errorf("restore snapshot: %w", err)
+	}
+	return t.AcquireOwnership(ctx, "", opts.patchOpts()...)
+}
+
+func (f *Freezer) reader() client.Reader {
+	if f.Reader != nil {
+		return f.Reader
+	}
+	return f.Client
+}
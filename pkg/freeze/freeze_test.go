@@ -0,0 +1,226 @@
+package freeze
+
+import (
+	"context"
+	"testing"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestFreezer(t *testing.T) {
+	const owner = "orchestrator:release-42"
+
+	newDeploy := func() *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web"},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(3))},
+		}
+	}
+	// newFreezer counts the writes sent through the client.
+	newFreezer := func(lean bool, objs ...client.Object) (*Freezer, *int) {
+		writes := new(int)
+		c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(objs...).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					*writes++
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+				SubResourceUpdate: func(ctx context.Context, c client.Client, sub string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+					*writes++
+					return c.SubResource(sub).Update(ctx, obj, opts...)
+				},
+			}).Build()
+		return &Freezer{Client: c, LeanRBAC: lean}, writes
+	}
+	fetch := func(t *testing.T, f *Freezer, d *appsv1.Deployment) *appsv1.Deployment {
+		got := &appsv1.Deployment{}
+		require.NoError(t, f.Client.Get(context.Background(), client.ObjectKeyFromObject(d), got))
+		return got
+	}
+
+	t.Run("Update_SingleWrite", func(t *testing.T) {
+		t.Parallel()
+		d := newDeploy()
+		f, writes := newFreezer(false, d)
+		d = fetch(t, f, d)
+
+		c := Change{FrozenBy: ptr.To(owner), Replicas: ptr.To(int32(0)), Paused: ptr.To(true)}
+		require.NoError(t, f.Update(context.Background(), d, c))
+		assert.Equal(t, 1, *writes)
+		// The held Deployment reflects the write.
+		assert.Equal(t, owner, Holder(d))
+		assert.Equal(t, int32(0), *d.Spec.Replicas)
+		assert.True(t, d.Spec.Paused)
+		assert.Equal(t, d.Spec, fetch(t, f, d).Spec)
+	})
+
+	t.Run("Update_LeanRBAC_MetadataAndScale", func(t *testing.T) {
+		t.Parallel()
+		d := newDeploy()
+		f, writes := newFreezer(true, d)
+		d = fetch(t, f, d)
+
+		require.NoError(t, f.Update(context.Background(), d, Change{FrozenBy: ptr.To(owner), Replicas: ptr.To(int32(0))}))
+		assert.Equal(t, 2, *writes)
+		got := fetch(t, f, d)
+		assert.Equal(t, owner, Holder(got))
+		assert.Equal(t, "true", got.Labels[LabelFrozen])
+		assert.Equal(t, int32(0), *got.Spec.Replicas)
+		assert.Equal(t, int32(0), *d.Spec.Replicas)
+	})
+
+	t.Run("Update_DryRunLeavesHeldDeployment", func(t *testing.T) {
+		t.Parallel()
+		d := newDeploy()
+		f, _ := newFreezer(false, d)
+		d = fetch(t, f, d)
+
+		require.NoError(t, f.Update(context.Background(), d, Change{Replicas: ptr.To(int32(0))}, client.DryRunAll))
+		assert.Equal(t, int32(3), *d.Spec.Replicas)
+	})
+
+	t.Run("Plan_ReturnsResultWithoutWriting", func(t *testing.T) {
+		t.Parallel()
+		d := newDeploy()
+		f, _ := newFreezer(false, d)
+		d = fetch(t, f, d)
+
+		planned, err := f.Plan(context.Background(), d, Change{FrozenBy: ptr.To(owner), Replicas: ptr.To(int32(0))})
+		require.NoError(t, err)
+		assert.Equal(t, owner, Holder(planned))
+		assert.Equal(t, int32(0), *planned.Spec.Replicas)
+		assert.Empty(t, Holder(d))
+		assert.Equal(t, int32(3), *fetch(t, f, d).Spec.Replicas)
+	})
+
+	t.Run("Freeze_RacingClaimantsOneWins", func(t *testing.T) {
+		t.Parallel()
+		for _, lean := range []bool{false, true} {
+			ctx := context.Background()
+			d := newDeploy()
+			f, _ := newFreezer(lean, d)
+			// Both read the unclaimed Deployment, as from a cache, before either writes.
+			first, second := fetch(t, f, d), fetch(t, f, d)
+
+			require.NoError(t, f.Freeze(ctx, first, "first", &State{}, Options{}), "lean=%v", lean)
+			err := f.Freeze(ctx, second, "second", &State{}, Options{})
+			require.ErrorIs(t, err, ErrFrozenByOther, "lean=%v", lean)
+			assert.Equal(t, "first", Holder(fetch(t, f, d)), "lean=%v", lean)
+		}
+	})
+
+	t.Run("Update_UnrelatedChangeRetried", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		d := newDeploy()
+		f, _ := newFreezer(false, d)
+		d = fetch(t, f, d)
+
+		other := fetch(t, f, d)
+		other.Labels = map[string]string{"team": "web"}
+		require.NoError(t, f.Client.Update(ctx, other))
+
+		require.NoError(t, f.Update(ctx, d, Change{FrozenBy: ptr.To(owner), Replicas: ptr.To(int32(0))}))
+		got := fetch(t, f, d)
+		assert.Equal(t, owner, Holder(got))
+		assert.Equal(t, int32(0), *got.Spec.Replicas)
+		assert.Equal(t, "web", got.Labels["team"])
+	})
+
+	t.Run("FreezeRestore_RoundTrip", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		hpa := &autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web"},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: "web"},
+				MinReplicas:    ptr.To(int32(2)),
+				MaxReplicas:    10,
+			},
+		}
+		d := newDeploy()
+		f, _ := newFreezer(false, d, hpa)
+		d = fetch(t, f, d)
+
+		var state State
+		require.NoError(t, f.Freeze(ctx, d, owner, &state, Options{PauseRollout: true}))
+		assert.Equal(t, ptr.To(int32(3)), state.OriginalReplicas)
+		assert.Equal(t, ptr.To(false), state.OriginalPaused)
+		require.NotNil(t, state.Snapshot.HPA)
+		got := fetch(t, f, d)
+		assert.Equal(t, owner, Holder(got))
+		assert.Equal(t, "true", got.Labels[LabelFrozen])
+		assert.Equal(t, int32(0), *got.Spec.Replicas)
+		assert.True(t, got.Spec.Paused)
+		assert.True(t, Frozen(got))
+
+		// A second call finds nothing left to do.
+		require.NoError(t, f.Freeze(ctx, d, owner, &state, Options{PauseRollout: true}))
+
+		require.NoError(t, f.Restore(ctx, d, owner, &state, Options{}))
+		got = fetch(t, f, d)
+		assert.Empty(t, Holder(got))
+		assert.NotContains(t, got.Labels, LabelFrozen)
+		assert.Equal(t, int32(3), *got.Spec.Replicas)
+		assert.False(t, got.Spec.Paused)
+	})
+
+	t.Run("Freeze_AlreadyPausedLeftPaused", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		d := newDeploy()
+		d.Spec.Paused = true
+		f, _ := newFreezer(false, d)
+		d = fetch(t, f, d)
+
+		var state State
+		require.NoError(t, f.Freeze(ctx, d, owner, &state, Options{PauseRollout: true}))
+		assert.Nil(t, state.OriginalPaused, "the freeze did not pause it")
+
+		require.NoError(t, f.Restore(ctx, fetch(t, f, d), owner, &state, Options{}))
+		assert.True(t, fetch(t, f, d).Spec.Paused)
+	})
+
+	t.Run("Freeze_HeldByOther", func(t *testing.T) {
+		t.Parallel()
+		d := newDeploy()
+		d.Annotations = map[string]string{AnnotationFrozenBy: "ns/other/uid"}
+		f, writes := newFreezer(false, d)
+		d = fetch(t, f, d)
+
+		var state State
+		err := f.Freeze(context.Background(), d, owner, &state, Options{})
+		require.ErrorIs(t, err, ErrFrozenByOther)
+		assert.Contains(t, err.Error(), "ns/other/uid")
+		assert.ErrorIs(t, f.Restore(context.Background(), d, owner, &state, Options{}), ErrFrozenByOther)
+		assert.Zero(t, *writes)
+	})
+
+	t.Run("RestoreReplicas_DefaultsFromZero", func(t *testing.T) {
+		t.Parallel()
+		d := newDeploy()
+		target := &deploymentTarget{d: d}
+		assert.Equal(t, int32(3), RestoreReplicas(target))
+		d.Spec.Replicas = ptr.To(int32(0))
+		assert.Equal(t, DefaultReplicas, RestoreReplicas(target))
+	})
+
+	t.Run("RestorePaused_OnlyOwnChange", func(t *testing.T) {
+		t.Parallel()
+		state := &State{Snapshot: &freezerv1alpha1.AutoscalingSnapshot{Paused: true}}
+		assert.Nil(t, (&Freezer{}).RestorePaused(state), "the snapshot is only a record")
+		state.OriginalPaused = ptr.To(false)
+		assert.Equal(t, ptr.To(false), (&Freezer{}).RestorePaused(state))
+		assert.Equal(t, ptr.To(false), (&Freezer{LeanRBAC: true}).RestorePaused(state))
+	})
+}
@@ -0,0 +1,176 @@
+package freeze
+
+import (
+	"context"
+	"fmt"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AdvancedStatefulSetGVK is the OpenKruise Advanced StatefulSet. The module has no dependency on
+// OpenKruise, so these are read and written as unstructured objects.
+var AdvancedStatefulSetGVK = schema.GroupVersionKind{Group: "apps.kruise.io", Version: "v1beta1", Kind: "StatefulSet"}
+
+func init() {
+	Register(AdvancedStatefulSetGVK.GroupKind(), func(f *Freezer, obj client.Object) (Freezable, error) {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return nil, fmt.Errorf("freeze: want an unstructured %s, got %T", AdvancedStatefulSetGVK.GroupKind(), obj)
+		}
+		// A UnitedDeployment or similar would scale it straight back.
+		if ref := metav1.GetControllerOf(u); ref != nil {
+			return nil, fmt.Errorf("%w: Advanced StatefulSet %s/%s is controlled by %s %s",
+				ErrControlled, u.GetNamespace(), u.GetName(), ref.Kind, ref.Name)
+		}
+		return &advancedStatefulSetTarget{f: f, u: u}, nil
+	})
+}
+
+// NewAdvancedStatefulSet returns an empty unstructured Advanced StatefulSet to read one into.
+func NewAdvancedStatefulSet() *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(AdvancedStatefulSetGVK)
+	return u
+}
+
+// advancedStatefulSetTarget is the built-in plugin for OpenKruise Advanced StatefulSets. They
+// scale like a ReplicaSet, through spec.replicas or the scale subresource, and may be scaled by
+// an HPA or a ScaledObject. Their rollouts are not paused.
+type advancedStatefulSetTarget struct {
+	f *Freezer
+	u *unstructured.Unstructured
+}
+
+var _ Batcher = &advancedStatefulSetTarget{}
+var _ Planner = &advancedStatefulSetTarget{}
+
+func (t *advancedStatefulSetTarget) Object() client.Object { return t.u }
+func (t *advancedStatefulSetTarget) Owner() string         { return Holder(t.u) }
+
+func (t *advancedStatefulSetTarget) GetReplicas() int32 {
+	return int32(nestedInt64(t.u, 1, "spec", "replicas"))
+}
+
+func (t *advancedStatefulSetTarget) Drained() bool {
+	return t.GetReplicas() == 0 && nestedInt64(t.u, 0, "status", "replicas") == 0
+}
+
+func (t *advancedStatefulSetTarget) ScaleTo(ctx context.Context, replicas int32, opts ...client.PatchOption) error {
+	return t.Apply(ctx, Change{Replicas: &replicas}, opts...)
+}
+
+func (t *advancedStatefulSetTarget) AcquireOwnership(ctx context.Context, owner string, opts ...client.PatchOption) error {
+	return t.Apply(ctx, Change{FrozenBy: &owner}, opts...)
+}
+
+// Apply writes the claim and the replicas of c; Paused is ignored.
+func (t *advancedStatefulSetTarget) Apply(ctx context.Context, c Change, opts ...client.PatchOption) error {
+	return t.update(ctx, t.u, c, !isDryRun(opts), opts...)
+}
+
+func (t *advancedStatefulSetTarget) Plan(ctx context.Context, c Change, opts ...client.PatchOption) (client.Object, error) {
+	planned := t.u.DeepCopy()
+	if err := t.update(ctx, planned, c, true, append(opts, client.DryRunAll)...); err != nil {
+		return nil, err
+	}
+	return planned, nil
+}
+
+func (t *advancedStatefulSetTarget) Snapshot(ctx context.Context) (*freezerv1alpha1.AutoscalingSnapshot, error) {
+	return t.f.SnapshotAutoscaling(ctx, AdvancedStatefulSetGVK.Kind, t.u)
+}
+
+func (t *advancedStatefulSetTarget) Restore(
+	ctx context.Context,
+	snap *freezerv1alpha1.AutoscalingSnapshot,
+	opts ...client.PatchOption,
+) error {
+	return t.f.RestoreAutoscaling(ctx, t.u.GetNamespace(), snap, opts...)
+}
+
+// update writes c to u with one merge patch, or in lean RBAC mode a metadata patch and the scale
+// subresource.
+func (t *advancedStatefulSetTarget) update(
+	ctx context.Context,
+	u *unstructured.Unstructured,
+	c Change,
+	adopt bool,
+	opts ...client.PatchOption,
+) error {
+	if c.FrozenBy == nil && c.Replicas == nil {
+		return nil
+	}
+	if !t.f.LeanRBAC {
+		latest, err := t.f.lockedPatch(ctx, u, c.FrozenBy, func(obj client.Object) error {
+			latest := obj.(*unstructured.Unstructured)
+			if c.FrozenBy != nil {
+				SetFrozenBy(latest, *c.FrozenBy)
+			}
+			if c.Replicas != nil {
+				return unstructured.SetNestedField(latest.Object, int64(*c.Replicas), "spec", "replicas")
+			}
+			return nil
+		}, opts...)
+		if err != nil {
+			return err
+		}
+		if adopt {
+			latest.(*unstructured.Unstructured).DeepCopyInto(u)
+		}
+		return nil
+	}
+
+	if c.FrozenBy != nil {
+		if err := t.f.patchMetadata(ctx, u, AdvancedStatefulSetGVK, *c.FrozenBy, adopt, opts...); err != nil {
+			return err
+		}
+	}
+	if c.Replicas != nil {
+		return t.scale(ctx, u, *c.Replicas, adopt, opts...)
+	}
+	return nil
+}
+
+// scale sets replicas through the scale subresource, re-reading u from the API server and
+// checking its claim again on a conflict.
+func (t *advancedStatefulSetTarget) scale(
+	ctx context.Context,
+	u *unstructured.Unstructured,
+	replicas int32,
+	adopt bool,
+	opts ...client.PatchOption,
+) error {
+	latest := u
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		scale, err := t.f.updateScale(ctx, latest, replicas, opts...)
+		if apierrors.IsConflict(err) {
+			fresh, getErr := t.f.reread(ctx, u, nil)
+			if getErr != nil {
+				return getErr
+			}
+			latest = fresh.(*unstructured.Unstructured)
+		}
+		if err != nil || !adopt {
+			return err
+		}
+		if latest != u {
+			latest.DeepCopyInto(u)
+		}
+		u.SetResourceVersion(scale.ResourceVersion)
+		return unstructured.SetNestedField(u.Object, int64(scale.Spec.Replicas), "spec", "replicas")
+	})
+}
+
+// nestedInt64 returns the integer at fields of u, or def if it is not set.
+func nestedInt64(u *unstructured.Unstructured, def int64, fields ...string) int64 {
+	if v, ok, err := unstructured.NestedInt64(u.Object, fields...); ok && err == nil {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,116 @@
+package freeze
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAdvancedStatefulSetTarget(t *testing.T) {
+	const owner = "orchestrator:release-42"
+
+	newAdvancedStatefulSet := func(replicas int64) *unstructured.Unstructured {
+		u := NewAdvancedStatefulSet()
+		u.SetNamespace("ns")
+		u.SetName("db")
+		require.NoError(t, unstructured.SetNestedField(u.Object, replicas, "spec", "replicas"))
+		return u
+	}
+	newFreezer := func(objs ...client.Object) *Freezer {
+		return &Freezer{Client: fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(objs...).Build()}
+	}
+	get := func(t *testing.T, f *Freezer) *unstructured.Unstructured {
+		got := NewAdvancedStatefulSet()
+		require.NoError(t, f.Client.Get(context.Background(), client.ObjectKey{Namespace: "ns", Name: "db"}, got))
+		return got
+	}
+
+	t.Run("FreezeRestoreRoundTrip", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		hpa := &autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "db"},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+					APIVersion: "apps.kruise.io/v1beta1", Kind: "StatefulSet", Name: "db",
+				},
+				MinReplicas: ptr.To(int32(3)),
+				MaxReplicas: 6,
+			},
+		}
+		f := newFreezer(newAdvancedStatefulSet(3), hpa)
+		sts := get(t, f)
+
+		var state State
+		require.NoError(t, f.Freeze(ctx, sts, owner, &state, Options{}))
+		assert.Equal(t, ptr.To(int32(3)), state.OriginalReplicas)
+		require.NotNil(t, state.Snapshot.HPA)
+		got := get(t, f)
+		assert.Equal(t, owner, Holder(got))
+		assert.Equal(t, "true", got.GetLabels()[LabelFrozen])
+		assert.Equal(t, int64(0), nestedInt64(got, -1, "spec", "replicas"))
+
+		target, err := f.Target(got)
+		require.NoError(t, err)
+		assert.True(t, target.Drained())
+
+		require.NoError(t, f.Restore(ctx, sts, owner, &state, Options{}))
+		got = get(t, f)
+		assert.Empty(t, Holder(got))
+		assert.Equal(t, int64(3), nestedInt64(got, -1, "spec", "replicas"))
+	})
+
+	t.Run("LeanRBAC_MetadataPatch", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		f := newFreezer(newAdvancedStatefulSet(2))
+		f.LeanRBAC = true
+		sts := get(t, f)
+		target, err := f.Target(sts)
+		require.NoError(t, err)
+
+		require.NoError(t, target.AcquireOwnership(ctx, owner))
+		assert.Equal(t, owner, Holder(sts))
+		got := get(t, f)
+		assert.Equal(t, owner, Holder(got))
+		assert.Equal(t, int64(2), nestedInt64(got, -1, "spec", "replicas"), "the spec is left alone")
+	})
+
+	t.Run("Plan_LeavesObjectUntouched", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		f := newFreezer(newAdvancedStatefulSet(3))
+		sts := get(t, f)
+		target, err := f.Target(sts)
+		require.NoError(t, err)
+
+		planned, err := target.(Planner).Plan(ctx, Change{FrozenBy: ptr.To(owner), Replicas: ptr.To(int32(0))})
+		require.NoError(t, err)
+		plannedTarget, err := f.Target(planned)
+		require.NoError(t, err)
+		assert.Equal(t, owner, plannedTarget.Owner())
+		assert.Equal(t, int32(0), plannedTarget.GetReplicas())
+		assert.Empty(t, target.Owner())
+		assert.Equal(t, int32(3), target.GetReplicas())
+	})
+
+	t.Run("Controlled_Refused", func(t *testing.T) {
+		t.Parallel()
+		sts := newAdvancedStatefulSet(3)
+		sts.SetOwnerReferences([]metav1.OwnerReference{{
+			APIVersion: "apps.kruise.io/v1alpha1", Kind: "UnitedDeployment", Name: "db", UID: "uid", Controller: ptr.To(true),
+		}})
+		_, err := newFreezer().Target(sts)
+		require.ErrorIs(t, err, ErrControlled)
+		assert.Contains(t, err.Error(), "UnitedDeployment db")
+	})
+}
@@ -0,0 +1,184 @@
+package freeze
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrControlled is returned for a ReplicaSet or ReplicationController that belongs to a
+// controller, such as the Deployment owning a ReplicaSet, which would scale it straight back up.
+// Freeze the controller instead.
+var ErrControlled = errors.New("workload is managed by a controller")
+
+// replicatedKind describes a kind whose Pods are kept at spec.replicas by a single object, with
+// no rollouts of its own: standalone ReplicaSets and legacy ReplicationControllers.
+type replicatedKind struct {
+	gvk schema.GroupVersionKind
+	// is reports whether an object has the kind's Go type.
+	is func(client.Object) bool
+	// replicas points at spec.replicas of an object of the kind.
+	replicas func(client.Object) **int32
+	// running returns status.replicas of an object of the kind.
+	running func(client.Object) int32
+	// adopt overwrites dst with src; both are objects of the kind.
+	adopt func(dst, src client.Object)
+}
+
+var replicaSetKind = replicatedKind{
+	gvk:      appsv1.SchemeGroupVersion.WithKind("ReplicaSet"),
+	is:       func(o client.Object) bool { _, ok := o.(*appsv1.ReplicaSet); return ok },
+	replicas: func(o client.Object) **int32 { return &o.(*appsv1.ReplicaSet).Spec.Replicas },
+	running:  func(o client.Object) int32 { return o.(*appsv1.ReplicaSet).Status.Replicas },
+	adopt:    func(dst, src client.Object) { *dst.(*appsv1.ReplicaSet) = *src.(*appsv1.ReplicaSet) },
+}
+
+var replicationControllerKind = replicatedKind{
+	gvk:      corev1.SchemeGroupVersion.WithKind("ReplicationController"),
+	is:       func(o client.Object) bool { _, ok := o.(*corev1.ReplicationController); return ok },
+	replicas: func(o client.Object) **int32 { return &o.(*corev1.ReplicationController).Spec.Replicas },
+	running:  func(o client.Object) int32 { return o.(*corev1.ReplicationController).Status.Replicas },
+	adopt: func(dst, src client.Object) {
+		*dst.(*corev1.ReplicationController) = *src.(*corev1.ReplicationController)
+	},
+}
+
+func init() {
+	for _, k := range []replicatedKind{replicaSetKind, replicationControllerKind} {
+		Register(k.gvk.GroupKind(), k.plugin)
+	}
+}
+
+// plugin wraps obj, refusing objects that a controller keeps at its own replica count.
+func (k replicatedKind) plugin(f *Freezer, obj client.Object) (Freezable, error) {
+	if !k.is(obj) {
+		return nil, fmt.Errorf("freeze: want a %s, got %T", k.gvk.Kind, obj)
+	}
+	if ref := metav1.GetControllerOf(obj); ref != nil {
+		return nil, fmt.Errorf("%w: %s %s/%s is controlled by %s %s",
+			ErrControlled, k.gvk.Kind, obj.GetNamespace(), obj.GetName(), ref.Kind, ref.Name)
+	}
+	return &replicatedTarget{f: f, k: k, obj: obj}, nil
+}
+
+// replicatedTarget is the built-in plugin for standalone ReplicaSets and ReplicationControllers.
+// It writes the claim and the replicas in one patch, like a Deployment, but has no rollouts to
+// pause.
+type replicatedTarget struct {
+	f   *Freezer
+	k   replicatedKind
+	obj client.Object
+}
+
+var _ Batcher = &replicatedTarget{}
+var _ Planner = &replicatedTarget{}
+
+func (t *replicatedTarget) Object() client.Object { return t.obj }
+func (t *replicatedTarget) GetReplicas() int32    { return ptr.Deref(*t.k.replicas(t.obj), 1) }
+func (t *replicatedTarget) Drained() bool         { return t.GetReplicas() == 0 && t.k.running(t.obj) == 0 }
+func (t *replicatedTarget) Owner() string         { return Holder(t.obj) }
+
+func (t *replicatedTarget) ScaleTo(ctx context.Context, replicas int32, opts ...client.PatchOption) error {
+	return t.Apply(ctx, Change{Replicas: &replicas}, opts...)
+}
+
+func (t *replicatedTarget) AcquireOwnership(ctx context.Context, owner string, opts ...client.PatchOption) error {
+	return t.Apply(ctx, Change{FrozenBy: &owner}, opts...)
+}
+
+// Apply writes the claim and the replicas of c; Paused is ignored.
+func (t *replicatedTarget) Apply(ctx context.Context, c Change, opts ...client.PatchOption) error {
+	return t.update(ctx, t.obj, c, !isDryRun(opts), opts...)
+}
+
+func (t *replicatedTarget) Plan(ctx context.Context, c Change, opts ...client.PatchOption) (client.Object, error) {
+	planned := t.obj.DeepCopyObject().(client.Object)
+	if err := t.update(ctx, planned, c, true, append(opts, client.DryRunAll)...); err != nil {
+		return nil, err
+	}
+	return planned, nil
+}
+
+func (t *replicatedTarget) Snapshot(ctx context.Context) (*freezerv1alpha1.AutoscalingSnapshot, error) {
+	return t.f.SnapshotAutoscaling(ctx, t.k.gvk.Kind, t.obj)
+}
+
+func (t *replicatedTarget) Restore(
+	ctx context.Context,
+	snap *freezerv1alpha1.AutoscalingSnapshot,
+	opts ...client.PatchOption,
+) error {
+	return t.f.RestoreAutoscaling(ctx, t.obj.GetNamespace(), snap, opts...)
+}
+
+// update writes c to obj with the same requests as a Deployment update, guarded by its
+// resourceVersion: one merge patch, or in lean RBAC mode a metadata patch and the scale
+// subresource.
+func (t *replicatedTarget) update(ctx context.Context, obj client.Object, c Change, adopt bool, opts ...client.PatchOption) error {
+	if c.FrozenBy == nil && c.Replicas == nil {
+		return nil
+	}
+	if !t.f.LeanRBAC {
+		latest, err := t.f.lockedPatch(ctx, obj, c.FrozenBy, func(latest client.Object) error {
+			if c.FrozenBy != nil {
+				SetFrozenBy(latest, *c.FrozenBy)
+			}
+			if c.Replicas != nil {
+				*t.k.replicas(latest) = ptr.To(*c.Replicas)
+			}
+			return nil
+		}, opts...)
+		if err != nil {
+			return err
+		}
+		if adopt {
+			t.k.adopt(obj, latest)
+		}
+		return nil
+	}
+
+	if c.FrozenBy != nil {
+		if err := t.f.patchMetadata(ctx, obj, t.k.gvk, *c.FrozenBy, adopt, opts...); err != nil {
+			return err
+		}
+	}
+	if c.Replicas != nil {
+		return t.scale(ctx, obj, *c.Replicas, adopt, opts...)
+	}
+	return nil
+}
+
+// scale sets replicas through the scale subresource, re-reading obj from the API server and
+// checking its claim again on a conflict.
+func (t *replicatedTarget) scale(ctx context.Context, obj client.Object, replicas int32, adopt bool, opts ...client.PatchOption) error {
+	latest := obj
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		scale, err := t.f.updateScale(ctx, latest, replicas, opts...)
+		if apierrors.IsConflict(err) {
+			fresh, getErr := t.f.reread(ctx, obj, nil)
+			if getErr != nil {
+				return getErr
+			}
+			latest = fresh
+		}
+		if err != nil || !adopt {
+			return err
+		}
+		if latest != obj {
+			t.k.adopt(obj, latest)
+		}
+		obj.SetResourceVersion(scale.ResourceVersion)
+		*t.k.replicas(obj) = ptr.To(scale.Spec.Replicas)
+		return nil
+	})
+}
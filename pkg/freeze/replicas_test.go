@@ -0,0 +1,140 @@
+package freeze
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestReplicatedTargets(t *testing.T) {
+	const owner = "orchestrator:release-42"
+
+	newFreezer := func(lean bool, objs ...client.Object) (*Freezer, *int) {
+		writes := new(int)
+		c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(objs...).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					*writes++
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+				SubResourceUpdate: func(ctx context.Context, c client.Client, sub string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+					*writes++
+					return c.SubResource(sub).Update(ctx, obj, opts...)
+				},
+			}).Build()
+		return &Freezer{Client: c, LeanRBAC: lean}, writes
+	}
+
+	t.Run("Kinds_Registered", func(t *testing.T) {
+		t.Parallel()
+		assert.Contains(t, Kinds(), replicaSetKind.gvk.GroupKind())
+		assert.Contains(t, Kinds(), replicationControllerKind.gvk.GroupKind())
+	})
+
+	t.Run("ReplicaSet_FreezeRestoreRoundTrip", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		rs := &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "legacy"},
+			Spec:       appsv1.ReplicaSetSpec{Replicas: ptr.To(int32(4))},
+		}
+		hpa := &autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "legacy"},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "ReplicaSet", Name: "legacy"},
+				MinReplicas:    ptr.To(int32(2)),
+				MaxReplicas:    8,
+			},
+		}
+		f, writes := newFreezer(false, rs, hpa)
+		require.NoError(t, f.Client.Get(ctx, client.ObjectKeyFromObject(rs), rs))
+
+		var state State
+		require.NoError(t, f.Freeze(ctx, rs, owner, &state, Options{PauseRollout: true}))
+		assert.Equal(t, 1, *writes)
+		assert.Equal(t, ptr.To(int32(4)), state.OriginalReplicas)
+		assert.Nil(t, state.OriginalPaused)
+		require.NotNil(t, state.Snapshot.HPA)
+		assert.Equal(t, "legacy", state.Snapshot.HPA.Name)
+		assert.Equal(t, owner, Holder(rs))
+		assert.Equal(t, int32(0), *rs.Spec.Replicas)
+
+		target, err := f.Target(rs)
+		require.NoError(t, err)
+		assert.True(t, target.Drained())
+
+		require.NoError(t, f.Restore(ctx, rs, owner, &state, Options{}))
+		got := &appsv1.ReplicaSet{}
+		require.NoError(t, f.Client.Get(ctx, client.ObjectKeyFromObject(rs), got))
+		assert.Empty(t, Holder(got))
+		assert.Equal(t, int32(4), *got.Spec.Replicas)
+	})
+
+	t.Run("ReplicationController_LeanRBAC_MetadataAndScale", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		rc := &corev1.ReplicationController{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "legacy"},
+			Spec:       corev1.ReplicationControllerSpec{Replicas: ptr.To(int32(2))},
+		}
+		f, writes := newFreezer(true, rc)
+		require.NoError(t, f.Client.Get(ctx, client.ObjectKeyFromObject(rc), rc))
+
+		var state State
+		require.NoError(t, f.Freeze(ctx, rc, owner, &state, Options{}))
+		assert.Equal(t, 2, *writes)
+		assert.Equal(t, owner, Holder(rc))
+		assert.Equal(t, int32(0), *rc.Spec.Replicas)
+
+		got := &corev1.ReplicationController{}
+		require.NoError(t, f.Client.Get(ctx, client.ObjectKeyFromObject(rc), got))
+		assert.Equal(t, owner, Holder(got))
+		assert.Equal(t, int32(0), *got.Spec.Replicas)
+	})
+
+	t.Run("Plan_LeavesObjectUntouched", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		rs := &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "legacy"},
+			Spec:       appsv1.ReplicaSetSpec{Replicas: ptr.To(int32(3))},
+		}
+		f, _ := newFreezer(false, rs)
+		require.NoError(t, f.Client.Get(ctx, client.ObjectKeyFromObject(rs), rs))
+		target, err := f.Target(rs)
+		require.NoError(t, err)
+
+		planned, err := target.(Planner).Plan(ctx, Change{FrozenBy: ptr.To(owner), Replicas: ptr.To(int32(0))})
+		require.NoError(t, err)
+		assert.Equal(t, owner, Holder(planned))
+		assert.Equal(t, int32(0), *planned.(*appsv1.ReplicaSet).Spec.Replicas)
+		assert.Empty(t, Holder(rs))
+		assert.Equal(t, int32(3), *rs.Spec.Replicas)
+	})
+
+	t.Run("ControlledReplicaSet_Refused", func(t *testing.T) {
+		t.Parallel()
+		f, _ := newFreezer(false)
+		rs := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "web-5d4f8",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1", Kind: "Deployment", Name: "web", UID: "uid", Controller: ptr.To(true),
+			}},
+		}}
+		_, err := f.Target(rs)
+		require.ErrorIs(t, err, ErrControlled)
+		assert.Contains(t, err.Error(), "Deployment web")
+	})
+}
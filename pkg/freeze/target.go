@@ -0,0 +1,157 @@
+package freeze
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// ErrUnsupportedKind is returned for objects of a kind no plugin was registered for.
+var ErrUnsupportedKind = errors.New("no freeze plugin registered for kind")
+
+// Freezable is a workload that can be frozen: one object of a registered kind, wrapped by its
+// plugin. Writes go through the Freezer that wrapped it and update the wrapped object in place,
+// so later calls see them; dry-run writes leave it untouched.
+type Freezable interface {
+	// Object returns the wrapped object.
+	Object() client.Object
+	// GetReplicas returns the desired replica count.
+	GetReplicas() int32
+	// ScaleTo sets the desired replica count.
+	ScaleTo(ctx context.Context, replicas int32, opts ...client.PatchOption) error
+	// Drained reports whether the workload is scaled to zero and no replicas are left running.
+	Drained() bool
+	// Owner returns the owner recorded in the frozen-by annotation, or "" if there is none.
+	Owner() string
+	// AcquireOwnership records owner in the frozen-by annotation and sets the frozen label, as
+	// SetFrozenBy does; "" releases the workload.
+	AcquireOwnership(ctx context.Context, owner string, opts ...client.PatchOption) error
+	// Snapshot records what a freeze may change besides replicas, such as the autoscalers.
+	Snapshot(ctx context.Context) (*freezerv1alpha1.AutoscalingSnapshot, error)
+	// Restore puts a snapshot back. It is idempotent.
+	Restore(ctx context.Context, snap *freezerv1alpha1.AutoscalingSnapshot, opts ...client.PatchOption) error
+}
+
+// Pausable is a Freezable whose rollouts can be paused, such as a Deployment.
+type Pausable interface {
+	Freezable
+	// Paused reports whether rollouts are paused.
+	Paused() bool
+	// SetPaused pauses or resumes rollouts.
+	SetPaused(ctx context.Context, paused bool, opts ...client.PatchOption) error
+}
+
+// Batcher is a Freezable that can write several changes at once. Freeze and Restore use it to
+// send the claim, the rollout pause and the scale-down as a single write.
+type Batcher interface {
+	Freezable
+	// Apply writes the change.
+	Apply(ctx context.Context, c Change, opts ...client.PatchOption) error
+}
+
+// Planner is a Freezable that can preview a change: it sends the change as a dry run and returns
+// the object as the API server would store it, admission effects included. The wrapped object is
+// left untouched.
+type Planner interface {
+	Freezable
+	// Plan dry-runs the change.
+	Plan(ctx context.Context, c Change, opts ...client.PatchOption) (client.Object, error)
+}
+
+// Plugin wraps an object of its kind for f. It must accept the typed object its kind is read
+// into from the Freezer's client.
+type Plugin func(f *Freezer, obj client.Object) (Freezable, error)
+
+var (
+	pluginsMu sync.RWMutex
+	plugins   = map[schema.GroupKind]Plugin{}
+)
+
+// Register adds the plugin for a target kind, typically from an init function. Registering a
+// kind twice panics.
+func Register(gk schema.GroupKind, p Plugin) {
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+	if _, ok := plugins[gk]; ok {
+		panic(fmt.Sprintf("freeze: plugin for %s registered twice", gk))
+	}
+	plugins[gk] = p
+}
+
+// Kinds returns the registered target kinds, sorted.
+func Kinds() []schema.GroupKind {
+	pluginsMu.RLock()
+	defer pluginsMu.RUnlock()
+	kinds := make([]schema.GroupKind, 0, len(plugins))
+	for gk := range plugins {
+		kinds = append(kinds, gk)
+	}
+	slices.SortFunc(kinds, func(a, b schema.GroupKind) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return kinds
+}
+
+// Target wraps obj in the plugin for its kind, which is taken from obj's type meta or, if that is
+// empty, from the scheme of the Freezer's client.
+func (f *Freezer) Target(obj client.Object) (Freezable, error) {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Empty() {
+		var err error
+		if gvk, err = apiutil.GVKForObject(obj, f.Client.Scheme()); err != nil {
+			return nil, err
+		}
+	}
+	pluginsMu.RLock()
+	p, ok := plugins[gvk.GroupKind()]
+	pluginsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnsupportedKind, gvk.GroupKind())
+	}
+	return p(f, obj)
+}
+
+// Change is a set of changes to a target written together; nil fields are left alone.
+type Change struct {
+	// FrozenBy sets the frozen-by annotation, or removes it when empty.
+	FrozenBy *string
+	// Replicas sets the desired replica count.
+	Replicas *int32
+	// Paused pauses or resumes rollouts.
+	Paused *bool
+}
+
+func (c Change) empty() bool {
+	return c.FrozenBy == nil && c.Replicas == nil && c.Paused == nil
+}
+
+// Apply writes the change to t, in a single write if t is a Batcher and one change at a time
+// otherwise: the claim first and the replicas last. Paused is ignored for targets that are not
+// Pausable.
+func Apply(ctx context.Context, t Freezable, c Change, opts ...client.PatchOption) error {
+	if b, ok := t.(Batcher); ok {
+		return b.Apply(ctx, c, opts...)
+	}
+	if c.FrozenBy != nil {
+		if err := t.AcquireOwnership(ctx, *c.FrozenBy, opts...); err != nil {
+			return err
+		}
+	}
+	if p, ok := t.(Pausable); ok && c.Paused != nil {
+		if err := p.SetPaused(ctx, *c.Paused, opts...); err != nil {
+			return err
+		}
+	}
+	if c.Replicas != nil {
+		return t.ScaleTo(ctx, *c.Replicas, opts...)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package freeze
+
+import (
+	"context"
+	"testing"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var statefulSetKind = appsv1.SchemeGroupVersion.WithKind("StatefulSet").GroupKind()
+
+func init() {
+	Register(statefulSetKind, func(f *Freezer, obj client.Object) (Freezable, error) {
+		return &statefulSetTarget{f: f, s: obj.(*appsv1.StatefulSet)}, nil
+	})
+}
+
+// statefulSetTarget is a minimal plugin: it is neither a Batcher nor Pausable.
+type statefulSetTarget struct {
+	f *Freezer
+	s *appsv1.StatefulSet
+}
+
+func (t *statefulSetTarget) Object() client.Object { return t.s }
+func (t *statefulSetTarget) GetReplicas() int32    { return ptr.Deref(t.s.Spec.Replicas, 1) }
+func (t *statefulSetTarget) Drained() bool         { return t.GetReplicas() == 0 && t.s.Status.Replicas == 0 }
+func (t *statefulSetTarget) Owner() string         { return Holder(t.s) }
+
+func (t *statefulSetTarget) ScaleTo(ctx context.Context, replicas int32, opts ...client.PatchOption) error {
+	return t.patch(ctx, func(s *appsv1.StatefulSet) { s.Spec.Replicas = &replicas }, opts...)
+}
+
+func (t *statefulSetTarget) AcquireOwnership(ctx context.Context, owner string, opts ...client.PatchOption) error {
+	return t.patch(ctx, func(s *appsv1.StatefulSet) { SetFrozenBy(s, owner) }, opts...)
+}
+
+func (t *statefulSetTarget) Snapshot(ctx context.Context) (*freezerv1alpha1.AutoscalingSnapshot, error) {
+	return t.f.SnapshotAutoscaling(ctx, "StatefulSet", t.s)
+}
+
+func (t *statefulSetTarget) Restore(
+	ctx context.Context,
+	snap *freezerv1alpha1.AutoscalingSnapshot,
+	opts ...client.PatchOption,
+) error {
+	return t.f.RestoreAutoscaling(ctx, t.s.Namespace, snap, opts...)
+}
+
+func (t *statefulSetTarget) patch(ctx context.Context, mutate func(*appsv1.StatefulSet), opts ...client.PatchOption) error {
+	latest := t.s.DeepCopy()
+	mutate(latest)
+	if err := t.f.Client.Patch(ctx, latest, client.MergeFrom(t.s), opts...); err != nil {
+		return err
+	}
+	*t.s = *latest
+	return nil
+}
+
+func TestTargets(t *testing.T) {
+	const owner = "orchestrator:release-42"
+
+	newFreezer := func(objs ...client.Object) (*Freezer, *int) {
+		writes := new(int)
+		c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(objs...).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					*writes++
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			}).Build()
+		return &Freezer{Client: c}, writes
+	}
+
+	t.Run("Kinds_IncludesDeployment", func(t *testing.T) {
+		t.Parallel()
+		assert.Contains(t, Kinds(), appsv1.SchemeGroupVersion.WithKind("Deployment").GroupKind())
+		assert.Contains(t, Kinds(), statefulSetKind)
+	})
+
+	t.Run("Target_KindFromScheme", func(t *testing.T) {
+		t.Parallel()
+		f, _ := newFreezer()
+		d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "web"}}
+		target, err := f.Target(d)
+		require.NoError(t, err)
+		assert.Same(t, d, target.Object())
+		assert.Implements(t, (*Batcher)(nil), target)
+		assert.Implements(t, (*Pausable)(nil), target)
+	})
+
+	t.Run("Target_UnsupportedKind", func(t *testing.T) {
+		t.Parallel()
+		f, _ := newFreezer()
+		_, err := f.Target(&batchv1.Job{})
+		require.ErrorIs(t, err, ErrUnsupportedKind)
+		assert.Contains(t, err.Error(), "Job.batch")
+	})
+
+	t.Run("Register_TwicePanics", func(t *testing.T) {
+		t.Parallel()
+		assert.Panics(t, func() {
+			Register(statefulSetKind, func(*Freezer, client.Object) (Freezable, error) { return nil, nil })
+		})
+	})
+
+	t.Run("FreezeRestore_PluginWithoutBatching", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		s := &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "db"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(3))},
+		}
+		f, writes := newFreezer(s)
+		require.NoError(t, f.Client.Get(ctx, client.ObjectKeyFromObject(s), s))
+
+		// PauseRollout is ignored: the target is not Pausable.
+		var state State
+		require.NoError(t, f.Freeze(ctx, s, owner, &state, Options{PauseRollout: true}))
+		assert.Equal(t, 2, *writes)
+		assert.Equal(t, ptr.To(int32(3)), state.OriginalReplicas)
+		assert.Nil(t, state.OriginalPaused)
+		assert.Equal(t, owner, Holder(s))
+		assert.Equal(t, int32(0), *s.Spec.Replicas)
+
+		require.NoError(t, f.Restore(ctx, s, owner, &state, Options{}))
+		got := &appsv1.StatefulSet{}
+		require.NoError(t, f.Client.Get(ctx, client.ObjectKeyFromObject(s), got))
+		assert.Empty(t, Holder(got))
+		assert.Equal(t, int32(3), *got.Spec.Replicas)
+	})
+}
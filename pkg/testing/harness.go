@@ -0,0 +1,260 @@
+// Package testing drives the DeploymentFreezer controller in unit tests, without envtest: a
+// Harness runs the real reconciler against a fake client and a fake clock, plays the part of
+// the Deployment controller, and asserts on phases and conditions.
+//
+//	h := dfztesting.New(t, dfztesting.Options{}, deploy, dfz)
+//	h.RunUntil(key, freezerv1alpha1.PhaseFrozen)
+//	h.AssertCondition(key, freezerv1alpha1.ConditionTypeFreezeProgress,
+//		freezerv1alpha1.ConditionStatusTrue, freezerv1alpha1.ConditionReasonScaledToZero)
+//
+// The fake client applies no CRD defaults and runs no admission webhooks, so objects should be
+// seeded as the API server would store them.
+package testing
+
+import (
+	"context"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/internal/controller"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// maxSteps bounds RunUntil; a freeze of a few hours takes a few hundred steps, since a Frozen
+// DFZ is rechecked every minute.
+const maxSteps = 1000
+
+// TB is the part of testing.TB the harness uses.
+type TB interface {
+	Helper()
+	Fatalf(format string, args ...any)
+	Errorf(format string, args ...any)
+}
+
+// Options configure the reconciler the harness runs; the zero value matches the controller's
+// flag defaults.
+type Options struct {
+	// Now is the start time of the fake clock; defaults to the current time.
+	Now time.Time
+	// DefaultDuration is used when spec.durationSeconds is unset; defaults to one hour.
+	DefaultDuration time.Duration
+	// MaxDuration caps every freeze window; 0 means unlimited.
+	MaxDuration time.Duration
+	// LeanRBAC and DryRun select the controller modes of the same names.
+	LeanRBAC bool
+	DryRun   bool
+}
+
+// Harness runs the DeploymentFreezer reconciler against a fake client.
+type Harness struct {
+	// Client is the fake API server: seed objects, change them between steps and read them back.
+	Client client.Client
+	// Clock is the reconciler's time source. Step advances it by the requeue delay.
+	Clock *clocktesting.FakeClock
+	// Recorder receives the events the reconciler emits.
+	Recorder *record.FakeRecorder
+
+	t TB
+	r *controller.DeploymentFreezerReconciler
+}
+
+// New returns a harness seeded with objs. Objects without a UID get one, as on a real API server.
+func New(t TB, opts Options, objs ...client.Object) *Harness {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("add client-go scheme: %v", err)
+	}
+	if err := freezerv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add freezer scheme: %v", err)
+	}
+	for _, obj := range objs {
+		if obj.GetUID() == "" {
+			obj.SetUID(uuid.NewUUID())
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+		WithStatusSubresource(&freezerv1alpha1.DeploymentFreezer{}, &appsv1.Deployment{}).
+		Build()
+
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	if opts.DefaultDuration == 0 {
+		opts.DefaultDuration = time.Hour
+	}
+	h := &Harness{
+		Client:   c,
+		Clock:    clocktesting.NewFakeClock(opts.Now),
+		Recorder: record.NewFakeRecorder(1024),
+		t:        t,
+	}
+	h.r = &controller.DeploymentFreezerReconciler{
+		Client:          c,
+		Scheme:          scheme,
+		Recorder:        controller.EventPolicy{}.Wrap(h.Recorder, h.Clock),
+		Clock:           h.Clock,
+		APIReader:       c,
+		DryRun:          opts.DryRun,
+		LeanRBAC:        opts.LeanRBAC,
+		DefaultDuration: opts.DefaultDuration,
+		MaxDuration:     opts.MaxDuration,
+	}
+	return h
+}
+
+// Reconcile runs one reconcile of the DFZ and fails the test if it returns an error.
+func (h *Harness) Reconcile(key types.NamespacedName) ctrl.Result {
+	h.t.Helper()
+	res, err := h.r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	if err != nil {
+		h.t.Fatalf("reconcile %s: %v", key, err)
+	}
+	return res
+}
+
+// Step runs one reconcile, lets the Deployments settle and advances the clock by the requeue
+// delay, which is what a running controller would do next. It returns the DFZ after the step.
+func (h *Harness) Step(key types.NamespacedName) *freezerv1alpha1.DeploymentFreezer {
+	h.t.Helper()
+	res := h.Reconcile(key)
+	h.SettleDeployments()
+	h.Clock.Step(res.RequeueAfter)
+	return h.DFZ(key)
+}
+
+// RunUntil steps the DFZ until it reaches phase and returns it. It fails the test once the DFZ
+// ends in another terminal phase, stops requeueing, or takes too many steps.
+func (h *Harness) RunUntil(key types.NamespacedName, phase freezerv1alpha1.Phase) *freezerv1alpha1.DeploymentFreezer {
+	h.t.Helper()
+	for range maxSteps {
+		before := h.DFZ(key)
+		if before.Status.Phase == phase {
+			return before
+		}
+		if terminal(before.Status.Phase) {
+			h.t.Fatalf("%s: ended in %s waiting for %s: %v", key, before.Status.Phase, phase, before.Status.Conditions)
+		}
+		res := h.Reconcile(key)
+		h.SettleDeployments()
+		h.Clock.Step(res.RequeueAfter)
+		if after := h.DFZ(key); res.IsZero() && after.Status.Phase == before.Status.Phase && !terminal(after.Status.Phase) {
+			h.t.Fatalf("%s: stalled in %s waiting for %s: %v", key, after.Status.Phase, phase, after.Status.Conditions)
+		}
+	}
+	got := h.DFZ(key)
+	h.t.Fatalf("%s: still %s after %d steps waiting for %s", key, got.Status.Phase, maxSteps, phase)
+	return got
+}
+
+// SettleDeployments plays the Deployment controller: every Deployment's status reports its
+// desired replicas as created, ready, available and updated.
+func (h *Harness) SettleDeployments() {
+	h.t.Helper()
+	ctx := context.Background()
+	var deploys appsv1.DeploymentList
+	if err := h.Client.List(ctx, &deploys); err != nil {
+		h.t.Fatalf("list Deployments: %v", err)
+	}
+	for i := range deploys.Items {
+		d := &deploys.Items[i]
+		replicas := ptr.Deref(d.Spec.Replicas, 1)
+		if d.Status.Replicas == replicas && d.Status.ReadyReplicas == replicas &&
+			d.Status.AvailableReplicas == replicas && d.Status.UpdatedReplicas == replicas {
+			continue
+		}
+		d.Status.ObservedGeneration = d.Generation
+		d.Status.Replicas = replicas
+		d.Status.ReadyReplicas = replicas
+		d.Status.AvailableReplicas = replicas
+		d.Status.UpdatedReplicas = replicas
+		if err := h.Client.Status().Update(ctx, d); err != nil {
+			h.t.Fatalf("settle Deployment %s/%s: %v", d.Namespace, d.Name, err)
+		}
+	}
+}
+
+// DFZ returns the stored DeploymentFreezer.
+func (h *Harness) DFZ(key types.NamespacedName) *freezerv1alpha1.DeploymentFreezer {
+	h.t.Helper()
+	var dfz freezerv1alpha1.DeploymentFreezer
+	if err := h.Client.Get(context.Background(), key, &dfz); err != nil {
+		h.t.Fatalf("get DeploymentFreezer %s: %v", key, err)
+	}
+	return &dfz
+}
+
+// Deployment returns the stored Deployment.
+func (h *Harness) Deployment(key types.NamespacedName) *appsv1.Deployment {
+	h.t.Helper()
+	var d appsv1.Deployment
+	if err := h.Client.Get(context.Background(), key, &d); err != nil {
+		h.t.Fatalf("get Deployment %s: %v", key, err)
+	}
+	return &d
+}
+
+// Events drains the events recorded so far, as "<type> <reason> <message>".
+func (h *Harness) Events() []string {
+	var events []string
+	for {
+		select {
+		case e := <-h.Recorder.Events:
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
+// AssertPhase reports an error unless the DFZ is in phase.
+func (h *Harness) AssertPhase(key types.NamespacedName, phase freezerv1alpha1.Phase) bool {
+	h.t.Helper()
+	if got := h.DFZ(key).Status.Phase; got != phase {
+		h.t.Errorf("%s: phase is %s, want %s", key, got, phase)
+		return false
+	}
+	return true
+}
+
+// AssertCondition reports an error unless the DFZ has the condition with the given status and reason.
+func (h *Harness) AssertCondition(
+	key types.NamespacedName,
+	condType freezerv1alpha1.ConditionType,
+	status freezerv1alpha1.ConditionStatus,
+	reason freezerv1alpha1.ConditionReason,
+) bool {
+	h.t.Helper()
+	dfz := h.DFZ(key)
+	for _, c := range dfz.Status.Conditions {
+		if c.Type != condType {
+			continue
+		}
+		if c.Status != status || c.Reason != reason {
+			h.t.Errorf("%s: condition %s is %s/%s (%q), want %s/%s", key, condType, c.Status, c.Reason, c.Message, status, reason)
+			return false
+		}
+		return true
+	}
+	h.t.Errorf("%s: no condition %s, want %s/%s", key, condType, status, reason)
+	return false
+}
+
+func terminal(phase freezerv1alpha1.Phase) bool {
+	switch phase {
+	case freezerv1alpha1.PhaseCompleted, freezerv1alpha1.PhaseDenied, freezerv1alpha1.PhaseAborted:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,112 @@
+package testing_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	freezerv1alpha1 "github.com/boolfixer/deployment-freezer/api/v1alpha1"
+	"github.com/boolfixer/deployment-freezer/pkg/freeze"
+	dfztesting "github.com/boolfixer/deployment-freezer/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+// recordingTB collects failures instead of failing the test; Fatalf panics to stop the caller.
+type recordingTB struct {
+	errors []string
+	fatal  string
+}
+
+func (r *recordingTB) Helper() {}
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+func (r *recordingTB) Fatalf(format string, args ...any) {
+	r.fatal = fmt.Sprintf(format, args...)
+	panic(r.fatal)
+}
+
+func TestHarness(t *testing.T) {
+	deployKey := types.NamespacedName{Namespace: "ns", Name: "web"}
+	dfzKey := types.NamespacedName{Namespace: "ns", Name: "freeze"}
+	newObjects := func() (*appsv1.Deployment, *freezerv1alpha1.DeploymentFreezer) {
+		deploy := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: deployKey.Namespace, Name: deployKey.Name},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(3))},
+		}
+		dfz := &freezerv1alpha1.DeploymentFreezer{
+			ObjectMeta: metav1.ObjectMeta{Namespace: dfzKey.Namespace, Name: dfzKey.Name},
+			Spec: freezerv1alpha1.DeploymentFreezerSpec{
+				TargetRef:       freezerv1alpha1.DeploymentTargetRef{Name: deployKey.Name},
+				DurationSeconds: 600,
+			},
+		}
+		return deploy, dfz
+	}
+
+	t.Run("RunUntil_FreezeAndRestore", func(t *testing.T) {
+		t.Parallel()
+		start := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+		deploy, dfz := newObjects()
+		h := dfztesting.New(t, dfztesting.Options{Now: start}, deploy, dfz)
+
+		frozen := h.RunUntil(dfzKey, freezerv1alpha1.PhaseFrozen)
+		assert.Equal(t, int32(0), *h.Deployment(deployKey).Spec.Replicas)
+		// The window starts once the Deployment has drained, on the harness clock.
+		frozenAt := frozen.Status.PhaseTransitionTimes[freezerv1alpha1.PhaseFrozen].Time
+		assert.WithinDuration(t, start, frozenAt, time.Minute)
+		assert.Equal(t, frozenAt.Add(10*time.Minute), frozen.Status.FreezeUntil.Time)
+		h.AssertCondition(dfzKey, freezerv1alpha1.ConditionTypeFreezeProgress,
+			freezerv1alpha1.ConditionStatusTrue, freezerv1alpha1.ConditionReasonScaledToZero)
+
+		h.RunUntil(dfzKey, freezerv1alpha1.PhaseCompleted)
+		assert.Equal(t, int32(3), *h.Deployment(deployKey).Spec.Replicas)
+		assert.False(t, h.Clock.Now().Before(start.Add(10*time.Minute)))
+		assert.NotEmpty(t, h.Events())
+	})
+
+	t.Run("Step_AdvancesClockByRequeue", func(t *testing.T) {
+		t.Parallel()
+		start := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+		deploy, dfz := newObjects()
+		h := dfztesting.New(t, dfztesting.Options{Now: start}, deploy, dfz)
+
+		assert.Equal(t, freezerv1alpha1.PhaseFreezing, h.Step(dfzKey).Status.Phase)
+		assert.True(t, h.Clock.Now().After(start))
+		// The settled Deployment has drained, so the next step finds it Frozen.
+		assert.Zero(t, h.Deployment(deployKey).Status.Replicas)
+		assert.Equal(t, freezerv1alpha1.PhaseFrozen, h.Step(dfzKey).Status.Phase)
+	})
+
+	t.Run("Asserts_ReportMismatches", func(t *testing.T) {
+		t.Parallel()
+		deploy, dfz := newObjects()
+		rec := &recordingTB{}
+		h := dfztesting.New(rec, dfztesting.Options{}, deploy, dfz)
+		h.Reconcile(dfzKey)
+
+		assert.False(t, h.AssertPhase(dfzKey, freezerv1alpha1.PhaseCompleted))
+		assert.False(t, h.AssertCondition(dfzKey, freezerv1alpha1.ConditionTypeUnfreezeProgress,
+			freezerv1alpha1.ConditionStatusTrue, freezerv1alpha1.ConditionReasonScaledUp))
+		require.Len(t, rec.errors, 2)
+		assert.Contains(t, rec.errors[0], "phase is Freezing, want Completed")
+		assert.Contains(t, rec.errors[1], "no condition UnfreezeProgress")
+	})
+
+	t.Run("RunUntil_FailsOnOtherTerminalPhase", func(t *testing.T) {
+		t.Parallel()
+		deploy, dfz := newObjects()
+		deploy.Annotations = map[string]string{freeze.AnnotationFrozenBy: "orchestrator:release-42"}
+		dfz.Spec.AcquireTimeoutSeconds = 60
+		rec := &recordingTB{}
+		h := dfztesting.New(rec, dfztesting.Options{}, deploy, dfz)
+
+		assert.Panics(t, func() { h.RunUntil(dfzKey, freezerv1alpha1.PhaseFrozen) })
+		assert.Contains(t, rec.fatal, "ended in Denied waiting for Frozen")
+	})
+}